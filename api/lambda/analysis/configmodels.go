@@ -20,48 +20,52 @@ package analysis
 
 // Config defines the file format when parsing a bulk upload.
 //
-// YAML tags required because the YAML unmarshaller needs them
+// YAML tags required because the YAML unmarshaller needs them (empty fields are omitted by BulkExport)
 // JSON tags not present because the JSON unmarshaller is easy
 type Config struct {
 	AnalysisType              string              `yaml:"AnalysisType"`
-	AutoRemediationID         string              `yaml:"AutoRemediationID"`
-	AutoRemediationParameters map[string]string   `yaml:"AutoRemediationParameters"`
-	DataModelID               string              `yaml:"DataModelID"`
-	DedupPeriodMinutes        int                 `yaml:"DedupPeriodMinutes"`
-	Description               string              `yaml:"Description"`
-	DisplayName               string              `yaml:"DisplayName"`
+	AutoRemediationID         string              `yaml:"AutoRemediationID,omitempty"`
+	AutoRemediationParameters map[string]string   `yaml:"AutoRemediationParameters,omitempty"`
+	DataModelID               string              `yaml:"DataModelID,omitempty"`
+	DedupPeriodMinutes        int                 `yaml:"DedupPeriodMinutes,omitempty"`
+	Description               string              `yaml:"Description,omitempty"`
+	DisplayName               string              `yaml:"DisplayName,omitempty"`
 	Enabled                   bool                `yaml:"Enabled"`
-	Filename                  string              `yaml:"Filename"`
-	GlobalID                  string              `yaml:"GlobalID"`
-	LogTypes                  []string            `yaml:"LogTypes"`
-	Mappings                  []Mapping           `yaml:"Mappings"`
-	OutputIds                 []string            `yaml:"OutputIds"`
-	PolicyID                  string              `yaml:"PolicyID"`
-	Reference                 string              `yaml:"Reference"`
-	Reports                   map[string][]string `yaml:"Reports"`
-	ResourceTypes             []string            `yaml:"ResourceTypes"`
-	RuleID                    string              `yaml:"RuleID"`
-	Runbook                   string              `yaml:"Runbook"`
-	Severity                  string              `yaml:"Severity"`
-	Suppressions              []string            `yaml:"Suppressions"`
-	Tags                      []string            `yaml:"Tags"`
-	Tests                     []Test              `yaml:"Tests"`
-	Threshold                 int                 `yaml:"Threshold"`
+	Extends                   string              `yaml:"Extends,omitempty"`
+	Filename                  string              `yaml:"Filename,omitempty"`
+	GlobalID                  string              `yaml:"GlobalID,omitempty"`
+	LogTypes                  []string            `yaml:"LogTypes,omitempty"`
+	Mappings                  []Mapping           `yaml:"Mappings,omitempty"`
+	OutputIds                 []string            `yaml:"OutputIds,omitempty"`
+	PolicyID                  string              `yaml:"PolicyID,omitempty"`
+	Reference                 string              `yaml:"Reference,omitempty"`
+	Reports                   map[string][]string `yaml:"Reports,omitempty"`
+	ResourceTypes             []string            `yaml:"ResourceTypes,omitempty"`
+	RuleID                    string              `yaml:"RuleID,omitempty"`
+	Runbook                   string              `yaml:"Runbook,omitempty"`
+	Severity                  string              `yaml:"Severity,omitempty"`
+	Suppressions              []string            `yaml:"Suppressions,omitempty"`
+	Tags                      []string            `yaml:"Tags,omitempty"`
+	Tests                     []Test              `yaml:"Tests,omitempty"`
+	Threshold                 int                 `yaml:"Threshold,omitempty"`
 }
 
 // Mapping converts source log field name to standard field name.
 type Mapping struct {
-	Path   string `yaml:"Path"`
-	Method string `yaml:"Method"`
+	Path   string `yaml:"Path,omitempty"`
+	Method string `yaml:"Method,omitempty"`
 	Name   string `yaml:"Name"`
+
+	// Restricts the mapping to a single log type of a data model
+	LogType string `yaml:"LogType,omitempty"`
 }
 
 // Test is a unit test definition when parsing policies in a bulk upload.
 type Test struct {
 	ExpectedResult bool        `yaml:"ExpectedResult"`
-	Log            interface{} `yaml:"Log"`
-	LogType        string      `yaml:"LogType"`
+	Log            interface{} `yaml:"Log,omitempty"`
+	LogType        string      `yaml:"LogType,omitempty"`
 	Name           string      `yaml:"Name"`
-	Resource       interface{} `yaml:"Resource"`
-	ResourceType   string      `yaml:"ResourceType"`
+	Resource       interface{} `yaml:"Resource,omitempty"`
+	ResourceType   string      `yaml:"ResourceType,omitempty"`
 }
@@ -22,6 +22,10 @@ package analysis
 type PolicyEngineInput struct {
 	Policies  []Policy   `json:"policies"`
 	Resources []Resource `json:"resources"`
+
+	// The resources referenced by the analyzed resources, keyed by ID. Each is sent once no matter
+	// how many resources reference it, to keep the payload under the Lambda limit.
+	RelatedResources map[string]RelatedResource `json:"relatedResources,omitempty"`
 }
 
 // Policy is a subset of the policy fields needed for analysis, returns True if compliant.
@@ -36,6 +40,18 @@ type Resource struct {
 	Attributes interface{} `json:"attributes"`
 	ID         string      `json:"id"`
 	Type       string      `json:"type"`
+
+	// IDs of the resources referenced by this resource, keyed by relation (e.g. "securityGroup")
+	Related map[string][]string `json:"related,omitempty"`
+}
+
+// RelatedResource is a resource referenced by a resource being analyzed.
+//
+// Attributes are nil if the referenced resource has not been scanned.
+type RelatedResource struct {
+	Attributes interface{} `json:"attributes"`
+	ID         string      `json:"id"`
+	Type       string      `json:"type"`
 }
 
 // PolicyEngineOutput is the response format returned by the panther-policy-engine Lambda function.
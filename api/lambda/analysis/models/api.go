@@ -29,9 +29,15 @@ const (
 
 type LambdaInput struct {
 	// Shared
+	BulkExport     *BulkExportInput     `json:"bulkExport,omitempty"`
 	BulkUpload     *BulkUploadInput     `json:"bulkUpload,omitempty"`
 	ListDetections *ListDetectionsInput `json:"listDetections,omitempty"`
 
+	// Versions
+	DiffDetectionVersions *DiffDetectionVersionsInput `json:"diffDetectionVersions,omitempty"`
+	ListDetectionVersions *ListDetectionVersionsInput `json:"listDetectionVersions,omitempty"`
+	RevertDetection       *RevertDetectionInput       `json:"revertDetection,omitempty"`
+
 	// Globals
 	CreateGlobal  *CreateGlobalInput  `json:"createGlobal,omitempty"`
 	DeleteGlobals *DeleteGlobalsInput `json:"deleteGlobals,omitempty"`
@@ -49,6 +55,11 @@ type LambdaInput struct {
 	TestPolicy   *TestPolicyInput   `json:"testPolicy,omitempty"`
 	UpdatePolicy *UpdatePolicyInput `json:"updatePolicy,omitempty"`
 
+	// Suppressions (cloud security)
+	ExpireSuppressions *ExpireSuppressionsInput `json:"expireSuppressions,omitempty"`
+	ListSuppressions   *ListSuppressionsInput   `json:"listSuppressions,omitempty"`
+	RevokeSuppressions *RevokeSuppressionsInput `json:"revokeSuppressions,omitempty"`
+
 	// Rules (log analysis)
 	CreateRule  *CreateRuleInput  `json:"createRule,omitempty"`
 	DeleteRules *DeleteRulesInput `json:"deleteRules,omitempty"`
@@ -72,9 +83,39 @@ type UnitTest struct {
 	Resource       string `json:"resource" validate:"required"`
 }
 
+type BulkExportInput struct {
+	// Only export the given analysis types (defaults to all types)
+	AnalysisTypes []DetectionType `json:"analysisTypes" validate:"omitempty,dive,oneof=POLICY RULE GLOBAL DATAMODEL"`
+
+	// Only export the items with these IDs
+	IDs []string `json:"ids" validate:"max=1000,dive,required,max=1000"`
+
+	// Only export enabled or disabled items
+	Enabled *bool `json:"enabled"`
+
+	// Only export items with at least one of these tags (case-insensitive)
+	Tags []string `json:"tags" validate:"omitempty,dive,required,max=1000"`
+}
+
+type BulkExportOutput struct {
+	Data string `json:"data"` // base64-encoded zipfile
+
+	TotalPolicies   int `json:"totalPolicies"`
+	TotalRules      int `json:"totalRules"`
+	TotalGlobals    int `json:"totalGlobals"`
+	TotalDataModels int `json:"totalDataModels"`
+}
+
 type BulkUploadInput struct {
 	Data   string `json:"data" validate:"required"` // base64-encoded zipfile
 	UserID string `json:"userId" validate:"required"`
+
+	// Validate and preview the changes without writing anything
+	DryRun bool `json:"dryRun"`
+
+	// The zipfile contains the complete set of items: existing items which are not in
+	// the zipfile are reported as removed (dry run only, nothing is ever deleted)
+	Authoritative bool `json:"authoritative"`
 }
 
 type BulkUploadOutput struct {
@@ -93,4 +134,29 @@ type BulkUploadOutput struct {
 	TotalDataModels    int `json:"totalDataModels"`
 	NewDataModels      int `json:"newDataModels"`
 	ModifiedDataModels int `json:"modifiedDataModels"`
+
+	// The changes for each item (dry run only)
+	Changes []BulkUploadChange `json:"changes,omitempty"`
+}
+
+type BulkUploadChange struct {
+	ID           string        `json:"id"`
+	AnalysisType DetectionType `json:"analysisType"`
+	Change       string        `json:"change"` // NEW, MODIFIED, UNCHANGED or REMOVED
+
+	// Differences with the existing item (modified items only)
+	Body     string      `json:"body,omitempty"`
+	Metadata []FieldDiff `json:"metadata,omitempty"`
+	Tests    []TestDiff  `json:"tests,omitempty"`
+
+	// Unit test results of the uploaded policy or rule
+	TestResults *BulkUploadTestResults `json:"testResults,omitempty"`
+}
+
+type BulkUploadTestResults struct {
+	Passed      int      `json:"passed"`
+	Failed      int      `json:"failed"`
+	FailedTests []string `json:"failedTests,omitempty"`
+	// An error which prevented the tests from running
+	Error string `json:"error,omitempty"`
 }
@@ -40,6 +40,11 @@ type ListDataModelsInput struct {
 	// Only include data models which apply to one of these log types
 	LogTypes []string `json:"logTypes" validate:"dive,required,max=500"`
 
+	// Return one entry per log type with the inherited and log type specific mappings
+	// merged into a flat list and the bodies of base data models prepended to the body.
+	// This is the form in which the rules engine consumes data models (paging still counts data models).
+	Resolved bool `json:"resolved"`
+
 	// ----- Sorting -----
 	SortBy  string `json:"sortBy" validate:"omitempty,oneof=enabled id lastModified logTypes"`
 	SortDir string `json:"sortDir" validate:"omitempty,oneof=ascending descending"`
@@ -59,9 +64,10 @@ type UpdateDataModelInput struct {
 	Description string             `json:"description" validate:"max=10000"`
 	DisplayName string             `json:"displayName" validate:"max=1000,excludesall='<>&\""`
 	Enabled     bool               `json:"enabled"`
+	Extends     string             `json:"extends" validate:"max=1000,excludesall='<>&\""` // ID of the base data model
 	ID          string             `json:"id" validate:"required,max=1000,excludesall='<>&\""`
-	LogTypes    []string           `json:"logTypes" validate:"len=1,dive,required,max=500"` // for now, only one logtype allowed
-	Mappings    []DataModelMapping `json:"mappings" validate:"min=1,max=500,dive"`
+	LogTypes    []string           `json:"logTypes" validate:"min=1,max=500,dive,required,max=500"`
+	Mappings    []DataModelMapping `json:"mappings" validate:"max=500,dive"` // optional if extending a base
 	UserID      string             `json:"userId" validate:"required"`
 }
 
@@ -72,6 +78,7 @@ type DataModel struct {
 	Description    string             `json:"description"`
 	DisplayName    string             `json:"displayName"`
 	Enabled        bool               `json:"enabled"`
+	Extends        string             `json:"extends"`
 	ID             string             `json:"id"`
 	LastModified   time.Time          `json:"lastModified"`
 	LastModifiedBy string             `json:"lastModifiedBy"`
@@ -84,4 +91,7 @@ type DataModelMapping struct {
 	Name   string `json:"name" validate:"required,max=1000"`
 	Path   string `json:"path" validate:"required_without=Method,max=1000"`
 	Method string `json:"method" validate:"required_without=Path,max=1000"`
+
+	// If set, the mapping only applies to this log type and overrides any mapping with the same name
+	LogType string `json:"logType,omitempty" validate:"max=500"`
 }
@@ -102,6 +102,15 @@ type SuppressInput struct {
 	// List of resource ID regexes that are excepted from the policy.
 	// The policy will still be evaluated, but failures will not trigger alerts nor remediations
 	ResourcePatterns []string `json:"resourcePatterns" validate:"min=1,dive,required,max=10000"`
+
+	// ----- Audit details, recorded with each suppression -----
+	Justification string `json:"justification" validate:"max=10000"`
+	RequestedBy   string `json:"requestedBy" validate:"max=1000"` // defaults to the user ID
+	ApprovedBy    string `json:"approvedBy" validate:"max=1000"`
+	TicketURL     string `json:"ticketUrl" validate:"omitempty,url,max=2000"`
+	// The suppression is lifted automatically after this time, the policy is then re-evaluated
+	ExpiresAt *time.Time `json:"expiresAt"`
+	UserID    string     `json:"userId" validate:"max=1000"`
 }
 
 type TestPolicyInput struct {
@@ -0,0 +1,83 @@
+package models
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+)
+
+type SuppressionStatus string
+
+const (
+	SuppressionActive  SuppressionStatus = "ACTIVE"
+	SuppressionExpired SuppressionStatus = "EXPIRED"
+	SuppressionRevoked SuppressionStatus = "REVOKED"
+)
+
+type ListSuppressionsInput struct {
+	// Only include suppressions of these policies
+	PolicyIDs []string `json:"policyIds" validate:"max=1000,dive,required,max=1000"`
+
+	// Include expired and revoked suppressions
+	IncludeInactive bool `json:"includeInactive"`
+}
+
+type ListSuppressionsOutput struct {
+	// Sorted by policy ID, then by creation time (newest first)
+	Suppressions []Suppression `json:"suppressions"`
+}
+
+type RevokeSuppressionsInput struct {
+	PolicyID         string   `json:"policyId" validate:"required,max=1000"`
+	ResourcePatterns []string `json:"resourcePatterns" validate:"min=1,dive,required,max=10000"`
+	UserID           string   `json:"userId" validate:"required"`
+}
+
+type RevokeSuppressionsOutput struct {
+	// The suppressions which were revoked, patterns which were not suppressed are ignored
+	Revoked []Suppression `json:"revoked"`
+}
+
+// Lift all suppressions past their expiration time.
+//
+// This is invoked on a schedule, it can also be invoked manually to expire suppressions sooner.
+type ExpireSuppressionsInput struct{}
+
+type ExpireSuppressionsOutput struct {
+	Expired []Suppression `json:"expired"`
+}
+
+// Suppression is the audit record of a resource pattern excepted from a policy.
+//
+// Suppressions which were added by editing the policy directly have no audit details.
+type Suppression struct {
+	PolicyID        string            `json:"policyId"`
+	ResourcePattern string            `json:"resourcePattern"`
+	Status          SuppressionStatus `json:"status"`
+
+	Justification string     `json:"justification,omitempty"`
+	RequestedBy   string     `json:"requestedBy,omitempty"`
+	ApprovedBy    string     `json:"approvedBy,omitempty"`
+	TicketURL     string     `json:"ticketUrl,omitempty"`
+	CreatedAt     *time.Time `json:"createdAt,omitempty"`
+	CreatedBy     string     `json:"createdBy,omitempty"`
+	ExpiresAt     *time.Time `json:"expiresAt,omitempty"`
+	RevokedAt     *time.Time `json:"revokedAt,omitempty"`
+	RevokedBy     string     `json:"revokedBy,omitempty"`
+}
@@ -0,0 +1,89 @@
+package models
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+)
+
+type ListDetectionVersionsInput struct {
+	ID string `json:"id" validate:"required,max=1000"`
+
+	// ----- Paging -----
+	PageSize int `json:"pageSize" validate:"min=0,max=100"`
+	// The nextVersionId from a previous response to continue listing older versions
+	VersionIDMarker string `json:"versionIdMarker" validate:"omitempty,len=32"`
+}
+
+type ListDetectionVersionsOutput struct {
+	// Versions are sorted from newest to oldest
+	Versions []DetectionVersion `json:"versions"`
+	// Set if there are more versions to list
+	NextVersionID string `json:"nextVersionId,omitempty"`
+}
+
+type DetectionVersion struct {
+	AnalysisType   DetectionType `json:"analysisType"`
+	ID             string        `json:"id"`
+	IsLatest       bool          `json:"isLatest"`
+	LastModified   time.Time     `json:"lastModified"`
+	LastModifiedBy string        `json:"lastModifiedBy"`
+	VersionID      string        `json:"versionId"`
+}
+
+type DiffDetectionVersionsInput struct {
+	ID string `json:"id" validate:"required,max=1000"`
+	// The older version to compare
+	FromVersionID string `json:"fromVersionId" validate:"required,len=32"`
+	// The newer version to compare (default: latest version)
+	ToVersionID string `json:"toVersionId" validate:"omitempty,len=32"`
+}
+
+type DiffDetectionVersionsOutput struct {
+	From DetectionVersion `json:"from"`
+	To   DetectionVersion `json:"to"`
+
+	// Unified diff of the python body (empty if unchanged)
+	Body string `json:"body"`
+	// Metadata fields which changed between the versions
+	Metadata []FieldDiff `json:"metadata"`
+	// Unit tests which were added, removed or modified between the versions
+	Tests []TestDiff `json:"tests"`
+}
+
+type FieldDiff struct {
+	Field string `json:"field"`
+	// JSON encoded field values
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type TestDiff struct {
+	Name   string `json:"name"`
+	Change string `json:"change"` // ADDED, REMOVED or MODIFIED
+	// Unified diff of the test expected result and formatted resource
+	Diff string `json:"diff"`
+}
+
+type RevertDetectionInput struct {
+	ID string `json:"id" validate:"required,max=1000"`
+	// The version to restore, saved as a new version
+	VersionID string `json:"versionId" validate:"required,len=32"`
+	UserID    string `json:"userId" validate:"required"`
+}
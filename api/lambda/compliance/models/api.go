@@ -46,11 +46,13 @@ const (
 
 // LambdaInput is the request structure for the compliance-api Lambda function.
 type LambdaInput struct {
-	DescribeOrg      *DescribeOrgInput      `json:"describeOrg"`
-	DescribePolicy   *DescribePolicyInput   `json:"describePolicy"`
-	DescribeResource *DescribeResourceInput `json:"describeResource"`
-	GetOrgOverview   *GetOrgOverviewInput   `json:"getOrgOverview"`
-	GetStatus        *GetStatusInput        `json:"getStatus"`
+	DescribeOrg              *DescribeOrgInput              `json:"describeOrg"`
+	DescribePolicy           *DescribePolicyInput           `json:"describePolicy"`
+	DescribeResource         *DescribeResourceInput         `json:"describeResource"`
+	GenerateComplianceReport *GenerateComplianceReportInput `json:"generateComplianceReport"`
+	GetFrameworkScores       *GetFrameworkScoresInput       `json:"getFrameworkScores"`
+	GetOrgOverview           *GetOrgOverviewInput           `json:"getOrgOverview"`
+	GetStatus                *GetStatusInput                `json:"getStatus"`
 
 	DeleteStatus   *DeleteStatusInput   `json:"deleteStatus"`
 	SetStatus      *SetStatusInput      `json:"setStatus"`
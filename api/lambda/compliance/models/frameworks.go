@@ -0,0 +1,153 @@
+package models
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import "time"
+
+// Policies are mapped to compliance framework controls through their "reports" field.
+//
+// For example, a policy with the reports {"CIS": ["2.1", "2.2"], "PCI": ["3.4"]}
+// provides evidence for CIS controls 2.1 and 2.2 and PCI-DSS requirement 3.4.
+const (
+	FrameworkCIS  = "CIS"
+	FrameworkNIST = "NIST"
+	FrameworkPCI  = "PCI"
+	FrameworkSOC2 = "SOC2"
+)
+
+// DefaultFrameworks are scored when no frameworks are requested.
+var DefaultFrameworks = []string{FrameworkCIS, FrameworkNIST, FrameworkPCI, FrameworkSOC2}
+
+// Score each compliance framework by cloud account and resource type.
+//
+// Only enabled policies are considered, and suppressed resources are not counted.
+// A control passes in a scope if every policy mapped to it passes on every resource in that scope;
+// controls with no evaluated resources in a scope are not applicable there and are not counted.
+//
+// Example: {
+//     "getFrameworkScores": {"frameworks": ["CIS"]}
+// }
+//
+// Response: {
+//     "frameworks": [
+//         {
+//             "framework": "CIS",
+//             "controls": {"error": 0, "fail": 3, "pass": 17},
+//             "score": 85,
+//             "byIntegration": [
+//                 {"id": "ff76ea2a-5afc-4005-9e77-61a32c4c365f", "controls": {"error": 0, "fail": 3, "pass": 15}, "score": 83.33}
+//             ],
+//             "byResourceType": [
+//                 {"id": "AWS.S3.Bucket", "controls": {"error": 0, "fail": 1, "pass": 4}, "score": 80}
+//             ]
+//         }
+//     ]
+// }
+type GetFrameworkScoresInput struct {
+	// Report keys of the frameworks to score (DefaultFrameworks if not specified)
+	Frameworks []string `json:"frameworks" validate:"max=50,dive,required,max=100"`
+}
+
+type GetFrameworkScoresOutput struct {
+	Frameworks []FrameworkScore `json:"frameworks"`
+}
+
+type FrameworkScore struct {
+	Framework string      `json:"framework"`
+	Controls  StatusCount `json:"controls"`
+	// Percentage of applicable controls which pass (0 if no controls are applicable)
+	Score          float64      `json:"score"`
+	ByIntegration  []ScopeScore `json:"byIntegration"`
+	ByResourceType []ScopeScore `json:"byResourceType"`
+}
+
+// Framework score within a single integration (cloud account) or resource type
+type ScopeScore struct {
+	ID       string      `json:"id"`
+	Controls StatusCount `json:"controls"`
+	Score    float64     `json:"score"`
+}
+
+// Generate an evidence bundle for a single compliance framework.
+// TODO - handle responses > 6MB
+//
+// The report is a snapshot of the compliance state at the time it is generated: every control in
+// the framework, the enabled policies mapped to it, the resources each policy passes and fails
+// and the audit trail of every active suppression. The same information is flattened into CSV.
+//
+// Reports are current-state only. The compliance table keeps only the latest status of each
+// policy and resource, and the resource history only has past configurations, not the statuses
+// they were evaluated to, so a report can't be generated for an earlier point in time.
+// Reports needed as evidence of an earlier state should be kept when they are generated.
+//
+// Example: {
+//     "generateComplianceReport": {"framework": "CIS"}
+// }
+type GenerateComplianceReportInput struct {
+	Framework string `json:"framework" validate:"required,max=100"`
+}
+
+type ComplianceReport struct {
+	Framework   string          `json:"framework"`
+	GeneratedAt time.Time       `json:"generatedAt"`
+	Controls    []ControlReport `json:"controls"`
+	Summary     FrameworkScore  `json:"summary"`
+
+	// One row per control, policy and resource
+	CSV string `json:"csv"`
+}
+
+type ControlReport struct {
+	ControlID string           `json:"controlId"`
+	Status    ComplianceStatus `json:"status"`
+	// False if none of the policies evaluated any resources
+	Applicable bool           `json:"applicable"`
+	Policies   []PolicyReport `json:"policies"`
+}
+
+type PolicyReport struct {
+	ID           string              `json:"id"`
+	DisplayName  string              `json:"displayName"`
+	Severity     Severity            `json:"severity"`
+	Status       ComplianceStatus    `json:"status"`
+	Totals       ActiveSuppressCount `json:"totals"`
+	Resources    []ReportResource    `json:"resources"`
+	Suppressions []ReportSuppression `json:"suppressions"`
+}
+
+type ReportResource struct {
+	ErrorMessage  string           `json:"errorMessage,omitempty"`
+	ID            string           `json:"id"`
+	IntegrationID string           `json:"integrationId"`
+	LastUpdated   time.Time        `json:"lastUpdated"`
+	Status        ComplianceStatus `json:"status"`
+	Suppressed    bool             `json:"suppressed"`
+	Type          string           `json:"type"`
+}
+
+// Audit details of an active suppression, as recorded by the analysis-api
+type ReportSuppression struct {
+	ResourcePattern string     `json:"resourcePattern"`
+	Justification   string     `json:"justification,omitempty"`
+	RequestedBy     string     `json:"requestedBy,omitempty"`
+	ApprovedBy      string     `json:"approvedBy,omitempty"`
+	TicketURL       string     `json:"ticketUrl,omitempty"`
+	CreatedAt       *time.Time `json:"createdAt,omitempty"`
+	ExpiresAt       *time.Time `json:"expiresAt,omitempty"`
+}
@@ -35,6 +35,16 @@ type LogTypesAPI interface {
 	DelCustomLog(input DelCustomLogInput) (DelCustomLogResponse, error)
 
 	ListCustomLogs() (ListCustomLogsResponse, error)
+
+	PutCustomIndicator(input PutCustomIndicatorInput) (PutCustomIndicatorResponse, error)
+
+	ListCustomIndicators() (ListCustomIndicatorsResponse, error)
+
+	DelCustomIndicator(input DelCustomIndicatorInput) (DelCustomIndicatorResponse, error)
+
+	InferCustomLog(input InferCustomLogInput) (InferCustomLogResponse, error)
+
+	TestCustomLog(input TestCustomLogInput) (TestCustomLogResponse, error)
 }
 
 // Models for LogTypesAPI
@@ -47,6 +57,22 @@ type LogTypesAPIPayload struct {
 	PutCustomLog          *PutCustomLogInput
 	DelCustomLog          *DelCustomLogInput
 	ListCustomLogs        *struct{}
+	PutCustomIndicator    *PutCustomIndicatorInput
+	ListCustomIndicators  *struct{}
+	DelCustomIndicator    *DelCustomIndicatorInput
+	InferCustomLog        *InferCustomLogInput
+	TestCustomLog         *TestCustomLogInput
+}
+
+type DelCustomIndicatorInput struct {
+	Name string `json:"name" validate:"required" description:"The indicator name"`
+}
+
+type DelCustomIndicatorResponse struct {
+	Error struct {
+		Code    string `json:"code" validate:"required"`
+		Message string `json:"message" validate:"required"`
+	} `json:"error,omitempty" description:"An error that occurred during the operation"`
 }
 
 type DelCustomLogInput struct {
@@ -81,10 +107,45 @@ type GetCustomLogResponse struct {
 	} `json:"error,omitempty" description:"An error that occurred while fetching the record"`
 }
 
+type InferCustomLogInput struct {
+	Lines []string `json:"lines,omitempty" validate:"required_without=S3URL,max=10000" description:"Sample log lines"`
+	S3URL string   `json:"s3Url,omitempty" validate:"omitempty,startswith=s3://" description:"The URL of an S3 object with sample log lines (s3://bucket/key) in one of the sample buckets"`
+}
+
+type InferCustomLogResponse struct {
+	LogSpec    string `json:"logSpec,omitempty" description:"The inferred log spec in YAML format"`
+	NumSamples int    `json:"numSamples,omitempty" description:"The number of sample lines used"`
+	Fields     []struct {
+		Path     string   `json:"path" description:"The path of the field"`
+		Count    int      `json:"count" description:"The number of samples with a non-null value for the field"`
+		Presence float64  `json:"presence" description:"The ratio of samples with a non-null value for the field"`
+		Examples []string `json:"examples,omitempty" description:"Distinct example values of the field"`
+	} `json:"fields,omitempty" description:"Statistics about the fields found in the sample lines"`
+	Error struct {
+		Code    string `json:"code" validate:"required"`
+		Message string `json:"message" validate:"required"`
+	} `json:"error,omitempty" description:"An error that occurred during the operation"`
+}
+
 type ListAvailableLogTypesResponse struct {
 	LogTypes []string `json:"logTypes"`
 }
 
+type ListCustomIndicatorsResponse struct {
+	CustomIndicators []struct {
+		UpdatedAt   time.Time `json:"updatedAt" description:"Last update timestamp of the record"`
+		Name        string    `json:"name" validate:"required" description:"The indicator name to use in the indicators of string fields"`
+		Field       string    `json:"field,omitempty" description:"The field where indicator values are collected (defaults to p_any_<name>s)"`
+		Description string    `json:"description,omitempty" description:"Indicator description"`
+		Regex       string    `json:"regex,omitempty" description:"A regular expression to extract values (the first non-empty capture group is used if any)"`
+		Builtin     string    `json:"builtin,omitempty" description:"A builtin extractor to use for values (value, lowercase, uppercase, mac_address, uuid)"`
+	} `json:"customIndicators" description:"Custom indicator records stored"`
+	Error struct {
+		Code    string `json:"code" validate:"required"`
+		Message string `json:"message" validate:"required"`
+	} `json:"error,omitempty" description:"An error that occurred during the operation"`
+}
+
 type ListCustomLogsResponse struct {
 	CustomLogs []struct {
 		LogType      string    `json:"logType" validate:"required,startswith=Custom." description:"The log type id"`
@@ -108,6 +169,29 @@ type ListDeletedCustomLogsResponse struct {
 	} `json:"error,omitempty" description:"An error that occurred while fetching the list"`
 }
 
+type PutCustomIndicatorInput struct {
+	Name        string `json:"name" validate:"required" description:"The indicator name to use in the indicators of string fields"`
+	Field       string `json:"field,omitempty" description:"The field where indicator values are collected (defaults to p_any_<name>s)"`
+	Description string `json:"description,omitempty" description:"Indicator description"`
+	Regex       string `json:"regex,omitempty" description:"A regular expression to extract values (the first non-empty capture group is used if any)"`
+	Builtin     string `json:"builtin,omitempty" description:"A builtin extractor to use for values (value, lowercase, uppercase, mac_address, uuid)"`
+}
+
+type PutCustomIndicatorResponse struct {
+	Result struct {
+		UpdatedAt   time.Time `json:"updatedAt" description:"Last update timestamp of the record"`
+		Name        string    `json:"name" validate:"required" description:"The indicator name to use in the indicators of string fields"`
+		Field       string    `json:"field,omitempty" description:"The field where indicator values are collected (defaults to p_any_<name>s)"`
+		Description string    `json:"description,omitempty" description:"Indicator description"`
+		Regex       string    `json:"regex,omitempty" description:"A regular expression to extract values (the first non-empty capture group is used if any)"`
+		Builtin     string    `json:"builtin,omitempty" description:"A builtin extractor to use for values (value, lowercase, uppercase, mac_address, uuid)"`
+	} `json:"record,omitempty" description:"The modified record (field is omitted if an error occurred)"`
+	Error struct {
+		Code    string `json:"code" validate:"required"`
+		Message string `json:"message" validate:"required"`
+	} `json:"error,omitempty" description:"An error that occurred during the operation"`
+}
+
 type PutCustomLogInput struct {
 	LogType      string `json:"logType" validate:"required,startswith=Custom." description:"The log type id"`
 	Revision     int64  `json:"revision,omitempty" validate:"omitempty,min=1" description:"Custom log record revision to update (if omitted a new record will be created)"`
@@ -130,3 +214,30 @@ type PutCustomLogResponse struct {
 		Message string `json:"message" validate:"required"`
 	} `json:"error,omitempty" description:"An error that occurred during the operation"`
 }
+
+type TestCustomLogInput struct {
+	LogType      string   `json:"logType,omitempty" validate:"omitempty,startswith=Custom." description:"The log type id to use for the parsed events (defaults to Custom.Test)"`
+	Description  string   `json:"description" description:"Log type description"`
+	ReferenceURL string   `json:"referenceURL" description:"A URL with reference docs for the log type"`
+	LogSpec      string   `json:"logSpec" validate:"required" description:"The log spec in YAML or JSON format"`
+	Lines        []string `json:"lines,omitempty" validate:"required_without=S3URL,max=10000" description:"Sample log lines"`
+	S3URL        string   `json:"s3Url,omitempty" validate:"omitempty,startswith=s3://" description:"The URL of an S3 object with sample log lines (s3://bucket/key) in one of the sample buckets"`
+}
+
+type TestCustomLogResponse struct {
+	Results []struct {
+		Line   int `json:"line" description:"The number of the sample line (starting at 1)"`
+		Events []struct {
+			Event      string              `json:"event" description:"The parsed event in JSON format including panther fields"`
+			Fields     []string            `json:"fields,omitempty" description:"The paths of the event fields that were populated"`
+			Indicators map[string][]string `json:"indicators,omitempty" description:"The indicator values extracted from the event by panther field"`
+		} `json:"events,omitempty" description:"The events parsed from the line"`
+		Error string `json:"error,omitempty" description:"The error that occurred while parsing the line"`
+	} `json:"results,omitempty" description:"The results for each sample line"`
+	NumEvents int `json:"numEvents,omitempty" description:"The total number of events parsed"`
+	NumErrors int `json:"numErrors,omitempty" description:"The total number of lines that failed to parse"`
+	Error     struct {
+		Code    string `json:"code" validate:"required"`
+		Message string `json:"message" validate:"required"`
+	} `json:"error,omitempty" description:"An error that occurred during the operation"`
+}
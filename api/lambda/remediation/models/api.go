@@ -18,10 +18,17 @@ package models
  * along with this program.  If not, see <https://www.gnu.org/licenses/>.
  */
 
+import "time"
+
 type LambdaInput struct {
 	ListRemediations       *ListRemediationsInput       `json:"listRemediations"`
 	RemediateResource      *RemediateResourceInput      `json:"remediateResource"`
 	RemediateResourceAsync *RemediateResourceAsyncInput `json:"remediateResourceAsync"`
+
+	ApproveRemediation     *ReviewRemediationInput      `json:"approveRemediation"`
+	DenyRemediation        *ReviewRemediationInput      `json:"denyRemediation"`
+	ListRemediationHistory *ListRemediationHistoryInput `json:"listRemediationHistory"`
+	ExpireRemediations     *ExpireRemediationsInput     `json:"expireRemediations"`
 }
 
 // Retrieve available remediations
@@ -30,10 +37,91 @@ type ListRemediationsInput struct{}
 type ListRemediationsOutput map[string]interface{}
 
 // Remediate resource for an account (blocking call)
+//
+// Returns the Remediation record: executed, or pending approval if approval is required.
 type RemediateResourceInput struct {
 	PolicyID   string `json:"policyId" validate:"required"`
 	ResourceID string `json:"resourceId" validate:"required"`
+
+	// The alert which triggered the remediation, if any
+	AlertID string `json:"alertId"`
+
+	// The user requesting the remediation, empty for automatic remediations
+	UserID string `json:"userId"`
+
+	// Report the intended change without applying it
+	DryRun bool `json:"dryRun"`
+
+	// Create a pending request which must be approved before the remediation runs
+	RequireApproval bool `json:"requireApproval"`
+
+	// How long the request can wait for approval (default 24 hours)
+	ApprovalTimeoutMinutes int `json:"approvalTimeoutMinutes" validate:"omitempty,min=1,max=10080"`
+
+	// Identifies the request so that retries of the same request record a single remediation (default random)
+	RequestID string `json:"requestId"`
 }
 
 // Asynchronously remediate reosurce for an account
 type RemediateResourceAsyncInput = RemediateResourceInput
+
+// Approve (and run) or deny a remediation pending approval
+type ReviewRemediationInput struct {
+	ID      string `json:"id" validate:"required"`
+	UserID  string `json:"userId" validate:"required"`
+	Comment string `json:"comment"`
+}
+
+// List the remediations of a resource or an alert, most recent first
+type ListRemediationHistoryInput struct {
+	ResourceID string `json:"resourceId" validate:"required_without=AlertID"`
+	AlertID    string `json:"alertId" validate:"required_without=ResourceID"`
+}
+
+type ListRemediationHistoryOutput struct {
+	Remediations []Remediation `json:"remediations"`
+}
+
+// Mark the remediations pending approval past their approval timeout as expired
+type ExpireRemediationsInput struct{}
+
+type ExpireRemediationsOutput struct {
+	Expired []string `json:"expired"` // the ids of the expired remediations
+}
+
+type RemediationStatus string
+
+const (
+	StatusPendingApproval RemediationStatus = "PENDING_APPROVAL"
+	StatusApproved        RemediationStatus = "APPROVED" // approved and being executed
+	StatusDenied          RemediationStatus = "DENIED"
+	StatusExpired         RemediationStatus = "EXPIRED"
+	StatusSucceeded       RemediationStatus = "SUCCEEDED"
+	StatusFailed          RemediationStatus = "FAILED"
+)
+
+// Remediation records a remediation request and its execution.
+type Remediation struct {
+	ID            string            `json:"id"`
+	AlertID       string            `json:"alertId,omitempty"`
+	PolicyID      string            `json:"policyId"`
+	ResourceID    string            `json:"resourceId"`
+	RemediationID string            `json:"remediationId"`
+	Parameters    interface{}       `json:"parameters"`
+	DryRun        bool              `json:"dryRun"`
+	Status        RemediationStatus `json:"status"`
+
+	RequestedAt time.Time `json:"requestedAt"`
+	RequestedBy string    `json:"requestedBy,omitempty"` // empty for automatic remediations
+
+	// Approval workflow
+	ApprovalExpiresAt *time.Time `json:"approvalExpiresAt,omitempty"`
+	ReviewedAt        *time.Time `json:"reviewedAt,omitempty"`
+	ReviewedBy        string     `json:"reviewedBy,omitempty"`
+	ReviewComment     string     `json:"reviewComment,omitempty"`
+
+	// Execution: the response of the remediation (the intended change for dry runs) or the error
+	ExecutedAt *time.Time  `json:"executedAt,omitempty"`
+	Result     interface{} `json:"result,omitempty"`
+	Error      string      `json:"error,omitempty"`
+}
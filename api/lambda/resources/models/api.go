@@ -26,10 +26,14 @@ import (
 
 // LambdaInput is the request structure for the resources-api Lambda function.
 type LambdaInput struct {
-	AddResources    *AddResourcesInput    `json:"addResources"`
-	GetResource     *GetResourceInput     `json:"getResource"`
-	DeleteResources *DeleteResourcesInput `json:"deleteResources"`
-	ListResources   *ListResourcesInput   `json:"listResources"`
+	AddResources       *AddResourcesInput       `json:"addResources"`
+	GetResource        *GetResourceInput        `json:"getResource"`
+	GetResourceAtTime  *GetResourceAtTimeInput  `json:"getResourceAtTime"`
+	GetResourceHistory *GetResourceHistoryInput `json:"getResourceHistory"`
+	DeleteResources    *DeleteResourcesInput    `json:"deleteResources"`
+	ListResources      *ListResourcesInput      `json:"listResources"`
+
+	GetResourceRelationships *GetResourceRelationshipsInput `json:"getResourceRelationships"`
 }
 
 // Backend adds or replaces resources
@@ -41,7 +45,7 @@ type AddResourceEntry struct {
 	Attributes      interface{} `json:"attributes" validate:"required"`
 	ID              string      `json:"id" validate:"required"`
 	IntegrationID   string      `json:"integrationId" validate:"uuid4"`
-	IntegrationType string      `json:"integrationType" validate:"oneof=aws"`
+	IntegrationType string      `json:"integrationType" validate:"oneof=aws gcp k8s"`
 	Type            string      `json:"type" validate:"required"`
 }
 
@@ -62,6 +66,127 @@ type Resource struct {
 	Type             string                  `json:"type"`
 }
 
+type GetResourceHistoryInput struct {
+	ID string `json:"resourceId" validate:"required"`
+
+	// Only include versions recorded in this time range (default: all retained history)
+	Since *time.Time `json:"since"`
+	Until *time.Time `json:"until"`
+}
+
+type GetResourceHistoryOutput struct {
+	// Recorded configurations of the resource, oldest first
+	Versions []ResourceVersion `json:"versions"`
+}
+
+type GetResourceAtTimeInput struct {
+	ID        string    `json:"resourceId" validate:"required"`
+	Timestamp time.Time `json:"timestamp" validate:"required"`
+}
+
+type GetResourceAtTimeOutput = ResourceVersion
+
+// ResourceVersion is one recorded configuration of a resource.
+type ResourceVersion struct {
+	Attributes    interface{} `json:"attributes"`
+	Deleted       bool        `json:"deleted"`
+	ID            string      `json:"id"`
+	IntegrationID string      `json:"integrationId"`
+	LastModified  time.Time   `json:"lastModified"`
+	Type          string      `json:"type"`
+
+	// Field-level changes from the previous version, keyed by attribute path (nil for the first version)
+	Changes map[string]AttributeChange `json:"changes"`
+
+	// The most recent CloudTrail event which changed the resource into this version, if known
+	ChangedBy *ChangeEvent `json:"changedBy"`
+
+	// Overall and per-policy compliance status while this version was current
+	ComplianceStatus models.ComplianceStatus            `json:"complianceStatus"`
+	PolicyStatuses   map[string]models.ComplianceStatus `json:"policyStatuses"`
+}
+
+type AttributeChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+type ChangeEvent struct {
+	ChangedBy   string    `json:"changedBy"` // ARN of the CloudTrail user identity
+	EventID     string    `json:"eventId"`
+	EventName   string    `json:"eventName"`
+	EventSource string    `json:"eventSource"`
+	EventTime   time.Time `json:"eventTime"`
+}
+
+// Relationships between resources are extracted from their attributes when they are added,
+// e.g. an EC2 instance is related to its security groups, which are related to their VPC.
+//
+// Example: {
+//     "getResourceRelationships": {
+//         "resourceIds": ["arn:aws:ec2:us-west-2:123456789012:instance/i-0123456789"],
+//         "depth": 2,
+//         "direction": "outbound"
+//     }
+// }
+//
+// Response: {
+//     "relationships": [
+//         {
+//             "sourceId":   "arn:aws:ec2:us-west-2:123456789012:instance/i-0123456789",
+//             "relation":   "securityGroup",
+//             "targetId":   "arn:aws:ec2:us-west-2:123456789012:security-group/sg-0123456789",
+//             "targetType": "AWS.EC2.SecurityGroup"
+//         },
+//         {
+//             "sourceId":   "arn:aws:ec2:us-west-2:123456789012:security-group/sg-0123456789",
+//             "relation":   "vpc",
+//             "targetId":   "arn:aws:ec2:us-west-2:123456789012:vpc/vpc-0123456789",
+//             "targetType": "AWS.EC2.VPC"
+//         }
+//     ],
+//     "resources": [
+//         {"id": "arn:aws:ec2:us-west-2:123456789012:instance/i-0123456789", "type": "AWS.EC2.Instance", ...},
+//         ...
+//     ]
+// }
+type GetResourceRelationshipsInput struct {
+	IDs []string `json:"resourceIds" validate:"min=1,max=1000,dive,required"`
+
+	// Number of relationships to traverse from each resource (default 1)
+	Depth int `json:"depth" validate:"omitempty,min=1,max=5"`
+
+	// Follow relationships from the resources (outbound), to them (inbound) or both (default)
+	Direction string `json:"direction" validate:"omitempty,oneof=both inbound outbound"`
+
+	// Include the attributes of every resource in the response
+	IncludeAttributes bool `json:"includeAttributes"`
+}
+
+type GetResourceRelationshipsOutput struct {
+	Relationships []Relationship `json:"relationships"`
+
+	// Every resource reached in the traversal, including the requested ones
+	Resources []RelatedResource `json:"resources"`
+}
+
+type Relationship struct {
+	SourceID   string `json:"sourceId"`
+	Relation   string `json:"relation"`
+	TargetID   string `json:"targetId"`
+	TargetType string `json:"targetType"`
+}
+
+type RelatedResource struct {
+	Attributes interface{} `json:"attributes,omitempty"`
+	Deleted    bool        `json:"deleted"`
+	ID         string      `json:"id"`
+	Type       string      `json:"type"`
+
+	// False if the resource is referenced by another resource but has not been scanned
+	Scanned bool `json:"scanned"`
+}
+
 type DeleteResourcesInput struct {
 	Resources []DeleteEntry `json:"resources" validate:"min=1,dive"`
 }
@@ -85,7 +210,7 @@ type ListResourcesInput struct {
 	IntegrationID string `json:"integrationId" validate:"omitempty,uuid4"`
 
 	// Only include resoures from this integration type
-	IntegrationType string `json:"integrationType" validate:"omitempty,oneof=aws"`
+	IntegrationType string `json:"integrationType" validate:"omitempty,oneof=aws gcp k8s"`
 
 	// Only include resources which match one of these resource types
 	Types []string `json:"types" validate:"omitempty,dive,required"`
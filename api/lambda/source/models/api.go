@@ -35,9 +35,11 @@ type LambdaInput struct {
 
 	UpdateIntegrationLastScanEnd   *UpdateIntegrationLastScanEndInput   `json:"updateIntegrationLastScanEnd"`
 	UpdateIntegrationLastScanStart *UpdateIntegrationLastScanStartInput `json:"updateIntegrationLastScanStart"`
+	UpdateIntegrationResourceScan  *UpdateIntegrationResourceScanInput  `json:"updateIntegrationResourceScan"`
 
-	FullScan     *FullScanInput     `json:"fullScan"`
-	UpdateStatus *UpdateStatusInput `json:"updateStatus"`
+	FullScan                    *FullScanInput                    `json:"fullScan"`
+	OnboardOrganizationAccounts *OnboardOrganizationAccountsInput `json:"onboardOrganizationAccounts"`
+	UpdateStatus                *UpdateStatusInput                `json:"updateStatus"`
 }
 
 //
@@ -47,12 +49,16 @@ type LambdaInput struct {
 // CheckIntegrationInput is used to check the health of a potential configuration.
 type CheckIntegrationInput struct {
 	AWSAccountID     string `genericapi:"redact" json:"awsAccountId" validate:"omitempty,len=12,numeric"`
-	IntegrationType  string `json:"integrationType" validate:"oneof=aws-scan aws-s3 aws-sqs"`
+	IntegrationType  string `json:"integrationType" validate:"oneof=aws-scan aws-s3 aws-sqs gcp-scan k8s-scan"`
 	IntegrationLabel string `json:"integrationLabel" validate:"required,integrationLabel"`
 
 	// Checks for cloudsec integrations
-	EnableCWESetup    *bool `json:"enableCWESetup"`
-	EnableRemediation *bool `json:"enableRemediation"`
+	EnableCWESetup     *bool `json:"enableCWESetup"`
+	EnableRemediation  *bool `json:"enableRemediation"`
+	EnableOrganization *bool `json:"enableOrganization"`
+
+	// Checks for aws-scan integrations with their own audit role
+	AuditRole *AuditRoleConfig `json:"auditRole,omitempty" validate:"omitempty"`
 
 	// Checks for log analysis integrations
 	S3Bucket         string           `json:"s3Bucket"`
@@ -61,6 +67,16 @@ type CheckIntegrationInput struct {
 
 	// Checks for Sqs configuration
 	SqsConfig *SqsConfig `json:"sqsConfig,omitempty"`
+
+	// Checks for gcp-scan integrations
+	GCPServiceAccountKey string   `genericapi:"redact" json:"gcpServiceAccountKey"`
+	GCPProjectIDs        []string `json:"gcpProjectIds"`
+
+	// Checks for k8s-scan integrations
+	K8sKubeconfig           string `genericapi:"redact" json:"k8sKubeconfig"`
+	K8sAPIServerURL         string `json:"k8sApiServerUrl" validate:"omitempty,url"`
+	K8sServiceAccountToken  string `genericapi:"redact" json:"k8sServiceAccountToken"`
+	K8sCertificateAuthority string `json:"k8sCertificateAuthority"`
 }
 
 //
@@ -74,22 +90,39 @@ type PutIntegrationInput struct {
 
 // PutIntegrationSettings are all the settings for the new integration.
 type PutIntegrationSettings struct {
-	IntegrationLabel        string           `json:"integrationLabel" validate:"required,integrationLabel,excludesall='<>&\""`
-	IntegrationType         string           `json:"integrationType" validate:"oneof=aws-scan aws-s3 aws-sqs"`
-	UserID                  string           `json:"userId" validate:"required,uuid4"`
-	AWSAccountID            string           `genericapi:"redact" json:"awsAccountId" validate:"omitempty,len=12,numeric"`
-	CWEEnabled              *bool            `json:"cweEnabled"`
-	RemediationEnabled      *bool            `json:"remediationEnabled"`
-	ScanIntervalMins        int              `json:"scanIntervalMins" validate:"omitempty,oneof=60 180 360 720 1440"`
-	Enabled                 *bool            `json:"enabled"`
-	RegionIgnoreList        []string         `json:"regionIgnoreList"`
-	ResourceTypeIgnoreList  []string         `json:"resourceTypeIgnoreList"`
-	ResourceRegexIgnoreList []string         `json:"resourceRegexIgnoreList"`
-	S3Bucket                string           `json:"s3Bucket"`
-	S3PrefixLogTypes        S3PrefixLogtypes `json:"s3PrefixLogTypes,omitempty" validate:"omitempty,min=1"`
-	KmsKey                  string           `json:"kmsKey" validate:"omitempty,kmsKeyArn"`
+	IntegrationLabel        string                   `json:"integrationLabel" validate:"required,integrationLabel,excludesall='<>&\""`
+	IntegrationType         string                   `json:"integrationType" validate:"oneof=aws-scan aws-s3 aws-sqs gcp-scan k8s-scan"`
+	UserID                  string                   `json:"userId" validate:"required,uuid4"`
+	AWSAccountID            string                   `genericapi:"redact" json:"awsAccountId" validate:"omitempty,len=12,numeric"`
+	CWEEnabled              *bool                    `json:"cweEnabled"`
+	RemediationEnabled      *bool                    `json:"remediationEnabled"`
+	ScanIntervalMins        int                      `json:"scanIntervalMins" validate:"omitempty,oneof=60 180 360 720 1440"`
+	Enabled                 *bool                    `json:"enabled"`
+	RegionIgnoreList        []string                 `json:"regionIgnoreList"`
+	ResourceTypeIgnoreList  []string                 `json:"resourceTypeIgnoreList"`
+	ResourceRegexIgnoreList []string                 `json:"resourceRegexIgnoreList"`
+	ResourceTypeScanConfigs []ResourceTypeScanConfig `json:"resourceTypeScanConfigs" validate:"omitempty,dive"`
+	OrganizationScanEnabled *bool                    `json:"organizationScanEnabled"`
+	OrganizationAutoOnboard *bool                    `json:"organizationAutoOnboard"`
+	S3Bucket                string                   `json:"s3Bucket"`
+	S3PrefixLogTypes        S3PrefixLogtypes         `json:"s3PrefixLogTypes,omitempty" validate:"omitempty,min=1"`
+	KmsKey                  string                   `json:"kmsKey" validate:"omitempty,kmsKeyArn"`
 
 	SqsConfig *SqsConfig `json:"sqsConfig,omitempty"`
+
+	// Optional for aws-scan integrations: the role to scan with instead of the default audit role
+	AuditRole *AuditRoleConfig `json:"auditRole,omitempty" validate:"omitempty"`
+
+	// The JSON key of the service account a gcp-scan integration scans as, and the projects it scans
+	GCPServiceAccountKey string   `genericapi:"redact" json:"gcpServiceAccountKey"`
+	GCPProjectIDs        []string `json:"gcpProjectIds" validate:"omitempty,dive,min=6,max=30"`
+
+	// How a k8s-scan integration connects to its cluster: either a kubeconfig with embedded credentials,
+	// or the URL of the API server with a service account token
+	K8sKubeconfig           string `genericapi:"redact" json:"k8sKubeconfig"`
+	K8sAPIServerURL         string `json:"k8sApiServerUrl" validate:"omitempty,url"`
+	K8sServiceAccountToken  string `genericapi:"redact" json:"k8sServiceAccountToken"`
+	K8sCertificateAuthority string `json:"k8sCertificateAuthority"`
 }
 
 //
@@ -98,25 +131,41 @@ type PutIntegrationSettings struct {
 
 // ListIntegrationsInput allows filtering by the IntegrationType field
 type ListIntegrationsInput struct {
-	IntegrationType *string `json:"integrationType" validate:"omitempty,oneof=aws-scan aws-s3 aws-sqs"`
+	IntegrationType *string `json:"integrationType" validate:"omitempty,oneof=aws-scan aws-s3 aws-sqs gcp-scan k8s-scan"`
 }
 
 // UpdateIntegrationSettingsInput is used to update integration settings.
 type UpdateIntegrationSettingsInput struct {
-	IntegrationID           string           `json:"integrationId" validate:"required,uuid4"`
-	IntegrationLabel        string           `json:"integrationLabel" validate:"required,integrationLabel,excludesall='<>&\""`
-	CWEEnabled              *bool            `json:"cweEnabled"`
-	RemediationEnabled      *bool            `json:"remediationEnabled"`
-	ScanIntervalMins        int              `json:"scanIntervalMins" validate:"omitempty,oneof=60 180 360 720 1440"`
-	Enabled                 *bool            `json:"enabled"`
-	RegionIgnoreList        []string         `json:"regionIgnoreList"`
-	ResourceTypeIgnoreList  []string         `json:"resourceTypeIgnoreList"`
-	ResourceRegexIgnoreList []string         `json:"resourceRegexIgnoreList"`
-	S3Bucket                string           `json:"s3Bucket" validate:"omitempty,min=1"`
-	S3PrefixLogTypes        S3PrefixLogtypes `json:"s3PrefixLogTypes,omitempty" validate:"omitempty,min=1"`
-	KmsKey                  string           `json:"kmsKey" validate:"omitempty,kmsKeyArn"`
+	IntegrationID           string                   `json:"integrationId" validate:"required,uuid4"`
+	IntegrationLabel        string                   `json:"integrationLabel" validate:"required,integrationLabel,excludesall='<>&\""`
+	CWEEnabled              *bool                    `json:"cweEnabled"`
+	RemediationEnabled      *bool                    `json:"remediationEnabled"`
+	ScanIntervalMins        int                      `json:"scanIntervalMins" validate:"omitempty,oneof=60 180 360 720 1440"`
+	Enabled                 *bool                    `json:"enabled"`
+	RegionIgnoreList        []string                 `json:"regionIgnoreList"`
+	ResourceTypeIgnoreList  []string                 `json:"resourceTypeIgnoreList"`
+	ResourceRegexIgnoreList []string                 `json:"resourceRegexIgnoreList"`
+	ResourceTypeScanConfigs []ResourceTypeScanConfig `json:"resourceTypeScanConfigs" validate:"omitempty,dive"`
+	OrganizationScanEnabled *bool                    `json:"organizationScanEnabled"`
+	OrganizationAutoOnboard *bool                    `json:"organizationAutoOnboard"`
+	S3Bucket                string                   `json:"s3Bucket" validate:"omitempty,min=1"`
+	S3PrefixLogTypes        S3PrefixLogtypes         `json:"s3PrefixLogTypes,omitempty" validate:"omitempty,min=1"`
+	KmsKey                  string                   `json:"kmsKey" validate:"omitempty,kmsKeyArn"`
 
 	SqsConfig *SqsConfig `json:"sqsConfig,omitempty"`
+
+	// Optional for aws-scan integrations: the role to scan with, nil to scan with the default audit role
+	AuditRole *AuditRoleConfig `json:"auditRole,omitempty" validate:"omitempty"`
+
+	// Optional for gcp-scan integrations: a new service account key replaces the stored one
+	GCPServiceAccountKey string   `genericapi:"redact" json:"gcpServiceAccountKey"`
+	GCPProjectIDs        []string `json:"gcpProjectIds" validate:"omitempty,dive,min=6,max=30"`
+
+	// Optional for k8s-scan integrations: new credentials replace the stored ones
+	K8sKubeconfig           string `genericapi:"redact" json:"k8sKubeconfig"`
+	K8sAPIServerURL         string `json:"k8sApiServerUrl" validate:"omitempty,url"`
+	K8sServiceAccountToken  string `genericapi:"redact" json:"k8sServiceAccountToken"`
+	K8sCertificateAuthority string `json:"k8sCertificateAuthority"`
 }
 
 // DeleteIntegrationInput is used to delete a specific item from the database.
@@ -144,6 +193,22 @@ type ListLogTypesOutput struct {
 // FullScanInput is used to do a full scan of one or more integrations.
 type FullScanInput struct {
 	Integrations []*SourceIntegrationMetadata
+
+	// Optional: the resource type / region slices to scan, keyed by integration ID.
+	// Integrations without an entry have every resource type scanned in all regions.
+	ScanSlices map[string][]ScanSlice `json:"scanSlices,omitempty"`
+}
+
+//
+// OnboardOrganizationAccounts: Used by the snapshot-poller when scanning an AWS Organization
+//
+
+// OnboardOrganizationAccountsInput lists the active member accounts of the organization managed by an
+// integration. Accounts which are not onboarded yet get their own aws-scan integration if the
+// organization integration has auto onboarding enabled.
+type OnboardOrganizationAccountsInput struct {
+	IntegrationID string   `json:"integrationId" validate:"required,uuid4"`
+	AccountIDs    []string `json:"accountIds" validate:"required,min=1,dive,len=12,numeric"`
 }
 
 //
@@ -186,6 +251,16 @@ type UpdateIntegrationLastScanEndInput struct {
 	LastScanErrorMessage string    `json:"lastScanErrorMessage"`
 }
 
+// UpdateIntegrationResourceScanInput is used by the snapshot poller to record that a resource type
+// has been fully scanned in a region.
+type UpdateIntegrationResourceScanInput struct {
+	IntegrationID string `json:"integrationId" validate:"required,uuid4"`
+	ResourceType  string `json:"resourceType" validate:"required"`
+	// Empty when the scan covered all regions
+	Region   string    `json:"region"`
+	ScanTime time.Time `json:"scanTime" validate:"required"`
+}
+
 // Updates the status of an integration
 // Sample request:
 // {
@@ -20,9 +20,17 @@ package models
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+
 	"github.com/panther-labs/panther/internal/compliance/snapshotlogs"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
 	"github.com/panther-labs/panther/pkg/stringset"
@@ -47,6 +55,9 @@ type SourceIntegrationScanInformation struct {
 	LastScanStartTime    *time.Time `json:"lastScanStartTime,omitempty"`
 	LastScanEndTime      *time.Time `json:"lastScanEndTime,omitempty"`
 	LastScanErrorMessage string     `json:"lastScanErrorMessage,omitempty"`
+
+	// When each resource type was last fully scanned, keyed by ResourceScanKey(resourceType, region)
+	ResourceScans map[string]time.Time `json:"resourceScans,omitempty"`
 }
 
 // SourceIntegrationMetadata is general settings and metadata for an integration.
@@ -67,6 +78,25 @@ type SourceIntegrationMetadata struct {
 	ResourceTypeIgnoreList  []string `json:"resourceTypeIgnoreList,omitempty"`
 	ResourceRegexIgnoreList []string `json:"resourceRegexIgnoreList,omitempty"`
 
+	// optional per resource type overrides of the scan interval and order
+	ResourceTypeScanConfigs []ResourceTypeScanConfig `json:"resourceTypeScanConfigs,omitempty"`
+
+	// optional fields for the management account of an AWS Organization
+	OrganizationScanEnabled *bool `json:"organizationScanEnabled,omitempty"`
+	OrganizationAutoOnboard *bool `json:"organizationAutoOnboard,omitempty"`
+
+	// optional role an aws-scan integration is scanned with instead of the default audit role
+	AuditRole *AuditRoleConfig `json:"auditRole,omitempty"`
+
+	// fields specific for a gcp-scan integration. The service account key itself is kept in
+	// Secrets Manager, see GCPServiceAccountKeySecretName.
+	GCPProjectIDs          []string `json:"gcpProjectIds,omitempty"`
+	GCPServiceAccountEmail string   `json:"gcpServiceAccountEmail,omitempty"`
+
+	// fields specific for a k8s-scan integration. The credentials are kept in Secrets Manager,
+	// see K8sCredentialsSecretName.
+	K8sAPIServerURL string `json:"k8sApiServerUrl,omitempty"`
+
 	// fields specific for an s3 integration (plus AWSAccountID, StackName)
 	S3Bucket          string           `json:"s3Bucket,omitempty"`
 	S3PrefixLogTypes  S3PrefixLogtypes `json:"s3PrefixLogTypes,omitempty"`
@@ -78,6 +108,105 @@ type SourceIntegrationMetadata struct {
 	SqsConfig *SqsConfig `json:"sqsConfig,omitempty"`
 }
 
+// ScansOrganization is true when the accounts, OUs and policies of the AWS Organization managed
+// by this integration's account are scanned.
+func (m *SourceIntegrationMetadata) ScansOrganization() bool {
+	return m.IntegrationType == IntegrationTypeAWSScan && m.OrganizationScanEnabled != nil && *m.OrganizationScanEnabled
+}
+
+// AuditRoleConfig is the role the snapshot-poller assumes to scan an aws-scan integration, for accounts
+// which use their own role names or are only reachable through intermediate (hub) roles.
+//
+// Panther may only assume the first role of the chain if it is listed in the AuditRoleArns deployment
+// setting, which also describes the trust policies each role of the chain needs.
+type AuditRoleConfig struct {
+	RoleARN    string `json:"roleArn" validate:"required,iamRoleArn"`
+	ExternalID string `json:"externalId,omitempty" validate:"omitempty,min=2,max=1224"`
+	// Roles assumed in order before RoleARN, each with the credentials of the one before it
+	RoleChain []ChainedRole `json:"roleChain,omitempty" validate:"omitempty,max=4,dive"`
+	// Tags of the role session. They are set when assuming the first role of the chain and are transitive,
+	// so they also apply to the sessions of the roles assumed after it.
+	SessionTags map[string]string `json:"sessionTags,omitempty" validate:"omitempty,max=50,dive,keys,min=1,max=128,endkeys,max=256"`
+}
+
+// ChainedRole is a role assumed on the way to the audit role.
+type ChainedRole struct {
+	RoleARN    string `json:"roleArn" validate:"required,iamRoleArn"`
+	ExternalID string `json:"externalId,omitempty" validate:"omitempty,min=2,max=1224"`
+}
+
+// Roles returns all the roles to assume in order, ending with the audit role itself.
+func (c *AuditRoleConfig) Roles() []ChainedRole {
+	roles := make([]ChainedRole, 0, len(c.RoleChain)+1)
+	roles = append(roles, c.RoleChain...)
+	return append(roles, ChainedRole{RoleARN: c.RoleARN, ExternalID: c.ExternalID})
+}
+
+// Credentials returns credentials for the audit role, where each role of the chain is assumed with the
+// credentials of the role before it. The session tags are set when assuming the first role, and are
+// transitive so that they carry over to the sessions of the other roles in the chain.
+func (c *AuditRoleConfig) Credentials(sess *session.Session,
+	options ...func(*stscreds.AssumeRoleProvider)) *credentials.Credentials {
+
+	keys := make([]string, 0, len(c.SessionTags))
+	for key := range c.SessionTags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var tags []*sts.Tag
+	for _, key := range keys {
+		tags = append(tags, &sts.Tag{Key: aws.String(key), Value: aws.String(c.SessionTags[key])})
+	}
+
+	roles := c.Roles()
+	var creds *credentials.Credentials
+	for i, role := range roles {
+		roleSession := sess
+		if creds != nil {
+			roleSession = sess.Copy(aws.NewConfig().WithCredentials(creds))
+		}
+		first, externalID := i == 0, role.ExternalID
+		creds = stscreds.NewCredentials(roleSession, role.RoleARN, func(p *stscreds.AssumeRoleProvider) {
+			if externalID != "" {
+				p.ExternalID = aws.String(externalID)
+			}
+			if first && len(tags) > 0 {
+				p.Tags = tags
+				if len(roles) > 1 {
+					p.TransitiveTagKeys = aws.StringSlice(keys)
+				}
+			}
+			for _, option := range options {
+				option(p)
+			}
+		})
+	}
+	return creds
+}
+
+// InAccount returns the same configuration for the role with the same name in another account, which is
+// how the member accounts of an AWS Organization are scanned.
+func (c *AuditRoleConfig) InAccount(accountID string) *AuditRoleConfig {
+	result := *c
+	if roleARN, err := arn.Parse(c.RoleARN); err == nil {
+		roleARN.AccountID = accountID
+		result.RoleARN = roleARN.String()
+	}
+	return &result
+}
+
+// GCPServiceAccountKeySecretName is the name of the Secrets Manager secret holding the service account
+// key of a gcp-scan integration.
+func GCPServiceAccountKeySecretName(integrationID string) string {
+	return "panther-source-gcp-" + integrationID
+}
+
+// K8sCredentialsSecretName is the name of the Secrets Manager secret holding the kubeconfig or service
+// account token of a k8s-scan integration.
+func K8sCredentialsSecretName(integrationID string) string {
+	return "panther-source-k8s-" + integrationID
+}
+
 // S3PrefixLogtypesMapping contains the logtypes Panther should parse for this s3 prefix.
 type S3PrefixLogtypesMapping struct {
 	S3Prefix string   `json:"prefix"`
@@ -117,7 +246,7 @@ func (pl S3PrefixLogtypes) LongestPrefixMatch(objectKey string) (bestMatch S3Pre
 // log types per prefix defined.
 func (s *SourceIntegration) RequiredLogTypes() (logTypes []string) {
 	switch s.IntegrationType {
-	case IntegrationTypeAWSScan:
+	case IntegrationTypeAWSScan, IntegrationTypeGCPScan, IntegrationTypeK8sScan:
 		return logtypes.CollectNames(snapshotlogs.LogTypes())
 	case IntegrationTypeAWS3:
 		return s.S3PrefixLogTypes.LogTypes()
@@ -132,7 +261,7 @@ func (s *SourceIntegration) RequiredLogTypes() (logTypes []string) {
 
 func (s *SourceIntegration) RequiredLogProcessingRole() string {
 	switch typ := s.IntegrationType; typ {
-	case IntegrationTypeAWS3, IntegrationTypeAWSScan:
+	case IntegrationTypeAWS3, IntegrationTypeAWSScan, IntegrationTypeGCPScan, IntegrationTypeK8sScan:
 		return s.LogProcessingRole
 	case IntegrationTypeSqs:
 		return s.SqsConfig.LogProcessingRole
@@ -145,7 +274,8 @@ func (s *SourceIntegration) RequiredLogProcessingRole() string {
 // For an s3 source, bucket and prefixes are user inputs.
 func (s *SourceIntegration) S3Info() (bucket string, prefixes []string) {
 	switch s.IntegrationType {
-	case IntegrationTypeAWSScan:
+	case IntegrationTypeAWSScan, IntegrationTypeGCPScan, IntegrationTypeK8sScan:
+		// The resource and compliance history of all cloud security sources is written here
 		return s.S3Bucket, []string{"cloudsecurity"}
 	case IntegrationTypeAWS3:
 		return s.S3Bucket, s.S3PrefixLogTypes.S3Prefixes()
@@ -165,6 +295,15 @@ type SourceIntegrationHealth struct {
 	AuditRoleStatus       SourceIntegrationItemStatus `json:"auditRoleStatus,omitempty"`
 	CWERoleStatus         SourceIntegrationItemStatus `json:"cweRoleStatus,omitempty"`
 	RemediationRoleStatus SourceIntegrationItemStatus `json:"remediationRoleStatus,omitempty"`
+	OrganizationStatus    SourceIntegrationItemStatus `json:"organizationStatus,omitempty"`
+
+	// Checks for gcp-scan integrations
+	ServiceAccountStatus SourceIntegrationItemStatus `json:"serviceAccountStatus,omitempty"`
+	ProjectsStatus       SourceIntegrationItemStatus `json:"projectsStatus,omitempty"`
+
+	// Checks for k8s-scan integrations
+	CredentialsStatus SourceIntegrationItemStatus `json:"credentialsStatus,omitempty"`
+	ClusterStatus     SourceIntegrationItemStatus `json:"clusterStatus,omitempty"`
 
 	// Checks for log analysis integrations
 	ProcessingRoleStatus SourceIntegrationItemStatus `json:"processingRoleStatus,omitempty"`
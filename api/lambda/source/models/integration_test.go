@@ -19,8 +19,17 @@ package models
  */
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -59,3 +68,74 @@ func TestS3PrefixLogtypes_LongestPrefixMatch_ReturnNil(t *testing.T) {
 	// No prefix matched
 	require.False(t, matched)
 }
+
+func TestAuditRoleConfigRoles(t *testing.T) {
+	auditRole := &AuditRoleConfig{
+		RoleARN:    "arn:aws:iam::123456789012:role/BusinessUnitAudit",
+		ExternalID: "bu-id",
+		RoleChain:  []ChainedRole{{RoleARN: "arn:aws:iam::111111111111:role/SecurityHub", ExternalID: "hub-id"}},
+	}
+	assert.Equal(t, []ChainedRole{
+		{RoleARN: "arn:aws:iam::111111111111:role/SecurityHub", ExternalID: "hub-id"},
+		{RoleARN: "arn:aws:iam::123456789012:role/BusinessUnitAudit", ExternalID: "bu-id"},
+	}, auditRole.Roles())
+
+	member := auditRole.InAccount("222222222222")
+	assert.Equal(t, "arn:aws:iam::222222222222:role/BusinessUnitAudit", member.RoleARN)
+	assert.Equal(t, auditRole.RoleChain, member.RoleChain)
+	// The original configuration is unchanged
+	assert.Equal(t, "arn:aws:iam::123456789012:role/BusinessUnitAudit", auditRole.RoleARN)
+}
+
+// The credentials of each role are used to assume the next one
+func TestAuditRoleConfigCredentials(t *testing.T) {
+	var mutex sync.Mutex
+	var requests []map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		request := map[string]string{"Signer": strings.Split(r.Header.Get("Authorization"), "/")[0]}
+		for key := range r.PostForm {
+			request[key] = r.PostForm.Get(key)
+		}
+		mutex.Lock()
+		requests = append(requests, request)
+		mutex.Unlock()
+		// The access key of the session identifies the assumed role
+		roleName := r.PostForm.Get("RoleArn")[strings.LastIndex(r.PostForm.Get("RoleArn"), "/")+1:]
+		fmt.Fprintf(w, `<AssumeRoleResponse><AssumeRoleResult><Credentials>
+<AccessKeyId>%s</AccessKeyId><SecretAccessKey>secret</SecretAccessKey><SessionToken>token</SessionToken>
+<Expiration>2100-01-01T00:00:00Z</Expiration></Credentials></AssumeRoleResult></AssumeRoleResponse>`, roleName)
+	}))
+	defer server.Close()
+
+	sess := session.Must(session.NewSession(&aws.Config{
+		Credentials: credentials.NewStaticCredentials("PantherSnapshotPoller", "secret", ""),
+		Endpoint:    aws.String(server.URL),
+		Region:      aws.String("us-west-2"),
+	}))
+	auditRole := &AuditRoleConfig{
+		RoleARN:     "arn:aws:iam::222222222222:role/BusinessUnitAudit",
+		RoleChain:   []ChainedRole{{RoleARN: "arn:aws:iam::111111111111:role/SecurityHub", ExternalID: "hub-external-id"}},
+		SessionTags: map[string]string{"team": "security", "bu": "payments"},
+	}
+	creds := auditRole.Credentials(sess)
+
+	value, err := creds.Get()
+	require.NoError(t, err)
+	assert.Equal(t, "BusinessUnitAudit", value.AccessKeyID)
+
+	require.Len(t, requests, 2)
+	assert.Equal(t, "arn:aws:iam::111111111111:role/SecurityHub", requests[0]["RoleArn"])
+	assert.Equal(t, "AWS4-HMAC-SHA256 Credential=PantherSnapshotPoller", requests[0]["Signer"])
+	assert.Equal(t, "hub-external-id", requests[0]["ExternalId"])
+	assert.Equal(t, "bu", requests[0]["Tags.member.1.Key"])
+	assert.Equal(t, "payments", requests[0]["Tags.member.1.Value"])
+	assert.Equal(t, "team", requests[0]["Tags.member.2.Key"])
+	assert.Equal(t, "bu", requests[0]["TransitiveTagKeys.member.1"])
+	assert.Equal(t, "team", requests[0]["TransitiveTagKeys.member.2"])
+
+	assert.Equal(t, "arn:aws:iam::222222222222:role/BusinessUnitAudit", requests[1]["RoleArn"])
+	assert.Equal(t, "AWS4-HMAC-SHA256 Credential=SecurityHub", requests[1]["Signer"])
+	assert.NotContains(t, requests[1], "ExternalId")
+	assert.NotContains(t, requests[1], "Tags.member.1.Key")
+}
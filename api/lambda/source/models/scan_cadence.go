@@ -0,0 +1,129 @@
+package models
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/panther-labs/panther/pkg/stringset"
+)
+
+// DefaultScanIntervalMins is used for integrations which were saved without a scan interval.
+const DefaultScanIntervalMins = 1440
+
+// ResourceTypeScanConfig overrides how often, and in which order, a resource type is scanned.
+type ResourceTypeScanConfig struct {
+	ResourceType string `json:"resourceType" validate:"required"`
+	// Minutes between scans of this resource type, defaults to the integration's ScanIntervalMins
+	IntervalMins int `json:"intervalMins" validate:"omitempty,min=15,max=10080"`
+	// Resource types with a higher priority are scanned first when several are due at once
+	Priority int `json:"priority" validate:"min=0,max=100"`
+}
+
+// ScanSlice is a single resource type in a single region, the unit in which scans are scheduled.
+type ScanSlice struct {
+	ResourceType string `json:"resourceType"`
+	// Empty to scan the resource type in all regions
+	Region   string `json:"region,omitempty"`
+	Priority int    `json:"priority,omitempty"`
+}
+
+// ResourceScanKey is the key of a slice in SourceIntegrationScanInformation.ResourceScans
+func ResourceScanKey(resourceType, region string) string {
+	return resourceType + "/" + region
+}
+
+// ScanConfig returns the scan settings of a resource type, falling back to the integration defaults.
+func (m *SourceIntegrationMetadata) ScanConfig(resourceType string) ResourceTypeScanConfig {
+	config := ResourceTypeScanConfig{ResourceType: resourceType, IntervalMins: m.ScanIntervalMins}
+	for _, override := range m.ResourceTypeScanConfigs {
+		if override.ResourceType != resourceType {
+			continue
+		}
+		if override.IntervalMins > 0 {
+			config.IntervalMins = override.IntervalMins
+		}
+		config.Priority = override.Priority
+		break
+	}
+	if config.IntervalMins <= 0 {
+		config.IntervalMins = DefaultScanIntervalMins
+	}
+	return config
+}
+
+// DueScanSlices returns the slices of the given resource types which need to be scanned now,
+// highest priority first.
+//
+// An all regions slice is issued whenever the last all regions scan of a type is due, so that newly
+// enabled regions are picked up. In between, regions whose own last scan is due (because it
+// finished late or was retried) are scanned individually.
+func (s *SourceIntegration) DueScanSlices(resourceTypes []string, now time.Time) []ScanSlice {
+	// Group the recorded scan times by resource type
+	scansByType := make(map[string]map[string]time.Time)
+	for key, scanTime := range s.ResourceScans {
+		split := strings.LastIndex(key, "/")
+		if split < 0 {
+			continue
+		}
+		resourceType, region := key[:split], key[split+1:]
+		if scansByType[resourceType] == nil {
+			scansByType[resourceType] = make(map[string]time.Time)
+		}
+		scansByType[resourceType][region] = scanTime
+	}
+
+	var slices []ScanSlice
+	for _, resourceType := range resourceTypes {
+		if stringset.Contains(s.ResourceTypeIgnoreList, resourceType) {
+			continue
+		}
+		config := s.ScanConfig(resourceType)
+		interval := time.Duration(config.IntervalMins) * time.Minute
+		scans := scansByType[resourceType]
+
+		if lastScan, ok := scans[""]; !ok || now.Sub(lastScan) >= interval {
+			slices = append(slices, ScanSlice{ResourceType: resourceType, Priority: config.Priority})
+			continue
+		}
+		for region, lastScan := range scans {
+			if region != "" && now.Sub(lastScan) >= interval {
+				slices = append(slices, ScanSlice{ResourceType: resourceType, Region: region, Priority: config.Priority})
+			}
+		}
+	}
+
+	SortScanSlices(slices)
+	return slices
+}
+
+// SortScanSlices orders slices by descending priority, then by resource type and region.
+func SortScanSlices(slices []ScanSlice) {
+	sort.SliceStable(slices, func(i, j int) bool {
+		if slices[i].Priority != slices[j].Priority {
+			return slices[i].Priority > slices[j].Priority
+		}
+		if slices[i].ResourceType != slices[j].ResourceType {
+			return slices[i].ResourceType < slices[j].ResourceType
+		}
+		return slices[i].Region < slices[j].Region
+	})
+}
@@ -0,0 +1,89 @@
+package models
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScanConfig(t *testing.T) {
+	metadata := SourceIntegrationMetadata{
+		ScanIntervalMins: 360,
+		ResourceTypeScanConfigs: []ResourceTypeScanConfig{
+			{ResourceType: "AWS.IAM.Role", IntervalMins: 60, Priority: 10},
+			{ResourceType: "AWS.S3.Bucket", Priority: 5},
+		},
+	}
+
+	assert.Equal(t, ResourceTypeScanConfig{ResourceType: "AWS.IAM.Role", IntervalMins: 60, Priority: 10},
+		metadata.ScanConfig("AWS.IAM.Role"))
+	assert.Equal(t, ResourceTypeScanConfig{ResourceType: "AWS.S3.Bucket", IntervalMins: 360, Priority: 5},
+		metadata.ScanConfig("AWS.S3.Bucket"))
+	assert.Equal(t, ResourceTypeScanConfig{ResourceType: "AWS.KMS.Key", IntervalMins: 360},
+		metadata.ScanConfig("AWS.KMS.Key"))
+
+	metadata.ScanIntervalMins = 0
+	assert.Equal(t, DefaultScanIntervalMins, metadata.ScanConfig("AWS.KMS.Key").IntervalMins)
+}
+
+func TestDueScanSlices(t *testing.T) {
+	now := time.Now()
+	integration := SourceIntegration{
+		SourceIntegrationMetadata: SourceIntegrationMetadata{
+			ScanIntervalMins: 1440,
+			ResourceTypeScanConfigs: []ResourceTypeScanConfig{
+				{ResourceType: "AWS.IAM.Role", IntervalMins: 60, Priority: 10},
+			},
+			ResourceTypeIgnoreList: []string{"AWS.EC2.AMI"},
+		},
+		SourceIntegrationScanInformation: SourceIntegrationScanInformation{
+			ResourceScans: map[string]time.Time{
+				// Full scan is due because of the shorter interval
+				ResourceScanKey("AWS.IAM.Role", ""):          now.Add(-2 * time.Hour),
+				ResourceScanKey("AWS.IAM.Role", "us-east-1"): now.Add(-2 * time.Hour),
+				// Full scan is recent, but one region finished late
+				ResourceScanKey("AWS.S3.Bucket", ""):          now.Add(-23 * time.Hour),
+				ResourceScanKey("AWS.S3.Bucket", "us-west-2"): now.Add(-25 * time.Hour),
+				ResourceScanKey("AWS.S3.Bucket", "us-east-1"): now.Add(-22 * time.Hour),
+				// Nothing is due
+				ResourceScanKey("AWS.KMS.Key", ""):          now.Add(-time.Hour),
+				ResourceScanKey("AWS.KMS.Key", "us-west-2"): now.Add(-time.Hour),
+			},
+		},
+	}
+
+	slices := integration.DueScanSlices(
+		[]string{"AWS.EC2.AMI", "AWS.KMS.Key", "AWS.Lambda.Function", "AWS.S3.Bucket", "AWS.IAM.Role"}, now)
+	assert.Equal(t, []ScanSlice{
+		{ResourceType: "AWS.IAM.Role", Priority: 10},
+		{ResourceType: "AWS.Lambda.Function"},
+		{ResourceType: "AWS.S3.Bucket", Region: "us-west-2"},
+	}, slices)
+}
+
+func TestDueScanSlicesNewIntegration(t *testing.T) {
+	integration := SourceIntegration{}
+	assert.Equal(t, []ScanSlice{
+		{ResourceType: "AWS.IAM.Role"},
+		{ResourceType: "AWS.S3.Bucket"},
+	}, integration.DueScanSlices([]string{"AWS.S3.Bucket", "AWS.IAM.Role"}, time.Now()))
+}
@@ -43,6 +43,9 @@ func Validator() (*validator.Validate, error) {
 	if err := result.RegisterValidation("kmsKeyArn", validateKmsKeyArn); err != nil {
 		return nil, err
 	}
+	if err := result.RegisterValidation("iamRoleArn", validateIAMRoleArn); err != nil {
+		return nil, err
+	}
 	return result, nil
 }
 
@@ -66,3 +69,11 @@ func validateKmsKeyArn(fl validator.FieldLevel) bool {
 	}
 	return true
 }
+
+func validateIAMRoleArn(fl validator.FieldLevel) bool {
+	roleArn, err := arn.Parse(fl.Field().String())
+	if err != nil {
+		return false
+	}
+	return roleArn.Service == "iam" && len(roleArn.AccountID) == 12 && strings.HasPrefix(roleArn.Resource, "role/")
+}
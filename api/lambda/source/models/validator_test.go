@@ -80,3 +80,27 @@ func TestValidateKmsKey(t *testing.T) {
 	})
 	require.NoError(t, err)
 }
+
+func TestValidateAuditRole(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	input := &PutIntegrationInput{
+		PutIntegrationSettings: PutIntegrationSettings{
+			AWSAccountID:     "123456789012",
+			IntegrationLabel: "Test12- ",
+			IntegrationType:  IntegrationTypeAWSScan,
+			UserID:           "cb7663c7-80ed-420b-a287-ed7dc50a0bf7",
+			AuditRole: &AuditRoleConfig{
+				RoleARN:     "arn:aws:iam::123456789012:role/BusinessUnitAudit",
+				RoleChain:   []ChainedRole{{RoleARN: "arn:aws:iam::111111111111:role/SecurityHub", ExternalID: "hub-id"}},
+				SessionTags: map[string]string{"team": "security"},
+			},
+		},
+	}
+	require.NoError(t, validator.Struct(input))
+
+	input.AuditRole.RoleChain[0].RoleARN = "arn:aws:iam::111111111111:user/SecurityHub"
+	errorMsg := "Key: 'PutIntegrationInput.PutIntegrationSettings.AuditRole.RoleChain[0].RoleARN' " +
+		"Error:Field validation for 'RoleARN' failed on the 'iamRoleArn' tag"
+	require.EqualError(t, validator.Struct(input), errorMsg)
+}
@@ -25,6 +25,10 @@ const (
 	IntegrationTypeAWS3 = "aws-s3"
 	// IntegrationTypeSqs is integration type for pulling data from an SQS queue.
 	IntegrationTypeSqs = "aws-sqs"
+	// IntegrationTypeGCPScan is the integration type for snapshots of customer GCP projects.
+	IntegrationTypeGCPScan = "gcp-scan"
+	// IntegrationTypeK8sScan is the integration type for snapshots of customer Kubernetes clusters.
+	IntegrationTypeK8sScan = "k8s-scan"
 
 	// StatusError is the string set in the database when an error occurs in a scan.
 	StatusError = "error"
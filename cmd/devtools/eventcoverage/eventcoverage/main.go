@@ -0,0 +1,90 @@
+package main
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This tool reports which AWS resource types scanned by the snapshot poller lack real-time classification
+// of their CloudTrail events in the aws_event_processor. Changes to those resource types are only picked
+// up by the generic ARN classifier, if at all, or by the next full scan.
+// Example usage:
+// $ eventcoverage
+// $ eventcoverage -all
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/panther-labs/panther/internal/compliance/aws_event_processor/processor"
+	awspoller "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws"
+)
+
+var all = flag.Bool("all", false, "List all resource types, including those with a dedicated classifier")
+
+func main() {
+	flag.Parse()
+
+	resourceTypes := make(map[string]struct{})
+	for resourceType := range awspoller.IndividualARNResourcePollers {
+		resourceTypes[resourceType] = struct{}{}
+	}
+	for resourceType := range awspoller.IndividualResourcePollers {
+		resourceTypes[resourceType] = struct{}{}
+	}
+	for resourceType := range awspoller.ServicePollers {
+		resourceTypes[resourceType] = struct{}{}
+	}
+	sorted := make([]string, 0, len(resourceTypes))
+	for resourceType := range resourceTypes {
+		sorted = append(sorted, resourceType)
+	}
+	sort.Strings(sorted)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "RESOURCE TYPE\tEVENT SOURCE\tREAL-TIME CLASSIFICATION")
+	missing := 0
+	for _, coverage := range processor.Coverage(sorted) {
+		var classification string
+		switch {
+		case coverage.Classified:
+			if !*all {
+				continue
+			}
+			classification = "classifier"
+		case coverage.GenericARN:
+			classification = "generic ARN classifier only"
+		default:
+			classification = "none, full scans only"
+		}
+		if !coverage.Classified {
+			missing++
+		}
+		eventSource := coverage.EventSource
+		if eventSource == "" {
+			eventSource = "unknown"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", coverage.ResourceType, eventSource, classification)
+	}
+	if err := w.Flush(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Printf("\n%d of %d resource types lack a dedicated real-time classifier\n", missing, len(sorted))
+}
@@ -0,0 +1,126 @@
+package analysisexport
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// SyncResult reports the changes made to a directory by Sync
+type SyncResult struct {
+	Written   []string
+	Unchanged []string
+	Removed   []string
+}
+
+// Sync extracts an exported zipfile into a directory (e.g. a git working tree).
+//
+// Only files whose content changed are written so that unchanged analysis items don't show up in a diff.
+// If prune is set, analysis files in the exported directories that are not part of the zipfile are removed.
+// Pruning should only be used with unfiltered exports, otherwise it removes the items that were filtered out.
+func Sync(dir string, content []byte, prune bool) (*SyncResult, error) {
+	zipReader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read zipfile")
+	}
+
+	result := SyncResult{}
+	exported := make(map[string]struct{}, len(zipReader.File))
+	exportedDirs := make(map[string]struct{})
+	for _, zipFile := range zipReader.File {
+		if strings.HasSuffix(zipFile.Name, "/") {
+			continue
+		}
+		name := filepath.FromSlash(zipFile.Name)
+		// Never write outside of the target directory
+		if filepath.IsAbs(name) || strings.HasPrefix(filepath.Clean(name), "..") {
+			return nil, errors.Errorf("invalid file name %q in zipfile", zipFile.Name)
+		}
+		exported[filepath.Clean(name)] = struct{}{}
+		exportedDirs[filepath.Dir(filepath.Clean(name))] = struct{}{}
+
+		data, err := readZipFile(zipFile)
+		if err != nil {
+			return nil, err
+		}
+		target := filepath.Join(dir, name)
+		if current, err := ioutil.ReadFile(target); err == nil && bytes.Equal(current, data) {
+			result.Unchanged = append(result.Unchanged, name)
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return nil, errors.Wrapf(err, "failed to create directory for %s", name)
+		}
+		if err := ioutil.WriteFile(target, data, 0644); err != nil { // nolint:gosec
+			return nil, errors.Wrapf(err, "failed to write %s", name)
+		}
+		result.Written = append(result.Written, name)
+	}
+
+	if !prune {
+		return &result, nil
+	}
+	for exportedDir := range exportedDirs {
+		files, err := ioutil.ReadDir(filepath.Join(dir, exportedDir))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list %s", exportedDir)
+		}
+		for _, f := range files {
+			name := filepath.Join(exportedDir, f.Name())
+			if _, ok := exported[name]; ok || f.IsDir() || !isAnalysisFile(name) {
+				continue
+			}
+			if err := os.Remove(filepath.Join(dir, name)); err != nil {
+				return nil, errors.Wrapf(err, "failed to remove %s", name)
+			}
+			result.Removed = append(result.Removed, name)
+		}
+	}
+	return &result, nil
+}
+
+// isAnalysisFile checks if a file would be read by BulkUpload
+func isAnalysisFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".py", ".yml", ".yaml", ".json":
+		return true
+	default:
+		return false
+	}
+}
+
+func readZipFile(zipFile *zip.File) ([]byte, error) {
+	f, err := zipFile.Open()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open %s", zipFile.Name)
+	}
+	defer f.Close()
+	data, err := ioutil.ReadAll(f)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", zipFile.Name)
+	}
+	return data, nil
+}
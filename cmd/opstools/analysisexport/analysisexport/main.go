@@ -0,0 +1,131 @@
+package main
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"encoding/base64"
+	"flag"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/lambda"
+
+	"github.com/panther-labs/panther/api/lambda/analysis/models"
+	"github.com/panther-labs/panther/cmd/opstools"
+	"github.com/panther-labs/panther/cmd/opstools/analysisexport"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+)
+
+var (
+	version string // we expect this to be set by the build tool as `-X main.version=<some version>`
+)
+
+func main() {
+	opstools.SetUsage("exports policies, rules, globals and data models into a directory (Panther version %s)", version)
+	opts := struct {
+		Dir     *string
+		Types   *string
+		IDs     *string
+		Tags    *string
+		Prune   *bool
+		Debug   *bool
+		Region  *string
+		DryRun  *bool
+		Enabled *string
+	}{
+		Dir:     flag.String("dir", ".", "The directory to write the exported files to (e.g. a git working tree)"),
+		Types:   flag.String("types", "", "Comma separated list of analysis types to export (POLICY,RULE,GLOBAL,DATAMODEL)"),
+		IDs:     flag.String("ids", "", "Comma separated list of analysis item ids to export"),
+		Tags:    flag.String("tags", "", "Comma separated list of tags to export"),
+		Enabled: flag.String("enabled", "", "Only export enabled (true) or disabled (false) items"),
+		Prune: flag.Bool("prune", false,
+			"Remove files in the exported directories that are not part of the export (use only without filters)"),
+		DryRun: flag.Bool("dry-run", false, "Only report the number of exported items without writing any files"),
+		Debug:  flag.Bool("debug", false, "Enable additional logging"),
+		Region: flag.String("region", "", "Set the AWS region to run on"),
+	}
+	flag.Parse()
+
+	log := opstools.MustBuildLogger(*opts.Debug)
+
+	input := models.BulkExportInput{
+		IDs:  splitList(*opts.IDs),
+		Tags: splitList(*opts.Tags),
+	}
+	for _, typ := range splitList(*opts.Types) {
+		input.AnalysisTypes = append(input.AnalysisTypes, models.DetectionType(strings.ToUpper(typ)))
+	}
+	switch *opts.Enabled {
+	case "":
+	case "true":
+		input.Enabled = aws.Bool(true)
+	case "false":
+		input.Enabled = aws.Bool(false)
+	default:
+		log.Fatalf("invalid -enabled value %q", *opts.Enabled)
+	}
+	if *opts.Prune && (len(input.IDs) > 0 || len(input.Tags) > 0 || input.Enabled != nil) {
+		log.Fatal("-prune cannot be used with -ids, -tags or -enabled filters")
+	}
+
+	sess, err := session.NewSession(&aws.Config{
+		Region: opts.Region,
+	})
+	if err != nil {
+		log.Fatalf("failed to start AWS session: %s", err)
+	}
+
+	client := gatewayapi.NewClient(lambda.New(sess), "panther-analysis-api")
+	var output models.BulkExportOutput
+	if _, err := client.Invoke(&models.LambdaInput{BulkExport: &input}, &output); err != nil {
+		log.Fatalf("failed to export analysis items: %s", err)
+	}
+	log.Infof("exported %d policies, %d rules, %d globals and %d data models",
+		output.TotalPolicies, output.TotalRules, output.TotalGlobals, output.TotalDataModels)
+	if *opts.DryRun {
+		return
+	}
+
+	content, err := base64.StdEncoding.DecodeString(output.Data)
+	if err != nil {
+		log.Fatalf("failed to decode zipfile: %s", err)
+	}
+	result, err := analysisexport.Sync(*opts.Dir, content, *opts.Prune)
+	if err != nil {
+		log.Fatalf("failed to sync %s: %s", *opts.Dir, err)
+	}
+	for _, name := range result.Written {
+		log.Debugf("wrote %s", name)
+	}
+	for _, name := range result.Removed {
+		log.Debugf("removed %s", name)
+	}
+	log.Infof("%d files written, %d unchanged, %d removed in %s",
+		len(result.Written), len(result.Unchanged), len(result.Removed), *opts.Dir)
+}
+
+func splitList(list string) (values []string) {
+	for _, value := range strings.Split(list, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
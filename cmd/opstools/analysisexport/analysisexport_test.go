@@ -0,0 +1,82 @@
+package analysisexport
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSync(t *testing.T) {
+	assert := require.New(t)
+	dir, err := ioutil.TempDir("", "analysisexport")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	writeFile := func(name, content string) {
+		path := filepath.Join(dir, name)
+		assert.NoError(os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(ioutil.WriteFile(path, []byte(content), 0644))
+	}
+	writeFile("rules/unchanged.py", "def rule(event): return True")
+	writeFile("rules/changed.py", "def rule(event): return False")
+	writeFile("rules/removed.yml", "RuleID: removed")
+	writeFile("rules/README.md", "not an analysis file")
+	writeFile("policies/other.py", "not exported")
+
+	content := buildZip(t, map[string]string{
+		"rules/unchanged.py": "def rule(event): return True",
+		"rules/changed.py":   "def rule(event): return True",
+		"rules/new.yml":      "RuleID: new",
+	})
+	result, err := Sync(dir, content, true)
+	assert.NoError(err)
+	assert.ElementsMatch([]string{"rules/changed.py", "rules/new.yml"}, result.Written)
+	assert.Equal([]string{"rules/unchanged.py"}, result.Unchanged)
+	assert.Equal([]string{"rules/removed.yml"}, result.Removed)
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "rules/changed.py"))
+	assert.NoError(err)
+	assert.Equal("def rule(event): return True", string(data))
+	assert.FileExists(filepath.Join(dir, "rules/README.md"))
+	assert.FileExists(filepath.Join(dir, "policies/other.py"))
+	assert.NoFileExists(filepath.Join(dir, "rules/removed.yml"))
+
+	_, err = Sync(dir, buildZip(t, map[string]string{"../escape.py": ""}), false)
+	assert.Error(err)
+}
+
+func buildZip(t *testing.T, files map[string]string) []byte {
+	var buffer bytes.Buffer
+	w := zip.NewWriter(&buffer)
+	for name, content := range files {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buffer.Bytes()
+}
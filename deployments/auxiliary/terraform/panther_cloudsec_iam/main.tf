@@ -129,8 +129,20 @@ resource "aws_iam_role_policy" "panther_get_tags" {
       {
         Effect : "Allow",
         Action : [
+          "cloudfront:ListTagsForResource",
           "dynamodb:ListTagsOfResource",
+          "ecr:GetLifecyclePolicy",
+          "ecr:GetRepositoryPolicy",
+          "ecr:ListTagsForResource",
+          "elasticache:ListTagsForResource",
+          "elasticfilesystem:DescribeBackupPolicy",
+          "elasticfilesystem:DescribeFileSystemPolicy",
+          "es:ListTags",
           "kms:ListResourceTags",
+          "route53:ListTagsForResource",
+          "secretsmanager:GetResourcePolicy",
+          "sqs:ListQueueTags",
+          "ssm:ListTagsForResource",
           "waf:ListTagsForResource",
           "waf-regional:ListTagsForResource"
         ],
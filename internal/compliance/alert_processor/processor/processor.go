@@ -189,6 +189,7 @@ func triggerRemediation(event *models.ComplianceNotification) error {
 
 	input := remediationmodels.LambdaInput{
 		RemediateResourceAsync: &remediationmodels.RemediateResourceAsyncInput{
+			AlertID:    *GenerateAlertID(event),
 			PolicyID:   event.PolicyID,
 			ResourceID: event.ResourceID,
 		},
@@ -99,6 +99,7 @@ func TestHandleEventWithAlert(t *testing.T) {
 	// mock call to remediate-api
 	remediationInput := &remediationmodels.LambdaInput{
 		RemediateResourceAsync: &remediationmodels.RemediateResourceAsyncInput{
+			AlertID:    *GenerateAlertID(input),
 			PolicyID:   "test-policy",
 			ResourceID: "test-resource",
 		},
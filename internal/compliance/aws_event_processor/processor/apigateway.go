@@ -0,0 +1,100 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+func classifyAPIGateway(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
+	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_amazonapigateway.html
+	//
+	// REST APIs (v1) and HTTP/WebSocket APIs (v2) share an event source and many event names
+	// (CreateStage, UpdateAuthorizer, ...), so we tell them apart by the ID in the request:
+	// REST API calls carry a restApiId, v2 calls carry an apiId. API Gateway ARNs never contain
+	// an account ID:
+	// arn:aws:apigateway:region::/restapis/api-id
+	// arn:aws:apigateway:region::/apis/api-id
+	var resourcePath string
+	switch metadata.eventName {
+	case "CreateRestApi", "ImportRestApi":
+		resourcePath = "/restapis/" + detail.Get("responseElements.id").Str
+	case "CreateApi", "ImportApi":
+		resourcePath = "/apis/" + detail.Get("responseElements.apiId").Str
+	case "TagResource", "UntagResource":
+		parsed, err := arn.Parse(detail.Get("requestParameters.resourceArn").Str)
+		if err != nil {
+			zap.L().Error("apigateway: error parsing ARN", zap.String("eventName", metadata.eventName), zap.Error(err))
+			return nil
+		}
+		// Stages can be tagged too, their ARNs are nested under the API:
+		// arn:aws:apigateway:region::/restapis/api-id/stages/stage-name
+		components := strings.Split(parsed.Resource, "/")
+		if len(components) < 3 || (components[1] != "restapis" && components[1] != "apis") {
+			return nil
+		}
+		resourcePath = strings.Join(components[:3], "/")
+	default:
+		if restAPIID := detail.Get("requestParameters.restApiId").Str; restAPIID != "" {
+			resourcePath = "/restapis/" + restAPIID
+		} else if apiID := detail.Get("requestParameters.apiId").Str; apiID != "" {
+			resourcePath = "/apis/" + apiID
+		} else {
+			// Account level settings, API keys, usage plans, domain names and VPC links are not
+			// part of the API snapshots
+			zap.L().Debug("apigateway: event does not reference an API", zap.String("eventName", metadata.eventName))
+			return nil
+		}
+	}
+
+	resourceType := schemas.ApiGatewayRestApiSchema
+	if strings.HasPrefix(resourcePath, "/apis/") {
+		resourceType = schemas.ApiGatewayV2ApiSchema
+	}
+	if strings.HasSuffix(resourcePath, "/") {
+		// We know the event affected an API, but not which one
+		zap.L().Warn("apigateway: missing api id", zap.String("eventName", metadata.eventName))
+		return []*resourceChange{{
+			AwsAccountID: metadata.accountID,
+			Delete:       false,
+			EventName:    metadata.eventName,
+			Region:       metadata.region,
+			ResourceType: resourceType,
+		}}
+	}
+
+	return []*resourceChange{{
+		AwsAccountID: metadata.accountID,
+		Delete:       metadata.eventName == "DeleteRestApi" || metadata.eventName == "DeleteApi",
+		EventName:    metadata.eventName,
+		ResourceID: arn.ARN{
+			Partition: "aws",
+			Service:   "apigateway",
+			Region:    metadata.region,
+			Resource:  resourcePath,
+		}.String(),
+		ResourceType: resourceType,
+	}}
+}
@@ -0,0 +1,86 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+func TestClassifyAPIGatewayCreateRestApi(t *testing.T) {
+	detail := gjson.Parse(`{"responseElements": {"id": "a1b2c3d4e5", "name": "example-api"}}`)
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "111111111111", eventName: "CreateRestApi"}
+
+	changes := classifyAPIGateway(detail, metadata)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "arn:aws:apigateway:us-west-2::/restapis/a1b2c3d4e5", changes[0].ResourceID)
+	assert.Equal(t, schemas.ApiGatewayRestApiSchema, changes[0].ResourceType)
+	assert.False(t, changes[0].Delete)
+}
+
+func TestClassifyAPIGatewayDeleteApi(t *testing.T) {
+	detail := gjson.Parse(`{"requestParameters": {"apiId": "f6g7h8i9j0"}}`)
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "111111111111", eventName: "DeleteApi"}
+
+	changes := classifyAPIGateway(detail, metadata)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "arn:aws:apigateway:us-west-2::/apis/f6g7h8i9j0", changes[0].ResourceID)
+	assert.Equal(t, schemas.ApiGatewayV2ApiSchema, changes[0].ResourceType)
+	assert.True(t, changes[0].Delete)
+}
+
+// Stage and authorizer events have the same name in both APIs
+func TestClassifyAPIGatewaySharedEventNames(t *testing.T) {
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "111111111111", eventName: "UpdateStage"}
+
+	changes := classifyAPIGateway(gjson.Parse(`{"requestParameters": {"restApiId": "a1b2c3d4e5", "stageName": "prod"}}`), metadata)
+	require.Len(t, changes, 1)
+	assert.Equal(t, schemas.ApiGatewayRestApiSchema, changes[0].ResourceType)
+
+	changes = classifyAPIGateway(gjson.Parse(`{"requestParameters": {"apiId": "f6g7h8i9j0", "stageName": "$default"}}`), metadata)
+	require.Len(t, changes, 1)
+	assert.Equal(t, schemas.ApiGatewayV2ApiSchema, changes[0].ResourceType)
+}
+
+func TestClassifyAPIGatewayTagStage(t *testing.T) {
+	detail := gjson.Parse(`{"requestParameters": {"resourceArn": "arn:aws:apigateway:us-west-2::/restapis/a1b2c3d4e5/stages/prod"}}`)
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "111111111111", eventName: "TagResource"}
+
+	changes := classifyAPIGateway(detail, metadata)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "arn:aws:apigateway:us-west-2::/restapis/a1b2c3d4e5", changes[0].ResourceID)
+}
+
+func TestClassifyAPIGatewayMissingID(t *testing.T) {
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "111111111111", eventName: "CreateApi"}
+
+	changes := classifyAPIGateway(gjson.Parse(`{}`), metadata)
+	require.Len(t, changes, 1)
+	assert.Empty(t, changes[0].ResourceID)
+	assert.Equal(t, "us-west-2", changes[0].Region)
+	assert.Equal(t, schemas.ApiGatewayV2ApiSchema, changes[0].ResourceType)
+
+	metadata.eventName = "CreateUsagePlan"
+	assert.Empty(t, classifyAPIGateway(gjson.Parse(`{"requestParameters": {"name": "example"}}`), metadata))
+}
@@ -22,6 +22,8 @@ import (
 	"os"
 
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
 	"github.com/aws/aws-sdk-go/service/lambda"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
@@ -30,8 +32,10 @@ import (
 )
 
 var (
-	awsSession                      = session.Must(session.NewSession())
-	sqsClient       sqsiface.SQSAPI = sqs.New(awsSession)
-	queueURL                        = os.Getenv("SNAPSHOT_QUEUE_URL")
-	resourcesClient gatewayapi.API  = gatewayapi.NewClient(lambda.New(awsSession), "panther-resources-api")
+	awsSession                                = session.Must(session.NewSession())
+	dynamoClient    dynamodbiface.DynamoDBAPI = dynamodb.New(awsSession)
+	sqsClient       sqsiface.SQSAPI           = sqs.New(awsSession)
+	queueURL                                  = os.Getenv("SNAPSHOT_QUEUE_URL")
+	historyTable                              = os.Getenv("HISTORY_TABLE") // resource history is not recorded if empty
+	resourcesClient gatewayapi.API            = gatewayapi.NewClient(lambda.New(awsSession), "panther-resources-api")
 )
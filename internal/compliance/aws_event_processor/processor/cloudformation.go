@@ -19,6 +19,8 @@ package processor
  */
 
 import (
+	"strings"
+
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/tidwall/gjson"
 	"go.uber.org/zap"
@@ -37,6 +39,14 @@ func classifyCloudFormation(detail gjson.Result, metadata *CloudTrailMetadata) [
 	}
 
 	switch metadata.eventName {
+	case "DetectStackDrift", "DetectStackResourceDrift":
+		// The snapshot poller detects drift on every stack scan, its own drift detections must not
+		// trigger yet another scan
+		if strings.HasSuffix(detail.Get("userIdentity.arn").Str, "/"+schemas.SnapshotPollerSessionName) {
+			zap.L().Debug("cloudformation: ignoring drift detection of the snapshot poller")
+			return nil
+		}
+		fallthrough
 	case "CancelUpdateStack", "CreateChangeSet", "ContinueUpdateRollback", "DeleteStack", "SetStackPolicy", "UpdateStack", "ExecuteChangeSet":
 
 		// stackName can either be the stack name or the stack ARN
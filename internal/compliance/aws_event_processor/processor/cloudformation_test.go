@@ -0,0 +1,72 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+func TestClassifyCloudFormationDetectStackDrift(t *testing.T) {
+	detail := gjson.Parse(`{
+		"userIdentity": {"arn": "arn:aws:sts::111111111111:assumed-role/Admin/alice"},
+		"requestParameters": {"stackName": "iam-roles"}
+	}`)
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "111111111111", eventName: "DetectStackDrift"}
+
+	assert.False(t, isIgnoredEvent("cloudformation.amazonaws.com", "DetectStackDrift"))
+	changes := classifyCloudFormation(detail, metadata)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "arn:aws:cloudformation:us-west-2:111111111111:stack/iam-roles", changes[0].ResourceID)
+	assert.Equal(t, schemas.CloudFormationStackSchema, changes[0].ResourceType)
+	assert.False(t, changes[0].Delete)
+}
+
+func TestClassifyCloudFormationDetectStackResourceDrift(t *testing.T) {
+	detail := gjson.Parse(`{
+		"userIdentity": {"arn": "arn:aws:sts::111111111111:assumed-role/Admin/alice"},
+		"requestParameters": {
+			"stackName": "arn:aws:cloudformation:us-west-2:111111111111:stack/iam-roles/67fc9960-556b-11e9-a978-067794494828",
+			"logicalResourceId": "Administrators"
+		}
+	}`)
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "111111111111", eventName: "DetectStackResourceDrift"}
+
+	changes := classifyCloudFormation(detail, metadata)
+	require.Len(t, changes, 1)
+	assert.Equal(t,
+		"arn:aws:cloudformation:us-west-2:111111111111:stack/iam-roles/67fc9960-556b-11e9-a978-067794494828",
+		changes[0].ResourceID)
+}
+
+// The drift detections started by the snapshot poller itself do not trigger another scan
+func TestClassifyCloudFormationDetectStackDriftSnapshotPoller(t *testing.T) {
+	detail := gjson.Parse(`{
+		"userIdentity": {"arn": "arn:aws:sts::111111111111:assumed-role/PantherAuditRole-us-west-2/panther-snapshot-poller"},
+		"requestParameters": {"stackName": "iam-roles"}
+	}`)
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "111111111111", eventName: "DetectStackDrift"}
+
+	assert.Empty(t, classifyCloudFormation(detail, metadata))
+}
@@ -0,0 +1,91 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+// CloudFront appends the API version to its CloudTrail event names, e.g. "UpdateDistribution2020_05_31"
+var cloudFrontAPIVersion = regexp.MustCompile(`\d{4}_\d{2}_\d{2}$`)
+
+func classifyCloudFront(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
+	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_amazoncloudfront.html
+	distributionARN := arn.ARN{
+		Partition: "aws",
+		Service:   "cloudfront",
+		AccountID: metadata.accountID,
+	}
+
+	eventName := cloudFrontAPIVersion.ReplaceAllString(metadata.eventName, "")
+	switch eventName {
+	case "CreateDistribution", "CreateDistributionWithTags":
+		if responseARN := detail.Get("responseElements.distribution.aRN").Str; responseARN != "" {
+			parsed, err := arn.Parse(responseARN)
+			if err != nil {
+				zap.L().Error("cloudfront: error parsing ARN", zap.String("eventName", metadata.eventName), zap.Error(err))
+				return nil
+			}
+			distributionARN = parsed
+		} else {
+			distributionARN.Resource = "distribution/" + detail.Get("responseElements.distribution.id").Str
+		}
+	case "AssociateAlias":
+		distributionARN.Resource = "distribution/" + detail.Get("requestParameters.targetDistributionId").Str
+	case "DeleteDistribution", "UpdateDistribution":
+		distributionARN.Resource = "distribution/" + detail.Get("requestParameters.id").Str
+	case "TagResource", "UntagResource":
+		parsed, err := arn.Parse(detail.Get("requestParameters.resource").Str)
+		if err != nil {
+			zap.L().Error("cloudfront: error parsing ARN", zap.String("eventName", metadata.eventName), zap.Error(err))
+			return nil
+		}
+		// Streaming distributions can be tagged as well, but we do not scan them
+		if !strings.HasPrefix(parsed.Resource, "distribution/") {
+			return nil
+		}
+		distributionARN = parsed
+	case "CreateInvalidation":
+		// Invalidations flush the edge caches, they don't change the distribution configuration
+		return nil
+	default:
+		zap.L().Info("cloudfront: encountered unknown event name", zap.String("eventName", metadata.eventName))
+		return nil
+	}
+
+	if distributionARN.Resource == "distribution/" {
+		zap.L().Warn("cloudfront: missing distribution id", zap.String("eventName", metadata.eventName), zap.Any("detail", detail))
+		return nil
+	}
+
+	return []*resourceChange{{
+		AwsAccountID: metadata.accountID,
+		Delete:       eventName == "DeleteDistribution",
+		EventName:    metadata.eventName,
+		ResourceID:   distributionARN.String(),
+		ResourceType: schemas.CloudFrontDistributionSchema,
+	}}
+}
@@ -0,0 +1,76 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+func TestClassifyCloudFrontCreateDistribution(t *testing.T) {
+	detail := gjson.Parse(`{"responseElements": {"distribution": {
+"aRN": "arn:aws:cloudfront::111111111111:distribution/EDFDVBD6EXAMPLE",
+"id": "EDFDVBD6EXAMPLE"
+}}}`)
+	metadata := &CloudTrailMetadata{region: "us-east-1", accountID: "111111111111", eventName: "CreateDistribution2020_05_31"}
+
+	changes := classifyCloudFront(detail, metadata)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "arn:aws:cloudfront::111111111111:distribution/EDFDVBD6EXAMPLE", changes[0].ResourceID)
+	assert.Equal(t, schemas.CloudFrontDistributionSchema, changes[0].ResourceType)
+	assert.False(t, changes[0].Delete)
+}
+
+func TestClassifyCloudFrontDeleteDistribution(t *testing.T) {
+	detail := gjson.Parse(`{"requestParameters": {"id": "EDFDVBD6EXAMPLE", "ifMatch": "E2QWRUHEXAMPLE"}}`)
+	metadata := &CloudTrailMetadata{region: "us-east-1", accountID: "111111111111", eventName: "DeleteDistribution2020_05_31"}
+
+	changes := classifyCloudFront(detail, metadata)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "arn:aws:cloudfront::111111111111:distribution/EDFDVBD6EXAMPLE", changes[0].ResourceID)
+	assert.True(t, changes[0].Delete)
+}
+
+func TestClassifyCloudFrontTagResource(t *testing.T) {
+	metadata := &CloudTrailMetadata{region: "us-east-1", accountID: "111111111111", eventName: "TagResource"}
+
+	changes := classifyCloudFront(gjson.Parse(`{"requestParameters": {
+"resource": "arn:aws:cloudfront::111111111111:distribution/EDFDVBD6EXAMPLE"
+}}`), metadata)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "arn:aws:cloudfront::111111111111:distribution/EDFDVBD6EXAMPLE", changes[0].ResourceID)
+
+	// Streaming distributions are not scanned
+	assert.Empty(t, classifyCloudFront(gjson.Parse(`{"requestParameters": {
+"resource": "arn:aws:cloudfront::111111111111:streaming-distribution/EDFDVBD6EXAMPLE"
+}}`), metadata))
+}
+
+func TestClassifyCloudFrontIgnored(t *testing.T) {
+	metadata := &CloudTrailMetadata{region: "us-east-1", accountID: "111111111111", eventName: "CreateInvalidation2020_05_31"}
+	assert.Empty(t, classifyCloudFront(gjson.Parse(`{"requestParameters": {"distributionId": "EDFDVBD6EXAMPLE"}}`), metadata))
+
+	metadata.eventName = "UpdateDistribution"
+	assert.Empty(t, classifyCloudFront(gjson.Parse(`{}`), metadata))
+}
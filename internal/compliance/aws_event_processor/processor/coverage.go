@@ -0,0 +1,54 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// ResourceTypeCoverage describes how changes to a resource type are detected in real time
+type ResourceTypeCoverage struct {
+	ResourceType string
+	// The CloudTrail event source of the service owning the resource type, empty if unknown
+	EventSource string
+	// True if the events of the service are handled by a dedicated classifier
+	Classified bool
+	// True if the resources are scanned when the generic classifier finds their ARN in an event of a
+	// service without a dedicated classifier
+	GenericARN bool
+}
+
+// Coverage reports how changes to each of the given resource types are detected in real time.
+//
+// Resource types of services without a dedicated classifier are at best scanned by the generic classifier,
+// otherwise they are only updated by full scans.
+func Coverage(resourceTypes []string) []ResourceTypeCoverage {
+	sources := make(map[string]resourceTypeSource, len(resourceTypeSources))
+	for _, source := range resourceTypeSources {
+		sources[source.resourceType] = source
+	}
+
+	result := make([]ResourceTypeCoverage, 0, len(resourceTypes))
+	for _, resourceType := range resourceTypes {
+		coverage := ResourceTypeCoverage{ResourceType: resourceType}
+		if source, ok := sources[resourceType]; ok {
+			_, coverage.Classified = classifiers[source.eventSource]
+			coverage.EventSource = source.eventSource
+			coverage.GenericARN = source.arnResource != nil
+		}
+		result = append(result, coverage)
+	}
+	return result
+}
@@ -0,0 +1,78 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	awspoller "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws"
+)
+
+// Every resource type scanned by the snapshot poller must be listed, otherwise the coverage report
+// cannot tell where its changes come from
+func TestResourceTypeSourcesComplete(t *testing.T) {
+	sources := make(map[string]bool)
+	for _, source := range resourceTypeSources {
+		assert.False(t, sources[source.resourceType], "%s listed twice", source.resourceType)
+		sources[source.resourceType] = true
+	}
+	for resourceType := range awspoller.IndividualARNResourcePollers {
+		assert.True(t, sources[resourceType], "%s is not listed", resourceType)
+	}
+	for resourceType := range awspoller.IndividualResourcePollers {
+		assert.True(t, sources[resourceType], "%s is not listed", resourceType)
+	}
+	for resourceType := range awspoller.ServicePollers {
+		assert.True(t, sources[resourceType], "%s is not listed", resourceType)
+	}
+}
+
+func TestCoverage(t *testing.T) {
+	report := Coverage([]string{schemas.S3BucketSchema, schemas.PasswordPolicySchema, "AWS.Example.Resource"})
+	require.Len(t, report, 3)
+	assert.Equal(t, ResourceTypeCoverage{
+		ResourceType: schemas.S3BucketSchema,
+		EventSource:  "s3.amazonaws.com",
+		Classified:   true,
+		GenericARN:   true,
+	}, report[0])
+	assert.Equal(t, ResourceTypeCoverage{
+		ResourceType: schemas.PasswordPolicySchema,
+		EventSource:  "iam.amazonaws.com",
+		Classified:   true,
+	}, report[1])
+	assert.Equal(t, ResourceTypeCoverage{ResourceType: "AWS.Example.Resource"}, report[2])
+}
+
+func TestCoverageUnclassified(t *testing.T) {
+	classifier := classifiers["sqs.amazonaws.com"]
+	delete(classifiers, "sqs.amazonaws.com")
+	defer func() { classifiers["sqs.amazonaws.com"] = classifier }()
+
+	report := Coverage([]string{schemas.SqsQueueSchema})
+	assert.Equal(t, []ResourceTypeCoverage{{
+		ResourceType: schemas.SqsQueueSchema,
+		EventSource:  "sqs.amazonaws.com",
+		GenericARN:   true,
+	}}, report)
+}
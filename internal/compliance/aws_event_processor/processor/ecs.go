@@ -32,7 +32,21 @@ import (
 func classifyECS(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
 	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_amazonelasticcontainerservice.html
 	var clusterARN string
+	var changes []*resourceChange
 	switch metadata.eventName {
+	case "RegisterTaskDefinition", "DeregisterTaskDefinition":
+		taskDefinitionARN := detail.Get("responseElements.taskDefinition.taskDefinitionArn").Str
+		if taskDefinitionARN == "" {
+			zap.L().Warn("ecs: missing task definition arn", zap.String("eventName", metadata.eventName))
+			return nil
+		}
+		return []*resourceChange{{
+			AwsAccountID: metadata.accountID,
+			Delete:       metadata.eventName == "DeregisterTaskDefinition",
+			EventName:    metadata.eventName,
+			ResourceID:   taskDefinitionARN,
+			ResourceType: schemas.EcsTaskDefinitionSchema,
+		}}
 	case "CreateTaskSet", "DeleteCluster", "DeleteTaskSet", "UpdateServicePrimaryTaskSet", "UpdateTaskSet":
 		clusterARN = detail.Get("requestParameters.cluster").Str
 	case "CreateService", "DeleteAttributes", "DeleteService", "DeregisterContainerInstance", "PutAttributes",
@@ -47,6 +61,17 @@ func classifyECS(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceC
 		if clusterARN == "" {
 			clusterARN = "default"
 		}
+
+		// Services are tracked as resources of their own, but also show up in their cluster's snapshot
+		if serviceARN := detail.Get("responseElements.service.serviceArn").Str; serviceARN != "" {
+			changes = append(changes, &resourceChange{
+				AwsAccountID: metadata.accountID,
+				Delete:       metadata.eventName == "DeleteService",
+				EventName:    metadata.eventName,
+				ResourceID:   serviceARN,
+				ResourceType: schemas.EcsServiceSchema,
+			})
+		}
 	case "CreateCluster":
 		clusterARN = detail.Get("responseElements.cluster.clusterArn").Str
 	case "TagResource", "UntagResource":
@@ -68,15 +93,33 @@ func classifyECS(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceC
 		if strings.HasPrefix(parsed.Resource, "cluster") {
 			break
 		}
+		if strings.HasPrefix(parsed.Resource, "task-definition/") {
+			return []*resourceChange{{
+				AwsAccountID: metadata.accountID,
+				EventName:    metadata.eventName,
+				ResourceID:   parsed.String(),
+				ResourceType: schemas.EcsTaskDefinitionSchema,
+			}}
+		}
+		if strings.HasPrefix(parsed.Resource, "service/") {
+			// Service tags are also reported on the cluster's snapshot, but the ARN does not always
+			// name the cluster so the cluster scan below is still needed
+			changes = append(changes, &resourceChange{
+				AwsAccountID: metadata.accountID,
+				EventName:    metadata.eventName,
+				ResourceID:   parsed.String(),
+				ResourceType: schemas.EcsServiceSchema,
+			})
+		}
 
 		// If it wasn't a cluster, we have to scan the whole region.
-		return []*resourceChange{{
+		return append(changes, &resourceChange{
 			AwsAccountID: metadata.accountID,
 			Delete:       false,
 			EventName:    metadata.eventName,
 			Region:       metadata.region,
 			ResourceType: schemas.EcsClusterSchema,
-		}}
+		})
 	default:
 		zap.L().Info("ecs: encountered unknown event name", zap.String("eventName", metadata.eventName))
 		return nil
@@ -85,7 +128,7 @@ func classifyECS(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceC
 	// If clusterARN is empty, we failed to parse the ARN out at some point despite trying
 	if clusterARN == "" {
 		zap.L().Error("ecs: known event name, but still failed to parse clusterARN", zap.String("eventName", metadata.eventName))
-		return nil
+		return changes
 	}
 
 	// All ECS Cluster API calls can be made with the full ARN or just the cluster name as the 'cluster' parameter.
@@ -102,11 +145,11 @@ func classifyECS(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceC
 		}.String()
 	}
 
-	return []*resourceChange{{
+	return append(changes, &resourceChange{
 		AwsAccountID: metadata.accountID,
 		Delete:       metadata.eventName == "DeleteCluster",
 		EventName:    metadata.eventName,
 		ResourceID:   clusterARN,
 		ResourceType: schemas.EcsClusterSchema,
-	}}
+	})
 }
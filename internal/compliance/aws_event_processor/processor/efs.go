@@ -0,0 +1,85 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+func classifyEFS(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
+	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_amazonelasticfilesystem.html
+	var fileSystemID string
+	switch metadata.eventName {
+	case "CreateFileSystem":
+		fileSystemID = detail.Get("responseElements.fileSystemId").Str
+	case "CreateMountTarget",
+		"CreateTags",
+		"DeleteFileSystem",
+		"DeleteFileSystemPolicy",
+		"DeleteTags",
+		"PutBackupPolicy",
+		"PutFileSystemPolicy",
+		"PutLifecycleConfiguration",
+		"UpdateFileSystem":
+		fileSystemID = detail.Get("requestParameters.fileSystemId").Str
+	case "TagResource", "UntagResource":
+		// Access points can be tagged as well, but they are not part of the file system snapshot
+		fileSystemID = detail.Get("requestParameters.resourceId").Str
+		if !strings.HasPrefix(fileSystemID, "fs-") {
+			return nil
+		}
+	case "DeleteMountTarget", "ModifyMountTargetSecurityGroups":
+		// Only the mount target ID is present, so we don't know which file system it belongs to
+		return []*resourceChange{{
+			AwsAccountID: metadata.accountID,
+			Delete:       false,
+			EventName:    metadata.eventName,
+			Region:       metadata.region,
+			ResourceType: schemas.EfsFileSystemSchema,
+		}}
+	default:
+		zap.L().Info("efs: encountered unknown event name", zap.String("eventName", metadata.eventName))
+		return nil
+	}
+
+	if fileSystemID == "" {
+		zap.L().Warn("efs: missing file system id", zap.String("eventName", metadata.eventName), zap.Any("detail", detail))
+		return nil
+	}
+
+	return []*resourceChange{{
+		AwsAccountID: metadata.accountID,
+		Delete:       metadata.eventName == "DeleteFileSystem",
+		EventName:    metadata.eventName,
+		ResourceID: arn.ARN{
+			Partition: "aws",
+			Service:   "elasticfilesystem",
+			Region:    metadata.region,
+			AccountID: metadata.accountID,
+			Resource:  "file-system/" + fileSystemID,
+		}.String(),
+		ResourceType: schemas.EfsFileSystemSchema,
+	}}
+}
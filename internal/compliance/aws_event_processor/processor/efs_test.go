@@ -0,0 +1,69 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+func TestClassifyEFSPutFileSystemPolicy(t *testing.T) {
+	detail := gjson.Parse(`{"requestParameters": {"fileSystemId": "fs-12345678"}}`)
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "111111111111", eventName: "PutFileSystemPolicy"}
+
+	changes := classifyEFS(detail, metadata)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "arn:aws:elasticfilesystem:us-west-2:111111111111:file-system/fs-12345678", changes[0].ResourceID)
+	assert.Equal(t, schemas.EfsFileSystemSchema, changes[0].ResourceType)
+	assert.False(t, changes[0].Delete)
+}
+
+func TestClassifyEFSDeleteFileSystem(t *testing.T) {
+	detail := gjson.Parse(`{"requestParameters": {"fileSystemId": "fs-12345678"}}`)
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "111111111111", eventName: "DeleteFileSystem"}
+
+	changes := classifyEFS(detail, metadata)
+	require.Len(t, changes, 1)
+	assert.True(t, changes[0].Delete)
+}
+
+// Mount target events do not include the file system, so the whole region is rescanned
+func TestClassifyEFSDeleteMountTarget(t *testing.T) {
+	detail := gjson.Parse(`{"requestParameters": {"mountTargetId": "fsmt-12345678"}}`)
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "111111111111", eventName: "DeleteMountTarget"}
+
+	changes := classifyEFS(detail, metadata)
+	require.Len(t, changes, 1)
+	assert.Empty(t, changes[0].ResourceID)
+	assert.Equal(t, "us-west-2", changes[0].Region)
+	assert.Equal(t, schemas.EfsFileSystemSchema, changes[0].ResourceType)
+}
+
+func TestClassifyEFSTagAccessPoint(t *testing.T) {
+	detail := gjson.Parse(`{"requestParameters": {"resourceId": "fsap-12345678"}}`)
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "111111111111", eventName: "TagResource"}
+
+	assert.False(t, isIgnoredEvent("elasticfilesystem.amazonaws.com", "TagResource"))
+	assert.Empty(t, classifyEFS(detail, metadata))
+}
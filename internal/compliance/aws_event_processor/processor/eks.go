@@ -0,0 +1,118 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+func classifyEKS(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
+	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_amazonelasticcontainerserviceforkubernetes.html
+	var clusterName string
+	var changes []*resourceChange
+	switch metadata.eventName {
+	case "CreateCluster", "DeleteCluster":
+		clusterName = detail.Get("requestParameters.name").Str
+	case "AssociateEncryptionConfig",
+		"CreateFargateProfile",
+		"DeleteFargateProfile",
+		"UpdateClusterConfig",
+		"UpdateClusterVersion":
+		clusterName = detail.Get("requestParameters.name").Str
+		if clusterName == "" {
+			clusterName = detail.Get("requestParameters.clusterName").Str
+		}
+	case "CreateNodegroup", "DeleteNodegroup", "UpdateNodegroupConfig", "UpdateNodegroupVersion":
+		// Node groups are tracked as resources of their own, but also show up in their cluster's snapshot
+		clusterName = detail.Get("requestParameters.clusterName").Str
+		nodegroupChange := &resourceChange{
+			AwsAccountID: metadata.accountID,
+			Delete:       metadata.eventName == "DeleteNodegroup",
+			EventName:    metadata.eventName,
+			ResourceType: schemas.EksNodeGroupSchema,
+		}
+		// Node group ARNs end in a generated ID, so when the response is missing we scan the region
+		if nodegroupARN := detail.Get("responseElements.nodegroup.nodegroupArn").Str; nodegroupARN != "" {
+			nodegroupChange.ResourceID = nodegroupARN
+		} else {
+			nodegroupChange.Delete = false
+			nodegroupChange.Region = metadata.region
+		}
+		changes = append(changes, nodegroupChange)
+	case "TagResource", "UntagResource":
+		resourceARN := detail.Get("requestParameters.resourceArn").Str
+		parsed, err := arn.Parse(resourceARN)
+		if err != nil {
+			zap.L().Warn("eks: unable to parse resource ARN",
+				zap.String("eventName", metadata.eventName), zap.String("resourceARN", resourceARN))
+			return nil
+		}
+		switch {
+		case strings.HasPrefix(parsed.Resource, "cluster/"):
+			return []*resourceChange{{
+				AwsAccountID: metadata.accountID,
+				EventName:    metadata.eventName,
+				ResourceID:   resourceARN,
+				ResourceType: schemas.EksClusterSchema,
+			}}
+		case strings.HasPrefix(parsed.Resource, "nodegroup/"):
+			// Node group tags are also reported on the cluster's snapshot
+			changes = append(changes, &resourceChange{
+				AwsAccountID: metadata.accountID,
+				EventName:    metadata.eventName,
+				ResourceID:   resourceARN,
+				ResourceType: schemas.EksNodeGroupSchema,
+			})
+			clusterName = strings.Split(parsed.Resource, "/")[1]
+		case strings.HasPrefix(parsed.Resource, "fargateprofile/"):
+			clusterName = strings.Split(parsed.Resource, "/")[1]
+		default:
+			zap.L().Info("eks: unsupported resource type", zap.String("resourceARN", resourceARN))
+			return nil
+		}
+	default:
+		zap.L().Info("eks: encountered unknown event name", zap.String("eventName", metadata.eventName))
+		return nil
+	}
+
+	if clusterName == "" {
+		zap.L().Warn("eks: missing cluster name", zap.String("eventName", metadata.eventName), zap.Any("detail", detail))
+		return changes
+	}
+
+	return append(changes, &resourceChange{
+		AwsAccountID: metadata.accountID,
+		Delete:       metadata.eventName == "DeleteCluster",
+		EventName:    metadata.eventName,
+		ResourceID: arn.ARN{
+			Partition: "aws",
+			Service:   "eks",
+			Region:    metadata.region,
+			AccountID: metadata.accountID,
+			Resource:  "cluster/" + clusterName,
+		}.String(),
+		ResourceType: schemas.EksClusterSchema,
+	})
+}
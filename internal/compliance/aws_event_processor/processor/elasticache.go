@@ -0,0 +1,84 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+func classifyElastiCache(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
+	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_amazonelasticache.html
+	var replicationGroupID string
+	switch metadata.eventName {
+	case "CreateReplicationGroup",
+		"DecreaseReplicaCount",
+		"DeleteReplicationGroup",
+		"IncreaseReplicaCount",
+		"ModifyReplicationGroup",
+		"ModifyReplicationGroupShardConfiguration",
+		"TestFailover":
+		replicationGroupID = detail.Get("requestParameters.replicationGroupId").Str
+	case "CreateCacheCluster":
+		// Only clusters created as members of a replication group are scanned
+		replicationGroupID = detail.Get("requestParameters.replicationGroupId").Str
+		if replicationGroupID == "" {
+			return nil
+		}
+	case "AddTagsToResource", "RemoveTagsFromResource":
+		// Cache clusters and snapshots can be tagged as well, but we do not scan them
+		resourceARN, err := arn.Parse(detail.Get("requestParameters.resourceName").Str)
+		if err != nil {
+			zap.L().Error("elasticache: error parsing ARN", zap.String("eventName", metadata.eventName), zap.Error(err))
+			return nil
+		}
+		if !strings.HasPrefix(resourceARN.Resource, "replicationgroup:") {
+			return nil
+		}
+		replicationGroupID = strings.TrimPrefix(resourceARN.Resource, "replicationgroup:")
+	default:
+		zap.L().Info("elasticache: encountered unknown event name", zap.String("eventName", metadata.eventName))
+		return nil
+	}
+
+	if replicationGroupID == "" {
+		zap.L().Warn("elasticache: missing replication group id",
+			zap.String("eventName", metadata.eventName), zap.Any("detail", detail))
+		return nil
+	}
+
+	return []*resourceChange{{
+		AwsAccountID: metadata.accountID,
+		Delete:       metadata.eventName == "DeleteReplicationGroup",
+		EventName:    metadata.eventName,
+		ResourceID: arn.ARN{
+			Partition: "aws",
+			Service:   "elasticache",
+			Region:    metadata.region,
+			AccountID: metadata.accountID,
+			Resource:  "replicationgroup:" + replicationGroupID,
+		}.String(),
+		ResourceType: schemas.ElastiCacheReplicationGroupSchema,
+	}}
+}
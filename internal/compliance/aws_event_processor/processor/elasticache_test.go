@@ -0,0 +1,58 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+func TestClassifyElastiCacheModifyReplicationGroup(t *testing.T) {
+	detail := gjson.Parse(`{"requestParameters": {"replicationGroupId": "example-group"}}`)
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "111111111111", eventName: "ModifyReplicationGroup"}
+
+	changes := classifyElastiCache(detail, metadata)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "arn:aws:elasticache:us-west-2:111111111111:replicationgroup:example-group", changes[0].ResourceID)
+	assert.Equal(t, schemas.ElastiCacheReplicationGroupSchema, changes[0].ResourceType)
+	assert.False(t, changes[0].Delete)
+}
+
+func TestClassifyElastiCacheAddTags(t *testing.T) {
+	detail := gjson.Parse(`{"requestParameters": {
+"resourceName": "arn:aws:elasticache:us-west-2:111111111111:replicationgroup:example-group"
+}}`)
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "111111111111", eventName: "AddTagsToResource"}
+
+	changes := classifyElastiCache(detail, metadata)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "arn:aws:elasticache:us-west-2:111111111111:replicationgroup:example-group", changes[0].ResourceID)
+}
+
+func TestClassifyElastiCacheStandaloneCluster(t *testing.T) {
+	detail := gjson.Parse(`{"requestParameters": {"cacheClusterId": "example-cluster"}}`)
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "111111111111", eventName: "CreateCacheCluster"}
+
+	assert.Empty(t, classifyElastiCache(detail, metadata))
+}
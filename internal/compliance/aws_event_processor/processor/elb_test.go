@@ -0,0 +1,79 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+func TestClassifyELBClassic(t *testing.T) {
+	detail := gjson.Parse(`{
+"apiVersion": "2012-06-01",
+"requestParameters": {"loadBalancerName": "example-classic-lb", "healthCheck": {"target": "HTTP:80/"}}
+}`)
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "111111111111", eventName: "ConfigureHealthCheck"}
+
+	changes := classifyELBV2(detail, metadata)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "arn:aws:elasticloadbalancing:us-west-2:111111111111:loadbalancer/example-classic-lb", changes[0].ResourceID)
+	assert.Equal(t, schemas.ElbLoadBalancerSchema, changes[0].ResourceType)
+	assert.False(t, changes[0].Delete)
+}
+
+func TestClassifyELBClassicDelete(t *testing.T) {
+	// Older events may not have an apiVersion, the request parameters give them away
+	detail := gjson.Parse(`{"requestParameters": {"loadBalancerName": "example-classic-lb"}}`)
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "111111111111", eventName: "DeleteLoadBalancer"}
+
+	changes := classifyELBV2(detail, metadata)
+	require.Len(t, changes, 1)
+	assert.Equal(t, schemas.ElbLoadBalancerSchema, changes[0].ResourceType)
+	assert.True(t, changes[0].Delete)
+}
+
+func TestClassifyELBClassicTags(t *testing.T) {
+	detail := gjson.Parse(`{
+"apiVersion": "2012-06-01",
+"requestParameters": {"loadBalancerNames": ["lb-one", "lb-two"], "tags": [{"key": "Key1", "value": "Value1"}]}
+}`)
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "111111111111", eventName: "AddTags"}
+
+	changes := classifyELBV2(detail, metadata)
+	require.Len(t, changes, 2)
+	assert.Equal(t, "arn:aws:elasticloadbalancing:us-west-2:111111111111:loadbalancer/lb-one", changes[0].ResourceID)
+	assert.Equal(t, "arn:aws:elasticloadbalancing:us-west-2:111111111111:loadbalancer/lb-two", changes[1].ResourceID)
+}
+
+func TestClassifyELBV2NotClassic(t *testing.T) {
+	detail := gjson.Parse(`{
+"apiVersion": "2015-12-01",
+"requestParameters": {"loadBalancerArn": "arn:aws:elasticloadbalancing:us-west-2:111111111111:loadbalancer/app/example/1111"}
+}`)
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "111111111111", eventName: "DeleteLoadBalancer"}
+
+	changes := classifyELBV2(detail, metadata)
+	require.Len(t, changes, 1)
+	assert.Equal(t, schemas.Elbv2LoadBalancerSchema, changes[0].ResourceType)
+}
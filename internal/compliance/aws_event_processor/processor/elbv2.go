@@ -30,6 +30,10 @@ import (
 
 func classifyELBV2(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
 	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_elasticloadbalancingv2.html
+	if isClassicELBEvent(detail) {
+		return classifyELB(detail, metadata)
+	}
+
 	var parseErr error
 	lbARN := arn.ARN{
 		Partition: "aws",
@@ -87,13 +91,7 @@ func classifyELBV2(detail gjson.Result, metadata *CloudTrailMetadata) []*resourc
 		return changes
 	case "CreateListener", "DeleteLoadBalancer", "ModifyLoadBalancerAttributes", "SetIpAddressType", "SetSecurityGroups", "SetSubnets":
 		lbARN, parseErr = arn.Parse(detail.Get("requestParameters.loadBalancerArn").Str)
-		// If no LB ARN is present, this may be a classic load balancer. We don't support classic
-		// load balancers. We can tell the difference based on the structure of the request parameters
 		if parseErr != nil {
-			lbName := detail.Get("requestParameters.loadBalancerName")
-			if lbName.Exists() {
-				return nil
-			}
 			zap.L().Error("elbv2: error parsing ARN", zap.String("eventName", metadata.eventName), zap.Any("event", metadata), zap.Error(parseErr))
 			return nil
 		}
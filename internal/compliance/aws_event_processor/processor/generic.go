@@ -0,0 +1,201 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/tidwall/gjson"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+// The most ARNs extracted from a single event by the generic classifier, events referencing more
+// resources than this are most likely bulk operations better handled by the next full scan
+const maxGenericARNs = 20
+
+// resourceTypeSource describes how changes to a resource type show up in CloudTrail
+type resourceTypeSource struct {
+	resourceType string
+	// The CloudTrail event source of the service owning the resource type
+	eventSource string
+	// Matches the resource part of the ARNs of this resource type. The match is the resource part of
+	// the resource ID, so ARNs of sub-resources (e.g. a DynamoDB stream) map to their parent resource.
+	//
+	// Nil if the resource type is not identified by its ARN or should not be scanned by the generic
+	// classifier.
+	arnResource *regexp.Regexp
+}
+
+// resourceTypeSources lists every AWS resource type scanned by the snapshot poller
+var resourceTypeSources = []resourceTypeSource{
+	{schemas.AcmCertificateSchema, "acm.amazonaws.com", regexp.MustCompile(`^certificate/.+`)},
+	{schemas.ApiGatewayRestApiSchema, "apigateway.amazonaws.com", regexp.MustCompile(`^/restapis/[^/]+`)},
+	{schemas.ApiGatewayV2ApiSchema, "apigateway.amazonaws.com", regexp.MustCompile(`^/apis/[^/]+`)},
+	{schemas.CloudFormationStackSchema, "cloudformation.amazonaws.com", regexp.MustCompile(`^stack/.+`)},
+	{schemas.CloudFrontDistributionSchema, "cloudfront.amazonaws.com", regexp.MustCompile(`^distribution/.+`)},
+	{schemas.CloudTrailSchema, "cloudtrail.amazonaws.com", regexp.MustCompile(`^trail/.+`)},
+	{schemas.CloudWatchLogGroupSchema, "logs.amazonaws.com", regexp.MustCompile(`^log-group:[^:]+`)},
+	{schemas.ConfigServiceSchema, "config.amazonaws.com", nil},
+	{schemas.DynamoDBTableSchema, "dynamodb.amazonaws.com", regexp.MustCompile(`^table/[^/]+`)},
+	{schemas.Ec2AmiSchema, "ec2.amazonaws.com", regexp.MustCompile(`^image/.+`)},
+	{schemas.Ec2InstanceSchema, "ec2.amazonaws.com", regexp.MustCompile(`^instance/.+`)},
+	{schemas.Ec2NetworkAclSchema, "ec2.amazonaws.com", regexp.MustCompile(`^network-acl/.+`)},
+	{schemas.Ec2SecurityGroupSchema, "ec2.amazonaws.com", regexp.MustCompile(`^security-group/.+`)},
+	{schemas.Ec2VolumeSchema, "ec2.amazonaws.com", regexp.MustCompile(`^volume/.+`)},
+	{schemas.Ec2VpcSchema, "ec2.amazonaws.com", regexp.MustCompile(`^vpc/.+`)},
+	{schemas.EcrRepositorySchema, "ecr.amazonaws.com", regexp.MustCompile(`^repository/.+`)},
+	{schemas.EcsClusterSchema, "ecs.amazonaws.com", regexp.MustCompile(`^cluster/[^/]+`)},
+	{schemas.EcsServiceSchema, "ecs.amazonaws.com", regexp.MustCompile(`^service/.+`)},
+	{schemas.EcsTaskDefinitionSchema, "ecs.amazonaws.com", regexp.MustCompile(`^task-definition/.+`)},
+	{schemas.EfsFileSystemSchema, "elasticfilesystem.amazonaws.com", regexp.MustCompile(`^file-system/.+`)},
+	{schemas.EksClusterSchema, "eks.amazonaws.com", regexp.MustCompile(`^cluster/[^/]+`)},
+	{schemas.EksNodeGroupSchema, "eks.amazonaws.com", regexp.MustCompile(`^nodegroup/.+`)},
+	{schemas.ElastiCacheReplicationGroupSchema, "elasticache.amazonaws.com", regexp.MustCompile(`^replicationgroup:.+`)},
+	{schemas.ElbLoadBalancerSchema, "elasticloadbalancing.amazonaws.com", regexp.MustCompile(`^loadbalancer/[^/]+$`)},
+	{schemas.Elbv2LoadBalancerSchema, "elasticloadbalancing.amazonaws.com", regexp.MustCompile(`^loadbalancer/app/[^/]+/[^/]+`)},
+	{schemas.GuardDutySchema, "guardduty.amazonaws.com", nil},
+	{schemas.IAMGroupSchema, "iam.amazonaws.com", regexp.MustCompile(`^group/.+`)},
+	{schemas.IAMPolicySchema, "iam.amazonaws.com", regexp.MustCompile(`^policy/.+`)},
+	{schemas.IAMRoleSchema, "iam.amazonaws.com", regexp.MustCompile(`^role/.+`)},
+	{schemas.IAMRootUserSchema, "iam.amazonaws.com", regexp.MustCompile(`^root$`)},
+	{schemas.IAMUserSchema, "iam.amazonaws.com", regexp.MustCompile(`^user/.+`)},
+	{schemas.KmsKeySchema, "kms.amazonaws.com", regexp.MustCompile(`^key/.+`)},
+	{schemas.LambdaFunctionSchema, "lambda.amazonaws.com", regexp.MustCompile(`^function:[^:]+`)},
+	{schemas.OpenSearchDomainSchema, "es.amazonaws.com", regexp.MustCompile(`^domain/[^/]+`)},
+	// Organization resources are only scanned for integrations with organization scanning enabled
+	{schemas.OrganizationsAccountSchema, "organizations.amazonaws.com", nil},
+	{schemas.OrganizationsDelegatedAdministratorSchema, "organizations.amazonaws.com", nil},
+	{schemas.OrganizationsOrganizationalUnitSchema, "organizations.amazonaws.com", nil},
+	{schemas.OrganizationsServiceControlPolicySchema, "organizations.amazonaws.com", nil},
+	{schemas.OrganizationsTagPolicySchema, "organizations.amazonaws.com", nil},
+	{schemas.PasswordPolicySchema, "iam.amazonaws.com", nil},
+	{schemas.RDSClusterSchema, "rds.amazonaws.com", regexp.MustCompile(`^cluster:.+`)},
+	{schemas.RDSClusterSnapshotSchema, "rds.amazonaws.com", regexp.MustCompile(`^cluster-snapshot:.+`)},
+	{schemas.RDSInstanceSchema, "rds.amazonaws.com", regexp.MustCompile(`^db:.+`)},
+	{schemas.RDSSnapshotSchema, "rds.amazonaws.com", regexp.MustCompile(`^snapshot:.+`)},
+	{schemas.RedshiftClusterSchema, "redshift.amazonaws.com", regexp.MustCompile(`^cluster:.+`)},
+	{schemas.Route53HostedZoneSchema, "route53.amazonaws.com", regexp.MustCompile(`^hostedzone/.+`)},
+	{schemas.S3BucketSchema, "s3.amazonaws.com", regexp.MustCompile(`^[^/]+`)},
+	{schemas.SecretsManagerSecretSchema, "secretsmanager.amazonaws.com", regexp.MustCompile(`^secret:.+`)},
+	{schemas.SnsTopicSchema, "sns.amazonaws.com", regexp.MustCompile(`^[^:]+`)},
+	{schemas.SqsQueueSchema, "sqs.amazonaws.com", regexp.MustCompile(`^[^:]+$`)},
+	{schemas.SsmParameterSchema, "ssm.amazonaws.com", regexp.MustCompile(`^parameter/.+`)},
+	{schemas.WafWebAclSchema, "waf.amazonaws.com", regexp.MustCompile(`^webacl/.+`)},
+	{schemas.WafRegionalWebAclSchema, "waf-regional.amazonaws.com", regexp.MustCompile(`^webacl/.+`)},
+}
+
+// arnResourceTypes maps the service of an ARN to the resource types identified by ARNs of that service
+var arnResourceTypes = func() map[string][]resourceTypeSource {
+	result := make(map[string][]resourceTypeSource)
+	for _, source := range resourceTypeSources {
+		if source.arnResource == nil {
+			continue
+		}
+		service := strings.TrimSuffix(source.eventSource, ".amazonaws.com")
+		result[service] = append(result[service], source)
+	}
+	return result
+}()
+
+// classifyGeneric is the classifier for events from services without a dedicated classifier.
+//
+// It scans the resources of supported resource types whose ARNs appear in the resources or the request
+// parameters of the event, so changes made through other services (e.g. tagging resources with the
+// resource groups tagging API) are picked up before the next full scan.
+func classifyGeneric(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
+	var changes []*resourceChange
+	seen := make(map[string]struct{})
+	for _, resourceARN := range extractARNs(detail) {
+		parsed, err := arn.Parse(resourceARN)
+		if err != nil {
+			continue
+		}
+		// Resources in other accounts are scanned by the integrations of those accounts
+		if parsed.AccountID != "" && parsed.AccountID != metadata.accountID {
+			continue
+		}
+		resourceID, resourceType := classifyARN(parsed)
+		if resourceType == "" {
+			continue
+		}
+		if _, ok := seen[resourceID]; ok {
+			continue
+		}
+		seen[resourceID] = struct{}{}
+		changes = append(changes, &resourceChange{
+			AwsAccountID: metadata.accountID,
+			Delete:       false,
+			EventName:    metadata.eventName,
+			ResourceID:   resourceID,
+			ResourceType: resourceType,
+		})
+	}
+	return changes
+}
+
+// classifyARN returns the ID and type of the supported resource identified by an ARN, or empty strings if
+// the ARN does not belong to a supported resource type
+func classifyARN(parsed arn.ARN) (resourceID string, resourceType string) {
+	for _, source := range arnResourceTypes[parsed.Service] {
+		if resource := source.arnResource.FindString(parsed.Resource); resource != "" {
+			parsed.Resource = resource
+			return parsed.String(), source.resourceType
+		}
+	}
+	return "", ""
+}
+
+// extractARNs returns the ARNs listed in the resources of an event, followed by any ARNs found in the
+// request parameters
+func extractARNs(detail gjson.Result) []string {
+	var arns []string
+	add := func(value string) bool {
+		if strings.HasPrefix(value, "arn:") {
+			arns = append(arns, value)
+		}
+		return len(arns) < maxGenericARNs
+	}
+
+	detail.Get("resources").ForEach(func(_, resource gjson.Result) bool {
+		return add(resource.Get("ARN").Str)
+	})
+
+	var walk func(value gjson.Result) bool
+	walk = func(value gjson.Result) bool {
+		if value.IsObject() || value.IsArray() {
+			keepGoing := true
+			value.ForEach(func(_, nested gjson.Result) bool {
+				keepGoing = walk(nested)
+				return keepGoing
+			})
+			return keepGoing
+		}
+		if value.Type == gjson.String {
+			return add(value.Str)
+		}
+		return true
+	}
+	if len(arns) < maxGenericARNs {
+		walk(detail.Get("requestParameters"))
+	}
+	return arns
+}
@@ -0,0 +1,171 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+// Tagging resources through the resource groups tagging API scans the tagged resources
+func TestClassifyGenericTagResources(t *testing.T) {
+	detail := gjson.Parse(`{
+		"requestParameters": {
+			"resourceARNList": [
+				"arn:aws:s3:::example-bucket",
+				"arn:aws:dynamodb:us-west-2:111111111111:table/example-table",
+				"arn:aws:lambda:us-west-2:111111111111:function:example-function:live",
+				"arn:aws:s3:::example-bucket"
+			],
+			"tags": {"team": "security"}
+		}
+	}`)
+	metadata := &CloudTrailMetadata{
+		region:      "us-west-2",
+		accountID:   "111111111111",
+		eventName:   "TagResources",
+		eventSource: "tagging.amazonaws.com",
+	}
+
+	changes := classifyGeneric(detail, metadata)
+	require.Len(t, changes, 3)
+	assert.Equal(t, &resourceChange{
+		AwsAccountID: "111111111111",
+		EventName:    "TagResources",
+		ResourceID:   "arn:aws:s3:::example-bucket",
+		ResourceType: schemas.S3BucketSchema,
+	}, changes[0])
+	assert.Equal(t, "arn:aws:dynamodb:us-west-2:111111111111:table/example-table", changes[1].ResourceID)
+	assert.Equal(t, schemas.DynamoDBTableSchema, changes[1].ResourceType)
+	// Qualified function ARNs scan the function
+	assert.Equal(t, "arn:aws:lambda:us-west-2:111111111111:function:example-function", changes[2].ResourceID)
+	assert.Equal(t, schemas.LambdaFunctionSchema, changes[2].ResourceType)
+}
+
+// Read only events of services without a classifier are dropped before the generic classifier scans
+// the resources they reference
+func TestProcessCloudTrailGenericReadOnly(t *testing.T) {
+	metadata := &CloudTrailMetadata{
+		region:      "us-west-2",
+		accountID:   "111111111111",
+		eventName:   "DescribeBackupVault",
+		eventSource: "backup.amazonaws.com",
+	}
+	accounts = map[string]*models.SourceIntegration{
+		"111111111111": {
+			SourceIntegrationMetadata: models.SourceIntegrationMetadata{
+				AWSAccountID:    "111111111111",
+				IntegrationID:   "ebb4d69f-177b-4eff-a7a6-9251fdc72d21",
+				IntegrationType: models.IntegrationTypeAWSScan,
+			},
+		},
+	}
+	defer func() { accounts = exampleAccounts }()
+
+	changes := make(map[string]*resourceChange)
+	event := gjson.Parse(`{"eventTime": "2019-08-01T04:43:00Z", "readOnly": true,
+		"resources": [{"ARN": "arn:aws:dynamodb:us-west-2:111111111111:table/example-table"}]}`)
+	require.NoError(t, processCloudTrailLog(event, metadata, changes))
+	assert.Empty(t, changes)
+
+	metadata.eventName = "UpdateBackupVault"
+	event = gjson.Parse(`{"eventTime": "2019-08-01T04:43:00Z", "readOnly": false,
+		"resources": [{"ARN": "arn:aws:dynamodb:us-west-2:111111111111:table/example-table"}]}`)
+	require.NoError(t, processCloudTrailLog(event, metadata, changes))
+	assert.Len(t, changes, 1)
+}
+
+func TestClassifyGenericResources(t *testing.T) {
+	detail := gjson.Parse(`{
+		"resources": [
+			{"accountId": "111111111111", "type": "AWS::DynamoDB::Stream",
+			 "ARN": "arn:aws:dynamodb:us-west-2:111111111111:table/example-table/stream/2020-06-01T00:00:00.000"},
+			{"accountId": "222222222222", "type": "AWS::EC2::Instance",
+			 "ARN": "arn:aws:ec2:us-west-2:222222222222:instance/i-0123456789abcdef0"},
+			{"ARN": "arn:aws:backup:us-west-2:111111111111:backup-vault:example-vault"}
+		],
+		"requestParameters": {"resourceArn": "arn:aws:logs:us-west-2:111111111111:log-group:/aws/example:*"}
+	}`)
+	metadata := &CloudTrailMetadata{
+		region:      "us-west-2",
+		accountID:   "111111111111",
+		eventName:   "StartBackupJob",
+		eventSource: "backup.amazonaws.com",
+	}
+
+	changes := classifyGeneric(detail, metadata)
+	require.Len(t, changes, 2)
+	// Sub-resources scan their parent resource
+	assert.Equal(t, "arn:aws:dynamodb:us-west-2:111111111111:table/example-table", changes[0].ResourceID)
+	// Resources in other accounts and of unsupported types are not scanned
+	assert.Equal(t, "arn:aws:logs:us-west-2:111111111111:log-group:/aws/example", changes[1].ResourceID)
+	assert.Equal(t, schemas.CloudWatchLogGroupSchema, changes[1].ResourceType)
+}
+
+func TestClassifyGenericNoARNs(t *testing.T) {
+	detail := gjson.Parse(`{"requestParameters": {"name": "example", "count": 3, "arn": "not-an-arn"}}`)
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "111111111111", eventName: "CreateThing"}
+
+	assert.Empty(t, classifyGeneric(detail, metadata))
+}
+
+func TestClassifyARN(t *testing.T) {
+	for resourceARN, expected := range map[string]string{
+		"arn:aws:elasticloadbalancing:us-west-2:111111111111:loadbalancer/example":                         schemas.ElbLoadBalancerSchema,
+		"arn:aws:elasticloadbalancing:us-west-2:111111111111:loadbalancer/app/example/50dc6c495c0c9188":    schemas.Elbv2LoadBalancerSchema,
+		"arn:aws:elasticloadbalancing:us-west-2:111111111111:loadbalancer/net/example/50dc6c495c0c9188":    "",
+		"arn:aws:apigateway:us-west-2::/restapis/a1b2c3/stages/prod":                                       schemas.ApiGatewayRestApiSchema,
+		"arn:aws:apigateway:us-west-2::/apis/a1b2c3":                                                       schemas.ApiGatewayV2ApiSchema,
+		"arn:aws:eks:us-west-2:111111111111:cluster/example":                                               schemas.EksClusterSchema,
+		"arn:aws:ecs:us-west-2:111111111111:cluster/example":                                               schemas.EcsClusterSchema,
+		"arn:aws:iam::111111111111:role/service-role/example":                                              schemas.IAMRoleSchema,
+		"arn:aws:kms:us-west-2:111111111111:alias/example":                                                 "",
+		"arn:aws:sns:us-west-2:111111111111:example-topic:7c3bc4ee-2e4b-4e4b-9b0a-ff3e3c1a2e6b":            schemas.SnsTopicSchema,
+		"arn:aws:waf-regional:us-west-2:111111111111:webacl/c4e0fb46-6fe4-4b4f-8c5b-3b2f1c0e2d1a":          schemas.WafRegionalWebAclSchema,
+		"arn:aws:organizations::111111111111:account/o-exampleorgid/222222222222":                          "",
+		"arn:aws:cloudformation:us-west-2:111111111111:stack/example/67fc9960-556b-11e9-a978-067794494828": schemas.CloudFormationStackSchema,
+	} {
+		parsed, err := arn.Parse(resourceARN)
+		require.NoError(t, err)
+		_, resourceType := classifyARN(parsed)
+		assert.Equal(t, expected, resourceType, resourceARN)
+	}
+}
+
+func TestExtractARNsLimit(t *testing.T) {
+	var resources []string
+	for i := 0; i < 2*maxGenericARNs; i++ {
+		resources = append(resources, `{"ARN": "arn:aws:s3:::bucket-`+strconv.Itoa(i)+`"}`)
+	}
+	detail := gjson.Parse(`{"resources": [` + strings.Join(resources, ",") + `],
+		"requestParameters": {"bucketArn": "arn:aws:s3:::other-bucket"}}`)
+
+	arns := extractARNs(detail)
+	require.Len(t, arns, maxGenericARNs)
+	assert.Equal(t, "arn:aws:s3:::bucket-0", arns[0])
+}
@@ -37,6 +37,7 @@ import (
 	"go.uber.org/zap"
 
 	api "github.com/panther-labs/panther/api/lambda/resources/models"
+	"github.com/panther-labs/panther/internal/compliance/resourcehistory"
 	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/sources"
 	"github.com/panther-labs/panther/internal/log_analysis/notify"
@@ -52,6 +53,7 @@ const maxBackoffSeconds = 30
 func Handle(lc *lambdacontext.LambdaContext, batch *events.SQSEvent) (err error) {
 	operation := oplog.NewManager("cloudsec", "aws_event_processor").Start(lc.InvokedFunctionArn).WithMemUsed(lambdacontext.MemoryLimitInMB)
 	defer func() {
+		logUnhandledEvents()
 		operation.Stop().Log(err, zap.Int("numEvents", len(batch.Records)))
 	}()
 
@@ -276,10 +278,16 @@ func submitChanges(changes map[string]*resourceChange) error {
 				RegionIgnoreList:        accounts[change.AwsAccountID].RegionIgnoreList,
 				ResourceTypeIgnoreList:  accounts[change.AwsAccountID].ResourceTypeIgnoreList,
 				ResourceRegexIgnoreList: accounts[change.AwsAccountID].ResourceRegexIgnoreList,
+				AuditRole:               accounts[change.AwsAccountID].AuditRole,
 			})
 		}
 	}
 
+	if err := recordChangeEvents(changes); err != nil {
+		// The scans are what keep the resources up to date, so don't let the history block them
+		zap.L().Error("failed to record resource change events", zap.Error(err))
+	}
+
 	// Send deletes to resources-api
 	if len(deleteRequest.Resources) > 0 {
 		zap.L().Debug("deleting resources", zap.Any("deleteRequest", &deleteRequest))
@@ -313,3 +321,29 @@ func submitChanges(changes map[string]*resourceChange) error {
 
 	return nil
 }
+
+// recordChangeEvents adds the CloudTrail event behind each resource change to the resource history.
+//
+// Service-wide and region-wide scans don't identify a resource, so they are not recorded.
+func recordChangeEvents(changes map[string]*resourceChange) error {
+	if historyTable == "" {
+		return nil
+	}
+
+	records := make([]*resourcehistory.Record, 0, len(changes))
+	for _, change := range changes {
+		if change.ResourceID == "" || change.EventID == "" {
+			continue
+		}
+		eventTime, err := time.Parse(time.RFC3339, change.EventTime)
+		if err != nil {
+			zap.L().Warn("invalid CloudTrail event time", zap.String("eventTime", change.EventTime))
+			continue
+		}
+		records = append(records, resourcehistory.NewEventRecord(
+			change.ResourceID, change.ChangedBy, change.EventID, change.EventName, change.EventSource, eventTime))
+	}
+
+	zap.L().Debug("recording resource change events", zap.Int("numEvents", len(records)))
+	return resourcehistory.Put(dynamoClient, historyTable, records)
+}
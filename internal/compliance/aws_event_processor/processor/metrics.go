@@ -0,0 +1,54 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/pkg/metrics"
+)
+
+var (
+	// UnhandledEventsLogger counts the events of services without a dedicated classifier, which are at
+	// best handled by the generic classifier
+	UnhandledEventsLogger = metrics.MustStaticLogger([]metrics.DimensionSet{
+		{
+			"EventSource",
+		},
+	}, []metrics.Metric{
+		{
+			Name: "UnhandledEvents",
+			Unit: metrics.UnitCount,
+		},
+	})
+
+	// The number of unhandled events per event source since they were last logged
+	unhandledEvents = make(map[string]int)
+)
+
+// countUnhandledEvent records an event from a service without a dedicated classifier
+func countUnhandledEvent(eventSource string) {
+	unhandledEvents[eventSource]++
+}
+
+// logUnhandledEvents logs the unhandled event counts as metrics and resets them
+func logUnhandledEvents() {
+	for eventSource, count := range unhandledEvents {
+		UnhandledEventsLogger.LogSingle(count, metrics.Dimension{Name: "EventSource", Value: eventSource})
+	}
+	unhandledEvents = make(map[string]int)
+}
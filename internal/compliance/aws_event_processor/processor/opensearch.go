@@ -0,0 +1,77 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+func classifyOpenSearch(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
+	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_amazonelasticsearchservice.html
+	//
+	// Both the Elasticsearch Service API names and their OpenSearch Service renames are handled
+	var domainARN string
+	switch metadata.eventName {
+	case "CancelElasticsearchServiceSoftwareUpdate",
+		"CancelServiceSoftwareUpdate",
+		"CreateDomain",
+		"CreateElasticsearchDomain",
+		"DeleteDomain",
+		"DeleteElasticsearchDomain",
+		"StartElasticsearchServiceSoftwareUpdate",
+		"StartServiceSoftwareUpdate",
+		"UpdateDomainConfig",
+		"UpdateElasticsearchDomainConfig",
+		"UpgradeDomain",
+		"UpgradeElasticsearchDomain":
+		domainName := detail.Get("requestParameters.domainName").Str
+		if domainName == "" {
+			break
+		}
+		domainARN = arn.ARN{
+			Partition: "aws",
+			Service:   "es",
+			Region:    metadata.region,
+			AccountID: metadata.accountID,
+			Resource:  "domain/" + domainName,
+		}.String()
+	case "AddTags", "RemoveTags":
+		domainARN = detail.Get("requestParameters.aRN").Str
+	default:
+		zap.L().Info("es: encountered unknown event name", zap.String("eventName", metadata.eventName))
+		return nil
+	}
+
+	if _, err := arn.Parse(domainARN); err != nil {
+		zap.L().Warn("es: missing arn", zap.String("eventName", metadata.eventName), zap.Any("detail", detail))
+		return nil
+	}
+
+	return []*resourceChange{{
+		AwsAccountID: metadata.accountID,
+		Delete:       metadata.eventName == "DeleteDomain" || metadata.eventName == "DeleteElasticsearchDomain",
+		EventName:    metadata.eventName,
+		ResourceID:   domainARN,
+		ResourceType: schemas.OpenSearchDomainSchema,
+	}}
+}
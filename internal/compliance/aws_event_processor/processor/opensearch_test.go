@@ -0,0 +1,63 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+func TestClassifyOpenSearchUpdateDomainConfig(t *testing.T) {
+	detail := gjson.Parse(`{"requestParameters": {"domainName": "example-domain"}}`)
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "111111111111", eventName: "UpdateElasticsearchDomainConfig"}
+
+	changes := classifyOpenSearch(detail, metadata)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "arn:aws:es:us-west-2:111111111111:domain/example-domain", changes[0].ResourceID)
+	assert.Equal(t, schemas.OpenSearchDomainSchema, changes[0].ResourceType)
+	assert.False(t, changes[0].Delete)
+}
+
+func TestClassifyOpenSearchDeleteDomain(t *testing.T) {
+	detail := gjson.Parse(`{"requestParameters": {"domainName": "example-domain"}}`)
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "111111111111", eventName: "DeleteDomain"}
+
+	changes := classifyOpenSearch(detail, metadata)
+	require.Len(t, changes, 1)
+	assert.True(t, changes[0].Delete)
+}
+
+func TestClassifyOpenSearchAddTags(t *testing.T) {
+	detail := gjson.Parse(`{"requestParameters": {"aRN": "arn:aws:es:us-west-2:111111111111:domain/example-domain"}}`)
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "111111111111", eventName: "AddTags"}
+
+	changes := classifyOpenSearch(detail, metadata)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "arn:aws:es:us-west-2:111111111111:domain/example-domain", changes[0].ResourceID)
+}
+
+func TestClassifyOpenSearchMissingDomain(t *testing.T) {
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "111111111111", eventName: "CreateElasticsearchDomain"}
+	assert.Empty(t, classifyOpenSearch(gjson.Parse(`{}`), metadata))
+}
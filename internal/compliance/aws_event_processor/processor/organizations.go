@@ -0,0 +1,91 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+// Organization resource ARNs contain the organization ID, which is not part of the events. Since an organization
+// has relatively few accounts, OUs and policies, the affected resource types are rescanned in full instead.
+func classifyOrganizations(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
+	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_awsorganizations.html
+	var resourceTypes []string
+	var delay int64
+	switch metadata.eventName {
+	case "CreateAccount":
+		// Accounts are created asynchronously, give the new account time to join the organization.
+		// Measured in seconds.
+		delay = 300
+		resourceTypes = []string{schemas.OrganizationsAccountSchema, schemas.OrganizationsOrganizationalUnitSchema}
+	case "AcceptHandshake",
+		"CloseAccount",
+		"InviteAccountToOrganization",
+		"LeaveOrganization",
+		"MoveAccount",
+		"RemoveAccountFromOrganization":
+		resourceTypes = []string{schemas.OrganizationsAccountSchema, schemas.OrganizationsOrganizationalUnitSchema}
+	case "CreateOrganizationalUnit", "DeleteOrganizationalUnit", "UpdateOrganizationalUnit":
+		resourceTypes = []string{schemas.OrganizationsOrganizationalUnitSchema}
+	case "CreatePolicy", "DeletePolicy", "UpdatePolicy", "DisablePolicyType", "EnablePolicyType":
+		resourceTypes = []string{schemas.OrganizationsServiceControlPolicySchema, schemas.OrganizationsTagPolicySchema}
+	case "AttachPolicy", "DetachPolicy":
+		// Policy targets, and the effective policies of everything below the target, change
+		resourceTypes = []string{
+			schemas.OrganizationsAccountSchema,
+			schemas.OrganizationsOrganizationalUnitSchema,
+			schemas.OrganizationsServiceControlPolicySchema,
+			schemas.OrganizationsTagPolicySchema,
+		}
+	case "DeregisterDelegatedAdministrator", "RegisterDelegatedAdministrator":
+		resourceTypes = []string{schemas.OrganizationsDelegatedAdministratorSchema}
+	case "TagResource", "UntagResource":
+		resourceID := detail.Get("requestParameters.resourceId").Str
+		switch {
+		case strings.HasPrefix(resourceID, "ou-"):
+			resourceTypes = []string{schemas.OrganizationsOrganizationalUnitSchema}
+		case strings.HasPrefix(resourceID, "p-"):
+			resourceTypes = []string{schemas.OrganizationsServiceControlPolicySchema, schemas.OrganizationsTagPolicySchema}
+		case strings.HasPrefix(resourceID, "r-"):
+			// Roots are not scanned
+			return nil
+		default:
+			resourceTypes = []string{schemas.OrganizationsAccountSchema}
+		}
+	default:
+		zap.L().Info("organizations: encountered unknown event name", zap.String("eventName", metadata.eventName))
+		return nil
+	}
+
+	changes := make([]*resourceChange, 0, len(resourceTypes))
+	for _, resourceType := range resourceTypes {
+		changes = append(changes, &resourceChange{
+			AwsAccountID: metadata.accountID,
+			Delay:        delay,
+			EventName:    metadata.eventName,
+			ResourceType: resourceType,
+		})
+	}
+	return changes
+}
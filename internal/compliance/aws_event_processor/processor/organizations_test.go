@@ -0,0 +1,111 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+func TestClassifyOrganizationsMoveAccount(t *testing.T) {
+	detail := gjson.Parse(`{"requestParameters": {"accountId": "222222222222"}}`)
+	metadata := &CloudTrailMetadata{region: "us-east-1", accountID: "111111111111", eventName: "MoveAccount"}
+
+	changes := classifyOrganizations(detail, metadata)
+	require.Len(t, changes, 2)
+	assert.Equal(t, schemas.OrganizationsAccountSchema, changes[0].ResourceType)
+	assert.Equal(t, schemas.OrganizationsOrganizationalUnitSchema, changes[1].ResourceType)
+	for _, change := range changes {
+		assert.Empty(t, change.ResourceID)
+		assert.Empty(t, change.Region)
+		assert.False(t, change.Delete)
+	}
+}
+
+func TestClassifyOrganizationsCreateAccountDelayed(t *testing.T) {
+	metadata := &CloudTrailMetadata{region: "us-east-1", accountID: "111111111111", eventName: "CreateAccount"}
+
+	changes := classifyOrganizations(gjson.Parse(`{}`), metadata)
+	require.NotEmpty(t, changes)
+	assert.Equal(t, int64(300), changes[0].Delay)
+}
+
+func TestClassifyOrganizationsAttachPolicy(t *testing.T) {
+	detail := gjson.Parse(`{"requestParameters": {"policyId": "p-examplepolicyid111", "targetId": "ou-abcd-12345678"}}`)
+	metadata := &CloudTrailMetadata{region: "us-east-1", accountID: "111111111111", eventName: "AttachPolicy"}
+
+	assert.Len(t, classifyOrganizations(detail, metadata), 4)
+}
+
+func TestClassifyOrganizationsTagResource(t *testing.T) {
+	metadata := &CloudTrailMetadata{region: "us-east-1", accountID: "111111111111", eventName: "TagResource"}
+	assert.False(t, isIgnoredEvent("organizations.amazonaws.com", "TagResource"))
+
+	changes := classifyOrganizations(gjson.Parse(`{"requestParameters": {"resourceId": "ou-abcd-12345678"}}`), metadata)
+	require.Len(t, changes, 1)
+	assert.Equal(t, schemas.OrganizationsOrganizationalUnitSchema, changes[0].ResourceType)
+
+	changes = classifyOrganizations(gjson.Parse(`{"requestParameters": {"resourceId": "222222222222"}}`), metadata)
+	require.Len(t, changes, 1)
+	assert.Equal(t, schemas.OrganizationsAccountSchema, changes[0].ResourceType)
+
+	assert.Empty(t, classifyOrganizations(gjson.Parse(`{"requestParameters": {"resourceId": "r-abcd"}}`), metadata))
+}
+
+func TestClassifyOrganizationsUnknownEvent(t *testing.T) {
+	metadata := &CloudTrailMetadata{region: "us-east-1", accountID: "111111111111", eventName: "EnableAllFeatures"}
+
+	assert.Empty(t, classifyOrganizations(gjson.Parse(`{}`), metadata))
+}
+
+// Organizations events are dropped unless the integration scans its organization
+func TestProcessCloudTrailOrganizations(t *testing.T) {
+	event := gjson.Parse(`{"eventTime": "2019-08-01T04:43:00Z", "requestParameters": {"accountId": "222222222222"}}`)
+	metadata := &CloudTrailMetadata{
+		region:      "us-east-1",
+		accountID:   "111111111111",
+		eventName:   "MoveAccount",
+		eventSource: "organizations.amazonaws.com",
+	}
+
+	accounts = map[string]*models.SourceIntegration{
+		"111111111111": {
+			SourceIntegrationMetadata: models.SourceIntegrationMetadata{
+				AWSAccountID:    "111111111111",
+				IntegrationID:   "ebb4d69f-177b-4eff-a7a6-9251fdc72d21",
+				IntegrationType: models.IntegrationTypeAWSScan,
+			},
+		},
+	}
+	changes := make(map[string]*resourceChange)
+	require.NoError(t, processCloudTrailLog(event, metadata, changes))
+	assert.Empty(t, changes)
+
+	accounts["111111111111"].OrganizationScanEnabled = aws.Bool(true)
+	require.NoError(t, processCloudTrailLog(event, metadata, changes))
+	assert.Len(t, changes, 2)
+	accounts = exampleAccounts
+}
@@ -29,9 +29,12 @@ import (
 // CloudWatch events which require downstream processing are summarized with this struct.
 type resourceChange struct {
 	AwsAccountID  string `json:"awsAccountId"`  // the 12-digit AWS account ID which owns the resource
+	ChangedBy     string `json:"changedBy"`     // ARN of the CloudTrail user identity which made the change
 	Delay         int64  `json:"delay"`         // How long in seconds to delay this message in SQS
 	Delete        bool   `json:"delete"`        // True if the resource should be marked deleted (otherwise, update)
-	EventName     string `json:"eventName"`     // CloudTrail event name (for logging only)
+	EventID       string `json:"eventId"`       // CloudTrail event ID (for the resource history)
+	EventName     string `json:"eventName"`     // CloudTrail event name
+	EventSource   string `json:"eventSource"`   // CloudTrail event source, e.g. "s3.amazonaws.com"
 	EventTime     string `json:"eventTime"`     // official CloudTrail RFC3339 timestamp
 	IntegrationID string `json:"integrationId"` // account integration ID
 	Region        string `json:"region"`        // Region (for resource type scans only)
@@ -46,21 +49,34 @@ type resourceChange struct {
 var (
 	classifiers = map[string]func(gjson.Result, *CloudTrailMetadata) []*resourceChange{
 		"acm.amazonaws.com":                  classifyACM,
+		"apigateway.amazonaws.com":           classifyAPIGateway,
 		"cloudformation.amazonaws.com":       classifyCloudFormation,
+		"cloudfront.amazonaws.com":           classifyCloudFront,
 		"cloudtrail.amazonaws.com":           classifyCloudTrail,
 		"config.amazonaws.com":               classifyConfig,
 		"dynamodb.amazonaws.com":             classifyDynamoDB,
 		"ec2.amazonaws.com":                  classifyEC2,
+		"ecr.amazonaws.com":                  classifyECR,
 		"ecs.amazonaws.com":                  classifyECS,
+		"eks.amazonaws.com":                  classifyEKS,
+		"elasticache.amazonaws.com":          classifyElastiCache,
+		"elasticfilesystem.amazonaws.com":    classifyEFS,
 		"elasticloadbalancing.amazonaws.com": classifyELBV2,
+		"es.amazonaws.com":                   classifyOpenSearch,
 		"guardduty.amazonaws.com":            classifyGuardDuty,
 		"iam.amazonaws.com":                  classifyIAM,
 		"kms.amazonaws.com":                  classifyKMS,
 		"lambda.amazonaws.com":               classifyLambda,
 		"logs.amazonaws.com":                 classifyCloudWatchLogGroup,
+		"organizations.amazonaws.com":        classifyOrganizations,
 		"rds.amazonaws.com":                  classifyRDS,
 		"redshift.amazonaws.com":             classifyRedshift,
+		"route53.amazonaws.com":              classifyRoute53,
 		"s3.amazonaws.com":                   classifyS3,
+		"secretsmanager.amazonaws.com":       classifySecretsManager,
+		"sns.amazonaws.com":                  classifySNS,
+		"sqs.amazonaws.com":                  classifySQS,
+		"ssm.amazonaws.com":                  classifySSM,
 		"waf.amazonaws.com":                  classifyWAF,
 		"waf-regional.amazonaws.com":         classifyWAFRegional,
 	}
@@ -76,13 +92,11 @@ var (
 		"StartSchemaCreation": {},
 
 		// cloudformation
-		"DeleteChangeSet":          {},
-		"DetectStackDrift":         {},
-		"DetectStackResourceDrift": {},
-		"DetectStackSetDrift":      {},
-		"CreateStackSet":           {},
-		"EstimateTemplateCost":     {},
-		"ValidateTemplate":         {},
+		"DeleteChangeSet":      {},
+		"DetectStackSetDrift":  {},
+		"CreateStackSet":       {},
+		"EstimateTemplateCost": {},
+		"ValidateTemplate":     {},
 
 		// cloudtrail
 		"LookupEvents": {},
@@ -145,12 +159,20 @@ var (
 		"CreateInternetGateway":  {}, // Currently we don't have an EC2 InternetGateway resource,
 		"DeleteInternetGateway":  {}, // when we do we will need to handle these
 
+		// ecr
+		"BatchCheckLayerAvailability": {},
+		"BatchDeleteImage":            {},
+		"CompleteLayerUpload":         {},
+		"InitiateLayerUpload":         {},
+		"PutImage":                    {},
+		"StartImageScan":              {},
+		"StartLifecyclePolicyPreview": {},
+		"UploadLayerPart":             {},
+
 		// ecs
 		"DeleteAccountSetting":     {},
-		"DeregisterTaskDefinition": {},
 		"PutAccountSetting":        {},
 		"PutAccountSettingDefault": {},
-		"RegisterTaskDefinition":   {},
 		"UpdateContainerAgent":     {},
 
 		// elbv2
@@ -161,13 +183,6 @@ var (
 		"RegisterTargets":             {},
 		"DeregisterTargets":           {},
 
-		// These are elb classic events that we don't support but can't differentiate from elbv2
-		// events
-		"RegisterInstancesWithLoadBalancer": {},
-		"ConfigureHealthCheck":              {},
-		"SetLoadBalancerPoliciesOfListener": {},
-		"CreateLoadBalancerPolicy":          {},
-
 		// guardduty
 		"ArchiveFindings":             {},
 		"CreateIPSet":                 {},
@@ -213,22 +228,17 @@ var (
 
 		// rds
 		// TODO get suffixes
-		"CreateDBClusterEndpoint":          {},
-		"DeleteDBClusterEndpoint":          {},
-		"CreateDBSecurityGroup":            {},
-		"DeleteDBSecurityGroup":            {},
-		"AuthorizeDBSecurityGroupIngress":  {},
-		"DeleteDBSubnetGroup":              {},
-		"DownloadDBLogFilePortion":         {},
-		"ModifyCurrentDBClusterCapacity":   {},
-		"ModifyDBClusterEndpoint":          {},
-		"ModifyDBClusterSnapshotAttribute": {},
-		"RestoreDBClusterFromS3":           {},
-		"RestoreDBClusterFromSnapshot":     {},
-		"RestoreDBClusterToPointInTime":    {},
-		"RevokeDBSecurityGroupIngress":     {},
-		"StartActivityStream":              {},
-		"StopActivityStream":               {},
+		"CreateDBClusterEndpoint":         {},
+		"DeleteDBClusterEndpoint":         {},
+		"CreateDBSecurityGroup":           {},
+		"DeleteDBSecurityGroup":           {},
+		"AuthorizeDBSecurityGroupIngress": {},
+		"DeleteDBSubnetGroup":             {},
+		"DownloadDBLogFilePortion":        {},
+		"ModifyDBClusterEndpoint":         {},
+		"RevokeDBSecurityGroupIngress":    {},
+		"StartActivityStream":             {},
+		"StopActivityStream":              {},
 
 		// redshift
 		"AcceptReservedNodeExchange":        {},
@@ -249,6 +259,12 @@ var (
 		"RevokeClusterSecurityGroupIngress": {},
 		"CreateClusterParameterGroup":       {},
 
+		// route53
+		"ChangeResourceRecordSets": {}, // record sets are not part of the hosted zone snapshot
+		"CreateHealthCheck":        {},
+		"DeleteHealthCheck":        {},
+		"UpdateHealthCheck":        {},
+
 		// s3
 		"UploadPart":              {},
 		"CreateMultipartUpload":   {},
@@ -257,6 +273,33 @@ var (
 		"HeadObject":              {},
 		"PutObject":               {},
 
+		// sns
+		"CheckIfPhoneNumberIsOptedOut": {},
+		"OptInPhoneNumber":             {},
+		"Publish":                      {},
+		"PublishBatch":                 {},
+		"SetSMSAttributes":             {},
+
+		// sqs
+		"ChangeMessageVisibility":      {},
+		"ChangeMessageVisibilityBatch": {},
+		"DeleteMessage":                {},
+		"DeleteMessageBatch":           {},
+		"PurgeQueue":                   {},
+		"ReceiveMessage":               {},
+		"SendMessage":                  {},
+		"SendMessageBatch":             {},
+
+		// ssm
+		"PutComplianceItems":              {},
+		"PutInventory":                    {},
+		"ResumeSession":                   {},
+		"SendCommand":                     {},
+		"StartSession":                    {},
+		"TerminateSession":                {},
+		"UpdateInstanceAssociationStatus": {},
+		"UpdateInstanceInformation":       {},
+
 		// waf, waf-regional
 		// TODO get suffixes
 		"DeletePermissionPolicy": {},
@@ -268,6 +311,48 @@ var (
 		"RespondToAuthChallenge": {},
 	}
 
+	// Events that are ignored above because most services do not need them, but which do change
+	// resources scanned for the given event sources
+	trackedEvents = map[string]map[string]struct{}{
+		"apigateway.amazonaws.com": {
+			"TagResource":   {},
+			"UntagResource": {},
+		},
+		"cloudfront.amazonaws.com": {
+			"TagResource":   {},
+			"UntagResource": {},
+		},
+		"ecr.amazonaws.com": {
+			"TagResource":   {},
+			"UntagResource": {},
+		},
+		"ecs.amazonaws.com": {
+			"TagResource":   {},
+			"UntagResource": {},
+		},
+		"eks.amazonaws.com": {
+			"TagResource":   {},
+			"UntagResource": {},
+		},
+		"elasticfilesystem.amazonaws.com": {
+			"TagResource":   {},
+			"UntagResource": {},
+		},
+		"organizations.amazonaws.com": {
+			"TagResource":   {},
+			"UntagResource": {},
+		},
+		"secretsmanager.amazonaws.com": {
+			"PutResourcePolicy": {},
+			"TagResource":       {},
+			"UntagResource":     {},
+		},
+		"sns.amazonaws.com": {
+			"TagResource":   {},
+			"UntagResource": {},
+		},
+	}
+
 	// Some prefixes are common to so many API calls (and new ones are so constantly being added) that we do a prefix
 	// check to save developer time from having to maintain an even more massive list
 	ignoredPrefixes = []string{
@@ -301,7 +386,7 @@ func preprocessCloudTrailLog(detail gjson.Result) (*CloudTrailMetadata, error) {
 	}
 
 	// If this is an ignored event, immediately halt processing
-	if isIgnoredEvent(eventName.Str) {
+	if isIgnoredEvent(detail.Get("eventSource").Str, eventName.Str) {
 		zap.L().Debug("ignoring read only event",
 			zap.String("eventSource", detail.Get("eventSource").Str), // best effort to add context
 			zap.String("eventName", eventName.Str))
@@ -314,8 +399,10 @@ func preprocessCloudTrailLog(detail gjson.Result) (*CloudTrailMetadata, error) {
 			eventName.Str)
 	}
 
-	// Check if the service is supported
-	if _, ok := classifiers[eventSource.Str]; !ok {
+	// Check if the service is supported. Events of AWS services without a dedicated classifier are
+	// handled by the generic classifier.
+	_, classified := classifiers[eventSource.Str]
+	if !classified && !strings.HasSuffix(eventSource.Str, ".amazonaws.com") {
 		zap.L().Debug("ignoring event from unsupported source",
 			zap.String("eventSource", eventSource.Str),
 			zap.String("eventName", eventName.Str))
@@ -323,6 +410,14 @@ func preprocessCloudTrailLog(detail gjson.Result) (*CloudTrailMetadata, error) {
 	}
 
 	accountID := detail.Get("userIdentity.accountId")
+	if !accountID.Exists() && !classified {
+		// Not all events of all services are made by an identity of the account, e.g. events of AWS
+		// services themselves. Only the dedicated classifiers know which events should have one.
+		zap.L().Debug("ignoring event without account from unsupported source",
+			zap.String("eventSource", eventSource.Str),
+			zap.String("eventName", eventName.Str))
+		return nil, nil
+	}
 	if !accountID.Exists() {
 		return nil, errors.Errorf("unable to extract CloudTrail accountId field for %s event %s",
 			eventSource.Str, eventName.Str)
@@ -345,7 +440,10 @@ func preprocessCloudTrailLog(detail gjson.Result) (*CloudTrailMetadata, error) {
 // most common of which is either being a read only event or being an event that effects resources we don't scan
 //
 // NOTE: we ignore the "detail.readOnly" field because it is not always present or accurate
-func isIgnoredEvent(eventName string) bool {
+func isIgnoredEvent(eventSource, eventName string) bool {
+	if _, ok := trackedEvents[eventSource][eventName]; ok {
+		return false
+	}
 	_, ok := ignoredEvents[eventName]
 	return ok || hasIgnoredPrefix(eventName)
 }
@@ -371,8 +469,13 @@ func processCloudTrailLog(detail gjson.Result, metadata *CloudTrailMetadata, cha
 		return nil
 	}
 
-	// Determine the AWS service the modified resource belongs to
-	classifier := classifiers[metadata.eventSource]
+	// Organization resources are only scanned for management accounts with organization scanning enabled
+	if metadata.eventSource == "organizations.amazonaws.com" && !integration.ScansOrganization() {
+		zap.L().Debug("dropping organizations event from account without organization scanning",
+			zap.String("eventName", metadata.eventName),
+			zap.String("accountID", metadata.accountID))
+		return nil
+	}
 
 	// Drop failed events, as they do not result in a resource change
 	if errorCode := detail.Get("errorCode").Str; errorCode != "" {
@@ -383,6 +486,21 @@ func processCloudTrailLog(detail gjson.Result, metadata *CloudTrailMetadata, cha
 		return nil
 	}
 
+	// Determine the AWS service the modified resource belongs to
+	classifier, ok := classifiers[metadata.eventSource]
+	if !ok {
+		// The generic classifier scans any resource an event references, so unlike the dedicated
+		// classifiers it can't tell reads from changes by the event name
+		if detail.Get("readOnly").Bool() {
+			zap.L().Debug("dropping readOnly event from service without a classifier",
+				zap.String("eventSource", metadata.eventSource),
+				zap.String("eventName", metadata.eventName))
+			return nil
+		}
+		countUnhandledEvent(metadata.eventSource)
+		classifier = classifyGeneric
+	}
+
 	// Process the body
 	newChanges := classifier(detail, metadata)
 	eventTime := detail.Get("eventTime").Str
@@ -399,6 +517,9 @@ func processCloudTrailLog(detail gjson.Result, metadata *CloudTrailMetadata, cha
 
 	// One event could require multiple scans (e.g. a new VPC peering connection between two VPCs)
 	for _, change := range newChanges {
+		change.ChangedBy = detail.Get("userIdentity.arn").Str
+		change.EventID = detail.Get("eventID").Str
+		change.EventSource = metadata.eventSource
 		change.EventTime = eventTime
 		change.IntegrationID = integration.IntegrationID
 		zap.L().Info("resource scan required", zap.Any("changeDetail", change))
@@ -142,6 +142,30 @@ func TestPreProcessCloudTrailBadSource(t *testing.T) {
 	assert.Equal(t, expected, logs.AllUntimed())
 }
 
+// events of other AWS services are handled by the generic classifier
+func TestPreProcessCloudTrailGenericSource(t *testing.T) {
+	event := `{
+"eventSource": "tagging.amazonaws.com",
+"awsRegion": "us-west-2",
+"userIdentity": { "accountId" : "111111111111" },
+"eventName": "TagResources"
+}`
+	metadata, err := preprocessCloudTrailLog(gjson.Parse(event))
+	require.NoError(t, err)
+	require.NotNil(t, metadata)
+	assert.Equal(t, "tagging.amazonaws.com", metadata.eventSource)
+
+	// AWS service events without an account are dropped
+	metadata, err = preprocessCloudTrailLog(gjson.Parse(`{
+"eventSource": "tagging.amazonaws.com",
+"awsRegion": "us-west-2",
+"userIdentity": { "type" : "AWSService" },
+"eventName": "TagResources"
+}`))
+	require.NoError(t, err)
+	assert.Nil(t, metadata)
+}
+
 // drop event if it describes a failed API call
 func TestProcessCloudTrailErrorCode(t *testing.T) {
 	logs := mockLogger()
@@ -245,6 +269,7 @@ func TestProcessCloudTrail(t *testing.T) {
 		AwsAccountID:  "111111111111",
 		Delete:        true,
 		EventName:     "DeleteBucket",
+		EventSource:   "s3.amazonaws.com",
 		EventTime:     "2019-08-01T04:43:00Z",
 		IntegrationID: "ebb4d69f-177b-4eff-a7a6-9251fdc72d21",
 		ResourceID:    "arn:aws:s3:::panther",
@@ -262,3 +287,35 @@ func TestProcessCloudTrail(t *testing.T) {
 	assert.Equal(t, expected, changeResults[expected.ResourceID+expected.ResourceType+expected.Region])
 	assert.Equal(t, expectedLogs, logs.AllUntimed())
 }
+
+func TestProcessCloudTrailGeneric(t *testing.T) {
+	mockLogger()
+	accounts = exampleAccounts
+	unhandledEvents = make(map[string]int)
+	event := `{
+"eventSource": "tagging.amazonaws.com",
+"awsRegion": "us-west-2",
+"eventName": "TagResources",
+"eventTime": "2019-08-01T04:43:00Z",
+"requestParameters": {"resourceARNList": ["arn:aws:s3:::panther"]},
+"userIdentity": {"accountId": "111111111111"}
+}`
+	metadata := &CloudTrailMetadata{
+		region:      "us-west-2",
+		accountID:   "111111111111",
+		eventName:   "TagResources",
+		eventSource: "tagging.amazonaws.com",
+	}
+	changeResults := exampleChanges()
+	require.NoError(t, processCloudTrailLog(gjson.Parse(event), metadata, changeResults))
+	require.NoError(t, processCloudTrailLog(gjson.Parse(event), metadata, changeResults))
+
+	change := changeResults["arn:aws:s3:::panther"+schemas.S3BucketSchema]
+	require.NotNil(t, change)
+	assert.Equal(t, "tagging.amazonaws.com", change.EventSource)
+	assert.Equal(t, "ebb4d69f-177b-4eff-a7a6-9251fdc72d21", change.IntegrationID)
+	assert.Equal(t, map[string]int{"tagging.amazonaws.com": 2}, unhandledEvents)
+
+	logUnhandledEvents()
+	assert.Empty(t, unhandledEvents)
+}
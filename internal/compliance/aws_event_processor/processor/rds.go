@@ -28,18 +28,48 @@ import (
 	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 )
 
+// rdsResourcesByPrefix maps the resource part of an RDS ARN to the resource type it identifies
+var rdsResourcesByPrefix = map[string]string{
+	"db:":               schemas.RDSInstanceSchema,
+	"cluster:":          schemas.RDSClusterSchema,
+	"cluster-snapshot:": schemas.RDSClusterSnapshotSchema,
+	"snapshot:":         schemas.RDSSnapshotSchema,
+}
+
 func classifyRDS(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
-	if strings.HasSuffix(metadata.eventName, "DBCluster") || // 9 APIs
-		strings.HasSuffix(metadata.eventName, "ParameterGroup") || // 10 APIs
+	if strings.HasSuffix(metadata.eventName, "ParameterGroup") || // 10 APIs
 		strings.HasSuffix(metadata.eventName, "Subscription") || // 5 APIs
 		strings.HasSuffix(metadata.eventName, "OptionGroup") || // 4 APIs
-		strings.HasSuffix(metadata.eventName, "GlobalCluster") || // 4 APIs
-		strings.HasSuffix(metadata.eventName, "ClusterSnapshot") { // 3 APIs
+		strings.HasSuffix(metadata.eventName, "GlobalCluster") { // 4 APIs
 
 		zap.L().Debug("rds: ignoring event", zap.String("eventName", metadata.eventName))
 		return nil
 	}
 
+	// DB clusters and snapshots are resources of their own, so changes to them are handled separately
+	switch metadata.eventName {
+	case "AddRoleToDBCluster", "BacktrackDBCluster", "CreateDBCluster", "DeleteDBCluster", "FailoverDBCluster",
+		"ModifyCurrentDBClusterCapacity", "ModifyDBCluster", "RemoveRoleFromDBCluster", "RestoreDBClusterFromS3",
+		"RestoreDBClusterFromSnapshot", "RestoreDBClusterToPointInTime", "StartDBCluster", "StopDBCluster":
+		return rdsResourceChanges(metadata, "cluster:", detail.Get("requestParameters.dBClusterIdentifier").Str)
+	case "CreateDBClusterSnapshot", "DeleteDBClusterSnapshot", "ModifyDBClusterSnapshotAttribute":
+		return rdsResourceChanges(metadata, "cluster-snapshot:",
+			detail.Get("requestParameters.dBClusterSnapshotIdentifier").Str)
+	case "CopyDBClusterSnapshot":
+		return rdsResourceChanges(metadata, "cluster-snapshot:",
+			detail.Get("requestParameters.targetDBClusterSnapshotIdentifier").Str)
+	}
+
+	// Changes to DB snapshots are tracked on the snapshot itself as well as on its source instance,
+	// as the instance snapshot includes the attributes of its manual snapshots
+	var snapshotChanges []*resourceChange
+	switch metadata.eventName {
+	case "CreateDBSnapshot", "DeleteDBSnapshot", "ModifyDBSnapshot", "ModifyDBSnapshotAttribute":
+		snapshotChanges = rdsResourceChanges(metadata, "snapshot:", detail.Get("requestParameters.dBSnapshotIdentifier").Str)
+	case "CopyDBSnapshot":
+		snapshotChanges = rdsResourceChanges(metadata, "snapshot:", detail.Get("requestParameters.targetDBSnapshotIdentifier").Str)
+	}
+
 	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_amazonrds.html
 	rdsARN := arn.ARN{
 		Partition: "aws",
@@ -55,7 +85,7 @@ func classifyRDS(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceC
 		instanceID := detail.Get("requestParameters.dBInstanceIdentifier")
 		if !instanceID.Exists() {
 			zap.L().Info("unable to extract dBInstanceIdentifier from event", zap.Any("requestParameters", detail.Get("requestParameters").Raw))
-			return nil
+			return snapshotChanges
 		}
 		rdsARN.Resource += instanceID.Str
 	case "AddTagsToResource", "RemoveTagsFromResource":
@@ -64,9 +94,15 @@ func classifyRDS(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceC
 			zap.L().Error("rds: error parsing ARN", zap.String("eventName", metadata.eventName), zap.Error(err))
 			return nil
 		}
-		if strings.HasPrefix(resourceARN.Resource, "db:") {
-			rdsARN = resourceARN
-			break
+		for prefix, resourceType := range rdsResourcesByPrefix {
+			if strings.HasPrefix(resourceARN.Resource, prefix) {
+				return []*resourceChange{{
+					AwsAccountID: metadata.accountID,
+					EventName:    metadata.eventName,
+					ResourceID:   resourceARN.String(),
+					ResourceType: resourceType,
+				}}
+			}
 		}
 		return nil
 	case "ApplyPendingMaintenanceAction":
@@ -87,7 +123,7 @@ func classifyRDS(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceC
 		// This can happen when a snapshot for a DB that no longer exists is changed
 		if !instanceID.Exists() {
 			zap.L().Info("unable to extract dBInstanceIdentifier from event", zap.Any("responseElements", detail.Get("responseElements").Raw))
-			return nil
+			return snapshotChanges
 		}
 		rdsARN.Resource += instanceID.Str
 	case "CreateDBInstanceReadReplica":
@@ -128,7 +164,7 @@ func classifyRDS(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceC
 		// this event, we send the panther-snapshot-poller the ID of the db snapshot and let the
 		// poller make the appropriate API call to tie this snapshot back to a particular instance.
 		snapshotID := detail.Get("requestParameters.dBSnapshotIdentifier").Str
-		return []*resourceChange{{
+		return append(snapshotChanges, &resourceChange{
 			AwsAccountID: metadata.accountID,
 			EventName:    metadata.eventName,
 			ResourceID: arn.ARN{
@@ -139,7 +175,7 @@ func classifyRDS(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceC
 				Resource:  "snapshot:" + snapshotID,
 			}.String(),
 			ResourceType: schemas.RDSInstanceSchema,
-		}}
+		})
 	case "RestoreDBInstanceToPointInTime":
 		// Similar to CreateDBInstanceReadReplica but with different field names
 		return []*resourceChange{{
@@ -158,11 +194,33 @@ func classifyRDS(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceC
 		return nil
 	}
 
-	return []*resourceChange{{
+	return append(snapshotChanges, &resourceChange{
 		AwsAccountID: metadata.accountID,
 		Delete:       metadata.eventName == "DeleteDBInstance",
 		EventName:    metadata.eventName,
 		ResourceID:   rdsARN.String(),
 		ResourceType: schemas.RDSInstanceSchema,
+	})
+}
+
+// rdsResourceChanges builds the change for an RDS DB cluster or snapshot from its identifier
+func rdsResourceChanges(metadata *CloudTrailMetadata, prefix string, identifier string) []*resourceChange {
+	if identifier == "" {
+		zap.L().Info("rds: unable to extract resource identifier from event", zap.String("eventName", metadata.eventName))
+		return nil
+	}
+
+	return []*resourceChange{{
+		AwsAccountID: metadata.accountID,
+		Delete:       strings.HasPrefix(metadata.eventName, "Delete"),
+		EventName:    metadata.eventName,
+		ResourceID: arn.ARN{
+			Partition: "aws",
+			Service:   "rds",
+			Region:    metadata.region,
+			AccountID: metadata.accountID,
+			Resource:  prefix + identifier,
+		}.String(),
+		ResourceType: rdsResourcesByPrefix[prefix],
 	}}
 }
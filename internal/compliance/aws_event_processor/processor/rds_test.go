@@ -0,0 +1,99 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+func TestClassifyRDSModifyDBSnapshotAttribute(t *testing.T) {
+	detail := gjson.Parse(`{"requestParameters": {
+"dBSnapshotIdentifier": "example-snapshot",
+"attributeName": "restore",
+"valuesToAdd": ["all"]
+}}`)
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "111111111111", eventName: "ModifyDBSnapshotAttribute"}
+
+	changes := classifyRDS(detail, metadata)
+	require.Len(t, changes, 2)
+	assert.Equal(t, "arn:aws:rds:us-west-2:111111111111:snapshot:example-snapshot", changes[0].ResourceID)
+	assert.Equal(t, schemas.RDSSnapshotSchema, changes[0].ResourceType)
+	assert.Equal(t, schemas.RDSInstanceSchema, changes[1].ResourceType)
+}
+
+func TestClassifyRDSDeleteDBSnapshot(t *testing.T) {
+	detail := gjson.Parse(`{
+"requestParameters": {"dBSnapshotIdentifier": "example-snapshot"},
+"responseElements": {"dBSnapshot": {"dBInstanceIdentifier": "example-instance"}}
+}`)
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "111111111111", eventName: "DeleteDBSnapshot"}
+
+	changes := classifyRDS(detail, metadata)
+	require.Len(t, changes, 2)
+	assert.Equal(t, schemas.RDSSnapshotSchema, changes[0].ResourceType)
+	assert.True(t, changes[0].Delete)
+	assert.Equal(t, "arn:aws:rds:us-west-2:111111111111:db:example-instance", changes[1].ResourceID)
+	assert.False(t, changes[1].Delete)
+}
+
+func TestClassifyRDSCluster(t *testing.T) {
+	detail := gjson.Parse(`{"requestParameters": {"dBClusterIdentifier": "example-cluster"}}`)
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "111111111111", eventName: "DeleteDBCluster"}
+
+	changes := classifyRDS(detail, metadata)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "arn:aws:rds:us-west-2:111111111111:cluster:example-cluster", changes[0].ResourceID)
+	assert.Equal(t, schemas.RDSClusterSchema, changes[0].ResourceType)
+	assert.True(t, changes[0].Delete)
+}
+
+func TestClassifyRDSModifyDBClusterSnapshotAttribute(t *testing.T) {
+	detail := gjson.Parse(`{"requestParameters": {"dBClusterSnapshotIdentifier": "example-cluster-snapshot"}}`)
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "111111111111", eventName: "ModifyDBClusterSnapshotAttribute"}
+
+	assert.False(t, isIgnoredEvent("rds.amazonaws.com", "ModifyDBClusterSnapshotAttribute"))
+	changes := classifyRDS(detail, metadata)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "arn:aws:rds:us-west-2:111111111111:cluster-snapshot:example-cluster-snapshot", changes[0].ResourceID)
+	assert.Equal(t, schemas.RDSClusterSnapshotSchema, changes[0].ResourceType)
+	assert.False(t, changes[0].Delete)
+}
+
+func TestClassifyRDSAddTagsToCluster(t *testing.T) {
+	detail := gjson.Parse(`{"requestParameters": {
+"resourceName": "arn:aws:rds:us-west-2:111111111111:cluster:example-cluster"
+}}`)
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "111111111111", eventName: "AddTagsToResource"}
+
+	changes := classifyRDS(detail, metadata)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "arn:aws:rds:us-west-2:111111111111:cluster:example-cluster", changes[0].ResourceID)
+	assert.Equal(t, schemas.RDSClusterSchema, changes[0].ResourceType)
+}
+
+func TestClassifyRDSClusterMissingIdentifier(t *testing.T) {
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "111111111111", eventName: "ModifyDBCluster"}
+	assert.Empty(t, classifyRDS(gjson.Parse(`{}`), metadata))
+}
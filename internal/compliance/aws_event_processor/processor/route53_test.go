@@ -0,0 +1,77 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+func TestClassifyRoute53CreateHostedZone(t *testing.T) {
+	detail := gjson.Parse(`{"responseElements": {"hostedZone": {"id": "/hostedzone/Z1D633PJN98FT9", "name": "example.com."}}}`)
+	metadata := &CloudTrailMetadata{region: "us-east-1", accountID: "111111111111", eventName: "CreateHostedZone"}
+
+	changes := classifyRoute53(detail, metadata)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "arn:aws:route53:::hostedzone/Z1D633PJN98FT9", changes[0].ResourceID)
+	assert.Equal(t, schemas.Route53HostedZoneSchema, changes[0].ResourceType)
+	assert.False(t, changes[0].Delete)
+}
+
+func TestClassifyRoute53DeleteHostedZone(t *testing.T) {
+	detail := gjson.Parse(`{"requestParameters": {"id": "Z1D633PJN98FT9"}}`)
+	metadata := &CloudTrailMetadata{region: "us-east-1", accountID: "111111111111", eventName: "DeleteHostedZone"}
+
+	changes := classifyRoute53(detail, metadata)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "arn:aws:route53:::hostedzone/Z1D633PJN98FT9", changes[0].ResourceID)
+	assert.True(t, changes[0].Delete)
+}
+
+func TestClassifyRoute53ChangeTags(t *testing.T) {
+	metadata := &CloudTrailMetadata{region: "us-east-1", accountID: "111111111111", eventName: "ChangeTagsForResource"}
+
+	changes := classifyRoute53(gjson.Parse(`{"requestParameters": {"resourceType": "hostedzone", "resourceId": "Z1D633PJN98FT9"}}`), metadata)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "arn:aws:route53:::hostedzone/Z1D633PJN98FT9", changes[0].ResourceID)
+
+	assert.Empty(t, classifyRoute53(gjson.Parse(`{"requestParameters": {"resourceType": "healthcheck", "resourceId": "abcdef11"}}`), metadata))
+}
+
+func TestClassifyRoute53DeleteQueryLoggingConfig(t *testing.T) {
+	detail := gjson.Parse(`{"requestParameters": {"id": "87654321-dcba-1234-abcd-1a2b3c4d5e6f"}}`)
+	metadata := &CloudTrailMetadata{region: "us-east-1", accountID: "111111111111", eventName: "DeleteQueryLoggingConfig"}
+
+	changes := classifyRoute53(detail, metadata)
+	require.Len(t, changes, 1)
+	assert.Empty(t, changes[0].ResourceID)
+	assert.Equal(t, "us-east-1", changes[0].Region)
+	assert.Equal(t, schemas.Route53HostedZoneSchema, changes[0].ResourceType)
+}
+
+func TestIsIgnoredEventRoute53(t *testing.T) {
+	assert.True(t, isIgnoredEvent("route53.amazonaws.com", "ChangeResourceRecordSets"))
+	assert.False(t, isIgnoredEvent("route53.amazonaws.com", "ChangeTagsForResource"))
+	assert.False(t, isIgnoredEvent("cloudfront.amazonaws.com", "TagResource"))
+}
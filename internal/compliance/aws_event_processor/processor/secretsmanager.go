@@ -0,0 +1,74 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+func classifySecretsManager(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
+	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_awssecretsmanager.html
+	switch metadata.eventName {
+	case "CancelRotateSecret",
+		"CreateSecret",
+		"DeleteResourcePolicy",
+		"DeleteSecret",
+		"PutResourcePolicy",
+		"PutSecretValue",
+		"RestoreSecret",
+		"RotateSecret",
+		"TagResource",
+		"UntagResource",
+		"UpdateSecret",
+		"UpdateSecretVersionStage":
+	default:
+		zap.L().Info("secretsmanager: encountered unknown event name", zap.String("eventName", metadata.eventName))
+		return nil
+	}
+
+	// Most responses include the full ARN of the secret. Otherwise the secret may have been referred to
+	// by its ARN or by its name, and names cannot be converted to ARNs because of the random suffix
+	// Secrets Manager appends.
+	secretARN := detail.Get("responseElements.aRN").Str
+	if _, err := arn.Parse(secretARN); err != nil {
+		secretARN = detail.Get("requestParameters.secretId").Str
+	}
+	if _, err := arn.Parse(secretARN); err != nil {
+		return []*resourceChange{{
+			AwsAccountID: metadata.accountID,
+			Delete:       false,
+			EventName:    metadata.eventName,
+			Region:       metadata.region,
+			ResourceType: schemas.SecretsManagerSecretSchema,
+		}}
+	}
+
+	return []*resourceChange{{
+		AwsAccountID: metadata.accountID,
+		// Secrets are normally only scheduled for deletion, and can still be restored
+		Delete:       metadata.eventName == "DeleteSecret" && detail.Get("requestParameters.forceDeleteWithoutRecovery").Bool(),
+		EventName:    metadata.eventName,
+		ResourceID:   secretARN,
+		ResourceType: schemas.SecretsManagerSecretSchema,
+	}}
+}
@@ -0,0 +1,52 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestClassifySecretsManagerByARN(t *testing.T) {
+	detail := gjson.Parse(`{
+"requestParameters": {"secretId": "example-secret", "forceDeleteWithoutRecovery": true},
+"responseElements": {"aRN": "arn:aws:secretsmanager:us-west-2:111111111111:secret:example-secret-a1b2c3"}
+}`)
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "111111111111", eventName: "DeleteSecret"}
+
+	changes := classifySecretsManager(detail, metadata)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "arn:aws:secretsmanager:us-west-2:111111111111:secret:example-secret-a1b2c3", changes[0].ResourceID)
+	assert.True(t, changes[0].Delete)
+}
+
+// Secrets referred to by name can not be mapped to an ARN, so the whole region is scanned
+func TestClassifySecretsManagerByName(t *testing.T) {
+	detail := gjson.Parse(`{"requestParameters": {"secretId": "example-secret"}}`)
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "111111111111", eventName: "TagResource"}
+
+	changes := classifySecretsManager(detail, metadata)
+	require.Len(t, changes, 1)
+	assert.Empty(t, changes[0].ResourceID)
+	assert.Equal(t, "us-west-2", changes[0].Region)
+	assert.False(t, changes[0].Delete)
+}
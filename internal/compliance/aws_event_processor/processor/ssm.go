@@ -0,0 +1,85 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+func classifySSM(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
+	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_awssystemsmanager.html
+	var names []string
+	switch metadata.eventName {
+	case "DeleteParameter",
+		"LabelParameterVersion",
+		"PutParameter":
+		names = append(names, detail.Get("requestParameters.name").Str)
+	case "DeleteParameters":
+		for _, name := range detail.Get("requestParameters.names").Array() {
+			names = append(names, name.Str)
+		}
+	case "AddTagsToResource", "RemoveTagsFromResource":
+		// Tags can be applied to many SSM resource types, but we only scan parameters
+		if detail.Get("requestParameters.resourceType").Str != "Parameter" {
+			return nil
+		}
+		names = append(names, detail.Get("requestParameters.resourceId").Str)
+	default:
+		zap.L().Info("ssm: encountered unknown event name", zap.String("eventName", metadata.eventName))
+		return nil
+	}
+
+	changes := make([]*resourceChange, 0, len(names))
+	for _, name := range names {
+		if name == "" {
+			zap.L().Warn("ssm: missing parameter name", zap.String("eventName", metadata.eventName))
+			continue
+		}
+		changes = append(changes, &resourceChange{
+			AwsAccountID: metadata.accountID,
+			Delete:       metadata.eventName == "DeleteParameter" || metadata.eventName == "DeleteParameters",
+			EventName:    metadata.eventName,
+			ResourceID:   ssmParameterARN(name, metadata),
+			ResourceType: schemas.SsmParameterSchema,
+		})
+	}
+	return changes
+}
+
+// ssmParameterARN builds the ARN of a parameter from its name or ARN.
+//
+// Hierarchical parameter names begin with a "/", which is dropped from the ARN.
+func ssmParameterARN(name string, metadata *CloudTrailMetadata) string {
+	if _, err := arn.Parse(name); err == nil {
+		return name
+	}
+	return arn.ARN{
+		Partition: "aws",
+		Service:   "ssm",
+		Region:    metadata.region,
+		AccountID: metadata.accountID,
+		Resource:  "parameter/" + strings.TrimPrefix(name, "/"),
+	}.String()
+}
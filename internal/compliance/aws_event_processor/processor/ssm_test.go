@@ -0,0 +1,50 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestClassifySSMDeleteParameters(t *testing.T) {
+	detail := gjson.Parse(`{"requestParameters": {"names": ["/example/parameter", "example-parameter"]}}`)
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "111111111111", eventName: "DeleteParameters"}
+
+	changes := classifySSM(detail, metadata)
+	require.Len(t, changes, 2)
+	assert.Equal(t, "arn:aws:ssm:us-west-2:111111111111:parameter/example/parameter", changes[0].ResourceID)
+	assert.Equal(t, "arn:aws:ssm:us-west-2:111111111111:parameter/example-parameter", changes[1].ResourceID)
+	assert.True(t, changes[0].Delete)
+}
+
+func TestClassifySSMTags(t *testing.T) {
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "111111111111", eventName: "AddTagsToResource"}
+
+	detail := gjson.Parse(`{"requestParameters": {"resourceType": "Parameter", "resourceId": "/example/parameter"}}`)
+	changes := classifySSM(detail, metadata)
+	require.Len(t, changes, 1)
+	assert.False(t, changes[0].Delete)
+
+	detail = gjson.Parse(`{"requestParameters": {"resourceType": "Document", "resourceId": "example-document"}}`)
+	assert.Empty(t, classifySSM(detail, metadata))
+}
@@ -0,0 +1,87 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	analysismodels "github.com/panther-labs/panther/api/lambda/analysis/models"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+)
+
+var analysisClient gatewayapi.API = gatewayapi.NewClient(lambda.New(awsSession), "panther-analysis-api")
+
+// The analysis-api accepts at most this many policy IDs when listing suppressions
+const maxSuppressionPolicies = 1000
+
+// Only the fields needed to map policies to framework controls
+var frameworkPolicyFields = []string{"displayName", "id", "reports", "severity"}
+
+// Load every enabled policy from the analysis-api
+func listEnabledPolicies() ([]analysismodels.Policy, error) {
+	listInput := analysismodels.LambdaInput{
+		ListPolicies: &analysismodels.ListPoliciesInput{
+			Enabled:  aws.Bool(true),
+			Fields:   frameworkPolicyFields,
+			Page:     1,
+			PageSize: 1000,
+		},
+	}
+
+	var policies []analysismodels.Policy
+	for {
+		var listOutput analysismodels.ListPoliciesOutput
+		if _, err := analysisClient.Invoke(&listInput, &listOutput); err != nil {
+			return nil, errors.WithMessage(err, "failed to load policies from analysis-api")
+		}
+		policies = append(policies, listOutput.Policies...)
+
+		if listOutput.Paging.ThisPage >= listOutput.Paging.TotalPages {
+			break
+		}
+		listInput.ListPolicies.Page++
+	}
+
+	zap.L().Debug("loaded enabled policies from analysis-api", zap.Int("policyCount", len(policies)))
+	return policies, nil
+}
+
+// Load the active suppressions of the given policies from the analysis-api
+func listActiveSuppressions(policyIDs []string) ([]analysismodels.Suppression, error) {
+	var suppressions []analysismodels.Suppression
+	for start := 0; start < len(policyIDs); start += maxSuppressionPolicies {
+		end := start + maxSuppressionPolicies
+		if end > len(policyIDs) {
+			end = len(policyIDs)
+		}
+
+		input := analysismodels.LambdaInput{
+			ListSuppressions: &analysismodels.ListSuppressionsInput{PolicyIDs: policyIDs[start:end]},
+		}
+		var output analysismodels.ListSuppressionsOutput
+		if _, err := analysisClient.Invoke(&input, &output); err != nil {
+			return nil, errors.WithMessage(err, "failed to load suppressions from analysis-api")
+		}
+		suppressions = append(suppressions, output.Suppressions...)
+	}
+	return suppressions, nil
+}
@@ -0,0 +1,351 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"go.uber.org/zap"
+
+	analysismodels "github.com/panther-labs/panther/api/lambda/analysis/models"
+	"github.com/panther-labs/panther/api/lambda/compliance/models"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+)
+
+// Map of control ID to the enabled policies which provide evidence for it
+type frameworkControls map[string][]*analysismodels.Policy
+
+// Map of policy ID to its status entries
+type policyEntries map[string][]*models.ComplianceEntry
+
+// GetFrameworkScores scores compliance frameworks by integration and resource type.
+func (API) GetFrameworkScores(input *models.GetFrameworkScoresInput) *events.APIGatewayProxyResponse {
+	frameworks := input.Frameworks
+	if len(frameworks) == 0 {
+		frameworks = models.DefaultFrameworks
+	}
+
+	policies, entries, err := loadFrameworkState()
+	if err != nil {
+		zap.L().Error("GetFrameworkScores failed", zap.Error(err))
+		return &events.APIGatewayProxyResponse{Body: err.Error(), StatusCode: http.StatusInternalServerError}
+	}
+
+	result := models.GetFrameworkScoresOutput{Frameworks: make([]models.FrameworkScore, 0, len(frameworks))}
+	for _, framework := range frameworks {
+		controls := mapControls(policies, framework)
+		result.Frameworks = append(result.Frameworks, scoreFramework(framework, controls, entries))
+	}
+	return gatewayapi.MarshalResponse(&result, http.StatusOK)
+}
+
+// GenerateComplianceReport builds the evidence bundle for a single compliance framework.
+func (API) GenerateComplianceReport(input *models.GenerateComplianceReportInput) *events.APIGatewayProxyResponse {
+	policies, entries, err := loadFrameworkState()
+	if err != nil {
+		zap.L().Error("GenerateComplianceReport failed", zap.Error(err))
+		return &events.APIGatewayProxyResponse{Body: err.Error(), StatusCode: http.StatusInternalServerError}
+	}
+
+	controls := mapControls(policies, input.Framework)
+	if len(controls) == 0 {
+		return &events.APIGatewayProxyResponse{
+			Body:       "no enabled policies are mapped to framework " + input.Framework,
+			StatusCode: http.StatusNotFound,
+		}
+	}
+
+	policyIDs := make([]string, 0, len(policies))
+	for _, mapped := range controls {
+		for _, policy := range mapped {
+			policyIDs = append(policyIDs, policy.ID)
+		}
+	}
+	suppressions, err := listActiveSuppressions(uniqueSorted(policyIDs))
+	if err != nil {
+		zap.L().Error("GenerateComplianceReport failed", zap.Error(err))
+		return &events.APIGatewayProxyResponse{Body: err.Error(), StatusCode: http.StatusInternalServerError}
+	}
+
+	report := buildReport(input.Framework, controls, entries, suppressions, time.Now().UTC())
+	if report.CSV, err = reportCSV(report); err != nil {
+		zap.L().Error("GenerateComplianceReport failed", zap.Error(err))
+		return &events.APIGatewayProxyResponse{Body: err.Error(), StatusCode: http.StatusInternalServerError}
+	}
+	return gatewayapi.MarshalResponse(report, http.StatusOK)
+}
+
+// Load the enabled policies from the analysis-api and every status entry from the compliance table
+func loadFrameworkState() ([]analysismodels.Policy, policyEntries, error) {
+	policies, err := listEnabledPolicies()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entries := make(policyEntries, len(policies))
+	err = scanPages(&dynamodb.ScanInput{TableName: &Env.ComplianceTable}, func(item *models.ComplianceEntry) error {
+		entries[item.PolicyID] = append(entries[item.PolicyID], item)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return policies, entries, nil
+}
+
+// Group policies by the controls they are mapped to in the given framework.
+//
+// Framework names are matched case-insensitively against the policy report keys.
+func mapControls(policies []analysismodels.Policy, framework string) frameworkControls {
+	result := make(frameworkControls)
+	for i := range policies {
+		policy := &policies[i]
+		seen := make(map[string]bool)
+		for key, controlIDs := range policy.Reports {
+			if !strings.EqualFold(key, framework) {
+				continue
+			}
+			for _, controlID := range controlIDs {
+				controlID = strings.TrimSpace(controlID)
+				if controlID == "" || seen[controlID] {
+					continue
+				}
+				seen[controlID] = true
+				result[controlID] = append(result[controlID], policy)
+			}
+		}
+	}
+	return result
+}
+
+// Score a framework overall and within each integration and resource type.
+func scoreFramework(framework string, controls frameworkControls, entries policyEntries) models.FrameworkScore {
+	var total models.StatusCount
+	byIntegration := make(map[string]*models.StatusCount)
+	byResourceType := make(map[string]*models.StatusCount)
+
+	for _, policies := range controls {
+		// Count the active policy/resource statuses of this control in every scope
+		var overall models.StatusCount
+		integrations := make(map[string]*models.StatusCount)
+		resourceTypes := make(map[string]*models.StatusCount)
+		for _, policy := range policies {
+			for _, entry := range entries[policy.ID] {
+				if entry.Suppressed {
+					continue
+				}
+				updateStatusCount(&overall, entry.Status)
+				updateStatusCount(scopeCount(integrations, entry.IntegrationID), entry.Status)
+				updateStatusCount(scopeCount(resourceTypes, entry.ResourceType), entry.Status)
+			}
+		}
+
+		// Then count the control itself once in every scope where it applies
+		if isApplicable(overall) {
+			updateStatusCount(&total, countToStatus(overall))
+		}
+		for id, count := range integrations {
+			updateStatusCount(scopeCount(byIntegration, id), countToStatus(*count))
+		}
+		for id, count := range resourceTypes {
+			updateStatusCount(scopeCount(byResourceType, id), countToStatus(*count))
+		}
+	}
+
+	return models.FrameworkScore{
+		Framework:      framework,
+		Controls:       total,
+		Score:          score(total),
+		ByIntegration:  scopeScores(byIntegration),
+		ByResourceType: scopeScores(byResourceType),
+	}
+}
+
+func scopeCount(scopes map[string]*models.StatusCount, id string) *models.StatusCount {
+	count, ok := scopes[id]
+	if !ok {
+		count = &models.StatusCount{}
+		scopes[id] = count
+	}
+	return count
+}
+
+func scopeScores(scopes map[string]*models.StatusCount) []models.ScopeScore {
+	result := make([]models.ScopeScore, 0, len(scopes))
+	for id, count := range scopes {
+		result = append(result, models.ScopeScore{ID: id, Controls: *count, Score: score(*count)})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+func isApplicable(count models.StatusCount) bool {
+	return count.Error+count.Fail+count.Pass > 0
+}
+
+// Percentage of passing controls, rounded to 2 decimal places
+func score(count models.StatusCount) float64 {
+	if !isApplicable(count) {
+		return 0
+	}
+	percent := float64(count.Pass) * 100 / float64(count.Error+count.Fail+count.Pass)
+	return math.Round(percent*100) / 100
+}
+
+func buildReport(
+	framework string,
+	controls frameworkControls,
+	entries policyEntries,
+	suppressions []analysismodels.Suppression,
+	generatedAt time.Time,
+) *models.ComplianceReport {
+
+	suppressionsByPolicy := make(map[string][]models.ReportSuppression)
+	for _, suppression := range suppressions {
+		suppressionsByPolicy[suppression.PolicyID] = append(suppressionsByPolicy[suppression.PolicyID],
+			models.ReportSuppression{
+				ResourcePattern: suppression.ResourcePattern,
+				Justification:   suppression.Justification,
+				RequestedBy:     suppression.RequestedBy,
+				ApprovedBy:      suppression.ApprovedBy,
+				TicketURL:       suppression.TicketURL,
+				CreatedAt:       suppression.CreatedAt,
+				ExpiresAt:       suppression.ExpiresAt,
+			})
+	}
+
+	report := &models.ComplianceReport{
+		Framework:   framework,
+		GeneratedAt: generatedAt,
+		Controls:    make([]models.ControlReport, 0, len(controls)),
+		Summary:     scoreFramework(framework, controls, entries),
+	}
+
+	for controlID, policies := range controls {
+		control := models.ControlReport{
+			ControlID: controlID,
+			Policies:  make([]models.PolicyReport, 0, len(policies)),
+		}
+
+		var active models.StatusCount
+		for _, policy := range policies {
+			policyReport := models.PolicyReport{
+				ID:           policy.ID,
+				DisplayName:  policy.DisplayName,
+				Severity:     policy.Severity,
+				Resources:    make([]models.ReportResource, 0, len(entries[policy.ID])),
+				Suppressions: suppressionsByPolicy[policy.ID],
+			}
+			for _, entry := range entries[policy.ID] {
+				if entry.Suppressed {
+					updateStatusCount(&policyReport.Totals.Suppressed, entry.Status)
+				} else {
+					updateStatusCount(&policyReport.Totals.Active, entry.Status)
+					updateStatusCount(&active, entry.Status)
+				}
+				policyReport.Resources = append(policyReport.Resources, models.ReportResource{
+					ErrorMessage:  entry.ErrorMessage,
+					ID:            entry.ResourceID,
+					IntegrationID: entry.IntegrationID,
+					LastUpdated:   entry.LastUpdated,
+					Status:        entry.Status,
+					Suppressed:    entry.Suppressed,
+					Type:          entry.ResourceType,
+				})
+			}
+			policyReport.Status = countToStatus(policyReport.Totals.Active)
+			sort.Slice(policyReport.Resources, func(i, j int) bool {
+				return policyReport.Resources[i].ID < policyReport.Resources[j].ID
+			})
+			control.Policies = append(control.Policies, policyReport)
+		}
+
+		control.Status = countToStatus(active)
+		control.Applicable = isApplicable(active)
+		sort.Slice(control.Policies, func(i, j int) bool { return control.Policies[i].ID < control.Policies[j].ID })
+		report.Controls = append(report.Controls, control)
+	}
+
+	sort.Slice(report.Controls, func(i, j int) bool {
+		return report.Controls[i].ControlID < report.Controls[j].ControlID
+	})
+	return report
+}
+
+var reportCSVHeader = []string{
+	"framework", "controlId", "controlStatus", "policyId", "policySeverity", "policyStatus",
+	"resourceId", "resourceType", "integrationId", "resourceStatus", "suppressed", "lastUpdated",
+}
+
+// Flatten a report into CSV, with one row per control, policy and resource.
+//
+// Policies which have not evaluated any resources get a single row without resource details.
+func reportCSV(report *models.ComplianceReport) (string, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	if err := writer.Write(reportCSVHeader); err != nil {
+		return "", err
+	}
+
+	for _, control := range report.Controls {
+		for _, policy := range control.Policies {
+			prefix := []string{
+				report.Framework, control.ControlID, string(control.Status),
+				policy.ID, string(policy.Severity), string(policy.Status),
+			}
+			if len(policy.Resources) == 0 {
+				if err := writer.Write(append(prefix, "", "", "", "", "", "")); err != nil {
+					return "", err
+				}
+				continue
+			}
+			for _, resource := range policy.Resources {
+				row := append(prefix[:len(prefix):len(prefix)],
+					resource.ID, resource.Type, resource.IntegrationID, string(resource.Status),
+					strconv.FormatBool(resource.Suppressed), resource.LastUpdated.Format(time.RFC3339))
+				if err := writer.Write(row); err != nil {
+					return "", err
+				}
+			}
+		}
+	}
+
+	writer.Flush()
+	return buffer.String(), writer.Error()
+}
+
+func uniqueSorted(values []string) []string {
+	sort.Strings(values)
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if len(result) == 0 || value != result[len(result)-1] {
+			result = append(result, value)
+		}
+	}
+	return result
+}
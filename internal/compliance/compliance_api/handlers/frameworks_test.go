@@ -0,0 +1,123 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	analysismodels "github.com/panther-labs/panther/api/lambda/analysis/models"
+	"github.com/panther-labs/panther/api/lambda/compliance/models"
+)
+
+var (
+	frameworkPolicies = []analysismodels.Policy{
+		{
+			ID:       "S3.Encryption",
+			Reports:  map[string][]string{"CIS": {"2.1"}, "PCI": {"3.4"}},
+			Severity: models.SeverityHigh,
+		},
+		{
+			ID:       "S3.Versioning",
+			Reports:  map[string][]string{"cis": {"2.1", "2.2"}},
+			Severity: models.SeverityLow,
+		},
+		{
+			ID:       "IAM.MFA",
+			Reports:  map[string][]string{"CIS": {"1.1"}, "MITRE ATT&CK": {"TA0001"}},
+			Severity: models.SeverityCritical,
+		},
+	}
+
+	frameworkEntries = policyEntries{
+		"S3.Encryption": {
+			{PolicyID: "S3.Encryption", ResourceID: "bucket-a", ResourceType: "AWS.S3.Bucket", IntegrationID: "account-1", Status: models.StatusPass},
+			{PolicyID: "S3.Encryption", ResourceID: "bucket-b", ResourceType: "AWS.S3.Bucket", IntegrationID: "account-2", Status: models.StatusFail},
+		},
+		"S3.Versioning": {
+			{PolicyID: "S3.Versioning", ResourceID: "bucket-a", ResourceType: "AWS.S3.Bucket", IntegrationID: "account-1", Status: models.StatusPass},
+			{PolicyID: "S3.Versioning", ResourceID: "bucket-b", ResourceType: "AWS.S3.Bucket", IntegrationID: "account-2", Status: models.StatusFail, Suppressed: true},
+		},
+	}
+)
+
+func TestMapControls(t *testing.T) {
+	controls := mapControls(frameworkPolicies, "CIS")
+	require.Len(t, controls, 3)
+	assert.Len(t, controls["2.1"], 2)
+	assert.Equal(t, "S3.Versioning", controls["2.2"][0].ID)
+	assert.Equal(t, "IAM.MFA", controls["1.1"][0].ID)
+
+	assert.Empty(t, mapControls(frameworkPolicies, "SOC2"))
+}
+
+func TestScoreFramework(t *testing.T) {
+	result := scoreFramework("CIS", mapControls(frameworkPolicies, "CIS"), frameworkEntries)
+
+	// 1.1 has no evaluated resources, 2.1 fails on bucket-b, 2.2 passes (the failure is suppressed)
+	assert.Equal(t, models.StatusCount{Fail: 1, Pass: 1}, result.Controls)
+	assert.Equal(t, float64(50), result.Score)
+	assert.Equal(t, []models.ScopeScore{
+		{ID: "account-1", Controls: models.StatusCount{Pass: 2}, Score: 100},
+		{ID: "account-2", Controls: models.StatusCount{Fail: 1}, Score: 0},
+	}, result.ByIntegration)
+	assert.Equal(t, []models.ScopeScore{
+		{ID: "AWS.S3.Bucket", Controls: models.StatusCount{Fail: 1, Pass: 1}, Score: 50},
+	}, result.ByResourceType)
+}
+
+func TestBuildReport(t *testing.T) {
+	expiresAt := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	suppressions := []analysismodels.Suppression{
+		{PolicyID: "S3.Versioning", ResourcePattern: "bucket-b", Justification: "log archive", ExpiresAt: &expiresAt},
+	}
+	generatedAt := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	report := buildReport("CIS", mapControls(frameworkPolicies, "CIS"), frameworkEntries, suppressions, generatedAt)
+
+	require.Len(t, report.Controls, 3)
+	assert.Equal(t, "1.1", report.Controls[0].ControlID)
+	assert.False(t, report.Controls[0].Applicable)
+	assert.Equal(t, models.StatusPass, report.Controls[0].Status)
+
+	control := report.Controls[1]
+	assert.Equal(t, "2.1", control.ControlID)
+	assert.True(t, control.Applicable)
+	assert.Equal(t, models.StatusFail, control.Status)
+	require.Len(t, control.Policies, 2)
+	assert.Equal(t, "S3.Encryption", control.Policies[0].ID)
+	assert.Equal(t, models.StatusCount{Fail: 1, Pass: 1}, control.Policies[0].Totals.Active)
+	versioning := control.Policies[1]
+	assert.Equal(t, models.StatusPass, versioning.Status)
+	assert.Equal(t, models.StatusCount{Fail: 1}, versioning.Totals.Suppressed)
+	require.Len(t, versioning.Suppressions, 1)
+	assert.Equal(t, "log archive", versioning.Suppressions[0].Justification)
+
+	csvReport, err := reportCSV(report)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(csvReport), "\n")
+	// header + 1.1 (no resources) + 2.1 (2 policies x 2 resources) + 2.2 (2 resources)
+	require.Len(t, lines, 8)
+	assert.Equal(t, strings.Join(reportCSVHeader, ","), lines[0])
+	assert.Equal(t, "CIS,1.1,PASS,IAM.MFA,CRITICAL,PASS,,,,,,", lines[1])
+	assert.Equal(t, "CIS,2.1,FAIL,S3.Encryption,HIGH,FAIL,bucket-b,AWS.S3.Bucket,account-2,FAIL,false,0001-01-01T00:00:00Z", lines[3])
+}
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
 	"github.com/aws/aws-sdk-go/service/firehose"
 	"github.com/aws/aws-sdk-go/service/firehose/firehoseiface"
 	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
@@ -32,6 +33,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/panther-labs/panther/internal/compliance/datalake_forwarder/forwarder/events"
+	"github.com/panther-labs/panther/internal/compliance/resourcehistory"
 	"github.com/panther-labs/panther/pkg/awsbatch/firehosebatch"
 )
 
@@ -45,9 +47,11 @@ const (
 )
 
 type StreamHandler struct {
+	DynamoClient       dynamodbiface.DynamoDBAPI
 	FirehoseClient     firehoseiface.FirehoseAPI
 	LambdaClient       lambdaiface.LambdaAPI
 	StreamName         string
+	HistoryTable       string // resource history is not recorded if empty
 	integrationIDCache map[string]string
 	lastUpdatedCache   time.Time
 }
@@ -55,6 +59,7 @@ type StreamHandler struct {
 // Run is the entry point for the datalake-forwarder lambda
 func (sh *StreamHandler) Run(ctx context.Context, logger *zap.Logger, event *events.DynamoDBEvent) (err error) {
 	firehoseRecords := make([]*firehose.Record, 0, len(event.Records))
+	var historyRecords []*resourcehistory.Record
 	for i := range event.Records {
 		// We should be passing pointers to avoid copy of the record struct
 		record := &event.Records[i]
@@ -77,6 +82,9 @@ func (sh *StreamHandler) Run(ctx context.Context, logger *zap.Logger, event *eve
 			)
 			continue
 		}
+		if history := historyRecord(changes); history != nil {
+			historyRecords = append(historyRecords, history)
+		}
 		data, err := jsoniter.Marshal(changes)
 		if err != nil {
 			logger.Error("failed to get marshal changes to JSON", zap.Error(err), zap.String("eventId", record.EventID))
@@ -87,6 +95,13 @@ func (sh *StreamHandler) Run(ctx context.Context, logger *zap.Logger, event *eve
 		firehoseRecords = append(firehoseRecords, &firehose.Record{Data: data})
 	}
 
+	if sh.HistoryTable != "" {
+		if err := resourcehistory.Put(sh.DynamoClient, sh.HistoryTable, historyRecords); err != nil {
+			// The data lake remains the primary destination, keep forwarding
+			logger.Error("failed to record resource history", zap.Error(err))
+		}
+	}
+
 	if len(firehoseRecords) == 0 {
 		logger.Debug("no records to process")
 		return nil
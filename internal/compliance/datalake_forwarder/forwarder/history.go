@@ -0,0 +1,64 @@
+package forwarder
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/compliance/resourcehistory"
+)
+
+// historyRecord converts a change forwarded to the data lake into a resource history record.
+//
+// Returns nil if the change does not need to be recorded in the resource history.
+func historyRecord(change interface{}) *resourcehistory.Record {
+	switch change := change.(type) {
+	case *ResourceChange:
+		// Syncs don't change the configuration of the resource
+		if change.ChangeType == ChangeTypeSync {
+			return nil
+		}
+		var resourceType string
+		if change.ResourceType != nil {
+			resourceType = *change.ResourceType
+		}
+		// Deletes carry the last known snapshot, which was modified before the deletion
+		deleted := change.ChangeType == ChangeTypeDelete
+		timestamp := resourcehistory.ParseTimestamp(change.LastUpdated)
+		if deleted {
+			timestamp = time.Now()
+		}
+		return resourcehistory.NewConfigRecord(
+			change.ID, resourceType, change.IntegrationID, change.Resource, deleted, timestamp)
+	case *ComplianceChange:
+		// Status entries are deleted when the resource or policy is deleted, which the
+		// CONFIG history already reflects
+		if change.ChangeType == ChangeTypeDelete {
+			return nil
+		}
+		return resourcehistory.NewComplianceRecord(
+			change.ResourceID,
+			change.PolicyID,
+			change.Status,
+			resourcehistory.ParseTimestamp(change.LastUpdated),
+		)
+	default:
+		return nil
+	}
+}
@@ -21,8 +21,6 @@ package forwarder
 import (
 	"time"
 
-	"github.com/aws/aws-sdk-go/aws"
-
 	"github.com/panther-labs/panther/api/lambda/source/models"
 	"github.com/panther-labs/panther/pkg/genericapi"
 )
@@ -47,10 +45,9 @@ func (sh *StreamHandler) getIntegrationLabel(integrationID string) (string, erro
 }
 
 func (sh *StreamHandler) updateIntegrationMapping() error {
+	// Resources and compliance statuses come from the cloud security sources of every provider
 	input := &models.LambdaInput{
-		ListIntegrations: &models.ListIntegrationsInput{
-			IntegrationType: aws.String(models.IntegrationTypeAWSScan),
-		},
+		ListIntegrations: &models.ListIntegrationsInput{},
 	}
 	var output []*models.SourceIntegration
 	if err := genericapi.Invoke(sh.LambdaClient, sourceAPIFunctionName, input, &output); err != nil {
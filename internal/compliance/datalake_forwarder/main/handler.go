@@ -26,6 +26,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/firehose"
 	lambdaservice "github.com/aws/aws-sdk-go/service/lambda"
 	"github.com/kelseyhightower/envconfig"
@@ -54,7 +55,8 @@ func Invoke(ctx context.Context, event *events.DynamoDBEvent) error {
 }
 
 type EnvConfig struct {
-	StreamName string `required:"true" split_words:"true"`
+	StreamName   string `required:"true" split_words:"true"`
+	HistoryTable string `split_words:"true"`
 }
 
 func NewHandler() *forwarder.StreamHandler {
@@ -67,8 +69,10 @@ func NewHandler() *forwarder.StreamHandler {
 	firehoseClient := firehose.New(awsSession)
 	lambdaClient := lambdaservice.New(awsSession)
 	return &forwarder.StreamHandler{
+		DynamoClient:   dynamodb.New(awsSession),
 		LambdaClient:   lambdaClient,
 		FirehoseClient: firehoseClient,
 		StreamName:     config.StreamName,
+		HistoryTable:   config.HistoryTable,
 	}
 }
@@ -24,7 +24,8 @@ from .policy import PolicySet
 def analyze(data: Dict[str, Any]) -> Dict[str, Any]:
     """Run the Python analysis"""
     policy_set = PolicySet(data['policies'])
-    result = {'resources': [policy_set.analyze(r) for r in data['resources']]}
+    related_resources = data.get('relatedResources') or {}
+    result = {'resources': [policy_set.analyze(r, related_resources) for r in data['resources']]}
     return result
 
 
@@ -16,7 +16,7 @@
 """Classes to represent a Panther policy and a collection of policies."""
 import collections
 from importlib import util as import_util
-from typing import Any, Dict, List, Union
+from typing import Any, Dict, List, Optional, Union
 
 
 class Policy:
@@ -83,9 +83,11 @@ class PolicySet:
             else:
                 self._global_policies.append(policy)
 
-    def analyze(self, resource: Dict[str, Any]) -> Dict[str, Any]:
+    def analyze(self, resource: Dict[str, Any], related_resources: Optional[Dict[str, Any]] = None) -> Dict[str, Any]:
         """Analyze a resource with this policy set.
 
+        The resource references its related resources by ID, related_resources maps each ID to the resource.
+
         Returns:
             {
                 'id': 'arn:aws:s3:::my-bucket',
@@ -103,8 +105,18 @@ class PolicySet:
         failed: List[str] = []
         passed: List[str] = []
 
+        attributes = resource['attributes']
+        if resource.get('related') and isinstance(attributes, dict):
+            # Related resources are available to policies as resource['p_related'][relation]
+            related_resources = related_resources or {}
+            related = {
+                relation: [related_resources[resource_id] for resource_id in ids if resource_id in related_resources]
+                for relation, ids in resource['related'].items()
+            }
+            attributes = dict(attributes, p_related=related)
+
         for policy in self._policies_by_type[resource['type']] + self._global_policies:
-            result = policy.run(resource['attributes'])
+            result = policy.run(attributes)
             if isinstance(result, Exception):
                 errored.append({'id': policy.policy_id, 'message': '{}: {}'.format(type(result).__name__, result)})
             elif result is False:
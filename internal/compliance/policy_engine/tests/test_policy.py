@@ -127,3 +127,37 @@ class TestPolicySet(unittest.TestCase):
         }
 
         self.assertEqual(expected, result)
+
+    def test_analyze_related(self) -> None:
+        """Policies can read the resources related to the analyzed resource."""
+        path_related = os.path.join(tempfile.gettempdir(), 'panther-related.py')
+        with open(path_related, 'w') as policy_file:
+            policy_file.write(
+                'def policy(resource): return all(group[\'attributes\'][\'open\'] is False '
+                'for group in resource[\'p_related\'][\'securityGroup\'])'
+            )
+        policy_set = PolicySet([{'body': path_related, 'id': 'test-policy-related'}])
+
+        resource = {
+            'attributes': {
+                'hello': 'world'
+            },
+            'id': 'arn:aws:ec2:us-west-2:123456789012:instance/i-1',
+            'type': 'AWS.EC2.Instance',
+            'related': {
+                'securityGroup': ['arn:aws:ec2:us-west-2:123456789012:security-group/sg-1']
+            }
+        }
+        related_resources = {
+            'arn:aws:ec2:us-west-2:123456789012:security-group/sg-1': {
+                'attributes': {
+                    'open': True
+                },
+                'id': 'arn:aws:ec2:us-west-2:123456789012:security-group/sg-1',
+                'type': 'AWS.EC2.SecurityGroup'
+            }
+        }
+        result = policy_set.analyze(resource, related_resources)
+
+        self.assertEqual(['test-policy-related'], result['failed'])
+        self.assertEqual({'hello': 'world'}, resource['attributes'])
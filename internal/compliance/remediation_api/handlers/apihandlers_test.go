@@ -30,9 +30,42 @@ type mockInvoker struct {
 	mock.Mock
 }
 
-func (m *mockInvoker) Remediate(input *models.RemediateResourceInput) error {
+func (m *mockInvoker) Remediate(input *models.RemediateResourceInput) (*models.Remediation, error) {
 	args := m.Called(input)
-	return args.Error(0)
+	return remediationResult(args)
+}
+
+func (m *mockInvoker) Approve(input *models.ReviewRemediationInput) (*models.Remediation, error) {
+	args := m.Called(input)
+	return remediationResult(args)
+}
+
+func (m *mockInvoker) Deny(input *models.ReviewRemediationInput) (*models.Remediation, error) {
+	args := m.Called(input)
+	return remediationResult(args)
+}
+
+func (m *mockInvoker) ListHistory(input *models.ListRemediationHistoryInput) (*models.ListRemediationHistoryOutput, error) {
+	args := m.Called(input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ListRemediationHistoryOutput), args.Error(1)
+}
+
+func (m *mockInvoker) ExpirePending() (*models.ExpireRemediationsOutput, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ExpireRemediationsOutput), args.Error(1)
+}
+
+func remediationResult(args mock.Arguments) (*models.Remediation, error) {
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Remediation), args.Error(1)
 }
 
 func (m *mockInvoker) GetRemediations() (*models.ListRemediationsOutput, error) {
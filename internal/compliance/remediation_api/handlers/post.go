@@ -28,13 +28,15 @@ import (
 
 	"github.com/panther-labs/panther/api/lambda/remediation/models"
 	"github.com/panther-labs/panther/internal/compliance/remediation_api/remediation"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
 )
 
-// RemediateResource remediates a resource synchronously
+// RemediateResource remediates a resource synchronously, or creates a request pending approval
 func (API) RemediateResource(request *models.RemediateResourceInput) *events.APIGatewayProxyResponse {
 	zap.L().Debug("invoking remediation synchronously")
 
-	if err := invoker.Remediate(request); err != nil {
+	record, err := invoker.Remediate(request)
+	if err != nil {
 		if err == remediation.ErrNotFound {
 			return &events.APIGatewayProxyResponse{
 				Body:       err.Error(),
@@ -45,10 +47,14 @@ func (API) RemediateResource(request *models.RemediateResourceInput) *events.API
 		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
 	}
 
+	if record.Status == models.StatusPendingApproval {
+		return gatewayapi.MarshalResponse(record, http.StatusCreated)
+	}
+
 	zap.L().Debug("successfully invoked remediation",
 		zap.Any("policyId", request.PolicyID),
 		zap.Any("resourceId", request.ResourceID))
-	return &events.APIGatewayProxyResponse{StatusCode: http.StatusOK}
+	return gatewayapi.MarshalResponse(record, http.StatusOK)
 }
 
 // RemediateResourceAsync triggers remediation for a resource. The remediation is asynchronous
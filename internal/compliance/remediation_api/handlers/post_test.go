@@ -24,10 +24,13 @@ import (
 
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	jsoniter "github.com/json-iterator/go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/panther-labs/panther/api/lambda/remediation/models"
+	"github.com/panther-labs/panther/internal/compliance/remediation_api/remediation"
 )
 
 type mockSqsClient struct {
@@ -51,15 +54,41 @@ func TestRemediateResource(t *testing.T) {
 	mockSqsClient := &mockSqsClient{}
 	sqsClient = mockSqsClient
 
-	mockInvoker.On("Remediate", input).Return(nil)
+	record := &models.Remediation{ID: "id", Status: models.StatusSucceeded}
+	mockInvoker.On("Remediate", input).Return(record, nil)
 
 	response := API{}.RemediateResource(input)
 	assert.Equal(t, http.StatusOK, response.StatusCode)
-	assert.Equal(t, "", response.Body)
+	var result models.Remediation
+	require.NoError(t, jsoniter.UnmarshalFromString(response.Body, &result))
+	assert.Equal(t, *record, result)
 	mockInvoker.AssertExpectations(t)
 	mockSqsClient.AssertExpectations(t)
 }
 
+func TestRemediateResourcePendingApproval(t *testing.T) {
+	mockInvoker := &mockInvoker{}
+	invoker = mockInvoker
+
+	record := &models.Remediation{ID: "id", Status: models.StatusPendingApproval}
+	mockInvoker.On("Remediate", input).Return(record, nil)
+
+	response := API{}.RemediateResource(input)
+	assert.Equal(t, http.StatusCreated, response.StatusCode)
+	mockInvoker.AssertExpectations(t)
+}
+
+func TestRemediateResourceNotFound(t *testing.T) {
+	mockInvoker := &mockInvoker{}
+	invoker = mockInvoker
+
+	mockInvoker.On("Remediate", input).Return(nil, remediation.ErrNotFound)
+
+	response := API{}.RemediateResource(input)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	mockInvoker.AssertExpectations(t)
+}
+
 func TestRemediateResourceAsync(t *testing.T) {
 	mockInvoker := &mockInvoker{}
 	invoker = mockInvoker
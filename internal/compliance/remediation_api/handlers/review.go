@@ -0,0 +1,76 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/lambda/remediation/models"
+	"github.com/panther-labs/panther/internal/compliance/remediation_api/remediation"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+)
+
+// ApproveRemediation approves a remediation pending approval and runs it
+func (API) ApproveRemediation(input *models.ReviewRemediationInput) *events.APIGatewayProxyResponse {
+	record, err := invoker.Approve(input)
+	return reviewResponse(record, err)
+}
+
+// DenyRemediation denies a remediation pending approval
+func (API) DenyRemediation(input *models.ReviewRemediationInput) *events.APIGatewayProxyResponse {
+	record, err := invoker.Deny(input)
+	return reviewResponse(record, err)
+}
+
+func reviewResponse(record *models.Remediation, err error) *events.APIGatewayProxyResponse {
+	switch err {
+	case nil:
+		return gatewayapi.MarshalResponse(record, http.StatusOK)
+	case remediation.ErrRemediationNotFound:
+		return &events.APIGatewayProxyResponse{Body: err.Error(), StatusCode: http.StatusNotFound}
+	case remediation.ErrNotPending:
+		return &events.APIGatewayProxyResponse{Body: err.Error(), StatusCode: http.StatusBadRequest}
+	default:
+		zap.L().Warn("failed to review remediation", zap.Error(err))
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+}
+
+// ListRemediationHistory returns the remediations of a resource or an alert
+func (API) ListRemediationHistory(input *models.ListRemediationHistoryInput) *events.APIGatewayProxyResponse {
+	result, err := invoker.ListHistory(input)
+	if err != nil {
+		zap.L().Warn("failed to list remediation history", zap.Error(err))
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+	return gatewayapi.MarshalResponse(result, http.StatusOK)
+}
+
+// ExpireRemediations marks the remediations that were not reviewed in time as expired
+func (API) ExpireRemediations(_ *models.ExpireRemediationsInput) *events.APIGatewayProxyResponse {
+	result, err := invoker.ExpirePending()
+	if err != nil {
+		zap.L().Error("failed to expire remediations", zap.Error(err))
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+	return gatewayapi.MarshalResponse(result, http.StatusOK)
+}
@@ -0,0 +1,70 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/panther-labs/panther/api/lambda/remediation/models"
+	"github.com/panther-labs/panther/internal/compliance/remediation_api/remediation"
+)
+
+var reviewInput = &models.ReviewRemediationInput{ID: "id", UserID: "user"}
+
+func TestApproveRemediation(t *testing.T) {
+	mockInvoker := &mockInvoker{}
+	invoker = mockInvoker
+
+	mockInvoker.On("Approve", reviewInput).Return(&models.Remediation{ID: "id", Status: models.StatusSucceeded}, nil)
+
+	response := API{}.ApproveRemediation(reviewInput)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	mockInvoker.AssertExpectations(t)
+}
+
+func TestReviewRemediationErrors(t *testing.T) {
+	mockInvoker := &mockInvoker{}
+	invoker = mockInvoker
+
+	mockInvoker.On("Deny", reviewInput).Return(nil, remediation.ErrRemediationNotFound).Once()
+	assert.Equal(t, http.StatusNotFound, API{}.DenyRemediation(reviewInput).StatusCode)
+
+	mockInvoker.On("Deny", reviewInput).Return(nil, remediation.ErrNotPending).Once()
+	assert.Equal(t, http.StatusBadRequest, API{}.DenyRemediation(reviewInput).StatusCode)
+
+	mockInvoker.On("Approve", reviewInput).Return(nil, errors.New("remediation failed")).Once()
+	assert.Equal(t, http.StatusInternalServerError, API{}.ApproveRemediation(reviewInput).StatusCode)
+	mockInvoker.AssertExpectations(t)
+}
+
+func TestExpireRemediations(t *testing.T) {
+	mockInvoker := &mockInvoker{}
+	invoker = mockInvoker
+
+	mockInvoker.On("ExpirePending").Return(&models.ExpireRemediationsOutput{Expired: []string{"id"}}, nil).Once()
+	assert.Equal(t, http.StatusOK, API{}.ExpireRemediations(&models.ExpireRemediationsInput{}).StatusCode)
+
+	mockInvoker.On("ExpirePending").Return(nil, errors.New("query failed")).Once()
+	assert.Equal(t, http.StatusInternalServerError, API{}.ExpireRemediations(&models.ExpireRemediationsInput{}).StatusCode)
+	mockInvoker.AssertExpectations(t)
+}
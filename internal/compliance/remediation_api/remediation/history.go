@@ -0,0 +1,272 @@
+package remediation
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"os"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	remediationmodels "github.com/panther-labs/panther/api/lambda/remediation/models"
+)
+
+// Remediation records are kept for a year
+const recordRetention = 365 * 24 * time.Hour
+
+var (
+	remediationsTable = os.Getenv("REMEDIATIONS_TABLE")
+
+	ErrRemediationNotFound = errors.New("Remediation does not exist")
+	ErrNotPending          = errors.New("Remediation is not pending approval")
+)
+
+// The record stored in Dynamo has a TTL in addition to the external model
+type remediationItem struct {
+	remediationmodels.Remediation
+	ExpiresAt int64 `json:"expiresAt"`
+	// Only set while the remediation is pending approval so that the pending-index is sparse
+	PendingApproval string `json:"pendingApproval,omitempty"`
+}
+
+const pendingApprovalKey = "PENDING"
+
+// Approve marks a pending remediation as approved and runs it.
+func (remediator *Invoker) Approve(input *remediationmodels.ReviewRemediationInput) (*remediationmodels.Remediation, error) {
+	record, err := remediator.review(input, remediationmodels.StatusApproved)
+	if err != nil {
+		return nil, err
+	}
+	return record, remediator.execute(record)
+}
+
+// Deny marks a pending remediation as denied, it will never run.
+func (remediator *Invoker) Deny(input *remediationmodels.ReviewRemediationInput) (*remediationmodels.Remediation, error) {
+	return remediator.review(input, remediationmodels.StatusDenied)
+}
+
+func (remediator *Invoker) review(
+	input *remediationmodels.ReviewRemediationInput, status remediationmodels.RemediationStatus) (*remediationmodels.Remediation, error) {
+
+	record, err := remediator.getRecord(input.ID)
+	if err != nil {
+		return nil, err
+	}
+	if record.Status != remediationmodels.StatusPendingApproval {
+		return nil, ErrNotPending
+	}
+
+	now := time.Now().UTC()
+	if isExpired(record, now) {
+		record.Status = remediationmodels.StatusExpired
+		if err := remediator.putRecord(record, true); err != nil && err != ErrNotPending {
+			return nil, err
+		}
+		return nil, ErrNotPending
+	}
+
+	record.Status = status
+	record.ReviewedAt = &now
+	record.ReviewedBy = input.UserID
+	record.ReviewComment = input.Comment
+	// The condition ensures a remediation is reviewed (and executed) only once
+	if err := remediator.putRecord(record, true); err != nil {
+		return nil, err
+	}
+
+	zap.L().Info("remediation reviewed",
+		zap.String("id", record.ID), zap.String("status", string(status)), zap.String("userId", input.UserID))
+	return record, nil
+}
+
+// ListHistory returns the remediations of a resource or an alert, most recent first.
+func (remediator *Invoker) ListHistory(
+	input *remediationmodels.ListRemediationHistoryInput) (*remediationmodels.ListRemediationHistoryOutput, error) {
+
+	keyName, indexName, keyValue := "resourceId", "resource-index", input.ResourceID
+	if input.AlertID != "" {
+		keyName, indexName, keyValue = "alertId", "alert-index", input.AlertID
+	}
+
+	expr, err := expression.NewBuilder().
+		WithKeyCondition(expression.Key(keyName).Equal(expression.Value(keyValue))).
+		Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build remediation history query")
+	}
+
+	var items []*remediationItem
+	var unmarshalErr error
+	err = remediator.dynamoClient.QueryPages(&dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		IndexName:                 aws.String(indexName),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 &remediationsTable,
+	}, func(page *dynamodb.QueryOutput, lastPage bool) bool {
+		var pageItems []*remediationItem
+		if unmarshalErr = dynamodbattribute.UnmarshalListOfMaps(page.Items, &pageItems); unmarshalErr != nil {
+			return false // stop paginating
+		}
+		items = append(items, pageItems...)
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query remediation history")
+	}
+	if unmarshalErr != nil {
+		return nil, errors.Wrap(unmarshalErr, "failed to unmarshal remediation history")
+	}
+
+	now := time.Now().UTC()
+	result := &remediationmodels.ListRemediationHistoryOutput{
+		Remediations: make([]remediationmodels.Remediation, 0, len(items)),
+	}
+	for _, item := range items {
+		// Expired requests are updated in the table by ExpirePending, this covers the time until its next run
+		if isExpired(&item.Remediation, now) {
+			item.Status = remediationmodels.StatusExpired
+		}
+		result.Remediations = append(result.Remediations, item.Remediation)
+	}
+	sort.Slice(result.Remediations, func(i, j int) bool {
+		return result.Remediations[i].RequestedAt.After(result.Remediations[j].RequestedAt)
+	})
+	return result, nil
+}
+
+// ExpirePending marks the remediations that were not reviewed in time as expired.
+// It is invoked on a schedule so that expired requests are not left pending in the table.
+func (remediator *Invoker) ExpirePending() (*remediationmodels.ExpireRemediationsOutput, error) {
+	expr, err := expression.NewBuilder().
+		WithKeyCondition(expression.Key("pendingApproval").Equal(expression.Value(pendingApprovalKey))).
+		Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build pending remediations query")
+	}
+
+	var items []*remediationItem
+	var unmarshalErr error
+	err = remediator.dynamoClient.QueryPages(&dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		IndexName:                 aws.String("pending-index"),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 &remediationsTable,
+	}, func(page *dynamodb.QueryOutput, lastPage bool) bool {
+		var pageItems []*remediationItem
+		if unmarshalErr = dynamodbattribute.UnmarshalListOfMaps(page.Items, &pageItems); unmarshalErr != nil {
+			return false // stop paginating
+		}
+		items = append(items, pageItems...)
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query pending remediations")
+	}
+	if unmarshalErr != nil {
+		return nil, errors.Wrap(unmarshalErr, "failed to unmarshal pending remediations")
+	}
+
+	now := time.Now().UTC()
+	result := &remediationmodels.ExpireRemediationsOutput{Expired: []string{}}
+	for _, item := range items {
+		record := &item.Remediation
+		if !isExpired(record, now) {
+			continue
+		}
+		record.Status = remediationmodels.StatusExpired
+		// A remediation reviewed in the meantime is left as is
+		if err := remediator.putRecord(record, true); err != nil {
+			if err == ErrNotPending {
+				continue
+			}
+			return nil, err
+		}
+		zap.L().Info("remediation approval expired", zap.String("id", record.ID))
+		result.Expired = append(result.Expired, record.ID)
+	}
+	return result, nil
+}
+
+func isExpired(record *remediationmodels.Remediation, now time.Time) bool {
+	return record.Status == remediationmodels.StatusPendingApproval &&
+		record.ApprovalExpiresAt != nil && now.After(*record.ApprovalExpiresAt)
+}
+
+func (remediator *Invoker) getRecord(id string) (*remediationmodels.Remediation, error) {
+	response, err := remediator.dynamoClient.GetItem(&dynamodb.GetItemInput{
+		Key:       map[string]*dynamodb.AttributeValue{"id": {S: aws.String(id)}},
+		TableName: &remediationsTable,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get remediation %s", id)
+	}
+	if len(response.Item) == 0 {
+		return nil, ErrRemediationNotFound
+	}
+
+	var item remediationItem
+	if err := dynamodbattribute.UnmarshalMap(response.Item, &item); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal remediation")
+	}
+	return &item.Remediation, nil
+}
+
+// putRecord stores a remediation, if pendingOnly it must still be pending approval in the table.
+func (remediator *Invoker) putRecord(record *remediationmodels.Remediation, pendingOnly bool) error {
+	stored := &remediationItem{
+		Remediation: *record,
+		ExpiresAt:   record.RequestedAt.Add(recordRetention).Unix(),
+	}
+	if record.Status == remediationmodels.StatusPendingApproval {
+		stored.PendingApproval = pendingApprovalKey
+	}
+	item, err := dynamodbattribute.MarshalMap(stored)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal remediation")
+	}
+
+	input := &dynamodb.PutItemInput{Item: item, TableName: &remediationsTable}
+	if pendingOnly {
+		condition := expression.Name("status").Equal(expression.Value(remediationmodels.StatusPendingApproval))
+		expr, err := expression.NewBuilder().WithCondition(condition).Build()
+		if err != nil {
+			return errors.Wrap(err, "failed to build remediation condition")
+		}
+		input.ConditionExpression = expr.Condition()
+		input.ExpressionAttributeNames = expr.Names()
+		input.ExpressionAttributeValues = expr.Values()
+	}
+
+	if _, err := remediator.dynamoClient.PutItem(input); err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return ErrNotPending
+		}
+		return errors.Wrapf(err, "failed to store remediation %s", record.ID)
+	}
+	return nil
+}
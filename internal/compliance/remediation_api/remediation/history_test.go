@@ -0,0 +1,273 @@
+package remediation
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	analysismodels "github.com/panther-labs/panther/api/lambda/analysis/models"
+	remediationmodels "github.com/panther-labs/panther/api/lambda/remediation/models"
+	resourcemodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+func marshalRecord(t *testing.T, record *remediationmodels.Remediation) map[string]*dynamodb.AttributeValue {
+	item, err := dynamodbattribute.MarshalMap(&remediationItem{Remediation: *record})
+	require.NoError(t, err)
+	return item
+}
+
+func pendingRecord(expiresAt time.Time) *remediationmodels.Remediation {
+	return &remediationmodels.Remediation{
+		ID:                "id",
+		PolicyID:          "policyId",
+		ResourceID:        "resourceId",
+		RemediationID:     "AWS.S3.EnableBucketEncryption",
+		Status:            remediationmodels.StatusPendingApproval,
+		RequestedAt:       expiresAt.Add(-time.Hour),
+		ApprovalExpiresAt: &expiresAt,
+	}
+}
+
+func TestRemediateRequiresApproval(t *testing.T) {
+	mockAnalysisClient := &gatewayapi.MockClient{}
+	analysisClient = mockAnalysisClient
+	mockAnalysisClient.On("Invoke", mock.Anything, &analysismodels.Policy{}).Return(http.StatusOK, nil, policy).Once()
+
+	mockDynamo := &testutils.DynamoDBMock{}
+	mockDynamo.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil).Once()
+	// The remediation lambda must not be invoked
+	mockClient := &mockLambdaClient{}
+	remediator := &Invoker{lambdaClient: mockClient, dynamoClient: mockDynamo}
+
+	result, err := remediator.Remediate(&remediationmodels.RemediateResourceInput{
+		PolicyID:               "policyId",
+		ResourceID:             "resourceId",
+		UserID:                 "user",
+		RequireApproval:        true,
+		ApprovalTimeoutMinutes: 60,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, remediationmodels.StatusPendingApproval, result.Status)
+	assert.Equal(t, "user", result.RequestedBy)
+	assert.Equal(t, result.RequestedAt.Add(time.Hour), *result.ApprovalExpiresAt)
+	assert.Nil(t, result.ExecutedAt)
+	mockAnalysisClient.AssertExpectations(t)
+	mockDynamo.AssertExpectations(t)
+	mockClient.AssertExpectations(t)
+}
+
+func TestApprove(t *testing.T) {
+	record := pendingRecord(time.Now().Add(time.Hour))
+	mockDynamo := &testutils.DynamoDBMock{}
+	mockDynamo.On("GetItem", mock.Anything).Return(&dynamodb.GetItemOutput{Item: marshalRecord(t, record)}, nil)
+	// The review is conditional on the request still pending, the execution result is not
+	mockDynamo.On("PutItem", mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
+		return input.ConditionExpression != nil
+	})).Return(&dynamodb.PutItemOutput{}, nil).Once()
+	mockDynamo.On("PutItem", mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
+		return input.ConditionExpression == nil
+	})).Return(&dynamodb.PutItemOutput{}, nil).Once()
+
+	mockResourcesClient := &gatewayapi.MockClient{}
+	resourcesClient = mockResourcesClient
+	mockResourcesClient.On("Invoke", mock.Anything, &resourcemodels.Resource{}).Return(http.StatusOK, nil, resource)
+
+	mockClient := &mockLambdaClient{}
+	mockClient.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{Payload: []byte(`{"applied": true}`)}, nil)
+	remediator := &Invoker{lambdaClient: mockClient, dynamoClient: mockDynamo}
+
+	result, err := remediator.Approve(&remediationmodels.ReviewRemediationInput{ID: "id", UserID: "approver", Comment: "ok"})
+	require.NoError(t, err)
+	assert.Equal(t, remediationmodels.StatusSucceeded, result.Status)
+	assert.Equal(t, "approver", result.ReviewedBy)
+	assert.Equal(t, "ok", result.ReviewComment)
+	assert.Equal(t, map[string]interface{}{"applied": true}, result.Result)
+	mockDynamo.AssertExpectations(t)
+	mockClient.AssertExpectations(t)
+}
+
+func TestApproveFailedExecution(t *testing.T) {
+	record := pendingRecord(time.Now().Add(time.Hour))
+	mockDynamo := &testutils.DynamoDBMock{}
+	mockDynamo.On("GetItem", mock.Anything).Return(&dynamodb.GetItemOutput{Item: marshalRecord(t, record)}, nil)
+	mockDynamo.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil).Twice()
+
+	mockResourcesClient := &gatewayapi.MockClient{}
+	resourcesClient = mockResourcesClient
+	mockResourcesClient.On("Invoke", mock.Anything, &resourcemodels.Resource{}).Return(http.StatusOK, nil, resource)
+
+	mockClient := &mockLambdaClient{}
+	mockClient.On("Invoke", mock.Anything).Return(
+		&lambda.InvokeOutput{FunctionError: aws.String("Unhandled"), Payload: []byte("AccessDenied")}, nil)
+	remediator := &Invoker{lambdaClient: mockClient, dynamoClient: mockDynamo}
+
+	result, err := remediator.Approve(&remediationmodels.ReviewRemediationInput{ID: "id", UserID: "approver"})
+	require.Error(t, err)
+	assert.Equal(t, remediationmodels.StatusFailed, result.Status)
+	assert.Contains(t, result.Error, "AccessDenied")
+	mockDynamo.AssertExpectations(t)
+}
+
+func TestDenyExpired(t *testing.T) {
+	record := pendingRecord(time.Now().Add(-time.Minute))
+	mockDynamo := &testutils.DynamoDBMock{}
+	mockDynamo.On("GetItem", mock.Anything).Return(&dynamodb.GetItemOutput{Item: marshalRecord(t, record)}, nil)
+	mockDynamo.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil).Once()
+	remediator := &Invoker{dynamoClient: mockDynamo}
+
+	result, err := remediator.Deny(&remediationmodels.ReviewRemediationInput{ID: "id", UserID: "approver"})
+	assert.Nil(t, result)
+	assert.Equal(t, ErrNotPending, err)
+	stored := mockDynamo.Calls[1].Arguments.Get(0).(*dynamodb.PutItemInput)
+	assert.Equal(t, string(remediationmodels.StatusExpired), aws.StringValue(stored.Item["status"].S))
+	mockDynamo.AssertExpectations(t)
+}
+
+func TestDenyAlreadyReviewed(t *testing.T) {
+	record := pendingRecord(time.Now().Add(time.Hour))
+	mockDynamo := &testutils.DynamoDBMock{}
+	mockDynamo.On("GetItem", mock.Anything).Return(&dynamodb.GetItemOutput{Item: marshalRecord(t, record)}, nil)
+	mockDynamo.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{},
+		awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "", nil))
+	remediator := &Invoker{dynamoClient: mockDynamo}
+
+	_, err := remediator.Deny(&remediationmodels.ReviewRemediationInput{ID: "id", UserID: "approver"})
+	assert.Equal(t, ErrNotPending, err)
+}
+
+func TestReviewNotFound(t *testing.T) {
+	mockDynamo := &testutils.DynamoDBMock{}
+	mockDynamo.On("GetItem", mock.Anything).Return(&dynamodb.GetItemOutput{}, nil)
+	remediator := &Invoker{dynamoClient: mockDynamo}
+
+	_, err := remediator.Approve(&remediationmodels.ReviewRemediationInput{ID: "id", UserID: "approver"})
+	assert.Equal(t, ErrRemediationNotFound, err)
+}
+
+func TestListHistory(t *testing.T) {
+	now := time.Now().UTC()
+	older := &remediationmodels.Remediation{ID: "older", Status: remediationmodels.StatusSucceeded, RequestedAt: now.Add(-2 * time.Hour)}
+	expired := pendingRecord(now.Add(-time.Minute))
+
+	mockDynamo := &testutils.DynamoDBMock{}
+	mockDynamo.On("QueryPages", mock.MatchedBy(func(input *dynamodb.QueryInput) bool {
+		return aws.StringValue(input.IndexName) == "alert-index"
+	}), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		page := &dynamodb.QueryOutput{Items: []map[string]*dynamodb.AttributeValue{
+			marshalRecord(t, older), marshalRecord(t, expired),
+		}}
+		args.Get(1).(func(*dynamodb.QueryOutput, bool) bool)(page, true)
+	})
+	remediator := &Invoker{dynamoClient: mockDynamo}
+
+	result, err := remediator.ListHistory(&remediationmodels.ListRemediationHistoryInput{AlertID: "alert"})
+	require.NoError(t, err)
+	require.Len(t, result.Remediations, 2)
+	assert.Equal(t, "id", result.Remediations[0].ID)
+	assert.Equal(t, remediationmodels.StatusExpired, result.Remediations[0].Status)
+	assert.Equal(t, "older", result.Remediations[1].ID)
+	mockDynamo.AssertExpectations(t)
+}
+
+func TestExpirePending(t *testing.T) {
+	now := time.Now().UTC()
+	expired := pendingRecord(now.Add(-time.Minute))
+	pending := pendingRecord(now.Add(time.Hour))
+	pending.ID = "pending"
+	reviewed := pendingRecord(now.Add(-time.Minute))
+	reviewed.ID = "reviewed"
+
+	mockDynamo := &testutils.DynamoDBMock{}
+	mockDynamo.On("QueryPages", mock.MatchedBy(func(input *dynamodb.QueryInput) bool {
+		return aws.StringValue(input.IndexName) == "pending-index"
+	}), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		page := &dynamodb.QueryOutput{Items: []map[string]*dynamodb.AttributeValue{
+			marshalRecord(t, expired), marshalRecord(t, pending), marshalRecord(t, reviewed),
+		}}
+		args.Get(1).(func(*dynamodb.QueryOutput, bool) bool)(page, true)
+	})
+	mockDynamo.On("PutItem", mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
+		return aws.StringValue(input.Item["id"].S) == "id"
+	})).Return(&dynamodb.PutItemOutput{}, nil).Once()
+	// Reviewed since the query
+	mockDynamo.On("PutItem", mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
+		return aws.StringValue(input.Item["id"].S) == "reviewed"
+	})).Return(&dynamodb.PutItemOutput{}, awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "", nil)).Once()
+	remediator := &Invoker{dynamoClient: mockDynamo}
+
+	result, err := remediator.ExpirePending()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id"}, result.Expired)
+	stored := mockDynamo.Calls[1].Arguments.Get(0).(*dynamodb.PutItemInput)
+	assert.Equal(t, string(remediationmodels.StatusExpired), aws.StringValue(stored.Item["status"].S))
+	// Expired remediations are removed from the pending index
+	assert.NotContains(t, stored.Item, "pendingApproval")
+	mockDynamo.AssertExpectations(t)
+}
+
+func TestRemediateRetriedRequest(t *testing.T) {
+	executed := &remediationmodels.Remediation{ID: "message-id", Status: remediationmodels.StatusSucceeded}
+	mockDynamo := &testutils.DynamoDBMock{}
+	mockDynamo.On("GetItem", mock.MatchedBy(func(input *dynamodb.GetItemInput) bool {
+		return aws.StringValue(input.Key["id"].S) == "message-id"
+	})).Return(&dynamodb.GetItemOutput{Item: marshalRecord(t, executed)}, nil).Once()
+	// Neither the policy nor the remediation lambda are invoked again
+	mockAnalysisClient := &gatewayapi.MockClient{}
+	analysisClient = mockAnalysisClient
+	mockClient := &mockLambdaClient{}
+	remediator := &Invoker{lambdaClient: mockClient, dynamoClient: mockDynamo}
+
+	result, err := remediator.Remediate(&remediationmodels.RemediateResourceInput{
+		PolicyID:   "policyId",
+		ResourceID: "resourceId",
+		RequestID:  "message-id",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, executed, result)
+	mockDynamo.AssertExpectations(t)
+	mockAnalysisClient.AssertExpectations(t)
+	mockClient.AssertExpectations(t)
+
+	// A request that was not recorded yet is recorded with the request id
+	mockDynamo.On("GetItem", mock.Anything).Return(&dynamodb.GetItemOutput{}, nil).Once()
+	mockAnalysisClient.On("Invoke", mock.Anything, &analysismodels.Policy{}).Return(http.StatusOK, nil, policy).Once()
+	mockDynamo.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil).Once()
+	result, err = remediator.Remediate(&remediationmodels.RemediateResourceInput{
+		PolicyID:        "policyId",
+		ResourceID:      "resourceId",
+		RequestID:       "message-id",
+		RequireApproval: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "message-id", result.ID)
+	mockDynamo.AssertExpectations(t)
+}
@@ -20,10 +20,12 @@ package remediation
 
 import (
 	"os"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/google/uuid"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -37,6 +39,9 @@ import (
 const remediationAction = "remediate"
 const listRemediationsAction = "listRemediations"
 
+// How long a remediation can wait for approval unless the request says otherwise
+const defaultApprovalTimeout = 24 * time.Hour
+
 var (
 	remediationLambdaArn = os.Getenv("REMEDIATION_LAMBDA_ARN")
 
@@ -49,42 +54,122 @@ var (
 	ErrNotFound = errors.New("Remediation not associated with policy")
 )
 
-// Remediate will invoke remediation action in an AWS account
-func (remediator *Invoker) Remediate(remediation *remediationmodels.RemediateResourceInput) error {
+// Remediate will invoke remediation action in an AWS account,
+// or record a request pending approval if approval is required.
+func (remediator *Invoker) Remediate(remediation *remediationmodels.RemediateResourceInput) (*remediationmodels.Remediation, error) {
 	zap.L().Debug("handling remediation",
 		zap.Any("policyId", remediation.PolicyID),
 		zap.Any("resourceId", remediation.ResourceID))
 
+	id := remediation.RequestID
+	if id == "" {
+		id = uuid.New().String()
+	} else {
+		// A retried request returns the recorded remediation unless it failed
+		existing, err := remediator.getRecord(id)
+		switch {
+		case err == nil && existing.Status != remediationmodels.StatusFailed:
+			zap.L().Info("remediation already recorded", zap.String("id", id), zap.String("status", string(existing.Status)))
+			return existing, nil
+		case err != nil && err != ErrRemediationNotFound:
+			return nil, err
+		}
+	}
+
 	policy, err := getPolicy(remediation.PolicyID)
 	if err != nil {
-		return errors.Wrap(err, "Encountered issue when getting policy")
+		return nil, errors.Wrap(err, "Encountered issue when getting policy")
 	}
 
 	if policy.AutoRemediationID == "" {
-		return ErrNotFound
+		return nil, ErrNotFound
+	}
+
+	record := &remediationmodels.Remediation{
+		ID:            id,
+		AlertID:       remediation.AlertID,
+		PolicyID:      remediation.PolicyID,
+		ResourceID:    remediation.ResourceID,
+		RemediationID: policy.AutoRemediationID,
+		Parameters:    policy.AutoRemediationParameters,
+		DryRun:        remediation.DryRun,
+		RequestedAt:   time.Now().UTC(),
+		RequestedBy:   remediation.UserID,
+	}
+
+	if remediation.RequireApproval {
+		timeout := defaultApprovalTimeout
+		if remediation.ApprovalTimeoutMinutes > 0 {
+			timeout = time.Duration(remediation.ApprovalTimeoutMinutes) * time.Minute
+		}
+		expiresAt := record.RequestedAt.Add(timeout)
+		record.ApprovalExpiresAt = &expiresAt
+		record.Status = remediationmodels.StatusPendingApproval
+
+		if err := remediator.putRecord(record, false); err != nil {
+			return nil, err
+		}
+		zap.L().Info("remediation is pending approval", zap.String("id", record.ID))
+		return record, nil
+	}
+
+	return record, remediator.execute(record)
+}
+
+// execute invokes the remediation and records the outcome, successful or not.
+func (remediator *Invoker) execute(record *remediationmodels.Remediation) error {
+	result, err := remediator.invokeRemediation(record)
+
+	executedAt := time.Now().UTC()
+	record.ExecutedAt = &executedAt
+	record.Result = result
+	if err != nil {
+		record.Status = remediationmodels.StatusFailed
+		record.Error = err.Error()
+	} else {
+		record.Status = remediationmodels.StatusSucceeded
 	}
 
-	resource, err := getResource(remediation.ResourceID)
+	if putErr := remediator.putRecord(record, false); putErr != nil {
+		if err != nil {
+			zap.L().Error("failed to record failed remediation", zap.Error(putErr))
+			return err
+		}
+		return putErr
+	}
+	return err
+}
+
+func (remediator *Invoker) invokeRemediation(record *remediationmodels.Remediation) (interface{}, error) {
+	resource, err := getResource(record.ResourceID)
 	if err != nil {
-		return errors.Wrap(err, "Encountered issue when getting resource")
+		return nil, errors.Wrap(err, "Encountered issue when getting resource")
 	}
 	remediationPayload := &Payload{
-		RemediationID: policy.AutoRemediationID,
+		RemediationID: record.RemediationID,
 		Resource:      resource.Attributes,
-		Parameters:    policy.AutoRemediationParameters,
+		Parameters:    record.Parameters,
+		DryRun:        record.DryRun,
 	}
 	lambdaInput := &LambdaInput{
 		Action:  aws.String(remediationAction),
 		Payload: remediationPayload,
 	}
 
-	_, err = remediator.invokeLambda(lambdaInput)
+	response, err := remediator.invokeLambda(lambdaInput)
 	if err != nil {
-		return errors.Wrap(err, "failed to invoke remediator")
+		return nil, errors.Wrap(err, "failed to invoke remediator")
+	}
+
+	var result interface{}
+	if len(response) > 0 {
+		if err := jsoniter.Unmarshal(response, &result); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal remediation result")
+		}
 	}
 
-	zap.L().Debug("finished remediate action")
-	return nil
+	zap.L().Debug("finished remediate action", zap.Bool("dryRun", record.DryRun))
+	return result, nil
 }
 
 //GetRemediations invokes the Lambda in customer account and retrieves the list of available remediations
@@ -170,4 +255,5 @@ type Payload struct {
 	RemediationID string      `json:"remediationId"`
 	Resource      interface{} `json:"resource"`
 	Parameters    interface{} `json:"parameters"`
+	DryRun        bool        `json:"dryRun,omitempty"`
 }
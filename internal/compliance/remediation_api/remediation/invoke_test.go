@@ -23,6 +23,7 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/lambda"
 	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
 	jsoniter "github.com/json-iterator/go"
@@ -34,6 +35,7 @@ import (
 	remediationmodels "github.com/panther-labs/panther/api/lambda/remediation/models"
 	resourcemodels "github.com/panther-labs/panther/api/lambda/resources/models"
 	"github.com/panther-labs/panther/pkg/gatewayapi"
+	"github.com/panther-labs/panther/pkg/testutils"
 )
 
 type mockLambdaClient struct {
@@ -107,7 +109,9 @@ func TestRemediate(t *testing.T) {
 
 	mockClient := &mockLambdaClient{}
 	mockClient.On("Invoke", expectedLambdaInput).Return(&lambda.InvokeOutput{}, nil)
-	remediator := &Invoker{lambdaClient: mockClient}
+	mockDynamo := &testutils.DynamoDBMock{}
+	mockDynamo.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil)
+	remediator := &Invoker{lambdaClient: mockClient, dynamoClient: mockDynamo}
 
 	// analysis-api mock
 	mockAnalysisClient := &gatewayapi.MockClient{}
@@ -120,13 +124,17 @@ func TestRemediate(t *testing.T) {
 		http.StatusOK, nil, policy).Once()
 
 	// run the function under test
-	result := remediator.Remediate(input)
+	result, err := remediator.Remediate(input)
 
 	// assert expectations
-	assert.NoError(t, result)
+	require.NoError(t, err)
+	assert.Equal(t, remediationmodels.StatusSucceeded, result.Status)
+	assert.Equal(t, policy.AutoRemediationID, result.RemediationID)
+	assert.NotNil(t, result.ExecutedAt)
 	mockResourcesClient.AssertExpectations(t)
 	mockClient.AssertExpectations(t)
 	mockAnalysisClient.AssertExpectations(t)
+	mockDynamo.AssertExpectations(t)
 }
 
 func TestGetRemediations(t *testing.T) {
@@ -171,9 +179,9 @@ func TestRemediationNotFoundErrorIfNoRemediationConfigured(t *testing.T) {
 	mockAnalysisClient.On("Invoke", getPolicyInput, &analysismodels.Policy{}).Return(
 		http.StatusOK, nil, policy).Once()
 
-	result := remediator.Remediate(input)
-	assert.Error(t, result)
-	assert.Equal(t, ErrNotFound, result)
+	result, err := remediator.Remediate(input)
+	assert.Nil(t, result)
+	assert.Equal(t, ErrNotFound, err)
 
 	mockClient.AssertExpectations(t)
 	mockAnalysisClient.AssertExpectations(t)
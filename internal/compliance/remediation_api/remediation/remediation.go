@@ -20,6 +20,8 @@ package remediation
 
 import (
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
 	"github.com/aws/aws-sdk-go/service/lambda"
 	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
 
@@ -29,18 +31,25 @@ import (
 //InvokerAPI is the interface for the Invoker,
 // the component that is responsible for invoking Remediation Lambda
 type InvokerAPI interface {
-	Remediate(*models.RemediateResourceInput) error
+	Remediate(*models.RemediateResourceInput) (*models.Remediation, error)
+	Approve(*models.ReviewRemediationInput) (*models.Remediation, error)
+	Deny(*models.ReviewRemediationInput) (*models.Remediation, error)
+	ListHistory(*models.ListRemediationHistoryInput) (*models.ListRemediationHistoryOutput, error)
+	ExpirePending() (*models.ExpireRemediationsOutput, error)
 	GetRemediations() (*models.ListRemediationsOutput, error)
 }
 
 //Invoker is responsible for invoking Remediation Lambda
+// and recording every remediation in the remediations table
 type Invoker struct {
 	lambdaClient lambdaiface.LambdaAPI
+	dynamoClient dynamodbiface.DynamoDBAPI
 }
 
 //NewInvoker method returns a new instance of Invoker
 func NewInvoker(sess *session.Session) *Invoker {
 	return &Invoker{
 		lambdaClient: lambda.New(sess),
+		dynamoClient: dynamodb.New(sess),
 	}
 }
@@ -30,7 +30,8 @@ def lambda_handler(event: Dict[str, Any], unused_context: Any) -> Dict[Any, Any]
         currently supported:
         1. 'listRemediations' event: The Lambda will return the available remediations
         and the parameters used by the remediation.
-        2. 'remediate' event: The Lambda invokes the appropriate remediation.
+        2. 'remediate' event: The Lambda invokes the appropriate remediation and returns a description
+        of the change. If the payload sets "dryRun", the change is only described, not applied.
 
         unused_context: AWS LambdaContext object
 
@@ -49,7 +50,8 @@ def lambda_handler(event: Dict[str, Any], unused_context: Any) -> Dict[Any, Any]
             "parameters": {
               "TargetBucket": "log-bucket",
               "TargetPrefix": "s3-access"
-            }
+            },
+            "dryRun": false
           }
         }
 
@@ -64,8 +66,7 @@ def lambda_handler(event: Dict[str, Any], unused_context: Any) -> Dict[Any, Any]
     if event['action'] == 'listRemediations':
         return Remediation.get_all_remediations()
     if event['action'] == 'remediate':
-        Remediation.get(event['payload']['remediationId'])().fix(event['payload'])
-        return {}
+        return Remediation.get(event['payload']['remediationId'])().fix(event['payload'])
     raise InvalidInput('Unknown action "{}"'.format(event['action']))
 
 
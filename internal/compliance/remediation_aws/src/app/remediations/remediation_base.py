@@ -16,14 +16,17 @@
 
 from abc import abstractmethod
 from functools import lru_cache
+import json
 import os
-from typing import Any, Dict
+from typing import Any, Dict, List, Optional, Tuple
 
 import boto3
 from boto3 import Session
+from botocore.awsrequest import AWSResponse
 from botocore.client import BaseClient
 from botocore.credentials import RefreshableCredentials
 from botocore.exceptions import ClientError
+from botocore.model import OperationModel
 
 from ..common import logging
 from ..common.exceptions import RemediationException, RemediationNotAuthorized
@@ -78,12 +81,26 @@ class RemediationBase:
         return '.'.join(['AWS', cls._id()])
 
     @classmethod
-    def fix(cls, event: Dict[str, Any]) -> None:
-        """Method invoked by AWS Lambda to perform remediative actions"""
+    def fix(cls, event: Dict[str, Any]) -> Dict[str, Any]:
+        """Method invoked by AWS Lambda to perform remediative actions
 
+        Returns: A description of the change, including every AWS API call that modifies the resource.
+        If event['dryRun'] is set, the calls are recorded but not sent so the change is not applied.
+        """
+        change = {
+            'remediationId': cls.remediation_id(),
+            'description': cls.__doc__,
+            'resourceId': event['resource'].get('ResourceId'),
+            'parameters': event['parameters'],
+            'dryRun': bool(event.get('dryRun')),
+            'changes': [],
+        }
+
+        # Dry runs still need a session, remediations may read the current state of the resource
         session = cls._get_session(event['resource']['AccountId'], event['resource']['Region'])
+        _ChangeRecorder(change['changes'], change['dryRun']).register(session)
         try:
-            cls.logger.info('Invoking remediation %s ', cls.remediation_id())
+            cls.logger.info('Invoking remediation %s (dry run: %s)', cls.remediation_id(), change['dryRun'])
             cls._fix(session, event['resource'], event['parameters'])
             cls.logger.info('Successfully invoked remediation %s', cls.remediation_id())
         except ClientError as exception:
@@ -92,6 +109,7 @@ class RemediationBase:
             raise RemediationException(exception)
         except Exception as exception:
             raise RemediationException(exception)
+        return change
 
     @classmethod
     def _get_session(cls, account_id: str, region: str) -> Session:
@@ -156,3 +174,39 @@ class RemediationBase:
         client = boto3.client('sts', region_name=region)
         _STS_CLIENT_MAP[region] = client
         return client
+
+
+class _ChangeRecorder:
+    """Records the AWS API calls of a remediation that modify resources, and skips them on dry runs"""
+    _READ_ONLY_PREFIXES = ('Describe', 'Get', 'List', 'Head')
+
+    def __init__(self, changes: List[Dict[str, Any]], dry_run: bool):
+        self.changes = changes
+        self.dry_run = dry_run
+
+    def register(self, session: Session) -> None:
+        """Registers the recorder with the session, it applies to the clients created afterwards"""
+        session.events.register('before-parameter-build', self._record)
+        if self.dry_run:
+            session.events.register('before-call', self._skip)
+
+    @classmethod
+    def _modifies(cls, model: OperationModel) -> bool:
+        return not model.name.startswith(cls._READ_ONLY_PREFIXES)
+
+    def _record(self, params: Dict[str, Any], model: OperationModel, **_: Any) -> None:
+        if self._modifies(model):
+            self.changes.append(
+                {
+                    'service': model.service_model.service_name,
+                    'operation': model.name,
+                    # Parameters can contain timestamps or bytes
+                    'parameters': json.loads(json.dumps(params, default=str)),
+                }
+            )
+
+    def _skip(self, model: OperationModel, **_: Any) -> Optional[Tuple[AWSResponse, Dict[str, Any]]]:
+        if self._modifies(model):
+            # A response short-circuits the request, the same way botocore.stub.Stubber does
+            return AWSResponse(None, 200, {}, None), {}
+        return None
@@ -0,0 +1,76 @@
+# Panther is a Cloud-Native SIEM for the Modern Security Team.
+# Copyright (C) 2020 Panther Labs Inc
+#
+# This program is free software: you can redistribute it and/or modify
+# it under the terms of the GNU Affero General Public License as
+# published by the Free Software Foundation, either version 3 of the
+# License, or (at your option) any later version.
+#
+# This program is distributed in the hope that it will be useful,
+# but WITHOUT ANY WARRANTY; without even the implied warranty of
+# MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+# GNU Affero General Public License for more details.
+#
+# You should have received a copy of the GNU Affero General Public License
+# along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+from typing import Any
+from unittest import mock, TestCase
+from boto3 import Session
+from ...src.app.remediations.aws_s3_enable_bucket_versioning import AwsS3EnableBucketVersioning
+
+
+class TestRemediationBase(TestCase):
+
+    @mock.patch.object(AwsS3EnableBucketVersioning, '_get_session')
+    @mock.patch.object(AwsS3EnableBucketVersioning, '_fix')
+    def test_fix(self, mock_fix: mock.MagicMock, mock_get_session: mock.MagicMock) -> None:
+        session = mock.MagicMock()
+        mock_get_session.return_value = session
+        event = {
+            'resource': {
+                'ResourceId': 'arn:aws:s3:::my-bucket',
+                'AccountId': '123456789012',
+                'Region': 'us-west-2'
+            },
+            'parameters': {}
+        }
+
+        change = AwsS3EnableBucketVersioning.fix(event)
+
+        mock_get_session.assert_called_once_with('123456789012', 'us-west-2')
+        mock_fix.assert_called_once_with(session, event['resource'], {})
+        self.assertEqual('AWS.S3.EnableBucketVersioning', change['remediationId'])
+        self.assertEqual('arn:aws:s3:::my-bucket', change['resourceId'])
+        self.assertFalse(change['dryRun'])
+
+    @mock.patch.object(AwsS3EnableBucketVersioning, '_get_session')
+    def test_fix_dry_run(self, mock_get_session: mock.MagicMock) -> None:
+        # A real session that cannot send requests, the dry run must not send the modifying call
+        session = Session(aws_access_key_id='key', aws_secret_access_key='secret', region_name='us-west-2')
+        session.events.register('before-send', self._fail_request)
+        mock_get_session.return_value = session
+        event = {
+            'resource': {
+                'Name': 'my-bucket',
+                'ResourceId': 'arn:aws:s3:::my-bucket',
+                'AccountId': '123456789012',
+                'Region': 'us-west-2'
+            },
+            'parameters': {},
+            'dryRun': True
+        }
+
+        change = AwsS3EnableBucketVersioning.fix(event)
+
+        self.assertTrue(change['dryRun'])
+        self.assertEqual('Remediation that enables versioning for an S3 bucket', change['description'])
+        self.assertEqual(1, len(change['changes']))
+        self.assertEqual('s3', change['changes'][0]['service'])
+        self.assertEqual('PutBucketVersioning', change['changes'][0]['operation'])
+        self.assertEqual('my-bucket', change['changes'][0]['parameters']['Bucket'])
+        self.assertEqual({'Status': 'Enabled'}, change['changes'][0]['parameters']['VersioningConfiguration'])
+
+    @staticmethod
+    def _fail_request(**_: Any) -> None:
+        raise AssertionError('request sent during a dry run')
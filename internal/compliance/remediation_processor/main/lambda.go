@@ -54,7 +54,10 @@ func lambdaHandler(ctx context.Context, event events.SQSEvent) (err error) {
 			err = errors.Wrap(err, "Failed to unmarshal item")
 			return err
 		}
-		if err = invoker.Remediate(&input); err != nil {
+		// The whole batch is delivered again if a message fails, keying the remediation
+		// by message id records each message once
+		input.RequestID = record.MessageId
+		if _, err = invoker.Remediate(&input); err != nil {
 			err = errors.Wrap(err, "encountered issue while processing event")
 			return err
 		}
@@ -238,8 +238,9 @@ func (r *batchResults) analyze(resources resourceMap, policies policyMap) error
 				ResourceID:      resource.ID,
 				OutputIds:       policy.OutputIDs,
 				Timestamp:       time.Now().UTC(),
-				// We only need to send an alert to the user if the status is newly FAILing
-				ShouldAlert: status != compliancemodels.StatusFail,
+				// We only need to send an alert to the user if the status is newly FAILing,
+				// or if the failure was suppressed until now (e.g. the suppression expired)
+				ShouldAlert: status != compliancemodels.StatusFail || response.Suppressed,
 			}
 			var sqsMessageBody string
 			if sqsMessageBody, err = jsoniter.MarshalToString(complianceNotification); err != nil {
@@ -276,11 +277,18 @@ func evaluatePolicies(policies policyMap, resources resourceMap) (*enginemodels.
 			ResourceTypes: policy.ResourceTypes,
 		})
 	}
+	relations, related, err := getRelatedResources(resources)
+	if err != nil {
+		// Policies are still evaluated, related resources are only extra context
+		relations, related = nil, nil
+	}
+	input.RelatedResources = related
 	for _, resource := range resources {
 		input.Resources = append(input.Resources, enginemodels.Resource{
 			Attributes: resource.Attributes,
 			ID:         resource.ID,
 			Type:       resource.Type,
+			Related:    relations[resource.ID],
 		})
 	}
 
@@ -19,14 +19,24 @@ package processor
  */
 
 import (
+	"errors"
+	"net/http"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/service/lambda"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
+	enginemodels "github.com/panther-labs/panther/api/lambda/analysis"
 	analysismodels "github.com/panther-labs/panther/api/lambda/analysis/models"
+	compliancemodels "github.com/panther-labs/panther/api/lambda/compliance/models"
 	resourcemodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	alertmodels "github.com/panther-labs/panther/internal/compliance/alert_processor/models"
 	"github.com/panther-labs/panther/internal/compliance/resource_processor/models"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+	"github.com/panther-labs/panther/pkg/testutils"
 )
 
 func TestParseQueueMsgResource(t *testing.T) {
@@ -102,3 +112,162 @@ func TestIsSuppressed(t *testing.T) {
 		Suppressions: []string{"not", "this", "one", "but", "here:", "*.us-west-2/*"},
 	}))
 }
+
+func TestAnalyzeShouldAlert(t *testing.T) {
+	resource := resourcemodels.Resource{
+		ID:            "prod.panther.us-west-2/device",
+		IntegrationID: "integration-id",
+		Type:          "AWS.S3.Bucket",
+		Attributes:    map[string]interface{}{"Name": "device"},
+	}
+	policy := analysismodels.Policy{
+		ID:            "policy.id",
+		Body:          "def policy(resource): return False",
+		ResourceTypes: []string{"AWS.S3.Bucket"},
+		VersionID:     "version",
+	}
+	engineOutput, err := jsoniter.Marshal(&enginemodels.PolicyEngineOutput{
+		Resources: []enginemodels.Result{{ID: resource.ID, Failed: []string{policy.ID}}},
+	})
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name         string
+		suppressions []string
+		previous     compliancemodels.ComplianceEntry
+		expectAlert  bool
+		expectNotify bool
+	}{
+		{
+			name:         "newly failing",
+			previous:     compliancemodels.ComplianceEntry{Status: compliancemodels.StatusPass},
+			expectNotify: true,
+			expectAlert:  true,
+		},
+		{
+			name:         "still failing",
+			previous:     compliancemodels.ComplianceEntry{Status: compliancemodels.StatusFail},
+			expectNotify: true,
+		},
+		{
+			// A failing resource that was suppressed until now alerts again
+			name:         "suppression lifted",
+			previous:     compliancemodels.ComplianceEntry{Status: compliancemodels.StatusFail, Suppressed: true},
+			expectNotify: true,
+			expectAlert:  true,
+		},
+		{
+			name:         "suppressed",
+			suppressions: []string{"prod.*"},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockLambda := &testutils.LambdaMock{}
+			lambdaClient = mockLambda
+			mockResources := &gatewayapi.MockClient{}
+			resourceClient = mockResources
+			mockCompliance := &gatewayapi.MockClient{}
+			complianceClient = mockCompliance
+
+			mockResources.On("Invoke", mock.Anything, mock.Anything).Return(
+				http.StatusOK, nil, resourcemodels.GetResourceRelationshipsOutput{})
+			mockLambda.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{Payload: engineOutput}, nil)
+			if tc.expectNotify {
+				mockCompliance.On("Invoke", mock.Anything, mock.Anything).Return(http.StatusOK, nil, tc.previous)
+			}
+
+			suppressed := policy
+			suppressed.Suppressions = tc.suppressions
+			results := batchResults{}
+			require.NoError(t, results.analyze(resourceMap{resource.ID: resource}, policyMap{policy.ID: suppressed}))
+
+			require.Len(t, results.StatusEntries, 1)
+			assert.Equal(t, compliancemodels.StatusFail, results.StatusEntries[0].Status)
+			assert.Equal(t, tc.suppressions != nil, results.StatusEntries[0].Suppressed)
+			if !tc.expectNotify {
+				assert.Empty(t, results.Alerts)
+				mockCompliance.AssertNotCalled(t, "Invoke", mock.Anything, mock.Anything)
+				return
+			}
+			require.Len(t, results.Alerts, 1)
+			var notification alertmodels.ComplianceNotification
+			require.NoError(t, jsoniter.UnmarshalFromString(*results.Alerts[0].MessageBody, &notification))
+			assert.Equal(t, policy.ID, notification.PolicyID)
+			assert.Equal(t, resource.ID, notification.ResourceID)
+			assert.Equal(t, tc.expectAlert, notification.ShouldAlert)
+			mockCompliance.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEvaluatePoliciesRelated(t *testing.T) {
+	resources := resourceMap{}
+	for _, id := range []string{"instance-1", "instance-2"} {
+		resources[id] = resourcemodels.Resource{ID: id, Type: "AWS.EC2.Instance", Attributes: map[string]interface{}{}}
+	}
+	policies := policyMap{"policy.id": analysismodels.Policy{ID: "policy.id", ResourceTypes: []string{"AWS.EC2.Instance"}}}
+	engineOutput, err := jsoniter.Marshal(&enginemodels.PolicyEngineOutput{})
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name      string
+		relations resourcemodels.GetResourceRelationshipsOutput
+		err       error
+		expected  enginemodels.PolicyEngineInput
+	}{
+		{
+			// Related resources are sent once, and deleted or missing targets are skipped
+			name: "related",
+			relations: resourcemodels.GetResourceRelationshipsOutput{
+				Relationships: []resourcemodels.Relationship{
+					{SourceID: "instance-1", Relation: "securityGroup", TargetID: "sg-1", TargetType: "AWS.EC2.SecurityGroup"},
+					{SourceID: "instance-2", Relation: "securityGroup", TargetID: "sg-1", TargetType: "AWS.EC2.SecurityGroup"},
+					{SourceID: "instance-2", Relation: "securityGroup", TargetID: "sg-deleted", TargetType: "AWS.EC2.SecurityGroup"},
+					{SourceID: "instance-2", Relation: "role", TargetID: "role-missing", TargetType: "AWS.IAM.Role"},
+				},
+				Resources: []resourcemodels.RelatedResource{
+					{ID: "sg-1", Type: "AWS.EC2.SecurityGroup", Attributes: map[string]interface{}{"open": true}, Scanned: true},
+					{ID: "sg-deleted", Type: "AWS.EC2.SecurityGroup", Deleted: true, Scanned: true},
+				},
+			},
+			expected: enginemodels.PolicyEngineInput{
+				RelatedResources: map[string]enginemodels.RelatedResource{
+					"sg-1": {ID: "sg-1", Type: "AWS.EC2.SecurityGroup", Attributes: map[string]interface{}{"open": true}},
+				},
+			},
+		},
+		{
+			// Policies are still evaluated without related resources
+			name: "relationships failed",
+			err:  errors.New("relationships failed"),
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockLambda := &testutils.LambdaMock{}
+			lambdaClient = mockLambda
+			mockResources := &gatewayapi.MockClient{}
+			resourceClient = mockResources
+
+			mockResources.On("Invoke", mock.Anything, mock.Anything).Return(http.StatusOK, tc.err, tc.relations)
+			mockLambda.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{Payload: engineOutput}, nil)
+
+			_, err := evaluatePolicies(policies, resources)
+			require.NoError(t, err)
+
+			var input enginemodels.PolicyEngineInput
+			payload := mockLambda.Calls[0].Arguments.Get(0).(*lambda.InvokeInput).Payload
+			require.NoError(t, jsoniter.Unmarshal(payload, &input))
+			assert.Len(t, input.Resources, 2)
+			assert.Equal(t, tc.expected.RelatedResources, input.RelatedResources)
+			for _, resource := range input.Resources {
+				if tc.err == nil {
+					assert.Equal(t, map[string][]string{"securityGroup": {"sg-1"}}, resource.Related)
+				} else {
+					assert.Empty(t, resource.Related)
+				}
+			}
+		})
+	}
+}
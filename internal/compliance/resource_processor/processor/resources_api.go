@@ -22,6 +22,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"go.uber.org/zap"
 
+	enginemodels "github.com/panther-labs/panther/api/lambda/analysis"
 	resourcemodels "github.com/panther-labs/panther/api/lambda/resources/models"
 )
 
@@ -29,6 +30,9 @@ import (
 // The goal is to keep this as high as possible while still keeping the result under 6MB.
 const resourcePageSize = 2000
 
+// How many resources we request relationships for at a time, each can have several related resources.
+const relatedPageSize = 100
+
 // Get a page of resources from the resources-api
 //
 // Returns {resourceID: resource}, totalPages, error
@@ -78,3 +82,63 @@ func getResource(resourceID string) (*resourcemodels.Resource, error) {
 
 	return &output, nil
 }
+
+// Get the resources directly referenced by each resource so policies can inspect them
+//
+// Returns {resourceID: {relation: related resource IDs}}, {related resource ID: related resource}, error
+func getRelatedResources(resources resourceMap) (
+	map[string]map[string][]string, map[string]enginemodels.RelatedResource, error) {
+
+	relations := make(map[string]map[string][]string)
+	result := make(map[string]enginemodels.RelatedResource)
+
+	ids := make([]string, 0, len(resources))
+	for id := range resources {
+		ids = append(ids, id)
+	}
+
+	for start := 0; start < len(ids); start += relatedPageSize {
+		end := start + relatedPageSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		input := resourcemodels.LambdaInput{
+			GetResourceRelationships: &resourcemodels.GetResourceRelationshipsInput{
+				IDs:               ids[start:end],
+				Depth:             1,
+				Direction:         "outbound",
+				IncludeAttributes: true,
+			},
+		}
+		var output resourcemodels.GetResourceRelationshipsOutput
+		if _, err := resourceClient.Invoke(&input, &output); err != nil {
+			zap.L().Error("failed to get resource relationships", zap.Error(err))
+			return nil, nil, err
+		}
+
+		related := make(map[string]resourcemodels.RelatedResource, len(output.Resources))
+		for _, resource := range output.Resources {
+			related[resource.ID] = resource
+		}
+
+		for _, relationship := range output.Relationships {
+			target, ok := related[relationship.TargetID]
+			if !ok || target.Deleted {
+				continue
+			}
+			if relations[relationship.SourceID] == nil {
+				relations[relationship.SourceID] = make(map[string][]string)
+			}
+			relations[relationship.SourceID][relationship.Relation] = append(
+				relations[relationship.SourceID][relationship.Relation], relationship.TargetID)
+			result[relationship.TargetID] = enginemodels.RelatedResource{
+				Attributes: target.Attributes,
+				ID:         relationship.TargetID,
+				Type:       relationship.TargetType,
+			}
+		}
+	}
+
+	return relations, result, nil
+}
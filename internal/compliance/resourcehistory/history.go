@@ -0,0 +1,209 @@
+package resourcehistory
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/pkg/awsbatch/dynamodbbatch"
+)
+
+// The panther-resource-history table stores the timeline of each resource.
+//
+// Each resource is a partition, and every record is sorted by the time it happened:
+//
+//     CONFIG     - a new configuration of the resource (written by the datalake-forwarder)
+//     EVENT      - a CloudTrail event which changed the resource (written by the aws-event-processor)
+//     COMPLIANCE - a policy status change for the resource (written by the datalake-forwarder)
+const (
+	KindConfig     = "CONFIG"
+	KindEvent      = "EVENT"
+	KindCompliance = "COMPLIANCE"
+
+	// History records are expired by Dynamo after this long
+	RetentionPeriod = 90 * 24 * time.Hour
+
+	// Fixed-width UTC timestamp so sort keys order chronologically
+	timestampFormat = "2006-01-02T15:04:05.000000000Z"
+	keySeparator    = "#"
+
+	maxWriteBackoff = time.Minute
+)
+
+// Record is a single entry in the resource history table.
+type Record struct {
+	ResourceID string    `json:"resourceId"`
+	SortKey    string    `json:"sortKey"`
+	Kind       string    `json:"kind"`
+	Timestamp  time.Time `json:"timestamp"`
+	ExpiresAt  int64     `json:"expiresAt"`
+
+	// CONFIG records
+	Attributes    interface{} `json:"attributes,omitempty"`
+	Deleted       bool        `json:"deleted,omitempty"`
+	IntegrationID string      `json:"integrationId,omitempty"`
+	ResourceType  string      `json:"resourceType,omitempty"`
+
+	// EVENT records
+	ChangedBy   string `json:"changedBy,omitempty"`
+	EventID     string `json:"eventId,omitempty"`
+	EventName   string `json:"eventName,omitempty"`
+	EventSource string `json:"eventSource,omitempty"`
+
+	// COMPLIANCE records
+	PolicyID string `json:"policyId,omitempty"`
+	Status   string `json:"status,omitempty"`
+}
+
+// NewConfigRecord records the configuration of a resource at a point in time.
+func NewConfigRecord(
+	resourceID, resourceType, integrationID string, attributes interface{}, deleted bool, timestamp time.Time) *Record {
+
+	record := newRecord(resourceID, KindConfig, "", timestamp)
+	record.Attributes = attributes
+	record.Deleted = deleted
+	record.IntegrationID = integrationID
+	record.ResourceType = resourceType
+	return record
+}
+
+// NewEventRecord records the CloudTrail event responsible for a resource change.
+func NewEventRecord(resourceID, changedBy, eventID, eventName, eventSource string, timestamp time.Time) *Record {
+	record := newRecord(resourceID, KindEvent, eventID, timestamp)
+	record.ChangedBy = changedBy
+	record.EventID = eventID
+	record.EventName = eventName
+	record.EventSource = eventSource
+	return record
+}
+
+// NewComplianceRecord records the status of one policy for a resource.
+func NewComplianceRecord(resourceID, policyID, status string, timestamp time.Time) *Record {
+	record := newRecord(resourceID, KindCompliance, policyID, timestamp)
+	record.PolicyID = policyID
+	record.Status = status
+	return record
+}
+
+func newRecord(resourceID, kind, suffix string, timestamp time.Time) *Record {
+	timestamp = timestamp.UTC()
+	sortKey := SortKeyPrefix(timestamp) + keySeparator + kind
+	if suffix != "" {
+		sortKey += keySeparator + suffix
+	}
+	return &Record{
+		ResourceID: resourceID,
+		SortKey:    sortKey,
+		Kind:       kind,
+		Timestamp:  timestamp,
+		ExpiresAt:  timestamp.Add(RetentionPeriod).Unix(),
+	}
+}
+
+// SortKeyPrefix returns the portion of the sort key shared by all records at the given time.
+func SortKeyPrefix(timestamp time.Time) string {
+	return timestamp.UTC().Format(timestampFormat)
+}
+
+// ParseTimestamp parses a timestamp from a Dynamo stream image, defaulting to the current time.
+func ParseTimestamp(value string) time.Time {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t
+	}
+	return time.Now()
+}
+
+// Put batch writes history records to the table.
+func Put(client dynamodbiface.DynamoDBAPI, tableName string, records []*Record) error {
+	// Dynamo rejects batches which write the same key twice; the last record wins
+	seen := make(map[string]int, len(records))
+	writeRequests := make([]*dynamodb.WriteRequest, 0, len(records))
+	for _, record := range records {
+		item, err := dynamodbattribute.MarshalMap(record)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal history record")
+		}
+		request := &dynamodb.WriteRequest{PutRequest: &dynamodb.PutRequest{Item: item}}
+
+		key := record.ResourceID + keySeparator + record.SortKey
+		if i, ok := seen[key]; ok {
+			writeRequests[i] = request
+			continue
+		}
+		seen[key] = len(writeRequests)
+		writeRequests = append(writeRequests, request)
+	}
+
+	if len(writeRequests) == 0 {
+		return nil
+	}
+	input := &dynamodb.BatchWriteItemInput{
+		RequestItems: map[string][]*dynamodb.WriteRequest{tableName: writeRequests},
+	}
+	return dynamodbbatch.BatchWriteItem(client, maxWriteBackoff, input)
+}
+
+// Query returns the history of a resource in chronological order.
+//
+// If until is not nil, only records at or before that time are returned.
+func Query(client dynamodbiface.DynamoDBAPI, tableName, resourceID string, until *time.Time) ([]*Record, error) {
+	keyCondition := expression.Key("resourceId").Equal(expression.Value(resourceID))
+	if until != nil {
+		// Every suffix sorts before "~", so this includes all records at exactly the until time
+		keyCondition = keyCondition.And(
+			expression.Key("sortKey").LessThanEqual(expression.Value(SortKeyPrefix(*until) + "~")))
+	}
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCondition).Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build history query")
+	}
+
+	input := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ScanIndexForward:          aws.Bool(true),
+		TableName:                 &tableName,
+	}
+
+	var records []*Record
+	var unmarshalErr error
+	err = client.QueryPages(input, func(page *dynamodb.QueryOutput, lastPage bool) bool {
+		var items []*Record
+		if unmarshalErr = dynamodbattribute.UnmarshalListOfMaps(page.Items, &items); unmarshalErr != nil {
+			return false // stop paginating
+		}
+		records = append(records, items...)
+		return true
+	})
+	if unmarshalErr != nil {
+		return nil, errors.Wrap(unmarshalErr, "failed to unmarshal history records")
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query history of %q", resourceID)
+	}
+	return records, nil
+}
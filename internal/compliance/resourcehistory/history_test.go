@@ -0,0 +1,75 @@
+package resourcehistory
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+func TestSortKeyOrder(t *testing.T) {
+	base := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	records := []*Record{
+		NewComplianceRecord("id", "policy", "PASS", base.Add(time.Second)),
+		NewConfigRecord("id", "AWS.S3.Bucket", "integration", nil, false, base.Add(time.Millisecond)),
+		// Different time zone, same instant
+		NewEventRecord("id", "arn:aws:iam::123456789012:user/alice", "event-1", "PutBucketAcl", "s3.amazonaws.com",
+			base.In(time.FixedZone("PST", -8*3600))),
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].SortKey < records[j].SortKey })
+
+	assert.Equal(t, KindEvent, records[0].Kind)
+	assert.Equal(t, "2021-01-02T03:04:05.000000000Z#EVENT#event-1", records[0].SortKey)
+	assert.Equal(t, KindConfig, records[1].Kind)
+	assert.Equal(t, "2021-01-02T03:04:05.001000000Z#CONFIG", records[1].SortKey)
+	assert.Equal(t, KindCompliance, records[2].Kind)
+	assert.Equal(t, base.Add(time.Second+RetentionPeriod).Unix(), records[2].ExpiresAt)
+}
+
+func TestPutDeduplicatesKeys(t *testing.T) {
+	now := time.Now()
+	first := NewComplianceRecord("id", "policy", "PASS", now)
+	second := NewComplianceRecord("id", "policy", "FAIL", now)
+	other := NewComplianceRecord("id", "other-policy", "PASS", now)
+
+	client := &testutils.DynamoDBMock{}
+	client.On("BatchWriteItem", mock.Anything).Return(&dynamodb.BatchWriteItemOutput{}, nil).Once()
+	require.NoError(t, Put(client, "history", []*Record{first, second, other}))
+	client.AssertExpectations(t)
+
+	input := client.Calls[0].Arguments.Get(0).(*dynamodb.BatchWriteItemInput)
+	requests := input.RequestItems["history"]
+	require.Len(t, requests, 2)
+	assert.Equal(t, "FAIL", *requests[0].PutRequest.Item["status"].S)
+	assert.Equal(t, "other-policy", *requests[1].PutRequest.Item["policyId"].S)
+}
+
+func TestPutEmpty(t *testing.T) {
+	client := &testutils.DynamoDBMock{}
+	require.NoError(t, Put(client, "history", nil))
+	client.AssertExpectations(t)
+}
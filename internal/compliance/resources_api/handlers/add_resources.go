@@ -51,6 +51,7 @@ func (API) AddResources(input *models.AddResourcesInput) *events.APIGatewayProxy
 	now := time.Now()
 	writeRequests := make([]*dynamodb.WriteRequest, 0, len(input.Resources))
 	sqsEntries := make([]*sqs.SendMessageBatchRequestEntry, 0, len(input.Resources))
+	items := make([]*resourceItem, 0, len(input.Resources))
 	for i, r := range input.Resources {
 		item := resourceItem{
 			Attributes:      r.Attributes,
@@ -78,6 +79,7 @@ func (API) AddResources(input *models.AddResourcesInput) *events.APIGatewayProxy
 			continue
 		}
 		writeRequests = append(writeRequests, &dynamodb.WriteRequest{PutRequest: &dynamodb.PutRequest{Item: marshalled}})
+		items = append(items, &item)
 
 		body, err := jsoniter.MarshalToString(item.Resource(""))
 		if err != nil {
@@ -103,6 +105,12 @@ func (API) AddResources(input *models.AddResourcesInput) *events.APIGatewayProxy
 		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
 	}
 
+	// Relationships must be stored before the resources are analyzed, policies can read them
+	if err := replaceRelationships(items); err != nil {
+		zap.L().Error("failed to store resource relationships", zap.Error(err))
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
 	sqsInput := &sqs.SendMessageBatchInput{
 		Entries:  sqsEntries,
 		QueueUrl: &env.ResourcesQueueURL,
@@ -31,8 +31,10 @@ import (
 )
 
 type envConfig struct {
-	ResourcesQueueURL string `required:"true" split_words:"true"`
-	ResourcesTable    string `required:"true" split_words:"true"`
+	HistoryTable       string `required:"true" split_words:"true"`
+	RelationshipsTable string `required:"true" split_words:"true"`
+	ResourcesQueueURL  string `required:"true" split_words:"true"`
+	ResourcesTable     string `required:"true" split_words:"true"`
 }
 
 // API has all of the handlers as receiver methods.
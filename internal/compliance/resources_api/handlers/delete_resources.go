@@ -36,6 +36,7 @@ const deleteWindowSecs = 30 * 24 * 60 * 60
 // DeleteResources marks one or more resources as deleted.
 func (API) DeleteResources(input *models.DeleteResourcesInput) *events.APIGatewayProxyResponse {
 	deletes := make([]compliancemodels.DeleteStatusEntry, len(input.Resources))
+	deletedIDs := make([]string, 0, len(input.Resources))
 	update := expression.
 		Set(expression.Name("deleted"), expression.Value(true)).
 		Set(expression.Name("expiresAt"), expression.Value(time.Now().Unix()+deleteWindowSecs))
@@ -48,7 +49,7 @@ func (API) DeleteResources(input *models.DeleteResourcesInput) *events.APIGatewa
 		response := doUpdate(update, entry.ID)
 		switch response.StatusCode {
 		case http.StatusOK:
-			continue
+			deletedIDs = append(deletedIDs, entry.ID)
 		case http.StatusNotFound:
 			// If the resource wasn't found, log but we don't need to fail the operation.
 			zap.L().Debug("resource no longer exists", zap.Any("deleteEntry", entry))
@@ -57,6 +58,11 @@ func (API) DeleteResources(input *models.DeleteResourcesInput) *events.APIGatewa
 		}
 	}
 
+	if err := deleteRelationships(deletedIDs); err != nil {
+		zap.L().Error("failed to delete resource relationships", zap.Error(err))
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
 	// Delete affected compliance states
 	zap.L().Info("deleting compliance status entries", zap.Int("itemCount", len(deletes)))
 	lambdaInput := compliancemodels.LambdaInput{
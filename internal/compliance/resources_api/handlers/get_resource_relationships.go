@@ -0,0 +1,187 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/lambda/resources/models"
+	"github.com/panther-labs/panther/pkg/awsbatch/dynamodbbatch"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+)
+
+const (
+	defaultRelationshipDepth     = 1
+	defaultRelationshipDirection = "both"
+)
+
+// GetResourceRelationships traverses the relationships of one or more resources.
+func (API) GetResourceRelationships(input *models.GetResourceRelationshipsInput) *events.APIGatewayProxyResponse {
+	if input.Depth == 0 {
+		input.Depth = defaultRelationshipDepth
+	}
+	if input.Direction == "" {
+		input.Direction = defaultRelationshipDirection
+	}
+
+	relationships, resourceTypes, err := traverseRelationships(input)
+	if err != nil {
+		zap.L().Error("failed to traverse resource relationships", zap.Error(err))
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	resources, err := getRelatedResources(resourceTypes, input.IncludeAttributes)
+	if err != nil {
+		zap.L().Error("failed to get related resources", zap.Error(err))
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	result := models.GetResourceRelationshipsOutput{Relationships: relationships, Resources: resources}
+	return gatewayapi.MarshalResponse(&result, http.StatusOK)
+}
+
+// traverseRelationships does a breadth-first search from the requested resources.
+//
+// Returns the relationships found and the type (if known from a relationship) of each resource reached.
+func traverseRelationships(input *models.GetResourceRelationshipsInput) ([]models.Relationship, map[string]string, error) {
+	relationships := make([]models.Relationship, 0)
+	reached := make(map[string]string, len(input.IDs))
+	seenRelationships := make(map[models.Relationship]bool)
+
+	var frontier []string
+	for _, id := range input.IDs {
+		if _, ok := reached[id]; !ok {
+			reached[id] = ""
+			frontier = append(frontier, id)
+		}
+	}
+
+	for depth := 0; depth < input.Depth && len(frontier) > 0; depth++ {
+		var next []string
+		for _, id := range frontier {
+			var items []*relationshipItem
+			if input.Direction != "inbound" {
+				outbound, err := queryRelationships(id, false)
+				if err != nil {
+					return nil, nil, err
+				}
+				items = append(items, outbound...)
+			}
+			if input.Direction != "outbound" {
+				inbound, err := queryRelationships(id, true)
+				if err != nil {
+					return nil, nil, err
+				}
+				items = append(items, inbound...)
+			}
+
+			for _, item := range items {
+				relationship := models.Relationship{
+					SourceID:   item.SourceID,
+					Relation:   item.Relation,
+					TargetID:   item.TargetID,
+					TargetType: item.TargetType,
+				}
+				if seenRelationships[relationship] {
+					continue
+				}
+				seenRelationships[relationship] = true
+				relationships = append(relationships, relationship)
+
+				// The resource on the other end of the relationship
+				neighbor, neighborType := item.TargetID, item.TargetType
+				if neighbor == id {
+					neighbor, neighborType = item.SourceID, item.SourceType
+				}
+				if _, ok := reached[neighbor]; !ok {
+					next = append(next, neighbor)
+				}
+				if reached[neighbor] == "" {
+					reached[neighbor] = neighborType
+				}
+			}
+		}
+		frontier = next
+	}
+
+	return relationships, reached, nil
+}
+
+// getRelatedResources loads every resource reached in the traversal.
+//
+// Resources which are referenced but have not been scanned are returned with only their ID and type.
+func getRelatedResources(resourceTypes map[string]string, includeAttributes bool) ([]models.RelatedResource, error) {
+	keys := make([]map[string]*dynamodb.AttributeValue, 0, len(resourceTypes))
+	for id := range resourceTypes {
+		keys = append(keys, tableKey(id))
+	}
+
+	projection := expression.NamesList(expression.Name("id"), expression.Name("type"), expression.Name("deleted"))
+	if includeAttributes {
+		projection = projection.AddNames(expression.Name("attributes"))
+	}
+	expr, err := expression.NewBuilder().WithProjection(projection).Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build resources projection")
+	}
+
+	response, err := dynamodbbatch.BatchGetItem(dynamoClient, &dynamodb.BatchGetItemInput{
+		RequestItems: map[string]*dynamodb.KeysAndAttributes{
+			env.ResourcesTable: {
+				ExpressionAttributeNames: expr.Names(),
+				Keys:                     keys,
+				ProjectionExpression:     expr.Projection(),
+			},
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get resources")
+	}
+
+	var items []*resourceItem
+	if err := dynamodbattribute.UnmarshalListOfMaps(response.Responses[env.ResourcesTable], &items); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal resources")
+	}
+
+	result := make([]models.RelatedResource, 0, len(resourceTypes))
+	for _, item := range items {
+		result = append(result, models.RelatedResource{
+			Attributes: item.Attributes,
+			Deleted:    item.Deleted,
+			ID:         item.ID,
+			Type:       item.Type,
+			Scanned:    true,
+		})
+		delete(resourceTypes, item.ID)
+	}
+	for id, resourceType := range resourceTypes {
+		result = append(result, models.RelatedResource{ID: id, Type: resourceType})
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
@@ -0,0 +1,173 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	compliancemodels "github.com/panther-labs/panther/api/lambda/compliance/models"
+	"github.com/panther-labs/panther/api/lambda/resources/models"
+	"github.com/panther-labs/panther/internal/compliance/datalake_forwarder/forwarder/diff"
+	"github.com/panther-labs/panther/internal/compliance/resourcehistory"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+)
+
+// GetResourceHistory returns each recorded configuration of a resource.
+func (API) GetResourceHistory(input *models.GetResourceHistoryInput) *events.APIGatewayProxyResponse {
+	records, err := resourcehistory.Query(dynamoClient, env.HistoryTable, input.ID, input.Until)
+	if err != nil {
+		zap.L().Error("failed to query resource history", zap.Error(err))
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	versions, err := buildVersions(records)
+	if err != nil {
+		zap.L().Error("failed to build resource versions", zap.Error(err))
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+	if len(versions) == 0 {
+		zap.L().Debug("no history for resource", zap.String("resourceID", input.ID))
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusNotFound}
+	}
+
+	// The earlier versions were still needed to compute diffs and carry over compliance status
+	result := models.GetResourceHistoryOutput{Versions: make([]models.ResourceVersion, 0, len(versions))}
+	for _, version := range versions {
+		if input.Since != nil && version.LastModified.Before(*input.Since) {
+			continue
+		}
+		result.Versions = append(result.Versions, version)
+	}
+	return gatewayapi.MarshalResponse(&result, http.StatusOK)
+}
+
+// GetResourceAtTime returns the configuration of a resource at the given time.
+func (API) GetResourceAtTime(input *models.GetResourceAtTimeInput) *events.APIGatewayProxyResponse {
+	records, err := resourcehistory.Query(dynamoClient, env.HistoryTable, input.ID, &input.Timestamp)
+	if err != nil {
+		zap.L().Error("failed to query resource history", zap.Error(err))
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	versions, err := buildVersions(records)
+	if err != nil {
+		zap.L().Error("failed to build resource versions", zap.Error(err))
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+	if len(versions) == 0 {
+		zap.L().Debug("no history for resource at time",
+			zap.String("resourceID", input.ID), zap.Time("timestamp", input.Timestamp))
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusNotFound}
+	}
+
+	result := versions[len(versions)-1]
+	return gatewayapi.MarshalResponse(&result, http.StatusOK)
+}
+
+// buildVersions folds the chronological history records of a resource into its versions.
+//
+// Each CONFIG record starts a new version. The latest EVENT since the previous version is the
+// change which produced it, and the COMPLIANCE records until the next version are its status.
+func buildVersions(records []*resourcehistory.Record) ([]models.ResourceVersion, error) {
+	var (
+		versions       []models.ResourceVersion
+		previousConfig []byte
+		lastEvent      *models.ChangeEvent
+	)
+	policyStatuses := make(map[string]compliancemodels.ComplianceStatus)
+
+	finishVersion := func() {
+		if len(versions) == 0 {
+			return
+		}
+		version := &versions[len(versions)-1]
+		version.PolicyStatuses = make(map[string]compliancemodels.ComplianceStatus, len(policyStatuses))
+		for policyID, status := range policyStatuses {
+			version.PolicyStatuses[policyID] = status
+		}
+		version.ComplianceStatus = overallStatus(policyStatuses)
+	}
+
+	for _, record := range records {
+		switch record.Kind {
+		case resourcehistory.KindEvent:
+			lastEvent = &models.ChangeEvent{
+				ChangedBy:   record.ChangedBy,
+				EventID:     record.EventID,
+				EventName:   record.EventName,
+				EventSource: record.EventSource,
+				EventTime:   record.Timestamp,
+			}
+		case resourcehistory.KindCompliance:
+			policyStatuses[record.PolicyID] = compliancemodels.ComplianceStatus(record.Status)
+		case resourcehistory.KindConfig:
+			finishVersion()
+
+			config, err := jsoniter.Marshal(record.Attributes)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to marshal resource attributes")
+			}
+			version := models.ResourceVersion{
+				Attributes:    record.Attributes,
+				ChangedBy:     lastEvent,
+				Deleted:       record.Deleted,
+				ID:            record.ResourceID,
+				IntegrationID: record.IntegrationID,
+				LastModified:  record.Timestamp,
+				Type:          record.ResourceType,
+			}
+			if previousConfig != nil {
+				changes, err := diff.CompJsons(previousConfig, config)
+				if err != nil {
+					return nil, errors.WithMessage(err, "failed to compare resource versions")
+				}
+				version.Changes = make(map[string]models.AttributeChange, len(changes))
+				for path, change := range changes {
+					version.Changes[path] = models.AttributeChange{From: change.From, To: change.To}
+				}
+			}
+
+			versions = append(versions, version)
+			previousConfig = config
+			lastEvent = nil
+		}
+	}
+
+	finishVersion()
+	return versions, nil
+}
+
+// Summarize the status of each policy into the status of the resource
+func overallStatus(policyStatuses map[string]compliancemodels.ComplianceStatus) compliancemodels.ComplianceStatus {
+	result := compliancemodels.StatusPass
+	for _, status := range policyStatuses {
+		switch status {
+		case compliancemodels.StatusError:
+			return compliancemodels.StatusError
+		case compliancemodels.StatusFail:
+			result = compliancemodels.StatusFail
+		}
+	}
+	return result
+}
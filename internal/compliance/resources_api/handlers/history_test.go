@@ -0,0 +1,94 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	compliancemodels "github.com/panther-labs/panther/api/lambda/compliance/models"
+	"github.com/panther-labs/panther/api/lambda/resources/models"
+	"github.com/panther-labs/panther/internal/compliance/resourcehistory"
+)
+
+func TestBuildVersions(t *testing.T) {
+	const bucket = "arn:aws:s3:::my-bucket"
+	base := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	records := []*resourcehistory.Record{
+		resourcehistory.NewConfigRecord(bucket, "AWS.S3.Bucket", "integration",
+			map[string]interface{}{"Versioning": "Enabled", "Name": "my-bucket"}, false, base),
+		resourcehistory.NewComplianceRecord(bucket, "Versioning", "PASS", base.Add(time.Minute)),
+		resourcehistory.NewComplianceRecord(bucket, "Encryption", "FAIL", base.Add(time.Minute)),
+		resourcehistory.NewEventRecord(bucket, "arn:aws:iam::123456789012:user/alice", "event-1",
+			"PutBucketVersioning", "s3.amazonaws.com", base.Add(time.Hour)),
+		resourcehistory.NewEventRecord(bucket, "arn:aws:iam::123456789012:user/bob", "event-2",
+			"PutBucketVersioning", "s3.amazonaws.com", base.Add(2*time.Hour)),
+		resourcehistory.NewConfigRecord(bucket, "AWS.S3.Bucket", "integration",
+			map[string]interface{}{"Versioning": "Suspended", "Name": "my-bucket"}, false, base.Add(3*time.Hour)),
+		resourcehistory.NewComplianceRecord(bucket, "Versioning", "FAIL", base.Add(4*time.Hour)),
+	}
+
+	versions, err := buildVersions(records)
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+
+	first := versions[0]
+	assert.Nil(t, first.Changes)
+	assert.Nil(t, first.ChangedBy)
+	assert.Equal(t, base, first.LastModified)
+	assert.Equal(t, compliancemodels.StatusFail, first.ComplianceStatus)
+	assert.Equal(t, map[string]compliancemodels.ComplianceStatus{
+		"Versioning": compliancemodels.StatusPass,
+		"Encryption": compliancemodels.StatusFail,
+	}, first.PolicyStatuses)
+
+	second := versions[1]
+	assert.Equal(t, map[string]models.AttributeChange{
+		"Versioning": {From: "Enabled", To: "Suspended"},
+	}, second.Changes)
+	require.NotNil(t, second.ChangedBy)
+	assert.Equal(t, "arn:aws:iam::123456789012:user/bob", second.ChangedBy.ChangedBy)
+	assert.Equal(t, "event-2", second.ChangedBy.EventID)
+	assert.Equal(t, map[string]compliancemodels.ComplianceStatus{
+		"Versioning": compliancemodels.StatusFail,
+		"Encryption": compliancemodels.StatusFail,
+	}, second.PolicyStatuses)
+}
+
+func TestBuildVersionsEmpty(t *testing.T) {
+	// Events and statuses without a recorded configuration don't make a version
+	records := []*resourcehistory.Record{
+		resourcehistory.NewComplianceRecord("id", "policy", "PASS", time.Now()),
+	}
+	versions, err := buildVersions(records)
+	require.NoError(t, err)
+	assert.Empty(t, versions)
+}
+
+func TestOverallStatus(t *testing.T) {
+	assert.Equal(t, compliancemodels.StatusPass, overallStatus(nil))
+	assert.Equal(t, compliancemodels.StatusError, overallStatus(map[string]compliancemodels.ComplianceStatus{
+		"a": compliancemodels.StatusFail,
+		"b": compliancemodels.StatusError,
+		"c": compliancemodels.StatusPass,
+	}))
+}
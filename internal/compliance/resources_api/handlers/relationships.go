@@ -0,0 +1,307 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/pkg/errors"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/pkg/awsbatch/dynamodbbatch"
+)
+
+// Name of the relationships table index keyed by the target resource
+const relationshipsTargetIndex = "target-index"
+
+// A directed edge between two resources, stored in the relationships table
+type relationshipItem struct {
+	SourceID   string `json:"sourceId"`
+	SourceType string `json:"sourceType"`
+	// Range key: relation#targetId, a resource can reference the same target in more than one way
+	SortKey    string `json:"sortKey"`
+	Relation   string `json:"relation"`
+	TargetID   string `json:"targetId"`
+	TargetType string `json:"targetType"`
+}
+
+func newRelationshipItem(source *resourceItem, rule relationshipRule, targetID string) *relationshipItem {
+	return &relationshipItem{
+		SourceID:   source.ID,
+		SourceType: source.Type,
+		SortKey:    rule.Relation + "#" + targetID,
+		Relation:   rule.Relation,
+		TargetID:   targetID,
+		TargetType: rule.TargetType,
+	}
+}
+
+func (r *relationshipItem) key() map[string]*dynamodb.AttributeValue {
+	return map[string]*dynamodb.AttributeValue{
+		"sourceId": {S: aws.String(r.SourceID)},
+		"sortKey":  {S: aws.String(r.SortKey)},
+	}
+}
+
+// relationshipRule extracts one kind of relationship from the attributes of a resource.
+type relationshipRule struct {
+	Relation   string
+	TargetType string
+	// Attribute names leading to the referenced IDs, lists along the way are flattened
+	Path []string
+	// Builds the target resource ID from the referenced ID (nil if the reference is already an ARN)
+	TargetID func(source arn.ARN, reference string) string
+}
+
+// ec2ID builds the ARN of an EC2 resource in the same account and region as the source resource
+func ec2ID(resourceType string) func(arn.ARN, string) string {
+	return func(source arn.ARN, reference string) string {
+		return arn.ARN{
+			Partition: source.Partition,
+			Service:   "ec2",
+			Region:    source.Region,
+			AccountID: source.AccountID,
+			Resource:  resourceType + "/" + reference,
+		}.String()
+	}
+}
+
+func path(names ...string) []string {
+	return names
+}
+
+var (
+	securityGroupIDs = ec2ID("security-group")
+	vpcIDs           = ec2ID("vpc")
+)
+
+// Relationships extracted from each resource type, keyed by the source resource type
+var relationshipRules = map[string][]relationshipRule{
+	awsmodels.Ec2InstanceSchema: {
+		{"image", awsmodels.Ec2AmiSchema, path("ImageId"), ec2ID("image")},
+		{"securityGroup", awsmodels.Ec2SecurityGroupSchema, path("SecurityGroups", "GroupId"), securityGroupIDs},
+		{"volume", awsmodels.Ec2VolumeSchema, path("BlockDeviceMappings", "Ebs", "VolumeId"), ec2ID("volume")},
+		{"vpc", awsmodels.Ec2VpcSchema, path("VpcId"), vpcIDs},
+	},
+	awsmodels.Ec2NetworkAclSchema: {
+		{"vpc", awsmodels.Ec2VpcSchema, path("VpcId"), vpcIDs},
+	},
+	awsmodels.Ec2SecurityGroupSchema: {
+		{"vpc", awsmodels.Ec2VpcSchema, path("VpcId"), vpcIDs},
+	},
+	awsmodels.Ec2VolumeSchema: {
+		{"attachedInstance", awsmodels.Ec2InstanceSchema, path("Attachments", "InstanceId"), ec2ID("instance")},
+		{"kmsKey", awsmodels.KmsKeySchema, path("KmsKeyId"), nil},
+	},
+	awsmodels.Elbv2LoadBalancerSchema: {
+		{"securityGroup", awsmodels.Ec2SecurityGroupSchema, path("SecurityGroups"), securityGroupIDs},
+		{"vpc", awsmodels.Ec2VpcSchema, path("VpcId"), vpcIDs},
+	},
+	awsmodels.IAMGroupSchema: {
+		{"managedPolicy", awsmodels.IAMPolicySchema, path("ManagedPolicyARNs"), nil},
+	},
+	awsmodels.IAMRoleSchema: {
+		{"managedPolicy", awsmodels.IAMPolicySchema, path("ManagedPolicyARNs"), nil},
+		{"permissionsBoundary", awsmodels.IAMPolicySchema, path("PermissionsBoundary", "PermissionsBoundaryArn"), nil},
+	},
+	awsmodels.IAMUserSchema: {
+		{"group", awsmodels.IAMGroupSchema, path("Groups", "Arn"), nil},
+		{"permissionsBoundary", awsmodels.IAMPolicySchema, path("PermissionsBoundary", "PermissionsBoundaryArn"), nil},
+	},
+	awsmodels.LambdaFunctionSchema: {
+		{"executionRole", awsmodels.IAMRoleSchema, path("Role"), nil},
+		{"kmsKey", awsmodels.KmsKeySchema, path("KMSKeyArn"), nil},
+		{"securityGroup", awsmodels.Ec2SecurityGroupSchema, path("VpcConfig", "SecurityGroupIds"), securityGroupIDs},
+		{"vpc", awsmodels.Ec2VpcSchema, path("VpcConfig", "VpcId"), vpcIDs},
+	},
+	awsmodels.RDSInstanceSchema: {
+		{"kmsKey", awsmodels.KmsKeySchema, path("KmsKeyId"), nil},
+		{"securityGroup", awsmodels.Ec2SecurityGroupSchema, path("VpcSecurityGroups", "VpcSecurityGroupId"), securityGroupIDs},
+		{"vpc", awsmodels.Ec2VpcSchema, path("DBSubnetGroup", "VpcId"), vpcIDs},
+	},
+	awsmodels.RedshiftClusterSchema: {
+		{"kmsKey", awsmodels.KmsKeySchema, path("KmsKeyId"), nil},
+		{"securityGroup", awsmodels.Ec2SecurityGroupSchema, path("VpcSecurityGroups", "VpcSecurityGroupId"), securityGroupIDs},
+		{"vpc", awsmodels.Ec2VpcSchema, path("VpcId"), vpcIDs},
+	},
+}
+
+// extractRelationships finds the resources referenced in the attributes of a resource.
+func extractRelationships(resource *resourceItem) []*relationshipItem {
+	rules := relationshipRules[resource.Type]
+	if len(rules) == 0 {
+		return nil
+	}
+
+	// The IDs of related resources are built from the account and region of the source
+	source, err := arn.Parse(resource.ID)
+	if err != nil {
+		return nil
+	}
+
+	var result []*relationshipItem
+	seen := make(map[string]bool)
+	for _, rule := range rules {
+		for _, reference := range attributeStrings(resource.Attributes, rule.Path) {
+			targetID := reference
+			if rule.TargetID != nil && !arn.IsARN(reference) {
+				targetID = rule.TargetID(source, reference)
+			} else if !arn.IsARN(reference) {
+				continue // a reference we can't resolve to a resource ID, e.g. a KMS key alias
+			}
+
+			item := newRelationshipItem(resource, rule, targetID)
+			if targetID == resource.ID || seen[item.SortKey] {
+				continue
+			}
+			seen[item.SortKey] = true
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// attributeStrings returns the non-empty strings found at the end of an attribute path.
+func attributeStrings(value interface{}, path []string) []string {
+	switch value := value.(type) {
+	case string:
+		if len(path) == 0 && strings.TrimSpace(value) != "" {
+			return []string{value}
+		}
+	case []interface{}:
+		var result []string
+		for _, element := range value {
+			result = append(result, attributeStrings(element, path)...)
+		}
+		return result
+	case map[string]interface{}:
+		if len(path) > 0 {
+			return attributeStrings(value[path[0]], path[1:])
+		}
+	}
+	return nil
+}
+
+// replaceRelationships stores the current outbound relationships of each resource,
+// removing relationships which are no longer present in its attributes.
+func replaceRelationships(resources []*resourceItem) error {
+	var writeRequests []*dynamodb.WriteRequest
+	for _, resource := range resources {
+		if len(relationshipRules[resource.Type]) == 0 {
+			continue
+		}
+
+		existing, err := queryRelationships(resource.ID, false)
+		if err != nil {
+			return err
+		}
+
+		current := make(map[string]bool)
+		for _, item := range extractRelationships(resource) {
+			current[item.SortKey] = true
+			marshalled, err := dynamodbattribute.MarshalMap(item)
+			if err != nil {
+				return errors.Wrap(err, "failed to marshal relationship")
+			}
+			writeRequests = append(writeRequests, &dynamodb.WriteRequest{PutRequest: &dynamodb.PutRequest{Item: marshalled}})
+		}
+
+		for _, item := range existing {
+			if !current[item.SortKey] {
+				writeRequests = append(writeRequests, &dynamodb.WriteRequest{DeleteRequest: &dynamodb.DeleteRequest{Key: item.key()}})
+			}
+		}
+	}
+
+	return writeRelationships(writeRequests)
+}
+
+// deleteRelationships removes the outbound relationships of deleted resources.
+//
+// Inbound relationships are kept: they are removed when the referencing resource changes.
+func deleteRelationships(resourceIDs []string) error {
+	var writeRequests []*dynamodb.WriteRequest
+	for _, id := range resourceIDs {
+		existing, err := queryRelationships(id, false)
+		if err != nil {
+			return err
+		}
+		for _, item := range existing {
+			writeRequests = append(writeRequests, &dynamodb.WriteRequest{DeleteRequest: &dynamodb.DeleteRequest{Key: item.key()}})
+		}
+	}
+
+	return writeRelationships(writeRequests)
+}
+
+func writeRelationships(writeRequests []*dynamodb.WriteRequest) error {
+	if len(writeRequests) == 0 {
+		return nil
+	}
+
+	input := &dynamodb.BatchWriteItemInput{
+		RequestItems: map[string][]*dynamodb.WriteRequest{env.RelationshipsTable: writeRequests},
+	}
+	return dynamodbbatch.BatchWriteItem(dynamoClient, maxBackoff, input)
+}
+
+// queryRelationships returns the relationships from (outbound) or to (inbound) a resource.
+func queryRelationships(resourceID string, inbound bool) ([]*relationshipItem, error) {
+	keyName, indexName := "sourceId", (*string)(nil)
+	if inbound {
+		keyName, indexName = "targetId", aws.String(relationshipsTargetIndex)
+	}
+
+	expr, err := expression.NewBuilder().
+		WithKeyCondition(expression.Key(keyName).Equal(expression.Value(resourceID))).
+		Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build relationships query")
+	}
+
+	var result []*relationshipItem
+	var unmarshalErr error
+	err = dynamoClient.QueryPages(&dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		IndexName:                 indexName,
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 &env.RelationshipsTable,
+	}, func(page *dynamodb.QueryOutput, lastPage bool) bool {
+		var items []*relationshipItem
+		if unmarshalErr = dynamodbattribute.UnmarshalListOfMaps(page.Items, &items); unmarshalErr != nil {
+			return false // stop paginating
+		}
+		result = append(result, items...)
+		return true
+	})
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query relationships of %s", resourceID)
+	}
+	if unmarshalErr != nil {
+		return nil, errors.Wrap(unmarshalErr, "failed to unmarshal relationships")
+	}
+	return result, nil
+}
@@ -0,0 +1,158 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/resources/models"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+const (
+	testInstance = "arn:aws:ec2:us-west-2:123456789012:instance/i-1"
+	testGroup    = "arn:aws:ec2:us-west-2:123456789012:security-group/sg-1"
+	testVpc      = "arn:aws:ec2:us-west-2:123456789012:vpc/vpc-1"
+)
+
+func TestExtractRelationships(t *testing.T) {
+	resource := &resourceItem{
+		ID:   testInstance,
+		Type: "AWS.EC2.Instance",
+		Attributes: map[string]interface{}{
+			"ImageId": "ami-1",
+			"SecurityGroups": []interface{}{
+				map[string]interface{}{"GroupId": "sg-1", "GroupName": "default"},
+				map[string]interface{}{"GroupId": "sg-1", "GroupName": "duplicate"},
+			},
+			"BlockDeviceMappings": []interface{}{
+				map[string]interface{}{"Ebs": map[string]interface{}{"VolumeId": "vol-1"}},
+				map[string]interface{}{"Ebs": nil},
+			},
+			"VpcId": "vpc-1",
+		},
+	}
+
+	var result []string
+	for _, item := range extractRelationships(resource) {
+		assert.Equal(t, testInstance, item.SourceID)
+		assert.Equal(t, item.Relation+"#"+item.TargetID, item.SortKey)
+		result = append(result, item.SortKey)
+	}
+	assert.Equal(t, []string{
+		"image#arn:aws:ec2:us-west-2:123456789012:image/ami-1",
+		"securityGroup#" + testGroup,
+		"volume#arn:aws:ec2:us-west-2:123456789012:volume/vol-1",
+		"vpc#" + testVpc,
+	}, result)
+}
+
+func TestExtractRelationshipsARNs(t *testing.T) {
+	resource := &resourceItem{
+		ID:   "arn:aws:lambda:us-west-2:123456789012:function:my-function",
+		Type: "AWS.Lambda.Function",
+		Attributes: map[string]interface{}{
+			"KMSKeyArn": "alias/aws/lambda", // not a resource ID
+			"Role":      "arn:aws:iam::123456789012:role/my-role",
+			"VpcConfig": map[string]interface{}{"SecurityGroupIds": []interface{}{"sg-1"}, "VpcId": ""},
+		},
+	}
+
+	items := extractRelationships(resource)
+	require.Len(t, items, 2)
+	assert.Equal(t, "arn:aws:iam::123456789012:role/my-role", items[0].TargetID)
+	assert.Equal(t, "AWS.IAM.Role", items[0].TargetType)
+	assert.Equal(t, "arn:aws:ec2:us-west-2:123456789012:security-group/sg-1", items[1].TargetID)
+}
+
+func TestExtractRelationshipsUnknownType(t *testing.T) {
+	resource := &resourceItem{
+		ID:         "arn:aws:s3:::my-bucket",
+		Type:       "AWS.S3.Bucket",
+		Attributes: map[string]interface{}{"VpcId": "vpc-1"},
+	}
+	assert.Nil(t, extractRelationships(resource))
+}
+
+// Respond to relationship queries with the given edges
+func mockRelationshipQueries(t *testing.T, mockDynamo *testutils.DynamoDBMock, edges []*relationshipItem) {
+	mockDynamo.On("QueryPages", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		input := args.Get(0).(*dynamodb.QueryInput)
+		key := aws.StringValue(input.ExpressionAttributeValues[":0"].S)
+
+		var page []*relationshipItem
+		for _, edge := range edges {
+			if (input.IndexName == nil && edge.SourceID == key) || (input.IndexName != nil && edge.TargetID == key) {
+				page = append(page, edge)
+			}
+		}
+		items, err := dynamodbattribute.MarshalList(page)
+		require.NoError(t, err)
+
+		output := &dynamodb.QueryOutput{}
+		for _, item := range items {
+			output.Items = append(output.Items, item.M)
+		}
+		args.Get(1).(func(*dynamodb.QueryOutput, bool) bool)(output, true)
+	})
+}
+
+func TestTraverseRelationships(t *testing.T) {
+	mockDynamo := &testutils.DynamoDBMock{}
+	dynamoClient = mockDynamo
+	instance := &resourceItem{ID: testInstance, Type: "AWS.EC2.Instance"}
+	group := &resourceItem{ID: testGroup, Type: "AWS.EC2.SecurityGroup"}
+	mockRelationshipQueries(t, mockDynamo, []*relationshipItem{
+		newRelationshipItem(instance, relationshipRule{Relation: "securityGroup", TargetType: group.Type}, testGroup),
+		newRelationshipItem(group, relationshipRule{Relation: "vpc", TargetType: "AWS.EC2.VPC"}, testVpc),
+	})
+
+	// Outbound from the instance to depth 1
+	relationships, reached, err := traverseRelationships(&models.GetResourceRelationshipsInput{
+		IDs: []string{testInstance}, Depth: 1, Direction: "outbound",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []models.Relationship{
+		{SourceID: testInstance, Relation: "securityGroup", TargetID: testGroup, TargetType: group.Type},
+	}, relationships)
+	assert.Equal(t, map[string]string{testInstance: "", testGroup: group.Type}, reached)
+
+	// Both directions from the security group reach the instance and the VPC
+	relationships, reached, err = traverseRelationships(&models.GetResourceRelationshipsInput{
+		IDs: []string{testGroup}, Depth: 1, Direction: "both",
+	})
+	require.NoError(t, err)
+	assert.Len(t, relationships, 2)
+	assert.Equal(t, map[string]string{testGroup: "", testInstance: instance.Type, testVpc: "AWS.EC2.VPC"}, reached)
+
+	// Inbound from the VPC to depth 2 reaches the instance through the security group
+	relationships, reached, err = traverseRelationships(&models.GetResourceRelationshipsInput{
+		IDs: []string{testVpc}, Depth: 2, Direction: "inbound",
+	})
+	require.NoError(t, err)
+	assert.Len(t, relationships, 2)
+	assert.Equal(t, map[string]string{testVpc: "", testGroup: group.Type, testInstance: instance.Type}, reached)
+}
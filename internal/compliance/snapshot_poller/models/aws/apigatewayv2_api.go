@@ -0,0 +1,50 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/service/apigatewayv2"
+)
+
+const (
+	ApiGatewayV2ApiSchema = "AWS.ApiGatewayV2.Api"
+)
+
+// ApiGatewayV2Api contains all the information about an API Gateway HTTP or WebSocket API
+type ApiGatewayV2Api struct {
+	// Generic resource fields
+	GenericAWSResource
+	GenericResource
+
+	// Fields embedded from apigatewayv2.Api
+	ApiEndpoint               *string
+	ApiGatewayManaged         *bool
+	ApiKeySelectionExpression *string
+	CorsConfiguration         *apigatewayv2.Cors
+	Description               *string
+	DisableExecuteApiEndpoint *bool
+	DisableSchemaValidation   *bool
+	ProtocolType              *string
+	RouteSelectionExpression  *string
+	Version                   *string
+
+	// Additional fields
+	Authorizers []*apigatewayv2.Authorizer
+	Stages      []*apigatewayv2.Stage
+}
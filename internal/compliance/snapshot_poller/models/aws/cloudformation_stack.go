@@ -55,5 +55,36 @@ type CloudFormationStack struct {
 	TimeoutInMinutes            *int64
 
 	// Additional fields
-	Drifts []*cloudformation.StackResourceDrift
+	Drifts         []*cloudformation.StackResourceDrift
+	DriftDetection *CloudFormationDriftDetection
+	ResourceDrifts map[string]*CloudFormationResourceDrift
+}
+
+// CloudFormationDriftDetection is the result of the drift detection started by the latest scan
+type CloudFormationDriftDetection struct {
+	DetectionStatus           *string
+	DetectionStatusReason     *string
+	DriftedStackResourceCount *int64
+	StackDriftDetectionId     *string
+	StackDriftStatus          *string
+	Timestamp                 *time.Time
+}
+
+// CloudFormationResourceDrift is the drift status of a stack resource, keyed by its logical ID in
+// CloudFormationStack.ResourceDrifts
+type CloudFormationResourceDrift struct {
+	PhysicalResourceId  *string
+	ResourceType        *string
+	DriftStatus         *string
+	Timestamp           *time.Time
+	PropertyDifferences []*CloudFormationPropertyDifference
+}
+
+// CloudFormationPropertyDifference is a resource property whose actual value differs from the
+// value expected by the stack template. The values are decoded from JSON when possible.
+type CloudFormationPropertyDifference struct {
+	PropertyPath   *string
+	DifferenceType *string
+	ExpectedValue  interface{}
+	ActualValue    interface{}
 }
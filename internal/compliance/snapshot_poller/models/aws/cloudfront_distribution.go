@@ -0,0 +1,62 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/cloudfront"
+)
+
+const (
+	CloudFrontDistributionSchema = "AWS.CloudFront.Distribution"
+)
+
+// CloudFrontDistribution contains all the information about a CloudFront web distribution
+type CloudFrontDistribution struct {
+	// Generic resource fields
+	GenericAWSResource
+	GenericResource
+
+	// Fields embedded from cloudfront.Distribution
+	ActiveTrustedKeyGroups        *cloudfront.ActiveTrustedKeyGroups
+	ActiveTrustedSigners          *cloudfront.ActiveTrustedSigners
+	DomainName                    *string
+	InProgressInvalidationBatches *int64
+	LastModifiedTime              *time.Time
+	Status                        *string
+
+	// Fields embedded from cloudfront.DistributionConfig
+	Aliases              *cloudfront.Aliases
+	CacheBehaviors       *cloudfront.CacheBehaviors
+	Comment              *string
+	CustomErrorResponses *cloudfront.CustomErrorResponses
+	DefaultCacheBehavior *cloudfront.DefaultCacheBehavior
+	DefaultRootObject    *string
+	Enabled              *bool
+	HttpVersion          *string
+	IsIPV6Enabled        *bool
+	Logging              *cloudfront.LoggingConfig
+	OriginGroups         *cloudfront.OriginGroups
+	Origins              *cloudfront.Origins
+	PriceClass           *string
+	Restrictions         *cloudfront.Restrictions
+	ViewerCertificate    *cloudfront.ViewerCertificate
+	WebACLId             *string
+}
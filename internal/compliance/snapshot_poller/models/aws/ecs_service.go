@@ -0,0 +1,62 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/service/ecs"
+)
+
+const (
+	EcsServiceSchema = "AWS.ECS.Service"
+)
+
+// EcsServiceResource contains all the information about an ECS Service, as a resource of its own.
+//
+// EcsService holds the same information for embedding into the EcsCluster resource.
+type EcsServiceResource struct {
+	// Generic resource fields
+	GenericAWSResource
+	GenericResource
+
+	// Fields embedded from ecs.Service
+	CapacityProviderStrategy      []*ecs.CapacityProviderStrategyItem
+	ClusterArn                    *string
+	CreatedBy                     *string
+	DeploymentConfiguration       *ecs.DeploymentConfiguration
+	DeploymentController          *ecs.DeploymentController
+	Deployments                   []*ecs.Deployment
+	DesiredCount                  *int64
+	EnableECSManagedTags          *bool
+	HealthCheckGracePeriodSeconds *int64
+	LaunchType                    *string
+	LoadBalancers                 []*ecs.LoadBalancer
+	NetworkConfiguration          *ecs.NetworkConfiguration
+	PendingCount                  *int64
+	PlacementConstraints          []*ecs.PlacementConstraint
+	PlacementStrategy             []*ecs.PlacementStrategy
+	PlatformVersion               *string
+	PropagateTags                 *string
+	RoleArn                       *string
+	RunningCount                  *int64
+	SchedulingStrategy            *string
+	ServiceRegistries             []*ecs.ServiceRegistry
+	Status                        *string
+	TaskDefinition                *string
+	TaskSets                      []*ecs.TaskSet
+}
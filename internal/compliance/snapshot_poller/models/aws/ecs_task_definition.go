@@ -0,0 +1,59 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/service/ecs"
+)
+
+const (
+	EcsTaskDefinitionSchema = "AWS.ECS.TaskDefinition"
+)
+
+// EcsTaskDefinition contains all the information about an active ECS task definition revision.
+//
+// The values of container environment variables are never stored, only their names.
+type EcsTaskDefinition struct {
+	// Generic resource fields
+	GenericAWSResource
+	GenericResource
+
+	// Fields embedded from ecs.TaskDefinition
+	Compatibilities         []*string
+	ContainerDefinitions    []*ecs.ContainerDefinition
+	Cpu                     *string
+	ExecutionRoleArn        *string
+	Family                  *string
+	InferenceAccelerators   []*ecs.InferenceAccelerator
+	IpcMode                 *string
+	Memory                  *string
+	NetworkMode             *string
+	PidMode                 *string
+	PlacementConstraints    []*ecs.TaskDefinitionPlacementConstraint
+	ProxyConfiguration      *ecs.ProxyConfiguration
+	RequiresAttributes      []*ecs.Attribute
+	RequiresCompatibilities []*string
+	Revision                *int64
+	Status                  *string
+	TaskRoleArn             *string
+	Volumes                 []*ecs.Volume
+
+	// Additional fields
+	PrivilegedContainers []*string
+}
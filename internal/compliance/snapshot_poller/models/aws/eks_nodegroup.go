@@ -0,0 +1,57 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/eks"
+)
+
+const (
+	EksNodeGroupSchema = "AWS.EKS.NodeGroup"
+)
+
+// EksNodeGroupResource contains all the information about an EKS managed node group, as a resource
+// of its own.
+//
+// EksNodegroup holds the same information for embedding into the EksCluster resource.
+type EksNodeGroupResource struct {
+	// Generic resource fields
+	GenericAWSResource
+	GenericResource
+
+	// Fields embedded from eks.Nodegroup
+	AmiType        *string
+	ClusterName    *string
+	DiskSize       *int64
+	Health         *eks.NodegroupHealth
+	InstanceTypes  []*string
+	Labels         map[string]*string
+	LaunchTemplate *eks.LaunchTemplateSpecification
+	ModifiedAt     *time.Time
+	NodeRole       *string
+	ReleaseVersion *string
+	RemoteAccess   *eks.RemoteAccessConfig
+	Resources      *eks.NodegroupResources
+	ScalingConfig  *eks.NodegroupScalingConfig
+	Status         *string
+	Subnets        []*string
+	Version        *string
+}
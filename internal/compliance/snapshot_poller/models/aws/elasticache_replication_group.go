@@ -0,0 +1,64 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/elasticache"
+)
+
+const (
+	ElastiCacheReplicationGroupSchema = "AWS.ElastiCache.ReplicationGroup"
+)
+
+// ElastiCacheReplicationGroup contains all the information about an ElastiCache replication group
+type ElastiCacheReplicationGroup struct {
+	// Generic resource fields
+	GenericAWSResource
+	GenericResource
+
+	// Fields embedded from elasticache.ReplicationGroup
+	AtRestEncryptionEnabled    *bool
+	AuthTokenEnabled           *bool
+	AuthTokenLastModifiedDate  *time.Time
+	AutomaticFailover          *string
+	CacheNodeType              *string
+	ClusterEnabled             *bool
+	ConfigurationEndpoint      *elasticache.Endpoint
+	Description                *string
+	GlobalReplicationGroupInfo *elasticache.GlobalReplicationGroupInfo
+	KmsKeyId                   *string
+	MemberClusters             []*string
+	MultiAZ                    *string
+	NodeGroups                 []*elasticache.NodeGroup
+	SnapshotRetentionLimit     *int64
+	SnapshotWindow             *string
+	SnapshottingClusterId      *string
+	Status                     *string
+	TransitEncryptionEnabled   *bool
+	UserGroupIds               []*string
+
+	// Fields embedded from the elasticache.CacheCluster members, which all share these settings
+	AutoMinorVersionUpgrade *bool
+	CacheSubnetGroupName    *string
+	Engine                  *string
+	EngineVersion           *string
+	SecurityGroups          []*elasticache.SecurityGroupMembership
+}
@@ -0,0 +1,54 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/service/elb"
+)
+
+const (
+	ElbLoadBalancerSchema = "AWS.ELB.LoadBalancer"
+)
+
+// ElbLoadBalancer contains all the information about a classic load balancer
+type ElbLoadBalancer struct {
+	// Generic resource fields
+	GenericAWSResource
+	GenericResource
+
+	// Fields embedded from elb.LoadBalancerDescription
+	AvailabilityZones         []*string
+	BackendServerDescriptions []*elb.BackendServerDescription
+	CanonicalHostedZoneName   *string
+	CanonicalHostedZoneNameID *string
+	DNSName                   *string
+	HealthCheck               *elb.HealthCheck
+	Instances                 []*elb.Instance
+	ListenerDescriptions      []*elb.ListenerDescription
+	Policies                  *elb.Policies
+	Scheme                    *string
+	SecurityGroups            []*string
+	SourceSecurityGroup       *elb.SourceSecurityGroup
+	Subnets                   []*string
+	VPCId                     *string
+
+	// Additional fields
+	Attributes         *elb.LoadBalancerAttributes
+	PolicyDescriptions []*elb.PolicyDescription
+}
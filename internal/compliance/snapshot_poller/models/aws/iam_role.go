@@ -40,5 +40,6 @@ type IAMRole struct {
 
 	// Additional fields
 	InlinePolicies     map[string]*string
+	ManagedPolicyARNs  []*string
 	ManagedPolicyNames []*string
 }
@@ -0,0 +1,111 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/organizations"
+)
+
+const (
+	OrganizationsAccountSchema                = "AWS.Organizations.Account"
+	OrganizationsDelegatedAdministratorSchema = "AWS.Organizations.DelegatedAdministrator"
+	OrganizationsOrganizationalUnitSchema     = "AWS.Organizations.OrganizationalUnit"
+	OrganizationsServiceControlPolicySchema   = "AWS.Organizations.ServiceControlPolicy"
+	OrganizationsTagPolicySchema              = "AWS.Organizations.TagPolicy"
+)
+
+// OrganizationsAccount contains all the information about a member account of an AWS Organization
+type OrganizationsAccount struct {
+	// Generic resource fields
+	GenericAWSResource
+	GenericResource
+
+	// Fields embedded from organizations.Account
+	Email           *string
+	JoinedMethod    *string
+	JoinedTimestamp *time.Time
+	Status          *string
+
+	// Additional fields
+	OrganizationID    *string
+	ManagementAccount bool
+	OrganizationsTargetPolicies
+}
+
+// OrganizationsOrganizationalUnit contains all the information about an organizational unit
+type OrganizationsOrganizationalUnit struct {
+	// Generic resource fields
+	GenericAWSResource
+	GenericResource
+
+	// Additional fields
+	OrganizationID      *string
+	Accounts            []*string
+	OrganizationalUnits []*string
+	OrganizationsTargetPolicies
+}
+
+// OrganizationsTargetPolicies describes where an account or OU sits in the organization and the policies
+// which apply to it.
+type OrganizationsTargetPolicies struct {
+	// The IDs of the root and the OUs above the target, starting with the root
+	ParentIDs []*string
+	// The IDs of the policies attached to the target itself
+	ServiceControlPolicies []*string
+	TagPolicies            []*string
+	// The IDs of the policies attached to the target or any of its parents
+	EffectiveServiceControlPolicies []*string
+	EffectiveTagPolicies            []*string
+}
+
+// OrganizationsPolicy contains all the information about a service control policy or tag policy
+type OrganizationsPolicy struct {
+	// Generic resource fields
+	GenericAWSResource
+	GenericResource
+
+	// Fields embedded from organizations.PolicySummary
+	AwsManaged  *bool
+	Description *string
+	Type        *string
+
+	// Additional fields
+	Content *string
+	Targets []*organizations.PolicyTargetSummary
+}
+
+// OrganizationsDelegatedAdministrator contains all the information about a member account which is
+// allowed to administer AWS services on behalf of the organization
+type OrganizationsDelegatedAdministrator struct {
+	// Generic resource fields
+	GenericAWSResource
+	GenericResource
+
+	// Fields embedded from organizations.DelegatedAdministrator
+	DelegationEnabledDate *time.Time
+	Email                 *string
+	JoinedMethod          *string
+	JoinedTimestamp       *time.Time
+	Status                *string
+
+	// Additional fields
+	DelegatedServices []*organizations.DelegatedService
+}
@@ -0,0 +1,82 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/rds"
+)
+
+const (
+	RDSClusterSchema = "AWS.RDS.Cluster"
+)
+
+// RDSCluster contains all the information about an RDS DB cluster, such as an Aurora cluster
+type RDSCluster struct {
+	// Generic resource fields
+	GenericAWSResource
+	GenericResource
+
+	// Fields embedded from rds.DBCluster
+	ActivityStreamKinesisStreamName  *string
+	ActivityStreamKmsKeyId           *string
+	ActivityStreamMode               *string
+	ActivityStreamStatus             *string
+	AllocatedStorage                 *int64
+	AssociatedRoles                  []*rds.DBClusterRole
+	AvailabilityZones                []*string
+	BacktrackWindow                  *int64
+	BackupRetentionPeriod            *int64
+	Capacity                         *int64
+	CopyTagsToSnapshot               *bool
+	CrossAccountClone                *bool
+	CustomEndpoints                  []*string
+	DBClusterMembers                 []*rds.DBClusterMember
+	DBClusterOptionGroupMemberships  []*rds.DBClusterOptionGroupStatus
+	DBClusterParameterGroup          *string
+	DBSubnetGroup                    *string
+	DatabaseName                     *string
+	DbClusterResourceId              *string
+	DeletionProtection               *bool
+	DomainMemberships                []*rds.DomainMembership
+	EarliestRestorableTime           *time.Time
+	EnabledCloudwatchLogsExports     []*string
+	Endpoint                         *string
+	Engine                           *string
+	EngineMode                       *string
+	EngineVersion                    *string
+	HostedZoneId                     *string
+	HttpEndpointEnabled              *bool
+	IAMDatabaseAuthenticationEnabled *bool
+	KmsKeyId                         *string
+	LatestRestorableTime             *time.Time
+	MasterUsername                   *string
+	MultiAZ                          *bool
+	Port                             *int64
+	PreferredBackupWindow            *string
+	PreferredMaintenanceWindow       *string
+	ReadReplicaIdentifiers           []*string
+	ReaderEndpoint                   *string
+	ReplicationSourceIdentifier      *string
+	ScalingConfigurationInfo         *rds.ScalingConfigurationInfo
+	Status                           *string
+	StorageEncrypted                 *bool
+	VpcSecurityGroups                []*rds.VpcSecurityGroupMembership
+}
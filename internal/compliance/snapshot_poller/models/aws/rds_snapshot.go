@@ -0,0 +1,96 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/rds"
+)
+
+const (
+	RDSSnapshotSchema        = "AWS.RDS.Snapshot"
+	RDSClusterSnapshotSchema = "AWS.RDS.ClusterSnapshot"
+)
+
+// RDSSnapshot contains all the information about a snapshot of an RDS DB instance
+type RDSSnapshot struct {
+	// Generic resource fields
+	GenericAWSResource
+	GenericResource
+
+	// Fields embedded from rds.DBSnapshot
+	AllocatedStorage                 *int64
+	AvailabilityZone                 *string
+	DBInstanceIdentifier             *string
+	DbiResourceId                    *string
+	Encrypted                        *bool
+	Engine                           *string
+	EngineVersion                    *string
+	IAMDatabaseAuthenticationEnabled *bool
+	InstanceCreateTime               *time.Time
+	Iops                             *int64
+	KmsKeyId                         *string
+	LicenseModel                     *string
+	MasterUsername                   *string
+	OptionGroupName                  *string
+	PercentProgress                  *int64
+	Port                             *int64
+	SnapshotType                     *string
+	SourceDBSnapshotIdentifier       *string
+	SourceRegion                     *string
+	Status                           *string
+	StorageType                      *string
+	TdeCredentialArn                 *string
+	VpcId                            *string
+
+	// Additional fields
+	Attributes []*rds.DBSnapshotAttribute
+	Public     *bool
+}
+
+// RDSClusterSnapshot contains all the information about a snapshot of an RDS DB cluster
+type RDSClusterSnapshot struct {
+	// Generic resource fields
+	GenericAWSResource
+	GenericResource
+
+	// Fields embedded from rds.DBClusterSnapshot
+	AllocatedStorage                 *int64
+	AvailabilityZones                []*string
+	ClusterCreateTime                *time.Time
+	DBClusterIdentifier              *string
+	Engine                           *string
+	EngineVersion                    *string
+	IAMDatabaseAuthenticationEnabled *bool
+	KmsKeyId                         *string
+	LicenseModel                     *string
+	MasterUsername                   *string
+	PercentProgress                  *int64
+	Port                             *int64
+	SnapshotType                     *string
+	SourceDBClusterSnapshotArn       *string
+	Status                           *string
+	StorageEncrypted                 *bool
+	VpcId                            *string
+
+	// Additional fields
+	Attributes []*rds.DBClusterSnapshotAttribute
+	Public     *bool
+}
@@ -0,0 +1,47 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/service/route53"
+)
+
+const (
+	Route53HostedZoneSchema = "AWS.Route53.HostedZone"
+)
+
+// Route53HostedZone contains all the information about a Route 53 hosted zone
+type Route53HostedZone struct {
+	// Generic resource fields
+	GenericAWSResource
+	GenericResource
+
+	// Fields embedded from route53.HostedZone
+	CallerReference        *string
+	Config                 *route53.HostedZoneConfig
+	LinkedService          *route53.LinkedService
+	ResourceRecordSetCount *int64
+
+	// Fields embedded from route53.GetHostedZoneOutput
+	DelegationSet *route53.DelegationSet
+	VPCs          []*route53.VPC
+
+	// Additional fields
+	QueryLoggingConfigs []*route53.QueryLoggingConfig
+}
@@ -26,11 +26,15 @@ import (
 	"github.com/aws/aws-sdk-go/aws/arn"
 
 	resourcesapimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	sourcemodels "github.com/panther-labs/panther/api/lambda/source/models"
 )
 
 // Used to populate the GenericAWSResource.Region field for global AWS resources
 const GlobalRegion = "global"
 
+// The role session name of the snapshot poller, which identifies its own API calls in CloudTrail
+const SnapshotPollerSessionName = "panther-snapshot-poller"
+
 // GenericResource contains fields that will be common to all resources, at some point this will
 // probably exist in a more global package but for now since this is the only poller it will exist
 // here.
@@ -76,6 +80,8 @@ type ResourcePollerInput struct {
 	ResourceTypeIgnoreList  []string
 	ResourceRegexIgnoreList []string
 	CompiledRegexIgnoreList []*regexp.Regexp
+	// How AuthSource is assumed, nil to assume it directly without an external ID
+	AuditRole *sourcemodels.AuditRoleConfig
 }
 
 func (r *ResourcePollerInput) CompileRegex() error {
@@ -0,0 +1,54 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+const ComputeFirewallSchema = "GCP.Compute.Firewall"
+
+// ComputeFirewall contains all the information about a VPC firewall rule
+type ComputeFirewall struct {
+	// Generic resource fields
+	GenericGCPResource
+	GenericResource
+
+	// Fields decoded from the Compute Engine API
+	Description           *string
+	Network               *string
+	Priority              *int64
+	Direction             *string
+	Disabled              *bool
+	SourceRanges          []*string
+	DestinationRanges     []*string
+	SourceTags            []*string
+	TargetTags            []*string
+	SourceServiceAccounts []*string
+	TargetServiceAccounts []*string
+	Allowed               []*ComputeFirewallRule
+	Denied                []*ComputeFirewallRule
+	LogConfig             *ComputeFirewallLogConfig
+}
+
+// ComputeFirewallRule is a protocol and the ports which are allowed or denied
+type ComputeFirewallRule struct {
+	IPProtocol *string
+	Ports      []*string
+}
+
+type ComputeFirewallLogConfig struct {
+	Enable *bool
+}
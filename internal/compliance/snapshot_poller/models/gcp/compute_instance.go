@@ -0,0 +1,101 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+const ComputeInstanceSchema = "GCP.Compute.Instance"
+
+// ComputeInstance contains all the information about a Compute Engine VM instance
+type ComputeInstance struct {
+	// Generic resource fields
+	GenericGCPResource
+	GenericResource
+
+	// Fields decoded from the Compute Engine API
+	Description                *string
+	Zone                       *string
+	MachineType                *string
+	Status                     *string
+	CanIPForward               *bool
+	DeletionProtection         *bool
+	Tags                       *ComputeInstanceTags
+	NetworkInterfaces          []*ComputeNetworkInterface
+	Disks                      []*ComputeAttachedDisk
+	ServiceAccounts            []*ComputeServiceAccount
+	Metadata                   *ComputeMetadata
+	ShieldedInstanceConfig     *ComputeShieldedInstanceConfig
+	ConfidentialInstanceConfig *ComputeConfidentialInstanceConfig
+}
+
+// ComputeInstanceTags are the network tags of an instance, which firewall rules can target
+type ComputeInstanceTags struct {
+	Items []*string
+}
+
+type ComputeNetworkInterface struct {
+	Name          *string
+	Network       *string
+	Subnetwork    *string
+	NetworkIP     *string
+	AccessConfigs []*ComputeAccessConfig
+}
+
+// ComputeAccessConfig gives a network interface an external IP address
+type ComputeAccessConfig struct {
+	Name  *string
+	Type  *string
+	NatIP *string
+}
+
+type ComputeAttachedDisk struct {
+	DeviceName        *string
+	Source            *string
+	Boot              *bool
+	Mode              *string
+	DiskEncryptionKey *ComputeDiskEncryptionKey
+}
+
+type ComputeDiskEncryptionKey struct {
+	KMSKeyName *string
+}
+
+type ComputeServiceAccount struct {
+	Email  *string
+	Scopes []*string
+}
+
+// ComputeMetadata is the instance metadata. The values of startup and shutdown scripts are removed
+// while polling as they often contain secrets.
+type ComputeMetadata struct {
+	Items []*ComputeMetadataItem
+}
+
+type ComputeMetadataItem struct {
+	Key   *string
+	Value *string
+}
+
+type ComputeShieldedInstanceConfig struct {
+	EnableSecureBoot          *bool
+	EnableVtpm                *bool
+	EnableIntegrityMonitoring *bool
+}
+
+type ComputeConfidentialInstanceConfig struct {
+	EnableConfidentialCompute *bool
+}
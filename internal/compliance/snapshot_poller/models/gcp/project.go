@@ -0,0 +1,52 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+const (
+	ProjectSchema          = "GCP.Project"
+	IAMProjectPolicySchema = "GCP.IAM.ProjectPolicy"
+)
+
+// Project contains all the information about a GCP project
+type Project struct {
+	// Generic resource fields
+	GenericGCPResource
+	GenericResource
+
+	// Fields decoded from the Resource Manager API
+	ProjectNumber  *string
+	LifecycleState *string
+	Parent         *ProjectParent
+}
+
+// ProjectParent is the organization or folder a project belongs to
+type ProjectParent struct {
+	Type *string
+	ID   *string
+}
+
+// IAMProjectPolicy contains the IAM policy of a GCP project, which grants roles on the project
+// and everything in it
+type IAMProjectPolicy struct {
+	// Generic resource fields
+	GenericGCPResource
+	GenericResource
+
+	IAMPolicy
+}
@@ -0,0 +1,87 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+const SQLInstanceSchema = "GCP.SQL.Instance"
+
+// SQLInstance contains all the information about a Cloud SQL instance
+type SQLInstance struct {
+	// Generic resource fields
+	GenericGCPResource
+	GenericResource
+
+	// Fields decoded from the Cloud SQL Admin API
+	ConnectionName              *string
+	DatabaseVersion             *string
+	GceZone                     *string
+	State                       *string
+	BackendType                 *string
+	InstanceType                *string
+	IPAddresses                 []*SQLIPMapping
+	Settings                    *SQLSettings
+	DiskEncryptionConfiguration *SQLDiskEncryptionConfiguration
+}
+
+type SQLIPMapping struct {
+	Type      *string
+	IPAddress *string
+}
+
+type SQLSettings struct {
+	Tier                      *string
+	AvailabilityType          *string
+	ActivationPolicy          *string
+	StorageAutoResize         *bool
+	DataDiskSizeGb            *string
+	DeletionProtectionEnabled *bool
+	IPConfiguration           *SQLIPConfiguration
+	BackupConfiguration       *SQLBackupConfiguration
+	DatabaseFlags             []*SQLDatabaseFlag
+	UserLabels                map[string]*string
+}
+
+type SQLIPConfiguration struct {
+	Ipv4Enabled        *bool
+	RequireSsl         *bool
+	SslMode            *string
+	PrivateNetwork     *string
+	AuthorizedNetworks []*SQLAuthorizedNetwork
+}
+
+// SQLAuthorizedNetwork is an external network allowed to connect to the instance
+type SQLAuthorizedNetwork struct {
+	Name  *string
+	Value *string
+}
+
+type SQLBackupConfiguration struct {
+	Enabled                    *bool
+	StartTime                  *string
+	BinaryLogEnabled           *bool
+	PointInTimeRecoveryEnabled *bool
+}
+
+type SQLDatabaseFlag struct {
+	Name  *string
+	Value *string
+}
+
+type SQLDiskEncryptionConfiguration struct {
+	KMSKeyName *string
+}
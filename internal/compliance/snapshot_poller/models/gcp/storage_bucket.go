@@ -0,0 +1,75 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import "time"
+
+const StorageBucketSchema = "GCP.Storage.Bucket"
+
+// StorageBucket contains all the information about a Cloud Storage bucket
+type StorageBucket struct {
+	// Generic resource fields
+	GenericGCPResource
+	GenericResource
+
+	// Fields decoded from the Cloud Storage API
+	ProjectNumber         *string
+	Location              *string
+	LocationType          *string
+	StorageClass          *string
+	Updated               *time.Time
+	DefaultEventBasedHold *bool
+	Versioning            *StorageBucketVersioning
+	Logging               *StorageBucketLogging
+	Encryption            *StorageBucketEncryption
+	IAMConfiguration      *StorageBucketIAMConfiguration
+	RetentionPolicy       *StorageBucketRetentionPolicy
+
+	// Additional fields
+	IAMPolicy *IAMPolicy
+}
+
+type StorageBucketVersioning struct {
+	Enabled *bool
+}
+
+type StorageBucketLogging struct {
+	LogBucket       *string
+	LogObjectPrefix *string
+}
+
+type StorageBucketEncryption struct {
+	DefaultKMSKeyName *string
+}
+
+type StorageBucketIAMConfiguration struct {
+	UniformBucketLevelAccess *StorageBucketUniformBucketLevelAccess
+	PublicAccessPrevention   *string
+}
+
+type StorageBucketUniformBucketLevelAccess struct {
+	Enabled    *bool
+	LockedTime *time.Time
+}
+
+type StorageBucketRetentionPolicy struct {
+	RetentionPeriod *string
+	EffectiveTime   *time.Time
+	IsLocked        *bool
+}
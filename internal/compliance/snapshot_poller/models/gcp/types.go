@@ -0,0 +1,142 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	resourcesapimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+)
+
+// Used to populate the GenericGCPResource.Region field for global GCP resources
+const GlobalRegion = "global"
+
+// GenericResource contains fields that will be common to all resources.
+type GenericResource struct {
+	ResourceID   *string    `json:"ResourceId"`   // A panther wide unique identifier
+	ResourceType *string    `json:"ResourceType"` // A panther defined resource type
+	TimeCreated  *time.Time `json:"TimeCreated"`  // A standardized format for when the resource was created
+}
+
+// GenericGCPResource contains information that is standard across GCP resources
+//
+// The GCP resource models are decoded directly from the JSON returned by the Google APIs, which matches
+// field names case insensitively, so fields must keep the name used by the API unless they are populated
+// after decoding.
+type GenericGCPResource struct {
+	// Fields that generally need to be populated after building the snapshot
+	ProjectID *string `json:"ProjectId"` // The ID of the GCP project the resource resides in
+	Region    *string `json:"Region"`    // The region the resource exists in, value of GLOBAL_REGION if global
+
+	// Fields that can generally be populated while building the snapshot
+	ID       *string            `json:"Id,omitempty"`       // The GCP resource identifier
+	Name     *string            `json:"Name,omitempty"`     // The GCP resource name
+	SelfLink *string            `json:"SelfLink,omitempty"` // The URL of the resource in its API
+	Labels   map[string]*string // The user defined key/value labels of the resource
+}
+
+// IAMPolicy is an IAM policy attached to a GCP resource.
+type IAMPolicy struct {
+	Version      *int64
+	Etag         *string
+	Bindings     []*IAMBinding
+	AuditConfigs []*IAMAuditConfig `json:",omitempty"`
+}
+
+// IAMBinding grants a role to a list of members, optionally subject to a condition.
+type IAMBinding struct {
+	Role      *string
+	Members   []*string
+	Condition *IAMCondition `json:",omitempty"`
+}
+
+// IAMCondition restricts when an IAMBinding applies.
+type IAMCondition struct {
+	Title       *string
+	Description *string
+	Expression  *string
+}
+
+// IAMAuditConfig configures the data access audit logs of a service.
+type IAMAuditConfig struct {
+	Service         *string
+	AuditLogConfigs []*IAMAuditLogConfig
+}
+
+// IAMAuditLogConfig enables one type of audit log, except for the exempted members.
+type IAMAuditLogConfig struct {
+	LogType         *string
+	ExemptedMembers []*string
+}
+
+// ResourcePollerInput contains the metadata to request GCP resource info.
+type ResourcePollerInput struct {
+	// An http client which authenticates requests as the integration's service account
+	Client                  *http.Client
+	IntegrationID           *string
+	ProjectID               *string
+	Timestamp               *time.Time
+	NextPageToken           *string
+	RegionIgnoreList        []string
+	ResourceTypeIgnoreList  []string
+	ResourceRegexIgnoreList []string
+	CompiledRegexIgnoreList []*regexp.Regexp
+}
+
+func (r *ResourcePollerInput) CompileRegex() error {
+	r.CompiledRegexIgnoreList = make([]*regexp.Regexp, 0, len(r.ResourceRegexIgnoreList))
+
+	for _, glob := range r.ResourceRegexIgnoreList {
+		if glob == "" {
+			continue
+		}
+		// Same glob syntax as the AWS pollers, only '*' is a wildcard
+		regex := "^" + strings.ReplaceAll(regexp.QuoteMeta(glob), `\*`, `.*`) + "$"
+		compiledGlob, err := regexp.Compile(regex)
+		if err != nil {
+			return err
+		}
+		r.CompiledRegexIgnoreList = append(r.CompiledRegexIgnoreList, compiledGlob)
+	}
+	return nil
+}
+
+func (r *ResourcePollerInput) ShouldIgnoreResource(resourceID string) (ignore bool) {
+	for _, compiledRegex := range r.CompiledRegexIgnoreList {
+		if compiledRegex.MatchString(resourceID) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *ResourcePollerInput) ShouldIgnoreRegion(region string) (ignore bool) {
+	for _, ignored := range r.RegionIgnoreList {
+		if ignored == region {
+			return true
+		}
+	}
+	return false
+}
+
+// ResourcePoller represents a function to poll a specific GCP resource type in a project.
+type ResourcePoller func(input *ResourcePollerInput) ([]resourcesapimodels.AddResourceEntry, *string, error)
@@ -0,0 +1,72 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+const (
+	ValidatingWebhookConfigurationSchema = "K8s.Admission.ValidatingWebhookConfiguration"
+	MutatingWebhookConfigurationSchema   = "K8s.Admission.MutatingWebhookConfiguration"
+)
+
+// WebhookConfiguration contains all the information about a validating or mutating admission webhook
+// configuration
+type WebhookConfiguration struct {
+	// Generic resource fields
+	GenericK8sResource
+	GenericResource
+
+	// Fields decoded from the Kubernetes API
+	Webhooks []*Webhook
+}
+
+// Webhook is an admission webhook, the CA bundle of its client config is not included
+type Webhook struct {
+	Name                    *string
+	ClientConfig            *WebhookClientConfig
+	Rules                   []*RuleWithOperations
+	FailurePolicy           *string
+	MatchPolicy             *string
+	NamespaceSelector       *LabelSelector
+	ObjectSelector          *LabelSelector
+	SideEffects             *string
+	TimeoutSeconds          *int64
+	AdmissionReviewVersions []*string
+	// Only set for mutating webhooks
+	ReinvocationPolicy *string `json:",omitempty"`
+}
+
+type WebhookClientConfig struct {
+	URL     *string           `json:"Url,omitempty"`
+	Service *ServiceReference `json:",omitempty"`
+}
+
+type ServiceReference struct {
+	Namespace *string
+	Name      *string
+	Path      *string
+	Port      *int64
+}
+
+// RuleWithOperations is the operations and resources a webhook is called for
+type RuleWithOperations struct {
+	Operations  []*string
+	APIGroups   []*string `json:"ApiGroups"`
+	APIVersions []*string `json:"ApiVersions"`
+	Resources   []*string
+	Scope       *string
+}
@@ -0,0 +1,66 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+const NetworkPolicySchema = "K8s.NetworkPolicy"
+
+// NetworkPolicy contains all the information about a network policy
+type NetworkPolicy struct {
+	// Generic resource fields
+	GenericK8sResource
+	GenericResource
+
+	// Fields decoded from the Kubernetes API
+	Spec *NetworkPolicySpec
+}
+
+type NetworkPolicySpec struct {
+	PodSelector *LabelSelector
+	PolicyTypes []*string
+	Ingress     []*NetworkPolicyIngressRule
+	Egress      []*NetworkPolicyEgressRule
+}
+
+type NetworkPolicyIngressRule struct {
+	From  []*NetworkPolicyPeer
+	Ports []*NetworkPolicyPort
+}
+
+type NetworkPolicyEgressRule struct {
+	To    []*NetworkPolicyPeer
+	Ports []*NetworkPolicyPort
+}
+
+type NetworkPolicyPeer struct {
+	PodSelector       *LabelSelector `json:",omitempty"`
+	NamespaceSelector *LabelSelector `json:",omitempty"`
+	IPBlock           *IPBlock       `json:"IpBlock,omitempty"`
+}
+
+type IPBlock struct {
+	CIDR   *string `json:"Cidr"`
+	Except []*string
+}
+
+type NetworkPolicyPort struct {
+	Protocol *string
+	// A port number or the name of a container port
+	Port    interface{}
+	EndPort *int64 `json:",omitempty"`
+}
@@ -0,0 +1,77 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+const (
+	RoleSchema               = "K8s.RBAC.Role"
+	ClusterRoleSchema        = "K8s.RBAC.ClusterRole"
+	RoleBindingSchema        = "K8s.RBAC.RoleBinding"
+	ClusterRoleBindingSchema = "K8s.RBAC.ClusterRoleBinding"
+)
+
+// Role contains all the information about a Role or ClusterRole
+type Role struct {
+	// Generic resource fields
+	GenericK8sResource
+	GenericResource
+
+	// Fields decoded from the Kubernetes API
+	Rules []*PolicyRule
+	// Only set for aggregated ClusterRoles, whose rules are filled in by the controller manager
+	AggregationRule *AggregationRule `json:",omitempty"`
+}
+
+// PolicyRule allows verbs on resources or non resource URLs
+type PolicyRule struct {
+	Verbs           []*string
+	APIGroups       []*string `json:"ApiGroups,omitempty"`
+	Resources       []*string `json:",omitempty"`
+	ResourceNames   []*string `json:",omitempty"`
+	NonResourceURLs []*string `json:"NonResourceUrls,omitempty"`
+}
+
+type AggregationRule struct {
+	ClusterRoleSelectors []*LabelSelector
+}
+
+// RoleBinding contains all the information about a RoleBinding or ClusterRoleBinding
+type RoleBinding struct {
+	// Generic resource fields
+	GenericK8sResource
+	GenericResource
+
+	// Fields decoded from the Kubernetes API
+	Subjects []*Subject
+	RoleRef  *RoleRef
+}
+
+// Subject is a user, group or service account a role is bound to
+type Subject struct {
+	Kind      *string
+	APIGroup  *string `json:"ApiGroup,omitempty"`
+	Name      *string
+	Namespace *string `json:",omitempty"`
+}
+
+// RoleRef is the Role or ClusterRole granted by a binding
+type RoleRef struct {
+	APIGroup *string `json:"ApiGroup"`
+	Kind     *string
+	Name     *string
+}
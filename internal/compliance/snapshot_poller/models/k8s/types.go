@@ -0,0 +1,141 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	resourcesapimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+)
+
+// Used to populate the GenericK8sResource.Region field for cluster scoped resources
+const GlobalRegion = "global"
+
+// GenericResource contains fields that will be common to all resources.
+type GenericResource struct {
+	ResourceID   *string    `json:"ResourceId"`   // A panther wide unique identifier
+	ResourceType *string    `json:"ResourceType"` // A panther defined resource type
+	TimeCreated  *time.Time `json:"TimeCreated"`  // A standardized format for when the resource was created
+}
+
+// Generic returns the fields common to all resources.
+func (r *GenericResource) Generic() *GenericResource {
+	return r
+}
+
+// GenericK8sResource contains information that is standard across Kubernetes objects
+//
+// The models are decoded directly from the JSON returned by the Kubernetes API, which matches field
+// names case insensitively. The object metadata is decoded separately and copied to these fields.
+type GenericK8sResource struct {
+	// The namespace of namespaced objects, the value of GlobalRegion for cluster scoped objects.
+	// Namespaces take the place of regions, so the region ignore list of an integration filters namespaces.
+	Region *string `json:"Region"`
+
+	Name        *string            `json:"Name,omitempty"`
+	Namespace   *string            `json:"Namespace,omitempty"`
+	UID         *string            `json:"Uid,omitempty"`
+	Labels      map[string]*string `json:"Labels,omitempty"`
+	Annotations map[string]*string `json:"Annotations,omitempty"`
+}
+
+// K8s returns the fields common to all Kubernetes objects.
+func (r *GenericK8sResource) K8s() *GenericK8sResource {
+	return r
+}
+
+// ObjectMeta is the metadata of every Kubernetes object, as returned by the API.
+type ObjectMeta struct {
+	Name              *string            `json:"name"`
+	Namespace         *string            `json:"namespace"`
+	UID               *string            `json:"uid"`
+	CreationTimestamp *time.Time         `json:"creationTimestamp"`
+	Labels            map[string]*string `json:"labels"`
+	Annotations       map[string]*string `json:"annotations"`
+}
+
+// LabelSelector selects objects by their labels.
+type LabelSelector struct {
+	MatchLabels      map[string]*string          `json:",omitempty"`
+	MatchExpressions []*LabelSelectorRequirement `json:",omitempty"`
+}
+
+type LabelSelectorRequirement struct {
+	Key      *string
+	Operator *string
+	Values   []*string
+}
+
+// ResourcePollerInput contains the metadata to request Kubernetes resource info.
+type ResourcePollerInput struct {
+	// An http client which authenticates requests with the integration's credentials
+	Client *http.Client
+	// The URL of the cluster's API server
+	Server                  string
+	IntegrationID           *string
+	Timestamp               *time.Time
+	NextPageToken           *string
+	RegionIgnoreList        []string
+	ResourceTypeIgnoreList  []string
+	ResourceRegexIgnoreList []string
+	CompiledRegexIgnoreList []*regexp.Regexp
+}
+
+func (r *ResourcePollerInput) CompileRegex() error {
+	r.CompiledRegexIgnoreList = make([]*regexp.Regexp, 0, len(r.ResourceRegexIgnoreList))
+
+	for _, glob := range r.ResourceRegexIgnoreList {
+		if glob == "" {
+			continue
+		}
+		// Same glob syntax as the AWS pollers, only '*' is a wildcard
+		regex := "^" + strings.ReplaceAll(regexp.QuoteMeta(glob), `\*`, `.*`) + "$"
+		compiledGlob, err := regexp.Compile(regex)
+		if err != nil {
+			return err
+		}
+		r.CompiledRegexIgnoreList = append(r.CompiledRegexIgnoreList, compiledGlob)
+	}
+	return nil
+}
+
+func (r *ResourcePollerInput) ShouldIgnoreResource(resourceID string) (ignore bool) {
+	for _, compiledRegex := range r.CompiledRegexIgnoreList {
+		if compiledRegex.MatchString(resourceID) {
+			return true
+		}
+	}
+	return false
+}
+
+// ShouldIgnoreRegion is true for objects in an ignored namespace.
+func (r *ResourcePollerInput) ShouldIgnoreRegion(region string) (ignore bool) {
+	for _, ignored := range r.RegionIgnoreList {
+		if ignored == region {
+			return true
+		}
+	}
+	return false
+}
+
+// ResourcePoller represents a function to poll a specific Kubernetes resource type in a cluster.
+type ResourcePoller func(input *ResourcePollerInput) ([]resourcesapimodels.AddResourceEntry, *string, error)
@@ -0,0 +1,158 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+const (
+	PodSchema        = "K8s.Pod"
+	DeploymentSchema = "K8s.Deployment"
+)
+
+// Pod contains all the information about a pod
+type Pod struct {
+	// Generic resource fields
+	GenericK8sResource
+	GenericResource
+
+	// Fields decoded from the Kubernetes API
+	Spec   *PodSpec
+	Status *PodStatus
+
+	// Fields added by the poller
+	Security *PodSecurity
+}
+
+type PodStatus struct {
+	Phase    *string
+	HostIP   *string `json:"HostIp"`
+	PodIP    *string `json:"PodIp"`
+	QOSClass *string `json:"QosClass"`
+}
+
+// Deployment contains all the information about a deployment
+type Deployment struct {
+	// Generic resource fields
+	GenericK8sResource
+	GenericResource
+
+	// Fields decoded from the Kubernetes API
+	Spec *DeploymentSpec
+
+	// Fields added by the poller, for the pods of the deployment
+	Security *PodSecurity
+}
+
+type DeploymentSpec struct {
+	Replicas *int64
+	Selector *LabelSelector
+	Template *PodTemplateSpec
+	Paused   *bool `json:",omitempty"`
+}
+
+type PodTemplateSpec struct {
+	Spec *PodSpec
+}
+
+// PodSpec is the part of a pod specification which is relevant to its security
+type PodSpec struct {
+	ServiceAccountName           *string
+	AutomountServiceAccountToken *bool
+	NodeName                     *string `json:",omitempty"`
+	HostNetwork                  *bool
+	HostPID                      *bool `json:"HostPid"`
+	HostIPC                      *bool `json:"HostIpc"`
+	SecurityContext              *PodSecurityContext
+	InitContainers               []*Container `json:",omitempty"`
+	Containers                   []*Container
+	Volumes                      []*Volume
+}
+
+type PodSecurityContext struct {
+	RunAsUser          *int64
+	RunAsGroup         *int64
+	RunAsNonRoot       *bool
+	FSGroup            *int64   `json:"FsGroup"`
+	SupplementalGroups []*int64 `json:",omitempty"`
+}
+
+// Container is the part of a container specification which is relevant to its security
+//
+// The environment is not included, it often contains secrets.
+type Container struct {
+	Name            *string
+	Image           *string
+	ImagePullPolicy *string
+	SecurityContext *SecurityContext
+	Ports           []*ContainerPort `json:",omitempty"`
+}
+
+type SecurityContext struct {
+	Privileged               *bool
+	AllowPrivilegeEscalation *bool
+	ReadOnlyRootFilesystem   *bool
+	RunAsUser                *int64
+	RunAsGroup               *int64
+	RunAsNonRoot             *bool
+	Capabilities             *Capabilities
+}
+
+type Capabilities struct {
+	Add  []*string
+	Drop []*string
+}
+
+type ContainerPort struct {
+	Name          *string `json:",omitempty"`
+	ContainerPort *int64
+	HostPort      *int64  `json:",omitempty"`
+	HostIP        *string `json:"HostIp,omitempty"`
+	Protocol      *string
+}
+
+// Volume is a volume of a pod, only the sources which matter for security are decoded
+type Volume struct {
+	Name                  *string
+	HostPath              *HostPathVolumeSource              `json:",omitempty"`
+	Secret                *SecretVolumeSource                `json:",omitempty"`
+	PersistentVolumeClaim *PersistentVolumeClaimVolumeSource `json:",omitempty"`
+}
+
+type HostPathVolumeSource struct {
+	Path *string
+	Type *string
+}
+
+type SecretVolumeSource struct {
+	SecretName *string
+}
+
+type PersistentVolumeClaimVolumeSource struct {
+	ClaimName *string
+	ReadOnly  *bool
+}
+
+// PodSecurity summarizes the host access of a pod, so policies don't have to inspect every container
+type PodSecurity struct {
+	Privileged           bool      // At least one container is privileged
+	PrivilegedContainers []*string // The names of the privileged containers
+	HostNetwork          bool
+	HostPID              bool      `json:"HostPid"`
+	HostIPC              bool      `json:"HostIpc"`
+	HostPaths            []*string // The host paths mounted as volumes
+	HostPorts            []*int64  // The host ports containers bind to
+}
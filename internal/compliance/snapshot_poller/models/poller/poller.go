@@ -18,6 +18,10 @@ package poller
  * along with this program.  If not, see <https://www.gnu.org/licenses/>.
  */
 
+import (
+	sourcemodels "github.com/panther-labs/panther/api/lambda/source/models"
+)
+
 // ScanMsg contains a list of Scan Entries.
 type ScanMsg struct {
 	Entries []*ScanEntry `json:"entries"`
@@ -40,4 +44,12 @@ type ScanEntry struct {
 	RegionIgnoreList        []string `json:"regionIgnoreList"`
 	ResourceTypeIgnoreList  []string `json:"resourceTypeIgnoreList"`
 	ResourceRegexIgnoreList []string `json:"resourceRegexIgnoreList"`
+
+	// The role an aws-scan integration is scanned with, nil to assume the default audit role of the account
+	AuditRole *sourcemodels.AuditRoleConfig `json:"auditRole,omitempty"`
+
+	// Used instead of AWSAccountID and Region by gcp-scan integrations. Scans without a project are
+	// split into one scan per project of the integration.
+	GCPProjectID  *string  `json:"gcpProjectId,omitempty"`
+	GCPProjectIDs []string `json:"gcpProjectIds,omitempty"`
 }
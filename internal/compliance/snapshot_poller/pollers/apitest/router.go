@@ -0,0 +1,82 @@
+package apitest
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/http"
+	"sync"
+)
+
+// ErrorWriter writes the error response of an API for a failed request, whose status is either
+// http.StatusUnauthorized or http.StatusNotFound.
+type ErrorWriter func(w http.ResponseWriter, code int, path string)
+
+// Router is the handler of a fake API server, which only answers requests authorized with a bearer
+// token and responds with the handler registered for their method and path.
+type Router struct {
+	token      string
+	writeError ErrorWriter
+
+	mu       sync.Mutex
+	handlers map[string]http.HandlerFunc
+	// The method and path of every API request, in order
+	Requests []string
+}
+
+// NewRouter returns a router without any API responses.
+func NewRouter(token string, writeError ErrorWriter) *Router {
+	return &Router{
+		token:      token,
+		writeError: writeError,
+		handlers:   make(map[string]http.HandlerFunc),
+	}
+}
+
+// Handle responds to requests for a method and path (without the query) with a JSON body.
+func (r *Router) Handle(method, path string, status int, body string) {
+	r.HandleFunc(method, path, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	})
+}
+
+// HandleFunc responds to requests for a method and path (without the query) with a handler.
+func (r *Router) HandleFunc(method, path string, handler http.HandlerFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[method+" "+path] = handler
+}
+
+func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	r.Requests = append(r.Requests, req.Method+" "+req.URL.Path)
+	handler, ok := r.handlers[req.Method+" "+req.URL.Path]
+	r.mu.Unlock()
+
+	if req.Header.Get("Authorization") != "Bearer "+r.token {
+		r.writeError(w, http.StatusUnauthorized, req.URL.Path)
+		return
+	}
+	if !ok {
+		r.writeError(w, http.StatusNotFound, req.URL.Path)
+		return
+	}
+	handler(w, req)
+}
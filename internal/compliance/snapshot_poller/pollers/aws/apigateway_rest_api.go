@@ -0,0 +1,239 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/apigateway"
+	"github.com/aws/aws-sdk-go/service/apigateway/apigatewayiface"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+)
+
+// Set as variables to be overridden in testing
+var (
+	ApiGatewayClientFunc = setupApiGatewayClient
+)
+
+func setupApiGatewayClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return apigateway.New(sess, cfg)
+}
+
+func getApiGatewayClient(pollerResourceInput *awsmodels.ResourcePollerInput, region string) (apigatewayiface.APIGatewayAPI, error) {
+	client, err := getClient(pollerResourceInput, ApiGatewayClientFunc, "apigateway", region)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.(apigatewayiface.APIGatewayAPI), nil
+}
+
+// isApiGatewayNotFound reports whether an error is an API Gateway (v1 or v2) not found error
+func isApiGatewayNotFound(err error) bool {
+	var awsErr awserr.Error
+	return errors.As(err, &awsErr) && awsErr.Code() == apigateway.ErrCodeNotFoundException
+}
+
+// apiGatewayARN builds the ARN of an API Gateway resource, which never contains an account ID
+func apiGatewayARN(region, path string) *string {
+	return aws.String(arn.ARN{
+		Partition: "aws",
+		Service:   "apigateway",
+		Region:    region,
+		Resource:  path,
+	}.String())
+}
+
+// PollApiGatewayRestApi polls a single API Gateway REST API resource
+func PollApiGatewayRestApi(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	_ *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getApiGatewayClient(pollerResourceInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	restApi, err := getRestApi(client, aws.String(strings.TrimPrefix(resourceARN.Resource, "/restapis/")))
+	if err != nil || restApi == nil {
+		return nil, err
+	}
+
+	snapshot, err := buildApiGatewayRestApiSnapshot(client, restApi, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+	// API Gateway ARNs do not contain an account ID
+	snapshot.AccountID = aws.String(pollerResourceInput.AuthSourceParsedARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	return snapshot, nil
+}
+
+// listRestApis returns all the API Gateway REST APIs in the account
+func listRestApis(apigatewaySvc apigatewayiface.APIGatewayAPI, nextMarker *string) (
+	restApis []*apigateway.RestApi, marker *string, err error) {
+
+	err = apigatewaySvc.GetRestApisPages(&apigateway.GetRestApisInput{
+		Limit:    aws.Int64(int64(defaultBatchSize)),
+		Position: nextMarker,
+	},
+		func(page *apigateway.GetRestApisOutput, lastPage bool) bool {
+			return apiGatewayRestApiIterator(page, &restApis, &marker)
+		})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "APIGateway.GetRestApisPages")
+	}
+	return
+}
+
+func apiGatewayRestApiIterator(page *apigateway.GetRestApisOutput, restApis *[]*apigateway.RestApi, marker **string) bool {
+	*restApis = append(*restApis, page.Items...)
+	*marker = page.Position
+	return len(*restApis) < defaultBatchSize
+}
+
+// getRestApi returns a single REST API, or nil if it no longer exists
+func getRestApi(apigatewaySvc apigatewayiface.APIGatewayAPI, id *string) (*apigateway.RestApi, error) {
+	restApi, err := apigatewaySvc.GetRestApi(&apigateway.GetRestApiInput{RestApiId: id})
+	if err != nil {
+		if isApiGatewayNotFound(err) {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", aws.StringValue(id)),
+				zap.String("resourceType", awsmodels.ApiGatewayRestApiSchema))
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "APIGateway.GetRestApi: %s", aws.StringValue(id))
+	}
+	return restApi, nil
+}
+
+// getRestApiStages returns the stages of a REST API, including their logging and WAF settings
+func getRestApiStages(apigatewaySvc apigatewayiface.APIGatewayAPI, id *string) ([]*apigateway.Stage, error) {
+	out, err := apigatewaySvc.GetStages(&apigateway.GetStagesInput{RestApiId: id})
+	if err != nil {
+		return nil, errors.Wrapf(err, "APIGateway.GetStages: %s", aws.StringValue(id))
+	}
+	return out.Item, nil
+}
+
+// getRestApiAuthorizers returns the authorizers of a REST API
+func getRestApiAuthorizers(apigatewaySvc apigatewayiface.APIGatewayAPI, id *string) (authorizers []*apigateway.Authorizer, err error) {
+	var position *string
+	for {
+		out, err := apigatewaySvc.GetAuthorizers(&apigateway.GetAuthorizersInput{
+			Position:  position,
+			RestApiId: id,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "APIGateway.GetAuthorizers: %s", aws.StringValue(id))
+		}
+		authorizers = append(authorizers, out.Items...)
+		if position = out.Position; position == nil {
+			return authorizers, nil
+		}
+	}
+}
+
+// buildApiGatewayRestApiSnapshot makes all the calls to build up a snapshot of a given REST API
+func buildApiGatewayRestApiSnapshot(
+	apigatewaySvc apigatewayiface.APIGatewayAPI,
+	restApi *apigateway.RestApi,
+	region string,
+) (*awsmodels.ApiGatewayRestApi, error) {
+
+	restApiARN := apiGatewayARN(region, "/restapis/"+aws.StringValue(restApi.Id))
+	snapshot := &awsmodels.ApiGatewayRestApi{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   restApiARN,
+			ResourceType: aws.String(awsmodels.ApiGatewayRestApiSchema),
+			TimeCreated:  restApi.CreatedDate,
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  restApiARN,
+			ID:   restApi.Id,
+			Name: restApi.Name,
+			Tags: restApi.Tags,
+		},
+		ApiKeySource:              restApi.ApiKeySource,
+		BinaryMediaTypes:          restApi.BinaryMediaTypes,
+		Description:               restApi.Description,
+		DisableExecuteApiEndpoint: restApi.DisableExecuteApiEndpoint,
+		EndpointConfiguration:     restApi.EndpointConfiguration,
+		MinimumCompressionSize:    restApi.MinimumCompressionSize,
+		Policy:                    restApi.Policy,
+		Version:                   restApi.Version,
+	}
+
+	var err error
+	if snapshot.Stages, err = getRestApiStages(apigatewaySvc, restApi.Id); err != nil {
+		return nil, err
+	}
+	if snapshot.Authorizers, err = getRestApiAuthorizers(apigatewaySvc, restApi.Id); err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// PollApiGatewayRestApis gathers information on each API Gateway REST API for an AWS account.
+func PollApiGatewayRestApis(pollerInput *awsmodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	zap.L().Debug("starting API Gateway REST API resource poller")
+
+	apigatewaySvc, err := getApiGatewayClient(pollerInput, *pollerInput.Region)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Start with generating a list of all REST APIs
+	restApis, marker, err := listRestApis(apigatewaySvc, pollerInput.NextPageToken)
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "region: %s", *pollerInput.Region)
+	}
+
+	resources := make([]apimodels.AddResourceEntry, 0, len(restApis))
+	for _, restApi := range restApis {
+		restApiSnapshot, err := buildApiGatewayRestApiSnapshot(apigatewaySvc, restApi, *pollerInput.Region)
+		if err != nil {
+			return nil, nil, err
+		}
+		restApiSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+		restApiSnapshot.Region = pollerInput.Region
+
+		resources = append(resources, apimodels.AddResourceEntry{
+			Attributes:      restApiSnapshot,
+			ID:              *restApiSnapshot.ResourceID,
+			IntegrationID:   *pollerInput.IntegrationID,
+			IntegrationType: integrationType,
+			Type:            awsmodels.ApiGatewayRestApiSchema,
+		})
+	}
+
+	return resources, marker, nil
+}
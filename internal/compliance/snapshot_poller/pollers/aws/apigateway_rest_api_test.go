@@ -0,0 +1,181 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/apigateway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+const exampleRestApiARN = "arn:aws:apigateway:us-west-2::/restapis/a1b2c3d4e5"
+
+func TestApiGatewayRestApiList(t *testing.T) {
+	mockSvc := awstest.BuildMockApiGatewaySvc([]string{"GetRestApisPages"})
+
+	out, marker, err := listRestApis(mockSvc, nil)
+	assert.NotEmpty(t, out)
+	assert.Nil(t, marker)
+	assert.NoError(t, err)
+}
+
+// Test the iterator works on consecutive pages but stops at max page size
+func TestApiGatewayRestApiListIterator(t *testing.T) {
+	var restApis []*apigateway.RestApi
+	var marker *string
+
+	cont := apiGatewayRestApiIterator(awstest.ExampleGetRestApisOutput, &restApis, &marker)
+	assert.True(t, cont)
+	assert.Nil(t, marker)
+	assert.Len(t, restApis, 1)
+
+	for i := 1; i < 50; i++ {
+		cont = apiGatewayRestApiIterator(awstest.ExampleGetRestApisOutputContinue, &restApis, &marker)
+		assert.True(t, cont)
+		assert.NotNil(t, marker)
+		assert.Len(t, restApis, 1+i*2)
+	}
+
+	cont = apiGatewayRestApiIterator(awstest.ExampleGetRestApisOutputContinue, &restApis, &marker)
+	assert.False(t, cont)
+	assert.NotNil(t, marker)
+	assert.Len(t, restApis, 101)
+}
+
+func TestApiGatewayRestApiListError(t *testing.T) {
+	mockSvc := awstest.BuildMockApiGatewaySvcError([]string{"GetRestApisPages"})
+
+	out, marker, err := listRestApis(mockSvc, nil)
+	assert.Nil(t, out)
+	assert.Nil(t, marker)
+	assert.Error(t, err)
+}
+
+func TestApiGatewayRestApiGetNotFound(t *testing.T) {
+	mockSvc := &awstest.MockApiGateway{}
+	mockSvc.On("GetRestApi", mock.Anything).Return(
+		&apigateway.RestApi{},
+		awserr.New(apigateway.ErrCodeNotFoundException, "not found", nil),
+	)
+
+	out, err := getRestApi(mockSvc, awstest.ExampleRestApiID)
+	require.NoError(t, err)
+	assert.Nil(t, out)
+}
+
+func TestApiGatewayRestApiBuildSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockApiGatewaySvcAll()
+
+	snapshot, err := buildApiGatewayRestApiSnapshot(mockSvc, awstest.ExampleRestApi, "us-west-2")
+	require.NoError(t, err)
+	assert.Equal(t, aws.String(exampleRestApiARN), snapshot.ResourceID)
+	assert.Equal(t, aws.String("example-api"), snapshot.Name)
+	assert.Equal(t, awstest.ExampleGetRestApiStagesOutput.Item, snapshot.Stages)
+	assert.Equal(t, awstest.ExampleGetRestApiAuthorizersOutput.Items, snapshot.Authorizers)
+	assert.Equal(t, awstest.ExampleRestApi.Tags, snapshot.Tags)
+}
+
+func TestApiGatewayRestApiBuildSnapshotErrors(t *testing.T) {
+	mockSvc := awstest.BuildMockApiGatewaySvcError([]string{"GetAuthorizers"})
+	mockSvc.On("GetStages", mock.Anything).Return(awstest.ExampleGetRestApiStagesOutput, nil)
+
+	snapshot, err := buildApiGatewayRestApiSnapshot(mockSvc, awstest.ExampleRestApi, "us-west-2")
+	assert.Error(t, err)
+	assert.Nil(t, snapshot)
+
+	mockSvc = awstest.BuildMockApiGatewaySvcAllError()
+	snapshot, err = buildApiGatewayRestApiSnapshot(mockSvc, awstest.ExampleRestApi, "us-west-2")
+	assert.Error(t, err)
+	assert.Nil(t, snapshot)
+}
+
+func TestApiGatewayRestApiPollSingle(t *testing.T) {
+	resetCache()
+	mockSvc := awstest.BuildMockApiGatewaySvcAll()
+	awstest.MockApiGatewayForSetup = mockSvc
+
+	ApiGatewayClientFunc = awstest.SetupMockApiGateway
+
+	resourceARN, err := arn.Parse(exampleRestApiARN)
+	require.NoError(t, err)
+	snapshot, err := PollApiGatewayRestApi(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Timestamp:           &awstest.ExampleTime,
+	}, resourceARN, &pollermodels.ScanEntry{ResourceID: aws.String(exampleRestApiARN)})
+
+	require.NoError(t, err)
+	restApi := snapshot.(*awsmodels.ApiGatewayRestApi)
+	assert.Equal(t, aws.String("123456789012"), restApi.AccountID)
+	assert.Equal(t, aws.String("us-west-2"), restApi.Region)
+	mockSvc.AssertCalled(t, "GetRestApi", &apigateway.GetRestApiInput{RestApiId: awstest.ExampleRestApiID})
+}
+
+func TestApiGatewayRestApiPoller(t *testing.T) {
+	resetCache()
+	awstest.MockApiGatewayForSetup = awstest.BuildMockApiGatewaySvcAll()
+
+	ApiGatewayClientFunc = awstest.SetupMockApiGateway
+
+	resources, marker, err := PollApiGatewayRestApis(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, awsmodels.ApiGatewayRestApiSchema, resources[0].Type)
+	assert.Equal(t, exampleRestApiARN, resources[0].ID)
+	assert.Nil(t, marker)
+}
+
+func TestApiGatewayRestApiPollerError(t *testing.T) {
+	resetCache()
+	awstest.MockApiGatewayForSetup = awstest.BuildMockApiGatewaySvcAllError()
+
+	ApiGatewayClientFunc = awstest.SetupMockApiGateway
+
+	resources, marker, err := PollApiGatewayRestApis(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	for _, event := range resources {
+		assert.Nil(t, event.Attributes)
+	}
+	assert.Nil(t, marker)
+	assert.Error(t, err)
+}
@@ -0,0 +1,256 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go/service/apigatewayv2/apigatewayv2iface"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+)
+
+// Set as variables to be overridden in testing
+var (
+	ApiGatewayV2ClientFunc = setupApiGatewayV2Client
+)
+
+func setupApiGatewayV2Client(sess *session.Session, cfg *aws.Config) interface{} {
+	return apigatewayv2.New(sess, cfg)
+}
+
+func getApiGatewayV2Client(pollerResourceInput *awsmodels.ResourcePollerInput, region string) (apigatewayv2iface.ApiGatewayV2API, error) {
+	client, err := getClient(pollerResourceInput, ApiGatewayV2ClientFunc, "apigatewayv2", region)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.(apigatewayv2iface.ApiGatewayV2API), nil
+}
+
+// PollApiGatewayV2Api polls a single API Gateway HTTP or WebSocket API resource
+func PollApiGatewayV2Api(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	_ *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getApiGatewayV2Client(pollerResourceInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	api, err := getApiGatewayV2Api(client, aws.String(strings.TrimPrefix(resourceARN.Resource, "/apis/")))
+	if err != nil || api == nil {
+		return nil, err
+	}
+
+	snapshot, err := buildApiGatewayV2ApiSnapshot(client, api, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+	// API Gateway ARNs do not contain an account ID
+	snapshot.AccountID = aws.String(pollerResourceInput.AuthSourceParsedARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	return snapshot, nil
+}
+
+// listApiGatewayV2Apis returns all the API Gateway HTTP and WebSocket APIs in the account
+func listApiGatewayV2Apis(apigatewayv2Svc apigatewayv2iface.ApiGatewayV2API, nextMarker *string) (
+	apis []*apigatewayv2.Api, marker *string, err error) {
+
+	// The SDK does not provide a paginator for GetApis
+	marker = nextMarker
+	for {
+		page, err := apigatewayv2Svc.GetApis(&apigatewayv2.GetApisInput{
+			MaxResults: aws.String(strconv.Itoa(defaultBatchSize)),
+			NextToken:  marker,
+		})
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "ApiGatewayV2.GetApis")
+		}
+		if !apiGatewayV2ApiIterator(page, &apis, &marker) || marker == nil {
+			return apis, marker, nil
+		}
+	}
+}
+
+func apiGatewayV2ApiIterator(page *apigatewayv2.GetApisOutput, apis *[]*apigatewayv2.Api, marker **string) bool {
+	*apis = append(*apis, page.Items...)
+	*marker = page.NextToken
+	return len(*apis) < defaultBatchSize
+}
+
+// getApiGatewayV2Api returns a single HTTP or WebSocket API, or nil if it no longer exists
+func getApiGatewayV2Api(apigatewayv2Svc apigatewayv2iface.ApiGatewayV2API, id *string) (*apigatewayv2.Api, error) {
+	out, err := apigatewayv2Svc.GetApi(&apigatewayv2.GetApiInput{ApiId: id})
+	if err != nil {
+		if isApiGatewayNotFound(err) {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", aws.StringValue(id)),
+				zap.String("resourceType", awsmodels.ApiGatewayV2ApiSchema))
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "ApiGatewayV2.GetApi: %s", aws.StringValue(id))
+	}
+	return &apigatewayv2.Api{
+		ApiEndpoint:               out.ApiEndpoint,
+		ApiGatewayManaged:         out.ApiGatewayManaged,
+		ApiId:                     out.ApiId,
+		ApiKeySelectionExpression: out.ApiKeySelectionExpression,
+		CorsConfiguration:         out.CorsConfiguration,
+		CreatedDate:               out.CreatedDate,
+		Description:               out.Description,
+		DisableExecuteApiEndpoint: out.DisableExecuteApiEndpoint,
+		DisableSchemaValidation:   out.DisableSchemaValidation,
+		ImportInfo:                out.ImportInfo,
+		Name:                      out.Name,
+		ProtocolType:              out.ProtocolType,
+		RouteSelectionExpression:  out.RouteSelectionExpression,
+		Tags:                      out.Tags,
+		Version:                   out.Version,
+		Warnings:                  out.Warnings,
+	}, nil
+}
+
+// getApiGatewayV2Stages returns the stages of an HTTP or WebSocket API, including their logging settings
+func getApiGatewayV2Stages(apigatewayv2Svc apigatewayv2iface.ApiGatewayV2API, id *string) (stages []*apigatewayv2.Stage, err error) {
+	var nextToken *string
+	for {
+		out, err := apigatewayv2Svc.GetStages(&apigatewayv2.GetStagesInput{
+			ApiId:     id,
+			NextToken: nextToken,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "ApiGatewayV2.GetStages: %s", aws.StringValue(id))
+		}
+		stages = append(stages, out.Items...)
+		if nextToken = out.NextToken; nextToken == nil {
+			return stages, nil
+		}
+	}
+}
+
+// getApiGatewayV2Authorizers returns the authorizers of an HTTP or WebSocket API
+func getApiGatewayV2Authorizers(
+	apigatewayv2Svc apigatewayv2iface.ApiGatewayV2API, id *string) (authorizers []*apigatewayv2.Authorizer, err error) {
+
+	var nextToken *string
+	for {
+		out, err := apigatewayv2Svc.GetAuthorizers(&apigatewayv2.GetAuthorizersInput{
+			ApiId:     id,
+			NextToken: nextToken,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "ApiGatewayV2.GetAuthorizers: %s", aws.StringValue(id))
+		}
+		authorizers = append(authorizers, out.Items...)
+		if nextToken = out.NextToken; nextToken == nil {
+			return authorizers, nil
+		}
+	}
+}
+
+// buildApiGatewayV2ApiSnapshot makes all the calls to build up a snapshot of a given HTTP or WebSocket API
+func buildApiGatewayV2ApiSnapshot(
+	apigatewayv2Svc apigatewayv2iface.ApiGatewayV2API,
+	api *apigatewayv2.Api,
+	region string,
+) (*awsmodels.ApiGatewayV2Api, error) {
+
+	apiARN := apiGatewayARN(region, "/apis/"+aws.StringValue(api.ApiId))
+	snapshot := &awsmodels.ApiGatewayV2Api{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   apiARN,
+			ResourceType: aws.String(awsmodels.ApiGatewayV2ApiSchema),
+			TimeCreated:  api.CreatedDate,
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  apiARN,
+			ID:   api.ApiId,
+			Name: api.Name,
+			Tags: api.Tags,
+		},
+		ApiEndpoint:               api.ApiEndpoint,
+		ApiGatewayManaged:         api.ApiGatewayManaged,
+		ApiKeySelectionExpression: api.ApiKeySelectionExpression,
+		CorsConfiguration:         api.CorsConfiguration,
+		Description:               api.Description,
+		DisableExecuteApiEndpoint: api.DisableExecuteApiEndpoint,
+		DisableSchemaValidation:   api.DisableSchemaValidation,
+		ProtocolType:              api.ProtocolType,
+		RouteSelectionExpression:  api.RouteSelectionExpression,
+		Version:                   api.Version,
+	}
+
+	var err error
+	if snapshot.Stages, err = getApiGatewayV2Stages(apigatewayv2Svc, api.ApiId); err != nil {
+		return nil, err
+	}
+	if snapshot.Authorizers, err = getApiGatewayV2Authorizers(apigatewayv2Svc, api.ApiId); err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// PollApiGatewayV2Apis gathers information on each API Gateway HTTP and WebSocket API for an AWS account.
+func PollApiGatewayV2Apis(pollerInput *awsmodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	zap.L().Debug("starting API Gateway V2 API resource poller")
+
+	apigatewayv2Svc, err := getApiGatewayV2Client(pollerInput, *pollerInput.Region)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Start with generating a list of all APIs
+	apis, marker, err := listApiGatewayV2Apis(apigatewayv2Svc, pollerInput.NextPageToken)
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "region: %s", *pollerInput.Region)
+	}
+
+	resources := make([]apimodels.AddResourceEntry, 0, len(apis))
+	for _, api := range apis {
+		apiSnapshot, err := buildApiGatewayV2ApiSnapshot(apigatewayv2Svc, api, *pollerInput.Region)
+		if err != nil {
+			return nil, nil, err
+		}
+		apiSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+		apiSnapshot.Region = pollerInput.Region
+
+		resources = append(resources, apimodels.AddResourceEntry{
+			Attributes:      apiSnapshot,
+			ID:              *apiSnapshot.ResourceID,
+			IntegrationID:   *pollerInput.IntegrationID,
+			IntegrationType: integrationType,
+			Type:            awsmodels.ApiGatewayV2ApiSchema,
+		})
+	}
+
+	return resources, marker, nil
+}
@@ -0,0 +1,193 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/apigatewayv2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+const exampleApiGatewayV2ApiARN = "arn:aws:apigateway:us-west-2::/apis/f6g7h8i9j0"
+
+func TestApiGatewayV2ApiList(t *testing.T) {
+	mockSvc := awstest.BuildMockApiGatewayV2Svc([]string{"GetApis"})
+
+	out, marker, err := listApiGatewayV2Apis(mockSvc, nil)
+	assert.NotEmpty(t, out)
+	assert.Nil(t, marker)
+	assert.NoError(t, err)
+}
+
+// Test the iterator works on consecutive pages but stops at max page size
+func TestApiGatewayV2ApiListIterator(t *testing.T) {
+	var apis []*apigatewayv2.Api
+	var marker *string
+
+	cont := apiGatewayV2ApiIterator(awstest.ExampleGetApisOutput, &apis, &marker)
+	assert.True(t, cont)
+	assert.Nil(t, marker)
+	assert.Len(t, apis, 1)
+
+	for i := 1; i < 50; i++ {
+		cont = apiGatewayV2ApiIterator(awstest.ExampleGetApisOutputContinue, &apis, &marker)
+		assert.True(t, cont)
+		assert.NotNil(t, marker)
+		assert.Len(t, apis, 1+i*2)
+	}
+
+	cont = apiGatewayV2ApiIterator(awstest.ExampleGetApisOutputContinue, &apis, &marker)
+	assert.False(t, cont)
+	assert.NotNil(t, marker)
+	assert.Len(t, apis, 101)
+}
+
+// Test the manual pagination loop follows NextToken until the batch is full
+func TestApiGatewayV2ApiListPaginates(t *testing.T) {
+	mockSvc := &awstest.MockApiGatewayV2{}
+	mockSvc.On("GetApis", mock.Anything).Return(awstest.ExampleGetApisOutputContinue, nil)
+
+	out, marker, err := listApiGatewayV2Apis(mockSvc, nil)
+	require.NoError(t, err)
+	assert.Len(t, out, 100)
+	assert.NotNil(t, marker)
+	mockSvc.AssertNumberOfCalls(t, "GetApis", 50)
+}
+
+func TestApiGatewayV2ApiListError(t *testing.T) {
+	mockSvc := awstest.BuildMockApiGatewayV2SvcError([]string{"GetApis"})
+
+	out, marker, err := listApiGatewayV2Apis(mockSvc, nil)
+	assert.Nil(t, out)
+	assert.Nil(t, marker)
+	assert.Error(t, err)
+}
+
+func TestApiGatewayV2ApiGetNotFound(t *testing.T) {
+	mockSvc := &awstest.MockApiGatewayV2{}
+	mockSvc.On("GetApi", mock.Anything).Return(
+		&apigatewayv2.GetApiOutput{},
+		awserr.New(apigatewayv2.ErrCodeNotFoundException, "not found", nil),
+	)
+
+	out, err := getApiGatewayV2Api(mockSvc, awstest.ExampleApiID)
+	require.NoError(t, err)
+	assert.Nil(t, out)
+}
+
+func TestApiGatewayV2ApiBuildSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockApiGatewayV2SvcAll()
+
+	snapshot, err := buildApiGatewayV2ApiSnapshot(mockSvc, awstest.ExampleApi, "us-west-2")
+	require.NoError(t, err)
+	assert.Equal(t, aws.String(exampleApiGatewayV2ApiARN), snapshot.ResourceID)
+	assert.Equal(t, aws.String("HTTP"), snapshot.ProtocolType)
+	assert.Equal(t, awstest.ExampleGetApiStagesOutput.Items, snapshot.Stages)
+	assert.Equal(t, awstest.ExampleGetApiAuthorizersOutput.Items, snapshot.Authorizers)
+}
+
+func TestApiGatewayV2ApiBuildSnapshotErrors(t *testing.T) {
+	mockSvc := awstest.BuildMockApiGatewayV2SvcError([]string{"GetAuthorizers"})
+	mockSvc.On("GetStages", mock.Anything).Return(awstest.ExampleGetApiStagesOutput, nil)
+
+	snapshot, err := buildApiGatewayV2ApiSnapshot(mockSvc, awstest.ExampleApi, "us-west-2")
+	assert.Error(t, err)
+	assert.Nil(t, snapshot)
+
+	mockSvc = awstest.BuildMockApiGatewayV2SvcAllError()
+	snapshot, err = buildApiGatewayV2ApiSnapshot(mockSvc, awstest.ExampleApi, "us-west-2")
+	assert.Error(t, err)
+	assert.Nil(t, snapshot)
+}
+
+func TestApiGatewayV2ApiPollSingle(t *testing.T) {
+	resetCache()
+	mockSvc := awstest.BuildMockApiGatewayV2SvcAll()
+	awstest.MockApiGatewayV2ForSetup = mockSvc
+
+	ApiGatewayV2ClientFunc = awstest.SetupMockApiGatewayV2
+
+	resourceARN, err := arn.Parse(exampleApiGatewayV2ApiARN)
+	require.NoError(t, err)
+	snapshot, err := PollApiGatewayV2Api(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Timestamp:           &awstest.ExampleTime,
+	}, resourceARN, &pollermodels.ScanEntry{ResourceID: aws.String(exampleApiGatewayV2ApiARN)})
+
+	require.NoError(t, err)
+	api := snapshot.(*awsmodels.ApiGatewayV2Api)
+	assert.Equal(t, aws.String("123456789012"), api.AccountID)
+	assert.Equal(t, aws.String("us-west-2"), api.Region)
+	assert.Equal(t, awstest.ExampleApi.ApiEndpoint, api.ApiEndpoint)
+	mockSvc.AssertCalled(t, "GetApi", &apigatewayv2.GetApiInput{ApiId: awstest.ExampleApiID})
+}
+
+func TestApiGatewayV2ApiPoller(t *testing.T) {
+	resetCache()
+	awstest.MockApiGatewayV2ForSetup = awstest.BuildMockApiGatewayV2SvcAll()
+
+	ApiGatewayV2ClientFunc = awstest.SetupMockApiGatewayV2
+
+	resources, marker, err := PollApiGatewayV2Apis(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, awsmodels.ApiGatewayV2ApiSchema, resources[0].Type)
+	assert.Equal(t, exampleApiGatewayV2ApiARN, resources[0].ID)
+	assert.Nil(t, marker)
+}
+
+func TestApiGatewayV2ApiPollerError(t *testing.T) {
+	resetCache()
+	awstest.MockApiGatewayV2ForSetup = awstest.BuildMockApiGatewayV2SvcAllError()
+
+	ApiGatewayV2ClientFunc = awstest.SetupMockApiGatewayV2
+
+	resources, marker, err := PollApiGatewayV2Apis(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	for _, event := range resources {
+		assert.Nil(t, event.Attributes)
+	}
+	assert.Nil(t, marker)
+	assert.Error(t, err)
+}
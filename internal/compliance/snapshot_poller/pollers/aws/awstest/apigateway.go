@@ -0,0 +1,215 @@
+package awstest
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/apigateway"
+	"github.com/aws/aws-sdk-go/service/apigateway/apigatewayiface"
+	"github.com/stretchr/testify/mock"
+)
+
+// Example APIGateway API return values
+var (
+	ExampleRestApiID = aws.String("a1b2c3d4e5")
+
+	ExampleRestApi = &apigateway.RestApi{
+		ApiKeySource: aws.String("HEADER"),
+		CreatedDate:  &ExampleTime,
+		EndpointConfiguration: &apigateway.EndpointConfiguration{
+			Types: []*string{aws.String("REGIONAL")},
+		},
+		Id:   ExampleRestApiID,
+		Name: aws.String("example-api"),
+		Tags: map[string]*string{"Key1": aws.String("Value1")},
+	}
+
+	ExampleGetRestApisOutput = &apigateway.GetRestApisOutput{
+		Items: []*apigateway.RestApi{ExampleRestApi},
+	}
+
+	ExampleGetRestApisOutputContinue = &apigateway.GetRestApisOutput{
+		Items:    []*apigateway.RestApi{ExampleRestApi, ExampleRestApi},
+		Position: aws.String("1"),
+	}
+
+	ExampleGetRestApiStagesOutput = &apigateway.GetStagesOutput{
+		Item: []*apigateway.Stage{
+			{
+				AccessLogSettings: &apigateway.AccessLogSettings{
+					DestinationArn: aws.String("arn:aws:logs:us-west-2:123456789012:log-group:example-api-access"),
+					Format:         aws.String("$context.requestId"),
+				},
+				MethodSettings: map[string]*apigateway.MethodSetting{
+					"*/*": {
+						DataTraceEnabled: aws.Bool(false),
+						LoggingLevel:     aws.String("ERROR"),
+					},
+				},
+				StageName:      aws.String("prod"),
+				TracingEnabled: aws.Bool(true),
+			},
+		},
+	}
+
+	ExampleGetRestApiAuthorizersOutput = &apigateway.GetAuthorizersOutput{
+		Items: []*apigateway.Authorizer{
+			{
+				Id:           aws.String("abc123"),
+				Name:         aws.String("example-authorizer"),
+				ProviderARNs: []*string{aws.String("arn:aws:cognito-idp:us-west-2:123456789012:userpool/us-west-2_example")},
+				Type:         aws.String("COGNITO_USER_POOLS"),
+			},
+		},
+	}
+
+	svcApiGatewaySetupCalls = map[string]func(*MockApiGateway){
+		"GetRestApisPages": func(svc *MockApiGateway) {
+			svc.On("GetRestApisPages", mock.Anything).
+				Return(nil)
+		},
+		"GetRestApi": func(svc *MockApiGateway) {
+			svc.On("GetRestApi", mock.Anything).
+				Return(ExampleRestApi, nil)
+		},
+		"GetStages": func(svc *MockApiGateway) {
+			svc.On("GetStages", mock.Anything).
+				Return(ExampleGetRestApiStagesOutput, nil)
+		},
+		"GetAuthorizers": func(svc *MockApiGateway) {
+			svc.On("GetAuthorizers", mock.Anything).
+				Return(ExampleGetRestApiAuthorizersOutput, nil)
+		},
+	}
+
+	svcApiGatewaySetupCallsError = map[string]func(*MockApiGateway){
+		"GetRestApisPages": func(svc *MockApiGateway) {
+			svc.On("GetRestApisPages", mock.Anything).
+				Return(errors.New("APIGateway.GetRestApisPages error"))
+		},
+		"GetRestApi": func(svc *MockApiGateway) {
+			svc.On("GetRestApi", mock.Anything).
+				Return(&apigateway.RestApi{},
+					errors.New("APIGateway.GetRestApi error"),
+				)
+		},
+		"GetStages": func(svc *MockApiGateway) {
+			svc.On("GetStages", mock.Anything).
+				Return(&apigateway.GetStagesOutput{},
+					errors.New("APIGateway.GetStages error"),
+				)
+		},
+		"GetAuthorizers": func(svc *MockApiGateway) {
+			svc.On("GetAuthorizers", mock.Anything).
+				Return(&apigateway.GetAuthorizersOutput{},
+					errors.New("APIGateway.GetAuthorizers error"),
+				)
+		},
+	}
+
+	MockApiGatewayForSetup = &MockApiGateway{}
+)
+
+// APIGateway mock
+
+// SetupMockApiGateway is used to override the APIGateway Client initializer
+func SetupMockApiGateway(_ *session.Session, _ *aws.Config) interface{} {
+	return MockApiGatewayForSetup
+}
+
+// MockApiGateway is a mock APIGateway client
+type MockApiGateway struct {
+	apigatewayiface.APIGatewayAPI
+	mock.Mock
+}
+
+// BuildMockApiGatewaySvc builds and returns a MockApiGateway struct
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockApiGatewaySvc(funcs []string) (mockSvc *MockApiGateway) {
+	mockSvc = &MockApiGateway{}
+	for _, f := range funcs {
+		svcApiGatewaySetupCalls[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockApiGatewaySvcError builds and returns a MockApiGateway struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockApiGatewaySvcError(funcs []string) (mockSvc *MockApiGateway) {
+	mockSvc = &MockApiGateway{}
+	for _, f := range funcs {
+		svcApiGatewaySetupCallsError[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockApiGatewaySvcAll builds and returns a MockApiGateway struct
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockApiGatewaySvcAll() (mockSvc *MockApiGateway) {
+	mockSvc = &MockApiGateway{}
+	for _, f := range svcApiGatewaySetupCalls {
+		f(mockSvc)
+	}
+	return
+}
+
+// BuildMockApiGatewaySvcAllError builds and returns a MockApiGateway struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockApiGatewaySvcAllError() (mockSvc *MockApiGateway) {
+	mockSvc = &MockApiGateway{}
+	for _, f := range svcApiGatewaySetupCallsError {
+		f(mockSvc)
+	}
+	return
+}
+
+func (m *MockApiGateway) GetRestApisPages(
+	in *apigateway.GetRestApisInput,
+	paginationFunction func(*apigateway.GetRestApisOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	paginationFunction(ExampleGetRestApisOutput, true)
+	return args.Error(0)
+}
+
+func (m *MockApiGateway) GetRestApi(in *apigateway.GetRestApiInput) (*apigateway.RestApi, error) {
+	args := m.Called(in)
+	return args.Get(0).(*apigateway.RestApi), args.Error(1)
+}
+
+func (m *MockApiGateway) GetStages(in *apigateway.GetStagesInput) (*apigateway.GetStagesOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*apigateway.GetStagesOutput), args.Error(1)
+}
+
+func (m *MockApiGateway) GetAuthorizers(in *apigateway.GetAuthorizersInput) (*apigateway.GetAuthorizersOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*apigateway.GetAuthorizersOutput), args.Error(1)
+}
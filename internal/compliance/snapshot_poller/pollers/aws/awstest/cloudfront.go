@@ -0,0 +1,227 @@
+package awstest
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudfront"
+	"github.com/aws/aws-sdk-go/service/cloudfront/cloudfrontiface"
+	"github.com/stretchr/testify/mock"
+)
+
+// Example CloudFront API return values
+var (
+	ExampleDistributionID  = aws.String("EDFDVBD6EXAMPLE")
+	ExampleDistributionARN = aws.String("arn:aws:cloudfront::123456789012:distribution/EDFDVBD6EXAMPLE")
+
+	ExampleDistributionSummary = &cloudfront.DistributionSummary{
+		ARN:        ExampleDistributionARN,
+		DomainName: aws.String("d111111abcdef8.cloudfront.net"),
+		Enabled:    aws.Bool(true),
+		Id:         ExampleDistributionID,
+		Status:     aws.String("Deployed"),
+	}
+
+	ExampleListDistributionsOutput = &cloudfront.ListDistributionsOutput{
+		DistributionList: &cloudfront.DistributionList{
+			Items: []*cloudfront.DistributionSummary{ExampleDistributionSummary},
+		},
+	}
+
+	ExampleListDistributionsOutputContinue = &cloudfront.ListDistributionsOutput{
+		DistributionList: &cloudfront.DistributionList{
+			Items:      []*cloudfront.DistributionSummary{ExampleDistributionSummary, ExampleDistributionSummary},
+			NextMarker: aws.String("1"),
+		},
+	}
+
+	ExampleGetDistributionOutput = &cloudfront.GetDistributionOutput{
+		Distribution: &cloudfront.Distribution{
+			ARN: ExampleDistributionARN,
+			DistributionConfig: &cloudfront.DistributionConfig{
+				Aliases: &cloudfront.Aliases{
+					Items:    []*string{aws.String("www.example.com")},
+					Quantity: aws.Int64(1),
+				},
+				DefaultCacheBehavior: &cloudfront.DefaultCacheBehavior{
+					TargetOriginId:       aws.String("example-origin"),
+					ViewerProtocolPolicy: aws.String("redirect-to-https"),
+				},
+				Enabled:     aws.Bool(true),
+				HttpVersion: aws.String("http2"),
+				Logging: &cloudfront.LoggingConfig{
+					Bucket:         aws.String("example-logs.s3.amazonaws.com"),
+					Enabled:        aws.Bool(true),
+					IncludeCookies: aws.Bool(false),
+					Prefix:         aws.String("cloudfront/"),
+				},
+				Origins: &cloudfront.Origins{
+					Items: []*cloudfront.Origin{
+						{
+							DomainName: aws.String("example-bucket.s3.amazonaws.com"),
+							Id:         aws.String("example-origin"),
+						},
+					},
+					Quantity: aws.Int64(1),
+				},
+				PriceClass: aws.String("PriceClass_All"),
+				ViewerCertificate: &cloudfront.ViewerCertificate{
+					ACMCertificateArn:      aws.String("arn:aws:acm:us-east-1:123456789012:certificate/example"),
+					MinimumProtocolVersion: aws.String("TLSv1.2_2019"),
+					SSLSupportMethod:       aws.String("sni-only"),
+				},
+				WebACLId: aws.String("arn:aws:wafv2:us-east-1:123456789012:global/webacl/example/1111"),
+			},
+			DomainName:       aws.String("d111111abcdef8.cloudfront.net"),
+			Id:               ExampleDistributionID,
+			LastModifiedTime: &ExampleTime,
+			Status:           aws.String("Deployed"),
+		},
+	}
+
+	ExampleListCloudFrontTagsOutput = &cloudfront.ListTagsForResourceOutput{
+		Tags: &cloudfront.Tags{
+			Items: []*cloudfront.Tag{
+				{
+					Key:   aws.String("Key1"),
+					Value: aws.String("Value1"),
+				},
+			},
+		},
+	}
+
+	svcCloudFrontSetupCalls = map[string]func(*MockCloudFront){
+		"ListDistributionsPages": func(svc *MockCloudFront) {
+			svc.On("ListDistributionsPages", mock.Anything).
+				Return(nil)
+		},
+		"GetDistribution": func(svc *MockCloudFront) {
+			svc.On("GetDistribution", mock.Anything).
+				Return(ExampleGetDistributionOutput, nil)
+		},
+		"ListTagsForResource": func(svc *MockCloudFront) {
+			svc.On("ListTagsForResource", mock.Anything).
+				Return(ExampleListCloudFrontTagsOutput, nil)
+		},
+	}
+
+	svcCloudFrontSetupCallsError = map[string]func(*MockCloudFront){
+		"ListDistributionsPages": func(svc *MockCloudFront) {
+			svc.On("ListDistributionsPages", mock.Anything).
+				Return(errors.New("CloudFront.ListDistributionsPages error"))
+		},
+		"GetDistribution": func(svc *MockCloudFront) {
+			svc.On("GetDistribution", mock.Anything).
+				Return(&cloudfront.GetDistributionOutput{},
+					errors.New("CloudFront.GetDistribution error"),
+				)
+		},
+		"ListTagsForResource": func(svc *MockCloudFront) {
+			svc.On("ListTagsForResource", mock.Anything).
+				Return(&cloudfront.ListTagsForResourceOutput{},
+					errors.New("CloudFront.ListTagsForResource error"),
+				)
+		},
+	}
+
+	MockCloudFrontForSetup = &MockCloudFront{}
+)
+
+// CloudFront mock
+
+// SetupMockCloudFront is used to override the CloudFront Client initializer
+func SetupMockCloudFront(_ *session.Session, _ *aws.Config) interface{} {
+	return MockCloudFrontForSetup
+}
+
+// MockCloudFront is a mock CloudFront client
+type MockCloudFront struct {
+	cloudfrontiface.CloudFrontAPI
+	mock.Mock
+}
+
+// BuildMockCloudFrontSvc builds and returns a MockCloudFront struct
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockCloudFrontSvc(funcs []string) (mockSvc *MockCloudFront) {
+	mockSvc = &MockCloudFront{}
+	for _, f := range funcs {
+		svcCloudFrontSetupCalls[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockCloudFrontSvcError builds and returns a MockCloudFront struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockCloudFrontSvcError(funcs []string) (mockSvc *MockCloudFront) {
+	mockSvc = &MockCloudFront{}
+	for _, f := range funcs {
+		svcCloudFrontSetupCallsError[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockCloudFrontSvcAll builds and returns a MockCloudFront struct
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockCloudFrontSvcAll() (mockSvc *MockCloudFront) {
+	mockSvc = &MockCloudFront{}
+	for _, f := range svcCloudFrontSetupCalls {
+		f(mockSvc)
+	}
+	return
+}
+
+// BuildMockCloudFrontSvcAllError builds and returns a MockCloudFront struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockCloudFrontSvcAllError() (mockSvc *MockCloudFront) {
+	mockSvc = &MockCloudFront{}
+	for _, f := range svcCloudFrontSetupCallsError {
+		f(mockSvc)
+	}
+	return
+}
+
+func (m *MockCloudFront) ListDistributionsPages(
+	in *cloudfront.ListDistributionsInput,
+	paginationFunction func(*cloudfront.ListDistributionsOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	paginationFunction(ExampleListDistributionsOutput, true)
+	return args.Error(0)
+}
+
+func (m *MockCloudFront) GetDistribution(in *cloudfront.GetDistributionInput) (*cloudfront.GetDistributionOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*cloudfront.GetDistributionOutput), args.Error(1)
+}
+
+func (m *MockCloudFront) ListTagsForResource(in *cloudfront.ListTagsForResourceInput) (*cloudfront.ListTagsForResourceOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*cloudfront.ListTagsForResourceOutput), args.Error(1)
+}
@@ -175,6 +175,64 @@ var (
 		},
 	}
 
+	ExampleTaskDefinitionArn = aws.String("arn:aws:ecs:us-west-2:123456789012:task-definition/example-task:3")
+
+	ExampleEcsListTaskDefinitions = &ecs.ListTaskDefinitionsOutput{
+		TaskDefinitionArns: []*string{ExampleTaskDefinitionArn},
+	}
+
+	ExampleEcsListTaskDefinitionsContinue = &ecs.ListTaskDefinitionsOutput{
+		TaskDefinitionArns: []*string{
+			ExampleTaskDefinitionArn,
+			aws.String("arn:aws:ecs:us-west-2:123456789012:task-definition/example-task:2"),
+		},
+		NextToken: aws.String("1"),
+	}
+
+	ExampleEcsDescribeTaskDefinitionOutput = &ecs.DescribeTaskDefinitionOutput{
+		TaskDefinition: &ecs.TaskDefinition{
+			Compatibilities: []*string{aws.String("EC2")},
+			ContainerDefinitions: []*ecs.ContainerDefinition{
+				{
+					Environment: []*ecs.KeyValuePair{
+						{
+							Name:  aws.String("DATABASE_PASSWORD"),
+							Value: aws.String("hunter2"),
+						},
+					},
+					Essential:  aws.Bool(true),
+					Image:      aws.String("123456789012.dkr.ecr.us-west-2.amazonaws.com/example:latest"),
+					Name:       aws.String("example-container"),
+					Privileged: aws.Bool(true),
+					Secrets: []*ecs.Secret{
+						{
+							Name:      aws.String("API_KEY"),
+							ValueFrom: aws.String("arn:aws:ssm:us-west-2:123456789012:parameter/example/api-key"),
+						},
+					},
+				},
+				{
+					Essential: aws.Bool(false),
+					Image:     aws.String("amazon/aws-xray-daemon"),
+					Name:      aws.String("xray"),
+				},
+			},
+			ExecutionRoleArn:  aws.String("arn:aws:iam::123456789012:role/ecsTaskExecutionRole"),
+			Family:            aws.String("example-task"),
+			NetworkMode:       aws.String("bridge"),
+			Revision:          aws.Int64(3),
+			Status:            aws.String("ACTIVE"),
+			TaskDefinitionArn: ExampleTaskDefinitionArn,
+			TaskRoleArn:       aws.String("arn:aws:iam::123456789012:role/example-task-role"),
+		},
+		Tags: []*ecs.Tag{
+			{
+				Key:   aws.String("Key1"),
+				Value: aws.String("Value1"),
+			},
+		},
+	}
+
 	svcEcsSetupCalls = map[string]func(*MockEcs){
 		"ListClustersPages": func(svc *MockEcs) {
 			svc.On("ListClustersPages", mock.Anything).
@@ -200,6 +258,14 @@ var (
 			svc.On("DescribeServices", mock.Anything).
 				Return(ExampleEcsDescribeServicesOutput, nil)
 		},
+		"ListTaskDefinitionsPages": func(svc *MockEcs) {
+			svc.On("ListTaskDefinitionsPages", mock.Anything).
+				Return(nil)
+		},
+		"DescribeTaskDefinition": func(svc *MockEcs) {
+			svc.On("DescribeTaskDefinition", mock.Anything).
+				Return(ExampleEcsDescribeTaskDefinitionOutput, nil)
+		},
 	}
 
 	svcEcsSetupCallsError = map[string]func(*MockEcs){
@@ -233,6 +299,16 @@ var (
 					errors.New("ECS.DescribeServices error"),
 				)
 		},
+		"ListTaskDefinitionsPages": func(svc *MockEcs) {
+			svc.On("ListTaskDefinitionsPages", mock.Anything).
+				Return(errors.New("ECS.ListTaskDefinitionsPages error"))
+		},
+		"DescribeTaskDefinition": func(svc *MockEcs) {
+			svc.On("DescribeTaskDefinition", mock.Anything).
+				Return(&ecs.DescribeTaskDefinitionOutput{},
+					errors.New("ECS.DescribeTaskDefinition error"),
+				)
+		},
 	}
 
 	MockEcsForSetup = &MockEcs{}
@@ -378,3 +454,21 @@ func (m *MockEcs) DescribeTasks(in *ecs.DescribeTasksInput) (*ecs.DescribeTasksO
 	}
 	return args.Get(0).(*ecs.DescribeTasksOutput), args.Error(1)
 }
+
+func (m *MockEcs) ListTaskDefinitionsPages(
+	in *ecs.ListTaskDefinitionsInput,
+	paginationFunction func(*ecs.ListTaskDefinitionsOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	paginationFunction(ExampleEcsListTaskDefinitions, true)
+	return args.Error(0)
+}
+
+func (m *MockEcs) DescribeTaskDefinition(in *ecs.DescribeTaskDefinitionInput) (*ecs.DescribeTaskDefinitionOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*ecs.DescribeTaskDefinitionOutput), args.Error(1)
+}
@@ -0,0 +1,291 @@
+package awstest
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/efs"
+	"github.com/aws/aws-sdk-go/service/efs/efsiface"
+	"github.com/stretchr/testify/mock"
+)
+
+// Example EFS API return values
+var (
+	ExampleEfsFileSystemID  = aws.String("fs-12345678")
+	ExampleEfsFileSystemArn = aws.String("arn:aws:elasticfilesystem:us-west-2:123456789012:file-system/fs-12345678")
+
+	ExampleEfsFileSystem = &efs.FileSystemDescription{
+		CreationTime:         &ExampleTime,
+		CreationToken:        aws.String("example-token"),
+		Encrypted:            aws.Bool(true),
+		FileSystemArn:        ExampleEfsFileSystemArn,
+		FileSystemId:         ExampleEfsFileSystemID,
+		KmsKeyId:             aws.String("arn:aws:kms:us-west-2:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"),
+		LifeCycleState:       aws.String("available"),
+		Name:                 aws.String("example-file-system"),
+		NumberOfMountTargets: aws.Int64(1),
+		OwnerId:              aws.String("123456789012"),
+		PerformanceMode:      aws.String("generalPurpose"),
+		SizeInBytes: &efs.FileSystemSize{
+			Value: aws.Int64(6144),
+		},
+		Tags: []*efs.Tag{
+			{
+				Key:   aws.String("Key1"),
+				Value: aws.String("Value1"),
+			},
+		},
+		ThroughputMode: aws.String("bursting"),
+	}
+
+	ExampleDescribeFileSystemsOutput = &efs.DescribeFileSystemsOutput{
+		FileSystems: []*efs.FileSystemDescription{ExampleEfsFileSystem},
+	}
+
+	ExampleDescribeFileSystemsOutputContinue = &efs.DescribeFileSystemsOutput{
+		FileSystems: []*efs.FileSystemDescription{
+			ExampleEfsFileSystem,
+			ExampleEfsFileSystem,
+		},
+		NextMarker: aws.String("1"),
+	}
+
+	ExampleDescribeFileSystemPolicyOutput = &efs.DescribeFileSystemPolicyOutput{
+		FileSystemId: ExampleEfsFileSystemID,
+		Policy:       aws.String(`{"Version":"2012-10-17","Statement":[]}`),
+	}
+
+	ExampleDescribeBackupPolicyOutput = &efs.DescribeBackupPolicyOutput{
+		BackupPolicy: &efs.BackupPolicy{
+			Status: aws.String("ENABLED"),
+		},
+	}
+
+	ExampleDescribeLifecycleConfigurationOutput = &efs.DescribeLifecycleConfigurationOutput{
+		LifecyclePolicies: []*efs.LifecyclePolicy{
+			{
+				TransitionToIA: aws.String("AFTER_30_DAYS"),
+			},
+		},
+	}
+
+	ExampleDescribeMountTargetsOutput = &efs.DescribeMountTargetsOutput{
+		MountTargets: []*efs.MountTargetDescription{
+			{
+				AvailabilityZoneId:   aws.String("usw2-az1"),
+				AvailabilityZoneName: aws.String("us-west-2a"),
+				FileSystemId:         ExampleEfsFileSystemID,
+				IpAddress:            aws.String("172.31.22.183"),
+				LifeCycleState:       aws.String("available"),
+				MountTargetId:        aws.String("fsmt-12345678"),
+				NetworkInterfaceId:   aws.String("eni-12345678"),
+				OwnerId:              aws.String("123456789012"),
+				SubnetId:             aws.String("subnet-12345678"),
+				VpcId:                aws.String("vpc-12345678"),
+			},
+		},
+	}
+
+	ExampleDescribeMountTargetSecurityGroupsOutput = &efs.DescribeMountTargetSecurityGroupsOutput{
+		SecurityGroups: []*string{aws.String("sg-12345678")},
+	}
+
+	svcEfsSetupCalls = map[string]func(*MockEfs){
+		"DescribeFileSystems": func(svc *MockEfs) {
+			svc.On("DescribeFileSystems", mock.Anything).
+				Return(ExampleDescribeFileSystemsOutput, nil)
+		},
+		"DescribeFileSystemsPages": func(svc *MockEfs) {
+			svc.On("DescribeFileSystemsPages", mock.Anything).
+				Return(nil)
+		},
+		"DescribeFileSystemPolicy": func(svc *MockEfs) {
+			svc.On("DescribeFileSystemPolicy", mock.Anything).
+				Return(ExampleDescribeFileSystemPolicyOutput, nil)
+		},
+		"DescribeBackupPolicy": func(svc *MockEfs) {
+			svc.On("DescribeBackupPolicy", mock.Anything).
+				Return(ExampleDescribeBackupPolicyOutput, nil)
+		},
+		"DescribeLifecycleConfiguration": func(svc *MockEfs) {
+			svc.On("DescribeLifecycleConfiguration", mock.Anything).
+				Return(ExampleDescribeLifecycleConfigurationOutput, nil)
+		},
+		"DescribeMountTargets": func(svc *MockEfs) {
+			svc.On("DescribeMountTargets", mock.Anything).
+				Return(ExampleDescribeMountTargetsOutput, nil)
+		},
+		"DescribeMountTargetSecurityGroups": func(svc *MockEfs) {
+			svc.On("DescribeMountTargetSecurityGroups", mock.Anything).
+				Return(ExampleDescribeMountTargetSecurityGroupsOutput, nil)
+		},
+	}
+
+	svcEfsSetupCallsError = map[string]func(*MockEfs){
+		"DescribeFileSystems": func(svc *MockEfs) {
+			svc.On("DescribeFileSystems", mock.Anything).
+				Return(&efs.DescribeFileSystemsOutput{},
+					errors.New("EFS.DescribeFileSystems error"),
+				)
+		},
+		"DescribeFileSystemsPages": func(svc *MockEfs) {
+			svc.On("DescribeFileSystemsPages", mock.Anything).
+				Return(errors.New("EFS.DescribeFileSystemsPages error"))
+		},
+		"DescribeFileSystemPolicy": func(svc *MockEfs) {
+			svc.On("DescribeFileSystemPolicy", mock.Anything).
+				Return(&efs.DescribeFileSystemPolicyOutput{},
+					errors.New("EFS.DescribeFileSystemPolicy error"),
+				)
+		},
+		"DescribeBackupPolicy": func(svc *MockEfs) {
+			svc.On("DescribeBackupPolicy", mock.Anything).
+				Return(&efs.DescribeBackupPolicyOutput{},
+					errors.New("EFS.DescribeBackupPolicy error"),
+				)
+		},
+		"DescribeLifecycleConfiguration": func(svc *MockEfs) {
+			svc.On("DescribeLifecycleConfiguration", mock.Anything).
+				Return(&efs.DescribeLifecycleConfigurationOutput{},
+					errors.New("EFS.DescribeLifecycleConfiguration error"),
+				)
+		},
+		"DescribeMountTargets": func(svc *MockEfs) {
+			svc.On("DescribeMountTargets", mock.Anything).
+				Return(&efs.DescribeMountTargetsOutput{},
+					errors.New("EFS.DescribeMountTargets error"),
+				)
+		},
+		"DescribeMountTargetSecurityGroups": func(svc *MockEfs) {
+			svc.On("DescribeMountTargetSecurityGroups", mock.Anything).
+				Return(&efs.DescribeMountTargetSecurityGroupsOutput{},
+					errors.New("EFS.DescribeMountTargetSecurityGroups error"),
+				)
+		},
+	}
+
+	MockEfsForSetup = &MockEfs{}
+)
+
+// EFS mock
+
+// SetupMockEfs is used to override the EFS Client initializer
+func SetupMockEfs(_ *session.Session, _ *aws.Config) interface{} {
+	return MockEfsForSetup
+}
+
+// MockEfs is a mock EFS client
+type MockEfs struct {
+	efsiface.EFSAPI
+	mock.Mock
+}
+
+// BuildMockEfsSvc builds and returns a MockEfs struct
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockEfsSvc(funcs []string) (mockSvc *MockEfs) {
+	mockSvc = &MockEfs{}
+	for _, f := range funcs {
+		svcEfsSetupCalls[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockEfsSvcError builds and returns a MockEfs struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockEfsSvcError(funcs []string) (mockSvc *MockEfs) {
+	mockSvc = &MockEfs{}
+	for _, f := range funcs {
+		svcEfsSetupCallsError[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockEfsSvcAll builds and returns a MockEfs struct
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockEfsSvcAll() (mockSvc *MockEfs) {
+	mockSvc = &MockEfs{}
+	for _, f := range svcEfsSetupCalls {
+		f(mockSvc)
+	}
+	return
+}
+
+// BuildMockEfsSvcAllError builds and returns a MockEfs struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockEfsSvcAllError() (mockSvc *MockEfs) {
+	mockSvc = &MockEfs{}
+	for _, f := range svcEfsSetupCallsError {
+		f(mockSvc)
+	}
+	return
+}
+
+func (m *MockEfs) DescribeFileSystems(in *efs.DescribeFileSystemsInput) (*efs.DescribeFileSystemsOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*efs.DescribeFileSystemsOutput), args.Error(1)
+}
+
+func (m *MockEfs) DescribeFileSystemsPages(
+	in *efs.DescribeFileSystemsInput,
+	paginationFunction func(*efs.DescribeFileSystemsOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	paginationFunction(ExampleDescribeFileSystemsOutput, true)
+	return args.Error(0)
+}
+
+func (m *MockEfs) DescribeFileSystemPolicy(in *efs.DescribeFileSystemPolicyInput) (*efs.DescribeFileSystemPolicyOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*efs.DescribeFileSystemPolicyOutput), args.Error(1)
+}
+
+func (m *MockEfs) DescribeBackupPolicy(in *efs.DescribeBackupPolicyInput) (*efs.DescribeBackupPolicyOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*efs.DescribeBackupPolicyOutput), args.Error(1)
+}
+
+func (m *MockEfs) DescribeLifecycleConfiguration(
+	in *efs.DescribeLifecycleConfigurationInput) (*efs.DescribeLifecycleConfigurationOutput, error) {
+
+	args := m.Called(in)
+	return args.Get(0).(*efs.DescribeLifecycleConfigurationOutput), args.Error(1)
+}
+
+func (m *MockEfs) DescribeMountTargets(in *efs.DescribeMountTargetsInput) (*efs.DescribeMountTargetsOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*efs.DescribeMountTargetsOutput), args.Error(1)
+}
+
+func (m *MockEfs) DescribeMountTargetSecurityGroups(
+	in *efs.DescribeMountTargetSecurityGroupsInput) (*efs.DescribeMountTargetSecurityGroupsOutput, error) {
+
+	args := m.Called(in)
+	return args.Get(0).(*efs.DescribeMountTargetSecurityGroupsOutput), args.Error(1)
+}
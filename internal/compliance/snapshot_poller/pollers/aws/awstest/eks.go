@@ -36,7 +36,7 @@ var (
 	ExampleEksClusterArn       = aws.String("arn:aws:eks:us-west-2:123456789012:cluster/example-cluster")
 	ExampleFargateProfileName  = aws.String("example-fargate-profile")
 	ExampleNodegroupName       = aws.String("example-nodegroup-name")
-	ExampleNodegroupArn        = aws.String("arn:aws:eks:us-west-2:123456789012:service/example-service")
+	ExampleNodegroupArn        = aws.String("arn:aws:eks:us-west-2:123456789012:nodegroup/example-cluster/example-nodegroup-name/1111-2222")
 	ExampleTags                = map[string]*string{*aws.String("test-tag-key"): aws.String("test-tag-value")}
 	ExampleLabels              = map[string]*string{*aws.String("test-label-key"): aws.String("test-label-value")}
 	ExampleCreatedAt           = aws.Time(time.Unix(1579896067, 0))
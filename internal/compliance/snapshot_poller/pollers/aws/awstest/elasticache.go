@@ -0,0 +1,228 @@
+package awstest
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/elasticache"
+	"github.com/aws/aws-sdk-go/service/elasticache/elasticacheiface"
+	"github.com/stretchr/testify/mock"
+)
+
+// Example ElastiCache API return values
+var (
+	ExampleElastiCacheReplicationGroupID  = aws.String("example-group")
+	ExampleElastiCacheReplicationGroupArn = aws.String("arn:aws:elasticache:us-west-2:123456789012:replicationgroup:example-group")
+
+	ExampleElastiCacheReplicationGroup = &elasticache.ReplicationGroup{
+		ARN:                      ExampleElastiCacheReplicationGroupArn,
+		AtRestEncryptionEnabled:  aws.Bool(true),
+		AuthTokenEnabled:         aws.Bool(false),
+		AutomaticFailover:        aws.String("enabled"),
+		CacheNodeType:            aws.String("cache.t3.micro"),
+		ClusterEnabled:           aws.Bool(false),
+		Description:              aws.String("Example replication group"),
+		MemberClusters:           []*string{aws.String("example-group-001"), aws.String("example-group-002")},
+		MultiAZ:                  aws.String("enabled"),
+		ReplicationGroupId:       ExampleElastiCacheReplicationGroupID,
+		SnapshotRetentionLimit:   aws.Int64(1),
+		SnapshotWindow:           aws.String("05:00-06:00"),
+		Status:                   aws.String("available"),
+		TransitEncryptionEnabled: aws.Bool(false),
+	}
+
+	ExampleDescribeReplicationGroupsOutput = &elasticache.DescribeReplicationGroupsOutput{
+		ReplicationGroups: []*elasticache.ReplicationGroup{ExampleElastiCacheReplicationGroup},
+	}
+
+	ExampleDescribeReplicationGroupsOutputContinue = &elasticache.DescribeReplicationGroupsOutput{
+		ReplicationGroups: []*elasticache.ReplicationGroup{
+			ExampleElastiCacheReplicationGroup,
+			ExampleElastiCacheReplicationGroup,
+		},
+		Marker: aws.String("1"),
+	}
+
+	ExampleDescribeCacheClustersOutput = &elasticache.DescribeCacheClustersOutput{
+		CacheClusters: []*elasticache.CacheCluster{
+			{
+				AutoMinorVersionUpgrade: aws.Bool(true),
+				CacheClusterCreateTime:  &ExampleTime,
+				CacheClusterId:          aws.String("example-group-001"),
+				CacheSubnetGroupName:    aws.String("default"),
+				Engine:                  aws.String("redis"),
+				EngineVersion:           aws.String("6.0.5"),
+				ReplicationGroupId:      ExampleElastiCacheReplicationGroupID,
+				SecurityGroups: []*elasticache.SecurityGroupMembership{
+					{
+						SecurityGroupId: aws.String("sg-12345678"),
+						Status:          aws.String("active"),
+					},
+				},
+			},
+		},
+	}
+
+	ExampleListElastiCacheTagsForResourceOutput = &elasticache.TagListMessage{
+		TagList: []*elasticache.Tag{
+			{
+				Key:   aws.String("Key1"),
+				Value: aws.String("Value1"),
+			},
+		},
+	}
+
+	svcElastiCacheSetupCalls = map[string]func(*MockElastiCache){
+		"DescribeReplicationGroups": func(svc *MockElastiCache) {
+			svc.On("DescribeReplicationGroups", mock.Anything).
+				Return(ExampleDescribeReplicationGroupsOutput, nil)
+		},
+		"DescribeReplicationGroupsPages": func(svc *MockElastiCache) {
+			svc.On("DescribeReplicationGroupsPages", mock.Anything).
+				Return(nil)
+		},
+		"DescribeCacheClusters": func(svc *MockElastiCache) {
+			svc.On("DescribeCacheClusters", mock.Anything).
+				Return(ExampleDescribeCacheClustersOutput, nil)
+		},
+		"ListTagsForResource": func(svc *MockElastiCache) {
+			svc.On("ListTagsForResource", mock.Anything).
+				Return(ExampleListElastiCacheTagsForResourceOutput, nil)
+		},
+	}
+
+	svcElastiCacheSetupCallsError = map[string]func(*MockElastiCache){
+		"DescribeReplicationGroups": func(svc *MockElastiCache) {
+			svc.On("DescribeReplicationGroups", mock.Anything).
+				Return(&elasticache.DescribeReplicationGroupsOutput{},
+					errors.New("ElastiCache.DescribeReplicationGroups error"),
+				)
+		},
+		"DescribeReplicationGroupsPages": func(svc *MockElastiCache) {
+			svc.On("DescribeReplicationGroupsPages", mock.Anything).
+				Return(errors.New("ElastiCache.DescribeReplicationGroupsPages error"))
+		},
+		"DescribeCacheClusters": func(svc *MockElastiCache) {
+			svc.On("DescribeCacheClusters", mock.Anything).
+				Return(&elasticache.DescribeCacheClustersOutput{},
+					errors.New("ElastiCache.DescribeCacheClusters error"),
+				)
+		},
+		"ListTagsForResource": func(svc *MockElastiCache) {
+			svc.On("ListTagsForResource", mock.Anything).
+				Return(&elasticache.TagListMessage{},
+					errors.New("ElastiCache.ListTagsForResource error"),
+				)
+		},
+	}
+
+	MockElastiCacheForSetup = &MockElastiCache{}
+)
+
+// ElastiCache mock
+
+// SetupMockElastiCache is used to override the ElastiCache Client initializer
+func SetupMockElastiCache(_ *session.Session, _ *aws.Config) interface{} {
+	return MockElastiCacheForSetup
+}
+
+// MockElastiCache is a mock ElastiCache client
+type MockElastiCache struct {
+	elasticacheiface.ElastiCacheAPI
+	mock.Mock
+}
+
+// BuildMockElastiCacheSvc builds and returns a MockElastiCache struct
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockElastiCacheSvc(funcs []string) (mockSvc *MockElastiCache) {
+	mockSvc = &MockElastiCache{}
+	for _, f := range funcs {
+		svcElastiCacheSetupCalls[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockElastiCacheSvcError builds and returns a MockElastiCache struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockElastiCacheSvcError(funcs []string) (mockSvc *MockElastiCache) {
+	mockSvc = &MockElastiCache{}
+	for _, f := range funcs {
+		svcElastiCacheSetupCallsError[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockElastiCacheSvcAll builds and returns a MockElastiCache struct
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockElastiCacheSvcAll() (mockSvc *MockElastiCache) {
+	mockSvc = &MockElastiCache{}
+	for _, f := range svcElastiCacheSetupCalls {
+		f(mockSvc)
+	}
+	return
+}
+
+// BuildMockElastiCacheSvcAllError builds and returns a MockElastiCache struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockElastiCacheSvcAllError() (mockSvc *MockElastiCache) {
+	mockSvc = &MockElastiCache{}
+	for _, f := range svcElastiCacheSetupCallsError {
+		f(mockSvc)
+	}
+	return
+}
+
+func (m *MockElastiCache) DescribeReplicationGroups(
+	in *elasticache.DescribeReplicationGroupsInput) (*elasticache.DescribeReplicationGroupsOutput, error) {
+
+	args := m.Called(in)
+	return args.Get(0).(*elasticache.DescribeReplicationGroupsOutput), args.Error(1)
+}
+
+func (m *MockElastiCache) DescribeReplicationGroupsPages(
+	in *elasticache.DescribeReplicationGroupsInput,
+	paginationFunction func(*elasticache.DescribeReplicationGroupsOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	paginationFunction(ExampleDescribeReplicationGroupsOutput, true)
+	return args.Error(0)
+}
+
+func (m *MockElastiCache) DescribeCacheClusters(
+	in *elasticache.DescribeCacheClustersInput) (*elasticache.DescribeCacheClustersOutput, error) {
+
+	args := m.Called(in)
+	return args.Get(0).(*elasticache.DescribeCacheClustersOutput), args.Error(1)
+}
+
+func (m *MockElastiCache) ListTagsForResource(in *elasticache.ListTagsForResourceInput) (*elasticache.TagListMessage, error) {
+	args := m.Called(in)
+	return args.Get(0).(*elasticache.TagListMessage), args.Error(1)
+}
@@ -0,0 +1,255 @@
+package awstest
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elb/elbiface"
+	"github.com/stretchr/testify/mock"
+)
+
+// Example ELB API return values
+var (
+	ExampleClassicLoadBalancerName = aws.String("example-classic-lb")
+	ExampleClassicLoadBalancerARN  = aws.String("arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/example-classic-lb")
+
+	ExampleClassicLoadBalancer = &elb.LoadBalancerDescription{
+		AvailabilityZones: []*string{aws.String("us-west-2a")},
+		CreatedTime:       &ExampleTime,
+		DNSName:           aws.String("example-classic-lb-1234567890.us-west-2.elb.amazonaws.com"),
+		ListenerDescriptions: []*elb.ListenerDescription{
+			{
+				Listener: &elb.Listener{
+					InstancePort:     aws.Int64(80),
+					LoadBalancerPort: aws.Int64(443),
+					Protocol:         aws.String("HTTPS"),
+					SSLCertificateId: aws.String("arn:aws:acm:us-west-2:123456789012:certificate/example"),
+				},
+				PolicyNames: []*string{aws.String("ELBSecurityPolicy-2016-08")},
+			},
+		},
+		LoadBalancerName: ExampleClassicLoadBalancerName,
+		Scheme:           aws.String("internet-facing"),
+		SecurityGroups:   []*string{aws.String("sg-1111")},
+		VPCId:            aws.String("vpc-1111"),
+	}
+
+	ExampleDescribeClassicLoadBalancersOutput = &elb.DescribeLoadBalancersOutput{
+		LoadBalancerDescriptions: []*elb.LoadBalancerDescription{ExampleClassicLoadBalancer},
+	}
+
+	ExampleDescribeClassicLoadBalancersOutputContinue = &elb.DescribeLoadBalancersOutput{
+		LoadBalancerDescriptions: []*elb.LoadBalancerDescription{ExampleClassicLoadBalancer, ExampleClassicLoadBalancer},
+		NextMarker:               aws.String("1"),
+	}
+
+	ExampleDescribeLoadBalancerAttributesOutput = &elb.DescribeLoadBalancerAttributesOutput{
+		LoadBalancerAttributes: &elb.LoadBalancerAttributes{
+			AccessLog: &elb.AccessLog{
+				Enabled: aws.Bool(false),
+			},
+			ConnectionDraining: &elb.ConnectionDraining{
+				Enabled: aws.Bool(true),
+				Timeout: aws.Int64(300),
+			},
+			CrossZoneLoadBalancing: &elb.CrossZoneLoadBalancing{
+				Enabled: aws.Bool(true),
+			},
+		},
+	}
+
+	ExampleDescribeLoadBalancerPoliciesOutput = &elb.DescribeLoadBalancerPoliciesOutput{
+		PolicyDescriptions: []*elb.PolicyDescription{
+			{
+				PolicyAttributeDescriptions: []*elb.PolicyAttributeDescription{
+					{
+						AttributeName:  aws.String("Reference-Security-Policy"),
+						AttributeValue: aws.String("ELBSecurityPolicy-2016-08"),
+					},
+				},
+				PolicyName:     aws.String("ELBSecurityPolicy-2016-08"),
+				PolicyTypeName: aws.String("SSLNegotiationPolicyType"),
+			},
+		},
+	}
+
+	ExampleDescribeClassicLoadBalancerTagsOutput = &elb.DescribeTagsOutput{
+		TagDescriptions: []*elb.TagDescription{
+			{
+				LoadBalancerName: ExampleClassicLoadBalancerName,
+				Tags: []*elb.Tag{
+					{
+						Key:   aws.String("Key1"),
+						Value: aws.String("Value1"),
+					},
+				},
+			},
+		},
+	}
+
+	svcElbSetupCalls = map[string]func(*MockElb){
+		"DescribeLoadBalancersPages": func(svc *MockElb) {
+			svc.On("DescribeLoadBalancersPages", mock.Anything).
+				Return(nil)
+		},
+		"DescribeLoadBalancers": func(svc *MockElb) {
+			svc.On("DescribeLoadBalancers", mock.Anything).
+				Return(ExampleDescribeClassicLoadBalancersOutput, nil)
+		},
+		"DescribeLoadBalancerAttributes": func(svc *MockElb) {
+			svc.On("DescribeLoadBalancerAttributes", mock.Anything).
+				Return(ExampleDescribeLoadBalancerAttributesOutput, nil)
+		},
+		"DescribeLoadBalancerPolicies": func(svc *MockElb) {
+			svc.On("DescribeLoadBalancerPolicies", mock.Anything).
+				Return(ExampleDescribeLoadBalancerPoliciesOutput, nil)
+		},
+		"DescribeTags": func(svc *MockElb) {
+			svc.On("DescribeTags", mock.Anything).
+				Return(ExampleDescribeClassicLoadBalancerTagsOutput, nil)
+		},
+	}
+
+	svcElbSetupCallsError = map[string]func(*MockElb){
+		"DescribeLoadBalancersPages": func(svc *MockElb) {
+			svc.On("DescribeLoadBalancersPages", mock.Anything).
+				Return(errors.New("ELB.DescribeLoadBalancersPages error"))
+		},
+		"DescribeLoadBalancers": func(svc *MockElb) {
+			svc.On("DescribeLoadBalancers", mock.Anything).
+				Return(&elb.DescribeLoadBalancersOutput{},
+					errors.New("ELB.DescribeLoadBalancers error"),
+				)
+		},
+		"DescribeLoadBalancerAttributes": func(svc *MockElb) {
+			svc.On("DescribeLoadBalancerAttributes", mock.Anything).
+				Return(&elb.DescribeLoadBalancerAttributesOutput{},
+					errors.New("ELB.DescribeLoadBalancerAttributes error"),
+				)
+		},
+		"DescribeLoadBalancerPolicies": func(svc *MockElb) {
+			svc.On("DescribeLoadBalancerPolicies", mock.Anything).
+				Return(&elb.DescribeLoadBalancerPoliciesOutput{},
+					errors.New("ELB.DescribeLoadBalancerPolicies error"),
+				)
+		},
+		"DescribeTags": func(svc *MockElb) {
+			svc.On("DescribeTags", mock.Anything).
+				Return(&elb.DescribeTagsOutput{},
+					errors.New("ELB.DescribeTags error"),
+				)
+		},
+	}
+
+	MockElbForSetup = &MockElb{}
+)
+
+// ELB mock
+
+// SetupMockElb is used to override the ELB Client initializer
+func SetupMockElb(_ *session.Session, _ *aws.Config) interface{} {
+	return MockElbForSetup
+}
+
+// MockElb is a mock ELB client
+type MockElb struct {
+	elbiface.ELBAPI
+	mock.Mock
+}
+
+// BuildMockElbSvc builds and returns a MockElb struct
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockElbSvc(funcs []string) (mockSvc *MockElb) {
+	mockSvc = &MockElb{}
+	for _, f := range funcs {
+		svcElbSetupCalls[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockElbSvcError builds and returns a MockElb struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockElbSvcError(funcs []string) (mockSvc *MockElb) {
+	mockSvc = &MockElb{}
+	for _, f := range funcs {
+		svcElbSetupCallsError[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockElbSvcAll builds and returns a MockElb struct
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockElbSvcAll() (mockSvc *MockElb) {
+	mockSvc = &MockElb{}
+	for _, f := range svcElbSetupCalls {
+		f(mockSvc)
+	}
+	return
+}
+
+// BuildMockElbSvcAllError builds and returns a MockElb struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockElbSvcAllError() (mockSvc *MockElb) {
+	mockSvc = &MockElb{}
+	for _, f := range svcElbSetupCallsError {
+		f(mockSvc)
+	}
+	return
+}
+
+func (m *MockElb) DescribeLoadBalancersPages(
+	in *elb.DescribeLoadBalancersInput,
+	paginationFunction func(*elb.DescribeLoadBalancersOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	paginationFunction(ExampleDescribeClassicLoadBalancersOutput, true)
+	return args.Error(0)
+}
+
+func (m *MockElb) DescribeLoadBalancers(in *elb.DescribeLoadBalancersInput) (*elb.DescribeLoadBalancersOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*elb.DescribeLoadBalancersOutput), args.Error(1)
+}
+
+func (m *MockElb) DescribeLoadBalancerAttributes(in *elb.DescribeLoadBalancerAttributesInput) (*elb.DescribeLoadBalancerAttributesOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*elb.DescribeLoadBalancerAttributesOutput), args.Error(1)
+}
+
+func (m *MockElb) DescribeLoadBalancerPolicies(in *elb.DescribeLoadBalancerPoliciesInput) (*elb.DescribeLoadBalancerPoliciesOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*elb.DescribeLoadBalancerPoliciesOutput), args.Error(1)
+}
+
+func (m *MockElb) DescribeTags(in *elb.DescribeTagsInput) (*elb.DescribeTagsOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*elb.DescribeTagsOutput), args.Error(1)
+}
@@ -0,0 +1,222 @@
+package awstest
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/elasticsearchservice"
+	"github.com/aws/aws-sdk-go/service/elasticsearchservice/elasticsearchserviceiface"
+	"github.com/stretchr/testify/mock"
+)
+
+// Example OpenSearch (Elasticsearch Service) API return values
+var (
+	ExampleOpenSearchDomainName = aws.String("example-domain")
+	ExampleOpenSearchDomainArn  = aws.String("arn:aws:es:us-west-2:123456789012:domain/example-domain")
+
+	ExampleOpenSearchDomainStatus = &elasticsearchservice.ElasticsearchDomainStatus{
+		ARN:            ExampleOpenSearchDomainArn,
+		AccessPolicies: aws.String(`{"Version":"2012-10-17","Statement":[]}`),
+		Created:        aws.Bool(true),
+		Deleted:        aws.Bool(false),
+		DomainEndpointOptions: &elasticsearchservice.DomainEndpointOptions{
+			EnforceHTTPS:      aws.Bool(true),
+			TLSSecurityPolicy: aws.String("Policy-Min-TLS-1-2-2019-07"),
+		},
+		DomainId:   aws.String("123456789012/example-domain"),
+		DomainName: ExampleOpenSearchDomainName,
+		ElasticsearchClusterConfig: &elasticsearchservice.ElasticsearchClusterConfig{
+			InstanceCount: aws.Int64(1),
+			InstanceType:  aws.String("t3.small.elasticsearch"),
+		},
+		ElasticsearchVersion: aws.String("7.9"),
+		EncryptionAtRestOptions: &elasticsearchservice.EncryptionAtRestOptions{
+			Enabled: aws.Bool(true),
+		},
+		Endpoints: map[string]*string{
+			"vpc": aws.String("vpc-example-domain-abcdefg.us-west-2.es.amazonaws.com"),
+		},
+		NodeToNodeEncryptionOptions: &elasticsearchservice.NodeToNodeEncryptionOptions{
+			Enabled: aws.Bool(true),
+		},
+		Processing: aws.Bool(false),
+		VPCOptions: &elasticsearchservice.VPCDerivedInfo{
+			SecurityGroupIds: []*string{aws.String("sg-12345678")},
+			SubnetIds:        []*string{aws.String("subnet-12345678")},
+			VPCId:            aws.String("vpc-12345678"),
+		},
+	}
+
+	ExampleListDomainNamesOutput = &elasticsearchservice.ListDomainNamesOutput{
+		DomainNames: []*elasticsearchservice.DomainInfo{
+			{DomainName: ExampleOpenSearchDomainName},
+		},
+	}
+
+	ExampleDescribeElasticsearchDomainOutput = &elasticsearchservice.DescribeElasticsearchDomainOutput{
+		DomainStatus: ExampleOpenSearchDomainStatus,
+	}
+
+	ExampleDescribeElasticsearchDomainsOutput = &elasticsearchservice.DescribeElasticsearchDomainsOutput{
+		DomainStatusList: []*elasticsearchservice.ElasticsearchDomainStatus{ExampleOpenSearchDomainStatus},
+	}
+
+	ExampleListOpenSearchTagsOutput = &elasticsearchservice.ListTagsOutput{
+		TagList: []*elasticsearchservice.Tag{
+			{
+				Key:   aws.String("Key1"),
+				Value: aws.String("Value1"),
+			},
+		},
+	}
+
+	svcOpenSearchSetupCalls = map[string]func(*MockOpenSearch){
+		"ListDomainNames": func(svc *MockOpenSearch) {
+			svc.On("ListDomainNames", mock.Anything).
+				Return(ExampleListDomainNamesOutput, nil)
+		},
+		"DescribeElasticsearchDomain": func(svc *MockOpenSearch) {
+			svc.On("DescribeElasticsearchDomain", mock.Anything).
+				Return(ExampleDescribeElasticsearchDomainOutput, nil)
+		},
+		"DescribeElasticsearchDomains": func(svc *MockOpenSearch) {
+			svc.On("DescribeElasticsearchDomains", mock.Anything).
+				Return(ExampleDescribeElasticsearchDomainsOutput, nil)
+		},
+		"ListTags": func(svc *MockOpenSearch) {
+			svc.On("ListTags", mock.Anything).
+				Return(ExampleListOpenSearchTagsOutput, nil)
+		},
+	}
+
+	svcOpenSearchSetupCallsError = map[string]func(*MockOpenSearch){
+		"ListDomainNames": func(svc *MockOpenSearch) {
+			svc.On("ListDomainNames", mock.Anything).
+				Return(&elasticsearchservice.ListDomainNamesOutput{},
+					errors.New("ElasticsearchService.ListDomainNames error"),
+				)
+		},
+		"DescribeElasticsearchDomain": func(svc *MockOpenSearch) {
+			svc.On("DescribeElasticsearchDomain", mock.Anything).
+				Return(&elasticsearchservice.DescribeElasticsearchDomainOutput{},
+					errors.New("ElasticsearchService.DescribeElasticsearchDomain error"),
+				)
+		},
+		"DescribeElasticsearchDomains": func(svc *MockOpenSearch) {
+			svc.On("DescribeElasticsearchDomains", mock.Anything).
+				Return(&elasticsearchservice.DescribeElasticsearchDomainsOutput{},
+					errors.New("ElasticsearchService.DescribeElasticsearchDomains error"),
+				)
+		},
+		"ListTags": func(svc *MockOpenSearch) {
+			svc.On("ListTags", mock.Anything).
+				Return(&elasticsearchservice.ListTagsOutput{},
+					errors.New("ElasticsearchService.ListTags error"),
+				)
+		},
+	}
+
+	MockOpenSearchForSetup = &MockOpenSearch{}
+)
+
+// OpenSearch mock
+
+// SetupMockOpenSearch is used to override the OpenSearch Client initializer
+func SetupMockOpenSearch(_ *session.Session, _ *aws.Config) interface{} {
+	return MockOpenSearchForSetup
+}
+
+// MockOpenSearch is a mock OpenSearch (Elasticsearch Service) client
+type MockOpenSearch struct {
+	elasticsearchserviceiface.ElasticsearchServiceAPI
+	mock.Mock
+}
+
+// BuildMockOpenSearchSvc builds and returns a MockOpenSearch struct
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockOpenSearchSvc(funcs []string) (mockSvc *MockOpenSearch) {
+	mockSvc = &MockOpenSearch{}
+	for _, f := range funcs {
+		svcOpenSearchSetupCalls[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockOpenSearchSvcError builds and returns a MockOpenSearch struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockOpenSearchSvcError(funcs []string) (mockSvc *MockOpenSearch) {
+	mockSvc = &MockOpenSearch{}
+	for _, f := range funcs {
+		svcOpenSearchSetupCallsError[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockOpenSearchSvcAll builds and returns a MockOpenSearch struct
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockOpenSearchSvcAll() (mockSvc *MockOpenSearch) {
+	mockSvc = &MockOpenSearch{}
+	for _, f := range svcOpenSearchSetupCalls {
+		f(mockSvc)
+	}
+	return
+}
+
+// BuildMockOpenSearchSvcAllError builds and returns a MockOpenSearch struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockOpenSearchSvcAllError() (mockSvc *MockOpenSearch) {
+	mockSvc = &MockOpenSearch{}
+	for _, f := range svcOpenSearchSetupCallsError {
+		f(mockSvc)
+	}
+	return
+}
+
+func (m *MockOpenSearch) ListDomainNames(
+	in *elasticsearchservice.ListDomainNamesInput) (*elasticsearchservice.ListDomainNamesOutput, error) {
+
+	args := m.Called(in)
+	return args.Get(0).(*elasticsearchservice.ListDomainNamesOutput), args.Error(1)
+}
+
+func (m *MockOpenSearch) DescribeElasticsearchDomain(
+	in *elasticsearchservice.DescribeElasticsearchDomainInput) (*elasticsearchservice.DescribeElasticsearchDomainOutput, error) {
+
+	args := m.Called(in)
+	return args.Get(0).(*elasticsearchservice.DescribeElasticsearchDomainOutput), args.Error(1)
+}
+
+func (m *MockOpenSearch) DescribeElasticsearchDomains(
+	in *elasticsearchservice.DescribeElasticsearchDomainsInput) (*elasticsearchservice.DescribeElasticsearchDomainsOutput, error) {
+
+	args := m.Called(in)
+	return args.Get(0).(*elasticsearchservice.DescribeElasticsearchDomainsOutput), args.Error(1)
+}
+
+func (m *MockOpenSearch) ListTags(in *elasticsearchservice.ListTagsInput) (*elasticsearchservice.ListTagsOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*elasticsearchservice.ListTagsOutput), args.Error(1)
+}
@@ -0,0 +1,592 @@
+package awstest
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/aws/aws-sdk-go/service/organizations/organizationsiface"
+	"github.com/stretchr/testify/mock"
+)
+
+// Example Organizations API return values
+var (
+	ExampleOrganizationID       = aws.String("o-exampleorgid")
+	ExampleOrganizationRootID   = aws.String("r-examplerootid111")
+	ExampleOrganizationalUnitID = aws.String("ou-examplerootid111-exampleouid111")
+	ExampleOrganizationsSCPID   = aws.String("p-examplepolicyid111")
+
+	ExampleDescribeOrganizationOutput = &organizations.DescribeOrganizationOutput{
+		Organization: &organizations.Organization{
+			Arn:                aws.String("arn:aws:organizations::111111111111:organization/o-exampleorgid"),
+			FeatureSet:         aws.String("ALL"),
+			Id:                 ExampleOrganizationID,
+			MasterAccountArn:   aws.String("arn:aws:organizations::111111111111:account/o-exampleorgid/111111111111"),
+			MasterAccountEmail: aws.String("management@example.com"),
+			MasterAccountId:    aws.String("111111111111"),
+		},
+	}
+
+	ExampleOrganizationsAccount = &organizations.Account{
+		Arn:             aws.String("arn:aws:organizations::111111111111:account/o-exampleorgid/222222222222"),
+		Email:           aws.String("member@example.com"),
+		Id:              aws.String("222222222222"),
+		JoinedMethod:    aws.String("CREATED"),
+		JoinedTimestamp: &ExampleTime,
+		Name:            aws.String("member"),
+		Status:          aws.String("ACTIVE"),
+	}
+
+	ExampleListAccountsOutput = &organizations.ListAccountsOutput{
+		Accounts: []*organizations.Account{ExampleOrganizationsAccount},
+	}
+
+	ExampleListAccountsOutputContinue = &organizations.ListAccountsOutput{
+		Accounts: []*organizations.Account{
+			ExampleOrganizationsAccount,
+			ExampleOrganizationsAccount,
+		},
+		NextToken: aws.String("1"),
+	}
+
+	ExampleDescribeAccountOutput = &organizations.DescribeAccountOutput{
+		Account: ExampleOrganizationsAccount,
+	}
+
+	ExampleListRootsOutput = &organizations.ListRootsOutput{
+		Roots: []*organizations.Root{
+			{
+				Arn:  aws.String("arn:aws:organizations::111111111111:root/o-exampleorgid/r-examplerootid111"),
+				Id:   ExampleOrganizationRootID,
+				Name: aws.String("Root"),
+			},
+		},
+	}
+
+	ExampleOrganizationalUnit = &organizations.OrganizationalUnit{
+		Arn:  aws.String("arn:aws:organizations::111111111111:ou/o-exampleorgid/ou-examplerootid111-exampleouid111"),
+		Id:   ExampleOrganizationalUnitID,
+		Name: aws.String("workloads"),
+	}
+
+	ExampleDescribeOrganizationalUnitOutput = &organizations.DescribeOrganizationalUnitOutput{
+		OrganizationalUnit: ExampleOrganizationalUnit,
+	}
+
+	// The example OU sits directly below the root and contains the example member account
+	ExampleListOrganizationalUnitsForParentOutput = &organizations.ListOrganizationalUnitsForParentOutput{
+		OrganizationalUnits: []*organizations.OrganizationalUnit{ExampleOrganizationalUnit},
+	}
+
+	ExampleListAccountsForParentOutput = &organizations.ListAccountsForParentOutput{
+		Accounts: []*organizations.Account{ExampleOrganizationsAccount},
+	}
+
+	ExampleListParentsOutput = &organizations.ListParentsOutput{
+		Parents: []*organizations.Parent{
+			{
+				Id:   ExampleOrganizationalUnitID,
+				Type: aws.String("ORGANIZATIONAL_UNIT"),
+			},
+		},
+	}
+
+	ExampleListParentsOutputRoot = &organizations.ListParentsOutput{
+		Parents: []*organizations.Parent{
+			{
+				Id:   ExampleOrganizationRootID,
+				Type: aws.String("ROOT"),
+			},
+		},
+	}
+
+	ExampleOrganizationsFullAWSAccessPolicy = &organizations.PolicySummary{
+		Arn:         aws.String("arn:aws:organizations::aws:policy/service_control_policy/p-FullAWSAccess"),
+		AwsManaged:  aws.Bool(true),
+		Description: aws.String("Allows access to every operation"),
+		Id:          aws.String("p-FullAWSAccess"),
+		Name:        aws.String("FullAWSAccess"),
+		Type:        aws.String("SERVICE_CONTROL_POLICY"),
+	}
+
+	ExampleOrganizationsSCP = &organizations.PolicySummary{
+		Arn: aws.String(
+			"arn:aws:organizations::111111111111:policy/o-exampleorgid/service_control_policy/p-examplepolicyid111"),
+		AwsManaged:  aws.Bool(false),
+		Description: aws.String("Deny leaving the organization"),
+		Id:          ExampleOrganizationsSCPID,
+		Name:        aws.String("deny-leave-organization"),
+		Type:        aws.String("SERVICE_CONTROL_POLICY"),
+	}
+
+	ExampleListPoliciesOutput = &organizations.ListPoliciesOutput{
+		Policies: []*organizations.PolicySummary{
+			ExampleOrganizationsFullAWSAccessPolicy,
+			ExampleOrganizationsSCP,
+		},
+	}
+
+	ExampleDescribePolicyOutput = &organizations.DescribePolicyOutput{
+		Policy: &organizations.Policy{
+			Content: aws.String(
+				`{"Version":"2012-10-17","Statement":[{"Effect":"Deny","Action":"organizations:LeaveOrganization","Resource":"*"}]}`),
+			PolicySummary: ExampleOrganizationsSCP,
+		},
+	}
+
+	ExampleListTargetsForPolicyOutput = &organizations.ListTargetsForPolicyOutput{
+		Targets: []*organizations.PolicyTargetSummary{
+			{
+				Arn:      ExampleOrganizationalUnit.Arn,
+				Name:     ExampleOrganizationalUnit.Name,
+				TargetId: ExampleOrganizationalUnitID,
+				Type:     aws.String("ORGANIZATIONAL_UNIT"),
+			},
+		},
+	}
+
+	// The AWS managed policy is attached to the root, the example SCP to the example OU
+	ExampleListPoliciesForTargetOutputRoot = &organizations.ListPoliciesForTargetOutput{
+		Policies: []*organizations.PolicySummary{ExampleOrganizationsFullAWSAccessPolicy},
+	}
+
+	ExampleListPoliciesForTargetOutputOrganizationalUnit = &organizations.ListPoliciesForTargetOutput{
+		Policies: []*organizations.PolicySummary{ExampleOrganizationsSCP},
+	}
+
+	ExampleListOrganizationsTagsForResourceOutput = &organizations.ListTagsForResourceOutput{
+		Tags: []*organizations.Tag{
+			{
+				Key:   aws.String("Key1"),
+				Value: aws.String("Value1"),
+			},
+		},
+	}
+
+	ExampleListDelegatedAdministratorsOutput = &organizations.ListDelegatedAdministratorsOutput{
+		DelegatedAdministrators: []*organizations.DelegatedAdministrator{
+			{
+				Arn:                   ExampleOrganizationsAccount.Arn,
+				DelegationEnabledDate: &ExampleTime,
+				Email:                 ExampleOrganizationsAccount.Email,
+				Id:                    ExampleOrganizationsAccount.Id,
+				JoinedMethod:          ExampleOrganizationsAccount.JoinedMethod,
+				JoinedTimestamp:       &ExampleTime,
+				Name:                  ExampleOrganizationsAccount.Name,
+				Status:                aws.String("ACTIVE"),
+			},
+		},
+	}
+
+	ExampleListDelegatedServicesForAccountOutput = &organizations.ListDelegatedServicesForAccountOutput{
+		DelegatedServices: []*organizations.DelegatedService{
+			{
+				DelegationEnabledDate: &ExampleTime,
+				ServicePrincipal:      aws.String("guardduty.amazonaws.com"),
+			},
+		},
+	}
+
+	svcOrganizationsSetupCalls = map[string]func(*MockOrganizations){
+		"DescribeOrganization": func(svc *MockOrganizations) {
+			svc.On("DescribeOrganization", mock.Anything).
+				Return(ExampleDescribeOrganizationOutput, nil)
+		},
+		"DescribeAccount": func(svc *MockOrganizations) {
+			svc.On("DescribeAccount", mock.Anything).
+				Return(ExampleDescribeAccountOutput, nil)
+		},
+		"DescribeOrganizationalUnit": func(svc *MockOrganizations) {
+			svc.On("DescribeOrganizationalUnit", mock.Anything).
+				Return(ExampleDescribeOrganizationalUnitOutput, nil)
+		},
+		"DescribePolicy": func(svc *MockOrganizations) {
+			svc.On("DescribePolicy", mock.Anything).
+				Return(ExampleDescribePolicyOutput, nil)
+		},
+		"ListParents": func(svc *MockOrganizations) {
+			svc.On("ListParents", &organizations.ListParentsInput{ChildId: ExampleOrganizationalUnitID}).
+				Return(ExampleListParentsOutputRoot, nil)
+			svc.On("ListParents", mock.Anything).
+				Return(ExampleListParentsOutput, nil)
+		},
+		"ListAccountsPages": func(svc *MockOrganizations) {
+			svc.On("ListAccountsPages", mock.Anything).
+				Return(nil)
+		},
+		"ListRootsPages": func(svc *MockOrganizations) {
+			svc.On("ListRootsPages", mock.Anything).
+				Return(nil)
+		},
+		"ListOrganizationalUnitsForParentPages": func(svc *MockOrganizations) {
+			svc.On("ListOrganizationalUnitsForParentPages", mock.Anything).
+				Return(nil)
+		},
+		"ListAccountsForParentPages": func(svc *MockOrganizations) {
+			svc.On("ListAccountsForParentPages", mock.Anything).
+				Return(nil)
+		},
+		"ListPoliciesPages": func(svc *MockOrganizations) {
+			svc.On("ListPoliciesPages", mock.Anything).
+				Return(nil)
+		},
+		"ListPoliciesForTargetPages": func(svc *MockOrganizations) {
+			svc.On("ListPoliciesForTargetPages", mock.Anything).
+				Return(nil)
+		},
+		"ListTargetsForPolicyPages": func(svc *MockOrganizations) {
+			svc.On("ListTargetsForPolicyPages", mock.Anything).
+				Return(nil)
+		},
+		"ListTagsForResourcePages": func(svc *MockOrganizations) {
+			svc.On("ListTagsForResourcePages", mock.Anything).
+				Return(nil)
+		},
+		"ListDelegatedAdministratorsPages": func(svc *MockOrganizations) {
+			svc.On("ListDelegatedAdministratorsPages", mock.Anything).
+				Return(nil)
+		},
+		"ListDelegatedServicesForAccountPages": func(svc *MockOrganizations) {
+			svc.On("ListDelegatedServicesForAccountPages", mock.Anything).
+				Return(nil)
+		},
+	}
+
+	svcOrganizationsSetupCallsError = map[string]func(*MockOrganizations){
+		"DescribeOrganization": func(svc *MockOrganizations) {
+			svc.On("DescribeOrganization", mock.Anything).
+				Return(&organizations.DescribeOrganizationOutput{},
+					errors.New("Organizations.DescribeOrganization error"),
+				)
+		},
+		"DescribeAccount": func(svc *MockOrganizations) {
+			svc.On("DescribeAccount", mock.Anything).
+				Return(&organizations.DescribeAccountOutput{},
+					errors.New("Organizations.DescribeAccount error"),
+				)
+		},
+		"DescribeOrganizationalUnit": func(svc *MockOrganizations) {
+			svc.On("DescribeOrganizationalUnit", mock.Anything).
+				Return(&organizations.DescribeOrganizationalUnitOutput{},
+					errors.New("Organizations.DescribeOrganizationalUnit error"),
+				)
+		},
+		"DescribePolicy": func(svc *MockOrganizations) {
+			svc.On("DescribePolicy", mock.Anything).
+				Return(&organizations.DescribePolicyOutput{},
+					errors.New("Organizations.DescribePolicy error"),
+				)
+		},
+		"ListParents": func(svc *MockOrganizations) {
+			svc.On("ListParents", mock.Anything).
+				Return(&organizations.ListParentsOutput{},
+					errors.New("Organizations.ListParents error"),
+				)
+		},
+		"ListAccountsPages": func(svc *MockOrganizations) {
+			svc.On("ListAccountsPages", mock.Anything).
+				Return(errors.New("Organizations.ListAccountsPages error"))
+		},
+		"ListRootsPages": func(svc *MockOrganizations) {
+			svc.On("ListRootsPages", mock.Anything).
+				Return(errors.New("Organizations.ListRootsPages error"))
+		},
+		"ListOrganizationalUnitsForParentPages": func(svc *MockOrganizations) {
+			svc.On("ListOrganizationalUnitsForParentPages", mock.Anything).
+				Return(errors.New("Organizations.ListOrganizationalUnitsForParentPages error"))
+		},
+		"ListAccountsForParentPages": func(svc *MockOrganizations) {
+			svc.On("ListAccountsForParentPages", mock.Anything).
+				Return(errors.New("Organizations.ListAccountsForParentPages error"))
+		},
+		"ListPoliciesPages": func(svc *MockOrganizations) {
+			svc.On("ListPoliciesPages", mock.Anything).
+				Return(errors.New("Organizations.ListPoliciesPages error"))
+		},
+		"ListPoliciesForTargetPages": func(svc *MockOrganizations) {
+			svc.On("ListPoliciesForTargetPages", mock.Anything).
+				Return(errors.New("Organizations.ListPoliciesForTargetPages error"))
+		},
+		"ListTargetsForPolicyPages": func(svc *MockOrganizations) {
+			svc.On("ListTargetsForPolicyPages", mock.Anything).
+				Return(errors.New("Organizations.ListTargetsForPolicyPages error"))
+		},
+		"ListTagsForResourcePages": func(svc *MockOrganizations) {
+			svc.On("ListTagsForResourcePages", mock.Anything).
+				Return(errors.New("Organizations.ListTagsForResourcePages error"))
+		},
+		"ListDelegatedAdministratorsPages": func(svc *MockOrganizations) {
+			svc.On("ListDelegatedAdministratorsPages", mock.Anything).
+				Return(errors.New("Organizations.ListDelegatedAdministratorsPages error"))
+		},
+		"ListDelegatedServicesForAccountPages": func(svc *MockOrganizations) {
+			svc.On("ListDelegatedServicesForAccountPages", mock.Anything).
+				Return(errors.New("Organizations.ListDelegatedServicesForAccountPages error"))
+		},
+	}
+
+	MockOrganizationsForSetup = &MockOrganizations{}
+)
+
+// Organizations mock
+
+// SetupMockOrganizations is used to override the Organizations Client initializer
+func SetupMockOrganizations(_ *session.Session, _ *aws.Config) interface{} {
+	return MockOrganizationsForSetup
+}
+
+// MockOrganizations is a mock Organizations client
+type MockOrganizations struct {
+	organizationsiface.OrganizationsAPI
+	mock.Mock
+}
+
+// BuildMockOrganizationsSvc builds and returns a MockOrganizations struct
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockOrganizationsSvc(funcs []string) (mockSvc *MockOrganizations) {
+	mockSvc = &MockOrganizations{}
+	for _, f := range funcs {
+		svcOrganizationsSetupCalls[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockOrganizationsSvcError builds and returns a MockOrganizations struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockOrganizationsSvcError(funcs []string) (mockSvc *MockOrganizations) {
+	mockSvc = &MockOrganizations{}
+	for _, f := range funcs {
+		svcOrganizationsSetupCallsError[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockOrganizationsSvcAll builds and returns a MockOrganizations struct
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockOrganizationsSvcAll() (mockSvc *MockOrganizations) {
+	mockSvc = &MockOrganizations{}
+	for _, f := range svcOrganizationsSetupCalls {
+		f(mockSvc)
+	}
+	return
+}
+
+// BuildMockOrganizationsSvcAllError builds and returns a MockOrganizations struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockOrganizationsSvcAllError() (mockSvc *MockOrganizations) {
+	mockSvc = &MockOrganizations{}
+	for _, f := range svcOrganizationsSetupCallsError {
+		f(mockSvc)
+	}
+	return
+}
+
+func (m *MockOrganizations) DescribeOrganization(
+	in *organizations.DescribeOrganizationInput) (*organizations.DescribeOrganizationOutput, error) {
+
+	args := m.Called(in)
+	return args.Get(0).(*organizations.DescribeOrganizationOutput), args.Error(1)
+}
+
+func (m *MockOrganizations) DescribeAccount(
+	in *organizations.DescribeAccountInput) (*organizations.DescribeAccountOutput, error) {
+
+	args := m.Called(in)
+	return args.Get(0).(*organizations.DescribeAccountOutput), args.Error(1)
+}
+
+func (m *MockOrganizations) DescribeOrganizationalUnit(
+	in *organizations.DescribeOrganizationalUnitInput) (*organizations.DescribeOrganizationalUnitOutput, error) {
+
+	args := m.Called(in)
+	return args.Get(0).(*organizations.DescribeOrganizationalUnitOutput), args.Error(1)
+}
+
+func (m *MockOrganizations) DescribePolicy(
+	in *organizations.DescribePolicyInput) (*organizations.DescribePolicyOutput, error) {
+
+	args := m.Called(in)
+	return args.Get(0).(*organizations.DescribePolicyOutput), args.Error(1)
+}
+
+func (m *MockOrganizations) ListParents(in *organizations.ListParentsInput) (*organizations.ListParentsOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*organizations.ListParentsOutput), args.Error(1)
+}
+
+func (m *MockOrganizations) ListAccountsPages(
+	in *organizations.ListAccountsInput,
+	paginationFunction func(*organizations.ListAccountsOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	paginationFunction(ExampleListAccountsOutput, true)
+	return args.Error(0)
+}
+
+func (m *MockOrganizations) ListRootsPages(
+	in *organizations.ListRootsInput,
+	paginationFunction func(*organizations.ListRootsOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	paginationFunction(ExampleListRootsOutput, true)
+	return args.Error(0)
+}
+
+func (m *MockOrganizations) ListOrganizationalUnitsForParentPages(
+	in *organizations.ListOrganizationalUnitsForParentInput,
+	paginationFunction func(*organizations.ListOrganizationalUnitsForParentOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	// Only the root has an OU below it
+	if aws.StringValue(in.ParentId) == aws.StringValue(ExampleOrganizationRootID) {
+		paginationFunction(ExampleListOrganizationalUnitsForParentOutput, true)
+	} else {
+		paginationFunction(&organizations.ListOrganizationalUnitsForParentOutput{}, true)
+	}
+	return args.Error(0)
+}
+
+func (m *MockOrganizations) ListAccountsForParentPages(
+	in *organizations.ListAccountsForParentInput,
+	paginationFunction func(*organizations.ListAccountsForParentOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	// Only the OU has an account in it
+	if aws.StringValue(in.ParentId) == aws.StringValue(ExampleOrganizationalUnitID) {
+		paginationFunction(ExampleListAccountsForParentOutput, true)
+	} else {
+		paginationFunction(&organizations.ListAccountsForParentOutput{}, true)
+	}
+	return args.Error(0)
+}
+
+func (m *MockOrganizations) ListPoliciesPages(
+	in *organizations.ListPoliciesInput,
+	paginationFunction func(*organizations.ListPoliciesOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	// Only service control policies exist in the example organization
+	if aws.StringValue(in.Filter) == organizations.PolicyTypeServiceControlPolicy {
+		paginationFunction(ExampleListPoliciesOutput, true)
+	} else {
+		paginationFunction(&organizations.ListPoliciesOutput{}, true)
+	}
+	return args.Error(0)
+}
+
+func (m *MockOrganizations) ListPoliciesForTargetPages(
+	in *organizations.ListPoliciesForTargetInput,
+	paginationFunction func(*organizations.ListPoliciesForTargetOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	switch {
+	case aws.StringValue(in.Filter) != organizations.PolicyTypeServiceControlPolicy:
+		paginationFunction(&organizations.ListPoliciesForTargetOutput{}, true)
+	case aws.StringValue(in.TargetId) == aws.StringValue(ExampleOrganizationRootID):
+		paginationFunction(ExampleListPoliciesForTargetOutputRoot, true)
+	case aws.StringValue(in.TargetId) == aws.StringValue(ExampleOrganizationalUnitID):
+		paginationFunction(ExampleListPoliciesForTargetOutputOrganizationalUnit, true)
+	default:
+		paginationFunction(&organizations.ListPoliciesForTargetOutput{}, true)
+	}
+	return args.Error(0)
+}
+
+func (m *MockOrganizations) ListTargetsForPolicyPages(
+	in *organizations.ListTargetsForPolicyInput,
+	paginationFunction func(*organizations.ListTargetsForPolicyOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	paginationFunction(ExampleListTargetsForPolicyOutput, true)
+	return args.Error(0)
+}
+
+func (m *MockOrganizations) ListTagsForResourcePages(
+	in *organizations.ListTagsForResourceInput,
+	paginationFunction func(*organizations.ListTagsForResourceOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	paginationFunction(ExampleListOrganizationsTagsForResourceOutput, true)
+	return args.Error(0)
+}
+
+func (m *MockOrganizations) ListDelegatedAdministratorsPages(
+	in *organizations.ListDelegatedAdministratorsInput,
+	paginationFunction func(*organizations.ListDelegatedAdministratorsOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	paginationFunction(ExampleListDelegatedAdministratorsOutput, true)
+	return args.Error(0)
+}
+
+func (m *MockOrganizations) ListDelegatedServicesForAccountPages(
+	in *organizations.ListDelegatedServicesForAccountInput,
+	paginationFunction func(*organizations.ListDelegatedServicesForAccountOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	paginationFunction(ExampleListDelegatedServicesForAccountOutput, true)
+	return args.Error(0)
+}
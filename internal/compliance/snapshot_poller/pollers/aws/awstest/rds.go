@@ -175,6 +175,86 @@ var (
 		},
 	}
 
+	ExampleRDSClusterArn = aws.String("arn:aws:rds:us-west-2:123456789012:cluster:example-cluster")
+
+	ExampleDescribeDBClustersOutput = &rds.DescribeDBClustersOutput{
+		DBClusters: []*rds.DBCluster{
+			{
+				BackupRetentionPeriod: aws.Int64(7),
+				ClusterCreateTime:     &ExampleTime,
+				DBClusterArn:          ExampleRDSClusterArn,
+				DBClusterIdentifier:   aws.String("example-cluster"),
+				DBClusterMembers: []*rds.DBClusterMember{
+					{
+						DBInstanceIdentifier: aws.String("example-cluster-instance-1"),
+						IsClusterWriter:      aws.Bool(true),
+					},
+				},
+				DBSubnetGroup:       aws.String("default"),
+				DeletionProtection:  aws.Bool(true),
+				Engine:              aws.String("aurora-postgresql"),
+				EngineMode:          aws.String("provisioned"),
+				EngineVersion:       aws.String("11.9"),
+				KmsKeyId:            aws.String("arn:aws:kms:us-west-2:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"),
+				MultiAZ:             aws.Bool(false),
+				Status:              aws.String("available"),
+				StorageEncrypted:    aws.Bool(true),
+				HttpEndpointEnabled: aws.Bool(false),
+				TagList: []*rds.Tag{
+					{
+						Key:   aws.String("Key1"),
+						Value: aws.String("Value1"),
+					},
+				},
+				VpcSecurityGroups: []*rds.VpcSecurityGroupMembership{
+					{
+						VpcSecurityGroupId: aws.String("sg-123456789"),
+						Status:             aws.String("active"),
+					},
+				},
+			},
+		},
+	}
+	ExampleDescribeDBClustersOutputContinue = &rds.DescribeDBClustersOutput{
+		DBClusters: []*rds.DBCluster{
+			ExampleDescribeDBClustersOutput.DBClusters[0],
+			ExampleDescribeDBClustersOutput.DBClusters[0],
+		},
+		Marker: aws.String("1"),
+	}
+
+	ExampleRDSClusterSnapshotID = aws.String("example-cluster-snapshot")
+
+	ExampleDescribeDBClusterSnapshotsOutput = &rds.DescribeDBClusterSnapshotsOutput{
+		DBClusterSnapshots: []*rds.DBClusterSnapshot{
+			{
+				ClusterCreateTime:           &ExampleTime,
+				DBClusterIdentifier:         aws.String("example-cluster"),
+				DBClusterSnapshotArn:        aws.String("arn:aws:rds:us-west-2:123456789012:cluster-snapshot:example-cluster-snapshot"),
+				DBClusterSnapshotIdentifier: ExampleRDSClusterSnapshotID,
+				Engine:                      aws.String("aurora-postgresql"),
+				EngineVersion:               aws.String("11.9"),
+				SnapshotCreateTime:          &ExampleTime,
+				SnapshotType:                aws.String("manual"),
+				Status:                      aws.String("available"),
+				StorageEncrypted:            aws.Bool(false),
+				VpcId:                       aws.String("vpc-asdfasdf"),
+			},
+		},
+	}
+
+	ExampleDescribeDBClusterSnapshotAttributesOutput = &rds.DescribeDBClusterSnapshotAttributesOutput{
+		DBClusterSnapshotAttributesResult: &rds.DBClusterSnapshotAttributesResult{
+			DBClusterSnapshotIdentifier: ExampleRDSClusterSnapshotID,
+			DBClusterSnapshotAttributes: []*rds.DBClusterSnapshotAttribute{
+				{
+					AttributeName:   aws.String("restore"),
+					AttributeValues: []*string{aws.String("all")},
+				},
+			},
+		},
+	}
+
 	ExampleListTagsForResourceRds = &rds.ListTagsForResourceOutput{
 		TagList: []*rds.Tag{
 			{
@@ -193,6 +273,30 @@ var (
 			svc.On("DescribeDBSnapshotsPages", mock.Anything).
 				Return(nil)
 		},
+		"DescribeDBSnapshots": func(svc *MockRds) {
+			svc.On("DescribeDBSnapshots", mock.Anything).
+				Return(ExampleDescribeDBSnapshotsOutput, nil)
+		},
+		"DescribeDBClusters": func(svc *MockRds) {
+			svc.On("DescribeDBClusters", mock.Anything).
+				Return(ExampleDescribeDBClustersOutput, nil)
+		},
+		"DescribeDBClustersPages": func(svc *MockRds) {
+			svc.On("DescribeDBClustersPages", mock.Anything).
+				Return(nil)
+		},
+		"DescribeDBClusterSnapshots": func(svc *MockRds) {
+			svc.On("DescribeDBClusterSnapshots", mock.Anything).
+				Return(ExampleDescribeDBClusterSnapshotsOutput, nil)
+		},
+		"DescribeDBClusterSnapshotsPages": func(svc *MockRds) {
+			svc.On("DescribeDBClusterSnapshotsPages", mock.Anything).
+				Return(nil)
+		},
+		"DescribeDBClusterSnapshotAttributes": func(svc *MockRds) {
+			svc.On("DescribeDBClusterSnapshotAttributes", mock.Anything).
+				Return(ExampleDescribeDBClusterSnapshotAttributesOutput, nil)
+		},
 		"DescribeDBSnapshotAttributes": func(svc *MockRds) {
 			svc.On("DescribeDBSnapshotAttributes", mock.Anything).
 				Return(ExampleDescribeDBSnapshotsAttributesOutput, nil)
@@ -212,6 +316,38 @@ var (
 			svc.On("DescribeDBSnapshotsPages", mock.Anything).
 				Return(errors.New("RDS.DescribeDBSnapshotsPages error"))
 		},
+		"DescribeDBSnapshots": func(svc *MockRds) {
+			svc.On("DescribeDBSnapshots", mock.Anything).
+				Return(&rds.DescribeDBSnapshotsOutput{},
+					errors.New("RDS.DescribeDBSnapshots error"),
+				)
+		},
+		"DescribeDBClusters": func(svc *MockRds) {
+			svc.On("DescribeDBClusters", mock.Anything).
+				Return(&rds.DescribeDBClustersOutput{},
+					errors.New("RDS.DescribeDBClusters error"),
+				)
+		},
+		"DescribeDBClustersPages": func(svc *MockRds) {
+			svc.On("DescribeDBClustersPages", mock.Anything).
+				Return(errors.New("RDS.DescribeDBClustersPages error"))
+		},
+		"DescribeDBClusterSnapshots": func(svc *MockRds) {
+			svc.On("DescribeDBClusterSnapshots", mock.Anything).
+				Return(&rds.DescribeDBClusterSnapshotsOutput{},
+					errors.New("RDS.DescribeDBClusterSnapshots error"),
+				)
+		},
+		"DescribeDBClusterSnapshotsPages": func(svc *MockRds) {
+			svc.On("DescribeDBClusterSnapshotsPages", mock.Anything).
+				Return(errors.New("RDS.DescribeDBClusterSnapshotsPages error"))
+		},
+		"DescribeDBClusterSnapshotAttributes": func(svc *MockRds) {
+			svc.On("DescribeDBClusterSnapshotAttributes", mock.Anything).
+				Return(&rds.DescribeDBClusterSnapshotAttributesOutput{},
+					errors.New("RDS.DescribeDBClusterSnapshotAttributes error"),
+				)
+		},
 		"DescribeDBSnapshotAttributes": func(svc *MockRds) {
 			svc.On("DescribeDBSnapshotAttributes", mock.Anything).
 				Return(&rds.DescribeDBSnapshotAttributesOutput{},
@@ -321,3 +457,53 @@ func (m *MockRds) ListTagsForResource(in *rds.ListTagsForResourceInput) (*rds.Li
 	args := m.Called(in)
 	return args.Get(0).(*rds.ListTagsForResourceOutput), args.Error(1)
 }
+
+func (m *MockRds) DescribeDBSnapshots(in *rds.DescribeDBSnapshotsInput) (*rds.DescribeDBSnapshotsOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*rds.DescribeDBSnapshotsOutput), args.Error(1)
+}
+
+func (m *MockRds) DescribeDBClusters(in *rds.DescribeDBClustersInput) (*rds.DescribeDBClustersOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*rds.DescribeDBClustersOutput), args.Error(1)
+}
+
+func (m *MockRds) DescribeDBClustersPages(
+	in *rds.DescribeDBClustersInput,
+	paginationFunction func(*rds.DescribeDBClustersOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	paginationFunction(ExampleDescribeDBClustersOutput, true)
+	return args.Error(0)
+}
+
+func (m *MockRds) DescribeDBClusterSnapshots(
+	in *rds.DescribeDBClusterSnapshotsInput) (*rds.DescribeDBClusterSnapshotsOutput, error) {
+
+	args := m.Called(in)
+	return args.Get(0).(*rds.DescribeDBClusterSnapshotsOutput), args.Error(1)
+}
+
+func (m *MockRds) DescribeDBClusterSnapshotsPages(
+	in *rds.DescribeDBClusterSnapshotsInput,
+	paginationFunction func(*rds.DescribeDBClusterSnapshotsOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	paginationFunction(ExampleDescribeDBClusterSnapshotsOutput, true)
+	return args.Error(0)
+}
+
+func (m *MockRds) DescribeDBClusterSnapshotAttributes(
+	in *rds.DescribeDBClusterSnapshotAttributesInput) (*rds.DescribeDBClusterSnapshotAttributesOutput, error) {
+
+	args := m.Called(in)
+	return args.Get(0).(*rds.DescribeDBClusterSnapshotAttributesOutput), args.Error(1)
+}
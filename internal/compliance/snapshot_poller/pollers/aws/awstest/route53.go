@@ -0,0 +1,221 @@
+package awstest
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+	"github.com/stretchr/testify/mock"
+)
+
+// Example Route53 API return values
+var (
+	ExampleHostedZoneID = aws.String("/hostedzone/Z1D633PJN98FT9")
+
+	ExampleHostedZone = &route53.HostedZone{
+		CallerReference: aws.String("example-reference"),
+		Config: &route53.HostedZoneConfig{
+			PrivateZone: aws.Bool(false),
+		},
+		Id:                     ExampleHostedZoneID,
+		Name:                   aws.String("example.com."),
+		ResourceRecordSetCount: aws.Int64(4),
+	}
+
+	ExampleListHostedZonesOutput = &route53.ListHostedZonesOutput{
+		HostedZones: []*route53.HostedZone{ExampleHostedZone},
+	}
+
+	ExampleListHostedZonesOutputContinue = &route53.ListHostedZonesOutput{
+		HostedZones: []*route53.HostedZone{ExampleHostedZone, ExampleHostedZone},
+		IsTruncated: aws.Bool(true),
+		NextMarker:  aws.String("1"),
+	}
+
+	ExampleGetHostedZoneOutput = &route53.GetHostedZoneOutput{
+		DelegationSet: &route53.DelegationSet{
+			NameServers: []*string{aws.String("ns-1.awsdns-01.org")},
+		},
+		HostedZone: ExampleHostedZone,
+	}
+
+	ExampleListQueryLoggingConfigsOutput = &route53.ListQueryLoggingConfigsOutput{
+		QueryLoggingConfigs: []*route53.QueryLoggingConfig{
+			{
+				CloudWatchLogsLogGroupArn: aws.String("arn:aws:logs:us-east-1:123456789012:log-group:/aws/route53/example.com"),
+				HostedZoneId:              aws.String("Z1D633PJN98FT9"),
+				Id:                        aws.String("87654321-dcba-1234-abcd-1a2b3c4d5e6f"),
+			},
+		},
+	}
+
+	ExampleListRoute53TagsOutput = &route53.ListTagsForResourceOutput{
+		ResourceTagSet: &route53.ResourceTagSet{
+			ResourceId:   aws.String("Z1D633PJN98FT9"),
+			ResourceType: aws.String("hostedzone"),
+			Tags: []*route53.Tag{
+				{
+					Key:   aws.String("Key1"),
+					Value: aws.String("Value1"),
+				},
+			},
+		},
+	}
+
+	svcRoute53SetupCalls = map[string]func(*MockRoute53){
+		"ListHostedZonesPages": func(svc *MockRoute53) {
+			svc.On("ListHostedZonesPages", mock.Anything).
+				Return(nil)
+		},
+		"GetHostedZone": func(svc *MockRoute53) {
+			svc.On("GetHostedZone", mock.Anything).
+				Return(ExampleGetHostedZoneOutput, nil)
+		},
+		"ListQueryLoggingConfigsPages": func(svc *MockRoute53) {
+			svc.On("ListQueryLoggingConfigsPages", mock.Anything).
+				Return(nil)
+		},
+		"ListTagsForResource": func(svc *MockRoute53) {
+			svc.On("ListTagsForResource", mock.Anything).
+				Return(ExampleListRoute53TagsOutput, nil)
+		},
+	}
+
+	svcRoute53SetupCallsError = map[string]func(*MockRoute53){
+		"ListHostedZonesPages": func(svc *MockRoute53) {
+			svc.On("ListHostedZonesPages", mock.Anything).
+				Return(errors.New("Route53.ListHostedZonesPages error"))
+		},
+		"GetHostedZone": func(svc *MockRoute53) {
+			svc.On("GetHostedZone", mock.Anything).
+				Return(&route53.GetHostedZoneOutput{},
+					errors.New("Route53.GetHostedZone error"),
+				)
+		},
+		"ListQueryLoggingConfigsPages": func(svc *MockRoute53) {
+			svc.On("ListQueryLoggingConfigsPages", mock.Anything).
+				Return(errors.New("Route53.ListQueryLoggingConfigsPages error"))
+		},
+		"ListTagsForResource": func(svc *MockRoute53) {
+			svc.On("ListTagsForResource", mock.Anything).
+				Return(&route53.ListTagsForResourceOutput{},
+					errors.New("Route53.ListTagsForResource error"),
+				)
+		},
+	}
+
+	MockRoute53ForSetup = &MockRoute53{}
+)
+
+// Route53 mock
+
+// SetupMockRoute53 is used to override the Route53 Client initializer
+func SetupMockRoute53(_ *session.Session, _ *aws.Config) interface{} {
+	return MockRoute53ForSetup
+}
+
+// MockRoute53 is a mock Route53 client
+type MockRoute53 struct {
+	route53iface.Route53API
+	mock.Mock
+}
+
+// BuildMockRoute53Svc builds and returns a MockRoute53 struct
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockRoute53Svc(funcs []string) (mockSvc *MockRoute53) {
+	mockSvc = &MockRoute53{}
+	for _, f := range funcs {
+		svcRoute53SetupCalls[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockRoute53SvcError builds and returns a MockRoute53 struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockRoute53SvcError(funcs []string) (mockSvc *MockRoute53) {
+	mockSvc = &MockRoute53{}
+	for _, f := range funcs {
+		svcRoute53SetupCallsError[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockRoute53SvcAll builds and returns a MockRoute53 struct
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockRoute53SvcAll() (mockSvc *MockRoute53) {
+	mockSvc = &MockRoute53{}
+	for _, f := range svcRoute53SetupCalls {
+		f(mockSvc)
+	}
+	return
+}
+
+// BuildMockRoute53SvcAllError builds and returns a MockRoute53 struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockRoute53SvcAllError() (mockSvc *MockRoute53) {
+	mockSvc = &MockRoute53{}
+	for _, f := range svcRoute53SetupCallsError {
+		f(mockSvc)
+	}
+	return
+}
+
+func (m *MockRoute53) ListHostedZonesPages(
+	in *route53.ListHostedZonesInput,
+	paginationFunction func(*route53.ListHostedZonesOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	paginationFunction(ExampleListHostedZonesOutput, true)
+	return args.Error(0)
+}
+
+func (m *MockRoute53) GetHostedZone(in *route53.GetHostedZoneInput) (*route53.GetHostedZoneOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*route53.GetHostedZoneOutput), args.Error(1)
+}
+
+func (m *MockRoute53) ListQueryLoggingConfigsPages(
+	in *route53.ListQueryLoggingConfigsInput,
+	paginationFunction func(*route53.ListQueryLoggingConfigsOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	paginationFunction(ExampleListQueryLoggingConfigsOutput, true)
+	return args.Error(0)
+}
+
+func (m *MockRoute53) ListTagsForResource(in *route53.ListTagsForResourceInput) (*route53.ListTagsForResourceOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*route53.ListTagsForResourceOutput), args.Error(1)
+}
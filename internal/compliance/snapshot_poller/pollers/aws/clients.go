@@ -19,6 +19,8 @@ package aws
  */
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -28,30 +30,42 @@ import (
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/aws/aws-sdk-go/service/apigateway"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/cloudfront"
 	"github.com/aws/aws-sdk-go/service/cloudtrail"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
 	"github.com/aws/aws-sdk-go/service/configservice"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/ecr"
 	"github.com/aws/aws-sdk-go/service/ecs"
 	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/aws/aws-sdk-go/service/elasticache"
+	"github.com/aws/aws-sdk-go/service/elasticsearchservice"
 	"github.com/aws/aws-sdk-go/service/guardduty"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/kms"
 	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/organizations"
 	"github.com/aws/aws-sdk-go/service/rds"
 	"github.com/aws/aws-sdk-go/service/redshift"
+	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/aws/aws-sdk-go/service/ssm"
 	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/aws/aws-sdk-go/service/waf"
 	"github.com/aws/aws-sdk-go/service/wafregional"
 	lru "github.com/hashicorp/golang-lru"
+	jsoniter "github.com/json-iterator/go"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	sourcemodels "github.com/panther-labs/panther/api/lambda/source/models"
 	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 	"github.com/panther-labs/panther/pkg/awsretry"
 )
@@ -77,53 +91,84 @@ var (
 	// This maps the name we have given to a type of resource to the corresponding AWS name for the
 	// service that the resource type is a part of.
 	typeToIDMapping = map[string]string{
-		awsmodels.AcmCertificateSchema:      acm.ServiceName,
-		awsmodels.CloudFormationStackSchema: cloudformation.ServiceName,
-		awsmodels.CloudTrailSchema:          cloudtrail.ServiceName,
-		awsmodels.CloudWatchLogGroupSchema:  cloudwatchlogs.ServiceName,
-		awsmodels.ConfigServiceSchema:       configservice.ServiceName,
-		awsmodels.DynamoDBTableSchema:       dynamodb.ServiceName,
-		awsmodels.Ec2AmiSchema:              ec2.ServiceName,
-		awsmodels.Ec2InstanceSchema:         ec2.ServiceName,
-		awsmodels.Ec2NetworkAclSchema:       ec2.ServiceName,
-		awsmodels.Ec2SecurityGroupSchema:    ec2.ServiceName,
-		awsmodels.Ec2VolumeSchema:           ec2.ServiceName,
-		awsmodels.Ec2VpcSchema:              ec2.ServiceName,
-		awsmodels.EcsClusterSchema:          ecs.ServiceName,
-		awsmodels.EksClusterSchema:          eks.ServiceName,
+		awsmodels.AcmCertificateSchema:         acm.ServiceName,
+		awsmodels.ApiGatewayRestApiSchema:      apigateway.ServiceName,
+		awsmodels.ApiGatewayV2ApiSchema:        apigateway.ServiceName,
+		awsmodels.CloudFrontDistributionSchema: cloudfront.ServiceName,
+		awsmodels.CloudFormationStackSchema:    cloudformation.ServiceName,
+		awsmodels.CloudTrailSchema:             cloudtrail.ServiceName,
+		awsmodels.CloudWatchLogGroupSchema:     cloudwatchlogs.ServiceName,
+		awsmodels.ConfigServiceSchema:          configservice.ServiceName,
+		awsmodels.DynamoDBTableSchema:          dynamodb.ServiceName,
+		awsmodels.Ec2AmiSchema:                 ec2.ServiceName,
+		awsmodels.Ec2InstanceSchema:            ec2.ServiceName,
+		awsmodels.Ec2NetworkAclSchema:          ec2.ServiceName,
+		awsmodels.Ec2SecurityGroupSchema:       ec2.ServiceName,
+		awsmodels.Ec2VolumeSchema:              ec2.ServiceName,
+		awsmodels.Ec2VpcSchema:                 ec2.ServiceName,
+		awsmodels.EcrRepositorySchema:          ecr.ServiceName,
+		awsmodels.EcsClusterSchema:             ecs.ServiceName,
+		awsmodels.EcsServiceSchema:             ecs.ServiceName,
+		awsmodels.EcsTaskDefinitionSchema:      ecs.ServiceName,
+		awsmodels.EksClusterSchema:             eks.ServiceName,
+		awsmodels.EksNodeGroupSchema:           eks.ServiceName,
 		// For every other service, the service name aligns with how SSM refers to the service. For
-		// just the elb and elbv2 service, this is not the case. AWS just had to do it to 'em.
-		awsmodels.Elbv2LoadBalancerSchema: "elb",
-		awsmodels.GuardDutySchema:         guardduty.ServiceName,
-		awsmodels.IAMGroupSchema:          iam.ServiceName,
-		awsmodels.IAMPolicySchema:         iam.ServiceName,
-		awsmodels.IAMRoleSchema:           iam.ServiceName,
-		awsmodels.IAMRootUserSchema:       iam.ServiceName,
-		awsmodels.IAMUserSchema:           iam.ServiceName,
-		awsmodels.KmsKeySchema:            kms.ServiceName,
-		awsmodels.LambdaFunctionSchema:    lambda.ServiceName,
-		awsmodels.PasswordPolicySchema:    iam.ServiceName,
-		awsmodels.RDSInstanceSchema:       rds.ServiceName,
-		awsmodels.RedshiftClusterSchema:   redshift.ServiceName,
-		awsmodels.S3BucketSchema:          s3.ServiceName,
-		awsmodels.WafRegionalWebAclSchema: waf.ServiceName,
-		awsmodels.WafWebAclSchema:         wafregional.ServiceName,
+		// just the efs, elb and elbv2 services, this is not the case. AWS just had to do it to 'em.
+		awsmodels.EfsFileSystemSchema:                       "efs",
+		awsmodels.ElastiCacheReplicationGroupSchema:         elasticache.ServiceName,
+		awsmodels.ElbLoadBalancerSchema:                     "elb",
+		awsmodels.Elbv2LoadBalancerSchema:                   "elb",
+		awsmodels.GuardDutySchema:                           guardduty.ServiceName,
+		awsmodels.IAMGroupSchema:                            iam.ServiceName,
+		awsmodels.IAMPolicySchema:                           iam.ServiceName,
+		awsmodels.IAMRoleSchema:                             iam.ServiceName,
+		awsmodels.IAMRootUserSchema:                         iam.ServiceName,
+		awsmodels.IAMUserSchema:                             iam.ServiceName,
+		awsmodels.KmsKeySchema:                              kms.ServiceName,
+		awsmodels.LambdaFunctionSchema:                      lambda.ServiceName,
+		awsmodels.OpenSearchDomainSchema:                    elasticsearchservice.ServiceName,
+		awsmodels.OrganizationsAccountSchema:                organizations.ServiceName,
+		awsmodels.OrganizationsDelegatedAdministratorSchema: organizations.ServiceName,
+		awsmodels.OrganizationsOrganizationalUnitSchema:     organizations.ServiceName,
+		awsmodels.OrganizationsServiceControlPolicySchema:   organizations.ServiceName,
+		awsmodels.OrganizationsTagPolicySchema:              organizations.ServiceName,
+		awsmodels.PasswordPolicySchema:                      iam.ServiceName,
+		awsmodels.RDSClusterSchema:                          rds.ServiceName,
+		awsmodels.RDSClusterSnapshotSchema:                  rds.ServiceName,
+		awsmodels.RDSInstanceSchema:                         rds.ServiceName,
+		awsmodels.RDSSnapshotSchema:                         rds.ServiceName,
+		awsmodels.RedshiftClusterSchema:                     redshift.ServiceName,
+		awsmodels.Route53HostedZoneSchema:                   route53.ServiceName,
+		awsmodels.S3BucketSchema:                            s3.ServiceName,
+		awsmodels.SecretsManagerSecretSchema:                secretsmanager.ServiceName,
+		awsmodels.SnsTopicSchema:                            sns.ServiceName,
+		awsmodels.SqsQueueSchema:                            sqs.ServiceName,
+		awsmodels.SsmParameterSchema:                        ssm.ServiceName,
+		awsmodels.WafRegionalWebAclSchema:                   waf.ServiceName,
+		awsmodels.WafWebAclSchema:                           wafregional.ServiceName,
 	}
 
 	// These services do not support regional scans, either because the resource itself is not
 	// regional or because we construct a "Meta" resource that needs the full context of every
 	// resource to be updated.
 	globalOnlyTypes = map[string]struct{}{
-		awsmodels.CloudTrailSchema:     {}, // Has a meta resource
-		awsmodels.ConfigServiceSchema:  {}, // Has a meta resource
-		awsmodels.GuardDutySchema:      {}, // Has a meta resource
-		awsmodels.IAMGroupSchema:       {}, // Global service
-		awsmodels.IAMPolicySchema:      {}, // Global service
-		awsmodels.IAMRoleSchema:        {}, // Global service
-		awsmodels.IAMRootUserSchema:    {}, // Global service
-		awsmodels.IAMUserSchema:        {}, // Global service
-		awsmodels.PasswordPolicySchema: {}, // Global service
-		awsmodels.WafWebAclSchema:      {}, // Global service
+		awsmodels.CloudFrontDistributionSchema:              {}, // Global service
+		awsmodels.CloudTrailSchema:                          {}, // Has a meta resource
+		awsmodels.ConfigServiceSchema:                       {}, // Has a meta resource
+		awsmodels.GuardDutySchema:                           {}, // Has a meta resource
+		awsmodels.IAMGroupSchema:                            {}, // Global service
+		awsmodels.IAMPolicySchema:                           {}, // Global service
+		awsmodels.IAMRoleSchema:                             {}, // Global service
+		awsmodels.IAMRootUserSchema:                         {}, // Global service
+		awsmodels.IAMUserSchema:                             {}, // Global service
+		awsmodels.OrganizationsAccountSchema:                {}, // Global service
+		awsmodels.OrganizationsDelegatedAdministratorSchema: {}, // Global service
+		awsmodels.OrganizationsOrganizationalUnitSchema:     {}, // Global service
+		awsmodels.OrganizationsServiceControlPolicySchema:   {}, // Global service
+		awsmodels.OrganizationsTagPolicySchema:              {}, // Global service
+		awsmodels.PasswordPolicySchema:                      {}, // Global service
+		awsmodels.Route53HostedZoneSchema:                   {}, // Global service
+		awsmodels.WafWebAclSchema:                           {}, // Global service
 	}
 
 	// Used to cache region & account specific AWS clients
@@ -138,6 +183,8 @@ type clientKey struct {
 	IntegrationID string
 	Service       string
 	Region        string
+	// Hash of the role the client was created with, so clients are not reused once it changes
+	RoleConfig string
 }
 
 type cachedClient struct {
@@ -248,6 +295,7 @@ func getClient(pollerInput *awsmodels.ResourcePollerInput,
 		IntegrationID: *pollerInput.IntegrationID,
 		Service:       service,
 		Region:        region,
+		RoleConfig:    roleConfigHash(pollerInput),
 	}
 
 	// Return the cached client
@@ -286,7 +334,21 @@ func getClient(pollerInput *awsmodels.ResourcePollerInput,
 	return client, nil
 }
 
-//  assumes an IAM role associated with an AWS Snapshot Integration.
+// roleConfigHash identifies the role assumed for a scan and how it is assumed.
+func roleConfigHash(pollerInput *awsmodels.ResourcePollerInput) string {
+	// The standard library config sorts map keys, so the session tags hash the same way every time
+	config, err := jsoniter.ConfigCompatibleWithStandardLibrary.Marshal(struct {
+		AuthSource *string
+		AuditRole  *sourcemodels.AuditRoleConfig
+	}{pollerInput.AuthSource, pollerInput.AuditRole})
+	if err != nil {
+		panic(err)
+	}
+	hash := sha256.Sum256(config)
+	return hex.EncodeToString(hash[:])
+}
+
+// assumes an IAM role associated with an AWS Snapshot Integration.
 func assumeRole(pollerInput *awsmodels.ResourcePollerInput, sess *session.Session) *credentials.Credentials {
 	zap.L().Debug("assuming role", zap.String("roleArn", *pollerInput.AuthSource))
 
@@ -294,11 +356,16 @@ func assumeRole(pollerInput *awsmodels.ResourcePollerInput, sess *session.Sessio
 		panic("must pass non-nil authSource to AssumeRole")
 	}
 
-	creds := stscreds.NewCredentials(
+	auditRole := pollerInput.AuditRole
+	if auditRole == nil {
+		auditRole = &sourcemodels.AuditRoleConfig{RoleARN: *pollerInput.AuthSource}
+	}
+
+	creds := auditRole.Credentials(
 		sess.Copy(aws.NewConfig().WithSTSRegionalEndpoint(endpoints.RegionalSTSEndpoint)),
-		*pollerInput.AuthSource,
 		func(p *stscreds.AssumeRoleProvider) {
 			p.Duration = assumeRoleDuration
+			p.RoleSessionName = awsmodels.SnapshotPollerSessionName
 		},
 	)
 
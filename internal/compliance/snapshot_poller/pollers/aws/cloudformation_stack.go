@@ -29,6 +29,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/aws/aws-sdk-go/service/cloudformation/cloudformationiface"
 	"github.com/cenkalti/backoff/v4"
+	jsoniter "github.com/json-iterator/go"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
@@ -101,11 +102,16 @@ func PollCloudFormationStack(
 				return nil, err
 			}
 		}
+		// Rate limited scans are re-queued by the caller
+		if isThrottlingError(err) {
+			return nil, err
+		}
 		return nil, nil
 	}
 
+	var detection *cloudformation.DescribeStackDriftDetectionStatusOutput
 	if driftID != nil {
-		waitForStackDriftDetection(cfClient, driftID)
+		detection = waitForStackDriftDetection(cfClient, driftID)
 	}
 
 	stack, err := getStack(cfClient, stackName)
@@ -113,7 +119,7 @@ func PollCloudFormationStack(
 		return nil, err
 	}
 
-	snapshot, err := buildCloudFormationStackSnapshot(cfClient, stack)
+	snapshot, err := buildCloudFormationStackSnapshot(cfClient, stack, detection)
 	if err != nil || snapshot == nil {
 		return nil, err
 	}
@@ -207,10 +213,50 @@ func describeStackResourceDrifts(
 	return
 }
 
+// buildResourceDrifts maps the logical ID of each stack resource to its drift status and property differences
+func buildResourceDrifts(drifts []*cloudformation.StackResourceDrift) map[string]*awsmodels.CloudFormationResourceDrift {
+	resourceDrifts := make(map[string]*awsmodels.CloudFormationResourceDrift, len(drifts))
+	for _, drift := range drifts {
+		if drift == nil || drift.LogicalResourceId == nil {
+			continue
+		}
+		resourceDrift := &awsmodels.CloudFormationResourceDrift{
+			PhysicalResourceId: drift.PhysicalResourceId,
+			ResourceType:       drift.ResourceType,
+			DriftStatus:        drift.StackResourceDriftStatus,
+			Timestamp:          drift.Timestamp,
+		}
+		for _, difference := range drift.PropertyDifferences {
+			resourceDrift.PropertyDifferences = append(resourceDrift.PropertyDifferences, &awsmodels.CloudFormationPropertyDifference{
+				PropertyPath:   difference.PropertyPath,
+				DifferenceType: difference.DifferenceType,
+				ExpectedValue:  propertyValue(difference.ExpectedValue),
+				ActualValue:    propertyValue(difference.ActualValue),
+			})
+		}
+		resourceDrifts[*drift.LogicalResourceId] = resourceDrift
+	}
+	return resourceDrifts
+}
+
+// propertyValue decodes a property value reported by drift detection, which is JSON for objects,
+// lists and numbers but may also be a plain string
+func propertyValue(value *string) interface{} {
+	if value == nil {
+		return nil
+	}
+	var decoded interface{}
+	if err := jsoniter.UnmarshalFromString(*value, &decoded); err != nil {
+		return *value
+	}
+	return decoded
+}
+
 // buildCloudFormationStackSnapshot returns a complete snapshot of CloudFormation stack
 func buildCloudFormationStackSnapshot(
 	cloudformationSvc cloudformationiface.CloudFormationAPI,
 	stack *cloudformation.Stack,
+	detection *cloudformation.DescribeStackDriftDetectionStatusOutput,
 ) (*awsmodels.CloudFormationStack, error) {
 
 	if stack == nil {
@@ -250,28 +296,50 @@ func buildCloudFormationStackSnapshot(
 
 	stackSnapshot.Tags = utils.ParseTagSlice(stack.Tags)
 
+	if detection != nil {
+		stackSnapshot.DriftDetection = &awsmodels.CloudFormationDriftDetection{
+			DetectionStatus:           detection.DetectionStatus,
+			DetectionStatusReason:     detection.DetectionStatusReason,
+			DriftedStackResourceCount: detection.DriftedStackResourceCount,
+			StackDriftDetectionId:     detection.StackDriftDetectionId,
+			StackDriftStatus:          detection.StackDriftStatus,
+			Timestamp:                 detection.Timestamp,
+		}
+	}
+
 	var err error
 	stackSnapshot.Drifts, err = describeStackResourceDrifts(cloudformationSvc, stack.StackId)
 	if err != nil {
 		return nil, err
 	}
+	stackSnapshot.ResourceDrifts = buildResourceDrifts(stackSnapshot.Drifts)
 
 	return stackSnapshot, nil
 }
 
-// waitForStackDriftDetection blocks and only returns when a given stack drift detection is complete
-func waitForStackDriftDetection(svc cloudformationiface.CloudFormationAPI, driftID *string) {
+// waitForStackDriftDetection blocks and only returns when a given stack drift detection is complete.
+//
+// The final status of the drift detection is returned, or nil if it did not complete in time.
+func waitForStackDriftDetection(
+	svc cloudformationiface.CloudFormationAPI, driftID *string) *cloudformation.DescribeStackDriftDetectionStatusOutput {
+
 	statusIn := &cloudformation.DescribeStackDriftDetectionStatusInput{
 		StackDriftDetectionId: driftID,
 	}
+	var detection *cloudformation.DescribeStackDriftDetectionStatusOutput
 	detectDriftStatus := func() error {
 		driftOut, driftErr := svc.DescribeStackDriftDetectionStatus(statusIn)
 		if driftErr != nil {
+			// Polling the status is rate limited too, slow down and keep waiting
+			if isThrottlingError(driftErr) {
+				return driftErr
+			}
 			return backoff.Permanent(driftErr)
 		}
-		if *driftOut.DetectionStatus == "DETECTION_IN_PROGRESS" {
+		if aws.StringValue(driftOut.DetectionStatus) == "DETECTION_IN_PROGRESS" {
 			return errors.New("stack detection in progress")
 		}
+		detection = driftOut
 		return nil
 	}
 
@@ -280,7 +348,27 @@ func waitForStackDriftDetection(svc cloudformationiface.CloudFormationAPI, drift
 	backoffErr := backoff.Retry(detectDriftStatus, expBackoff)
 	if backoffErr != nil {
 		utils.LogAWSError("CloudFormation.DescribeStackDriftDetectionStatus", backoffErr)
+		return nil
+	}
+	return detection
+}
+
+// requeueStackScans sends one scan request for all the given stacks
+func requeueStackScans(pollerInput *awsmodels.ResourcePollerInput, stackIDs []*string, delay int64) error {
+	scanRequest := pollermodels.ScanMsg{}
+	for _, stackID := range stackIDs {
+		scanRequest.Entries = append(scanRequest.Entries, &pollermodels.ScanEntry{
+			AWSAccountID:            &pollerInput.AuthSourceParsedARN.AccountID,
+			IntegrationID:           pollerInput.IntegrationID,
+			ResourceID:              stackID,
+			ResourceType:            aws.String(awsmodels.CloudFormationStackSchema),
+			RegionIgnoreList:        pollerInput.RegionIgnoreList,
+			ResourceRegexIgnoreList: pollerInput.ResourceRegexIgnoreList,
+			ResourceTypeIgnoreList:  pollerInput.ResourceTypeIgnoreList,
+			AuditRole:               pollerInput.AuditRole,
+		})
 	}
+	return utils.Requeue(scanRequest, delay)
 }
 
 // PollCloudFormationStacks gathers information on each CloudFormation Stack for an AWS account.
@@ -302,7 +390,7 @@ func PollCloudFormationStacks(pollerInput *awsmodels.ResourcePollerInput) ([]api
 	// List of stack drift detection statuses
 	stackDriftDetectionIds := make(map[string]*string)
 	ignoredIds := make(map[string]bool)
-	var requeueIds []*string
+	var requeueIds, rateLimitedIds []*string
 
 	// Initiate the stack drift detections
 	for _, stack := range stacks {
@@ -320,6 +408,9 @@ func PollCloudFormationStacks(pollerInput *awsmodels.ResourcePollerInput) ([]api
 			if err.Error() == requeueRequiredError {
 				// The drift detection did not work, and we must re-queue a scan for this message
 				requeueIds = append(requeueIds, stack.StackId)
+			} else if isThrottlingError(err) {
+				// Drift detection is rate limited, scan this stack again once the limit is lifted
+				rateLimitedIds = append(rateLimitedIds, stack.StackId)
 			} else {
 				// To be in line with the policy of "whenever a resource fails to scan cancel the scan",
 				// we should technically exit at this point. But this poller is so finicky that I worry
@@ -337,27 +428,16 @@ func PollCloudFormationStacks(pollerInput *awsmodels.ResourcePollerInput) ([]api
 
 	// Construct and send one re-scan request for all the stacks that need to be re-scanned
 	if len(requeueIds) > 0 {
-		scanRequest := pollermodels.ScanMsg{}
-		for _, stackId := range requeueIds {
-			scanRequest.Entries = append(scanRequest.Entries, &pollermodels.ScanEntry{
-				AWSAccountID:            &pollerInput.AuthSourceParsedARN.AccountID,
-				IntegrationID:           pollerInput.IntegrationID,
-				ResourceID:              stackId,
-				ResourceType:            aws.String(awsmodels.CloudFormationStackSchema),
-				RegionIgnoreList:        pollerInput.RegionIgnoreList,
-				ResourceRegexIgnoreList: pollerInput.ResourceRegexIgnoreList,
-				ResourceTypeIgnoreList:  pollerInput.ResourceTypeIgnoreList,
-			})
-		}
-		if err = utils.Requeue(scanRequest, driftDetectionRequeueDelaySeconds); err != nil {
+		if err = requeueStackScans(pollerInput, requeueIds, driftDetectionRequeueDelaySeconds); err != nil {
 			return nil, nil, err
 		}
 	}
 
 	// Wait for all stack drift detections to be complete
 	// TODO: Parallelize this and begin the next step for the stacks that complete
-	for _, driftID := range stackDriftDetectionIds {
-		waitForStackDriftDetection(cloudformationSvc, driftID)
+	stackDriftDetections := make(map[string]*cloudformation.DescribeStackDriftDetectionStatusOutput)
+	for stackID, driftID := range stackDriftDetectionIds {
+		stackDriftDetections[stackID] = waitForStackDriftDetection(cloudformationSvc, driftID)
 	}
 
 	// Build the stack snapshots
@@ -375,11 +455,20 @@ func PollCloudFormationStacks(pollerInput *awsmodels.ResourcePollerInput) ([]api
 		// Additionally, we want to update the stack drift information post stack drift detection
 		// completion so we need to make a describe stack call anyways.
 		fullStack, err := getStack(cloudformationSvc, *stack.StackName)
-		if err != nil {
-			return nil, nil, err
+		if err == nil && fullStack == nil {
+			// The stack was deleted since it was listed
+			continue
+		}
+		var cfnStackSnapshot *awsmodels.CloudFormationStack
+		if err == nil {
+			cfnStackSnapshot, err = buildCloudFormationStackSnapshot(
+				cloudformationSvc, fullStack, stackDriftDetections[*stack.StackId])
 		}
-		cfnStackSnapshot, err := buildCloudFormationStackSnapshot(cloudformationSvc, fullStack)
 		if err != nil {
+			if isThrottlingError(err) {
+				rateLimitedIds = append(rateLimitedIds, stack.StackId)
+				continue
+			}
 			return nil, nil, err
 		}
 
@@ -396,5 +485,16 @@ func PollCloudFormationStacks(pollerInput *awsmodels.ResourcePollerInput) ([]api
 		})
 	}
 
+	// Rate limited stacks are scanned again individually after the same delay as other rate limited scans
+	if len(rateLimitedIds) > 0 {
+		zap.L().Debug("CloudFormation: stack scans rate limited", zap.Int("stacks", len(rateLimitedIds)))
+		err = requeueRateLimited(aws.StringValueSlice(rateLimitedIds), func(delay int64) error {
+			return requeueStackScans(pollerInput, rateLimitedIds, delay)
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	return resources, marker, nil
 }
@@ -21,8 +21,11 @@ package aws
 import (
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
@@ -108,6 +111,7 @@ func TestCloudFormationStackBuildSnapshot(t *testing.T) {
 	certSnapshot, err := buildCloudFormationStackSnapshot(
 		mockSvc,
 		awstest.ExampleDescribeStacks.Stacks[0],
+		nil,
 	)
 
 	assert.NoError(t, err)
@@ -115,12 +119,77 @@ func TestCloudFormationStackBuildSnapshot(t *testing.T) {
 	assert.NotEmpty(t, certSnapshot.Drifts)
 }
 
+func TestCloudFormationStackBuildSnapshotDrift(t *testing.T) {
+	mockSvc := awstest.BuildMockCloudFormationSvcAll()
+
+	snapshot, err := buildCloudFormationStackSnapshot(
+		mockSvc,
+		awstest.ExampleDescribeStacks.Stacks[0],
+		awstest.ExampleDescribeStackDriftDetectionStatus,
+	)
+	require.NoError(t, err)
+
+	require.NotNil(t, snapshot.DriftDetection)
+	assert.Equal(t, "DRIFTED", *snapshot.DriftDetection.StackDriftStatus)
+	assert.Equal(t, "DETECTION_COMPLETE", *snapshot.DriftDetection.DetectionStatus)
+	assert.Equal(t, int64(1), *snapshot.DriftDetection.DriftedStackResourceCount)
+
+	require.Len(t, snapshot.ResourceDrifts, 1)
+	drift := snapshot.ResourceDrifts["Administrators"]
+	require.NotNil(t, drift)
+	assert.Equal(t, "MODIFIED", *drift.DriftStatus)
+	assert.Equal(t, "AWS::IAM::Role", *drift.ResourceType)
+	assert.Equal(t, "PantherDevNickAdministrator", *drift.PhysicalResourceId)
+	assert.Equal(t, []*awsmodels.CloudFormationPropertyDifference{
+		{
+			PropertyPath:   aws.String("/AssumeRolePolicyDocument/Statement/0/Principal/AWS"),
+			DifferenceType: aws.String("NOT_EQUAL"),
+			ExpectedValue:  float64(111111111111),
+			ActualValue:    "arn:aws:iam::111111111111:root",
+		},
+	}, drift.PropertyDifferences)
+}
+
+func TestCloudFormationStackPropertyValue(t *testing.T) {
+	assert.Nil(t, propertyValue(nil))
+	assert.Equal(t, "Enabled", propertyValue(aws.String("Enabled")))
+	assert.Equal(t, true, propertyValue(aws.String("true")))
+	assert.Equal(t, []interface{}{"10.0.0.0/16"}, propertyValue(aws.String(`["10.0.0.0/16"]`)))
+	assert.Equal(t, map[string]interface{}{"Key": "env"}, propertyValue(aws.String(`{"Key":"env"}`)))
+}
+
+func TestCloudFormationStackDetectStackDriftThrottled(t *testing.T) {
+	mockSvc := &awstest.MockCloudFormation{}
+	mockSvc.On("DetectStackDrift", mock.Anything).Return(&cloudformation.DetectStackDriftOutput{},
+		awserr.New("Throttling", "Rate exceeded", nil))
+
+	out, err := detectStackDrift(mockSvc, awstest.ExampleDescribeStacks.Stacks[0].StackId)
+	require.Error(t, err)
+	assert.True(t, isThrottlingError(err))
+	assert.Nil(t, out)
+}
+
+func TestCloudFormationStackWaitForDriftDetection(t *testing.T) {
+	mockSvc := awstest.BuildMockCloudFormationSvc([]string{"DescribeStackDriftDetectionStatus"})
+
+	out := waitForStackDriftDetection(mockSvc, awstest.ExampleDetectStackDrift.StackDriftDetectionId)
+	assert.Equal(t, awstest.ExampleDescribeStackDriftDetectionStatus, out)
+}
+
+func TestCloudFormationStackWaitForDriftDetectionError(t *testing.T) {
+	mockSvc := awstest.BuildMockCloudFormationSvcError([]string{"DescribeStackDriftDetectionStatus"})
+
+	out := waitForStackDriftDetection(mockSvc, awstest.ExampleDetectStackDrift.StackDriftDetectionId)
+	assert.Nil(t, out)
+}
+
 func TestCloudFormationStackBuildSnapshotError(t *testing.T) {
 	mockSvc := awstest.BuildMockCloudFormationSvcAllError()
 
 	certSnapshot, err := buildCloudFormationStackSnapshot(
 		mockSvc,
 		awstest.ExampleDescribeStacks.Stacks[0],
+		nil,
 	)
 
 	assert.Error(t, err)
@@ -144,6 +213,10 @@ func TestCloudFormationStackPoller(t *testing.T) {
 	assert.Nil(t, marker)
 	assert.Equal(t, *awstest.ExampleDescribeStacks.Stacks[0].StackId, resources[0].ID)
 	assert.NotEmpty(t, resources)
+	snapshot := resources[0].Attributes.(*awsmodels.CloudFormationStack)
+	require.NotNil(t, snapshot.DriftDetection)
+	assert.Equal(t, "DETECTION_COMPLETE", *snapshot.DriftDetection.DetectionStatus)
+	assert.Contains(t, snapshot.ResourceDrifts, "Administrators")
 }
 
 func TestCloudFormationStackPollerError(t *testing.T) {
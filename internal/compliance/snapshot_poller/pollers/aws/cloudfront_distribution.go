@@ -0,0 +1,226 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudfront"
+	"github.com/aws/aws-sdk-go/service/cloudfront/cloudfrontiface"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// Set as variables to be overridden in testing
+var (
+	CloudFrontClientFunc = setupCloudFrontClient
+)
+
+func setupCloudFrontClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return cloudfront.New(sess, cfg)
+}
+
+func getCloudFrontClient(pollerResourceInput *awsmodels.ResourcePollerInput, region string) (cloudfrontiface.CloudFrontAPI, error) {
+	client, err := getClient(pollerResourceInput, CloudFrontClientFunc, "cloudfront", region)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.(cloudfrontiface.CloudFrontAPI), nil
+}
+
+// PollCloudFrontDistribution polls a single CloudFront distribution resource
+func PollCloudFrontDistribution(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	_ *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getCloudFrontClient(pollerResourceInput, defaultRegion)
+	if err != nil {
+		return nil, err
+	}
+
+	distributionID := strings.TrimPrefix(resourceARN.Resource, "distribution/")
+	snapshot, err := buildCloudFrontDistributionSnapshot(client, aws.String(distributionID))
+	if err != nil || snapshot == nil {
+		return nil, err
+	}
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(awsmodels.GlobalRegion)
+	return snapshot, nil
+}
+
+// listCloudFrontDistributions returns a summary of all CloudFront distributions in the account
+func listCloudFrontDistributions(cloudfrontSvc cloudfrontiface.CloudFrontAPI, nextMarker *string) (
+	distributions []*cloudfront.DistributionSummary, marker *string, err error) {
+
+	err = cloudfrontSvc.ListDistributionsPages(&cloudfront.ListDistributionsInput{
+		Marker:   nextMarker,
+		MaxItems: aws.Int64(int64(defaultBatchSize)),
+	},
+		func(page *cloudfront.ListDistributionsOutput, lastPage bool) bool {
+			return cloudFrontDistributionIterator(page, &distributions, &marker)
+		})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "CloudFront.ListDistributionsPages")
+	}
+	return
+}
+
+func cloudFrontDistributionIterator(
+	page *cloudfront.ListDistributionsOutput, distributions *[]*cloudfront.DistributionSummary, marker **string) bool {
+
+	if page.DistributionList == nil {
+		return false
+	}
+	*distributions = append(*distributions, page.DistributionList.Items...)
+	*marker = page.DistributionList.NextMarker
+	return len(*distributions) < defaultBatchSize
+}
+
+// getCloudFrontDistribution returns the full configuration of a CloudFront distribution, or nil if
+// it no longer exists
+func getCloudFrontDistribution(cloudfrontSvc cloudfrontiface.CloudFrontAPI, id *string) (*cloudfront.Distribution, error) {
+	out, err := cloudfrontSvc.GetDistribution(&cloudfront.GetDistributionInput{Id: id})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == cloudfront.ErrCodeNoSuchDistribution {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", aws.StringValue(id)),
+				zap.String("resourceType", awsmodels.CloudFrontDistributionSchema))
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "CloudFront.GetDistribution: %s", aws.StringValue(id))
+	}
+	return out.Distribution, nil
+}
+
+// listCloudFrontTags returns the tags of a CloudFront distribution
+func listCloudFrontTags(cloudfrontSvc cloudfrontiface.CloudFrontAPI, distributionARN *string) ([]*cloudfront.Tag, error) {
+	out, err := cloudfrontSvc.ListTagsForResource(&cloudfront.ListTagsForResourceInput{Resource: distributionARN})
+	if err != nil {
+		return nil, errors.Wrapf(err, "CloudFront.ListTagsForResource: %s", aws.StringValue(distributionARN))
+	}
+	if out.Tags == nil {
+		return nil, nil
+	}
+	return out.Tags.Items, nil
+}
+
+// buildCloudFrontDistributionSnapshot makes all the calls to build up a snapshot of a given
+// CloudFront distribution
+func buildCloudFrontDistributionSnapshot(
+	cloudfrontSvc cloudfrontiface.CloudFrontAPI, id *string) (*awsmodels.CloudFrontDistribution, error) {
+
+	distribution, err := getCloudFrontDistribution(cloudfrontSvc, id)
+	if err != nil || distribution == nil || distribution.DistributionConfig == nil {
+		return nil, err
+	}
+	config := distribution.DistributionConfig
+
+	snapshot := &awsmodels.CloudFrontDistribution{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   distribution.ARN,
+			ResourceType: aws.String(awsmodels.CloudFrontDistributionSchema),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN: distribution.ARN,
+			ID:  distribution.Id,
+		},
+		ActiveTrustedKeyGroups:        distribution.ActiveTrustedKeyGroups,
+		ActiveTrustedSigners:          distribution.ActiveTrustedSigners,
+		DomainName:                    distribution.DomainName,
+		InProgressInvalidationBatches: distribution.InProgressInvalidationBatches,
+		LastModifiedTime:              distribution.LastModifiedTime,
+		Status:                        distribution.Status,
+		Aliases:                       config.Aliases,
+		CacheBehaviors:                config.CacheBehaviors,
+		Comment:                       config.Comment,
+		CustomErrorResponses:          config.CustomErrorResponses,
+		DefaultCacheBehavior:          config.DefaultCacheBehavior,
+		DefaultRootObject:             config.DefaultRootObject,
+		Enabled:                       config.Enabled,
+		HttpVersion:                   config.HttpVersion,
+		IsIPV6Enabled:                 config.IsIPV6Enabled,
+		Logging:                       config.Logging,
+		OriginGroups:                  config.OriginGroups,
+		Origins:                       config.Origins,
+		PriceClass:                    config.PriceClass,
+		Restrictions:                  config.Restrictions,
+		ViewerCertificate:             config.ViewerCertificate,
+		WebACLId:                      config.WebACLId,
+	}
+
+	tags, err := listCloudFrontTags(cloudfrontSvc, distribution.ARN)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.Tags = utils.ParseTagSlice(tags)
+
+	return snapshot, nil
+}
+
+// PollCloudFrontDistributions gathers information on each CloudFront distribution for an AWS account.
+func PollCloudFrontDistributions(pollerInput *awsmodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	zap.L().Debug("starting CloudFront Distribution resource poller")
+
+	cloudfrontSvc, err := getCloudFrontClient(pollerInput, defaultRegion)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Start with generating a list of all distributions
+	distributions, marker, err := listCloudFrontDistributions(cloudfrontSvc, pollerInput.NextPageToken)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "region: global")
+	}
+
+	resources := make([]apimodels.AddResourceEntry, 0, len(distributions))
+	for _, distribution := range distributions {
+		distributionSnapshot, err := buildCloudFrontDistributionSnapshot(cloudfrontSvc, distribution.Id)
+		if err != nil {
+			return nil, nil, err
+		}
+		if distributionSnapshot == nil {
+			continue
+		}
+		distributionSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+		distributionSnapshot.Region = aws.String(awsmodels.GlobalRegion)
+
+		resources = append(resources, apimodels.AddResourceEntry{
+			Attributes:      distributionSnapshot,
+			ID:              *distributionSnapshot.ResourceID,
+			IntegrationID:   *pollerInput.IntegrationID,
+			IntegrationType: integrationType,
+			Type:            awsmodels.CloudFrontDistributionSchema,
+		})
+	}
+
+	return resources, marker, nil
+}
@@ -0,0 +1,180 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudfront"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestCloudFrontDistributionList(t *testing.T) {
+	mockSvc := awstest.BuildMockCloudFrontSvc([]string{"ListDistributionsPages"})
+
+	out, marker, err := listCloudFrontDistributions(mockSvc, nil)
+	assert.NotEmpty(t, out)
+	assert.Nil(t, marker)
+	assert.NoError(t, err)
+}
+
+// Test the iterator works on consecutive pages but stops at max page size
+func TestCloudFrontDistributionListIterator(t *testing.T) {
+	var distributions []*cloudfront.DistributionSummary
+	var marker *string
+
+	cont := cloudFrontDistributionIterator(awstest.ExampleListDistributionsOutput, &distributions, &marker)
+	assert.True(t, cont)
+	assert.Nil(t, marker)
+	assert.Len(t, distributions, 1)
+
+	for i := 1; i < 50; i++ {
+		cont = cloudFrontDistributionIterator(awstest.ExampleListDistributionsOutputContinue, &distributions, &marker)
+		assert.True(t, cont)
+		assert.NotNil(t, marker)
+		assert.Len(t, distributions, 1+i*2)
+	}
+
+	cont = cloudFrontDistributionIterator(awstest.ExampleListDistributionsOutputContinue, &distributions, &marker)
+	assert.False(t, cont)
+	assert.NotNil(t, marker)
+	assert.Len(t, distributions, 101)
+}
+
+func TestCloudFrontDistributionListError(t *testing.T) {
+	mockSvc := awstest.BuildMockCloudFrontSvcError([]string{"ListDistributionsPages"})
+
+	out, marker, err := listCloudFrontDistributions(mockSvc, nil)
+	assert.Nil(t, out)
+	assert.Nil(t, marker)
+	assert.Error(t, err)
+}
+
+func TestCloudFrontDistributionGetNotFound(t *testing.T) {
+	mockSvc := &awstest.MockCloudFront{}
+	mockSvc.On("GetDistribution", mock.Anything).Return(
+		&cloudfront.GetDistributionOutput{},
+		awserr.New(cloudfront.ErrCodeNoSuchDistribution, "not found", nil),
+	)
+
+	out, err := getCloudFrontDistribution(mockSvc, awstest.ExampleDistributionID)
+	require.NoError(t, err)
+	assert.Nil(t, out)
+}
+
+func TestCloudFrontDistributionBuildSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockCloudFrontSvcAll()
+
+	snapshot, err := buildCloudFrontDistributionSnapshot(mockSvc, awstest.ExampleDistributionID)
+	require.NoError(t, err)
+	assert.Equal(t, awstest.ExampleDistributionARN, snapshot.ResourceID)
+	assert.Equal(t, awstest.ExampleDistributionID, snapshot.ID)
+	assert.Equal(t, aws.Bool(true), snapshot.Logging.Enabled)
+	assert.Equal(t, aws.String("TLSv1.2_2019"), snapshot.ViewerCertificate.MinimumProtocolVersion)
+	assert.NotNil(t, snapshot.WebACLId)
+	assert.Equal(t, map[string]*string{"Key1": aws.String("Value1")}, snapshot.Tags)
+}
+
+func TestCloudFrontDistributionBuildSnapshotErrors(t *testing.T) {
+	mockSvc := awstest.BuildMockCloudFrontSvcError([]string{"ListTagsForResource"})
+	mockSvc.On("GetDistribution", mock.Anything).Return(awstest.ExampleGetDistributionOutput, nil)
+
+	snapshot, err := buildCloudFrontDistributionSnapshot(mockSvc, awstest.ExampleDistributionID)
+	assert.Error(t, err)
+	assert.Nil(t, snapshot)
+
+	mockSvc = awstest.BuildMockCloudFrontSvcAllError()
+	snapshot, err = buildCloudFrontDistributionSnapshot(mockSvc, awstest.ExampleDistributionID)
+	assert.Error(t, err)
+	assert.Nil(t, snapshot)
+}
+
+func TestCloudFrontDistributionPollSingle(t *testing.T) {
+	resetCache()
+	mockSvc := awstest.BuildMockCloudFrontSvc([]string{"GetDistribution", "ListTagsForResource"})
+	awstest.MockCloudFrontForSetup = mockSvc
+
+	CloudFrontClientFunc = awstest.SetupMockCloudFront
+
+	resourceARN, err := arn.Parse(*awstest.ExampleDistributionARN)
+	require.NoError(t, err)
+	snapshot, err := PollCloudFrontDistribution(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Timestamp:           &awstest.ExampleTime,
+	}, resourceARN, &pollermodels.ScanEntry{ResourceID: awstest.ExampleDistributionARN})
+
+	require.NoError(t, err)
+	distribution := snapshot.(*awsmodels.CloudFrontDistribution)
+	assert.Equal(t, aws.String("123456789012"), distribution.AccountID)
+	assert.Equal(t, aws.String(awsmodels.GlobalRegion), distribution.Region)
+	mockSvc.AssertCalled(t, "GetDistribution", &cloudfront.GetDistributionInput{Id: awstest.ExampleDistributionID})
+}
+
+func TestCloudFrontDistributionPoller(t *testing.T) {
+	resetCache()
+	awstest.MockCloudFrontForSetup = awstest.BuildMockCloudFrontSvcAll()
+
+	CloudFrontClientFunc = awstest.SetupMockCloudFront
+
+	resources, marker, err := PollCloudFrontDistributions(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              aws.String(awsmodels.GlobalRegion),
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, awsmodels.CloudFrontDistributionSchema, resources[0].Type)
+	assert.Equal(t, *awstest.ExampleDistributionARN, resources[0].ID)
+	assert.Nil(t, marker)
+}
+
+func TestCloudFrontDistributionPollerError(t *testing.T) {
+	resetCache()
+	awstest.MockCloudFrontForSetup = awstest.BuildMockCloudFrontSvcAllError()
+
+	CloudFrontClientFunc = awstest.SetupMockCloudFront
+
+	resources, marker, err := PollCloudFrontDistributions(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              aws.String(awsmodels.GlobalRegion),
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	for _, event := range resources {
+		assert.Nil(t, event.Attributes)
+	}
+	assert.Nil(t, marker)
+	assert.Error(t, err)
+}
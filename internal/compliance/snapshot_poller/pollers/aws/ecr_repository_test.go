@@ -0,0 +1,262 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestEcrRepositoryList(t *testing.T) {
+	mockSvc := awstest.BuildMockEcrSvc([]string{"DescribeRepositoriesPages"})
+
+	out, marker, err := listRepositories(mockSvc, nil)
+	assert.NotEmpty(t, out)
+	assert.Nil(t, marker)
+	assert.NoError(t, err)
+}
+
+// Test the iterator works on consecutive pages but stops at max page size
+func TestEcrRepositoryListIterator(t *testing.T) {
+	var repositories []*ecr.Repository
+	var marker *string
+
+	cont := ecrRepositoryIterator(awstest.ExampleDescribeRepositoriesOutput, &repositories, &marker)
+	assert.True(t, cont)
+	assert.Nil(t, marker)
+	assert.Len(t, repositories, 1)
+
+	for i := 1; i < 50; i++ {
+		cont = ecrRepositoryIterator(awstest.ExampleDescribeRepositoriesOutputContinue, &repositories, &marker)
+		assert.True(t, cont)
+		assert.NotNil(t, marker)
+		assert.Len(t, repositories, 1+i*2)
+	}
+
+	cont = ecrRepositoryIterator(awstest.ExampleDescribeRepositoriesOutputContinue, &repositories, &marker)
+	assert.False(t, cont)
+	assert.NotNil(t, marker)
+	assert.Len(t, repositories, 101)
+}
+
+func TestEcrRepositoryListError(t *testing.T) {
+	mockSvc := awstest.BuildMockEcrSvcError([]string{"DescribeRepositoriesPages"})
+
+	out, marker, err := listRepositories(mockSvc, nil)
+	assert.Nil(t, out)
+	assert.Nil(t, marker)
+	assert.Error(t, err)
+}
+
+func TestEcrRepositoryDescribe(t *testing.T) {
+	mockSvc := awstest.BuildMockEcrSvc([]string{"DescribeRepositories"})
+
+	out, err := describeRepository(mockSvc, arn.ARN{
+		AccountID: "123456789012",
+		Resource:  "repository/example-repository",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, awstest.ExampleEcrRepository, out)
+}
+
+func TestEcrRepositoryDescribeNotFound(t *testing.T) {
+	mockSvc := &awstest.MockEcr{}
+	mockSvc.On("DescribeRepositories", mock.Anything).Return(
+		&ecr.DescribeRepositoriesOutput{},
+		awserr.New(ecr.ErrCodeRepositoryNotFoundException, "not found", nil),
+	)
+
+	out, err := describeRepository(mockSvc, arn.ARN{Resource: "repository/missing"})
+	require.NoError(t, err)
+	assert.Nil(t, out)
+}
+
+func TestEcrRepositoryDescribeError(t *testing.T) {
+	mockSvc := awstest.BuildMockEcrSvcError([]string{"DescribeRepositories"})
+
+	out, err := describeRepository(mockSvc, arn.ARN{Resource: "repository/example-repository"})
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestEcrRepositoryGetPolicy(t *testing.T) {
+	mockSvc := awstest.BuildMockEcrSvc([]string{"GetRepositoryPolicy"})
+
+	out, err := getRepositoryPolicy(mockSvc, awstest.ExampleEcrRepository)
+	require.NoError(t, err)
+	assert.NotEmpty(t, out)
+}
+
+func TestEcrRepositoryGetPolicyNotFound(t *testing.T) {
+	mockSvc := &awstest.MockEcr{}
+	mockSvc.On("GetRepositoryPolicy", mock.Anything).Return(
+		&ecr.GetRepositoryPolicyOutput{},
+		awserr.New(ecr.ErrCodeRepositoryPolicyNotFoundException, "no policy", nil),
+	)
+
+	out, err := getRepositoryPolicy(mockSvc, awstest.ExampleEcrRepository)
+	require.NoError(t, err)
+	assert.Nil(t, out)
+}
+
+func TestEcrRepositoryGetPolicyError(t *testing.T) {
+	mockSvc := awstest.BuildMockEcrSvcError([]string{"GetRepositoryPolicy"})
+
+	out, err := getRepositoryPolicy(mockSvc, awstest.ExampleEcrRepository)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestEcrRepositoryGetLifecyclePolicy(t *testing.T) {
+	mockSvc := awstest.BuildMockEcrSvc([]string{"GetLifecyclePolicy"})
+
+	out, err := getRepositoryLifecyclePolicy(mockSvc, awstest.ExampleEcrRepository)
+	require.NoError(t, err)
+	assert.NotEmpty(t, out)
+}
+
+func TestEcrRepositoryGetLifecyclePolicyNotFound(t *testing.T) {
+	mockSvc := &awstest.MockEcr{}
+	mockSvc.On("GetLifecyclePolicy", mock.Anything).Return(
+		&ecr.GetLifecyclePolicyOutput{},
+		awserr.New(ecr.ErrCodeLifecyclePolicyNotFoundException, "no lifecycle policy", nil),
+	)
+
+	out, err := getRepositoryLifecyclePolicy(mockSvc, awstest.ExampleEcrRepository)
+	require.NoError(t, err)
+	assert.Nil(t, out)
+}
+
+func TestEcrRepositoryGetLifecyclePolicyError(t *testing.T) {
+	mockSvc := awstest.BuildMockEcrSvcError([]string{"GetLifecyclePolicy"})
+
+	out, err := getRepositoryLifecyclePolicy(mockSvc, awstest.ExampleEcrRepository)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestEcrRepositoryListTags(t *testing.T) {
+	mockSvc := awstest.BuildMockEcrSvc([]string{"ListTagsForResource"})
+
+	out, err := listEcrRepositoryTags(mockSvc, awstest.ExampleEcrRepositoryArn)
+	require.NoError(t, err)
+	assert.NotEmpty(t, out)
+}
+
+func TestEcrRepositoryListTagsError(t *testing.T) {
+	mockSvc := awstest.BuildMockEcrSvcError([]string{"ListTagsForResource"})
+
+	out, err := listEcrRepositoryTags(mockSvc, awstest.ExampleEcrRepositoryArn)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestBuildEcrRepositorySnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockEcrSvcAll()
+
+	snapshot, err := buildEcrRepositorySnapshot(mockSvc, awstest.ExampleEcrRepository)
+	require.NoError(t, err)
+	assert.Equal(t, awstest.ExampleEcrRepositoryArn, snapshot.ARN)
+	assert.Equal(t, aws.String("IMMUTABLE"), snapshot.ImageTagMutability)
+	assert.NotEmpty(t, snapshot.Policy)
+	assert.NotEmpty(t, snapshot.LifecyclePolicy)
+	assert.Equal(t, map[string]*string{"Key1": aws.String("Value1")}, snapshot.Tags)
+}
+
+func TestBuildEcrRepositorySnapshotErrors(t *testing.T) {
+	mockSvc := awstest.BuildMockEcrSvcAllError()
+
+	snapshot, err := buildEcrRepositorySnapshot(mockSvc, awstest.ExampleEcrRepository)
+	assert.Nil(t, snapshot)
+	assert.Error(t, err)
+}
+
+func TestEcrRepositoryPollSingle(t *testing.T) {
+	resetCache()
+	awstest.MockEcrForSetup = awstest.BuildMockEcrSvcAll()
+
+	EcrClientFunc = awstest.SetupMockEcr
+
+	resourceARN, err := arn.Parse(*awstest.ExampleEcrRepositoryArn)
+	require.NoError(t, err)
+	snapshot, err := PollECRRepository(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Timestamp:           &awstest.ExampleTime,
+	}, resourceARN, &pollermodels.ScanEntry{ResourceID: awstest.ExampleEcrRepositoryArn})
+
+	require.NoError(t, err)
+	require.NotNil(t, snapshot)
+	repository := snapshot.(*awsmodels.EcrRepository)
+	assert.Equal(t, aws.String("123456789012"), repository.AccountID)
+	assert.Equal(t, aws.String("us-west-2"), repository.Region)
+}
+
+func TestEcrRepositoryPoller(t *testing.T) {
+	awstest.MockEcrForSetup = awstest.BuildMockEcrSvcAll()
+
+	EcrClientFunc = awstest.SetupMockEcr
+
+	resources, marker, err := PollEcrRepositories(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, *awstest.ExampleEcrRepositoryArn, resources[0].ID)
+	assert.Equal(t, awsmodels.EcrRepositorySchema, resources[0].Type)
+	assert.Nil(t, marker)
+}
+
+func TestEcrRepositoryPollerError(t *testing.T) {
+	resetCache()
+	awstest.MockEcrForSetup = awstest.BuildMockEcrSvcAllError()
+
+	EcrClientFunc = awstest.SetupMockEcr
+
+	resources, marker, err := PollEcrRepositories(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	for _, event := range resources {
+		assert.Nil(t, event.Attributes)
+	}
+	assert.Nil(t, marker)
+	assert.Error(t, err)
+}
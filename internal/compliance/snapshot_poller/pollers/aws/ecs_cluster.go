@@ -217,9 +217,8 @@ func getECSClusterTasks(ecsSvc ecsiface.ECSAPI, clusterArn *string) ([]*awsmodel
 	return tasks, nil
 }
 
-// getECSClusterServices enumerates and then describes all active services of a cluster
-func getECSClusterServices(ecsSvc ecsiface.ECSAPI, clusterArn *string) ([]*awsmodels.EcsService, error) {
-	// Enumerate services
+// listECSServices enumerates all active services of a cluster
+func listECSServices(ecsSvc ecsiface.ECSAPI, clusterArn *string) ([]*string, error) {
 	var serviceArns []*string
 	err := ecsSvc.ListServicesPages(&ecs.ListServicesInput{Cluster: clusterArn},
 		func(page *ecs.ListServicesOutput, lastPage bool) bool {
@@ -230,14 +229,11 @@ func getECSClusterServices(ecsSvc ecsiface.ECSAPI, clusterArn *string) ([]*awsmo
 	if err != nil {
 		return nil, errors.Wrapf(err, "ECS.ListServicesPages: %s", aws.StringValue(clusterArn))
 	}
+	return serviceArns, nil
+}
 
-	// If there are no services, stop here
-	if len(serviceArns) == 0 {
-		return nil, nil
-	}
-
-	// Describe services
-	//
+// describeECSServices describes the given services of a cluster
+func describeECSServices(ecsSvc ecsiface.ECSAPI, clusterArn *string, serviceArns []*string) ([]*ecs.Service, error) {
 	// The DescribeServices API call does not have a version with builtin paging like the list
 	// API call does. API set a limit of 10 services to describe in a single operation.
 	// Loop through results 10 elements at a time and aggregate the results
@@ -263,9 +259,30 @@ func getECSClusterServices(ecsSvc ecsiface.ECSAPI, clusterArn *string) ([]*awsmo
 		// ListServicesPages and DescribeServices, we can safely discard those results.
 		rawServices.Services = append(rawServices.Services, rawServicesPage.Services...)
 	}
+	return rawServices.Services, nil
+}
+
+// getECSClusterServices enumerates and then describes all active services of a cluster
+func getECSClusterServices(ecsSvc ecsiface.ECSAPI, clusterArn *string) ([]*awsmodels.EcsService, error) {
+	// Enumerate services
+	serviceArns, err := listECSServices(ecsSvc, clusterArn)
+	if err != nil {
+		return nil, err
+	}
+
+	// If there are no services, stop here
+	if len(serviceArns) == 0 {
+		return nil, nil
+	}
+
+	// Describe services
+	rawServices, err := describeECSServices(ecsSvc, clusterArn, serviceArns)
+	if err != nil {
+		return nil, err
+	}
 
-	services := make([]*awsmodels.EcsService, 0, len(rawServices.Services))
-	for _, service := range rawServices.Services {
+	services := make([]*awsmodels.EcsService, 0, len(rawServices))
+	for _, service := range rawServices {
 		services = append(services, &awsmodels.EcsService{
 			GenericAWSResource: awsmodels.GenericAWSResource{
 				ARN:  service.ServiceArn,
@@ -0,0 +1,174 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/aws/aws-sdk-go/service/ecs/ecsiface"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// ecsServiceStatusInactive is the status of services that have been deleted
+const ecsServiceStatusInactive = "INACTIVE"
+
+// PollECSService polls a single ECS service resource
+func PollECSService(
+	pollerInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	scanRequest *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getEcsClient(pollerInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	// Service ARNs are either service/<cluster>/<service> or, in the older format, service/<service>
+	// for services in the default cluster
+	var cluster *string
+	if parts := strings.Split(resourceARN.Resource, "/"); len(parts) == 3 {
+		cluster = aws.String(parts[1])
+	}
+
+	services, err := describeECSServices(client, cluster, []*string{scanRequest.ResourceID})
+	if err != nil {
+		return nil, err
+	}
+	if len(services) == 0 || aws.StringValue(services[0].Status) == ecsServiceStatusInactive {
+		zap.L().Warn("tried to scan non-existent resource",
+			zap.String("resource", resourceARN.String()),
+			zap.String("resourceType", awsmodels.EcsServiceSchema))
+		return nil, nil
+	}
+
+	snapshot := buildEcsServiceSnapshot(services[0])
+	snapshot.Region = aws.String(resourceARN.Region)
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+
+	return snapshot, nil
+}
+
+// buildEcsServiceSnapshot returns a snapshot of an ECS service
+func buildEcsServiceSnapshot(service *ecs.Service) *awsmodels.EcsServiceResource {
+	return &awsmodels.EcsServiceResource{
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  service.ServiceArn,
+			Name: service.ServiceName,
+			Tags: utils.ParseTagSlice(service.Tags),
+		},
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   service.ServiceArn,
+			ResourceType: aws.String(awsmodels.EcsServiceSchema),
+			TimeCreated:  service.CreatedAt,
+		},
+		CapacityProviderStrategy:      service.CapacityProviderStrategy,
+		ClusterArn:                    service.ClusterArn,
+		CreatedBy:                     service.CreatedBy,
+		DeploymentConfiguration:       service.DeploymentConfiguration,
+		DeploymentController:          service.DeploymentController,
+		Deployments:                   service.Deployments,
+		DesiredCount:                  service.DesiredCount,
+		EnableECSManagedTags:          service.EnableECSManagedTags,
+		HealthCheckGracePeriodSeconds: service.HealthCheckGracePeriodSeconds,
+		LaunchType:                    service.LaunchType,
+		LoadBalancers:                 service.LoadBalancers,
+		NetworkConfiguration:          service.NetworkConfiguration,
+		PendingCount:                  service.PendingCount,
+		PlacementConstraints:          service.PlacementConstraints,
+		PlacementStrategy:             service.PlacementStrategy,
+		PlatformVersion:               service.PlatformVersion,
+		PropagateTags:                 service.PropagateTags,
+		RoleArn:                       service.RoleArn,
+		RunningCount:                  service.RunningCount,
+		SchedulingStrategy:            service.SchedulingStrategy,
+		ServiceRegistries:             service.ServiceRegistries,
+		Status:                        service.Status,
+		TaskDefinition:                service.TaskDefinition,
+		TaskSets:                      service.TaskSets,
+	}
+}
+
+// getECSServiceSnapshots builds snapshots of all active services of a cluster
+func getECSServiceSnapshots(ecsSvc ecsiface.ECSAPI, clusterArn *string) ([]*awsmodels.EcsServiceResource, error) {
+	serviceArns, err := listECSServices(ecsSvc, clusterArn)
+	if err != nil || len(serviceArns) == 0 {
+		return nil, err
+	}
+
+	services, err := describeECSServices(ecsSvc, clusterArn, serviceArns)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]*awsmodels.EcsServiceResource, 0, len(services))
+	for _, service := range services {
+		snapshots = append(snapshots, buildEcsServiceSnapshot(service))
+	}
+	return snapshots, nil
+}
+
+// PollEcsServices gathers information on each ECS service for an AWS account.
+//
+// Services are scanned one page of clusters at a time.
+func PollEcsServices(pollerInput *awsmodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	zap.L().Debug("starting ECS Service resource poller")
+
+	ecsSvc, err := getEcsClient(pollerInput, *pollerInput.Region)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clusters, marker, err := listECSClusters(ecsSvc, pollerInput.NextPageToken)
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "region: %s", *pollerInput.Region)
+	}
+
+	var resources []apimodels.AddResourceEntry
+	for _, clusterArn := range clusters {
+		serviceSnapshots, err := getECSServiceSnapshots(ecsSvc, clusterArn)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, serviceSnapshot := range serviceSnapshots {
+			serviceSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+			serviceSnapshot.Region = pollerInput.Region
+
+			resources = append(resources, apimodels.AddResourceEntry{
+				Attributes:      serviceSnapshot,
+				ID:              *serviceSnapshot.ResourceID,
+				IntegrationID:   *pollerInput.IntegrationID,
+				IntegrationType: integrationType,
+				Type:            awsmodels.EcsServiceSchema,
+			})
+		}
+	}
+
+	return resources, marker, nil
+}
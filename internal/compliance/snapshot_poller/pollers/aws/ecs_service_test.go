@@ -0,0 +1,157 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestBuildEcsServiceSnapshot(t *testing.T) {
+	service := awstest.ExampleEcsDescribeServicesOutput.Services[0]
+
+	snapshot := buildEcsServiceSnapshot(service)
+	assert.Equal(t, service.ServiceArn, snapshot.ResourceID)
+	assert.Equal(t, aws.String(awsmodels.EcsServiceSchema), snapshot.ResourceType)
+	assert.Equal(t, service.ClusterArn, snapshot.ClusterArn)
+	assert.Equal(t, service.LoadBalancers, snapshot.LoadBalancers)
+}
+
+func TestGetEcsServiceSnapshots(t *testing.T) {
+	mockSvc := awstest.BuildMockEcsSvc([]string{"ListServicesPages", "DescribeServices"})
+
+	snapshots, err := getECSServiceSnapshots(mockSvc, awstest.ExampleEcsClusterArn)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	assert.Equal(t, aws.String("example"), snapshots[0].Name)
+}
+
+func TestGetEcsServiceSnapshotsError(t *testing.T) {
+	mockSvc := awstest.BuildMockEcsSvc([]string{"ListServicesPages"})
+	mockSvc.On("DescribeServices", mock.Anything).
+		Return(&ecs.DescribeServicesOutput{}, assert.AnError)
+
+	snapshots, err := getECSServiceSnapshots(mockSvc, awstest.ExampleEcsClusterArn)
+	assert.Error(t, err)
+	assert.Nil(t, snapshots)
+}
+
+func TestEcsServicePollSingle(t *testing.T) {
+	resetCache()
+	mockSvc := awstest.BuildMockEcsSvc([]string{"DescribeServices"})
+	awstest.MockEcsForSetup = mockSvc
+
+	EcsClientFunc = awstest.SetupMockEcs
+
+	serviceARN := "arn:aws:ecs:us-west-2:123456789012:service/example-cluster/example"
+	resourceARN, err := arn.Parse(serviceARN)
+	require.NoError(t, err)
+	snapshot, err := PollECSService(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Timestamp:           &awstest.ExampleTime,
+	}, resourceARN, &pollermodels.ScanEntry{ResourceID: aws.String(serviceARN)})
+
+	require.NoError(t, err)
+	require.NotNil(t, snapshot)
+	service := snapshot.(*awsmodels.EcsServiceResource)
+	assert.Equal(t, aws.String("123456789012"), service.AccountID)
+	assert.Equal(t, aws.String("us-west-2"), service.Region)
+	mockSvc.AssertCalled(t, "DescribeServices", &ecs.DescribeServicesInput{
+		Cluster:  aws.String("example-cluster"),
+		Include:  []*string{aws.String("TAGS")},
+		Services: []*string{aws.String(serviceARN)},
+	})
+}
+
+func TestEcsServicePollSingleInactive(t *testing.T) {
+	resetCache()
+	inactive := *awstest.ExampleEcsDescribeServicesOutput.Services[0]
+	inactive.Status = aws.String(ecsServiceStatusInactive)
+	mockSvc := &awstest.MockEcs{}
+	mockSvc.On("DescribeServices", mock.Anything).
+		Return(&ecs.DescribeServicesOutput{Services: []*ecs.Service{&inactive}}, nil)
+	awstest.MockEcsForSetup = mockSvc
+
+	EcsClientFunc = awstest.SetupMockEcs
+
+	resourceARN, err := arn.Parse(*inactive.ServiceArn)
+	require.NoError(t, err)
+	snapshot, err := PollECSService(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Timestamp:           &awstest.ExampleTime,
+	}, resourceARN, &pollermodels.ScanEntry{ResourceID: inactive.ServiceArn})
+
+	require.NoError(t, err)
+	assert.Nil(t, snapshot)
+}
+
+func TestEcsServicePoller(t *testing.T) {
+	resetCache()
+	awstest.MockEcsForSetup = awstest.BuildMockEcsSvcAll()
+
+	EcsClientFunc = awstest.SetupMockEcs
+
+	resources, marker, err := PollEcsServices(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, resources)
+	assert.Equal(t, awsmodels.EcsServiceSchema, resources[0].Type)
+	assert.Nil(t, marker)
+}
+
+func TestEcsServicePollerError(t *testing.T) {
+	resetCache()
+	awstest.MockEcsForSetup = awstest.BuildMockEcsSvcAllError()
+
+	EcsClientFunc = awstest.SetupMockEcs
+
+	resources, marker, err := PollEcsServices(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	for _, event := range resources {
+		assert.Nil(t, event.Attributes)
+	}
+	assert.Nil(t, marker)
+	assert.Error(t, err)
+}
@@ -0,0 +1,212 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/aws/aws-sdk-go/service/ecs/ecsiface"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// PollECSTaskDefinition polls a single ECS task definition resource
+func PollECSTaskDefinition(
+	pollerInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	scanRequest *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getEcsClient(pollerInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := buildEcsTaskDefinitionSnapshot(client, scanRequest.ResourceID)
+	if err != nil || snapshot == nil {
+		return nil, err
+	}
+	snapshot.Region = aws.String(resourceARN.Region)
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+
+	return snapshot, nil
+}
+
+// listECSTaskDefinitions returns the ARNs of all active ECS task definitions in the account
+func listECSTaskDefinitions(ecsSvc ecsiface.ECSAPI, nextMarker *string) (taskDefinitions []*string, marker *string, err error) {
+	err = ecsSvc.ListTaskDefinitionsPages(&ecs.ListTaskDefinitionsInput{
+		NextToken:  nextMarker,
+		MaxResults: aws.Int64(int64(defaultBatchSize)),
+		Status:     aws.String(ecs.TaskDefinitionStatusActive),
+	},
+		func(page *ecs.ListTaskDefinitionsOutput, lastPage bool) bool {
+			return ecsTaskDefinitionIterator(page, &taskDefinitions, &marker)
+		})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "ECS.ListTaskDefinitionsPages")
+	}
+	return
+}
+
+func ecsTaskDefinitionIterator(page *ecs.ListTaskDefinitionsOutput, taskDefinitions *[]*string, marker **string) bool {
+	*taskDefinitions = append(*taskDefinitions, page.TaskDefinitionArns...)
+	*marker = page.NextToken
+	return len(*taskDefinitions) < defaultBatchSize
+}
+
+// describeECSTaskDefinition provides detailed information for a given ECS task definition
+func describeECSTaskDefinition(ecsSvc ecsiface.ECSAPI, taskDefinitionARN *string) (*ecs.DescribeTaskDefinitionOutput, error) {
+	out, err := ecsSvc.DescribeTaskDefinition(&ecs.DescribeTaskDefinitionInput{
+		Include:        []*string{aws.String("TAGS")},
+		TaskDefinition: taskDefinitionARN,
+	})
+	if err != nil {
+		// ECS reports unknown task definitions as a generic client error
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == ecs.ErrCodeClientException {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", aws.StringValue(taskDefinitionARN)),
+				zap.String("resourceType", awsmodels.EcsTaskDefinitionSchema))
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "ECS.DescribeTaskDefinition: %s", aws.StringValue(taskDefinitionARN))
+	}
+	return out, nil
+}
+
+// redactContainerDefinitions copies container definitions, keeping only the names of their
+// environment variables so that secret values are never stored in a snapshot. It also returns the
+// names of any containers running in privileged mode.
+func redactContainerDefinitions(
+	definitions []*ecs.ContainerDefinition) (redacted []*ecs.ContainerDefinition, privileged []*string) {
+
+	redacted = make([]*ecs.ContainerDefinition, 0, len(definitions))
+	for _, definition := range definitions {
+		if definition == nil {
+			continue
+		}
+		definitionCopy := *definition
+		definitionCopy.Environment = make([]*ecs.KeyValuePair, 0, len(definition.Environment))
+		for _, variable := range definition.Environment {
+			definitionCopy.Environment = append(definitionCopy.Environment, &ecs.KeyValuePair{Name: variable.Name})
+		}
+		redacted = append(redacted, &definitionCopy)
+
+		if aws.BoolValue(definition.Privileged) {
+			privileged = append(privileged, definition.Name)
+		}
+	}
+	return redacted, privileged
+}
+
+// buildEcsTaskDefinitionSnapshot returns a complete snapshot of an active ECS task definition
+func buildEcsTaskDefinitionSnapshot(ecsSvc ecsiface.ECSAPI, taskDefinitionARN *string) (*awsmodels.EcsTaskDefinition, error) {
+	if taskDefinitionARN == nil {
+		return nil, nil
+	}
+
+	out, err := describeECSTaskDefinition(ecsSvc, taskDefinitionARN)
+	if err != nil || out == nil || out.TaskDefinition == nil {
+		return nil, err
+	}
+	details := out.TaskDefinition
+
+	// Deregistered revisions remain visible, but can no longer be used to run tasks
+	if aws.StringValue(details.Status) != ecs.TaskDefinitionStatusActive {
+		return nil, nil
+	}
+
+	containerDefinitions, privileged := redactContainerDefinitions(details.ContainerDefinitions)
+	return &awsmodels.EcsTaskDefinition{
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  details.TaskDefinitionArn,
+			Name: details.Family,
+			Tags: utils.ParseTagSlice(out.Tags),
+		},
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   details.TaskDefinitionArn,
+			ResourceType: aws.String(awsmodels.EcsTaskDefinitionSchema),
+		},
+		Compatibilities:         details.Compatibilities,
+		ContainerDefinitions:    containerDefinitions,
+		Cpu:                     details.Cpu,
+		ExecutionRoleArn:        details.ExecutionRoleArn,
+		Family:                  details.Family,
+		InferenceAccelerators:   details.InferenceAccelerators,
+		IpcMode:                 details.IpcMode,
+		Memory:                  details.Memory,
+		NetworkMode:             details.NetworkMode,
+		PidMode:                 details.PidMode,
+		PlacementConstraints:    details.PlacementConstraints,
+		ProxyConfiguration:      details.ProxyConfiguration,
+		RequiresAttributes:      details.RequiresAttributes,
+		RequiresCompatibilities: details.RequiresCompatibilities,
+		Revision:                details.Revision,
+		Status:                  details.Status,
+		TaskRoleArn:             details.TaskRoleArn,
+		Volumes:                 details.Volumes,
+		PrivilegedContainers:    privileged,
+	}, nil
+}
+
+// PollEcsTaskDefinitions gathers information on each active ECS task definition for an AWS account.
+func PollEcsTaskDefinitions(pollerInput *awsmodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	zap.L().Debug("starting ECS Task Definition resource poller")
+
+	ecsSvc, err := getEcsClient(pollerInput, *pollerInput.Region)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Start with generating a list of all active task definitions
+	taskDefinitions, marker, err := listECSTaskDefinitions(ecsSvc, pollerInput.NextPageToken)
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "region: %s", *pollerInput.Region)
+	}
+
+	resources := make([]apimodels.AddResourceEntry, 0, len(taskDefinitions))
+	for _, taskDefinitionARN := range taskDefinitions {
+		taskDefinitionSnapshot, err := buildEcsTaskDefinitionSnapshot(ecsSvc, taskDefinitionARN)
+		if err != nil {
+			return nil, nil, err
+		}
+		if taskDefinitionSnapshot == nil {
+			continue
+		}
+		taskDefinitionSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+		taskDefinitionSnapshot.Region = pollerInput.Region
+
+		resources = append(resources, apimodels.AddResourceEntry{
+			Attributes:      taskDefinitionSnapshot,
+			ID:              *taskDefinitionSnapshot.ResourceID,
+			IntegrationID:   *pollerInput.IntegrationID,
+			IntegrationType: integrationType,
+			Type:            awsmodels.EcsTaskDefinitionSchema,
+		})
+	}
+
+	return resources, marker, nil
+}
@@ -0,0 +1,215 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestEcsTaskDefinitionList(t *testing.T) {
+	mockSvc := awstest.BuildMockEcsSvc([]string{"ListTaskDefinitionsPages"})
+
+	out, marker, err := listECSTaskDefinitions(mockSvc, nil)
+	assert.NotEmpty(t, out)
+	assert.Nil(t, marker)
+	assert.NoError(t, err)
+}
+
+// Test the iterator works on consecutive pages but stops at max page size
+func TestEcsTaskDefinitionListIterator(t *testing.T) {
+	var taskDefinitions []*string
+	var marker *string
+
+	cont := ecsTaskDefinitionIterator(awstest.ExampleEcsListTaskDefinitions, &taskDefinitions, &marker)
+	assert.True(t, cont)
+	assert.Nil(t, marker)
+	assert.Len(t, taskDefinitions, 1)
+
+	for i := 1; i < 50; i++ {
+		cont = ecsTaskDefinitionIterator(awstest.ExampleEcsListTaskDefinitionsContinue, &taskDefinitions, &marker)
+		assert.True(t, cont)
+		assert.NotNil(t, marker)
+		assert.Len(t, taskDefinitions, 1+i*2)
+	}
+
+	cont = ecsTaskDefinitionIterator(awstest.ExampleEcsListTaskDefinitionsContinue, &taskDefinitions, &marker)
+	assert.False(t, cont)
+	assert.NotNil(t, marker)
+	assert.Len(t, taskDefinitions, 101)
+}
+
+func TestEcsTaskDefinitionListError(t *testing.T) {
+	mockSvc := awstest.BuildMockEcsSvcError([]string{"ListTaskDefinitionsPages"})
+
+	out, marker, err := listECSTaskDefinitions(mockSvc, nil)
+	assert.Nil(t, out)
+	assert.Nil(t, marker)
+	assert.Error(t, err)
+}
+
+func TestEcsTaskDefinitionDescribe(t *testing.T) {
+	mockSvc := awstest.BuildMockEcsSvc([]string{"DescribeTaskDefinition"})
+
+	out, err := describeECSTaskDefinition(mockSvc, awstest.ExampleTaskDefinitionArn)
+	require.NoError(t, err)
+	assert.NotEmpty(t, out)
+}
+
+func TestEcsTaskDefinitionDescribeNotFound(t *testing.T) {
+	mockSvc := &awstest.MockEcs{}
+	mockSvc.On("DescribeTaskDefinition", mock.Anything).Return(
+		&ecs.DescribeTaskDefinitionOutput{},
+		awserr.New(ecs.ErrCodeClientException, "Unable to describe task definition.", nil),
+	)
+
+	out, err := describeECSTaskDefinition(mockSvc, awstest.ExampleTaskDefinitionArn)
+	require.NoError(t, err)
+	assert.Nil(t, out)
+}
+
+func TestEcsTaskDefinitionDescribeError(t *testing.T) {
+	mockSvc := awstest.BuildMockEcsSvcError([]string{"DescribeTaskDefinition"})
+
+	out, err := describeECSTaskDefinition(mockSvc, awstest.ExampleTaskDefinitionArn)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestRedactContainerDefinitions(t *testing.T) {
+	definitions := awstest.ExampleEcsDescribeTaskDefinitionOutput.TaskDefinition.ContainerDefinitions
+
+	redacted, privileged := redactContainerDefinitions(definitions)
+	require.Len(t, redacted, 2)
+	require.Len(t, redacted[0].Environment, 1)
+	assert.Equal(t, aws.String("DATABASE_PASSWORD"), redacted[0].Environment[0].Name)
+	assert.Nil(t, redacted[0].Environment[0].Value)
+	assert.Equal(t, definitions[0].Secrets, redacted[0].Secrets)
+	assert.Equal(t, []*string{aws.String("example-container")}, privileged)
+
+	// The original definitions are left untouched
+	assert.Equal(t, aws.String("hunter2"), definitions[0].Environment[0].Value)
+}
+
+func TestBuildEcsTaskDefinitionSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockEcsSvc([]string{"DescribeTaskDefinition"})
+
+	snapshot, err := buildEcsTaskDefinitionSnapshot(mockSvc, awstest.ExampleTaskDefinitionArn)
+	require.NoError(t, err)
+	require.NotNil(t, snapshot)
+	assert.Equal(t, awstest.ExampleTaskDefinitionArn, snapshot.ResourceID)
+	assert.Equal(t, aws.String("example-task"), snapshot.Name)
+	assert.Equal(t, []*string{aws.String("example-container")}, snapshot.PrivilegedContainers)
+	assert.Nil(t, snapshot.ContainerDefinitions[0].Environment[0].Value)
+	assert.Equal(t, map[string]*string{"Key1": aws.String("Value1")}, snapshot.Tags)
+}
+
+func TestBuildEcsTaskDefinitionSnapshotInactive(t *testing.T) {
+	inactive := *awstest.ExampleEcsDescribeTaskDefinitionOutput.TaskDefinition
+	inactive.Status = aws.String(ecs.TaskDefinitionStatusInactive)
+	mockSvc := &awstest.MockEcs{}
+	mockSvc.On("DescribeTaskDefinition", mock.Anything).
+		Return(&ecs.DescribeTaskDefinitionOutput{TaskDefinition: &inactive}, nil)
+
+	snapshot, err := buildEcsTaskDefinitionSnapshot(mockSvc, awstest.ExampleTaskDefinitionArn)
+	require.NoError(t, err)
+	assert.Nil(t, snapshot)
+}
+
+func TestBuildEcsTaskDefinitionSnapshotErrors(t *testing.T) {
+	mockSvc := awstest.BuildMockEcsSvcAllError()
+
+	snapshot, err := buildEcsTaskDefinitionSnapshot(mockSvc, awstest.ExampleTaskDefinitionArn)
+	assert.Nil(t, snapshot)
+	assert.Error(t, err)
+}
+
+func TestEcsTaskDefinitionPollSingle(t *testing.T) {
+	resetCache()
+	awstest.MockEcsForSetup = awstest.BuildMockEcsSvcAll()
+
+	EcsClientFunc = awstest.SetupMockEcs
+
+	resourceARN, err := arn.Parse(*awstest.ExampleTaskDefinitionArn)
+	require.NoError(t, err)
+	snapshot, err := PollECSTaskDefinition(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Timestamp:           &awstest.ExampleTime,
+	}, resourceARN, &pollermodels.ScanEntry{ResourceID: awstest.ExampleTaskDefinitionArn})
+
+	require.NoError(t, err)
+	require.NotNil(t, snapshot)
+	taskDefinition := snapshot.(*awsmodels.EcsTaskDefinition)
+	assert.Equal(t, aws.String("123456789012"), taskDefinition.AccountID)
+	assert.Equal(t, aws.String("us-west-2"), taskDefinition.Region)
+}
+
+func TestEcsTaskDefinitionPoller(t *testing.T) {
+	awstest.MockEcsForSetup = awstest.BuildMockEcsSvcAll()
+
+	EcsClientFunc = awstest.SetupMockEcs
+
+	resources, marker, err := PollEcsTaskDefinitions(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, *awstest.ExampleTaskDefinitionArn, resources[0].ID)
+	assert.Equal(t, awsmodels.EcsTaskDefinitionSchema, resources[0].Type)
+	assert.Nil(t, marker)
+}
+
+func TestEcsTaskDefinitionPollerError(t *testing.T) {
+	resetCache()
+	awstest.MockEcsForSetup = awstest.BuildMockEcsSvcAllError()
+
+	EcsClientFunc = awstest.SetupMockEcs
+
+	resources, marker, err := PollEcsTaskDefinitions(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	for _, event := range resources {
+		assert.Nil(t, event.Attributes)
+	}
+	assert.Nil(t, marker)
+	assert.Error(t, err)
+}
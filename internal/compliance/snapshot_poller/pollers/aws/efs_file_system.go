@@ -0,0 +1,280 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/efs"
+	"github.com/aws/aws-sdk-go/service/efs/efsiface"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// Set as variables to be overridden in testing
+var (
+	EfsClientFunc = setupEfsClient
+)
+
+func setupEfsClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return efs.New(sess, cfg)
+}
+
+func getEfsClient(pollerResourceInput *awsmodels.ResourcePollerInput, region string) (efsiface.EFSAPI, error) {
+	client, err := getClient(pollerResourceInput, EfsClientFunc, "efs", region)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.(efsiface.EFSAPI), nil
+}
+
+// isEfsPolicyNotFound reports whether an error means a file system has no policy of the requested kind
+func isEfsPolicyNotFound(err error) bool {
+	var awsErr awserr.Error
+	return errors.As(err, &awsErr) && awsErr.Code() == efs.ErrCodePolicyNotFound
+}
+
+// PollEfsFileSystem polls a single EFS file system resource
+func PollEfsFileSystem(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	_ *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getEfsClient(pollerResourceInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	fileSystem, err := describeFileSystem(client, aws.String(strings.TrimPrefix(resourceARN.Resource, "file-system/")))
+	if err != nil || fileSystem == nil {
+		return nil, err
+	}
+
+	snapshot, err := buildEfsFileSystemSnapshot(client, fileSystem)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	return snapshot, nil
+}
+
+// describeFileSystems returns all the EFS file systems in the account
+func describeFileSystems(efsSvc efsiface.EFSAPI, nextMarker *string) (
+	fileSystems []*efs.FileSystemDescription, marker *string, err error) {
+
+	err = efsSvc.DescribeFileSystemsPages(&efs.DescribeFileSystemsInput{
+		Marker:   nextMarker,
+		MaxItems: aws.Int64(int64(defaultBatchSize)),
+	},
+		func(page *efs.DescribeFileSystemsOutput, lastPage bool) bool {
+			return efsFileSystemIterator(page, &fileSystems, &marker)
+		})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "EFS.DescribeFileSystemsPages")
+	}
+	return
+}
+
+func efsFileSystemIterator(page *efs.DescribeFileSystemsOutput, fileSystems *[]*efs.FileSystemDescription, marker **string) bool {
+	*fileSystems = append(*fileSystems, page.FileSystems...)
+	*marker = page.NextMarker
+	return len(*fileSystems) < defaultBatchSize
+}
+
+// describeFileSystem returns a single EFS file system, or nil if it no longer exists
+func describeFileSystem(efsSvc efsiface.EFSAPI, id *string) (*efs.FileSystemDescription, error) {
+	out, err := efsSvc.DescribeFileSystems(&efs.DescribeFileSystemsInput{FileSystemId: id})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == efs.ErrCodeFileSystemNotFound {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", aws.StringValue(id)),
+				zap.String("resourceType", awsmodels.EfsFileSystemSchema))
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "EFS.DescribeFileSystems: %s", aws.StringValue(id))
+	}
+	if len(out.FileSystems) != 1 {
+		return nil, errors.Errorf("EFS.DescribeFileSystems: expected exactly 1 file system for %s, found %d",
+			aws.StringValue(id), len(out.FileSystems))
+	}
+	return out.FileSystems[0], nil
+}
+
+// describeFileSystemPolicy returns the resource policy of a file system, if it has one
+func describeFileSystemPolicy(efsSvc efsiface.EFSAPI, id *string) (*string, error) {
+	out, err := efsSvc.DescribeFileSystemPolicy(&efs.DescribeFileSystemPolicyInput{FileSystemId: id})
+	if err != nil {
+		if isEfsPolicyNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "EFS.DescribeFileSystemPolicy: %s", aws.StringValue(id))
+	}
+	return out.Policy, nil
+}
+
+// describeBackupPolicy returns whether AWS Backup automatic backups are enabled for a file system
+func describeBackupPolicy(efsSvc efsiface.EFSAPI, id *string) (*efs.BackupPolicy, error) {
+	out, err := efsSvc.DescribeBackupPolicy(&efs.DescribeBackupPolicyInput{FileSystemId: id})
+	if err != nil {
+		if isEfsPolicyNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "EFS.DescribeBackupPolicy: %s", aws.StringValue(id))
+	}
+	return out.BackupPolicy, nil
+}
+
+// describeLifecycleConfiguration returns the infrequent access transition policies of a file system
+func describeLifecycleConfiguration(efsSvc efsiface.EFSAPI, id *string) ([]*efs.LifecyclePolicy, error) {
+	out, err := efsSvc.DescribeLifecycleConfiguration(&efs.DescribeLifecycleConfigurationInput{FileSystemId: id})
+	if err != nil {
+		return nil, errors.Wrapf(err, "EFS.DescribeLifecycleConfiguration: %s", aws.StringValue(id))
+	}
+	return out.LifecyclePolicies, nil
+}
+
+// describeMountTargets returns the mount targets of a file system along with their security groups
+func describeMountTargets(efsSvc efsiface.EFSAPI, id *string) (mountTargets []*awsmodels.EfsMountTarget, err error) {
+	var marker *string
+	for {
+		out, err := efsSvc.DescribeMountTargets(&efs.DescribeMountTargetsInput{
+			FileSystemId: id,
+			Marker:       marker,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "EFS.DescribeMountTargets: %s", aws.StringValue(id))
+		}
+
+		for _, target := range out.MountTargets {
+			securityGroups, err := efsSvc.DescribeMountTargetSecurityGroups(&efs.DescribeMountTargetSecurityGroupsInput{
+				MountTargetId: target.MountTargetId,
+			})
+			if err != nil {
+				return nil, errors.Wrapf(err, "EFS.DescribeMountTargetSecurityGroups: %s", aws.StringValue(target.MountTargetId))
+			}
+			mountTargets = append(mountTargets, &awsmodels.EfsMountTarget{
+				AvailabilityZoneId:   target.AvailabilityZoneId,
+				AvailabilityZoneName: target.AvailabilityZoneName,
+				IpAddress:            target.IpAddress,
+				LifeCycleState:       target.LifeCycleState,
+				MountTargetId:        target.MountTargetId,
+				NetworkInterfaceId:   target.NetworkInterfaceId,
+				SubnetId:             target.SubnetId,
+				VpcId:                target.VpcId,
+				SecurityGroups:       securityGroups.SecurityGroups,
+			})
+		}
+
+		if marker = out.NextMarker; marker == nil {
+			return mountTargets, nil
+		}
+	}
+}
+
+// buildEfsFileSystemSnapshot makes all the calls to build up a snapshot of a given EFS file system
+func buildEfsFileSystemSnapshot(efsSvc efsiface.EFSAPI, fileSystem *efs.FileSystemDescription) (*awsmodels.EfsFileSystem, error) {
+	snapshot := &awsmodels.EfsFileSystem{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   fileSystem.FileSystemArn,
+			TimeCreated:  fileSystem.CreationTime,
+			ResourceType: aws.String(awsmodels.EfsFileSystemSchema),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  fileSystem.FileSystemArn,
+			ID:   fileSystem.FileSystemId,
+			Name: fileSystem.Name,
+			Tags: utils.ParseTagSlice(fileSystem.Tags),
+		},
+		CreationToken:                fileSystem.CreationToken,
+		Encrypted:                    fileSystem.Encrypted,
+		KmsKeyId:                     fileSystem.KmsKeyId,
+		LifeCycleState:               fileSystem.LifeCycleState,
+		NumberOfMountTargets:         fileSystem.NumberOfMountTargets,
+		OwnerId:                      fileSystem.OwnerId,
+		PerformanceMode:              fileSystem.PerformanceMode,
+		ProvisionedThroughputInMibps: fileSystem.ProvisionedThroughputInMibps,
+		SizeInBytes:                  fileSystem.SizeInBytes,
+		ThroughputMode:               fileSystem.ThroughputMode,
+	}
+
+	var err error
+	if snapshot.Policy, err = describeFileSystemPolicy(efsSvc, fileSystem.FileSystemId); err != nil {
+		return nil, err
+	}
+	if snapshot.BackupPolicy, err = describeBackupPolicy(efsSvc, fileSystem.FileSystemId); err != nil {
+		return nil, err
+	}
+	if snapshot.LifecyclePolicies, err = describeLifecycleConfiguration(efsSvc, fileSystem.FileSystemId); err != nil {
+		return nil, err
+	}
+	if snapshot.MountTargets, err = describeMountTargets(efsSvc, fileSystem.FileSystemId); err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// PollEfsFileSystems gathers information on each EFS file system for an AWS account.
+func PollEfsFileSystems(pollerInput *awsmodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	zap.L().Debug("starting EFS File System resource poller")
+
+	efsSvc, err := getEfsClient(pollerInput, *pollerInput.Region)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Start with generating a list of all file systems
+	fileSystems, marker, err := describeFileSystems(efsSvc, pollerInput.NextPageToken)
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "region: %s", *pollerInput.Region)
+	}
+
+	resources := make([]apimodels.AddResourceEntry, 0, len(fileSystems))
+	for _, fileSystem := range fileSystems {
+		fileSystemSnapshot, err := buildEfsFileSystemSnapshot(efsSvc, fileSystem)
+		if err != nil {
+			return nil, nil, err
+		}
+		fileSystemSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+		fileSystemSnapshot.Region = pollerInput.Region
+
+		resources = append(resources, apimodels.AddResourceEntry{
+			Attributes:      fileSystemSnapshot,
+			ID:              *fileSystemSnapshot.ResourceID,
+			IntegrationID:   *pollerInput.IntegrationID,
+			IntegrationType: integrationType,
+			Type:            awsmodels.EfsFileSystemSchema,
+		})
+	}
+
+	return resources, marker, nil
+}
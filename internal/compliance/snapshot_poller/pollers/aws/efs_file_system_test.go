@@ -0,0 +1,194 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/efs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestEfsFileSystemList(t *testing.T) {
+	mockSvc := awstest.BuildMockEfsSvc([]string{"DescribeFileSystemsPages"})
+
+	out, marker, err := describeFileSystems(mockSvc, nil)
+	assert.NotEmpty(t, out)
+	assert.Nil(t, marker)
+	assert.NoError(t, err)
+}
+
+// Test the iterator works on consecutive pages but stops at max page size
+func TestEfsFileSystemListIterator(t *testing.T) {
+	var fileSystems []*efs.FileSystemDescription
+	var marker *string
+
+	cont := efsFileSystemIterator(awstest.ExampleDescribeFileSystemsOutput, &fileSystems, &marker)
+	assert.True(t, cont)
+	assert.Nil(t, marker)
+	assert.Len(t, fileSystems, 1)
+
+	for i := 1; i < 50; i++ {
+		cont = efsFileSystemIterator(awstest.ExampleDescribeFileSystemsOutputContinue, &fileSystems, &marker)
+		assert.True(t, cont)
+		assert.NotNil(t, marker)
+		assert.Len(t, fileSystems, 1+i*2)
+	}
+
+	cont = efsFileSystemIterator(awstest.ExampleDescribeFileSystemsOutputContinue, &fileSystems, &marker)
+	assert.False(t, cont)
+	assert.NotNil(t, marker)
+	assert.Len(t, fileSystems, 101)
+}
+
+func TestEfsFileSystemListError(t *testing.T) {
+	mockSvc := awstest.BuildMockEfsSvcError([]string{"DescribeFileSystemsPages"})
+
+	out, marker, err := describeFileSystems(mockSvc, nil)
+	assert.Nil(t, out)
+	assert.Nil(t, marker)
+	assert.Error(t, err)
+}
+
+func TestEfsFileSystemDescribe(t *testing.T) {
+	mockSvc := awstest.BuildMockEfsSvc([]string{"DescribeFileSystems"})
+
+	out, err := describeFileSystem(mockSvc, awstest.ExampleEfsFileSystemID)
+	require.NoError(t, err)
+	assert.Equal(t, awstest.ExampleEfsFileSystemID, out.FileSystemId)
+}
+
+func TestEfsFileSystemDescribeNotFound(t *testing.T) {
+	mockSvc := &awstest.MockEfs{}
+	mockSvc.On("DescribeFileSystems", mock.Anything).Return(
+		&efs.DescribeFileSystemsOutput{},
+		awserr.New(efs.ErrCodeFileSystemNotFound, "File system does not exist", nil),
+	)
+
+	out, err := describeFileSystem(mockSvc, awstest.ExampleEfsFileSystemID)
+	require.NoError(t, err)
+	assert.Nil(t, out)
+}
+
+func TestEfsFileSystemDescribePolicyNotFound(t *testing.T) {
+	mockSvc := &awstest.MockEfs{}
+	mockSvc.On("DescribeFileSystemPolicy", mock.Anything).Return(
+		&efs.DescribeFileSystemPolicyOutput{},
+		awserr.New(efs.ErrCodePolicyNotFound, "No policy found", nil),
+	)
+
+	out, err := describeFileSystemPolicy(mockSvc, awstest.ExampleEfsFileSystemID)
+	require.NoError(t, err)
+	assert.Nil(t, out)
+}
+
+func TestEfsFileSystemDescribePolicyError(t *testing.T) {
+	mockSvc := awstest.BuildMockEfsSvcError([]string{"DescribeFileSystemPolicy"})
+
+	out, err := describeFileSystemPolicy(mockSvc, awstest.ExampleEfsFileSystemID)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestEfsFileSystemDescribeMountTargets(t *testing.T) {
+	mockSvc := awstest.BuildMockEfsSvc([]string{"DescribeMountTargets", "DescribeMountTargetSecurityGroups"})
+
+	out, err := describeMountTargets(mockSvc, awstest.ExampleEfsFileSystemID)
+	require.NoError(t, err)
+	require.Len(t, out, 1)
+	assert.Equal(t, "sg-12345678", *out[0].SecurityGroups[0])
+}
+
+func TestEfsFileSystemDescribeMountTargetsError(t *testing.T) {
+	mockSvc := awstest.BuildMockEfsSvcError([]string{"DescribeMountTargets"})
+
+	out, err := describeMountTargets(mockSvc, awstest.ExampleEfsFileSystemID)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestBuildEfsFileSystemSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockEfsSvcAll()
+
+	fileSystemSnapshot, err := buildEfsFileSystemSnapshot(mockSvc, awstest.ExampleEfsFileSystem)
+	require.NoError(t, err)
+	assert.Equal(t, "example-file-system", *fileSystemSnapshot.Name)
+	assert.True(t, *fileSystemSnapshot.Encrypted)
+	assert.Equal(t, "ENABLED", *fileSystemSnapshot.BackupPolicy.Status)
+	assert.NotNil(t, fileSystemSnapshot.Policy)
+	assert.Len(t, fileSystemSnapshot.LifecyclePolicies, 1)
+	assert.Len(t, fileSystemSnapshot.MountTargets, 1)
+	assert.Equal(t, "Value1", *fileSystemSnapshot.Tags["Key1"])
+}
+
+func TestBuildEfsFileSystemSnapshotErrors(t *testing.T) {
+	mockSvc := awstest.BuildMockEfsSvcAllError()
+
+	fileSystemSnapshot, err := buildEfsFileSystemSnapshot(mockSvc, awstest.ExampleEfsFileSystem)
+	assert.Nil(t, fileSystemSnapshot)
+	assert.Error(t, err)
+}
+
+func TestEfsFileSystemPoller(t *testing.T) {
+	resetCache()
+	awstest.MockEfsForSetup = awstest.BuildMockEfsSvcAll()
+
+	EfsClientFunc = awstest.SetupMockEfs
+
+	resources, marker, err := PollEfsFileSystems(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, *awstest.ExampleEfsFileSystemArn, resources[0].ID)
+	assert.Equal(t, awsmodels.EfsFileSystemSchema, resources[0].Type)
+	assert.Nil(t, marker)
+}
+
+func TestEfsFileSystemPollerError(t *testing.T) {
+	resetCache()
+	awstest.MockEfsForSetup = awstest.BuildMockEfsSvcAllError()
+
+	EfsClientFunc = awstest.SetupMockEfs
+
+	resources, marker, err := PollEfsFileSystems(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	for _, event := range resources {
+		assert.Nil(t, event.Attributes)
+	}
+	assert.Nil(t, marker)
+	assert.Error(t, err)
+}
@@ -19,7 +19,10 @@ package aws
  */
 
 import (
+	"strings"
+
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/eks"
@@ -30,7 +33,6 @@ import (
 	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
 	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
-	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
 )
 
 // Set as variables to be overridden in testing
@@ -52,24 +54,26 @@ func getEksClient(pollerResourceInput *awsmodels.ResourcePollerInput, region str
 // PollEKSCluster polls a single EKS cluster resource
 func PollEKSCluster(
 	pollerInput *awsmodels.ResourcePollerInput,
-	parsedResourceID *utils.ParsedResourceID,
+	resourceARN arn.ARN,
 	scanRequest *pollermodels.ScanEntry,
 ) (interface{}, error) {
 
-	client, err := getEksClient(pollerInput, parsedResourceID.Region)
+	client, err := getEksClient(pollerInput, resourceARN.Region)
 	if err != nil {
 		return nil, err
 	}
 
-	snapshot, err := buildEksClusterSnapshot(client, scanRequest.ResourceID, pollerInput)
+	// The EKS API refers to clusters by name, which is the final part of the ARN
+	clusterName := aws.String(strings.TrimPrefix(resourceARN.Resource, "cluster/"))
+	snapshot, err := buildEksClusterSnapshot(client, clusterName, pollerInput)
 	if err != nil {
 		return nil, err
 	}
 	if snapshot == nil {
 		return nil, nil
 	}
-	snapshot.Region = aws.String(parsedResourceID.Region)
-	snapshot.AccountID = aws.String(parsedResourceID.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
 
 	return snapshot, nil
 }
@@ -102,6 +106,12 @@ func describeEKSCluster(eksSvc eksiface.EKSAPI, clusterName *string) (*eks.Clust
 		Name: clusterName,
 	})
 	if err != nil {
+		if isEksNotFound(err) {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", aws.StringValue(clusterName)),
+				zap.String("resourceType", awsmodels.EksClusterSchema))
+			return nil, nil
+		}
 		return nil, errors.Wrapf(err, "EKS.DescribeCluster: %s", aws.StringValue(clusterName))
 	}
 
@@ -171,9 +181,14 @@ func getEKSFargateProfiles(eksSvc eksiface.EKSAPI, clusterName *string) ([]*awsm
 	return fargateProfiles, nil
 }
 
-// getEKSNodeGroups enumerates and then describes all active node groups of a cluster
-func getEKSNodegroups(eksSvc eksiface.EKSAPI, clusterName *string) ([]*awsmodels.EksNodegroup, error) {
-	// Enumerate Nodegroups
+// isEksNotFound reports whether an error means the requested EKS resource no longer exists
+func isEksNotFound(err error) bool {
+	var awsErr awserr.Error
+	return errors.As(err, &awsErr) && awsErr.Code() == eks.ErrCodeResourceNotFoundException
+}
+
+// listEKSNodegroups enumerates the names of all node groups of a cluster
+func listEKSNodegroups(eksSvc eksiface.EKSAPI, clusterName *string) ([]*string, error) {
 	var nodeGroups []*string
 	err := eksSvc.ListNodegroupsPages(&eks.ListNodegroupsInput{ClusterName: clusterName},
 		func(page *eks.ListNodegroupsOutput, lastPage bool) bool {
@@ -184,6 +199,31 @@ func getEKSNodegroups(eksSvc eksiface.EKSAPI, clusterName *string) ([]*awsmodels
 	if err != nil {
 		return nil, errors.Wrapf(err, "EKS.ListNodegroupsPages: %s", aws.StringValue(clusterName))
 	}
+	return nodeGroups, nil
+}
+
+// describeEKSNodegroup provides detailed information for a given node group, or nil if it no longer exists
+func describeEKSNodegroup(eksSvc eksiface.EKSAPI, clusterName, nodegroupName *string) (*eks.Nodegroup, error) {
+	out, err := eksSvc.DescribeNodegroup(&eks.DescribeNodegroupInput{
+		ClusterName:   clusterName,
+		NodegroupName: nodegroupName,
+	})
+	if err != nil {
+		if isEksNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "EKS.DescribeNodegroup: %s", aws.StringValue(nodegroupName))
+	}
+	return out.Nodegroup, nil
+}
+
+// getEKSNodeGroups enumerates and then describes all active node groups of a cluster
+func getEKSNodegroups(eksSvc eksiface.EKSAPI, clusterName *string) ([]*awsmodels.EksNodegroup, error) {
+	// Enumerate Nodegroups
+	nodeGroups, err := listEKSNodegroups(eksSvc, clusterName)
+	if err != nil {
+		return nil, err
+	}
 
 	// If there are no services, stop here
 	if len(nodeGroups) == 0 {
@@ -194,16 +234,15 @@ func getEKSNodegroups(eksSvc eksiface.EKSAPI, clusterName *string) ([]*awsmodels
 	nodegroupResults := make([]*awsmodels.EksNodegroup, 0, len(nodeGroups))
 
 	for _, nodegroup := range nodeGroups {
-		rawNodegroup, err := eksSvc.DescribeNodegroup(&eks.DescribeNodegroupInput{
-			ClusterName:   clusterName,
-			NodegroupName: nodegroup,
-		})
-
+		rawNodegroup, err := describeEKSNodegroup(eksSvc, clusterName, nodegroup)
 		if err != nil {
-			return nil, errors.Wrapf(err, "EKS.DescribeNodegroup: %s", aws.StringValue(nodegroup))
+			return nil, err
+		}
+		if rawNodegroup == nil {
+			continue
 		}
 
-		curNodegroup := *rawNodegroup.Nodegroup
+		curNodegroup := *rawNodegroup
 		nodegroupResults = append(nodegroupResults, &awsmodels.EksNodegroup{
 			GenericAWSResource: awsmodels.GenericAWSResource{
 				ARN:  curNodegroup.NodegroupArn,
@@ -243,8 +282,7 @@ func buildEksClusterSnapshot(
 	}
 
 	details, err := describeEKSCluster(eksSvc, clusterName)
-
-	if err != nil {
+	if err != nil || details == nil {
 		return nil, err
 	}
 
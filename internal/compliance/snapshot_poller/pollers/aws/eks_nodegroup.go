@@ -0,0 +1,166 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/aws/aws-sdk-go/service/eks/eksiface"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+)
+
+// PollEKSNodeGroup polls a single EKS managed node group resource
+func PollEKSNodeGroup(
+	pollerInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	scanRequest *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	// Node group ARNs are of the form nodegroup/<cluster>/<nodegroup>/<uuid>
+	parts := strings.Split(resourceARN.Resource, "/")
+	if len(parts) < 3 {
+		return nil, errors.Errorf("unable to parse EKS node group ARN: %s", resourceARN.String())
+	}
+
+	client, err := getEksClient(pollerInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	nodegroup, err := describeEKSNodegroup(client, aws.String(parts[1]), aws.String(parts[2]))
+	if err != nil {
+		return nil, err
+	}
+	if nodegroup == nil {
+		zap.L().Warn("tried to scan non-existent resource",
+			zap.String("resource", resourceARN.String()),
+			zap.String("resourceType", awsmodels.EksNodeGroupSchema))
+		return nil, nil
+	}
+
+	snapshot := buildEksNodeGroupSnapshot(nodegroup)
+	snapshot.Region = aws.String(resourceARN.Region)
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+
+	return snapshot, nil
+}
+
+// buildEksNodeGroupSnapshot returns a snapshot of an EKS managed node group
+func buildEksNodeGroupSnapshot(nodegroup *eks.Nodegroup) *awsmodels.EksNodeGroupResource {
+	return &awsmodels.EksNodeGroupResource{
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  nodegroup.NodegroupArn,
+			Name: nodegroup.NodegroupName,
+			Tags: nodegroup.Tags,
+		},
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   nodegroup.NodegroupArn,
+			ResourceType: aws.String(awsmodels.EksNodeGroupSchema),
+			TimeCreated:  nodegroup.CreatedAt,
+		},
+		AmiType:        nodegroup.AmiType,
+		ClusterName:    nodegroup.ClusterName,
+		DiskSize:       nodegroup.DiskSize,
+		Health:         nodegroup.Health,
+		InstanceTypes:  nodegroup.InstanceTypes,
+		Labels:         nodegroup.Labels,
+		LaunchTemplate: nodegroup.LaunchTemplate,
+		ModifiedAt:     nodegroup.ModifiedAt,
+		NodeRole:       nodegroup.NodeRole,
+		ReleaseVersion: nodegroup.ReleaseVersion,
+		RemoteAccess:   nodegroup.RemoteAccess,
+		Resources:      nodegroup.Resources,
+		ScalingConfig:  nodegroup.ScalingConfig,
+		Status:         nodegroup.Status,
+		Subnets:        nodegroup.Subnets,
+		Version:        nodegroup.Version,
+	}
+}
+
+// getEKSNodeGroupSnapshots builds snapshots of all managed node groups of a cluster
+func getEKSNodeGroupSnapshots(eksSvc eksiface.EKSAPI, clusterName *string) ([]*awsmodels.EksNodeGroupResource, error) {
+	nodegroupNames, err := listEKSNodegroups(eksSvc, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]*awsmodels.EksNodeGroupResource, 0, len(nodegroupNames))
+	for _, nodegroupName := range nodegroupNames {
+		nodegroup, err := describeEKSNodegroup(eksSvc, clusterName, nodegroupName)
+		if err != nil {
+			return nil, err
+		}
+		if nodegroup == nil {
+			continue
+		}
+		snapshots = append(snapshots, buildEksNodeGroupSnapshot(nodegroup))
+	}
+	return snapshots, nil
+}
+
+// PollEksNodeGroups gathers information on each EKS managed node group for an AWS account.
+//
+// Node groups are scanned one page of clusters at a time.
+func PollEksNodeGroups(pollerInput *awsmodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	zap.L().Debug("starting EKS Node Group resource poller")
+
+	eksSvc, err := getEksClient(pollerInput, *pollerInput.Region)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clusters, marker, err := listEKSClusters(eksSvc, pollerInput.NextPageToken)
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "region: %s", *pollerInput.Region)
+	}
+
+	var resources []apimodels.AddResourceEntry
+	for _, clusterName := range clusters {
+		nodegroupSnapshots, err := getEKSNodeGroupSnapshots(eksSvc, clusterName)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, nodegroupSnapshot := range nodegroupSnapshots {
+			if pollerInput.ShouldIgnoreResource(*nodegroupSnapshot.ResourceID) {
+				continue
+			}
+			nodegroupSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+			nodegroupSnapshot.Region = pollerInput.Region
+
+			resources = append(resources, apimodels.AddResourceEntry{
+				Attributes:      nodegroupSnapshot,
+				ID:              *nodegroupSnapshot.ResourceID,
+				IntegrationID:   *pollerInput.IntegrationID,
+				IntegrationType: integrationType,
+				Type:            awsmodels.EksNodeGroupSchema,
+			})
+		}
+	}
+
+	return resources, marker, nil
+}
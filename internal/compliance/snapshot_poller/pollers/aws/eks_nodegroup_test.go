@@ -0,0 +1,150 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestBuildEksNodeGroupSnapshot(t *testing.T) {
+	nodegroup := awstest.ExampleEksNodegroup[0]
+
+	snapshot := buildEksNodeGroupSnapshot(nodegroup)
+	assert.Equal(t, awstest.ExampleNodegroupArn, snapshot.ResourceID)
+	assert.Equal(t, aws.String(awsmodels.EksNodeGroupSchema), snapshot.ResourceType)
+	assert.Equal(t, aws.String("example-cluster"), snapshot.ClusterName)
+	assert.Equal(t, awstest.ExampleLabels, snapshot.Labels)
+	assert.Equal(t, awstest.ExampleTags, snapshot.Tags)
+}
+
+func TestGetEksNodeGroupSnapshots(t *testing.T) {
+	mockSvc := awstest.BuildMockEksSvc([]string{"ListNodegroupsPages", "DescribeNodegroup"})
+
+	snapshots, err := getEKSNodeGroupSnapshots(mockSvc, awstest.ExampleEksClusterName)
+	require.NoError(t, err)
+	assert.Len(t, snapshots, 2)
+}
+
+func TestGetEksNodeGroupSnapshotsSkipsDeleted(t *testing.T) {
+	mockSvc := awstest.BuildMockEksSvc([]string{"ListNodegroupsPages"})
+	mockSvc.On("DescribeNodegroup", mock.Anything).Return(
+		&eks.DescribeNodegroupOutput{},
+		awserr.New(eks.ErrCodeResourceNotFoundException, "not found", nil),
+	)
+
+	snapshots, err := getEKSNodeGroupSnapshots(mockSvc, awstest.ExampleEksClusterName)
+	require.NoError(t, err)
+	assert.Empty(t, snapshots)
+}
+
+func TestGetEksNodeGroupSnapshotsError(t *testing.T) {
+	mockSvc := awstest.BuildMockEksSvcError([]string{"ListNodegroupsPages"})
+
+	snapshots, err := getEKSNodeGroupSnapshots(mockSvc, awstest.ExampleEksClusterName)
+	assert.Error(t, err)
+	assert.Nil(t, snapshots)
+}
+
+func TestEksNodeGroupPollSingle(t *testing.T) {
+	resetCache()
+	mockSvc := awstest.BuildMockEksSvc([]string{"DescribeNodegroup"})
+	awstest.MockEksForSetup = mockSvc
+
+	EksClientFunc = awstest.SetupMockEks
+
+	resourceARN, err := arn.Parse(*awstest.ExampleNodegroupArn)
+	require.NoError(t, err)
+	snapshot, err := PollEKSNodeGroup(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Timestamp:           &awstest.ExampleTime,
+	}, resourceARN, &pollermodels.ScanEntry{ResourceID: awstest.ExampleNodegroupArn})
+
+	require.NoError(t, err)
+	require.NotNil(t, snapshot)
+	nodegroup := snapshot.(*awsmodels.EksNodeGroupResource)
+	assert.Equal(t, aws.String("123456789012"), nodegroup.AccountID)
+	assert.Equal(t, aws.String("us-west-2"), nodegroup.Region)
+	mockSvc.AssertCalled(t, "DescribeNodegroup", &eks.DescribeNodegroupInput{
+		ClusterName:   aws.String("example-cluster"),
+		NodegroupName: aws.String("example-nodegroup-name"),
+	})
+}
+
+func TestEksNodeGroupPollSingleInvalidARN(t *testing.T) {
+	snapshot, err := PollEKSNodeGroup(&awsmodels.ResourcePollerInput{},
+		arn.ARN{Resource: "nodegroup/example-cluster"}, &pollermodels.ScanEntry{})
+	assert.Error(t, err)
+	assert.Nil(t, snapshot)
+}
+
+func TestEksNodeGroupPoller(t *testing.T) {
+	resetCache()
+	awstest.MockEksForSetup = awstest.BuildMockEksSvcAll()
+
+	EksClientFunc = awstest.SetupMockEks
+
+	resources, marker, err := PollEksNodeGroups(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, resources)
+	assert.Equal(t, awsmodels.EksNodeGroupSchema, resources[0].Type)
+	assert.Equal(t, *awstest.ExampleNodegroupArn, resources[0].ID)
+	assert.Nil(t, marker)
+}
+
+func TestEksNodeGroupPollerError(t *testing.T) {
+	resetCache()
+	awstest.MockEksForSetup = awstest.BuildMockEksSvcAllError()
+
+	EksClientFunc = awstest.SetupMockEks
+
+	resources, marker, err := PollEksNodeGroups(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	for _, event := range resources {
+		assert.Nil(t, event.Attributes)
+	}
+	assert.Nil(t, marker)
+	assert.Error(t, err)
+}
@@ -0,0 +1,249 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/elasticache"
+	"github.com/aws/aws-sdk-go/service/elasticache/elasticacheiface"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// Set as variables to be overridden in testing
+var (
+	ElastiCacheClientFunc = setupElastiCacheClient
+)
+
+func setupElastiCacheClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return elasticache.New(sess, cfg)
+}
+
+func getElastiCacheClient(pollerResourceInput *awsmodels.ResourcePollerInput,
+	region string) (elasticacheiface.ElastiCacheAPI, error) {
+
+	client, err := getClient(pollerResourceInput, ElastiCacheClientFunc, "elasticache", region)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.(elasticacheiface.ElastiCacheAPI), nil
+}
+
+// PollElastiCacheReplicationGroup polls a single ElastiCache replication group resource
+func PollElastiCacheReplicationGroup(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	_ *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getElastiCacheClient(pollerResourceInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	replicationGroup, err := getReplicationGroup(
+		client, aws.String(strings.TrimPrefix(resourceARN.Resource, "replicationgroup:")))
+	if err != nil || replicationGroup == nil {
+		return nil, err
+	}
+
+	snapshot, err := buildElastiCacheReplicationGroupSnapshot(client, replicationGroup)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	return snapshot, nil
+}
+
+// getReplicationGroup returns a single ElastiCache replication group, or nil if it no longer exists
+func getReplicationGroup(svc elasticacheiface.ElastiCacheAPI, id *string) (*elasticache.ReplicationGroup, error) {
+	out, err := svc.DescribeReplicationGroups(&elasticache.DescribeReplicationGroupsInput{ReplicationGroupId: id})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == elasticache.ErrCodeReplicationGroupNotFoundFault {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", aws.StringValue(id)),
+				zap.String("resourceType", awsmodels.ElastiCacheReplicationGroupSchema))
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "ElastiCache.DescribeReplicationGroups: %s", aws.StringValue(id))
+	}
+	if len(out.ReplicationGroups) != 1 {
+		return nil, errors.Errorf("ElastiCache.DescribeReplicationGroups: expected exactly 1 replication group for %s, found %d",
+			aws.StringValue(id), len(out.ReplicationGroups))
+	}
+	return out.ReplicationGroups[0], nil
+}
+
+// describeReplicationGroups returns all the ElastiCache replication groups in the account
+func describeReplicationGroups(svc elasticacheiface.ElastiCacheAPI, nextMarker *string) (
+	replicationGroups []*elasticache.ReplicationGroup, marker *string, err error) {
+
+	err = svc.DescribeReplicationGroupsPages(&elasticache.DescribeReplicationGroupsInput{
+		Marker:     nextMarker,
+		MaxRecords: aws.Int64(int64(defaultBatchSize)),
+	},
+		func(page *elasticache.DescribeReplicationGroupsOutput, lastPage bool) bool {
+			return elastiCacheReplicationGroupIterator(page, &replicationGroups, &marker)
+		})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "ElastiCache.DescribeReplicationGroupsPages")
+	}
+	return
+}
+
+func elastiCacheReplicationGroupIterator(
+	page *elasticache.DescribeReplicationGroupsOutput, replicationGroups *[]*elasticache.ReplicationGroup, marker **string) bool {
+
+	*replicationGroups = append(*replicationGroups, page.ReplicationGroups...)
+	*marker = page.Marker
+	return len(*replicationGroups) < defaultBatchSize
+}
+
+// describeCacheCluster returns a single member cache cluster of a replication group
+func describeCacheCluster(svc elasticacheiface.ElastiCacheAPI, id *string) (*elasticache.CacheCluster, error) {
+	out, err := svc.DescribeCacheClusters(&elasticache.DescribeCacheClustersInput{CacheClusterId: id})
+	if err != nil {
+		return nil, errors.Wrapf(err, "ElastiCache.DescribeCacheClusters: %s", aws.StringValue(id))
+	}
+	if len(out.CacheClusters) == 0 {
+		return nil, nil
+	}
+	return out.CacheClusters[0], nil
+}
+
+// listElastiCacheTags returns the tags of an ElastiCache resource
+func listElastiCacheTags(svc elasticacheiface.ElastiCacheAPI, resourceARN *string) ([]*elasticache.Tag, error) {
+	out, err := svc.ListTagsForResource(&elasticache.ListTagsForResourceInput{ResourceName: resourceARN})
+	if err != nil {
+		return nil, errors.Wrapf(err, "ElastiCache.ListTagsForResource: %s", aws.StringValue(resourceARN))
+	}
+	return out.TagList, nil
+}
+
+// buildElastiCacheReplicationGroupSnapshot makes all the calls to build up a snapshot of a given
+// ElastiCache replication group
+func buildElastiCacheReplicationGroupSnapshot(
+	svc elasticacheiface.ElastiCacheAPI,
+	replicationGroup *elasticache.ReplicationGroup,
+) (*awsmodels.ElastiCacheReplicationGroup, error) {
+
+	snapshot := &awsmodels.ElastiCacheReplicationGroup{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   replicationGroup.ARN,
+			ResourceType: aws.String(awsmodels.ElastiCacheReplicationGroupSchema),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  replicationGroup.ARN,
+			ID:   replicationGroup.ReplicationGroupId,
+			Name: replicationGroup.ReplicationGroupId,
+		},
+		AtRestEncryptionEnabled:    replicationGroup.AtRestEncryptionEnabled,
+		AuthTokenEnabled:           replicationGroup.AuthTokenEnabled,
+		AuthTokenLastModifiedDate:  replicationGroup.AuthTokenLastModifiedDate,
+		AutomaticFailover:          replicationGroup.AutomaticFailover,
+		CacheNodeType:              replicationGroup.CacheNodeType,
+		ClusterEnabled:             replicationGroup.ClusterEnabled,
+		ConfigurationEndpoint:      replicationGroup.ConfigurationEndpoint,
+		Description:                replicationGroup.Description,
+		GlobalReplicationGroupInfo: replicationGroup.GlobalReplicationGroupInfo,
+		KmsKeyId:                   replicationGroup.KmsKeyId,
+		MemberClusters:             replicationGroup.MemberClusters,
+		MultiAZ:                    replicationGroup.MultiAZ,
+		NodeGroups:                 replicationGroup.NodeGroups,
+		SnapshotRetentionLimit:     replicationGroup.SnapshotRetentionLimit,
+		SnapshotWindow:             replicationGroup.SnapshotWindow,
+		SnapshottingClusterId:      replicationGroup.SnapshottingClusterId,
+		Status:                     replicationGroup.Status,
+		TransitEncryptionEnabled:   replicationGroup.TransitEncryptionEnabled,
+		UserGroupIds:               replicationGroup.UserGroupIds,
+	}
+
+	// The engine and network settings live on the member clusters, which all share them
+	if len(replicationGroup.MemberClusters) > 0 {
+		cacheCluster, err := describeCacheCluster(svc, replicationGroup.MemberClusters[0])
+		if err != nil {
+			return nil, err
+		}
+		if cacheCluster != nil {
+			snapshot.TimeCreated = cacheCluster.CacheClusterCreateTime
+			snapshot.AutoMinorVersionUpgrade = cacheCluster.AutoMinorVersionUpgrade
+			snapshot.CacheSubnetGroupName = cacheCluster.CacheSubnetGroupName
+			snapshot.Engine = cacheCluster.Engine
+			snapshot.EngineVersion = cacheCluster.EngineVersion
+			snapshot.SecurityGroups = cacheCluster.SecurityGroups
+		}
+	}
+
+	tags, err := listElastiCacheTags(svc, replicationGroup.ARN)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.Tags = utils.ParseTagSlice(tags)
+
+	return snapshot, nil
+}
+
+// PollElastiCacheReplicationGroups gathers information on each ElastiCache replication group for an AWS account.
+func PollElastiCacheReplicationGroups(pollerInput *awsmodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	zap.L().Debug("starting ElastiCache Replication Group resource poller")
+
+	elastiCacheSvc, err := getElastiCacheClient(pollerInput, *pollerInput.Region)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Start with generating a list of all replication groups
+	replicationGroups, marker, err := describeReplicationGroups(elastiCacheSvc, pollerInput.NextPageToken)
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "region: %s", *pollerInput.Region)
+	}
+
+	resources := make([]apimodels.AddResourceEntry, 0, len(replicationGroups))
+	for _, replicationGroup := range replicationGroups {
+		replicationGroupSnapshot, err := buildElastiCacheReplicationGroupSnapshot(elastiCacheSvc, replicationGroup)
+		if err != nil {
+			return nil, nil, err
+		}
+		replicationGroupSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+		replicationGroupSnapshot.Region = pollerInput.Region
+
+		resources = append(resources, apimodels.AddResourceEntry{
+			Attributes:      replicationGroupSnapshot,
+			ID:              *replicationGroupSnapshot.ResourceID,
+			IntegrationID:   *pollerInput.IntegrationID,
+			IntegrationType: integrationType,
+			Type:            awsmodels.ElastiCacheReplicationGroupSchema,
+		})
+	}
+
+	return resources, marker, nil
+}
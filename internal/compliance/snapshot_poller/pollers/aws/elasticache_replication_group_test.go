@@ -0,0 +1,173 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elasticache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestElastiCacheReplicationGroupList(t *testing.T) {
+	mockSvc := awstest.BuildMockElastiCacheSvc([]string{"DescribeReplicationGroupsPages"})
+
+	out, marker, err := describeReplicationGroups(mockSvc, nil)
+	assert.NotEmpty(t, out)
+	assert.Nil(t, marker)
+	assert.NoError(t, err)
+}
+
+// Test the iterator works on consecutive pages but stops at max page size
+func TestElastiCacheReplicationGroupListIterator(t *testing.T) {
+	var replicationGroups []*elasticache.ReplicationGroup
+	var marker *string
+
+	cont := elastiCacheReplicationGroupIterator(awstest.ExampleDescribeReplicationGroupsOutput, &replicationGroups, &marker)
+	assert.True(t, cont)
+	assert.Nil(t, marker)
+	assert.Len(t, replicationGroups, 1)
+
+	for i := 1; i < 50; i++ {
+		cont = elastiCacheReplicationGroupIterator(
+			awstest.ExampleDescribeReplicationGroupsOutputContinue, &replicationGroups, &marker)
+		assert.True(t, cont)
+		assert.NotNil(t, marker)
+		assert.Len(t, replicationGroups, 1+i*2)
+	}
+
+	cont = elastiCacheReplicationGroupIterator(
+		awstest.ExampleDescribeReplicationGroupsOutputContinue, &replicationGroups, &marker)
+	assert.False(t, cont)
+	assert.NotNil(t, marker)
+	assert.Len(t, replicationGroups, 101)
+}
+
+func TestElastiCacheReplicationGroupListError(t *testing.T) {
+	mockSvc := awstest.BuildMockElastiCacheSvcError([]string{"DescribeReplicationGroupsPages"})
+
+	out, marker, err := describeReplicationGroups(mockSvc, nil)
+	assert.Nil(t, out)
+	assert.Nil(t, marker)
+	assert.Error(t, err)
+}
+
+func TestElastiCacheReplicationGroupGet(t *testing.T) {
+	mockSvc := awstest.BuildMockElastiCacheSvc([]string{"DescribeReplicationGroups"})
+
+	out, err := getReplicationGroup(mockSvc, awstest.ExampleElastiCacheReplicationGroupID)
+	require.NoError(t, err)
+	assert.Equal(t, awstest.ExampleElastiCacheReplicationGroupID, out.ReplicationGroupId)
+}
+
+func TestElastiCacheReplicationGroupGetNotFound(t *testing.T) {
+	mockSvc := &awstest.MockElastiCache{}
+	mockSvc.On("DescribeReplicationGroups", mock.Anything).Return(
+		&elasticache.DescribeReplicationGroupsOutput{},
+		awserr.New(elasticache.ErrCodeReplicationGroupNotFoundFault, "Replication group not found", nil),
+	)
+
+	out, err := getReplicationGroup(mockSvc, awstest.ExampleElastiCacheReplicationGroupID)
+	require.NoError(t, err)
+	assert.Nil(t, out)
+}
+
+func TestElastiCacheListTags(t *testing.T) {
+	mockSvc := awstest.BuildMockElastiCacheSvc([]string{"ListTagsForResource"})
+
+	out, err := listElastiCacheTags(mockSvc, awstest.ExampleElastiCacheReplicationGroupArn)
+	require.NoError(t, err)
+	assert.NotEmpty(t, out)
+}
+
+func TestElastiCacheListTagsError(t *testing.T) {
+	mockSvc := awstest.BuildMockElastiCacheSvcError([]string{"ListTagsForResource"})
+
+	out, err := listElastiCacheTags(mockSvc, awstest.ExampleElastiCacheReplicationGroupArn)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestBuildElastiCacheReplicationGroupSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockElastiCacheSvcAll()
+
+	groupSnapshot, err := buildElastiCacheReplicationGroupSnapshot(mockSvc, awstest.ExampleElastiCacheReplicationGroup)
+	require.NoError(t, err)
+	assert.Equal(t, "example-group", *groupSnapshot.Name)
+	assert.True(t, *groupSnapshot.AtRestEncryptionEnabled)
+	assert.Equal(t, "redis", *groupSnapshot.Engine)
+	assert.Len(t, groupSnapshot.SecurityGroups, 1)
+	assert.Equal(t, "Value1", *groupSnapshot.Tags["Key1"])
+}
+
+func TestBuildElastiCacheReplicationGroupSnapshotErrors(t *testing.T) {
+	mockSvc := awstest.BuildMockElastiCacheSvcAllError()
+
+	groupSnapshot, err := buildElastiCacheReplicationGroupSnapshot(mockSvc, awstest.ExampleElastiCacheReplicationGroup)
+	assert.Nil(t, groupSnapshot)
+	assert.Error(t, err)
+}
+
+func TestElastiCacheReplicationGroupPoller(t *testing.T) {
+	resetCache()
+	awstest.MockElastiCacheForSetup = awstest.BuildMockElastiCacheSvcAll()
+
+	ElastiCacheClientFunc = awstest.SetupMockElastiCache
+
+	resources, marker, err := PollElastiCacheReplicationGroups(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, *awstest.ExampleElastiCacheReplicationGroupArn, resources[0].ID)
+	assert.Equal(t, awsmodels.ElastiCacheReplicationGroupSchema, resources[0].Type)
+	assert.Nil(t, marker)
+}
+
+func TestElastiCacheReplicationGroupPollerError(t *testing.T) {
+	resetCache()
+	awstest.MockElastiCacheForSetup = awstest.BuildMockElastiCacheSvcAllError()
+
+	ElastiCacheClientFunc = awstest.SetupMockElastiCache
+
+	resources, marker, err := PollElastiCacheReplicationGroups(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	for _, event := range resources {
+		assert.Nil(t, event.Attributes)
+	}
+	assert.Nil(t, marker)
+	assert.Error(t, err)
+}
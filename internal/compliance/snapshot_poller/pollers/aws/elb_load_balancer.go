@@ -0,0 +1,256 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elb/elbiface"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// Set as variables to be overridden in testing
+var (
+	ElbClientFunc = setupElbClient
+)
+
+func setupElbClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return elb.New(sess, cfg)
+}
+
+func getElbClient(pollerResourceInput *awsmodels.ResourcePollerInput, region string) (elbiface.ELBAPI, error) {
+	client, err := getClient(pollerResourceInput, ElbClientFunc, "elb", region)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.(elbiface.ELBAPI), nil
+}
+
+// PollELBLoadBalancer polls a single classic load balancer resource
+func PollELBLoadBalancer(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	_ *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getElbClient(pollerResourceInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	loadBalancer, err := describeClassicLoadBalancer(client, aws.String(strings.TrimPrefix(resourceARN.Resource, "loadbalancer/")))
+	if err != nil || loadBalancer == nil {
+		return nil, err
+	}
+
+	snapshot, err := buildElbLoadBalancerSnapshot(client, loadBalancer, resourceARN.Region, resourceARN.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	return snapshot, nil
+}
+
+// describeClassicLoadBalancers returns all the classic load balancers in the account in the current region
+func describeClassicLoadBalancers(elbSvc elbiface.ELBAPI, nextMarker *string) (
+	loadBalancers []*elb.LoadBalancerDescription, marker *string, err error) {
+
+	err = elbSvc.DescribeLoadBalancersPages(&elb.DescribeLoadBalancersInput{
+		Marker:   nextMarker,
+		PageSize: aws.Int64(int64(defaultBatchSize)),
+	},
+		func(page *elb.DescribeLoadBalancersOutput, lastPage bool) bool {
+			return classicLoadBalancerIterator(page, &loadBalancers, &marker)
+		})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "ELB.DescribeLoadBalancersPages")
+	}
+	return
+}
+
+func classicLoadBalancerIterator(
+	page *elb.DescribeLoadBalancersOutput, loadBalancers *[]*elb.LoadBalancerDescription, marker **string) bool {
+
+	*loadBalancers = append(*loadBalancers, page.LoadBalancerDescriptions...)
+	*marker = page.NextMarker
+	return len(*loadBalancers) < defaultBatchSize
+}
+
+// describeClassicLoadBalancer returns a single classic load balancer, or nil if it no longer exists
+func describeClassicLoadBalancer(elbSvc elbiface.ELBAPI, name *string) (*elb.LoadBalancerDescription, error) {
+	out, err := elbSvc.DescribeLoadBalancers(&elb.DescribeLoadBalancersInput{
+		LoadBalancerNames: []*string{name},
+	})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == elb.ErrCodeAccessPointNotFoundException {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", aws.StringValue(name)),
+				zap.String("resourceType", awsmodels.ElbLoadBalancerSchema))
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "ELB.DescribeLoadBalancers: %s", aws.StringValue(name))
+	}
+	if len(out.LoadBalancerDescriptions) != 1 {
+		return nil, errors.Errorf("ELB.DescribeLoadBalancers: expected exactly one load balancer for %s, found %d",
+			aws.StringValue(name), len(out.LoadBalancerDescriptions))
+	}
+	return out.LoadBalancerDescriptions[0], nil
+}
+
+// describeClassicLoadBalancerAttributes returns the access log, connection and cross zone settings
+// of a classic load balancer
+func describeClassicLoadBalancerAttributes(elbSvc elbiface.ELBAPI, name *string) (*elb.LoadBalancerAttributes, error) {
+	out, err := elbSvc.DescribeLoadBalancerAttributes(&elb.DescribeLoadBalancerAttributesInput{LoadBalancerName: name})
+	if err != nil {
+		return nil, errors.Wrapf(err, "ELB.DescribeLoadBalancerAttributes: %s", aws.StringValue(name))
+	}
+	return out.LoadBalancerAttributes, nil
+}
+
+// describeClassicLoadBalancerPolicies returns the policies of a classic load balancer, including the
+// SSL negotiation policies used by its listeners
+func describeClassicLoadBalancerPolicies(elbSvc elbiface.ELBAPI, name *string) ([]*elb.PolicyDescription, error) {
+	out, err := elbSvc.DescribeLoadBalancerPolicies(&elb.DescribeLoadBalancerPoliciesInput{LoadBalancerName: name})
+	if err != nil {
+		return nil, errors.Wrapf(err, "ELB.DescribeLoadBalancerPolicies: %s", aws.StringValue(name))
+	}
+	return out.PolicyDescriptions, nil
+}
+
+// describeClassicLoadBalancerTags returns the tags of a classic load balancer
+func describeClassicLoadBalancerTags(elbSvc elbiface.ELBAPI, name *string) ([]*elb.Tag, error) {
+	out, err := elbSvc.DescribeTags(&elb.DescribeTagsInput{LoadBalancerNames: []*string{name}})
+	if err != nil {
+		return nil, errors.Wrapf(err, "ELB.DescribeTags: %s", aws.StringValue(name))
+	}
+	if len(out.TagDescriptions) == 0 {
+		return nil, nil
+	}
+	return out.TagDescriptions[0].Tags, nil
+}
+
+// buildElbLoadBalancerSnapshot makes all the calls to build up a snapshot of a given classic load balancer
+func buildElbLoadBalancerSnapshot(
+	elbSvc elbiface.ELBAPI,
+	lb *elb.LoadBalancerDescription,
+	region, accountID string,
+) (*awsmodels.ElbLoadBalancer, error) {
+
+	// The classic load balancer API does not return ARNs
+	loadBalancerARN := aws.String(arn.ARN{
+		Partition: "aws",
+		Service:   "elasticloadbalancing",
+		Region:    region,
+		AccountID: accountID,
+		Resource:  "loadbalancer/" + aws.StringValue(lb.LoadBalancerName),
+	}.String())
+
+	snapshot := &awsmodels.ElbLoadBalancer{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   loadBalancerARN,
+			ResourceType: aws.String(awsmodels.ElbLoadBalancerSchema),
+			TimeCreated:  lb.CreatedTime,
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  loadBalancerARN,
+			Name: lb.LoadBalancerName,
+		},
+		AvailabilityZones:         lb.AvailabilityZones,
+		BackendServerDescriptions: lb.BackendServerDescriptions,
+		CanonicalHostedZoneName:   lb.CanonicalHostedZoneName,
+		CanonicalHostedZoneNameID: lb.CanonicalHostedZoneNameID,
+		DNSName:                   lb.DNSName,
+		HealthCheck:               lb.HealthCheck,
+		Instances:                 lb.Instances,
+		ListenerDescriptions:      lb.ListenerDescriptions,
+		Policies:                  lb.Policies,
+		Scheme:                    lb.Scheme,
+		SecurityGroups:            lb.SecurityGroups,
+		SourceSecurityGroup:       lb.SourceSecurityGroup,
+		Subnets:                   lb.Subnets,
+		VPCId:                     lb.VPCId,
+	}
+
+	var err error
+	if snapshot.Attributes, err = describeClassicLoadBalancerAttributes(elbSvc, lb.LoadBalancerName); err != nil {
+		return nil, err
+	}
+	if snapshot.PolicyDescriptions, err = describeClassicLoadBalancerPolicies(elbSvc, lb.LoadBalancerName); err != nil {
+		return nil, err
+	}
+
+	tags, err := describeClassicLoadBalancerTags(elbSvc, lb.LoadBalancerName)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.Tags = utils.ParseTagSlice(tags)
+
+	return snapshot, nil
+}
+
+// PollElbLoadBalancers gathers information on each classic load balancer for an AWS account.
+func PollElbLoadBalancers(pollerInput *awsmodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	zap.L().Debug("starting ELB Classic Load Balancer resource poller")
+
+	elbSvc, err := getElbClient(pollerInput, *pollerInput.Region)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Start with generating a list of all classic load balancers
+	loadBalancers, marker, err := describeClassicLoadBalancers(elbSvc, pollerInput.NextPageToken)
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "region: %s", *pollerInput.Region)
+	}
+
+	accountID := pollerInput.AuthSourceParsedARN.AccountID
+	resources := make([]apimodels.AddResourceEntry, 0, len(loadBalancers))
+	for _, loadBalancer := range loadBalancers {
+		loadBalancerSnapshot, err := buildElbLoadBalancerSnapshot(elbSvc, loadBalancer, *pollerInput.Region, accountID)
+		if err != nil {
+			return nil, nil, err
+		}
+		loadBalancerSnapshot.AccountID = aws.String(accountID)
+		loadBalancerSnapshot.Region = pollerInput.Region
+
+		resources = append(resources, apimodels.AddResourceEntry{
+			Attributes:      loadBalancerSnapshot,
+			ID:              *loadBalancerSnapshot.ResourceID,
+			IntegrationID:   *pollerInput.IntegrationID,
+			IntegrationType: integrationType,
+			Type:            awsmodels.ElbLoadBalancerSchema,
+		})
+	}
+
+	return resources, marker, nil
+}
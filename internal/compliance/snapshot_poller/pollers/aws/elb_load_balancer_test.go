@@ -0,0 +1,182 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestElbLoadBalancerDescribe(t *testing.T) {
+	mockSvc := awstest.BuildMockElbSvc([]string{"DescribeLoadBalancersPages"})
+
+	out, marker, err := describeClassicLoadBalancers(mockSvc, nil)
+	assert.NotEmpty(t, out)
+	assert.Nil(t, marker)
+	assert.NoError(t, err)
+}
+
+// Test the iterator works on consecutive pages but stops at max page size
+func TestElbLoadBalancerDescribeIterator(t *testing.T) {
+	var loadBalancers []*elb.LoadBalancerDescription
+	var marker *string
+
+	cont := classicLoadBalancerIterator(awstest.ExampleDescribeClassicLoadBalancersOutput, &loadBalancers, &marker)
+	assert.True(t, cont)
+	assert.Nil(t, marker)
+	assert.Len(t, loadBalancers, 1)
+
+	for i := 1; i < 50; i++ {
+		cont = classicLoadBalancerIterator(awstest.ExampleDescribeClassicLoadBalancersOutputContinue, &loadBalancers, &marker)
+		assert.True(t, cont)
+		assert.NotNil(t, marker)
+		assert.Len(t, loadBalancers, 1+i*2)
+	}
+
+	cont = classicLoadBalancerIterator(awstest.ExampleDescribeClassicLoadBalancersOutputContinue, &loadBalancers, &marker)
+	assert.False(t, cont)
+	assert.NotNil(t, marker)
+	assert.Len(t, loadBalancers, 101)
+}
+
+func TestElbLoadBalancerDescribeError(t *testing.T) {
+	mockSvc := awstest.BuildMockElbSvcError([]string{"DescribeLoadBalancersPages"})
+
+	out, marker, err := describeClassicLoadBalancers(mockSvc, nil)
+	assert.Nil(t, out)
+	assert.Nil(t, marker)
+	assert.Error(t, err)
+}
+
+func TestElbLoadBalancerDescribeSingleNotFound(t *testing.T) {
+	mockSvc := &awstest.MockElb{}
+	mockSvc.On("DescribeLoadBalancers", mock.Anything).Return(
+		&elb.DescribeLoadBalancersOutput{},
+		awserr.New(elb.ErrCodeAccessPointNotFoundException, "not found", nil),
+	)
+
+	out, err := describeClassicLoadBalancer(mockSvc, awstest.ExampleClassicLoadBalancerName)
+	require.NoError(t, err)
+	assert.Nil(t, out)
+}
+
+func TestElbLoadBalancerBuildSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockElbSvcAll()
+
+	snapshot, err := buildElbLoadBalancerSnapshot(mockSvc, awstest.ExampleClassicLoadBalancer, "us-west-2", "123456789012")
+	require.NoError(t, err)
+	assert.Equal(t, awstest.ExampleClassicLoadBalancerARN, snapshot.ResourceID)
+	assert.Equal(t, awstest.ExampleClassicLoadBalancerName, snapshot.Name)
+	assert.Equal(t, aws.Bool(false), snapshot.Attributes.AccessLog.Enabled)
+	assert.Len(t, snapshot.PolicyDescriptions, 1)
+	assert.Equal(t, map[string]*string{"Key1": aws.String("Value1")}, snapshot.Tags)
+}
+
+func TestElbLoadBalancerBuildSnapshotErrors(t *testing.T) {
+	mockSvc := awstest.BuildMockElbSvcError([]string{"DescribeLoadBalancerPolicies"})
+	mockSvc.On("DescribeLoadBalancerAttributes", mock.Anything).
+		Return(awstest.ExampleDescribeLoadBalancerAttributesOutput, nil)
+
+	snapshot, err := buildElbLoadBalancerSnapshot(mockSvc, awstest.ExampleClassicLoadBalancer, "us-west-2", "123456789012")
+	assert.Error(t, err)
+	assert.Nil(t, snapshot)
+
+	mockSvc = awstest.BuildMockElbSvcAllError()
+	snapshot, err = buildElbLoadBalancerSnapshot(mockSvc, awstest.ExampleClassicLoadBalancer, "us-west-2", "123456789012")
+	assert.Error(t, err)
+	assert.Nil(t, snapshot)
+}
+
+func TestElbLoadBalancerPollSingle(t *testing.T) {
+	resetCache()
+	mockSvc := awstest.BuildMockElbSvcAll()
+	awstest.MockElbForSetup = mockSvc
+
+	ElbClientFunc = awstest.SetupMockElb
+
+	resourceARN, err := arn.Parse(*awstest.ExampleClassicLoadBalancerARN)
+	require.NoError(t, err)
+	snapshot, err := PollELBLoadBalancer(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Timestamp:           &awstest.ExampleTime,
+	}, resourceARN, &pollermodels.ScanEntry{ResourceID: awstest.ExampleClassicLoadBalancerARN})
+
+	require.NoError(t, err)
+	loadBalancer := snapshot.(*awsmodels.ElbLoadBalancer)
+	assert.Equal(t, aws.String("123456789012"), loadBalancer.AccountID)
+	assert.Equal(t, aws.String("us-west-2"), loadBalancer.Region)
+	mockSvc.AssertCalled(t, "DescribeLoadBalancers", &elb.DescribeLoadBalancersInput{
+		LoadBalancerNames: []*string{awstest.ExampleClassicLoadBalancerName},
+	})
+}
+
+func TestElbLoadBalancerPoller(t *testing.T) {
+	resetCache()
+	awstest.MockElbForSetup = awstest.BuildMockElbSvcAll()
+
+	ElbClientFunc = awstest.SetupMockElb
+
+	resources, marker, err := PollElbLoadBalancers(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, awsmodels.ElbLoadBalancerSchema, resources[0].Type)
+	assert.Equal(t, *awstest.ExampleClassicLoadBalancerARN, resources[0].ID)
+	assert.Nil(t, marker)
+}
+
+func TestElbLoadBalancerPollerError(t *testing.T) {
+	resetCache()
+	awstest.MockElbForSetup = awstest.BuildMockElbSvcAllError()
+
+	ElbClientFunc = awstest.SetupMockElb
+
+	resources, marker, err := PollElbLoadBalancers(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	for _, event := range resources {
+		assert.Nil(t, event.Attributes)
+	}
+	assert.Nil(t, marker)
+	assert.Error(t, err)
+}
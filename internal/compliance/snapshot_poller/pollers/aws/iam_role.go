@@ -130,7 +130,7 @@ func getRolePolicy(iamSvc iamiface.IAMAPI, roleName *string, policyName *string)
 // getRolePolicies aggregates all the policies assigned to a user by polling both
 // the ListRolePolicies and ListAttachedRolePolicies APIs.
 func getRolePolicies(iamSvc iamiface.IAMAPI, roleName *string) (
-	inlinePolicies []*string, managedPolicies []*string, err error) {
+	inlinePolicies []*string, managedPolicies []*iam.AttachedPolicy, err error) {
 
 	err = iamSvc.ListRolePoliciesPages(
 		&iam.ListRolePoliciesInput{RoleName: roleName},
@@ -150,9 +150,7 @@ func getRolePolicies(iamSvc iamiface.IAMAPI, roleName *string) (
 	err = iamSvc.ListAttachedRolePoliciesPages(
 		&iam.ListAttachedRolePoliciesInput{RoleName: roleName},
 		func(page *iam.ListAttachedRolePoliciesOutput, lastPage bool) bool {
-			for _, attachedPolicy := range page.AttachedPolicies {
-				managedPolicies = append(managedPolicies, attachedPolicy.PolicyName)
-			}
+			managedPolicies = append(managedPolicies, page.AttachedPolicies...)
 			return true
 		},
 	)
@@ -202,7 +200,10 @@ func BuildIAMRoleSnapshot(iamSvc iamiface.IAMAPI, role *iam.Role) (*awsmodels.IA
 	if err != nil {
 		return nil, err
 	}
-	iamRoleSnapshot.ManagedPolicyNames = managedPolicies
+	for _, managedPolicy := range managedPolicies {
+		iamRoleSnapshot.ManagedPolicyNames = append(iamRoleSnapshot.ManagedPolicyNames, managedPolicy.PolicyName)
+		iamRoleSnapshot.ManagedPolicyARNs = append(iamRoleSnapshot.ManagedPolicyARNs, managedPolicy.PolicyArn)
+	}
 	if inlinePolicies != nil {
 		iamRoleSnapshot.InlinePolicies = make(map[string]*string, len(inlinePolicies))
 		for _, inlinePolicy := range inlinePolicies {
@@ -105,7 +105,10 @@ func TestIAMRolesGetPolicies(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(
 		t,
-		[]*string{aws.String("AdministratorAccess")},
+		[]*iam.AttachedPolicy{{
+			PolicyArn:  aws.String("arn:aws:iam::aws:policy/AdministratorAccess"),
+			PolicyName: aws.String("AdministratorAccess"),
+		}},
 		managedPolicies,
 	)
 	assert.Equal(
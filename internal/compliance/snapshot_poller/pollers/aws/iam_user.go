@@ -538,6 +538,7 @@ func PollIAMUsers(pollerInput *awsmodels.ResourcePollerInput) ([]apimodels.AddRe
 					RegionIgnoreList:        pollerInput.RegionIgnoreList,
 					ResourceRegexIgnoreList: pollerInput.ResourceRegexIgnoreList,
 					ResourceTypeIgnoreList:  pollerInput.ResourceTypeIgnoreList,
+					AuditRole:               pollerInput.AuditRole,
 				}},
 			}, credentialReportRequeueDelaySeconds)
 			if err != nil {
@@ -589,6 +590,7 @@ func PollIAMUsers(pollerInput *awsmodels.ResourcePollerInput) ([]apimodels.AddRe
 						RegionIgnoreList:        pollerInput.RegionIgnoreList,
 						ResourceRegexIgnoreList: pollerInput.ResourceRegexIgnoreList,
 						ResourceTypeIgnoreList:  pollerInput.ResourceTypeIgnoreList,
+						AuditRole:               pollerInput.AuditRole,
 					},
 				},
 			}, utils.MaxRequeueDelaySeconds)
@@ -0,0 +1,238 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/elasticsearchservice"
+	"github.com/aws/aws-sdk-go/service/elasticsearchservice/elasticsearchserviceiface"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// DescribeElasticsearchDomains accepts at most this many domain names per call
+const openSearchDescribeBatchSize = 5
+
+// Set as variables to be overridden in testing
+var (
+	OpenSearchClientFunc = setupOpenSearchClient
+)
+
+func setupOpenSearchClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return elasticsearchservice.New(sess, cfg)
+}
+
+func getOpenSearchClient(pollerResourceInput *awsmodels.ResourcePollerInput,
+	region string) (elasticsearchserviceiface.ElasticsearchServiceAPI, error) {
+
+	client, err := getClient(pollerResourceInput, OpenSearchClientFunc, "es", region)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.(elasticsearchserviceiface.ElasticsearchServiceAPI), nil
+}
+
+// PollOpenSearchDomain polls a single OpenSearch domain resource
+func PollOpenSearchDomain(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	_ *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getOpenSearchClient(pollerResourceInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	domain, err := describeOpenSearchDomain(client, aws.String(strings.TrimPrefix(resourceARN.Resource, "domain/")))
+	if err != nil || domain == nil {
+		return nil, err
+	}
+
+	snapshot, err := buildOpenSearchDomainSnapshot(client, domain)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	return snapshot, nil
+}
+
+// describeOpenSearchDomain returns a single OpenSearch domain, or nil if it no longer exists
+func describeOpenSearchDomain(svc elasticsearchserviceiface.ElasticsearchServiceAPI,
+	name *string) (*elasticsearchservice.ElasticsearchDomainStatus, error) {
+
+	out, err := svc.DescribeElasticsearchDomain(&elasticsearchservice.DescribeElasticsearchDomainInput{DomainName: name})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == elasticsearchservice.ErrCodeResourceNotFoundException {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", aws.StringValue(name)),
+				zap.String("resourceType", awsmodels.OpenSearchDomainSchema))
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "ElasticsearchService.DescribeElasticsearchDomain: %s", aws.StringValue(name))
+	}
+	return out.DomainStatus, nil
+}
+
+// listOpenSearchDomainNames returns the names of all the OpenSearch domains in the account
+func listOpenSearchDomainNames(svc elasticsearchserviceiface.ElasticsearchServiceAPI) ([]*string, error) {
+	out, err := svc.ListDomainNames(&elasticsearchservice.ListDomainNamesInput{})
+	if err != nil {
+		return nil, errors.Wrap(err, "ElasticsearchService.ListDomainNames")
+	}
+
+	names := make([]*string, 0, len(out.DomainNames))
+	for _, domain := range out.DomainNames {
+		names = append(names, domain.DomainName)
+	}
+	return names, nil
+}
+
+// describeOpenSearchDomains returns the configuration of each of the named OpenSearch domains
+func describeOpenSearchDomains(svc elasticsearchserviceiface.ElasticsearchServiceAPI,
+	names []*string) ([]*elasticsearchservice.ElasticsearchDomainStatus, error) {
+
+	domains := make([]*elasticsearchservice.ElasticsearchDomainStatus, 0, len(names))
+	for start := 0; start < len(names); start += openSearchDescribeBatchSize {
+		end := start + openSearchDescribeBatchSize
+		if end > len(names) {
+			end = len(names)
+		}
+		out, err := svc.DescribeElasticsearchDomains(&elasticsearchservice.DescribeElasticsearchDomainsInput{
+			DomainNames: names[start:end],
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "ElasticsearchService.DescribeElasticsearchDomains")
+		}
+		domains = append(domains, out.DomainStatusList...)
+	}
+	return domains, nil
+}
+
+// listOpenSearchTags returns the tags of an OpenSearch domain
+func listOpenSearchTags(svc elasticsearchserviceiface.ElasticsearchServiceAPI,
+	domainARN *string) ([]*elasticsearchservice.Tag, error) {
+
+	out, err := svc.ListTags(&elasticsearchservice.ListTagsInput{ARN: domainARN})
+	if err != nil {
+		return nil, errors.Wrapf(err, "ElasticsearchService.ListTags: %s", aws.StringValue(domainARN))
+	}
+	return out.TagList, nil
+}
+
+// buildOpenSearchDomainSnapshot makes all the calls to build up a snapshot of a given OpenSearch domain
+func buildOpenSearchDomainSnapshot(
+	svc elasticsearchserviceiface.ElasticsearchServiceAPI,
+	domain *elasticsearchservice.ElasticsearchDomainStatus,
+) (*awsmodels.OpenSearchDomain, error) {
+
+	tags, err := listOpenSearchTags(svc, domain.ARN)
+	if err != nil {
+		return nil, err
+	}
+
+	return &awsmodels.OpenSearchDomain{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   domain.ARN,
+			ResourceType: aws.String(awsmodels.OpenSearchDomainSchema),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  domain.ARN,
+			ID:   domain.DomainId,
+			Name: domain.DomainName,
+			Tags: utils.ParseTagSlice(tags),
+		},
+		AccessPolicies:              domain.AccessPolicies,
+		AdvancedOptions:             domain.AdvancedOptions,
+		AdvancedSecurityOptions:     domain.AdvancedSecurityOptions,
+		CognitoOptions:              domain.CognitoOptions,
+		Created:                     domain.Created,
+		Deleted:                     domain.Deleted,
+		DomainEndpointOptions:       domain.DomainEndpointOptions,
+		EBSOptions:                  domain.EBSOptions,
+		ElasticsearchClusterConfig:  domain.ElasticsearchClusterConfig,
+		ElasticsearchVersion:        domain.ElasticsearchVersion,
+		EncryptionAtRestOptions:     domain.EncryptionAtRestOptions,
+		Endpoint:                    domain.Endpoint,
+		Endpoints:                   domain.Endpoints,
+		LogPublishingOptions:        domain.LogPublishingOptions,
+		NodeToNodeEncryptionOptions: domain.NodeToNodeEncryptionOptions,
+		Processing:                  domain.Processing,
+		ServiceSoftwareOptions:      domain.ServiceSoftwareOptions,
+		SnapshotOptions:             domain.SnapshotOptions,
+		UpgradeProcessing:           domain.UpgradeProcessing,
+		VPCOptions:                  domain.VPCOptions,
+	}, nil
+}
+
+// PollOpenSearchDomains gathers information on each OpenSearch domain for an AWS account.
+//
+// ListDomainNames is not paginated and accounts are limited to a few hundred domains per region,
+// so all domains are scanned in a single page.
+func PollOpenSearchDomains(pollerInput *awsmodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	zap.L().Debug("starting OpenSearch Domain resource poller")
+
+	openSearchSvc, err := getOpenSearchClient(pollerInput, *pollerInput.Region)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Start with generating a list of all domains
+	names, err := listOpenSearchDomainNames(openSearchSvc)
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "region: %s", *pollerInput.Region)
+	}
+	domains, err := describeOpenSearchDomains(openSearchSvc, names)
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "region: %s", *pollerInput.Region)
+	}
+
+	resources := make([]apimodels.AddResourceEntry, 0, len(domains))
+	for _, domain := range domains {
+		domainSnapshot, err := buildOpenSearchDomainSnapshot(openSearchSvc, domain)
+		if err != nil {
+			return nil, nil, err
+		}
+		domainSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+		domainSnapshot.Region = pollerInput.Region
+
+		resources = append(resources, apimodels.AddResourceEntry{
+			Attributes:      domainSnapshot,
+			ID:              *domainSnapshot.ResourceID,
+			IntegrationID:   *pollerInput.IntegrationID,
+			IntegrationType: integrationType,
+			Type:            awsmodels.OpenSearchDomainSchema,
+		})
+	}
+
+	return resources, nil, nil
+}
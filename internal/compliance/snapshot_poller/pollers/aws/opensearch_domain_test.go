@@ -0,0 +1,144 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elasticsearchservice"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestOpenSearchDomainListNames(t *testing.T) {
+	mockSvc := awstest.BuildMockOpenSearchSvc([]string{"ListDomainNames"})
+
+	out, err := listOpenSearchDomainNames(mockSvc)
+	require.NoError(t, err)
+	assert.Equal(t, []*string{awstest.ExampleOpenSearchDomainName}, out)
+}
+
+func TestOpenSearchDomainListNamesError(t *testing.T) {
+	mockSvc := awstest.BuildMockOpenSearchSvcError([]string{"ListDomainNames"})
+
+	out, err := listOpenSearchDomainNames(mockSvc)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+// Domains are described in batches of at most five names
+func TestOpenSearchDomainDescribeBatches(t *testing.T) {
+	mockSvc := awstest.BuildMockOpenSearchSvc([]string{"DescribeElasticsearchDomains"})
+
+	names := make([]*string, 7)
+	for i := range names {
+		names[i] = aws.String("example-domain")
+	}
+	out, err := describeOpenSearchDomains(mockSvc, names)
+	require.NoError(t, err)
+	assert.Len(t, out, 2)
+	mockSvc.AssertNumberOfCalls(t, "DescribeElasticsearchDomains", 2)
+}
+
+func TestOpenSearchDomainDescribe(t *testing.T) {
+	mockSvc := awstest.BuildMockOpenSearchSvc([]string{"DescribeElasticsearchDomain"})
+
+	out, err := describeOpenSearchDomain(mockSvc, awstest.ExampleOpenSearchDomainName)
+	require.NoError(t, err)
+	assert.Equal(t, awstest.ExampleOpenSearchDomainArn, out.ARN)
+}
+
+func TestOpenSearchDomainDescribeNotFound(t *testing.T) {
+	mockSvc := &awstest.MockOpenSearch{}
+	mockSvc.On("DescribeElasticsearchDomain", mock.Anything).Return(
+		&elasticsearchservice.DescribeElasticsearchDomainOutput{},
+		awserr.New(elasticsearchservice.ErrCodeResourceNotFoundException, "Domain not found", nil),
+	)
+
+	out, err := describeOpenSearchDomain(mockSvc, awstest.ExampleOpenSearchDomainName)
+	require.NoError(t, err)
+	assert.Nil(t, out)
+}
+
+func TestBuildOpenSearchDomainSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockOpenSearchSvcAll()
+
+	domainSnapshot, err := buildOpenSearchDomainSnapshot(mockSvc, awstest.ExampleOpenSearchDomainStatus)
+	require.NoError(t, err)
+	assert.Equal(t, "example-domain", *domainSnapshot.Name)
+	assert.True(t, *domainSnapshot.EncryptionAtRestOptions.Enabled)
+	assert.Equal(t, "vpc-12345678", *domainSnapshot.VPCOptions.VPCId)
+	assert.Equal(t, "Value1", *domainSnapshot.Tags["Key1"])
+}
+
+func TestBuildOpenSearchDomainSnapshotErrors(t *testing.T) {
+	mockSvc := awstest.BuildMockOpenSearchSvcAllError()
+
+	domainSnapshot, err := buildOpenSearchDomainSnapshot(mockSvc, awstest.ExampleOpenSearchDomainStatus)
+	assert.Nil(t, domainSnapshot)
+	assert.Error(t, err)
+}
+
+func TestOpenSearchDomainPoller(t *testing.T) {
+	resetCache()
+	awstest.MockOpenSearchForSetup = awstest.BuildMockOpenSearchSvcAll()
+
+	OpenSearchClientFunc = awstest.SetupMockOpenSearch
+
+	resources, marker, err := PollOpenSearchDomains(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, *awstest.ExampleOpenSearchDomainArn, resources[0].ID)
+	assert.Equal(t, awsmodels.OpenSearchDomainSchema, resources[0].Type)
+	assert.Nil(t, marker)
+}
+
+func TestOpenSearchDomainPollerError(t *testing.T) {
+	resetCache()
+	awstest.MockOpenSearchForSetup = awstest.BuildMockOpenSearchSvcAllError()
+
+	OpenSearchClientFunc = awstest.SetupMockOpenSearch
+
+	resources, marker, err := PollOpenSearchDomains(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	for _, event := range resources {
+		assert.Nil(t, event.Attributes)
+	}
+	assert.Nil(t, marker)
+	assert.Error(t, err)
+}
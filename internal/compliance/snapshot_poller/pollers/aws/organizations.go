@@ -0,0 +1,879 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/aws/aws-sdk-go/service/organizations/organizationsiface"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// Set as variables to be overridden in testing
+var (
+	OrganizationsClientFunc = setupOrganizationsClient
+
+	// The resource type of each policy type we poll
+	organizationsPolicySchemas = map[string]string{
+		organizations.PolicyTypeServiceControlPolicy: awsmodels.OrganizationsServiceControlPolicySchema,
+		organizations.PolicyTypeTagPolicy:            awsmodels.OrganizationsTagPolicySchema,
+	}
+)
+
+func setupOrganizationsClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return organizations.New(sess, cfg)
+}
+
+func getOrganizationsClient(pollerResourceInput *awsmodels.ResourcePollerInput,
+	region string) (organizationsiface.OrganizationsAPI, error) {
+
+	client, err := getClient(pollerResourceInput, OrganizationsClientFunc, "organizations", region)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.(organizationsiface.OrganizationsAPI), nil
+}
+
+// organizationsResourceID extracts the trailing ID from the ARN of an account, OU or policy
+//
+// Example: arn:aws:organizations::111111111111:ou/o-exampleorgid/ou-examplerootid111-exampleouid111
+func organizationsResourceID(resourceARN arn.ARN) *string {
+	return aws.String(resourceARN.Resource[strings.LastIndex(resourceARN.Resource, "/")+1:])
+}
+
+// isOrganizationsNotFound determines whether an error was caused by the organization or the requested
+// resource not existing
+func isOrganizationsNotFound(err error, notFoundCode string) bool {
+	var awsErr awserr.Error
+	if !errors.As(err, &awsErr) {
+		return false
+	}
+	return awsErr.Code() == notFoundCode || awsErr.Code() == organizations.ErrCodeAWSOrganizationsNotInUseException
+}
+
+// describeOrganization returns the organization the account belongs to, or nil if it isn't in one
+func describeOrganization(organizationsSvc organizationsiface.OrganizationsAPI) (*organizations.Organization, error) {
+	out, err := organizationsSvc.DescribeOrganization(&organizations.DescribeOrganizationInput{})
+	if err != nil {
+		if isOrganizationsNotFound(err, organizations.ErrCodeAWSOrganizationsNotInUseException) {
+			zap.L().Warn("organization scanning is enabled for an account which is not in an organization")
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "Organizations.DescribeOrganization")
+	}
+	return out.Organization, nil
+}
+
+// listOrganizationsTags returns the tags of an account, OU, policy or root
+func listOrganizationsTags(organizationsSvc organizationsiface.OrganizationsAPI, resourceID *string) ([]*organizations.Tag, error) {
+	var tags []*organizations.Tag
+	err := organizationsSvc.ListTagsForResourcePages(&organizations.ListTagsForResourceInput{ResourceId: resourceID},
+		func(page *organizations.ListTagsForResourceOutput, lastPage bool) bool {
+			tags = append(tags, page.Tags...)
+			return true
+		})
+	if err != nil {
+		return nil, errors.Wrapf(err, "Organizations.ListTagsForResourcePages: %s", aws.StringValue(resourceID))
+	}
+	return tags, nil
+}
+
+// organizationTree resolves the parents of accounts and OUs, and the policies attached along the way.
+//
+// Lookups are cached since siblings share their parents, so a tree should only live for one scan.
+type organizationTree struct {
+	svc organizationsiface.OrganizationsAPI
+	// Keyed by child ID
+	parents map[string]*string
+	// Keyed by parent ID
+	organizationalUnits map[string][]*organizations.OrganizationalUnit
+	// Keyed by policy type and target ID
+	policies map[string][]*string
+}
+
+func newOrganizationTree(organizationsSvc organizationsiface.OrganizationsAPI) *organizationTree {
+	return &organizationTree{
+		svc:                 organizationsSvc,
+		parents:             make(map[string]*string),
+		organizationalUnits: make(map[string][]*organizations.OrganizationalUnit),
+		policies:            make(map[string][]*string),
+	}
+}
+
+// roots returns the roots of the organization (currently there is always exactly one)
+func (t *organizationTree) roots() ([]*organizations.Root, error) {
+	var roots []*organizations.Root
+	err := t.svc.ListRootsPages(&organizations.ListRootsInput{},
+		func(page *organizations.ListRootsOutput, lastPage bool) bool {
+			roots = append(roots, page.Roots...)
+			return true
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, "Organizations.ListRootsPages")
+	}
+	return roots, nil
+}
+
+// parent returns the ID of the root or OU directly above a child, or nil if the child is a root
+func (t *organizationTree) parent(childID *string) (*string, error) {
+	if strings.HasPrefix(*childID, "r-") {
+		return nil, nil
+	}
+	if parentID, ok := t.parents[*childID]; ok {
+		return parentID, nil
+	}
+
+	out, err := t.svc.ListParents(&organizations.ListParentsInput{ChildId: childID})
+	if err != nil {
+		return nil, errors.Wrapf(err, "Organizations.ListParents: %s", *childID)
+	}
+	if len(out.Parents) == 0 {
+		return nil, errors.Errorf("Organizations.ListParents: no parent found for %s", *childID)
+	}
+	t.parents[*childID] = out.Parents[0].Id
+	return out.Parents[0].Id, nil
+}
+
+// parentIDs returns the IDs of the root and the OUs above a child, starting with the root
+func (t *organizationTree) parentIDs(childID *string) ([]*string, error) {
+	var parentIDs []*string
+	for {
+		parentID, err := t.parent(childID)
+		if err != nil {
+			return nil, err
+		}
+		if parentID == nil {
+			return parentIDs, nil
+		}
+		parentIDs = append([]*string{parentID}, parentIDs...)
+		childID = parentID
+	}
+}
+
+// children returns the OUs directly below a root or OU, and the IDs of the accounts directly in it
+func (t *organizationTree) children(parentID *string) ([]*organizations.OrganizationalUnit, []*string, error) {
+	organizationalUnits, ok := t.organizationalUnits[*parentID]
+	if !ok {
+		err := t.svc.ListOrganizationalUnitsForParentPages(
+			&organizations.ListOrganizationalUnitsForParentInput{ParentId: parentID},
+			func(page *organizations.ListOrganizationalUnitsForParentOutput, lastPage bool) bool {
+				organizationalUnits = append(organizationalUnits, page.OrganizationalUnits...)
+				return true
+			})
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "Organizations.ListOrganizationalUnitsForParentPages: %s", *parentID)
+		}
+		t.organizationalUnits[*parentID] = organizationalUnits
+		for _, organizationalUnit := range organizationalUnits {
+			t.parents[*organizationalUnit.Id] = parentID
+		}
+	}
+
+	var accountIDs []*string
+	err := t.svc.ListAccountsForParentPages(&organizations.ListAccountsForParentInput{ParentId: parentID},
+		func(page *organizations.ListAccountsForParentOutput, lastPage bool) bool {
+			for _, account := range page.Accounts {
+				accountIDs = append(accountIDs, account.Id)
+			}
+			return true
+		})
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "Organizations.ListAccountsForParentPages: %s", *parentID)
+	}
+	for _, accountID := range accountIDs {
+		t.parents[*accountID] = parentID
+	}
+
+	return organizationalUnits, accountIDs, nil
+}
+
+// attachedPolicies returns the IDs of the policies of a given type attached directly to a target
+func (t *organizationTree) attachedPolicies(targetID *string, policyType string) ([]*string, error) {
+	key := policyType + "/" + *targetID
+	if policyIDs, ok := t.policies[key]; ok {
+		return policyIDs, nil
+	}
+
+	var policyIDs []*string
+	err := t.svc.ListPoliciesForTargetPages(&organizations.ListPoliciesForTargetInput{
+		Filter:   aws.String(policyType),
+		TargetId: targetID,
+	},
+		func(page *organizations.ListPoliciesForTargetOutput, lastPage bool) bool {
+			for _, policy := range page.Policies {
+				policyIDs = append(policyIDs, policy.Id)
+			}
+			return true
+		})
+	if err != nil {
+		return nil, errors.Wrapf(err, "Organizations.ListPoliciesForTargetPages: %s", *targetID)
+	}
+	t.policies[key] = policyIDs
+	return policyIDs, nil
+}
+
+// targetPolicies looks up where an account or OU sits in the organization, and which policies apply to it
+func (t *organizationTree) targetPolicies(targetID *string) (*awsmodels.OrganizationsTargetPolicies, error) {
+	parentIDs, err := t.parentIDs(targetID)
+	if err != nil {
+		return nil, err
+	}
+	targetPolicies := &awsmodels.OrganizationsTargetPolicies{ParentIDs: parentIDs}
+
+	for _, policyType := range []string{organizations.PolicyTypeServiceControlPolicy, organizations.PolicyTypeTagPolicy} {
+		attached, err := t.attachedPolicies(targetID, policyType)
+		if err != nil {
+			return nil, err
+		}
+		// Policies are inherited from every parent, starting with the root
+		var effective []*string
+		seen := make(map[string]struct{})
+		for _, id := range append(append([]*string{}, parentIDs...), targetID) {
+			policyIDs, err := t.attachedPolicies(id, policyType)
+			if err != nil {
+				return nil, err
+			}
+			for _, policyID := range policyIDs {
+				if _, ok := seen[*policyID]; !ok {
+					seen[*policyID] = struct{}{}
+					effective = append(effective, policyID)
+				}
+			}
+		}
+
+		if policyType == organizations.PolicyTypeServiceControlPolicy {
+			targetPolicies.ServiceControlPolicies = attached
+			targetPolicies.EffectiveServiceControlPolicies = effective
+		} else {
+			targetPolicies.TagPolicies = attached
+			targetPolicies.EffectiveTagPolicies = effective
+		}
+	}
+
+	return targetPolicies, nil
+}
+
+// PollOrganizationsAccount polls a single member account of an AWS Organization
+func PollOrganizationsAccount(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	_ *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	organizationsSvc, err := getOrganizationsClient(pollerResourceInput, defaultRegion)
+	if err != nil {
+		return nil, err
+	}
+	organization, err := describeOrganization(organizationsSvc)
+	if err != nil || organization == nil {
+		return nil, err
+	}
+
+	accountID := organizationsResourceID(resourceARN)
+	out, err := organizationsSvc.DescribeAccount(&organizations.DescribeAccountInput{AccountId: accountID})
+	if err != nil {
+		if isOrganizationsNotFound(err, organizations.ErrCodeAccountNotFoundException) {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", resourceARN.String()),
+				zap.String("resourceType", awsmodels.OrganizationsAccountSchema))
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "Organizations.DescribeAccount: %s", *accountID)
+	}
+
+	snapshot, err := buildOrganizationsAccountSnapshot(organizationsSvc, newOrganizationTree(organizationsSvc), organization, out.Account)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(awsmodels.GlobalRegion)
+	return snapshot, nil
+}
+
+// listOrganizationsAccounts returns a page of the member accounts of the organization
+func listOrganizationsAccounts(organizationsSvc organizationsiface.OrganizationsAPI, nextMarker *string) (
+	accounts []*organizations.Account, marker *string, err error) {
+
+	err = organizationsSvc.ListAccountsPages(&organizations.ListAccountsInput{NextToken: nextMarker},
+		func(page *organizations.ListAccountsOutput, lastPage bool) bool {
+			return organizationsAccountIterator(page, &accounts, &marker)
+		})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "Organizations.ListAccountsPages")
+	}
+	return
+}
+
+func organizationsAccountIterator(
+	page *organizations.ListAccountsOutput, accounts *[]*organizations.Account, marker **string) bool {
+
+	*accounts = append(*accounts, page.Accounts...)
+	*marker = page.NextToken
+	return len(*accounts) < defaultBatchSize
+}
+
+// buildOrganizationsAccountSnapshot makes all the calls to build up a snapshot of a given member account
+func buildOrganizationsAccountSnapshot(
+	organizationsSvc organizationsiface.OrganizationsAPI,
+	tree *organizationTree,
+	organization *organizations.Organization,
+	account *organizations.Account,
+) (*awsmodels.OrganizationsAccount, error) {
+
+	snapshot := &awsmodels.OrganizationsAccount{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   account.Arn,
+			ResourceType: aws.String(awsmodels.OrganizationsAccountSchema),
+			TimeCreated:  account.JoinedTimestamp,
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  account.Arn,
+			ID:   account.Id,
+			Name: account.Name,
+		},
+		Email:             account.Email,
+		JoinedMethod:      account.JoinedMethod,
+		JoinedTimestamp:   account.JoinedTimestamp,
+		Status:            account.Status,
+		OrganizationID:    organization.Id,
+		ManagementAccount: aws.StringValue(account.Id) == aws.StringValue(organization.MasterAccountId),
+	}
+
+	targetPolicies, err := tree.targetPolicies(account.Id)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.OrganizationsTargetPolicies = *targetPolicies
+
+	tags, err := listOrganizationsTags(organizationsSvc, account.Id)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.Tags = utils.ParseTagSlice(tags)
+
+	return snapshot, nil
+}
+
+// PollOrganizationsAccounts gathers information on each member account of the AWS Organization managed by
+// the scanned account.
+func PollOrganizationsAccounts(pollerInput *awsmodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	zap.L().Debug("starting Organizations Account resource poller")
+
+	organizationsSvc, err := getOrganizationsClient(pollerInput, defaultRegion)
+	if err != nil {
+		return nil, nil, err
+	}
+	organization, err := describeOrganization(organizationsSvc)
+	if err != nil || organization == nil {
+		return nil, nil, err
+	}
+
+	accounts, marker, err := listOrganizationsAccounts(organizationsSvc, pollerInput.NextPageToken)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tree := newOrganizationTree(organizationsSvc)
+	resources := make([]apimodels.AddResourceEntry, 0, len(accounts))
+	for _, account := range accounts {
+		accountSnapshot, err := buildOrganizationsAccountSnapshot(organizationsSvc, tree, organization, account)
+		if err != nil {
+			return nil, nil, err
+		}
+		accountSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+		accountSnapshot.Region = aws.String(awsmodels.GlobalRegion)
+
+		resources = append(resources, apimodels.AddResourceEntry{
+			Attributes:      accountSnapshot,
+			ID:              *accountSnapshot.ResourceID,
+			IntegrationID:   *pollerInput.IntegrationID,
+			IntegrationType: integrationType,
+			Type:            awsmodels.OrganizationsAccountSchema,
+		})
+	}
+
+	return resources, marker, nil
+}
+
+// PollOrganizationsOrganizationalUnit polls a single organizational unit
+func PollOrganizationsOrganizationalUnit(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	_ *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	organizationsSvc, err := getOrganizationsClient(pollerResourceInput, defaultRegion)
+	if err != nil {
+		return nil, err
+	}
+	organization, err := describeOrganization(organizationsSvc)
+	if err != nil || organization == nil {
+		return nil, err
+	}
+
+	organizationalUnitID := organizationsResourceID(resourceARN)
+	out, err := organizationsSvc.DescribeOrganizationalUnit(&organizations.DescribeOrganizationalUnitInput{
+		OrganizationalUnitId: organizationalUnitID,
+	})
+	if err != nil {
+		if isOrganizationsNotFound(err, organizations.ErrCodeOrganizationalUnitNotFoundException) {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", resourceARN.String()),
+				zap.String("resourceType", awsmodels.OrganizationsOrganizationalUnitSchema))
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "Organizations.DescribeOrganizationalUnit: %s", *organizationalUnitID)
+	}
+
+	snapshot, _, err := buildOrganizationsOrganizationalUnitSnapshot(
+		organizationsSvc, newOrganizationTree(organizationsSvc), organization, out.OrganizationalUnit)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(awsmodels.GlobalRegion)
+	return snapshot, nil
+}
+
+// buildOrganizationsOrganizationalUnitSnapshot makes all the calls to build up a snapshot of a given OU,
+// and also returns the OUs directly below it.
+func buildOrganizationsOrganizationalUnitSnapshot(
+	organizationsSvc organizationsiface.OrganizationsAPI,
+	tree *organizationTree,
+	organization *organizations.Organization,
+	organizationalUnit *organizations.OrganizationalUnit,
+) (*awsmodels.OrganizationsOrganizationalUnit, []*organizations.OrganizationalUnit, error) {
+
+	snapshot := &awsmodels.OrganizationsOrganizationalUnit{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   organizationalUnit.Arn,
+			ResourceType: aws.String(awsmodels.OrganizationsOrganizationalUnitSchema),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  organizationalUnit.Arn,
+			ID:   organizationalUnit.Id,
+			Name: organizationalUnit.Name,
+		},
+		OrganizationID: organization.Id,
+	}
+
+	children, accountIDs, err := tree.children(organizationalUnit.Id)
+	if err != nil {
+		return nil, nil, err
+	}
+	snapshot.Accounts = accountIDs
+	for _, child := range children {
+		snapshot.OrganizationalUnits = append(snapshot.OrganizationalUnits, child.Id)
+	}
+
+	targetPolicies, err := tree.targetPolicies(organizationalUnit.Id)
+	if err != nil {
+		return nil, nil, err
+	}
+	snapshot.OrganizationsTargetPolicies = *targetPolicies
+
+	tags, err := listOrganizationsTags(organizationsSvc, organizationalUnit.Id)
+	if err != nil {
+		return nil, nil, err
+	}
+	snapshot.Tags = utils.ParseTagSlice(tags)
+
+	return snapshot, children, nil
+}
+
+// PollOrganizationsOrganizationalUnits gathers information on each OU of the AWS Organization managed by
+// the scanned account.
+//
+// OUs can only be listed one parent at a time, so the whole tree is walked in a single scan.
+func PollOrganizationsOrganizationalUnits(pollerInput *awsmodels.ResourcePollerInput) (
+	[]apimodels.AddResourceEntry, *string, error) {
+
+	zap.L().Debug("starting Organizations OrganizationalUnit resource poller")
+
+	organizationsSvc, err := getOrganizationsClient(pollerInput, defaultRegion)
+	if err != nil {
+		return nil, nil, err
+	}
+	organization, err := describeOrganization(organizationsSvc)
+	if err != nil || organization == nil {
+		return nil, nil, err
+	}
+
+	tree := newOrganizationTree(organizationsSvc)
+	roots, err := tree.roots()
+	if err != nil {
+		return nil, nil, err
+	}
+	var pending []*organizations.OrganizationalUnit
+	for _, root := range roots {
+		children, _, err := tree.children(root.Id)
+		if err != nil {
+			return nil, nil, err
+		}
+		pending = append(pending, children...)
+	}
+
+	var resources []apimodels.AddResourceEntry
+	for len(pending) > 0 {
+		organizationalUnit := pending[0]
+		pending = pending[1:]
+
+		ouSnapshot, children, err := buildOrganizationsOrganizationalUnitSnapshot(
+			organizationsSvc, tree, organization, organizationalUnit)
+		if err != nil {
+			return nil, nil, err
+		}
+		pending = append(pending, children...)
+		ouSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+		ouSnapshot.Region = aws.String(awsmodels.GlobalRegion)
+
+		resources = append(resources, apimodels.AddResourceEntry{
+			Attributes:      ouSnapshot,
+			ID:              *ouSnapshot.ResourceID,
+			IntegrationID:   *pollerInput.IntegrationID,
+			IntegrationType: integrationType,
+			Type:            awsmodels.OrganizationsOrganizationalUnitSchema,
+		})
+	}
+
+	return resources, nil, nil
+}
+
+// PollOrganizationsPolicy polls a single service control policy or tag policy
+func PollOrganizationsPolicy(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	_ *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	organizationsSvc, err := getOrganizationsClient(pollerResourceInput, defaultRegion)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := buildOrganizationsPolicySnapshot(organizationsSvc, organizationsResourceID(resourceARN))
+	if err != nil || snapshot == nil {
+		return nil, err
+	}
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(awsmodels.GlobalRegion)
+	return snapshot, nil
+}
+
+// listOrganizationsPolicies returns a page of the policies of a given type
+func listOrganizationsPolicies(organizationsSvc organizationsiface.OrganizationsAPI, policyType string, nextMarker *string) (
+	policies []*organizations.PolicySummary, marker *string, err error) {
+
+	err = organizationsSvc.ListPoliciesPages(&organizations.ListPoliciesInput{
+		Filter:    aws.String(policyType),
+		NextToken: nextMarker,
+	},
+		func(page *organizations.ListPoliciesOutput, lastPage bool) bool {
+			return organizationsPolicyIterator(page, &policies, &marker)
+		})
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "Organizations.ListPoliciesPages: %s", policyType)
+	}
+	return
+}
+
+func organizationsPolicyIterator(
+	page *organizations.ListPoliciesOutput, policies *[]*organizations.PolicySummary, marker **string) bool {
+
+	*policies = append(*policies, page.Policies...)
+	*marker = page.NextToken
+	return len(*policies) < defaultBatchSize
+}
+
+// buildOrganizationsPolicySnapshot makes all the calls to build up a snapshot of a given policy, or
+// returns nil if it no longer exists
+func buildOrganizationsPolicySnapshot(
+	organizationsSvc organizationsiface.OrganizationsAPI, policyID *string) (*awsmodels.OrganizationsPolicy, error) {
+
+	out, err := organizationsSvc.DescribePolicy(&organizations.DescribePolicyInput{PolicyId: policyID})
+	if err != nil {
+		if isOrganizationsNotFound(err, organizations.ErrCodePolicyNotFoundException) {
+			zap.L().Warn("tried to scan non-existent organizations policy",
+				zap.String("resource", aws.StringValue(policyID)))
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "Organizations.DescribePolicy: %s", aws.StringValue(policyID))
+	}
+	if out.Policy == nil || out.Policy.PolicySummary == nil {
+		return nil, nil
+	}
+	summary := out.Policy.PolicySummary
+	schema, ok := organizationsPolicySchemas[aws.StringValue(summary.Type)]
+	if !ok {
+		return nil, nil
+	}
+
+	snapshot := &awsmodels.OrganizationsPolicy{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   summary.Arn,
+			ResourceType: aws.String(schema),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  summary.Arn,
+			ID:   summary.Id,
+			Name: summary.Name,
+		},
+		AwsManaged:  summary.AwsManaged,
+		Description: summary.Description,
+		Type:        summary.Type,
+		Content:     out.Policy.Content,
+	}
+
+	err = organizationsSvc.ListTargetsForPolicyPages(&organizations.ListTargetsForPolicyInput{PolicyId: policyID},
+		func(page *organizations.ListTargetsForPolicyOutput, lastPage bool) bool {
+			snapshot.Targets = append(snapshot.Targets, page.Targets...)
+			return true
+		})
+	if err != nil {
+		return nil, errors.Wrapf(err, "Organizations.ListTargetsForPolicyPages: %s", aws.StringValue(policyID))
+	}
+
+	// AWS managed policies can't be tagged
+	if !aws.BoolValue(summary.AwsManaged) {
+		tags, err := listOrganizationsTags(organizationsSvc, policyID)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Tags = utils.ParseTagSlice(tags)
+	}
+
+	return snapshot, nil
+}
+
+// pollOrganizationsPolicies gathers information on each customer managed policy of a given type. AWS
+// managed policies are shared by every organization, so like IAM they are not scanned.
+func pollOrganizationsPolicies(pollerInput *awsmodels.ResourcePollerInput, policyType string) (
+	[]apimodels.AddResourceEntry, *string, error) {
+
+	zap.L().Debug("starting Organizations Policy resource poller", zap.String("policyType", policyType))
+
+	organizationsSvc, err := getOrganizationsClient(pollerInput, defaultRegion)
+	if err != nil {
+		return nil, nil, err
+	}
+	organization, err := describeOrganization(organizationsSvc)
+	if err != nil || organization == nil {
+		return nil, nil, err
+	}
+
+	policies, marker, err := listOrganizationsPolicies(organizationsSvc, policyType, pollerInput.NextPageToken)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resources := make([]apimodels.AddResourceEntry, 0, len(policies))
+	for _, policy := range policies {
+		if aws.BoolValue(policy.AwsManaged) {
+			continue
+		}
+		policySnapshot, err := buildOrganizationsPolicySnapshot(organizationsSvc, policy.Id)
+		if err != nil {
+			return nil, nil, err
+		}
+		if policySnapshot == nil {
+			continue
+		}
+		policySnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+		policySnapshot.Region = aws.String(awsmodels.GlobalRegion)
+
+		resources = append(resources, apimodels.AddResourceEntry{
+			Attributes:      policySnapshot,
+			ID:              *policySnapshot.ResourceID,
+			IntegrationID:   *pollerInput.IntegrationID,
+			IntegrationType: integrationType,
+			Type:            *policySnapshot.ResourceType,
+		})
+	}
+
+	return resources, marker, nil
+}
+
+// PollOrganizationsServiceControlPolicies gathers information on each service control policy
+func PollOrganizationsServiceControlPolicies(pollerInput *awsmodels.ResourcePollerInput) (
+	[]apimodels.AddResourceEntry, *string, error) {
+
+	return pollOrganizationsPolicies(pollerInput, organizations.PolicyTypeServiceControlPolicy)
+}
+
+// PollOrganizationsTagPolicies gathers information on each tag policy
+func PollOrganizationsTagPolicies(pollerInput *awsmodels.ResourcePollerInput) (
+	[]apimodels.AddResourceEntry, *string, error) {
+
+	return pollOrganizationsPolicies(pollerInput, organizations.PolicyTypeTagPolicy)
+}
+
+// PollOrganizationsDelegatedAdministrator polls the services delegated to a single member account
+func PollOrganizationsDelegatedAdministrator(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	parsedResourceID *utils.ParsedResourceID,
+	_ *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	if parsedResourceID == nil {
+		return nil, nil
+	}
+	organizationsSvc, err := getOrganizationsClient(pollerResourceInput, defaultRegion)
+	if err != nil {
+		return nil, err
+	}
+
+	administrators, err := listDelegatedAdministrators(organizationsSvc)
+	if err != nil {
+		return nil, err
+	}
+	for _, administrator := range administrators {
+		if aws.StringValue(administrator.Id) != parsedResourceID.AccountID {
+			continue
+		}
+		snapshot, err := buildDelegatedAdministratorSnapshot(organizationsSvc, administrator)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.AccountID = aws.String(pollerResourceInput.AuthSourceParsedARN.AccountID)
+		snapshot.Region = aws.String(awsmodels.GlobalRegion)
+		return snapshot, nil
+	}
+
+	zap.L().Warn("tried to scan non-existent resource",
+		zap.String("resource", parsedResourceID.AccountID),
+		zap.String("resourceType", awsmodels.OrganizationsDelegatedAdministratorSchema))
+	return nil, nil
+}
+
+// listDelegatedAdministrators returns every member account with delegated administrator permissions
+func listDelegatedAdministrators(organizationsSvc organizationsiface.OrganizationsAPI) (
+	[]*organizations.DelegatedAdministrator, error) {
+
+	var administrators []*organizations.DelegatedAdministrator
+	err := organizationsSvc.ListDelegatedAdministratorsPages(&organizations.ListDelegatedAdministratorsInput{},
+		func(page *organizations.ListDelegatedAdministratorsOutput, lastPage bool) bool {
+			administrators = append(administrators, page.DelegatedAdministrators...)
+			return true
+		})
+	if err != nil {
+		if isOrganizationsNotFound(err, organizations.ErrCodeAWSOrganizationsNotInUseException) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "Organizations.ListDelegatedAdministratorsPages")
+	}
+	return administrators, nil
+}
+
+// buildDelegatedAdministratorSnapshot makes all the calls to build up a snapshot of a given delegated
+// administrator
+func buildDelegatedAdministratorSnapshot(
+	organizationsSvc organizationsiface.OrganizationsAPI,
+	administrator *organizations.DelegatedAdministrator,
+) (*awsmodels.OrganizationsDelegatedAdministrator, error) {
+
+	resourceID := utils.GenerateResourceID(
+		aws.StringValue(administrator.Id),
+		"",
+		awsmodels.OrganizationsDelegatedAdministratorSchema,
+	)
+	snapshot := &awsmodels.OrganizationsDelegatedAdministrator{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   aws.String(resourceID),
+			ResourceType: aws.String(awsmodels.OrganizationsDelegatedAdministratorSchema),
+			TimeCreated:  administrator.DelegationEnabledDate,
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  administrator.Arn,
+			ID:   administrator.Id,
+			Name: administrator.Name,
+		},
+		DelegationEnabledDate: administrator.DelegationEnabledDate,
+		Email:                 administrator.Email,
+		JoinedMethod:          administrator.JoinedMethod,
+		JoinedTimestamp:       administrator.JoinedTimestamp,
+		Status:                administrator.Status,
+	}
+
+	err := organizationsSvc.ListDelegatedServicesForAccountPages(
+		&organizations.ListDelegatedServicesForAccountInput{AccountId: administrator.Id},
+		func(page *organizations.ListDelegatedServicesForAccountOutput, lastPage bool) bool {
+			snapshot.DelegatedServices = append(snapshot.DelegatedServices, page.DelegatedServices...)
+			return true
+		})
+	if err != nil {
+		return nil, errors.Wrapf(err, "Organizations.ListDelegatedServicesForAccountPages: %s",
+			aws.StringValue(administrator.Id))
+	}
+
+	return snapshot, nil
+}
+
+// PollOrganizationsDelegatedAdministrators gathers information on each member account with delegated
+// administrator permissions.
+func PollOrganizationsDelegatedAdministrators(pollerInput *awsmodels.ResourcePollerInput) (
+	[]apimodels.AddResourceEntry, *string, error) {
+
+	zap.L().Debug("starting Organizations DelegatedAdministrator resource poller")
+
+	organizationsSvc, err := getOrganizationsClient(pollerInput, defaultRegion)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	administrators, err := listDelegatedAdministrators(organizationsSvc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resources := make([]apimodels.AddResourceEntry, 0, len(administrators))
+	for _, administrator := range administrators {
+		administratorSnapshot, err := buildDelegatedAdministratorSnapshot(organizationsSvc, administrator)
+		if err != nil {
+			return nil, nil, err
+		}
+		// Check if ResourceID matches the integration's regex filter
+		if pollerInput.ShouldIgnoreResource(*administratorSnapshot.ResourceID) {
+			continue
+		}
+		administratorSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+		administratorSnapshot.Region = aws.String(awsmodels.GlobalRegion)
+
+		resources = append(resources, apimodels.AddResourceEntry{
+			Attributes:      administratorSnapshot,
+			ID:              *administratorSnapshot.ResourceID,
+			IntegrationID:   *pollerInput.IntegrationID,
+			IntegrationType: integrationType,
+			Type:            awsmodels.OrganizationsDelegatedAdministratorSchema,
+		})
+	}
+
+	return resources, nil, nil
+}
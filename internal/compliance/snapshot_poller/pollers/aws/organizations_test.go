@@ -0,0 +1,345 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+var organizationsPollerInput = &awsmodels.ResourcePollerInput{
+	AuthSource:          &awstest.ExampleAuthSource,
+	AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+	IntegrationID:       awstest.ExampleIntegrationID,
+	Region:              awstest.ExampleRegion,
+	Timestamp:           &awstest.ExampleTime,
+}
+
+func TestOrganizationsAccountList(t *testing.T) {
+	mockSvc := awstest.BuildMockOrganizationsSvc([]string{"ListAccountsPages"})
+
+	out, marker, err := listOrganizationsAccounts(mockSvc, nil)
+	assert.NotEmpty(t, out)
+	assert.Nil(t, marker)
+	assert.NoError(t, err)
+}
+
+// Test the iterator works on consecutive pages but stops at max page size
+func TestOrganizationsAccountListIterator(t *testing.T) {
+	var accounts []*organizations.Account
+	var marker *string
+
+	cont := organizationsAccountIterator(awstest.ExampleListAccountsOutput, &accounts, &marker)
+	assert.True(t, cont)
+	assert.Nil(t, marker)
+	assert.Len(t, accounts, 1)
+
+	for i := 1; i < 50; i++ {
+		cont = organizationsAccountIterator(awstest.ExampleListAccountsOutputContinue, &accounts, &marker)
+		assert.True(t, cont)
+		assert.NotNil(t, marker)
+		assert.Len(t, accounts, 1+i*2)
+	}
+
+	cont = organizationsAccountIterator(awstest.ExampleListAccountsOutputContinue, &accounts, &marker)
+	assert.False(t, cont)
+	assert.NotNil(t, marker)
+	assert.Len(t, accounts, 101)
+}
+
+func TestOrganizationsAccountListError(t *testing.T) {
+	mockSvc := awstest.BuildMockOrganizationsSvcError([]string{"ListAccountsPages"})
+
+	out, marker, err := listOrganizationsAccounts(mockSvc, nil)
+	assert.Nil(t, out)
+	assert.Nil(t, marker)
+	assert.Error(t, err)
+}
+
+func TestDescribeOrganizationNotInUse(t *testing.T) {
+	mockSvc := &awstest.MockOrganizations{}
+	mockSvc.On("DescribeOrganization", mock.Anything).Return(
+		&organizations.DescribeOrganizationOutput{},
+		awserr.New(organizations.ErrCodeAWSOrganizationsNotInUseException, "not in an organization", nil),
+	)
+
+	out, err := describeOrganization(mockSvc)
+	require.NoError(t, err)
+	assert.Nil(t, out)
+}
+
+func TestOrganizationTreeTargetPolicies(t *testing.T) {
+	mockSvc := awstest.BuildMockOrganizationsSvc([]string{"ListParents", "ListPoliciesForTargetPages"})
+
+	tree := newOrganizationTree(mockSvc)
+	out, err := tree.targetPolicies(awstest.ExampleOrganizationsAccount.Id)
+	require.NoError(t, err)
+	assert.Equal(t, []*string{awstest.ExampleOrganizationRootID, awstest.ExampleOrganizationalUnitID}, out.ParentIDs)
+	assert.Empty(t, out.ServiceControlPolicies)
+	assert.Equal(t, []*string{aws.String("p-FullAWSAccess"), awstest.ExampleOrganizationsSCPID},
+		out.EffectiveServiceControlPolicies)
+	assert.Empty(t, out.EffectiveTagPolicies)
+
+	// Parents and policies are looked up once per tree
+	_, err = tree.targetPolicies(awstest.ExampleOrganizationalUnitID)
+	require.NoError(t, err)
+	mockSvc.AssertNumberOfCalls(t, "ListParents", 2)
+	mockSvc.AssertNumberOfCalls(t, "ListPoliciesForTargetPages", 6)
+}
+
+func TestOrganizationTreeTargetPoliciesError(t *testing.T) {
+	mockSvc := awstest.BuildMockOrganizationsSvcError([]string{"ListParents"})
+
+	out, err := newOrganizationTree(mockSvc).targetPolicies(awstest.ExampleOrganizationsAccount.Id)
+	assert.Nil(t, out)
+	assert.Error(t, err)
+}
+
+func TestBuildOrganizationsPolicySnapshotNotFound(t *testing.T) {
+	mockSvc := &awstest.MockOrganizations{}
+	mockSvc.On("DescribePolicy", mock.Anything).Return(
+		&organizations.DescribePolicyOutput{},
+		awserr.New(organizations.ErrCodePolicyNotFoundException, "policy not found", nil),
+	)
+
+	out, err := buildOrganizationsPolicySnapshot(mockSvc, awstest.ExampleOrganizationsSCPID)
+	require.NoError(t, err)
+	assert.Nil(t, out)
+}
+
+func TestOrganizationsAccountPoller(t *testing.T) {
+	resetCache()
+	awstest.MockOrganizationsForSetup = awstest.BuildMockOrganizationsSvcAll()
+
+	OrganizationsClientFunc = awstest.SetupMockOrganizations
+
+	resources, marker, err := PollOrganizationsAccounts(organizationsPollerInput)
+
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, *awstest.ExampleOrganizationsAccount.Arn, resources[0].ID)
+	assert.Equal(t, awsmodels.OrganizationsAccountSchema, resources[0].Type)
+	assert.Nil(t, marker)
+
+	account := resources[0].Attributes.(*awsmodels.OrganizationsAccount)
+	assert.Equal(t, "222222222222", *account.ID)
+	assert.Equal(t, awstest.ExampleOrganizationID, account.OrganizationID)
+	assert.False(t, account.ManagementAccount)
+	assert.Equal(t, awsmodels.GlobalRegion, *account.Region)
+	assert.Equal(t, []*string{aws.String("p-FullAWSAccess"), awstest.ExampleOrganizationsSCPID},
+		account.EffectiveServiceControlPolicies)
+	assert.Equal(t, "Value1", *account.Tags["Key1"])
+}
+
+func TestOrganizationsAccountPollerError(t *testing.T) {
+	resetCache()
+	awstest.MockOrganizationsForSetup = awstest.BuildMockOrganizationsSvcAllError()
+
+	OrganizationsClientFunc = awstest.SetupMockOrganizations
+
+	resources, marker, err := PollOrganizationsAccounts(organizationsPollerInput)
+
+	assert.Empty(t, resources)
+	assert.Nil(t, marker)
+	assert.Error(t, err)
+}
+
+func TestOrganizationsPollerNotInOrganization(t *testing.T) {
+	resetCache()
+	mockSvc := awstest.BuildMockOrganizationsSvcAll()
+	mockSvc.ExpectedCalls = nil
+	mockSvc.On("DescribeOrganization", mock.Anything).Return(
+		&organizations.DescribeOrganizationOutput{},
+		awserr.New(organizations.ErrCodeAWSOrganizationsNotInUseException, "not in an organization", nil),
+	)
+	awstest.MockOrganizationsForSetup = mockSvc
+
+	OrganizationsClientFunc = awstest.SetupMockOrganizations
+
+	for _, poller := range []func(*awsmodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error){
+		PollOrganizationsAccounts,
+		PollOrganizationsOrganizationalUnits,
+		PollOrganizationsServiceControlPolicies,
+	} {
+		resources, marker, err := poller(organizationsPollerInput)
+		require.NoError(t, err)
+		assert.Empty(t, resources)
+		assert.Nil(t, marker)
+	}
+}
+
+func TestOrganizationsAccountPollerSingle(t *testing.T) {
+	resetCache()
+	awstest.MockOrganizationsForSetup = awstest.BuildMockOrganizationsSvcAll()
+
+	OrganizationsClientFunc = awstest.SetupMockOrganizations
+
+	resourceARN, err := arn.Parse(*awstest.ExampleOrganizationsAccount.Arn)
+	require.NoError(t, err)
+	resource, err := PollOrganizationsAccount(organizationsPollerInput, resourceARN, nil)
+
+	require.NoError(t, err)
+	account := resource.(*awsmodels.OrganizationsAccount)
+	assert.Equal(t, "111111111111", *account.AccountID)
+	assert.Equal(t, []*string{awstest.ExampleOrganizationRootID, awstest.ExampleOrganizationalUnitID}, account.ParentIDs)
+}
+
+func TestOrganizationsOrganizationalUnitPoller(t *testing.T) {
+	resetCache()
+	awstest.MockOrganizationsForSetup = awstest.BuildMockOrganizationsSvcAll()
+
+	OrganizationsClientFunc = awstest.SetupMockOrganizations
+
+	resources, marker, err := PollOrganizationsOrganizationalUnits(organizationsPollerInput)
+
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, *awstest.ExampleOrganizationalUnit.Arn, resources[0].ID)
+	assert.Equal(t, awsmodels.OrganizationsOrganizationalUnitSchema, resources[0].Type)
+	assert.Nil(t, marker)
+
+	organizationalUnit := resources[0].Attributes.(*awsmodels.OrganizationsOrganizationalUnit)
+	assert.Equal(t, []*string{awstest.ExampleOrganizationsAccount.Id}, organizationalUnit.Accounts)
+	assert.Empty(t, organizationalUnit.OrganizationalUnits)
+	assert.Equal(t, []*string{awstest.ExampleOrganizationRootID}, organizationalUnit.ParentIDs)
+	assert.Equal(t, []*string{awstest.ExampleOrganizationsSCPID}, organizationalUnit.ServiceControlPolicies)
+	// The root's parent is known from walking the tree, so it is never looked up
+	awstest.MockOrganizationsForSetup.AssertNotCalled(t, "ListParents", mock.Anything)
+}
+
+func TestOrganizationsOrganizationalUnitPollerError(t *testing.T) {
+	resetCache()
+	awstest.MockOrganizationsForSetup = awstest.BuildMockOrganizationsSvcAllError()
+
+	OrganizationsClientFunc = awstest.SetupMockOrganizations
+
+	resources, marker, err := PollOrganizationsOrganizationalUnits(organizationsPollerInput)
+
+	assert.Empty(t, resources)
+	assert.Nil(t, marker)
+	assert.Error(t, err)
+}
+
+func TestOrganizationsServiceControlPolicyPoller(t *testing.T) {
+	resetCache()
+	awstest.MockOrganizationsForSetup = awstest.BuildMockOrganizationsSvcAll()
+
+	OrganizationsClientFunc = awstest.SetupMockOrganizations
+
+	resources, marker, err := PollOrganizationsServiceControlPolicies(organizationsPollerInput)
+
+	require.NoError(t, err)
+	// The AWS managed FullAWSAccess policy is skipped
+	require.Len(t, resources, 1)
+	assert.Equal(t, *awstest.ExampleOrganizationsSCP.Arn, resources[0].ID)
+	assert.Equal(t, awsmodels.OrganizationsServiceControlPolicySchema, resources[0].Type)
+	assert.Nil(t, marker)
+
+	policy := resources[0].Attributes.(*awsmodels.OrganizationsPolicy)
+	assert.NotNil(t, policy.Content)
+	assert.Len(t, policy.Targets, 1)
+	assert.Equal(t, "Value1", *policy.Tags["Key1"])
+}
+
+func TestOrganizationsTagPolicyPoller(t *testing.T) {
+	resetCache()
+	awstest.MockOrganizationsForSetup = awstest.BuildMockOrganizationsSvcAll()
+
+	OrganizationsClientFunc = awstest.SetupMockOrganizations
+
+	resources, marker, err := PollOrganizationsTagPolicies(organizationsPollerInput)
+
+	require.NoError(t, err)
+	assert.Empty(t, resources)
+	assert.Nil(t, marker)
+}
+
+func TestOrganizationsServiceControlPolicyPollerError(t *testing.T) {
+	resetCache()
+	awstest.MockOrganizationsForSetup = awstest.BuildMockOrganizationsSvcAllError()
+
+	OrganizationsClientFunc = awstest.SetupMockOrganizations
+
+	resources, marker, err := PollOrganizationsServiceControlPolicies(organizationsPollerInput)
+
+	assert.Empty(t, resources)
+	assert.Nil(t, marker)
+	assert.Error(t, err)
+}
+
+func TestOrganizationsDelegatedAdministratorPoller(t *testing.T) {
+	resetCache()
+	awstest.MockOrganizationsForSetup = awstest.BuildMockOrganizationsSvcAll()
+
+	OrganizationsClientFunc = awstest.SetupMockOrganizations
+
+	resources, marker, err := PollOrganizationsDelegatedAdministrators(organizationsPollerInput)
+
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, "222222222222::AWS.Organizations.DelegatedAdministrator", resources[0].ID)
+	assert.Equal(t, awsmodels.OrganizationsDelegatedAdministratorSchema, resources[0].Type)
+	assert.Nil(t, marker)
+
+	administrator := resources[0].Attributes.(*awsmodels.OrganizationsDelegatedAdministrator)
+	require.Len(t, administrator.DelegatedServices, 1)
+	assert.Equal(t, "guardduty.amazonaws.com", *administrator.DelegatedServices[0].ServicePrincipal)
+}
+
+func TestOrganizationsDelegatedAdministratorPollerSingle(t *testing.T) {
+	resetCache()
+	awstest.MockOrganizationsForSetup = awstest.BuildMockOrganizationsSvcAll()
+
+	OrganizationsClientFunc = awstest.SetupMockOrganizations
+
+	resource, err := PollOrganizationsDelegatedAdministrator(organizationsPollerInput,
+		utils.ParseResourceID("222222222222::AWS.Organizations.DelegatedAdministrator"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "222222222222", *resource.(*awsmodels.OrganizationsDelegatedAdministrator).ID)
+
+	resource, err = PollOrganizationsDelegatedAdministrator(organizationsPollerInput,
+		utils.ParseResourceID("333333333333::AWS.Organizations.DelegatedAdministrator"), nil)
+	require.NoError(t, err)
+	assert.Nil(t, resource)
+}
+
+func TestOrganizationsDelegatedAdministratorPollerError(t *testing.T) {
+	resetCache()
+	awstest.MockOrganizationsForSetup = awstest.BuildMockOrganizationsSvcAllError()
+
+	OrganizationsClientFunc = awstest.SetupMockOrganizations
+
+	resources, marker, err := PollOrganizationsDelegatedAdministrators(organizationsPollerInput)
+
+	assert.Empty(t, resources)
+	assert.Nil(t, marker)
+	assert.Error(t, err)
+}
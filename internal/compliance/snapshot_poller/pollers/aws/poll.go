@@ -65,77 +65,144 @@ var (
 	// functions for resources whose ID is their ARN.
 	IndividualARNResourcePollers = map[string]func(
 		input *awsmodels.ResourcePollerInput, arn arn.ARN, entry *pollermodels.ScanEntry) (interface{}, error){
-		awsmodels.AcmCertificateSchema:      PollACMCertificate,
-		awsmodels.CloudFormationStackSchema: PollCloudFormationStack,
-		awsmodels.CloudTrailSchema:          PollCloudTrailTrail,
-		awsmodels.CloudWatchLogGroupSchema:  PollCloudWatchLogsLogGroup,
-		awsmodels.DynamoDBTableSchema:       PollDynamoDBTable,
-		awsmodels.Ec2AmiSchema:              PollEC2Image,
-		awsmodels.Ec2InstanceSchema:         PollEC2Instance,
-		awsmodels.Ec2NetworkAclSchema:       PollEC2NetworkACL,
-		awsmodels.Ec2SecurityGroupSchema:    PollEC2SecurityGroup,
-		awsmodels.Ec2VolumeSchema:           PollEC2Volume,
-		awsmodels.Ec2VpcSchema:              PollEC2VPC,
-		awsmodels.EcsClusterSchema:          PollECSCluster,
-		awsmodels.Elbv2LoadBalancerSchema:   PollELBV2LoadBalancer,
-		awsmodels.IAMGroupSchema:            PollIAMGroup,
-		awsmodels.IAMPolicySchema:           PollIAMPolicy,
-		awsmodels.IAMRoleSchema:             PollIAMRole,
-		awsmodels.IAMUserSchema:             PollIAMUser,
-		awsmodels.IAMRootUserSchema:         PollIAMRootUser,
-		awsmodels.KmsKeySchema:              PollKMSKey,
-		awsmodels.LambdaFunctionSchema:      PollLambdaFunction,
-		awsmodels.RDSInstanceSchema:         PollRDSInstance,
-		awsmodels.RedshiftClusterSchema:     PollRedshiftCluster,
-		awsmodels.S3BucketSchema:            PollS3Bucket,
-		awsmodels.WafWebAclSchema:           PollWAFWebACL,
-		awsmodels.WafRegionalWebAclSchema:   PollWAFRegionalWebACL,
+		awsmodels.AcmCertificateSchema:                    PollACMCertificate,
+		awsmodels.ApiGatewayRestApiSchema:                 PollApiGatewayRestApi,
+		awsmodels.ApiGatewayV2ApiSchema:                   PollApiGatewayV2Api,
+		awsmodels.CloudFrontDistributionSchema:            PollCloudFrontDistribution,
+		awsmodels.CloudFormationStackSchema:               PollCloudFormationStack,
+		awsmodels.CloudTrailSchema:                        PollCloudTrailTrail,
+		awsmodels.CloudWatchLogGroupSchema:                PollCloudWatchLogsLogGroup,
+		awsmodels.DynamoDBTableSchema:                     PollDynamoDBTable,
+		awsmodels.Ec2AmiSchema:                            PollEC2Image,
+		awsmodels.Ec2InstanceSchema:                       PollEC2Instance,
+		awsmodels.Ec2NetworkAclSchema:                     PollEC2NetworkACL,
+		awsmodels.Ec2SecurityGroupSchema:                  PollEC2SecurityGroup,
+		awsmodels.Ec2VolumeSchema:                         PollEC2Volume,
+		awsmodels.Ec2VpcSchema:                            PollEC2VPC,
+		awsmodels.EcrRepositorySchema:                     PollECRRepository,
+		awsmodels.EcsClusterSchema:                        PollECSCluster,
+		awsmodels.EcsServiceSchema:                        PollECSService,
+		awsmodels.EcsTaskDefinitionSchema:                 PollECSTaskDefinition,
+		awsmodels.EksClusterSchema:                        PollEKSCluster,
+		awsmodels.EfsFileSystemSchema:                     PollEfsFileSystem,
+		awsmodels.EksNodeGroupSchema:                      PollEKSNodeGroup,
+		awsmodels.ElastiCacheReplicationGroupSchema:       PollElastiCacheReplicationGroup,
+		awsmodels.ElbLoadBalancerSchema:                   PollELBLoadBalancer,
+		awsmodels.Elbv2LoadBalancerSchema:                 PollELBV2LoadBalancer,
+		awsmodels.IAMGroupSchema:                          PollIAMGroup,
+		awsmodels.IAMPolicySchema:                         PollIAMPolicy,
+		awsmodels.IAMRoleSchema:                           PollIAMRole,
+		awsmodels.IAMUserSchema:                           PollIAMUser,
+		awsmodels.IAMRootUserSchema:                       PollIAMRootUser,
+		awsmodels.KmsKeySchema:                            PollKMSKey,
+		awsmodels.LambdaFunctionSchema:                    PollLambdaFunction,
+		awsmodels.OpenSearchDomainSchema:                  PollOpenSearchDomain,
+		awsmodels.OrganizationsAccountSchema:              PollOrganizationsAccount,
+		awsmodels.OrganizationsOrganizationalUnitSchema:   PollOrganizationsOrganizationalUnit,
+		awsmodels.OrganizationsServiceControlPolicySchema: PollOrganizationsPolicy,
+		awsmodels.OrganizationsTagPolicySchema:            PollOrganizationsPolicy,
+		awsmodels.RDSClusterSchema:                        PollRDSCluster,
+		awsmodels.RDSClusterSnapshotSchema:                PollRDSClusterSnapshot,
+		awsmodels.RDSInstanceSchema:                       PollRDSInstance,
+		awsmodels.RDSSnapshotSchema:                       PollRDSSnapshot,
+		awsmodels.RedshiftClusterSchema:                   PollRedshiftCluster,
+		awsmodels.Route53HostedZoneSchema:                 PollRoute53HostedZone,
+		awsmodels.S3BucketSchema:                          PollS3Bucket,
+		awsmodels.SecretsManagerSecretSchema:              PollSecretsManagerSecret,
+		awsmodels.SnsTopicSchema:                          PollSNSTopic,
+		awsmodels.SqsQueueSchema:                          PollSQSQueue,
+		awsmodels.SsmParameterSchema:                      PollSSMParameter,
+		awsmodels.WafWebAclSchema:                         PollWAFWebACL,
+		awsmodels.WafRegionalWebAclSchema:                 PollWAFRegionalWebACL,
 	}
 
 	// IndividualResourcePollers maps resource types to their corresponding individual polling
 	// functions for resources whose ID is not their ARN.
 	IndividualResourcePollers = map[string]func(
 		input *awsmodels.ResourcePollerInput, id *utils.ParsedResourceID, entry *pollermodels.ScanEntry) (interface{}, error){
-		awsmodels.ConfigServiceSchema:  PollConfigService,
-		awsmodels.EksClusterSchema:     PollEKSCluster, // This function is never entered - lacking event-processor
-		awsmodels.GuardDutySchema:      PollGuardDutyDetector,
-		awsmodels.PasswordPolicySchema: PollPasswordPolicyResource,
+		awsmodels.ConfigServiceSchema:                       PollConfigService,
+		awsmodels.GuardDutySchema:                           PollGuardDutyDetector,
+		awsmodels.OrganizationsDelegatedAdministratorSchema: PollOrganizationsDelegatedAdministrator,
+		awsmodels.PasswordPolicySchema:                      PollPasswordPolicyResource,
 	}
 
 	// ServicePollers maps a resource type to its Poll function
 	ServicePollers = map[string]resourcePoller{
-		awsmodels.AcmCertificateSchema:      {"ACMCertificate", PollAcmCertificates},
-		awsmodels.CloudFormationStackSchema: {"CloudFormationStack", PollCloudFormationStacks},
-		awsmodels.CloudTrailSchema:          {"CloudTrail", PollCloudTrails},
-		awsmodels.CloudWatchLogGroupSchema:  {"CloudWatchLogGroup", PollCloudWatchLogsLogGroups},
-		awsmodels.ConfigServiceSchema:       {"ConfigService", PollConfigServices},
-		awsmodels.DynamoDBTableSchema:       {"DynamoDBTable", PollDynamoDBTables},
-		awsmodels.Ec2AmiSchema:              {"EC2AMI", PollEc2Amis},
-		awsmodels.Ec2InstanceSchema:         {"EC2Instance", PollEc2Instances},
-		awsmodels.Ec2NetworkAclSchema:       {"EC2NetworkACL", PollEc2NetworkAcls},
-		awsmodels.Ec2SecurityGroupSchema:    {"EC2SecurityGroup", PollEc2SecurityGroups},
-		awsmodels.Ec2VolumeSchema:           {"EC2Volume", PollEc2Volumes},
-		awsmodels.Ec2VpcSchema:              {"EC2VPC", PollEc2Vpcs},
-		awsmodels.EcsClusterSchema:          {"ECSCluster", PollEcsClusters},
-		awsmodels.EksClusterSchema:          {"EKSCluster", PollEksClusters},
-		awsmodels.Elbv2LoadBalancerSchema:   {"ELBV2LoadBalancer", PollElbv2ApplicationLoadBalancers},
-		awsmodels.GuardDutySchema:           {"GuardDutyDetector", PollGuardDutyDetectors},
-		awsmodels.IAMGroupSchema:            {"IAMGroups", PollIamGroups},
-		awsmodels.IAMPolicySchema:           {"IAMPolicies", PollIamPolicies},
-		awsmodels.IAMRoleSchema:             {"IAMRoles", PollIAMRoles},
-		awsmodels.IAMUserSchema:             {"IAMUser", PollIAMUsers},
+		awsmodels.AcmCertificateSchema:              {"ACMCertificate", PollAcmCertificates},
+		awsmodels.ApiGatewayRestApiSchema:           {"APIGatewayRestAPI", PollApiGatewayRestApis},
+		awsmodels.ApiGatewayV2ApiSchema:             {"APIGatewayV2API", PollApiGatewayV2Apis},
+		awsmodels.CloudFrontDistributionSchema:      {"CloudFrontDistribution", PollCloudFrontDistributions},
+		awsmodels.CloudFormationStackSchema:         {"CloudFormationStack", PollCloudFormationStacks},
+		awsmodels.CloudTrailSchema:                  {"CloudTrail", PollCloudTrails},
+		awsmodels.CloudWatchLogGroupSchema:          {"CloudWatchLogGroup", PollCloudWatchLogsLogGroups},
+		awsmodels.ConfigServiceSchema:               {"ConfigService", PollConfigServices},
+		awsmodels.DynamoDBTableSchema:               {"DynamoDBTable", PollDynamoDBTables},
+		awsmodels.Ec2AmiSchema:                      {"EC2AMI", PollEc2Amis},
+		awsmodels.Ec2InstanceSchema:                 {"EC2Instance", PollEc2Instances},
+		awsmodels.Ec2NetworkAclSchema:               {"EC2NetworkACL", PollEc2NetworkAcls},
+		awsmodels.Ec2SecurityGroupSchema:            {"EC2SecurityGroup", PollEc2SecurityGroups},
+		awsmodels.Ec2VolumeSchema:                   {"EC2Volume", PollEc2Volumes},
+		awsmodels.Ec2VpcSchema:                      {"EC2VPC", PollEc2Vpcs},
+		awsmodels.EcrRepositorySchema:               {"ECRRepository", PollEcrRepositories},
+		awsmodels.EcsClusterSchema:                  {"ECSCluster", PollEcsClusters},
+		awsmodels.EcsServiceSchema:                  {"ECSService", PollEcsServices},
+		awsmodels.EcsTaskDefinitionSchema:           {"ECSTaskDefinition", PollEcsTaskDefinitions},
+		awsmodels.EfsFileSystemSchema:               {"EFSFileSystem", PollEfsFileSystems},
+		awsmodels.EksClusterSchema:                  {"EKSCluster", PollEksClusters},
+		awsmodels.EksNodeGroupSchema:                {"EKSNodeGroup", PollEksNodeGroups},
+		awsmodels.ElastiCacheReplicationGroupSchema: {"ElastiCacheReplicationGroup", PollElastiCacheReplicationGroups},
+		awsmodels.ElbLoadBalancerSchema:             {"ELBLoadBalancer", PollElbLoadBalancers},
+		awsmodels.Elbv2LoadBalancerSchema:           {"ELBV2LoadBalancer", PollElbv2ApplicationLoadBalancers},
+		awsmodels.GuardDutySchema:                   {"GuardDutyDetector", PollGuardDutyDetectors},
+		awsmodels.IAMGroupSchema:                    {"IAMGroups", PollIamGroups},
+		awsmodels.IAMPolicySchema:                   {"IAMPolicies", PollIamPolicies},
+		awsmodels.IAMRoleSchema:                     {"IAMRoles", PollIAMRoles},
+		awsmodels.IAMUserSchema:                     {"IAMUser", PollIAMUsers},
 		// Service scan for the resource type IAMRootUserSchema is not defined! Do not do it!
-		awsmodels.KmsKeySchema:            {"KMSKey", PollKmsKeys},
-		awsmodels.LambdaFunctionSchema:    {"LambdaFunctions", PollLambdaFunctions},
-		awsmodels.PasswordPolicySchema:    {"PasswordPolicy", PollPasswordPolicy},
-		awsmodels.RDSInstanceSchema:       {"RDSInstance", PollRDSInstances},
-		awsmodels.RedshiftClusterSchema:   {"RedshiftCluster", PollRedshiftClusters},
-		awsmodels.S3BucketSchema:          {"S3Bucket", PollS3Buckets},
-		awsmodels.WafWebAclSchema:         {"WAFWebAcl", PollWafWebAcls},
-		awsmodels.WafRegionalWebAclSchema: {"WAFRegionalWebAcl", PollWafRegionalWebAcls},
+		awsmodels.KmsKeySchema:               {"KMSKey", PollKmsKeys},
+		awsmodels.LambdaFunctionSchema:       {"LambdaFunctions", PollLambdaFunctions},
+		awsmodels.OpenSearchDomainSchema:     {"OpenSearchDomain", PollOpenSearchDomains},
+		awsmodels.OrganizationsAccountSchema: {"OrganizationsAccount", PollOrganizationsAccounts},
+		awsmodels.OrganizationsDelegatedAdministratorSchema: {
+			"OrganizationsDelegatedAdministrator", PollOrganizationsDelegatedAdministrators},
+		awsmodels.OrganizationsOrganizationalUnitSchema: {
+			"OrganizationsOrganizationalUnit", PollOrganizationsOrganizationalUnits},
+		awsmodels.OrganizationsServiceControlPolicySchema: {
+			"OrganizationsServiceControlPolicy", PollOrganizationsServiceControlPolicies},
+		awsmodels.OrganizationsTagPolicySchema: {"OrganizationsTagPolicy", PollOrganizationsTagPolicies},
+		awsmodels.PasswordPolicySchema:         {"PasswordPolicy", PollPasswordPolicy},
+		awsmodels.RDSClusterSchema:             {"RDSCluster", PollRDSClusters},
+		awsmodels.RDSClusterSnapshotSchema:     {"RDSClusterSnapshot", PollRDSClusterSnapshots},
+		awsmodels.RDSInstanceSchema:            {"RDSInstance", PollRDSInstances},
+		awsmodels.RDSSnapshotSchema:            {"RDSSnapshot", PollRDSSnapshots},
+		awsmodels.RedshiftClusterSchema:        {"RedshiftCluster", PollRedshiftClusters},
+		awsmodels.Route53HostedZoneSchema:      {"Route53HostedZone", PollRoute53HostedZones},
+		awsmodels.S3BucketSchema:               {"S3Bucket", PollS3Buckets},
+		awsmodels.SecretsManagerSecretSchema:   {"SecretsManagerSecret", PollSecretsManagerSecrets},
+		awsmodels.SnsTopicSchema:               {"SNSTopic", PollSnsTopics},
+		awsmodels.SqsQueueSchema:               {"SQSQueue", PollSqsQueues},
+		awsmodels.SsmParameterSchema:           {"SSMParameter", PollSsmParameters},
+		awsmodels.WafWebAclSchema:              {"WAFWebAcl", PollWafWebAcls},
+		awsmodels.WafRegionalWebAclSchema:      {"WAFRegionalWebAcl", PollWafRegionalWebAcls},
+	}
+
+	// Resource types which can only be scanned from the management account of an AWS Organization. They
+	// are only scanned for integrations with organization scanning enabled.
+	organizationResourceTypes = map[string]struct{}{
+		awsmodels.OrganizationsAccountSchema:                {},
+		awsmodels.OrganizationsDelegatedAdministratorSchema: {},
+		awsmodels.OrganizationsOrganizationalUnitSchema:     {},
+		awsmodels.OrganizationsServiceControlPolicySchema:   {},
+		awsmodels.OrganizationsTagPolicySchema:              {},
 	}
 )
 
+// IsOrganizationResourceType returns true if a resource type is only scanned for organization integrations.
+func IsOrganizationResourceType(resourceType string) bool {
+	_, ok := organizationResourceTypes[resourceType]
+	return ok
+}
+
 // Poll coordinates AWS generatedEvents gathering across all relevant resources for compliance monitoring.
 func Poll(scanRequest *pollermodels.ScanEntry) (
 	generatedEvents []resourcesapimodels.AddResourceEntry, err error) {
@@ -144,13 +211,18 @@ func Poll(scanRequest *pollermodels.ScanEntry) (
 		return nil, errors.New("no AWS AccountID provided")
 	}
 
-	// Build the audit role manually
+	// Build the audit role manually, unless the integration is scanned with its own role
 	// Format: arn:aws:iam::$(ACCOUNT_ID):role/PantherAuditRole-($REGION)
-	if len(AuditRoleName) == 0 {
-		return nil, errors.New("no audit role configured")
+	var auditRoleARN string
+	if scanRequest.AuditRole != nil {
+		auditRoleARN = scanRequest.AuditRole.RoleARN
+	} else {
+		if len(AuditRoleName) == 0 {
+			return nil, errors.New("no audit role configured")
+		}
+		auditRoleARN = fmt.Sprintf("arn:aws:iam::%s:role/%s",
+			*scanRequest.AWSAccountID, AuditRoleName)
 	}
-	auditRoleARN := fmt.Sprintf("arn:aws:iam::%s:role/%s",
-		*scanRequest.AWSAccountID, AuditRoleName)
 
 	zap.L().Debug("constructed audit role", zap.String("role", auditRoleARN))
 
@@ -161,6 +233,12 @@ func Poll(scanRequest *pollermodels.ScanEntry) (
 		zap.L().Error("unable to parse constructed audit role", zap.Error(err), zap.String("roleARN", auditRoleARN))
 		return nil, nil
 	}
+	// The account of the audit role is the account the resources are reported in
+	if roleArn.AccountID != *scanRequest.AWSAccountID {
+		zap.L().Error("audit role is not in the scanned account",
+			zap.String("roleARN", auditRoleARN), zap.String("accountId", *scanRequest.AWSAccountID))
+		return nil, nil
+	}
 
 	pollerResourceInput := &awsmodels.ResourcePollerInput{
 		AuthSource:          &auditRoleARN,
@@ -174,6 +252,7 @@ func Poll(scanRequest *pollermodels.ScanEntry) (
 		RegionIgnoreList:        scanRequest.RegionIgnoreList,
 		ResourceRegexIgnoreList: scanRequest.ResourceRegexIgnoreList,
 		ResourceTypeIgnoreList:  scanRequest.ResourceTypeIgnoreList,
+		AuditRole:               scanRequest.AuditRole,
 	}
 	err = pollerResourceInput.CompileRegex()
 	if err != nil {
@@ -275,6 +354,7 @@ func multiRegionScan(
 					RegionIgnoreList:        scanRequest.RegionIgnoreList,
 					ResourceRegexIgnoreList: scanRequest.ResourceRegexIgnoreList,
 					ResourceTypeIgnoreList:  scanRequest.ResourceTypeIgnoreList,
+					AuditRole:               scanRequest.AuditRole,
 				},
 			},
 		}, int64(pageRequeueDelayer.Intn(30)+1)) // Delay between 1 & 30 seconds to spread out region scans
@@ -309,10 +389,10 @@ func serviceScan(
 	)
 
 	// If we exited early because we hit the max batch size, re-queue a scan starting from where we
-	// left off
+	// left off. A nil token tells the caller the scan of this region is complete.
+	scanRequest.NextPageToken = marker
 	if marker != nil {
 		zap.L().Debug("hit max batch size")
-		scanRequest.NextPageToken = marker
 		err = utils.Requeue(pollermodels.ScanMsg{
 			Entries: []*pollermodels.ScanEntry{
 				scanRequest,
@@ -326,6 +406,11 @@ func serviceScan(
 	return generatedEvents, nil
 }
 
+// isThrottlingError is true for the error codes AWS services return when a request is rate limited
+func isThrottlingError(err error) bool {
+	return awsutils.IsAnyError(err, "ThrottlingException", throttlingErrorCode)
+}
+
 func singleResourceScan(
 	scanRequest *pollermodels.ScanEntry,
 	pollerInput *awsmodels.ResourcePollerInput,
@@ -381,17 +466,18 @@ func singleResourceScan(
 		// Check for rate limit errors. We don't want to blindly retry rate limit errors as this will
 		// cause more rate limit errors, so we re-schedule one new scan several minutes in the future
 		// and suppress all other scans for this resource until that time.
-		if awsutils.IsAnyError(err, "ThrottlingException") {
+		if isThrottlingError(err) {
 			// If we parallelize this function, we will need to see if this is already cached before
 			// re-queueing. For now, this is not necessary.
-			err = utils.Requeue(pollermodels.ScanMsg{
-				Entries: []*pollermodels.ScanEntry{scanRequest},
-			}, int64(rateLimitDelay.Seconds())+int64(pageRequeueDelayer.Intn(60)+5))
+			err = requeueRateLimited([]string{*scanRequest.ResourceID}, func(delay int64) error {
+				return utils.Requeue(pollermodels.ScanMsg{
+					Entries: []*pollermodels.ScanEntry{scanRequest},
+				}, delay)
+			})
 			// If the requeue failed, give up and just let lambda error retrying handle it
 			if err != nil {
 				return nil, err
 			}
-			RateLimitTracker.Add(*scanRequest.ResourceID, time.Now().Add(rateLimitDelay))
 			return nil, nil
 		}
 
@@ -418,3 +504,15 @@ func singleResourceScan(
 		Type:            *scanRequest.ResourceType,
 	}}, nil
 }
+
+// requeueRateLimited schedules the scan of rate limited resources several minutes in the future, and
+// suppresses all other scans of them until then.
+func requeueRateLimited(resourceIDs []string, requeue func(delay int64) error) error {
+	if err := requeue(int64(rateLimitDelay.Seconds()) + int64(pageRequeueDelayer.Intn(60)+5)); err != nil {
+		return err
+	}
+	for _, resourceID := range resourceIDs {
+		RateLimitTracker.Add(resourceID, time.Now().Add(rateLimitDelay))
+	}
+	return nil
+}
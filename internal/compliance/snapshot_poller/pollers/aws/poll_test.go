@@ -21,10 +21,63 @@ package aws
 import (
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sourcemodels "github.com/panther-labs/panther/api/lambda/source/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
 )
 
 // Unit tests
 func TestAssumeRoleMissingParams(t *testing.T) {
 	assert.Panics(t, func() { _ = assumeRole(nil, nil) })
 }
+
+// Resources are reported in the account of the audit role, so it must be the scanned account
+func TestPollAuditRoleInOtherAccount(t *testing.T) {
+	events, err := Poll(&pollermodels.ScanEntry{
+		AWSAccountID:  aws.String("222222222222"),
+		IntegrationID: aws.String("integration-id"),
+		ResourceType:  aws.String(awsmodels.S3BucketSchema),
+		AuditRole:     &sourcemodels.AuditRoleConfig{RoleARN: "arn:aws:iam::111111111111:role/BusinessUnitAudit"},
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+// Cached clients are not reused once the role of the integration changes
+func TestGetClientRoleChanged(t *testing.T) {
+	resetCache()
+	defer func() { AssumeRoleFunc = awstest.AssumeRoleMock }()
+	var assumed []string
+	AssumeRoleFunc = func(pollerInput *awsmodels.ResourcePollerInput, sess *session.Session) *credentials.Credentials {
+		assumed = append(assumed, pollerInput.AuditRole.RoleARN)
+		return awstest.AssumeRoleMock(pollerInput, sess)
+	}
+
+	pollerInput := &awsmodels.ResourcePollerInput{
+		AuthSource:    &awstest.ExampleAuthSource,
+		IntegrationID: awstest.ExampleIntegrationID,
+		AuditRole: &sourcemodels.AuditRoleConfig{
+			RoleARN:     awstest.ExampleAuthSource,
+			SessionTags: map[string]string{"team": "security", "bu": "payments"},
+		},
+	}
+	for i := 0; i < 2; i++ {
+		_, err := getClient(pollerInput, S3ClientFunc, "s3", "us-west-2")
+		require.NoError(t, err)
+	}
+	pollerInput.AuditRole = &sourcemodels.AuditRoleConfig{
+		RoleARN:   awstest.ExampleAuthSource,
+		RoleChain: []sourcemodels.ChainedRole{{RoleARN: "arn:aws:iam::111111111111:role/SecurityHub"}},
+	}
+	_, err := getClient(pollerInput, S3ClientFunc, "s3", "us-west-2")
+	require.NoError(t, err)
+
+	assert.Len(t, assumed, 2)
+}
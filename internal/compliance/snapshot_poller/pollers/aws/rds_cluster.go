@@ -0,0 +1,203 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/aws/aws-sdk-go/service/rds/rdsiface"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// PollRDSCluster polls a single RDS DB cluster resource
+func PollRDSCluster(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	scanRequest *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	rdsClient, err := getRDSClient(pollerResourceInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster, err := getRDSCluster(rdsClient, scanRequest.ResourceID)
+	if err != nil || cluster == nil {
+		return nil, err
+	}
+
+	snapshot := buildRDSClusterSnapshot(cluster)
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	return snapshot, nil
+}
+
+// getRDSCluster returns a specific RDS cluster
+func getRDSCluster(svc rdsiface.RDSAPI, clusterARN *string) (*rds.DBCluster, error) {
+	clusters, err := svc.DescribeDBClusters(&rds.DescribeDBClustersInput{
+		Filters: []*rds.Filter{
+			{
+				Name:   aws.String("db-cluster-id"),
+				Values: []*string{clusterARN},
+			},
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "RDS.DescribeDBClusters: %s", aws.StringValue(clusterARN))
+	}
+
+	if len(clusters.DBClusters) == 0 {
+		zap.L().Warn("tried to scan non-existent resource",
+			zap.String("resource", aws.StringValue(clusterARN)),
+			zap.String("resourceType", awsmodels.RDSClusterSchema))
+		return nil, nil
+	}
+	if len(clusters.DBClusters) != 1 {
+		return nil, errors.WithMessagef(
+			errors.New("RDS.DescribeDBClusters"),
+			"expected exactly 1 DB cluster from RDS.DescribeDBClusters when describing %s, found %d DB clusters",
+			aws.StringValue(clusterARN),
+			len(clusters.DBClusters),
+		)
+	}
+	return clusters.DBClusters[0], nil
+}
+
+// describeDBClusters returns a list of all RDS clusters in the account
+func describeDBClusters(rdsSvc rdsiface.RDSAPI, nextMarker *string) (clusters []*rds.DBCluster, marker *string, err error) {
+	err = rdsSvc.DescribeDBClustersPages(&rds.DescribeDBClustersInput{
+		Marker:     nextMarker,
+		MaxRecords: aws.Int64(int64(rdsMaxBatchSize)),
+	},
+		func(page *rds.DescribeDBClustersOutput, lastPage bool) bool {
+			return rdsClusterIterator(page, &clusters, &marker)
+		})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "RDS.DescribeDBClustersPages")
+	}
+	return
+}
+
+func rdsClusterIterator(page *rds.DescribeDBClustersOutput, clusters *[]*rds.DBCluster, marker **string) bool {
+	*clusters = append(*clusters, page.DBClusters...)
+	*marker = page.Marker
+	return len(*clusters) < rdsMaxBatchSize
+}
+
+// buildRDSClusterSnapshot builds a snapshot of a given RDS DB cluster. DescribeDBClusters already
+// returns the tags, so no additional calls are needed.
+func buildRDSClusterSnapshot(cluster *rds.DBCluster) *awsmodels.RDSCluster {
+	return &awsmodels.RDSCluster{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   cluster.DBClusterArn,
+			TimeCreated:  cluster.ClusterCreateTime,
+			ResourceType: aws.String(awsmodels.RDSClusterSchema),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  cluster.DBClusterArn,
+			ID:   cluster.DBClusterIdentifier,
+			Name: cluster.DBClusterIdentifier,
+			Tags: utils.ParseTagSlice(cluster.TagList),
+		},
+		ActivityStreamKinesisStreamName:  cluster.ActivityStreamKinesisStreamName,
+		ActivityStreamKmsKeyId:           cluster.ActivityStreamKmsKeyId,
+		ActivityStreamMode:               cluster.ActivityStreamMode,
+		ActivityStreamStatus:             cluster.ActivityStreamStatus,
+		AllocatedStorage:                 cluster.AllocatedStorage,
+		AssociatedRoles:                  cluster.AssociatedRoles,
+		AvailabilityZones:                cluster.AvailabilityZones,
+		BacktrackWindow:                  cluster.BacktrackWindow,
+		BackupRetentionPeriod:            cluster.BackupRetentionPeriod,
+		Capacity:                         cluster.Capacity,
+		CopyTagsToSnapshot:               cluster.CopyTagsToSnapshot,
+		CrossAccountClone:                cluster.CrossAccountClone,
+		CustomEndpoints:                  cluster.CustomEndpoints,
+		DBClusterMembers:                 cluster.DBClusterMembers,
+		DBClusterOptionGroupMemberships:  cluster.DBClusterOptionGroupMemberships,
+		DBClusterParameterGroup:          cluster.DBClusterParameterGroup,
+		DBSubnetGroup:                    cluster.DBSubnetGroup,
+		DatabaseName:                     cluster.DatabaseName,
+		DbClusterResourceId:              cluster.DbClusterResourceId,
+		DeletionProtection:               cluster.DeletionProtection,
+		DomainMemberships:                cluster.DomainMemberships,
+		EarliestRestorableTime:           cluster.EarliestRestorableTime,
+		EnabledCloudwatchLogsExports:     cluster.EnabledCloudwatchLogsExports,
+		Endpoint:                         cluster.Endpoint,
+		Engine:                           cluster.Engine,
+		EngineMode:                       cluster.EngineMode,
+		EngineVersion:                    cluster.EngineVersion,
+		HostedZoneId:                     cluster.HostedZoneId,
+		HttpEndpointEnabled:              cluster.HttpEndpointEnabled,
+		IAMDatabaseAuthenticationEnabled: cluster.IAMDatabaseAuthenticationEnabled,
+		KmsKeyId:                         cluster.KmsKeyId,
+		LatestRestorableTime:             cluster.LatestRestorableTime,
+		MasterUsername:                   cluster.MasterUsername,
+		MultiAZ:                          cluster.MultiAZ,
+		Port:                             cluster.Port,
+		PreferredBackupWindow:            cluster.PreferredBackupWindow,
+		PreferredMaintenanceWindow:       cluster.PreferredMaintenanceWindow,
+		ReadReplicaIdentifiers:           cluster.ReadReplicaIdentifiers,
+		ReaderEndpoint:                   cluster.ReaderEndpoint,
+		ReplicationSourceIdentifier:      cluster.ReplicationSourceIdentifier,
+		ScalingConfigurationInfo:         cluster.ScalingConfigurationInfo,
+		Status:                           cluster.Status,
+		StorageEncrypted:                 cluster.StorageEncrypted,
+		VpcSecurityGroups:                cluster.VpcSecurityGroups,
+	}
+}
+
+// PollRDSClusters gathers information on each RDS DB cluster for an AWS account.
+func PollRDSClusters(pollerInput *awsmodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	zap.L().Debug("starting RDS Cluster resource poller")
+
+	rdsSvc, err := getRDSClient(pollerInput, *pollerInput.Region)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Start with generating a list of all clusters
+	clusters, marker, err := describeDBClusters(rdsSvc, pollerInput.NextPageToken)
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "region: %s", *pollerInput.Region)
+	}
+
+	resources := make([]apimodels.AddResourceEntry, 0, len(clusters))
+	for _, cluster := range clusters {
+		rdsClusterSnapshot := buildRDSClusterSnapshot(cluster)
+		rdsClusterSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+		rdsClusterSnapshot.Region = pollerInput.Region
+
+		resources = append(resources, apimodels.AddResourceEntry{
+			Attributes:      rdsClusterSnapshot,
+			ID:              *rdsClusterSnapshot.ResourceID,
+			IntegrationID:   *pollerInput.IntegrationID,
+			IntegrationType: integrationType,
+			Type:            awsmodels.RDSClusterSchema,
+		})
+	}
+
+	return resources, marker, nil
+}
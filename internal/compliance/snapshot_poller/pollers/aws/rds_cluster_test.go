@@ -0,0 +1,139 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestRDSClusterDescribe(t *testing.T) {
+	mockSvc := awstest.BuildMockRdsSvc([]string{"DescribeDBClustersPages"})
+
+	out, marker, err := describeDBClusters(mockSvc, nil)
+	assert.NotEmpty(t, out)
+	assert.Nil(t, marker)
+	assert.NoError(t, err)
+}
+
+// Test the iterator works on consecutive pages but stops at max page size
+func TestRDSClusterListIterator(t *testing.T) {
+	var clusters []*rds.DBCluster
+	var marker *string
+
+	cont := rdsClusterIterator(awstest.ExampleDescribeDBClustersOutput, &clusters, &marker)
+	assert.True(t, cont)
+	assert.Nil(t, marker)
+	assert.Len(t, clusters, 1)
+
+	for i := 1; i < 10; i++ {
+		cont = rdsClusterIterator(awstest.ExampleDescribeDBClustersOutputContinue, &clusters, &marker)
+		assert.True(t, cont)
+		assert.NotNil(t, marker)
+		assert.Len(t, clusters, 1+i*2)
+	}
+
+	cont = rdsClusterIterator(awstest.ExampleDescribeDBClustersOutputContinue, &clusters, &marker)
+	assert.False(t, cont)
+	assert.NotNil(t, marker)
+	assert.Len(t, clusters, 21)
+}
+
+func TestRDSClusterDescribeError(t *testing.T) {
+	mockSvc := awstest.BuildMockRdsSvcError([]string{"DescribeDBClustersPages"})
+
+	out, marker, err := describeDBClusters(mockSvc, nil)
+	assert.Nil(t, out)
+	assert.Nil(t, marker)
+	assert.Error(t, err)
+}
+
+func TestRDSClusterGet(t *testing.T) {
+	mockSvc := awstest.BuildMockRdsSvc([]string{"DescribeDBClusters"})
+
+	out, err := getRDSCluster(mockSvc, awstest.ExampleRDSClusterArn)
+	require.NoError(t, err)
+	assert.Equal(t, awstest.ExampleRDSClusterArn, out.DBClusterArn)
+}
+
+func TestRDSClusterGetNotFound(t *testing.T) {
+	mockSvc := &awstest.MockRds{}
+	mockSvc.On("DescribeDBClusters", mock.Anything).Return(&rds.DescribeDBClustersOutput{}, nil)
+
+	out, err := getRDSCluster(mockSvc, awstest.ExampleRDSClusterArn)
+	require.NoError(t, err)
+	assert.Nil(t, out)
+}
+
+func TestRDSClusterBuildSnapshot(t *testing.T) {
+	cluster := buildRDSClusterSnapshot(awstest.ExampleDescribeDBClustersOutput.DBClusters[0])
+
+	assert.Equal(t, "example-cluster", *cluster.Name)
+	assert.True(t, *cluster.StorageEncrypted)
+	assert.Equal(t, int64(7), *cluster.BackupRetentionPeriod)
+	assert.Len(t, cluster.VpcSecurityGroups, 1)
+	assert.Equal(t, "Value1", *cluster.Tags["Key1"])
+}
+
+func TestRDSClusterPoller(t *testing.T) {
+	resetCache()
+	awstest.MockRdsForSetup = awstest.BuildMockRdsSvcAll()
+
+	RDSClientFunc = awstest.SetupMockRds
+
+	resources, marker, err := PollRDSClusters(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, *awstest.ExampleRDSClusterArn, resources[0].ID)
+	assert.Equal(t, awsmodels.RDSClusterSchema, resources[0].Type)
+	assert.Nil(t, marker)
+}
+
+func TestRDSClusterPollerError(t *testing.T) {
+	resetCache()
+	awstest.MockRdsForSetup = awstest.BuildMockRdsSvcAllError()
+
+	RDSClientFunc = awstest.SetupMockRds
+
+	resources, marker, err := PollRDSClusters(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	assert.Empty(t, resources)
+	assert.Nil(t, marker)
+	assert.Error(t, err)
+}
@@ -145,6 +145,7 @@ func TestRDSInstanceBuildSnapshotErrors(t *testing.T) {
 }
 
 func TestRDSInstancePoller(t *testing.T) {
+	resetCache()
 	awstest.MockRdsForSetup = awstest.BuildMockRdsSvcAll()
 
 	RDSClientFunc = awstest.SetupMockRds
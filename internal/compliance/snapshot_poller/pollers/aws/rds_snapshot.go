@@ -0,0 +1,398 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/aws/aws-sdk-go/service/rds/rdsiface"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+const (
+	// Snapshots are shared with other accounts by adding them to the "restore" attribute, and made
+	// public by adding "all"
+	rdsSnapshotRestoreAttribute = "restore"
+	rdsSnapshotPublicValue      = "all"
+
+	// Automated snapshots cannot be shared, so we only look up the attributes of manual snapshots
+	rdsManualSnapshotType = "manual"
+)
+
+// isRDSSnapshotPublic determines whether a snapshot can be restored by any AWS account
+func isRDSSnapshotPublic(attributeName *string, attributeValues []*string) bool {
+	if aws.StringValue(attributeName) != rdsSnapshotRestoreAttribute {
+		return false
+	}
+	for _, value := range attributeValues {
+		if aws.StringValue(value) == rdsSnapshotPublicValue {
+			return true
+		}
+	}
+	return false
+}
+
+// PollRDSSnapshot polls a single RDS DB snapshot resource
+func PollRDSSnapshot(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	_ *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	rdsClient, err := getRDSClient(pollerResourceInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	dbSnapshot, err := getRDSSnapshot(rdsClient, aws.String(strings.TrimPrefix(resourceARN.Resource, "snapshot:")))
+	if err != nil || dbSnapshot == nil {
+		return nil, err
+	}
+
+	snapshot, err := buildRDSSnapshotSnapshot(rdsClient, dbSnapshot)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	return snapshot, nil
+}
+
+// getRDSSnapshot returns a specific RDS DB snapshot, or nil if it no longer exists
+func getRDSSnapshot(svc rdsiface.RDSAPI, snapshotID *string) (*rds.DBSnapshot, error) {
+	out, err := svc.DescribeDBSnapshots(&rds.DescribeDBSnapshotsInput{DBSnapshotIdentifier: snapshotID})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == rds.ErrCodeDBSnapshotNotFoundFault {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", aws.StringValue(snapshotID)),
+				zap.String("resourceType", awsmodels.RDSSnapshotSchema))
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "RDS.DescribeDBSnapshots: %s", aws.StringValue(snapshotID))
+	}
+	if len(out.DBSnapshots) != 1 {
+		return nil, errors.Errorf("RDS.DescribeDBSnapshots: expected exactly 1 DB snapshot for %s, found %d",
+			aws.StringValue(snapshotID), len(out.DBSnapshots))
+	}
+	return out.DBSnapshots[0], nil
+}
+
+// listRDSSnapshots returns all the manual and automated DB snapshots in the account
+func listRDSSnapshots(rdsSvc rdsiface.RDSAPI, nextMarker *string) (snapshots []*rds.DBSnapshot, marker *string, err error) {
+	err = rdsSvc.DescribeDBSnapshotsPages(&rds.DescribeDBSnapshotsInput{
+		Marker:     nextMarker,
+		MaxRecords: aws.Int64(int64(rdsMaxBatchSize)),
+	},
+		func(page *rds.DescribeDBSnapshotsOutput, lastPage bool) bool {
+			return rdsSnapshotIterator(page, &snapshots, &marker)
+		})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "RDS.DescribeDBSnapshotsPages")
+	}
+	return
+}
+
+func rdsSnapshotIterator(page *rds.DescribeDBSnapshotsOutput, snapshots *[]*rds.DBSnapshot, marker **string) bool {
+	*snapshots = append(*snapshots, page.DBSnapshots...)
+	*marker = page.Marker
+	return len(*snapshots) < rdsMaxBatchSize
+}
+
+// buildRDSSnapshotSnapshot makes all the calls to build up a snapshot of a given RDS DB snapshot
+func buildRDSSnapshotSnapshot(rdsSvc rdsiface.RDSAPI, dbSnapshot *rds.DBSnapshot) (*awsmodels.RDSSnapshot, error) {
+	snapshot := &awsmodels.RDSSnapshot{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   dbSnapshot.DBSnapshotArn,
+			TimeCreated:  dbSnapshot.SnapshotCreateTime,
+			ResourceType: aws.String(awsmodels.RDSSnapshotSchema),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  dbSnapshot.DBSnapshotArn,
+			ID:   dbSnapshot.DBSnapshotIdentifier,
+			Name: dbSnapshot.DBSnapshotIdentifier,
+			Tags: utils.ParseTagSlice(dbSnapshot.TagList),
+		},
+		AllocatedStorage:                 dbSnapshot.AllocatedStorage,
+		AvailabilityZone:                 dbSnapshot.AvailabilityZone,
+		DBInstanceIdentifier:             dbSnapshot.DBInstanceIdentifier,
+		DbiResourceId:                    dbSnapshot.DbiResourceId,
+		Encrypted:                        dbSnapshot.Encrypted,
+		Engine:                           dbSnapshot.Engine,
+		EngineVersion:                    dbSnapshot.EngineVersion,
+		IAMDatabaseAuthenticationEnabled: dbSnapshot.IAMDatabaseAuthenticationEnabled,
+		InstanceCreateTime:               dbSnapshot.InstanceCreateTime,
+		Iops:                             dbSnapshot.Iops,
+		KmsKeyId:                         dbSnapshot.KmsKeyId,
+		LicenseModel:                     dbSnapshot.LicenseModel,
+		MasterUsername:                   dbSnapshot.MasterUsername,
+		OptionGroupName:                  dbSnapshot.OptionGroupName,
+		PercentProgress:                  dbSnapshot.PercentProgress,
+		Port:                             dbSnapshot.Port,
+		SnapshotType:                     dbSnapshot.SnapshotType,
+		SourceDBSnapshotIdentifier:       dbSnapshot.SourceDBSnapshotIdentifier,
+		SourceRegion:                     dbSnapshot.SourceRegion,
+		Status:                           dbSnapshot.Status,
+		StorageType:                      dbSnapshot.StorageType,
+		TdeCredentialArn:                 dbSnapshot.TdeCredentialArn,
+		VpcId:                            dbSnapshot.VpcId,
+		Public:                           aws.Bool(false),
+	}
+
+	if aws.StringValue(dbSnapshot.SnapshotType) != rdsManualSnapshotType {
+		return snapshot, nil
+	}
+
+	attributes, err := describeDBSnapshotAttributes(rdsSvc, dbSnapshot.DBSnapshotIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	if attributes != nil {
+		snapshot.Attributes = attributes.DBSnapshotAttributes
+	}
+	for _, attribute := range snapshot.Attributes {
+		if isRDSSnapshotPublic(attribute.AttributeName, attribute.AttributeValues) {
+			snapshot.Public = aws.Bool(true)
+		}
+	}
+
+	return snapshot, nil
+}
+
+// PollRDSSnapshots gathers information on each RDS DB snapshot for an AWS account.
+func PollRDSSnapshots(pollerInput *awsmodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	zap.L().Debug("starting RDS Snapshot resource poller")
+
+	rdsSvc, err := getRDSClient(pollerInput, *pollerInput.Region)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Start with generating a list of all snapshots
+	dbSnapshots, marker, err := listRDSSnapshots(rdsSvc, pollerInput.NextPageToken)
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "region: %s", *pollerInput.Region)
+	}
+
+	resources := make([]apimodels.AddResourceEntry, 0, len(dbSnapshots))
+	for _, dbSnapshot := range dbSnapshots {
+		rdsSnapshot, err := buildRDSSnapshotSnapshot(rdsSvc, dbSnapshot)
+		if err != nil {
+			return nil, nil, err
+		}
+		rdsSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+		rdsSnapshot.Region = pollerInput.Region
+
+		resources = append(resources, apimodels.AddResourceEntry{
+			Attributes:      rdsSnapshot,
+			ID:              *rdsSnapshot.ResourceID,
+			IntegrationID:   *pollerInput.IntegrationID,
+			IntegrationType: integrationType,
+			Type:            awsmodels.RDSSnapshotSchema,
+		})
+	}
+
+	return resources, marker, nil
+}
+
+// PollRDSClusterSnapshot polls a single RDS DB cluster snapshot resource
+func PollRDSClusterSnapshot(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	_ *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	rdsClient, err := getRDSClient(pollerResourceInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterSnapshot, err := getRDSClusterSnapshot(
+		rdsClient, aws.String(strings.TrimPrefix(resourceARN.Resource, "cluster-snapshot:")))
+	if err != nil || clusterSnapshot == nil {
+		return nil, err
+	}
+
+	snapshot, err := buildRDSClusterSnapshotSnapshot(rdsClient, clusterSnapshot)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	return snapshot, nil
+}
+
+// getRDSClusterSnapshot returns a specific RDS DB cluster snapshot, or nil if it no longer exists
+func getRDSClusterSnapshot(svc rdsiface.RDSAPI, snapshotID *string) (*rds.DBClusterSnapshot, error) {
+	out, err := svc.DescribeDBClusterSnapshots(&rds.DescribeDBClusterSnapshotsInput{DBClusterSnapshotIdentifier: snapshotID})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == rds.ErrCodeDBClusterSnapshotNotFoundFault {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", aws.StringValue(snapshotID)),
+				zap.String("resourceType", awsmodels.RDSClusterSnapshotSchema))
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "RDS.DescribeDBClusterSnapshots: %s", aws.StringValue(snapshotID))
+	}
+	if len(out.DBClusterSnapshots) != 1 {
+		return nil, errors.Errorf("RDS.DescribeDBClusterSnapshots: expected exactly 1 DB cluster snapshot for %s, found %d",
+			aws.StringValue(snapshotID), len(out.DBClusterSnapshots))
+	}
+	return out.DBClusterSnapshots[0], nil
+}
+
+// listRDSClusterSnapshots returns all the manual and automated DB cluster snapshots in the account
+func listRDSClusterSnapshots(rdsSvc rdsiface.RDSAPI, nextMarker *string) (
+	snapshots []*rds.DBClusterSnapshot, marker *string, err error) {
+
+	err = rdsSvc.DescribeDBClusterSnapshotsPages(&rds.DescribeDBClusterSnapshotsInput{
+		Marker:     nextMarker,
+		MaxRecords: aws.Int64(int64(rdsMaxBatchSize)),
+	},
+		func(page *rds.DescribeDBClusterSnapshotsOutput, lastPage bool) bool {
+			return rdsClusterSnapshotIterator(page, &snapshots, &marker)
+		})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "RDS.DescribeDBClusterSnapshotsPages")
+	}
+	return
+}
+
+func rdsClusterSnapshotIterator(
+	page *rds.DescribeDBClusterSnapshotsOutput, snapshots *[]*rds.DBClusterSnapshot, marker **string) bool {
+
+	*snapshots = append(*snapshots, page.DBClusterSnapshots...)
+	*marker = page.Marker
+	return len(*snapshots) < rdsMaxBatchSize
+}
+
+// describeDBClusterSnapshotAttributes returns the sharing attributes of an RDS DB cluster snapshot
+func describeDBClusterSnapshotAttributes(rdsSvc rdsiface.RDSAPI, snapshotID *string) (*rds.DBClusterSnapshotAttributesResult, error) {
+	out, err := rdsSvc.DescribeDBClusterSnapshotAttributes(
+		&rds.DescribeDBClusterSnapshotAttributesInput{DBClusterSnapshotIdentifier: snapshotID},
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "RDS.DescribeDBClusterSnapshotAttributes: %s", aws.StringValue(snapshotID))
+	}
+	return out.DBClusterSnapshotAttributesResult, nil
+}
+
+// buildRDSClusterSnapshotSnapshot makes all the calls to build up a snapshot of a given RDS DB
+// cluster snapshot
+func buildRDSClusterSnapshotSnapshot(
+	rdsSvc rdsiface.RDSAPI, clusterSnapshot *rds.DBClusterSnapshot) (*awsmodels.RDSClusterSnapshot, error) {
+
+	snapshot := &awsmodels.RDSClusterSnapshot{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   clusterSnapshot.DBClusterSnapshotArn,
+			TimeCreated:  clusterSnapshot.SnapshotCreateTime,
+			ResourceType: aws.String(awsmodels.RDSClusterSnapshotSchema),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  clusterSnapshot.DBClusterSnapshotArn,
+			ID:   clusterSnapshot.DBClusterSnapshotIdentifier,
+			Name: clusterSnapshot.DBClusterSnapshotIdentifier,
+			Tags: utils.ParseTagSlice(clusterSnapshot.TagList),
+		},
+		AllocatedStorage:                 clusterSnapshot.AllocatedStorage,
+		AvailabilityZones:                clusterSnapshot.AvailabilityZones,
+		ClusterCreateTime:                clusterSnapshot.ClusterCreateTime,
+		DBClusterIdentifier:              clusterSnapshot.DBClusterIdentifier,
+		Engine:                           clusterSnapshot.Engine,
+		EngineVersion:                    clusterSnapshot.EngineVersion,
+		IAMDatabaseAuthenticationEnabled: clusterSnapshot.IAMDatabaseAuthenticationEnabled,
+		KmsKeyId:                         clusterSnapshot.KmsKeyId,
+		LicenseModel:                     clusterSnapshot.LicenseModel,
+		MasterUsername:                   clusterSnapshot.MasterUsername,
+		PercentProgress:                  clusterSnapshot.PercentProgress,
+		Port:                             clusterSnapshot.Port,
+		SnapshotType:                     clusterSnapshot.SnapshotType,
+		SourceDBClusterSnapshotArn:       clusterSnapshot.SourceDBClusterSnapshotArn,
+		Status:                           clusterSnapshot.Status,
+		StorageEncrypted:                 clusterSnapshot.StorageEncrypted,
+		VpcId:                            clusterSnapshot.VpcId,
+		Public:                           aws.Bool(false),
+	}
+
+	if aws.StringValue(clusterSnapshot.SnapshotType) != rdsManualSnapshotType {
+		return snapshot, nil
+	}
+
+	attributes, err := describeDBClusterSnapshotAttributes(rdsSvc, clusterSnapshot.DBClusterSnapshotIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	if attributes != nil {
+		snapshot.Attributes = attributes.DBClusterSnapshotAttributes
+	}
+	for _, attribute := range snapshot.Attributes {
+		if isRDSSnapshotPublic(attribute.AttributeName, attribute.AttributeValues) {
+			snapshot.Public = aws.Bool(true)
+		}
+	}
+
+	return snapshot, nil
+}
+
+// PollRDSClusterSnapshots gathers information on each RDS DB cluster snapshot for an AWS account.
+func PollRDSClusterSnapshots(pollerInput *awsmodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	zap.L().Debug("starting RDS Cluster Snapshot resource poller")
+
+	rdsSvc, err := getRDSClient(pollerInput, *pollerInput.Region)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Start with generating a list of all cluster snapshots
+	clusterSnapshots, marker, err := listRDSClusterSnapshots(rdsSvc, pollerInput.NextPageToken)
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "region: %s", *pollerInput.Region)
+	}
+
+	resources := make([]apimodels.AddResourceEntry, 0, len(clusterSnapshots))
+	for _, clusterSnapshot := range clusterSnapshots {
+		rdsClusterSnapshot, err := buildRDSClusterSnapshotSnapshot(rdsSvc, clusterSnapshot)
+		if err != nil {
+			return nil, nil, err
+		}
+		rdsClusterSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+		rdsClusterSnapshot.Region = pollerInput.Region
+
+		resources = append(resources, apimodels.AddResourceEntry{
+			Attributes:      rdsClusterSnapshot,
+			ID:              *rdsClusterSnapshot.ResourceID,
+			IntegrationID:   *pollerInput.IntegrationID,
+			IntegrationType: integrationType,
+			Type:            awsmodels.RDSClusterSnapshotSchema,
+		})
+	}
+
+	return resources, marker, nil
+}
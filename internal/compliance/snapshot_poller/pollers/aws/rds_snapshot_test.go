@@ -0,0 +1,208 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestIsRDSSnapshotPublic(t *testing.T) {
+	assert.True(t, isRDSSnapshotPublic(aws.String("restore"), []*string{aws.String("123456789012"), aws.String("all")}))
+	assert.False(t, isRDSSnapshotPublic(aws.String("restore"), []*string{aws.String("123456789012")}))
+	assert.False(t, isRDSSnapshotPublic(aws.String("other"), []*string{aws.String("all")}))
+	assert.False(t, isRDSSnapshotPublic(aws.String("restore"), nil))
+}
+
+func TestRDSSnapshotGet(t *testing.T) {
+	mockSvc := awstest.BuildMockRdsSvc([]string{"DescribeDBSnapshots"})
+
+	out, err := getRDSSnapshot(mockSvc, awstest.ExampleRDSSnapshotID)
+	require.NoError(t, err)
+	assert.NotNil(t, out)
+}
+
+func TestRDSSnapshotGetNotFound(t *testing.T) {
+	mockSvc := &awstest.MockRds{}
+	mockSvc.On("DescribeDBSnapshots", mock.Anything).Return(
+		&rds.DescribeDBSnapshotsOutput{},
+		awserr.New(rds.ErrCodeDBSnapshotNotFoundFault, "DBSnapshot not found", nil),
+	)
+
+	out, err := getRDSSnapshot(mockSvc, awstest.ExampleRDSSnapshotID)
+	require.NoError(t, err)
+	assert.Nil(t, out)
+}
+
+func TestRDSSnapshotList(t *testing.T) {
+	mockSvc := awstest.BuildMockRdsSvc([]string{"DescribeDBSnapshotsPages"})
+
+	out, marker, err := listRDSSnapshots(mockSvc, nil)
+	assert.NotEmpty(t, out)
+	assert.Nil(t, marker)
+	assert.NoError(t, err)
+}
+
+func TestRDSSnapshotListError(t *testing.T) {
+	mockSvc := awstest.BuildMockRdsSvcError([]string{"DescribeDBSnapshotsPages"})
+
+	out, marker, err := listRDSSnapshots(mockSvc, nil)
+	assert.Nil(t, out)
+	assert.Nil(t, marker)
+	assert.Error(t, err)
+}
+
+// Automated snapshots cannot be shared, so their attributes are never looked up
+func TestRDSSnapshotBuildSnapshotAutomated(t *testing.T) {
+	mockSvc := &awstest.MockRds{}
+
+	snapshot, err := buildRDSSnapshotSnapshot(mockSvc, awstest.ExampleDescribeDBSnapshotsOutput.DBSnapshots[0])
+	require.NoError(t, err)
+	assert.False(t, *snapshot.Public)
+	assert.Nil(t, snapshot.Attributes)
+	mockSvc.AssertNotCalled(t, "DescribeDBSnapshotAttributes", mock.Anything)
+}
+
+func TestRDSSnapshotBuildSnapshotManual(t *testing.T) {
+	mockSvc := &awstest.MockRds{}
+	mockSvc.On("DescribeDBSnapshotAttributes", mock.Anything).Return(&rds.DescribeDBSnapshotAttributesOutput{
+		DBSnapshotAttributesResult: &rds.DBSnapshotAttributesResult{
+			DBSnapshotAttributes: []*rds.DBSnapshotAttribute{
+				{
+					AttributeName:   aws.String("restore"),
+					AttributeValues: []*string{aws.String("all")},
+				},
+			},
+		},
+	}, nil)
+
+	dbSnapshot := *awstest.ExampleDescribeDBSnapshotsOutput.DBSnapshots[0]
+	dbSnapshot.SnapshotType = aws.String("manual")
+	snapshot, err := buildRDSSnapshotSnapshot(mockSvc, &dbSnapshot)
+	require.NoError(t, err)
+	assert.True(t, *snapshot.Public)
+	assert.Len(t, snapshot.Attributes, 1)
+}
+
+func TestRDSSnapshotPoller(t *testing.T) {
+	resetCache()
+	awstest.MockRdsForSetup = awstest.BuildMockRdsSvcAll()
+
+	RDSClientFunc = awstest.SetupMockRds
+
+	resources, marker, err := PollRDSSnapshots(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, awsmodels.RDSSnapshotSchema, resources[0].Type)
+	assert.Nil(t, marker)
+}
+
+func TestRDSSnapshotPollerError(t *testing.T) {
+	resetCache()
+	awstest.MockRdsForSetup = awstest.BuildMockRdsSvcAllError()
+
+	RDSClientFunc = awstest.SetupMockRds
+
+	resources, marker, err := PollRDSSnapshots(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	assert.Empty(t, resources)
+	assert.Nil(t, marker)
+	assert.Error(t, err)
+}
+
+func TestRDSClusterSnapshotGet(t *testing.T) {
+	mockSvc := awstest.BuildMockRdsSvc([]string{"DescribeDBClusterSnapshots"})
+
+	out, err := getRDSClusterSnapshot(mockSvc, awstest.ExampleRDSClusterSnapshotID)
+	require.NoError(t, err)
+	assert.Equal(t, awstest.ExampleRDSClusterSnapshotID, out.DBClusterSnapshotIdentifier)
+}
+
+func TestRDSClusterSnapshotGetNotFound(t *testing.T) {
+	mockSvc := &awstest.MockRds{}
+	mockSvc.On("DescribeDBClusterSnapshots", mock.Anything).Return(
+		&rds.DescribeDBClusterSnapshotsOutput{},
+		awserr.New(rds.ErrCodeDBClusterSnapshotNotFoundFault, "DBClusterSnapshot not found", nil),
+	)
+
+	out, err := getRDSClusterSnapshot(mockSvc, awstest.ExampleRDSClusterSnapshotID)
+	require.NoError(t, err)
+	assert.Nil(t, out)
+}
+
+func TestRDSClusterSnapshotBuildSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockRdsSvc([]string{"DescribeDBClusterSnapshotAttributes"})
+
+	snapshot, err := buildRDSClusterSnapshotSnapshot(mockSvc, awstest.ExampleDescribeDBClusterSnapshotsOutput.DBClusterSnapshots[0])
+	require.NoError(t, err)
+	assert.Equal(t, "example-cluster-snapshot", *snapshot.Name)
+	assert.True(t, *snapshot.Public)
+	assert.False(t, *snapshot.StorageEncrypted)
+}
+
+func TestRDSClusterSnapshotBuildSnapshotError(t *testing.T) {
+	mockSvc := awstest.BuildMockRdsSvcError([]string{"DescribeDBClusterSnapshotAttributes"})
+
+	snapshot, err := buildRDSClusterSnapshotSnapshot(mockSvc, awstest.ExampleDescribeDBClusterSnapshotsOutput.DBClusterSnapshots[0])
+	assert.Error(t, err)
+	assert.Nil(t, snapshot)
+}
+
+func TestRDSClusterSnapshotPoller(t *testing.T) {
+	resetCache()
+	awstest.MockRdsForSetup = awstest.BuildMockRdsSvcAll()
+
+	RDSClientFunc = awstest.SetupMockRds
+
+	resources, marker, err := PollRDSClusterSnapshots(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, awsmodels.RDSClusterSnapshotSchema, resources[0].Type)
+	assert.True(t, *resources[0].Attributes.(*awsmodels.RDSClusterSnapshot).Public)
+	assert.Nil(t, marker)
+}
@@ -142,7 +142,7 @@ func TestRedshiftClusterPollerError(t *testing.T) {
 
 	RedshiftClientFunc = awstest.SetupMockRedshift
 
-	resources, marker, err := PollRDSInstances(&awsmodels.ResourcePollerInput{
+	resources, marker, err := PollRedshiftClusters(&awsmodels.ResourcePollerInput{
 		AuthSource:          &awstest.ExampleAuthSource,
 		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
 		IntegrationID:       awstest.ExampleIntegrationID,
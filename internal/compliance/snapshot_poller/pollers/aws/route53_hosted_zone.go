@@ -0,0 +1,238 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// Set as variables to be overridden in testing
+var (
+	Route53ClientFunc = setupRoute53Client
+)
+
+func setupRoute53Client(sess *session.Session, cfg *aws.Config) interface{} {
+	return route53.New(sess, cfg)
+}
+
+func getRoute53Client(pollerResourceInput *awsmodels.ResourcePollerInput, region string) (route53iface.Route53API, error) {
+	client, err := getClient(pollerResourceInput, Route53ClientFunc, "route53", region)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.(route53iface.Route53API), nil
+}
+
+// route53HostedZoneID strips the "/hostedzone/" prefix the Route 53 API puts on hosted zone IDs
+func route53HostedZoneID(id *string) string {
+	return strings.TrimPrefix(aws.StringValue(id), "/hostedzone/")
+}
+
+// PollRoute53HostedZone polls a single Route 53 hosted zone resource
+func PollRoute53HostedZone(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	_ *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getRoute53Client(pollerResourceInput, defaultRegion)
+	if err != nil {
+		return nil, err
+	}
+
+	hostedZoneID := strings.TrimPrefix(resourceARN.Resource, "hostedzone/")
+	snapshot, err := buildRoute53HostedZoneSnapshot(client, aws.String(hostedZoneID))
+	if err != nil || snapshot == nil {
+		return nil, err
+	}
+	// Hosted zone ARNs do not contain an account ID
+	snapshot.AccountID = aws.String(pollerResourceInput.AuthSourceParsedARN.AccountID)
+	snapshot.Region = aws.String(awsmodels.GlobalRegion)
+	return snapshot, nil
+}
+
+// listHostedZones returns all the Route 53 hosted zones in the account
+func listHostedZones(route53Svc route53iface.Route53API, nextMarker *string) (
+	hostedZones []*route53.HostedZone, marker *string, err error) {
+
+	err = route53Svc.ListHostedZonesPages(&route53.ListHostedZonesInput{
+		Marker:   nextMarker,
+		MaxItems: aws.String(strconv.Itoa(defaultBatchSize)),
+	},
+		func(page *route53.ListHostedZonesOutput, lastPage bool) bool {
+			return route53HostedZoneIterator(page, &hostedZones, &marker)
+		})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "Route53.ListHostedZonesPages")
+	}
+	return
+}
+
+func route53HostedZoneIterator(page *route53.ListHostedZonesOutput, hostedZones *[]*route53.HostedZone, marker **string) bool {
+	*hostedZones = append(*hostedZones, page.HostedZones...)
+	*marker = page.NextMarker
+	return len(*hostedZones) < defaultBatchSize
+}
+
+// getHostedZone returns the details of a Route 53 hosted zone, or nil if it no longer exists
+func getHostedZone(route53Svc route53iface.Route53API, id *string) (*route53.GetHostedZoneOutput, error) {
+	out, err := route53Svc.GetHostedZone(&route53.GetHostedZoneInput{Id: id})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == route53.ErrCodeNoSuchHostedZone {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", aws.StringValue(id)),
+				zap.String("resourceType", awsmodels.Route53HostedZoneSchema))
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "Route53.GetHostedZone: %s", aws.StringValue(id))
+	}
+	return out, nil
+}
+
+// listQueryLoggingConfigs returns the DNS query logging configurations of a hosted zone
+func listQueryLoggingConfigs(route53Svc route53iface.Route53API, id *string) (configs []*route53.QueryLoggingConfig, err error) {
+	err = route53Svc.ListQueryLoggingConfigsPages(&route53.ListQueryLoggingConfigsInput{HostedZoneId: id},
+		func(page *route53.ListQueryLoggingConfigsOutput, lastPage bool) bool {
+			configs = append(configs, page.QueryLoggingConfigs...)
+			return true
+		})
+	if err != nil {
+		return nil, errors.Wrapf(err, "Route53.ListQueryLoggingConfigsPages: %s", aws.StringValue(id))
+	}
+	return
+}
+
+// listHostedZoneTags returns the tags of a hosted zone
+func listHostedZoneTags(route53Svc route53iface.Route53API, id *string) ([]*route53.Tag, error) {
+	out, err := route53Svc.ListTagsForResource(&route53.ListTagsForResourceInput{
+		ResourceId:   id,
+		ResourceType: aws.String(route53.TagResourceTypeHostedzone),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "Route53.ListTagsForResource: %s", aws.StringValue(id))
+	}
+	if out.ResourceTagSet == nil {
+		return nil, nil
+	}
+	return out.ResourceTagSet.Tags, nil
+}
+
+// buildRoute53HostedZoneSnapshot makes all the calls to build up a snapshot of a given hosted zone
+//
+// TODO: add the DNSSEC signing status once the AWS SDK is upgraded to a version with GetDNSSEC
+func buildRoute53HostedZoneSnapshot(route53Svc route53iface.Route53API, id *string) (*awsmodels.Route53HostedZone, error) {
+	details, err := getHostedZone(route53Svc, id)
+	if err != nil || details == nil || details.HostedZone == nil {
+		return nil, err
+	}
+	hostedZone := details.HostedZone
+
+	hostedZoneID := aws.String(route53HostedZoneID(hostedZone.Id))
+	hostedZoneARN := aws.String(arn.ARN{
+		Partition: "aws",
+		Service:   "route53",
+		Resource:  "hostedzone/" + *hostedZoneID,
+	}.String())
+
+	snapshot := &awsmodels.Route53HostedZone{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   hostedZoneARN,
+			ResourceType: aws.String(awsmodels.Route53HostedZoneSchema),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  hostedZoneARN,
+			ID:   hostedZoneID,
+			Name: hostedZone.Name,
+		},
+		CallerReference:        hostedZone.CallerReference,
+		Config:                 hostedZone.Config,
+		LinkedService:          hostedZone.LinkedService,
+		ResourceRecordSetCount: hostedZone.ResourceRecordSetCount,
+		DelegationSet:          details.DelegationSet,
+		VPCs:                   details.VPCs,
+	}
+
+	if snapshot.QueryLoggingConfigs, err = listQueryLoggingConfigs(route53Svc, hostedZoneID); err != nil {
+		return nil, err
+	}
+
+	tags, err := listHostedZoneTags(route53Svc, hostedZoneID)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.Tags = utils.ParseTagSlice(tags)
+
+	return snapshot, nil
+}
+
+// PollRoute53HostedZones gathers information on each Route 53 hosted zone for an AWS account.
+func PollRoute53HostedZones(pollerInput *awsmodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	zap.L().Debug("starting Route53 Hosted Zone resource poller")
+
+	route53Svc, err := getRoute53Client(pollerInput, defaultRegion)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Start with generating a list of all hosted zones
+	hostedZones, marker, err := listHostedZones(route53Svc, pollerInput.NextPageToken)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "region: global")
+	}
+
+	resources := make([]apimodels.AddResourceEntry, 0, len(hostedZones))
+	for _, hostedZone := range hostedZones {
+		hostedZoneSnapshot, err := buildRoute53HostedZoneSnapshot(route53Svc, aws.String(route53HostedZoneID(hostedZone.Id)))
+		if err != nil {
+			return nil, nil, err
+		}
+		if hostedZoneSnapshot == nil {
+			continue
+		}
+		hostedZoneSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+		hostedZoneSnapshot.Region = aws.String(awsmodels.GlobalRegion)
+
+		resources = append(resources, apimodels.AddResourceEntry{
+			Attributes:      hostedZoneSnapshot,
+			ID:              *hostedZoneSnapshot.ResourceID,
+			IntegrationID:   *pollerInput.IntegrationID,
+			IntegrationType: integrationType,
+			Type:            awsmodels.Route53HostedZoneSchema,
+		})
+	}
+
+	return resources, marker, nil
+}
@@ -0,0 +1,186 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+const exampleHostedZoneARN = "arn:aws:route53:::hostedzone/Z1D633PJN98FT9"
+
+func TestRoute53HostedZoneList(t *testing.T) {
+	mockSvc := awstest.BuildMockRoute53Svc([]string{"ListHostedZonesPages"})
+
+	out, marker, err := listHostedZones(mockSvc, nil)
+	assert.NotEmpty(t, out)
+	assert.Nil(t, marker)
+	assert.NoError(t, err)
+}
+
+// Test the iterator works on consecutive pages but stops at max page size
+func TestRoute53HostedZoneListIterator(t *testing.T) {
+	var hostedZones []*route53.HostedZone
+	var marker *string
+
+	cont := route53HostedZoneIterator(awstest.ExampleListHostedZonesOutput, &hostedZones, &marker)
+	assert.True(t, cont)
+	assert.Nil(t, marker)
+	assert.Len(t, hostedZones, 1)
+
+	for i := 1; i < 50; i++ {
+		cont = route53HostedZoneIterator(awstest.ExampleListHostedZonesOutputContinue, &hostedZones, &marker)
+		assert.True(t, cont)
+		assert.NotNil(t, marker)
+		assert.Len(t, hostedZones, 1+i*2)
+	}
+
+	cont = route53HostedZoneIterator(awstest.ExampleListHostedZonesOutputContinue, &hostedZones, &marker)
+	assert.False(t, cont)
+	assert.NotNil(t, marker)
+	assert.Len(t, hostedZones, 101)
+}
+
+func TestRoute53HostedZoneListError(t *testing.T) {
+	mockSvc := awstest.BuildMockRoute53SvcError([]string{"ListHostedZonesPages"})
+
+	out, marker, err := listHostedZones(mockSvc, nil)
+	assert.Nil(t, out)
+	assert.Nil(t, marker)
+	assert.Error(t, err)
+}
+
+func TestRoute53HostedZoneGetNotFound(t *testing.T) {
+	mockSvc := &awstest.MockRoute53{}
+	mockSvc.On("GetHostedZone", mock.Anything).Return(
+		&route53.GetHostedZoneOutput{},
+		awserr.New(route53.ErrCodeNoSuchHostedZone, "not found", nil),
+	)
+
+	out, err := getHostedZone(mockSvc, aws.String("Z1D633PJN98FT9"))
+	require.NoError(t, err)
+	assert.Nil(t, out)
+}
+
+func TestRoute53HostedZoneBuildSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockRoute53SvcAll()
+
+	snapshot, err := buildRoute53HostedZoneSnapshot(mockSvc, aws.String("Z1D633PJN98FT9"))
+	require.NoError(t, err)
+	assert.Equal(t, aws.String(exampleHostedZoneARN), snapshot.ResourceID)
+	assert.Equal(t, aws.String("Z1D633PJN98FT9"), snapshot.ID)
+	assert.Equal(t, aws.String("example.com."), snapshot.Name)
+	assert.Len(t, snapshot.QueryLoggingConfigs, 1)
+	assert.NotNil(t, snapshot.DelegationSet)
+	assert.Equal(t, map[string]*string{"Key1": aws.String("Value1")}, snapshot.Tags)
+	mockSvc.AssertCalled(t, "ListTagsForResource", &route53.ListTagsForResourceInput{
+		ResourceId:   aws.String("Z1D633PJN98FT9"),
+		ResourceType: aws.String(route53.TagResourceTypeHostedzone),
+	})
+}
+
+func TestRoute53HostedZoneBuildSnapshotErrors(t *testing.T) {
+	mockSvc := awstest.BuildMockRoute53SvcError([]string{"ListQueryLoggingConfigsPages"})
+	mockSvc.On("GetHostedZone", mock.Anything).Return(awstest.ExampleGetHostedZoneOutput, nil)
+
+	snapshot, err := buildRoute53HostedZoneSnapshot(mockSvc, aws.String("Z1D633PJN98FT9"))
+	assert.Error(t, err)
+	assert.Nil(t, snapshot)
+
+	mockSvc = awstest.BuildMockRoute53SvcAllError()
+	snapshot, err = buildRoute53HostedZoneSnapshot(mockSvc, aws.String("Z1D633PJN98FT9"))
+	assert.Error(t, err)
+	assert.Nil(t, snapshot)
+}
+
+func TestRoute53HostedZonePollSingle(t *testing.T) {
+	resetCache()
+	mockSvc := awstest.BuildMockRoute53SvcAll()
+	awstest.MockRoute53ForSetup = mockSvc
+
+	Route53ClientFunc = awstest.SetupMockRoute53
+
+	resourceARN, err := arn.Parse(exampleHostedZoneARN)
+	require.NoError(t, err)
+	snapshot, err := PollRoute53HostedZone(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Timestamp:           &awstest.ExampleTime,
+	}, resourceARN, &pollermodels.ScanEntry{ResourceID: aws.String(exampleHostedZoneARN)})
+
+	require.NoError(t, err)
+	hostedZone := snapshot.(*awsmodels.Route53HostedZone)
+	assert.Equal(t, aws.String("123456789012"), hostedZone.AccountID)
+	assert.Equal(t, aws.String(awsmodels.GlobalRegion), hostedZone.Region)
+	mockSvc.AssertCalled(t, "GetHostedZone", &route53.GetHostedZoneInput{Id: aws.String("Z1D633PJN98FT9")})
+}
+
+func TestRoute53HostedZonePoller(t *testing.T) {
+	resetCache()
+	awstest.MockRoute53ForSetup = awstest.BuildMockRoute53SvcAll()
+
+	Route53ClientFunc = awstest.SetupMockRoute53
+
+	resources, marker, err := PollRoute53HostedZones(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              aws.String(awsmodels.GlobalRegion),
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, awsmodels.Route53HostedZoneSchema, resources[0].Type)
+	assert.Equal(t, exampleHostedZoneARN, resources[0].ID)
+	assert.Nil(t, marker)
+}
+
+func TestRoute53HostedZonePollerError(t *testing.T) {
+	resetCache()
+	awstest.MockRoute53ForSetup = awstest.BuildMockRoute53SvcAllError()
+
+	Route53ClientFunc = awstest.SetupMockRoute53
+
+	resources, marker, err := PollRoute53HostedZones(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              aws.String(awsmodels.GlobalRegion),
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	for _, event := range resources {
+		assert.Nil(t, event.Attributes)
+	}
+	assert.Nil(t, marker)
+	assert.Error(t, err)
+}
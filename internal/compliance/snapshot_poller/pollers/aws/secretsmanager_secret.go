@@ -0,0 +1,216 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// Set as variables to be overridden in testing
+var (
+	SecretsManagerClientFunc = setupSecretsManagerClient
+)
+
+func setupSecretsManagerClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return secretsmanager.New(sess, cfg)
+}
+
+func getSecretsManagerClient(pollerResourceInput *awsmodels.ResourcePollerInput,
+	region string) (secretsmanageriface.SecretsManagerAPI, error) {
+
+	client, err := getClient(pollerResourceInput, SecretsManagerClientFunc, "secretsmanager", region)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.(secretsmanageriface.SecretsManagerAPI), nil
+}
+
+// PollSecretsManagerSecret polls a single Secrets Manager secret resource
+func PollSecretsManagerSecret(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	scanRequest *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getSecretsManagerClient(pollerResourceInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := buildSecretsManagerSecretSnapshot(client, scanRequest.ResourceID)
+	if err != nil || snapshot == nil {
+		return nil, err
+	}
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	return snapshot, nil
+}
+
+// listSecrets returns a list of all secrets in the account
+func listSecrets(secretsSvc secretsmanageriface.SecretsManagerAPI,
+	nextMarker *string) (secrets []*secretsmanager.SecretListEntry, marker *string, err error) {
+
+	err = secretsSvc.ListSecretsPages(&secretsmanager.ListSecretsInput{
+		MaxResults: aws.Int64(int64(defaultBatchSize)),
+		NextToken:  nextMarker,
+	},
+		func(page *secretsmanager.ListSecretsOutput, lastPage bool) bool {
+			return secretsManagerSecretIterator(page, &secrets, &marker)
+		})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "SecretsManager.ListSecretsPages")
+	}
+	return
+}
+
+func secretsManagerSecretIterator(
+	page *secretsmanager.ListSecretsOutput,
+	secrets *[]*secretsmanager.SecretListEntry,
+	marker **string,
+) bool {
+
+	*secrets = append(*secrets, page.SecretList...)
+	*marker = page.NextToken
+	return len(*secrets) < defaultBatchSize
+}
+
+// describeSecret returns the metadata of a secret, or nil if it no longer exists
+func describeSecret(secretsSvc secretsmanageriface.SecretsManagerAPI,
+	secretID *string) (*secretsmanager.DescribeSecretOutput, error) {
+
+	out, err := secretsSvc.DescribeSecret(&secretsmanager.DescribeSecretInput{SecretId: secretID})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == secretsmanager.ErrCodeResourceNotFoundException {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", aws.StringValue(secretID)),
+				zap.String("resourceType", awsmodels.SecretsManagerSecretSchema))
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "SecretsManager.DescribeSecret: %s", aws.StringValue(secretID))
+	}
+	return out, nil
+}
+
+// getSecretResourcePolicy returns the resource policy attached to a secret, if there is one
+func getSecretResourcePolicy(secretsSvc secretsmanageriface.SecretsManagerAPI, secretID *string) (*string, error) {
+	out, err := secretsSvc.GetResourcePolicy(&secretsmanager.GetResourcePolicyInput{SecretId: secretID})
+	if err != nil {
+		return nil, errors.Wrapf(err, "SecretsManager.GetResourcePolicy: %s", aws.StringValue(secretID))
+	}
+	return out.ResourcePolicy, nil
+}
+
+// buildSecretsManagerSecretSnapshot makes all the calls to build up a snapshot of a given secret.
+//
+// The secret value is never retrieved.
+func buildSecretsManagerSecretSnapshot(secretsSvc secretsmanageriface.SecretsManagerAPI,
+	secretID *string) (*awsmodels.SecretsManagerSecret, error) {
+
+	if secretID == nil {
+		return nil, nil
+	}
+
+	details, err := describeSecret(secretsSvc, secretID)
+	if err != nil || details == nil {
+		return nil, err
+	}
+
+	secret := &awsmodels.SecretsManagerSecret{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   details.ARN,
+			ResourceType: aws.String(awsmodels.SecretsManagerSecretSchema),
+			TimeCreated:  details.CreatedDate,
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  details.ARN,
+			Name: details.Name,
+			Tags: utils.ParseTagSlice(details.Tags),
+		},
+		DeletedDate:       details.DeletedDate,
+		Description:       details.Description,
+		KmsKeyId:          details.KmsKeyId,
+		LastAccessedDate:  details.LastAccessedDate,
+		LastChangedDate:   details.LastChangedDate,
+		LastRotatedDate:   details.LastRotatedDate,
+		OwningService:     details.OwningService,
+		RotationEnabled:   details.RotationEnabled,
+		RotationLambdaARN: details.RotationLambdaARN,
+		RotationRules:     details.RotationRules,
+	}
+
+	if secret.ResourcePolicy, err = getSecretResourcePolicy(secretsSvc, details.ARN); err != nil {
+		return nil, err
+	}
+
+	return secret, nil
+}
+
+// PollSecretsManagerSecrets gathers information on each Secrets Manager secret for an AWS account.
+func PollSecretsManagerSecrets(pollerInput *awsmodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	zap.L().Debug("starting Secrets Manager Secret resource poller")
+
+	secretsSvc, err := getSecretsManagerClient(pollerInput, *pollerInput.Region)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Start with generating a list of all secrets
+	secrets, marker, err := listSecrets(secretsSvc, pollerInput.NextPageToken)
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "region: %s", *pollerInput.Region)
+	}
+
+	resources := make([]apimodels.AddResourceEntry, 0, len(secrets))
+	for _, secret := range secrets {
+		secretSnapshot, err := buildSecretsManagerSecretSnapshot(secretsSvc, secret.ARN)
+		if err != nil {
+			return nil, nil, err
+		}
+		if secretSnapshot == nil {
+			continue
+		}
+
+		secretSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+		secretSnapshot.Region = pollerInput.Region
+
+		resources = append(resources, apimodels.AddResourceEntry{
+			Attributes:      secretSnapshot,
+			ID:              *secretSnapshot.ResourceID,
+			IntegrationID:   *pollerInput.IntegrationID,
+			IntegrationType: integrationType,
+			Type:            awsmodels.SecretsManagerSecretSchema,
+		})
+	}
+
+	return resources, marker, nil
+}
@@ -0,0 +1,178 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestSecretsManagerSecretList(t *testing.T) {
+	mockSvc := awstest.BuildMockSecretsManagerSvc([]string{"ListSecretsPages"})
+
+	out, marker, err := listSecrets(mockSvc, nil)
+	assert.NotEmpty(t, out)
+	assert.Nil(t, marker)
+	assert.NoError(t, err)
+}
+
+// Test the iterator works on consecutive pages but stops at max page size
+func TestSecretsManagerSecretListIterator(t *testing.T) {
+	var secrets []*secretsmanager.SecretListEntry
+	var marker *string
+
+	cont := secretsManagerSecretIterator(awstest.ExampleListSecretsOutput, &secrets, &marker)
+	assert.True(t, cont)
+	assert.Nil(t, marker)
+	assert.Len(t, secrets, 1)
+
+	for i := 1; i < 50; i++ {
+		cont = secretsManagerSecretIterator(awstest.ExampleListSecretsOutputContinue, &secrets, &marker)
+		assert.True(t, cont)
+		assert.NotNil(t, marker)
+		assert.Len(t, secrets, 1+i*2)
+	}
+
+	cont = secretsManagerSecretIterator(awstest.ExampleListSecretsOutputContinue, &secrets, &marker)
+	assert.False(t, cont)
+	assert.NotNil(t, marker)
+	assert.Len(t, secrets, 101)
+}
+
+func TestSecretsManagerSecretListError(t *testing.T) {
+	mockSvc := awstest.BuildMockSecretsManagerSvcError([]string{"ListSecretsPages"})
+
+	out, marker, err := listSecrets(mockSvc, nil)
+	assert.Nil(t, out)
+	assert.Nil(t, marker)
+	assert.Error(t, err)
+}
+
+func TestSecretsManagerSecretDescribe(t *testing.T) {
+	mockSvc := awstest.BuildMockSecretsManagerSvc([]string{"DescribeSecret"})
+
+	out, err := describeSecret(mockSvc, awstest.ExampleSecretArn)
+	require.NoError(t, err)
+	assert.NotEmpty(t, out)
+}
+
+func TestSecretsManagerSecretDescribeError(t *testing.T) {
+	mockSvc := awstest.BuildMockSecretsManagerSvcError([]string{"DescribeSecret"})
+
+	out, err := describeSecret(mockSvc, awstest.ExampleSecretArn)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestSecretsManagerSecretDescribeNotFound(t *testing.T) {
+	mockSvc := &awstest.MockSecretsManager{}
+	mockSvc.On("DescribeSecret", mock.Anything).Return(
+		&secretsmanager.DescribeSecretOutput{},
+		awserr.New(secretsmanager.ErrCodeResourceNotFoundException, "Secrets Manager can't find the specified secret", nil),
+	)
+
+	out, err := describeSecret(mockSvc, awstest.ExampleSecretArn)
+	require.NoError(t, err)
+	assert.Nil(t, out)
+}
+
+func TestSecretsManagerSecretGetResourcePolicy(t *testing.T) {
+	mockSvc := awstest.BuildMockSecretsManagerSvc([]string{"GetResourcePolicy"})
+
+	out, err := getSecretResourcePolicy(mockSvc, awstest.ExampleSecretArn)
+	require.NoError(t, err)
+	assert.NotEmpty(t, out)
+}
+
+func TestSecretsManagerSecretGetResourcePolicyError(t *testing.T) {
+	mockSvc := awstest.BuildMockSecretsManagerSvcError([]string{"GetResourcePolicy"})
+
+	out, err := getSecretResourcePolicy(mockSvc, awstest.ExampleSecretArn)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestBuildSecretsManagerSecretSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockSecretsManagerSvcAll()
+
+	secretSnapshot, err := buildSecretsManagerSecretSnapshot(mockSvc, awstest.ExampleSecretArn)
+	require.NoError(t, err)
+	assert.Equal(t, awstest.ExampleSecretArn, secretSnapshot.ARN)
+	assert.Equal(t, "example-secret", *secretSnapshot.Name)
+	assert.True(t, *secretSnapshot.RotationEnabled)
+	assert.NotNil(t, secretSnapshot.ResourcePolicy)
+	assert.Equal(t, "Value1", *secretSnapshot.Tags["Key1"])
+}
+
+func TestBuildSecretsManagerSecretSnapshotErrors(t *testing.T) {
+	mockSvc := awstest.BuildMockSecretsManagerSvcAllError()
+
+	secretSnapshot, err := buildSecretsManagerSecretSnapshot(mockSvc, awstest.ExampleSecretArn)
+	assert.Nil(t, secretSnapshot)
+	assert.Error(t, err)
+}
+
+func TestSecretsManagerSecretPoller(t *testing.T) {
+	awstest.MockSecretsManagerForSetup = awstest.BuildMockSecretsManagerSvcAll()
+
+	SecretsManagerClientFunc = awstest.SetupMockSecretsManager
+
+	resources, marker, err := PollSecretsManagerSecrets(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, *awstest.ExampleSecretArn, resources[0].ID)
+	assert.Equal(t, awsmodels.SecretsManagerSecretSchema, resources[0].Type)
+	assert.Nil(t, marker)
+}
+
+func TestSecretsManagerSecretPollerError(t *testing.T) {
+	resetCache()
+	awstest.MockSecretsManagerForSetup = awstest.BuildMockSecretsManagerSvcAllError()
+
+	SecretsManagerClientFunc = awstest.SetupMockSecretsManager
+
+	resources, marker, err := PollSecretsManagerSecrets(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	for _, event := range resources {
+		assert.Nil(t, event.Attributes)
+	}
+	assert.Nil(t, marker)
+	assert.Error(t, err)
+}
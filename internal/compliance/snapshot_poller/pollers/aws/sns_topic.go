@@ -0,0 +1,220 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/sns/snsiface"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// Set as variables to be overridden in testing
+var (
+	SnsClientFunc = setupSnsClient
+)
+
+func setupSnsClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return sns.New(sess, cfg)
+}
+
+func getSnsClient(pollerResourceInput *awsmodels.ResourcePollerInput, region string) (snsiface.SNSAPI, error) {
+	client, err := getClient(pollerResourceInput, SnsClientFunc, "sns", region)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.(snsiface.SNSAPI), nil
+}
+
+// PollSNSTopic polls a single SNS Topic resource
+func PollSNSTopic(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	scanRequest *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getSnsClient(pollerResourceInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := buildSnsTopicSnapshot(client, scanRequest.ResourceID)
+	if err != nil || snapshot == nil {
+		return nil, err
+	}
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	return snapshot, nil
+}
+
+// listTopics returns a list of all SNS topics in the account
+func listTopics(snsSvc snsiface.SNSAPI, nextMarker *string) (topics []*sns.Topic, marker *string, err error) {
+	err = snsSvc.ListTopicsPages(&sns.ListTopicsInput{NextToken: nextMarker},
+		func(page *sns.ListTopicsOutput, lastPage bool) bool {
+			return snsTopicIterator(page, &topics, &marker)
+		})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "SNS.ListTopicsPages")
+	}
+	return
+}
+
+func snsTopicIterator(page *sns.ListTopicsOutput, topics *[]*sns.Topic, marker **string) bool {
+	*topics = append(*topics, page.Topics...)
+	*marker = page.NextToken
+	return len(*topics) < defaultBatchSize
+}
+
+// getTopicAttributes returns the attributes of an SNS topic, or nil if it no longer exists
+func getTopicAttributes(snsSvc snsiface.SNSAPI, topicARN *string) (map[string]*string, error) {
+	out, err := snsSvc.GetTopicAttributes(&sns.GetTopicAttributesInput{TopicArn: topicARN})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == sns.ErrCodeNotFoundException {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", aws.StringValue(topicARN)),
+				zap.String("resourceType", awsmodels.SnsTopicSchema))
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "SNS.GetTopicAttributes: %s", aws.StringValue(topicARN))
+	}
+	return out.Attributes, nil
+}
+
+// listSubscriptionsByTopic returns all the subscriptions of an SNS topic
+func listSubscriptionsByTopic(snsSvc snsiface.SNSAPI, topicARN *string) (subscriptions []*sns.Subscription, err error) {
+	err = snsSvc.ListSubscriptionsByTopicPages(&sns.ListSubscriptionsByTopicInput{TopicArn: topicARN},
+		func(page *sns.ListSubscriptionsByTopicOutput, lastPage bool) bool {
+			subscriptions = append(subscriptions, page.Subscriptions...)
+			return true
+		})
+	if err != nil {
+		return nil, errors.Wrapf(err, "SNS.ListSubscriptionsByTopicPages: %s", aws.StringValue(topicARN))
+	}
+	return subscriptions, nil
+}
+
+// listSnsTopicTags returns the tags of an SNS topic
+func listSnsTopicTags(snsSvc snsiface.SNSAPI, topicARN *string) ([]*sns.Tag, error) {
+	out, err := snsSvc.ListTagsForResource(&sns.ListTagsForResourceInput{ResourceArn: topicARN})
+	if err != nil {
+		return nil, errors.Wrapf(err, "SNS.ListTagsForResource: %s", aws.StringValue(topicARN))
+	}
+	return out.Tags, nil
+}
+
+// buildSnsTopicSnapshot makes all the calls to build up a snapshot of a given SNS topic
+func buildSnsTopicSnapshot(snsSvc snsiface.SNSAPI, topicARN *string) (*awsmodels.SnsTopic, error) {
+	if topicARN == nil {
+		return nil, nil
+	}
+	parsedARN, err := arn.Parse(*topicARN)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse SNS topic ARN: %s", *topicARN)
+	}
+
+	attributes, err := getTopicAttributes(snsSvc, topicARN)
+	if err != nil || attributes == nil {
+		return nil, err
+	}
+
+	topic := &awsmodels.SnsTopic{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   topicARN,
+			ResourceType: aws.String(awsmodels.SnsTopicSchema),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  topicARN,
+			Name: aws.String(parsedARN.Resource),
+		},
+		ContentBasedDeduplication: utils.AttributeBool(attributes, "ContentBasedDeduplication"),
+		DeliveryPolicy:            attributes["DeliveryPolicy"],
+		DisplayName:               attributes["DisplayName"],
+		EffectiveDeliveryPolicy:   attributes["EffectiveDeliveryPolicy"],
+		FifoTopic:                 utils.AttributeBool(attributes, "FifoTopic"),
+		KmsMasterKeyId:            attributes["KmsMasterKeyId"],
+		Owner:                     attributes["Owner"],
+		Policy:                    attributes["Policy"],
+		SubscriptionsConfirmed:    utils.AttributeInt(attributes, "SubscriptionsConfirmed"),
+		SubscriptionsDeleted:      utils.AttributeInt(attributes, "SubscriptionsDeleted"),
+		SubscriptionsPending:      utils.AttributeInt(attributes, "SubscriptionsPending"),
+	}
+
+	if topic.Subscriptions, err = listSubscriptionsByTopic(snsSvc, topicARN); err != nil {
+		return nil, err
+	}
+
+	tags, err := listSnsTopicTags(snsSvc, topicARN)
+	if err != nil {
+		return nil, err
+	}
+	topic.Tags = utils.ParseTagSlice(tags)
+
+	return topic, nil
+}
+
+// PollSnsTopics gathers information on each SNS topic for an AWS account.
+func PollSnsTopics(pollerInput *awsmodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	zap.L().Debug("starting SNS Topic resource poller")
+
+	snsSvc, err := getSnsClient(pollerInput, *pollerInput.Region)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Start with generating a list of all topics
+	topics, marker, err := listTopics(snsSvc, pollerInput.NextPageToken)
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "region: %s", *pollerInput.Region)
+	}
+
+	resources := make([]apimodels.AddResourceEntry, 0, len(topics))
+	for _, topic := range topics {
+		topicSnapshot, err := buildSnsTopicSnapshot(snsSvc, topic.TopicArn)
+		if err != nil {
+			return nil, nil, err
+		}
+		if topicSnapshot == nil {
+			continue
+		}
+
+		topicSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+		topicSnapshot.Region = pollerInput.Region
+
+		resources = append(resources, apimodels.AddResourceEntry{
+			Attributes:      topicSnapshot,
+			ID:              *topicSnapshot.ResourceID,
+			IntegrationID:   *pollerInput.IntegrationID,
+			IntegrationType: integrationType,
+			Type:            awsmodels.SnsTopicSchema,
+		})
+	}
+
+	return resources, marker, nil
+}
@@ -0,0 +1,193 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestSnsTopicList(t *testing.T) {
+	mockSvc := awstest.BuildMockSnsSvc([]string{"ListTopicsPages"})
+
+	out, marker, err := listTopics(mockSvc, nil)
+	assert.NotEmpty(t, out)
+	assert.Nil(t, marker)
+	assert.NoError(t, err)
+}
+
+// Test the iterator works on consecutive pages but stops at max page size
+func TestSnsTopicListIterator(t *testing.T) {
+	var topics []*sns.Topic
+	var marker *string
+
+	cont := snsTopicIterator(awstest.ExampleListTopicsOutput, &topics, &marker)
+	assert.True(t, cont)
+	assert.Nil(t, marker)
+	assert.Len(t, topics, 1)
+
+	for i := 1; i < 50; i++ {
+		cont = snsTopicIterator(awstest.ExampleListTopicsOutputContinue, &topics, &marker)
+		assert.True(t, cont)
+		assert.NotNil(t, marker)
+		assert.Len(t, topics, 1+i*2)
+	}
+
+	cont = snsTopicIterator(awstest.ExampleListTopicsOutputContinue, &topics, &marker)
+	assert.False(t, cont)
+	assert.NotNil(t, marker)
+	assert.Len(t, topics, 101)
+}
+
+func TestSnsTopicListError(t *testing.T) {
+	mockSvc := awstest.BuildMockSnsSvcError([]string{"ListTopicsPages"})
+
+	out, marker, err := listTopics(mockSvc, nil)
+	assert.Nil(t, out)
+	assert.Nil(t, marker)
+	assert.Error(t, err)
+}
+
+func TestSnsTopicGetAttributes(t *testing.T) {
+	mockSvc := awstest.BuildMockSnsSvc([]string{"GetTopicAttributes"})
+
+	out, err := getTopicAttributes(mockSvc, awstest.ExampleSnsTopicArn)
+	require.NoError(t, err)
+	assert.NotEmpty(t, out)
+}
+
+func TestSnsTopicGetAttributesError(t *testing.T) {
+	mockSvc := awstest.BuildMockSnsSvcError([]string{"GetTopicAttributes"})
+
+	out, err := getTopicAttributes(mockSvc, awstest.ExampleSnsTopicArn)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestSnsTopicGetAttributesNotFound(t *testing.T) {
+	mockSvc := &awstest.MockSns{}
+	mockSvc.On("GetTopicAttributes", mock.Anything).Return(
+		&sns.GetTopicAttributesOutput{},
+		awserr.New(sns.ErrCodeNotFoundException, "Topic does not exist", nil),
+	)
+
+	out, err := getTopicAttributes(mockSvc, awstest.ExampleSnsTopicArn)
+	require.NoError(t, err)
+	assert.Nil(t, out)
+}
+
+func TestSnsTopicListSubscriptions(t *testing.T) {
+	mockSvc := awstest.BuildMockSnsSvc([]string{"ListSubscriptionsByTopicPages"})
+
+	out, err := listSubscriptionsByTopic(mockSvc, awstest.ExampleSnsTopicArn)
+	require.NoError(t, err)
+	assert.NotEmpty(t, out)
+}
+
+func TestSnsTopicListSubscriptionsError(t *testing.T) {
+	mockSvc := awstest.BuildMockSnsSvcError([]string{"ListSubscriptionsByTopicPages"})
+
+	out, err := listSubscriptionsByTopic(mockSvc, awstest.ExampleSnsTopicArn)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestSnsTopicListTags(t *testing.T) {
+	mockSvc := awstest.BuildMockSnsSvc([]string{"ListTagsForResource"})
+
+	out, err := listSnsTopicTags(mockSvc, awstest.ExampleSnsTopicArn)
+	require.NoError(t, err)
+	assert.NotEmpty(t, out)
+}
+
+func TestSnsTopicListTagsError(t *testing.T) {
+	mockSvc := awstest.BuildMockSnsSvcError([]string{"ListTagsForResource"})
+
+	out, err := listSnsTopicTags(mockSvc, awstest.ExampleSnsTopicArn)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestBuildSnsTopicSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockSnsSvcAll()
+
+	topicSnapshot, err := buildSnsTopicSnapshot(mockSvc, awstest.ExampleSnsTopicArn)
+	require.NoError(t, err)
+	assert.Equal(t, "example-topic", *topicSnapshot.Name)
+	assert.Equal(t, int64(1), *topicSnapshot.SubscriptionsConfirmed)
+	assert.Len(t, topicSnapshot.Subscriptions, 1)
+	assert.Equal(t, "Value1", *topicSnapshot.Tags["Key1"])
+}
+
+func TestBuildSnsTopicSnapshotErrors(t *testing.T) {
+	mockSvc := awstest.BuildMockSnsSvcAllError()
+
+	topicSnapshot, err := buildSnsTopicSnapshot(mockSvc, awstest.ExampleSnsTopicArn)
+	assert.Nil(t, topicSnapshot)
+	assert.Error(t, err)
+}
+
+func TestSnsTopicPoller(t *testing.T) {
+	awstest.MockSnsForSetup = awstest.BuildMockSnsSvcAll()
+
+	SnsClientFunc = awstest.SetupMockSns
+
+	resources, marker, err := PollSnsTopics(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, *awstest.ExampleSnsTopicArn, resources[0].ID)
+	assert.Equal(t, awsmodels.SnsTopicSchema, resources[0].Type)
+	assert.Nil(t, marker)
+}
+
+func TestSnsTopicPollerError(t *testing.T) {
+	resetCache()
+	awstest.MockSnsForSetup = awstest.BuildMockSnsSvcAllError()
+
+	SnsClientFunc = awstest.SetupMockSns
+
+	resources, marker, err := PollSnsTopics(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	for _, event := range resources {
+		assert.Nil(t, event.Attributes)
+	}
+	assert.Nil(t, marker)
+	assert.Error(t, err)
+}
@@ -0,0 +1,250 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// Set as variables to be overridden in testing
+var (
+	SqsClientFunc = setupSqsClient
+)
+
+func setupSqsClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return sqs.New(sess, cfg)
+}
+
+func getSqsClient(pollerResourceInput *awsmodels.ResourcePollerInput, region string) (sqsiface.SQSAPI, error) {
+	client, err := getClient(pollerResourceInput, SqsClientFunc, "sqs", region)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.(sqsiface.SQSAPI), nil
+}
+
+// PollSQSQueue polls a single SQS Queue resource
+func PollSQSQueue(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	scanRequest *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getSqsClient(pollerResourceInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	queueURL, err := getQueueURL(client, resourceARN)
+	if err != nil || queueURL == nil {
+		return nil, err
+	}
+
+	snapshot, err := buildSqsQueueSnapshot(client, queueURL)
+	if err != nil || snapshot == nil {
+		return nil, err
+	}
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	return snapshot, nil
+}
+
+// isSqsQueueNotFound reports whether an error means the queue no longer exists
+func isSqsQueueNotFound(err error) bool {
+	var awsErr awserr.Error
+	return errors.As(err, &awsErr) && awsErr.Code() == sqs.ErrCodeQueueDoesNotExist
+}
+
+// getQueueURL looks up the URL of an SQS queue from its ARN, or nil if it no longer exists
+func getQueueURL(sqsSvc sqsiface.SQSAPI, queueARN arn.ARN) (*string, error) {
+	out, err := sqsSvc.GetQueueUrl(&sqs.GetQueueUrlInput{
+		QueueName:              aws.String(queueARN.Resource),
+		QueueOwnerAWSAccountId: aws.String(queueARN.AccountID),
+	})
+	if err != nil {
+		if isSqsQueueNotFound(err) {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", queueARN.String()),
+				zap.String("resourceType", awsmodels.SqsQueueSchema))
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "SQS.GetQueueUrl: %s", queueARN.String())
+	}
+	return out.QueueUrl, nil
+}
+
+// listQueues returns a list of all SQS queue URLs in the account
+func listQueues(sqsSvc sqsiface.SQSAPI, nextMarker *string) (queueURLs []*string, marker *string, err error) {
+	err = sqsSvc.ListQueuesPages(&sqs.ListQueuesInput{
+		MaxResults: aws.Int64(int64(defaultBatchSize)),
+		NextToken:  nextMarker,
+	},
+		func(page *sqs.ListQueuesOutput, lastPage bool) bool {
+			return sqsQueueIterator(page, &queueURLs, &marker)
+		})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "SQS.ListQueuesPages")
+	}
+	return
+}
+
+func sqsQueueIterator(page *sqs.ListQueuesOutput, queueURLs *[]*string, marker **string) bool {
+	*queueURLs = append(*queueURLs, page.QueueUrls...)
+	*marker = page.NextToken
+	return len(*queueURLs) < defaultBatchSize
+}
+
+// getQueueAttributes returns all the attributes of an SQS queue, or nil if it no longer exists
+func getQueueAttributes(sqsSvc sqsiface.SQSAPI, queueURL *string) (map[string]*string, error) {
+	out, err := sqsSvc.GetQueueAttributes(&sqs.GetQueueAttributesInput{
+		AttributeNames: []*string{aws.String(sqs.QueueAttributeNameAll)},
+		QueueUrl:       queueURL,
+	})
+	if err != nil {
+		if isSqsQueueNotFound(err) {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", aws.StringValue(queueURL)),
+				zap.String("resourceType", awsmodels.SqsQueueSchema))
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "SQS.GetQueueAttributes: %s", aws.StringValue(queueURL))
+	}
+	return out.Attributes, nil
+}
+
+// listQueueTags returns the tags of an SQS queue
+func listQueueTags(sqsSvc sqsiface.SQSAPI, queueURL *string) (map[string]*string, error) {
+	out, err := sqsSvc.ListQueueTags(&sqs.ListQueueTagsInput{QueueUrl: queueURL})
+	if err != nil {
+		return nil, errors.Wrapf(err, "SQS.ListQueueTags: %s", aws.StringValue(queueURL))
+	}
+	return out.Tags, nil
+}
+
+// buildSqsQueueSnapshot makes all the calls to build up a snapshot of a given SQS queue
+func buildSqsQueueSnapshot(sqsSvc sqsiface.SQSAPI, queueURL *string) (*awsmodels.SqsQueue, error) {
+	if queueURL == nil {
+		return nil, nil
+	}
+
+	attributes, err := getQueueAttributes(sqsSvc, queueURL)
+	if err != nil || attributes == nil {
+		return nil, err
+	}
+
+	// The queue name is the final path element of the queue URL
+	name := *queueURL
+	if idx := strings.LastIndex(name, "/"); idx >= 0 {
+		name = name[idx+1:]
+	}
+
+	queue := &awsmodels.SqsQueue{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   attributes[sqs.QueueAttributeNameQueueArn],
+			ResourceType: aws.String(awsmodels.SqsQueueSchema),
+			TimeCreated:  utils.AttributeUnixTime(attributes, sqs.QueueAttributeNameCreatedTimestamp),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  attributes[sqs.QueueAttributeNameQueueArn],
+			Name: aws.String(name),
+		},
+		ContentBasedDeduplication:     utils.AttributeBool(attributes, sqs.QueueAttributeNameContentBasedDeduplication),
+		DeduplicationScope:            attributes["DeduplicationScope"],
+		DelaySeconds:                  utils.AttributeInt(attributes, sqs.QueueAttributeNameDelaySeconds),
+		FifoQueue:                     utils.AttributeBool(attributes, sqs.QueueAttributeNameFifoQueue),
+		FifoThroughputLimit:           attributes["FifoThroughputLimit"],
+		KmsDataKeyReusePeriodSeconds:  utils.AttributeInt(attributes, sqs.QueueAttributeNameKmsDataKeyReusePeriodSeconds),
+		KmsMasterKeyId:                attributes[sqs.QueueAttributeNameKmsMasterKeyId],
+		LastModifiedTimestamp:         utils.AttributeUnixTime(attributes, sqs.QueueAttributeNameLastModifiedTimestamp),
+		MaximumMessageSize:            utils.AttributeInt(attributes, sqs.QueueAttributeNameMaximumMessageSize),
+		MessageRetentionPeriod:        utils.AttributeInt(attributes, sqs.QueueAttributeNameMessageRetentionPeriod),
+		Policy:                        attributes[sqs.QueueAttributeNamePolicy],
+		ReceiveMessageWaitTimeSeconds: utils.AttributeInt(attributes, sqs.QueueAttributeNameReceiveMessageWaitTimeSeconds),
+		RedriveAllowPolicy:            attributes["RedriveAllowPolicy"],
+		RedrivePolicy:                 attributes[sqs.QueueAttributeNameRedrivePolicy],
+		VisibilityTimeout:             utils.AttributeInt(attributes, sqs.QueueAttributeNameVisibilityTimeout),
+		QueueUrl:                      queueURL,
+	}
+	if queue.ResourceID == nil {
+		return nil, errors.Errorf("SQS.GetQueueAttributes: %s: missing QueueArn", *queueURL)
+	}
+
+	tags, err := listQueueTags(sqsSvc, queueURL)
+	if err != nil {
+		return nil, err
+	}
+	queue.Tags = tags
+
+	return queue, nil
+}
+
+// PollSqsQueues gathers information on each SQS queue for an AWS account.
+func PollSqsQueues(pollerInput *awsmodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	zap.L().Debug("starting SQS Queue resource poller")
+
+	sqsSvc, err := getSqsClient(pollerInput, *pollerInput.Region)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Start with generating a list of all queues
+	queueURLs, marker, err := listQueues(sqsSvc, pollerInput.NextPageToken)
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "region: %s", *pollerInput.Region)
+	}
+
+	resources := make([]apimodels.AddResourceEntry, 0, len(queueURLs))
+	for _, queueURL := range queueURLs {
+		queueSnapshot, err := buildSqsQueueSnapshot(sqsSvc, queueURL)
+		if err != nil {
+			return nil, nil, err
+		}
+		if queueSnapshot == nil {
+			continue
+		}
+
+		queueSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+		queueSnapshot.Region = pollerInput.Region
+
+		resources = append(resources, apimodels.AddResourceEntry{
+			Attributes:      queueSnapshot,
+			ID:              *queueSnapshot.ResourceID,
+			IntegrationID:   *pollerInput.IntegrationID,
+			IntegrationType: integrationType,
+			Type:            awsmodels.SqsQueueSchema,
+		})
+	}
+
+	return resources, marker, nil
+}
@@ -0,0 +1,204 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+var exampleSqsQueueARN = arn.ARN{
+	Partition: "aws",
+	Service:   "sqs",
+	Region:    "us-west-2",
+	AccountID: "123456789012",
+	Resource:  "example-queue",
+}
+
+func TestSqsQueueList(t *testing.T) {
+	mockSvc := awstest.BuildMockSqsSvc([]string{"ListQueuesPages"})
+
+	out, marker, err := listQueues(mockSvc, nil)
+	assert.NotEmpty(t, out)
+	assert.Nil(t, marker)
+	assert.NoError(t, err)
+}
+
+// Test the iterator works on consecutive pages but stops at max page size
+func TestSqsQueueListIterator(t *testing.T) {
+	var queueURLs []*string
+	var marker *string
+
+	cont := sqsQueueIterator(awstest.ExampleListQueuesOutput, &queueURLs, &marker)
+	assert.True(t, cont)
+	assert.Nil(t, marker)
+	assert.Len(t, queueURLs, 1)
+
+	for i := 1; i < 50; i++ {
+		cont = sqsQueueIterator(awstest.ExampleListQueuesOutputContinue, &queueURLs, &marker)
+		assert.True(t, cont)
+		assert.NotNil(t, marker)
+		assert.Len(t, queueURLs, 1+i*2)
+	}
+
+	cont = sqsQueueIterator(awstest.ExampleListQueuesOutputContinue, &queueURLs, &marker)
+	assert.False(t, cont)
+	assert.NotNil(t, marker)
+	assert.Len(t, queueURLs, 101)
+}
+
+func TestSqsQueueListError(t *testing.T) {
+	mockSvc := awstest.BuildMockSqsSvcError([]string{"ListQueuesPages"})
+
+	out, marker, err := listQueues(mockSvc, nil)
+	assert.Nil(t, out)
+	assert.Nil(t, marker)
+	assert.Error(t, err)
+}
+
+func TestSqsQueueGetURL(t *testing.T) {
+	mockSvc := awstest.BuildMockSqsSvc([]string{"GetQueueUrl"})
+
+	out, err := getQueueURL(mockSvc, exampleSqsQueueARN)
+	require.NoError(t, err)
+	assert.Equal(t, awstest.ExampleSqsQueueUrl, out)
+}
+
+func TestSqsQueueGetURLError(t *testing.T) {
+	mockSvc := awstest.BuildMockSqsSvcError([]string{"GetQueueUrl"})
+
+	out, err := getQueueURL(mockSvc, exampleSqsQueueARN)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestSqsQueueGetURLNotFound(t *testing.T) {
+	mockSvc := &awstest.MockSqs{}
+	mockSvc.On("GetQueueUrl", mock.Anything).Return(
+		&sqs.GetQueueUrlOutput{},
+		awserr.New(sqs.ErrCodeQueueDoesNotExist, "The specified queue does not exist", nil),
+	)
+
+	out, err := getQueueURL(mockSvc, exampleSqsQueueARN)
+	require.NoError(t, err)
+	assert.Nil(t, out)
+}
+
+func TestSqsQueueGetAttributes(t *testing.T) {
+	mockSvc := awstest.BuildMockSqsSvc([]string{"GetQueueAttributes"})
+
+	out, err := getQueueAttributes(mockSvc, awstest.ExampleSqsQueueUrl)
+	require.NoError(t, err)
+	assert.NotEmpty(t, out)
+}
+
+func TestSqsQueueGetAttributesError(t *testing.T) {
+	mockSvc := awstest.BuildMockSqsSvcError([]string{"GetQueueAttributes"})
+
+	out, err := getQueueAttributes(mockSvc, awstest.ExampleSqsQueueUrl)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestSqsQueueListTags(t *testing.T) {
+	mockSvc := awstest.BuildMockSqsSvc([]string{"ListQueueTags"})
+
+	out, err := listQueueTags(mockSvc, awstest.ExampleSqsQueueUrl)
+	require.NoError(t, err)
+	assert.NotEmpty(t, out)
+}
+
+func TestSqsQueueListTagsError(t *testing.T) {
+	mockSvc := awstest.BuildMockSqsSvcError([]string{"ListQueueTags"})
+
+	out, err := listQueueTags(mockSvc, awstest.ExampleSqsQueueUrl)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestBuildSqsQueueSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockSqsSvcAll()
+
+	queueSnapshot, err := buildSqsQueueSnapshot(mockSvc, awstest.ExampleSqsQueueUrl)
+	require.NoError(t, err)
+	assert.Equal(t, exampleSqsQueueARN.String(), *queueSnapshot.ARN)
+	assert.Equal(t, "example-queue", *queueSnapshot.Name)
+	assert.Equal(t, int64(30), *queueSnapshot.VisibilityTimeout)
+	assert.NotNil(t, queueSnapshot.TimeCreated)
+	assert.NotNil(t, queueSnapshot.RedrivePolicy)
+	assert.Equal(t, "Value1", *queueSnapshot.Tags["Key1"])
+}
+
+func TestBuildSqsQueueSnapshotErrors(t *testing.T) {
+	mockSvc := awstest.BuildMockSqsSvcAllError()
+
+	queueSnapshot, err := buildSqsQueueSnapshot(mockSvc, awstest.ExampleSqsQueueUrl)
+	assert.Nil(t, queueSnapshot)
+	assert.Error(t, err)
+}
+
+func TestSqsQueuePoller(t *testing.T) {
+	awstest.MockSqsForSetup = awstest.BuildMockSqsSvcAll()
+
+	SqsClientFunc = awstest.SetupMockSqs
+
+	resources, marker, err := PollSqsQueues(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, exampleSqsQueueARN.String(), resources[0].ID)
+	assert.Equal(t, awsmodels.SqsQueueSchema, resources[0].Type)
+	assert.Nil(t, marker)
+}
+
+func TestSqsQueuePollerError(t *testing.T) {
+	resetCache()
+	awstest.MockSqsForSetup = awstest.BuildMockSqsSvcAllError()
+
+	SqsClientFunc = awstest.SetupMockSqs
+
+	resources, marker, err := PollSqsQueues(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	for _, event := range resources {
+		assert.Nil(t, event.Attributes)
+	}
+	assert.Nil(t, marker)
+	assert.Error(t, err)
+}
@@ -0,0 +1,234 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+const (
+	// DescribeParameters returns at most 50 parameters per page
+	ssmParameterPageSize     = 50
+	ssmParameterARNPrefix    = "parameter/"
+	ssmParameterSeparator    = "/"
+	ssmParameterResourceType = "Parameter"
+)
+
+// Set as variables to be overridden in testing
+var (
+	SsmClientFunc = setupSsmClient
+)
+
+func setupSsmClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return ssm.New(sess, cfg)
+}
+
+func getSsmClient(pollerResourceInput *awsmodels.ResourcePollerInput, region string) (ssmiface.SSMAPI, error) {
+	client, err := getClient(pollerResourceInput, SsmClientFunc, "ssm", region)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.(ssmiface.SSMAPI), nil
+}
+
+// ssmParameterNameFromARN recovers a parameter name from its ARN.
+//
+// Hierarchical parameter names always begin with a "/", which is dropped from the ARN, while
+// non-hierarchical parameter names never contain one.
+func ssmParameterNameFromARN(resourceARN arn.ARN) string {
+	name := strings.TrimPrefix(resourceARN.Resource, ssmParameterARNPrefix)
+	if strings.Contains(name, ssmParameterSeparator) {
+		return ssmParameterSeparator + name
+	}
+	return name
+}
+
+// ssmParameterARN builds the ARN of a parameter from its name
+func ssmParameterARN(partition, region, accountID, name string) string {
+	return arn.ARN{
+		Partition: partition,
+		Service:   "ssm",
+		Region:    region,
+		AccountID: accountID,
+		Resource:  ssmParameterARNPrefix + strings.TrimPrefix(name, ssmParameterSeparator),
+	}.String()
+}
+
+// PollSSMParameter polls a single SSM parameter resource
+func PollSSMParameter(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	scanRequest *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getSsmClient(pollerResourceInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	name := ssmParameterNameFromARN(resourceARN)
+	parameters, _, err := describeParameters(client, &ssm.DescribeParametersInput{
+		ParameterFilters: []*ssm.ParameterStringFilter{
+			{
+				Key:    aws.String("Name"),
+				Option: aws.String("Equals"),
+				Values: []*string{aws.String(name)},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(parameters) == 0 {
+		zap.L().Warn("tried to scan non-existent resource",
+			zap.String("resource", resourceARN.String()),
+			zap.String("resourceType", awsmodels.SsmParameterSchema))
+		return nil, nil
+	}
+
+	snapshot, err := buildSsmParameterSnapshot(client, parameters[0], resourceARN.String())
+	if err != nil {
+		return nil, err
+	}
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	return snapshot, nil
+}
+
+// describeParameters returns the metadata of the parameters matching the given input
+func describeParameters(ssmSvc ssmiface.SSMAPI,
+	input *ssm.DescribeParametersInput) (parameters []*ssm.ParameterMetadata, marker *string, err error) {
+
+	input.MaxResults = aws.Int64(ssmParameterPageSize)
+	err = ssmSvc.DescribeParametersPages(input,
+		func(page *ssm.DescribeParametersOutput, lastPage bool) bool {
+			return ssmParameterIterator(page, &parameters, &marker)
+		})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "SSM.DescribeParametersPages")
+	}
+	return
+}
+
+func ssmParameterIterator(page *ssm.DescribeParametersOutput, parameters *[]*ssm.ParameterMetadata, marker **string) bool {
+	*parameters = append(*parameters, page.Parameters...)
+	*marker = page.NextToken
+	return len(*parameters) < defaultBatchSize
+}
+
+// listSsmParameterTags returns the tags of an SSM parameter
+func listSsmParameterTags(ssmSvc ssmiface.SSMAPI, name *string) ([]*ssm.Tag, error) {
+	out, err := ssmSvc.ListTagsForResource(&ssm.ListTagsForResourceInput{
+		ResourceId:   name,
+		ResourceType: aws.String(ssmParameterResourceType),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "SSM.ListTagsForResource: %s", aws.StringValue(name))
+	}
+	return out.TagList, nil
+}
+
+// buildSsmParameterSnapshot makes all the calls to build up a snapshot of a given SSM parameter.
+//
+// The parameter value is never retrieved.
+func buildSsmParameterSnapshot(ssmSvc ssmiface.SSMAPI,
+	parameter *ssm.ParameterMetadata, parameterARN string) (*awsmodels.SsmParameter, error) {
+
+	tags, err := listSsmParameterTags(ssmSvc, parameter.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &awsmodels.SsmParameter{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   aws.String(parameterARN),
+			ResourceType: aws.String(awsmodels.SsmParameterSchema),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  aws.String(parameterARN),
+			Name: parameter.Name,
+			Tags: utils.ParseTagSlice(tags),
+		},
+		AllowedPattern:   parameter.AllowedPattern,
+		DataType:         parameter.DataType,
+		Description:      parameter.Description,
+		KeyId:            parameter.KeyId,
+		LastModifiedDate: parameter.LastModifiedDate,
+		LastModifiedUser: parameter.LastModifiedUser,
+		Policies:         parameter.Policies,
+		Tier:             parameter.Tier,
+		Type:             parameter.Type,
+		Version:          parameter.Version,
+	}, nil
+}
+
+// PollSsmParameters gathers information on each SSM parameter for an AWS account.
+func PollSsmParameters(pollerInput *awsmodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	zap.L().Debug("starting SSM Parameter resource poller")
+
+	ssmSvc, err := getSsmClient(pollerInput, *pollerInput.Region)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Start with generating a list of all parameters
+	parameters, marker, err := describeParameters(ssmSvc, &ssm.DescribeParametersInput{
+		NextToken: pollerInput.NextPageToken,
+	})
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "region: %s", *pollerInput.Region)
+	}
+
+	resources := make([]apimodels.AddResourceEntry, 0, len(parameters))
+	for _, parameter := range parameters {
+		parameterARN := ssmParameterARN(pollerInput.AuthSourceParsedARN.Partition,
+			*pollerInput.Region, pollerInput.AuthSourceParsedARN.AccountID, aws.StringValue(parameter.Name))
+		parameterSnapshot, err := buildSsmParameterSnapshot(ssmSvc, parameter, parameterARN)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		parameterSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+		parameterSnapshot.Region = pollerInput.Region
+
+		resources = append(resources, apimodels.AddResourceEntry{
+			Attributes:      parameterSnapshot,
+			ID:              *parameterSnapshot.ResourceID,
+			IntegrationID:   *pollerInput.IntegrationID,
+			IntegrationType: integrationType,
+			Type:            awsmodels.SsmParameterSchema,
+		})
+	}
+
+	return resources, marker, nil
+}
@@ -0,0 +1,179 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestSsmParameterARN(t *testing.T) {
+	hierarchical := ssmParameterARN("aws", "us-west-2", "123456789012", "/example/parameter")
+	assert.Equal(t, "arn:aws:ssm:us-west-2:123456789012:parameter/example/parameter", hierarchical)
+	parsed, err := arn.Parse(hierarchical)
+	require.NoError(t, err)
+	assert.Equal(t, "/example/parameter", ssmParameterNameFromARN(parsed))
+
+	flat := ssmParameterARN("aws", "us-west-2", "123456789012", "example-parameter")
+	assert.Equal(t, "arn:aws:ssm:us-west-2:123456789012:parameter/example-parameter", flat)
+	parsed, err = arn.Parse(flat)
+	require.NoError(t, err)
+	assert.Equal(t, "example-parameter", ssmParameterNameFromARN(parsed))
+}
+
+func TestSsmParameterDescribe(t *testing.T) {
+	mockSvc := awstest.BuildMockSsmSvc([]string{"DescribeParametersPages"})
+
+	out, marker, err := describeParameters(mockSvc, &ssm.DescribeParametersInput{})
+	assert.NotEmpty(t, out)
+	assert.Nil(t, marker)
+	assert.NoError(t, err)
+}
+
+// Test the iterator works on consecutive pages but stops at max page size
+func TestSsmParameterDescribeIterator(t *testing.T) {
+	var parameters []*ssm.ParameterMetadata
+	var marker *string
+
+	cont := ssmParameterIterator(awstest.ExampleDescribeParametersOutput, &parameters, &marker)
+	assert.True(t, cont)
+	assert.Nil(t, marker)
+	assert.Len(t, parameters, 1)
+
+	for i := 1; i < 50; i++ {
+		cont = ssmParameterIterator(awstest.ExampleDescribeParametersOutputContinue, &parameters, &marker)
+		assert.True(t, cont)
+		assert.NotNil(t, marker)
+		assert.Len(t, parameters, 1+i*2)
+	}
+
+	cont = ssmParameterIterator(awstest.ExampleDescribeParametersOutputContinue, &parameters, &marker)
+	assert.False(t, cont)
+	assert.NotNil(t, marker)
+	assert.Len(t, parameters, 101)
+}
+
+func TestSsmParameterDescribeError(t *testing.T) {
+	mockSvc := awstest.BuildMockSsmSvcError([]string{"DescribeParametersPages"})
+
+	out, marker, err := describeParameters(mockSvc, &ssm.DescribeParametersInput{})
+	assert.Nil(t, out)
+	assert.Nil(t, marker)
+	assert.Error(t, err)
+}
+
+func TestSsmParameterListTags(t *testing.T) {
+	mockSvc := awstest.BuildMockSsmSvc([]string{"ListTagsForResource"})
+
+	out, err := listSsmParameterTags(mockSvc, awstest.ExampleSsmParameterName)
+	require.NoError(t, err)
+	assert.NotEmpty(t, out)
+}
+
+func TestSsmParameterListTagsError(t *testing.T) {
+	mockSvc := awstest.BuildMockSsmSvcError([]string{"ListTagsForResource"})
+
+	out, err := listSsmParameterTags(mockSvc, awstest.ExampleSsmParameterName)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestBuildSsmParameterSnapshotErrors(t *testing.T) {
+	mockSvc := awstest.BuildMockSsmSvcAllError()
+
+	parameterSnapshot, err := buildSsmParameterSnapshot(
+		mockSvc, awstest.ExampleDescribeParametersOutput.Parameters[0], "arn:aws:ssm:us-west-2:123456789012:parameter/example")
+	assert.Nil(t, parameterSnapshot)
+	assert.Error(t, err)
+}
+
+func TestSsmParameterPoller(t *testing.T) {
+	awstest.MockSsmForSetup = awstest.BuildMockSsmSvcAll()
+
+	SsmClientFunc = awstest.SetupMockSsm
+
+	resources, marker, err := PollSsmParameters(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	expectedARN := ssmParameterARN(awstest.ExampleAuthSourceParsedARN.Partition, *awstest.ExampleRegion,
+		awstest.ExampleAuthSourceParsedARN.AccountID, *awstest.ExampleSsmParameterName)
+	assert.Equal(t, expectedARN, resources[0].ID)
+	assert.Equal(t, awsmodels.SsmParameterSchema, resources[0].Type)
+	parameter := resources[0].Attributes.(*awsmodels.SsmParameter)
+	assert.Equal(t, "Value1", *parameter.Tags["Key1"])
+	assert.Nil(t, marker)
+}
+
+func TestSsmParameterPollerSingle(t *testing.T) {
+	awstest.MockSsmForSetup = awstest.BuildMockSsmSvcAll()
+
+	SsmClientFunc = awstest.SetupMockSsm
+
+	resourceARN, err := arn.Parse("arn:aws:ssm:us-west-2:123456789012:parameter/example/parameter")
+	require.NoError(t, err)
+	resource, err := PollSSMParameter(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Timestamp:           &awstest.ExampleTime,
+	}, resourceARN, &pollermodels.ScanEntry{ResourceID: aws.String(resourceARN.String())})
+
+	require.NoError(t, err)
+	parameter := resource.(*awsmodels.SsmParameter)
+	assert.Equal(t, resourceARN.String(), *parameter.ARN)
+	assert.Equal(t, "123456789012", *parameter.AccountID)
+	assert.Equal(t, "us-west-2", *parameter.Region)
+}
+
+func TestSsmParameterPollerError(t *testing.T) {
+	resetCache()
+	awstest.MockSsmForSetup = awstest.BuildMockSsmSvcAllError()
+
+	SsmClientFunc = awstest.SetupMockSsm
+
+	resources, marker, err := PollSsmParameters(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	for _, event := range resources {
+		assert.Nil(t, event.Attributes)
+	}
+	assert.Nil(t, marker)
+	assert.Error(t, err)
+}
@@ -21,11 +21,13 @@ package pollers
 import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
 
 	"github.com/panther-labs/panther/pkg/gatewayapi"
 )
 
 var (
-	awsSession                = session.Must(session.NewSession())
-	apiClient  gatewayapi.API = gatewayapi.NewClient(lambda.New(awsSession), "panther-resources-api")
+	awsSession                         = session.Must(session.NewSession())
+	apiClient    gatewayapi.API        = gatewayapi.NewClient(lambda.New(awsSession), "panther-resources-api")
+	lambdaClient lambdaiface.LambdaAPI = lambda.New(awsSession)
 )
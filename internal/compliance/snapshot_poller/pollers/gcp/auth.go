@@ -0,0 +1,209 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+)
+
+const (
+	// The token_uri of every Google service account key, which is also the audience of the assertion
+	tokenURI = "https://oauth2.googleapis.com/token"
+	// All pollers only read resources
+	readOnlyScope = "https://www.googleapis.com/auth/cloud-platform.read-only"
+	jwtGrantType  = "urn:ietf:params:oauth:grant-type:jwt-bearer"
+	// Google accepts assertions which are valid for at most an hour
+	assertionLifetime = time.Hour
+	// Tokens are refreshed a little before they expire so in flight requests don't fail
+	tokenExpiryMargin = time.Minute
+)
+
+// ServiceAccountKey is the JSON key file of a GCP service account.
+type ServiceAccountKey struct {
+	Type         string `json:"type"`
+	ProjectID    string `json:"project_id"`
+	PrivateKeyID string `json:"private_key_id"`
+	PrivateKey   string `json:"private_key"`
+	ClientEmail  string `json:"client_email"`
+	TokenURI     string `json:"token_uri"`
+
+	signer *rsa.PrivateKey
+}
+
+// ParseServiceAccountKey reads and validates the JSON key file of a service account.
+func ParseServiceAccountKey(data []byte) (*ServiceAccountKey, error) {
+	key := &ServiceAccountKey{}
+	if err := jsoniter.Unmarshal(data, key); err != nil {
+		return nil, errors.Wrap(err, "service account key is not valid JSON")
+	}
+	if key.Type != "service_account" {
+		return nil, errors.Errorf("key type must be service_account, not %q", key.Type)
+	}
+	if key.ClientEmail == "" {
+		return nil, errors.New("service account key has no client_email")
+	}
+	// The key is supplied by the user, so tokens are never requested from any other endpoint
+	if key.TokenURI != "" && key.TokenURI != tokenURI {
+		return nil, errors.Errorf("service account token_uri must be %s", tokenURI)
+	}
+
+	block, _ := pem.Decode([]byte(key.PrivateKey))
+	if block == nil {
+		return nil, errors.New("service account key has no PEM encoded private_key")
+	}
+	// Google generates PKCS#8 keys, older tools produced PKCS#1 ones
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		if parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes); err != nil {
+			return nil, errors.Wrap(err, "failed to parse the service account private_key")
+		}
+	}
+	signer, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("service account private_key is not an RSA key")
+	}
+	key.signer = signer
+	return key, nil
+}
+
+// NewClient returns an http client which authenticates requests as the service account.
+func NewClient(key *ServiceAccountKey) *http.Client {
+	return &http.Client{
+		Timeout: httpClient.Timeout,
+		Transport: &tokenTransport{
+			source: &tokenSource{key: key},
+			base:   httpClient.Transport,
+		},
+	}
+}
+
+// tokenSource exchanges a signed assertion for an OAuth2 access token, and caches the token until
+// it expires.
+type tokenSource struct {
+	key *ServiceAccountKey
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+func (s *tokenSource) Token() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if s.token != "" && now.Before(s.expiry) {
+		return s.token, nil
+	}
+
+	assertion, err := s.key.assertion(now)
+	if err != nil {
+		return "", err
+	}
+	resp, err := httpClient.PostForm(tokenEndpoint, url.Values{
+		"grant_type": {jwtGrantType},
+		"assertion":  {assertion},
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to request an access token")
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err = jsoniter.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", errors.Wrapf(err, "failed to decode access token response (status %d)", resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK || body.AccessToken == "" {
+		return "", errors.Errorf("access token request for %s was rejected (status %d)",
+			s.key.ClientEmail, resp.StatusCode)
+	}
+
+	s.token = body.AccessToken
+	s.expiry = now.Add(time.Duration(body.ExpiresIn)*time.Second - tokenExpiryMargin)
+	return s.token, nil
+}
+
+// assertion builds the RS256 signed JWT which identifies the service account to the token endpoint.
+func (k *ServiceAccountKey) assertion(now time.Time) (string, error) {
+	header, err := jsoniter.Marshal(map[string]string{
+		"alg": "RS256",
+		"typ": "JWT",
+		"kid": k.PrivateKeyID,
+	})
+	if err != nil {
+		return "", err
+	}
+	claims, err := jsoniter.Marshal(map[string]interface{}{
+		"iss":   k.ClientEmail,
+		"scope": readOnlyScope,
+		"aud":   tokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(assertionLifetime).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, k.signer, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign the access token assertion")
+	}
+	return strings.Join([]string{unsigned, base64.RawURLEncoding.EncodeToString(signature)}, "."), nil
+}
+
+// tokenTransport adds the service account's access token to every request.
+type tokenTransport struct {
+	source *tokenSource
+	base   http.RoundTripper
+}
+
+func (t *tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.source.Token()
+	if err != nil {
+		return nil, err
+	}
+	// A RoundTripper must not modify the request it was given
+	authorized := req.Clone(req.Context())
+	authorized.Header.Set("Authorization", "Bearer "+token)
+
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return base.RoundTrip(authorized)
+}
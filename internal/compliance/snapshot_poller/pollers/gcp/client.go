@@ -0,0 +1,221 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+
+	sourcemodels "github.com/panther-labs/panther/api/lambda/source/models"
+)
+
+const (
+	// How long an authenticated client is reused before the service account key is read again,
+	// so rotated keys are picked up
+	clientCacheDuration = time.Hour
+)
+
+var (
+	// The base URLs of the Google APIs, replaced by unit tests with a fake server
+	resourceManagerEndpoint = "https://cloudresourcemanager.googleapis.com"
+	storageEndpoint         = "https://storage.googleapis.com"
+	computeEndpoint         = "https://compute.googleapis.com"
+	sqlAdminEndpoint        = "https://sqladmin.googleapis.com"
+	tokenEndpoint           = tokenURI
+
+	httpClient = &http.Client{Timeout: time.Minute}
+
+	secretsClient secretsmanageriface.SecretsManagerAPI = secretsmanager.New(session.Must(session.NewSession()))
+
+	clientCache   = make(map[string]cachedClient)
+	clientCacheMu sync.Mutex
+)
+
+type cachedClient struct {
+	client  *http.Client
+	expires time.Time
+}
+
+// getClient returns an http client authenticated as the service account of an integration.
+func getClient(integrationID string) (*http.Client, error) {
+	clientCacheMu.Lock()
+	defer clientCacheMu.Unlock()
+
+	if cached, ok := clientCache[integrationID]; ok && time.Now().Before(cached.expires) {
+		return cached.client, nil
+	}
+
+	data, err := getServiceAccountKey(integrationID)
+	if err != nil {
+		return nil, err
+	}
+	key, err := ParseServiceAccountKey(data)
+	if err != nil {
+		return nil, err
+	}
+	client := NewClient(key)
+	clientCache[integrationID] = cachedClient{client: client, expires: time.Now().Add(clientCacheDuration)}
+	return client, nil
+}
+
+// getServiceAccountKey reads the service account key the source-api stored for an integration.
+//
+// Replaced by unit tests
+var getServiceAccountKey = func(integrationID string) ([]byte, error) {
+	output, err := secretsClient.GetSecretValue(&secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(sourcemodels.GCPServiceAccountKeySecretName(integrationID)),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read the service account key of integration %s", integrationID)
+	}
+	return []byte(aws.StringValue(output.SecretString)), nil
+}
+
+// APIError is the error response of a Google API.
+type APIError struct {
+	StatusCode int
+	Status     string
+	Message    string
+	Reasons    []string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("google api error %d %s: %s", e.StatusCode, e.Status, e.Message)
+}
+
+// IsRateLimited is true for errors returned when a quota of a Google API is exhausted.
+func IsRateLimited(err error) bool {
+	apiErr, ok := errors.Cause(err).(*APIError)
+	if !ok {
+		return false
+	}
+	if apiErr.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	for _, reason := range apiErr.Reasons {
+		if reason == "rateLimitExceeded" || reason == "userRateLimitExceeded" {
+			return true
+		}
+	}
+	return false
+}
+
+// IsNotFound is true when the requested resource does not exist.
+func IsNotFound(err error) bool {
+	apiErr, ok := errors.Cause(err).(*APIError)
+	return ok && apiErr.StatusCode == http.StatusNotFound
+}
+
+// getJSON decodes the response of a GET request into out.
+func getJSON(client *http.Client, requestURL string, out interface{}) error {
+	return doJSON(client, http.MethodGet, requestURL, nil, out)
+}
+
+// postJSON decodes the response of a POST request with a JSON body into out.
+func postJSON(client *http.Client, requestURL string, body interface{}, out interface{}) error {
+	data, err := jsoniter.Marshal(body)
+	if err != nil {
+		return err
+	}
+	return doJSON(client, http.MethodPost, requestURL, bytes.NewReader(data), out)
+}
+
+func doJSON(client *http.Client, method, requestURL string, body io.Reader, out interface{}) error {
+	req, err := http.NewRequest(method, requestURL, body)
+	if err != nil {
+		return errors.Wrapf(err, "failed to build request for %s", requestURL)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "%s %s failed", method, requestURL)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var errBody struct {
+			Error struct {
+				Code    int    `json:"code"`
+				Status  string `json:"status"`
+				Message string `json:"message"`
+				Errors  []struct {
+					Reason string `json:"reason"`
+				} `json:"errors"`
+			} `json:"error"`
+		}
+		// The body is best effort, the status code is enough to classify the error
+		_ = jsoniter.NewDecoder(resp.Body).Decode(&errBody)
+		apiErr := &APIError{
+			StatusCode: resp.StatusCode,
+			Status:     errBody.Error.Status,
+			Message:    errBody.Error.Message,
+		}
+		for _, e := range errBody.Error.Errors {
+			apiErr.Reasons = append(apiErr.Reasons, e.Reason)
+		}
+		return errors.Wrapf(apiErr, "%s %s failed", method, requestURL)
+	}
+
+	if err = jsoniter.ConfigCompatibleWithStandardLibrary.NewDecoder(resp.Body).Decode(out); err != nil {
+		return errors.Wrapf(err, "failed to decode response of %s", requestURL)
+	}
+	return nil
+}
+
+// nextPage returns the token of the next page, or nil on the last page.
+func nextPage(token *string) *string {
+	if token == nil || *token == "" {
+		return nil
+	}
+	return token
+}
+
+// lastPathSegment returns the name at the end of a resource URL, such as the zone of an instance.
+func lastPathSegment(resourceURL string) string {
+	return resourceURL[strings.LastIndex(resourceURL, "/")+1:]
+}
+
+// pageURL adds the page size and token parameters of the list APIs to a URL.
+func pageURL(base string, pageToken *string) string {
+	params := url.Values{"maxResults": {fmt.Sprint(defaultBatchSize)}}
+	if pageToken != nil {
+		params.Set("pageToken", *pageToken)
+	}
+	separator := "?"
+	if strings.Contains(base, "?") {
+		separator = "&"
+	}
+	return base + separator + params.Encode()
+}
@@ -0,0 +1,106 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+)
+
+// computeFirewallResponse holds the fields of the Compute Engine firewall which need converting
+type computeFirewallResponse struct {
+	gcpmodels.ComputeFirewall
+	CreationTimestamp *time.Time
+}
+
+// PollComputeFirewall polls a single firewall rule by its full resource name
+func PollComputeFirewall(pollerInput *gcpmodels.ResourcePollerInput, resourceID string) (interface{}, error) {
+	// Format: //compute.googleapis.com/projects/{project}/global/firewalls/{name}
+	parts := strings.Split(strings.TrimPrefix(resourceID, computeResourcePrefix), "/")
+	if len(parts) != 5 || parts[0] != "projects" || parts[1] != *pollerInput.ProjectID {
+		return nil, nil
+	}
+
+	firewall := &computeFirewallResponse{}
+	requestURL := fmt.Sprintf("%s/compute/v1/projects/%s/global/firewalls/%s", computeEndpoint,
+		url.PathEscape(parts[1]), url.PathEscape(parts[4]))
+	err := getJSON(pollerInput.Client, requestURL, firewall)
+	if IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buildComputeFirewallSnapshot(pollerInput, firewall), nil
+}
+
+func buildComputeFirewallSnapshot(
+	pollerInput *gcpmodels.ResourcePollerInput, firewall *computeFirewallResponse) *gcpmodels.ComputeFirewall {
+
+	snapshot := firewall.ComputeFirewall
+	snapshot.ResourceID = aws.String(fmt.Sprintf("%sprojects/%s/global/firewalls/%s",
+		computeResourcePrefix, *pollerInput.ProjectID, aws.StringValue(snapshot.Name)))
+	snapshot.ResourceType = aws.String(gcpmodels.ComputeFirewallSchema)
+	snapshot.TimeCreated = firewall.CreationTimestamp
+	snapshot.ProjectID = pollerInput.ProjectID
+	snapshot.Region = aws.String(gcpmodels.GlobalRegion)
+	return &snapshot
+}
+
+// PollComputeFirewalls gathers information on each VPC firewall rule in a project
+func PollComputeFirewalls(pollerInput *gcpmodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	var resources []apimodels.AddResourceEntry
+	nextPageToken := pollerInput.NextPageToken
+	for {
+		var page struct {
+			Items         []*computeFirewallResponse
+			NextPageToken *string
+		}
+		requestURL := computeEndpoint + "/compute/v1/projects/" + url.PathEscape(*pollerInput.ProjectID) + "/global/firewalls"
+		if err := getJSON(pollerInput.Client, pageURL(requestURL, nextPageToken), &page); err != nil {
+			return nil, nil, err
+		}
+
+		for _, firewall := range page.Items {
+			snapshot := buildComputeFirewallSnapshot(pollerInput, firewall)
+			if pollerInput.ShouldIgnoreResource(*snapshot.ResourceID) {
+				continue
+			}
+			resources = append(resources, apimodels.AddResourceEntry{
+				Attributes:      snapshot,
+				ID:              *snapshot.ResourceID,
+				IntegrationID:   *pollerInput.IntegrationID,
+				IntegrationType: integrationType,
+				Type:            gcpmodels.ComputeFirewallSchema,
+			})
+		}
+
+		nextPageToken = nextPage(page.NextPageToken)
+		if nextPageToken == nil || len(resources) >= defaultBatchSize {
+			return resources, nextPageToken, nil
+		}
+	}
+}
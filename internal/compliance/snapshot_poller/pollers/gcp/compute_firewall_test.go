@@ -0,0 +1,64 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+)
+
+func TestPollComputeFirewalls(t *testing.T) {
+	setupExampleServer(t)
+
+	resources, marker, err := PollComputeFirewalls(testPollerInput(t))
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	require.Len(t, resources, 1)
+
+	firewall := resources[0].Attributes.(*gcpmodels.ComputeFirewall)
+	assert.Equal(t, "//compute.googleapis.com/projects/example-project/global/firewalls/default-allow-ssh", resources[0].ID)
+	assert.Equal(t, "global", *firewall.Region)
+	assert.Equal(t, "INGRESS", *firewall.Direction)
+	assert.Equal(t, int64(65534), *firewall.Priority)
+	assert.Equal(t, []*string{aws.String("0.0.0.0/0")}, firewall.SourceRanges)
+	assert.Equal(t, []*gcpmodels.ComputeFirewallRule{
+		{IPProtocol: aws.String("tcp"), Ports: []*string{aws.String("22")}},
+	}, firewall.Allowed)
+	assert.False(t, *firewall.LogConfig.Enable)
+	assert.NotNil(t, firewall.TimeCreated)
+}
+
+func TestPollComputeFirewall(t *testing.T) {
+	setupExampleServer(t)
+	resourceID := "//compute.googleapis.com/projects/example-project/global/firewalls/default-allow-ssh"
+
+	resource, err := PollComputeFirewall(testPollerInput(t), resourceID)
+	require.NoError(t, err)
+	assert.Equal(t, resourceID, *resource.(*gcpmodels.ComputeFirewall).ResourceID)
+
+	resource, err = PollComputeFirewall(testPollerInput(t),
+		"//compute.googleapis.com/projects/other-project/global/firewalls/default-allow-ssh")
+	require.NoError(t, err)
+	assert.Nil(t, resource)
+}
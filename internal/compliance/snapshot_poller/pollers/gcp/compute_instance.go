@@ -0,0 +1,137 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+)
+
+const computeResourcePrefix = "//compute.googleapis.com/"
+
+// computeInstanceResponse holds the fields of the Compute Engine instance which need converting
+type computeInstanceResponse struct {
+	gcpmodels.ComputeInstance
+	CreationTimestamp *time.Time
+}
+
+// PollComputeInstance polls a single instance by its full resource name
+func PollComputeInstance(pollerInput *gcpmodels.ResourcePollerInput, resourceID string) (interface{}, error) {
+	// Format: //compute.googleapis.com/projects/{project}/zones/{zone}/instances/{name}
+	parts := strings.Split(strings.TrimPrefix(resourceID, computeResourcePrefix), "/")
+	if len(parts) != 6 || parts[0] != "projects" || parts[1] != *pollerInput.ProjectID {
+		return nil, nil
+	}
+
+	instance := &computeInstanceResponse{}
+	requestURL := fmt.Sprintf("%s/compute/v1/projects/%s/zones/%s/instances/%s", computeEndpoint,
+		url.PathEscape(parts[1]), url.PathEscape(parts[3]), url.PathEscape(parts[5]))
+	err := getJSON(pollerInput.Client, requestURL, instance)
+	if IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := buildComputeInstanceSnapshot(pollerInput, instance)
+	if snapshot == nil {
+		return nil, nil
+	}
+	return snapshot, nil
+}
+
+func buildComputeInstanceSnapshot(
+	pollerInput *gcpmodels.ResourcePollerInput, instance *computeInstanceResponse) *gcpmodels.ComputeInstance {
+
+	snapshot := instance.ComputeInstance
+	zone := lastPathSegment(aws.StringValue(snapshot.Zone))
+	region := zone
+	if i := strings.LastIndex(zone, "-"); i > 0 {
+		region = zone[:i]
+	}
+	if pollerInput.ShouldIgnoreRegion(region) {
+		return nil
+	}
+
+	snapshot.ResourceID = aws.String(fmt.Sprintf("%sprojects/%s/zones/%s/instances/%s",
+		computeResourcePrefix, *pollerInput.ProjectID, zone, aws.StringValue(snapshot.Name)))
+	snapshot.ResourceType = aws.String(gcpmodels.ComputeInstanceSchema)
+	snapshot.TimeCreated = instance.CreationTimestamp
+	snapshot.ProjectID = pollerInput.ProjectID
+	snapshot.Region = aws.String(region)
+	snapshot.Zone = aws.String(zone)
+
+	// Startup and shutdown scripts are the equivalent of EC2 user data, and often contain secrets
+	if snapshot.Metadata != nil {
+		for _, item := range snapshot.Metadata.Items {
+			if strings.Contains(aws.StringValue(item.Key), "script") {
+				item.Value = nil
+			}
+		}
+	}
+	return &snapshot
+}
+
+// PollComputeInstances gathers information on each VM instance in a project
+func PollComputeInstances(pollerInput *gcpmodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	var resources []apimodels.AddResourceEntry
+	nextPageToken := pollerInput.NextPageToken
+	for {
+		// The aggregated list returns the instances of all zones, keyed by zone
+		var page struct {
+			Items map[string]struct {
+				Instances []*computeInstanceResponse
+			}
+			NextPageToken *string
+		}
+		requestURL := computeEndpoint + "/compute/v1/projects/" + url.PathEscape(*pollerInput.ProjectID) + "/aggregated/instances"
+		if err := getJSON(pollerInput.Client, pageURL(requestURL, nextPageToken), &page); err != nil {
+			return nil, nil, err
+		}
+
+		for _, scope := range page.Items {
+			for _, instance := range scope.Instances {
+				snapshot := buildComputeInstanceSnapshot(pollerInput, instance)
+				if snapshot == nil || pollerInput.ShouldIgnoreResource(*snapshot.ResourceID) {
+					continue
+				}
+				resources = append(resources, apimodels.AddResourceEntry{
+					Attributes:      snapshot,
+					ID:              *snapshot.ResourceID,
+					IntegrationID:   *pollerInput.IntegrationID,
+					IntegrationType: integrationType,
+					Type:            gcpmodels.ComputeInstanceSchema,
+				})
+			}
+		}
+
+		nextPageToken = nextPage(page.NextPageToken)
+		if nextPageToken == nil || len(resources) >= defaultBatchSize {
+			return resources, nextPageToken, nil
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+)
+
+func TestPollComputeInstances(t *testing.T) {
+	setupExampleServer(t)
+
+	resources, marker, err := PollComputeInstances(testPollerInput(t))
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	// Zones without instances only have a warning
+	require.Len(t, resources, 1)
+
+	instance := resources[0].Attributes.(*gcpmodels.ComputeInstance)
+	assert.Equal(t, "//compute.googleapis.com/projects/example-project/zones/us-central1-a/instances/example-instance", resources[0].ID)
+	assert.Equal(t, gcpmodels.ComputeInstanceSchema, resources[0].Type)
+	assert.Equal(t, "4567890123456789012", *instance.ID)
+	assert.Equal(t, "us-central1-a", *instance.Zone)
+	assert.Equal(t, "us-central1", *instance.Region)
+	assert.Equal(t, "example-project", *instance.ProjectID)
+	assert.Equal(t, "RUNNING", *instance.Status)
+	assert.Equal(t, time.Date(2020, 6, 4, 17, 0, 0, 0, time.UTC), instance.TimeCreated.UTC())
+	assert.Equal(t, []*string{aws.String("http-server")}, instance.Tags.Items)
+	assert.Equal(t, "203.0.113.10", *instance.NetworkInterfaces[0].AccessConfigs[0].NatIP)
+	assert.Equal(t, "10.128.0.2", *instance.NetworkInterfaces[0].NetworkIP)
+	assert.True(t, *instance.Disks[0].Boot)
+	assert.Equal(t, []*string{aws.String("https://www.googleapis.com/auth/cloud-platform")}, instance.ServiceAccounts[0].Scopes)
+	assert.False(t, *instance.ShieldedInstanceConfig.EnableSecureBoot)
+	assert.Equal(t, map[string]*string{"env": aws.String("prod")}, instance.Labels)
+
+	// Scripts are dropped, the rest of the metadata is kept
+	require.Len(t, instance.Metadata.Items, 2)
+	assert.Equal(t, "TRUE", *instance.Metadata.Items[0].Value)
+	assert.Equal(t, "startup-script", *instance.Metadata.Items[1].Key)
+	assert.Nil(t, instance.Metadata.Items[1].Value)
+}
+
+func TestPollComputeInstancesIgnoredRegion(t *testing.T) {
+	setupExampleServer(t)
+	input := testPollerInput(t)
+	input.RegionIgnoreList = []string{"us-central1"}
+
+	resources, _, err := PollComputeInstances(input)
+	require.NoError(t, err)
+	assert.Empty(t, resources)
+}
+
+func TestPollComputeInstance(t *testing.T) {
+	setupExampleServer(t)
+	resourceID := "//compute.googleapis.com/projects/example-project/zones/us-central1-a/instances/example-instance"
+
+	resource, err := PollComputeInstance(testPollerInput(t), resourceID)
+	require.NoError(t, err)
+	instance := resource.(*gcpmodels.ComputeInstance)
+	assert.Equal(t, resourceID, *instance.ResourceID)
+	assert.Equal(t, "us-central1-a", *instance.Zone)
+
+	resource, err = PollComputeInstance(testPollerInput(t),
+		"//compute.googleapis.com/projects/example-project/zones/us-central1-a/instances/deleted-instance")
+	require.NoError(t, err)
+	assert.Nil(t, resource)
+
+	resource, err = PollComputeInstance(testPollerInput(t), "//compute.googleapis.com/projects/example-project")
+	require.NoError(t, err)
+	assert.Nil(t, resource)
+}
@@ -0,0 +1,259 @@
+package gcptest
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/http"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Example responses of the Google APIs for one project, based on the API documentation
+const (
+	ExampleProjectID = "example-project"
+
+	ExampleProject = `{
+  "projectNumber": "123456789012",
+  "projectId": "example-project",
+  "lifecycleState": "ACTIVE",
+  "name": "Example Project",
+  "labels": {"env": "prod"},
+  "createTime": "2020-06-01T17:20:00.000Z",
+  "parent": {"type": "organization", "id": "111122223333"}
+}`
+
+	ExampleProjectIAMPolicy = `{
+  "version": 3,
+  "etag": "BwW4Y1vEw2Q=",
+  "bindings": [
+    {
+      "role": "roles/owner",
+      "members": ["user:admin@example.com"]
+    },
+    {
+      "role": "roles/storage.objectViewer",
+      "members": ["allUsers"],
+      "condition": {
+        "title": "expires_2021",
+        "expression": "request.time < timestamp('2021-01-01T00:00:00Z')"
+      }
+    }
+  ],
+  "auditConfigs": [
+    {
+      "service": "allServices",
+      "auditLogConfigs": [{"logType": "ADMIN_READ"}, {"logType": "DATA_WRITE", "exemptedMembers": ["user:ci@example.com"]}]
+    }
+  ]
+}`
+
+	ExampleStorageBuckets = `{
+  "kind": "storage#buckets",
+  "items": [
+    {
+      "kind": "storage#bucket",
+      "selfLink": "https://www.googleapis.com/storage/v1/b/example-bucket",
+      "id": "example-bucket",
+      "name": "example-bucket",
+      "projectNumber": "123456789012",
+      "metageneration": "3",
+      "location": "US",
+      "storageClass": "STANDARD",
+      "etag": "CAM=",
+      "timeCreated": "2020-06-02T10:00:00.000Z",
+      "updated": "2020-06-03T10:00:00.000Z",
+      "labels": {"team": "security"},
+      "versioning": {"enabled": true},
+      "logging": {"logBucket": "example-logs", "logObjectPrefix": "example-bucket"},
+      "encryption": {"defaultKmsKeyName": "projects/example-project/locations/us/keyRings/ring/cryptoKeys/key"},
+      "iamConfiguration": {
+        "bucketPolicyOnly": {"enabled": true, "lockedTime": "2020-09-01T10:00:00.000Z"},
+        "uniformBucketLevelAccess": {"enabled": true, "lockedTime": "2020-09-01T10:00:00.000Z"},
+        "publicAccessPrevention": "enforced"
+      },
+      "retentionPolicy": {"retentionPeriod": "86400", "effectiveTime": "2020-06-02T10:00:00.000Z", "isLocked": false},
+      "locationType": "multi-region"
+    }
+  ]
+}`
+
+	ExampleStorageBucketIAMPolicy = `{
+  "kind": "storage#policy",
+  "resourceId": "projects/_/buckets/example-bucket",
+  "version": 1,
+  "etag": "CAE=",
+  "bindings": [
+    {"role": "roles/storage.legacyBucketOwner", "members": ["projectOwner:example-project"]},
+    {"role": "roles/storage.objectViewer", "members": ["allUsers"]}
+  ]
+}`
+
+	ExampleComputeInstances = `{
+  "kind": "compute#instanceAggregatedList",
+  "id": "projects/example-project/aggregated/instances",
+  "items": {
+    "zones/us-central1-a": {
+      "instances": [
+        {
+          "kind": "compute#instance",
+          "id": "4567890123456789012",
+          "creationTimestamp": "2020-06-04T10:00:00.000-07:00",
+          "name": "example-instance",
+          "description": "",
+          "tags": {"items": ["http-server"], "fingerprint": "FYLDgkTKlA4="},
+          "machineType": "https://www.googleapis.com/compute/v1/projects/example-project/zones/us-central1-a/machineTypes/e2-medium",
+          "status": "RUNNING",
+          "zone": "https://www.googleapis.com/compute/v1/projects/example-project/zones/us-central1-a",
+          "canIpForward": false,
+          "networkInterfaces": [
+            {
+              "network": "https://www.googleapis.com/compute/v1/projects/example-project/global/networks/default",
+              "subnetwork": "https://www.googleapis.com/compute/v1/projects/example-project/regions/us-central1/subnetworks/default",
+              "networkIP": "10.128.0.2",
+              "name": "nic0",
+              "accessConfigs": [{"type": "ONE_TO_ONE_NAT", "name": "External NAT", "natIP": "203.0.113.10"}]
+            }
+          ],
+          "disks": [
+            {
+              "type": "PERSISTENT",
+              "mode": "READ_WRITE",
+              "source": "https://www.googleapis.com/compute/v1/projects/example-project/zones/us-central1-a/disks/example-instance",
+              "deviceName": "example-instance",
+              "boot": true
+            }
+          ],
+          "metadata": {
+            "fingerprint": "Y0aCAdEmYb4=",
+            "items": [
+              {"key": "enable-oslogin", "value": "TRUE"},
+              {"key": "startup-script", "value": "#! /bin/bash\nexport DB_PASSWORD=hunter2"}
+            ]
+          },
+          "serviceAccounts": [
+            {
+              "email": "123456789012-compute@developer.gserviceaccount.com",
+              "scopes": ["https://www.googleapis.com/auth/cloud-platform"]
+            }
+          ],
+          "selfLink": "https://www.googleapis.com/compute/v1/projects/example-project/zones/us-central1-a/instances/example-instance",
+          "shieldedInstanceConfig": {"enableSecureBoot": false, "enableVtpm": true, "enableIntegrityMonitoring": true},
+          "labels": {"env": "prod"},
+          "deletionProtection": false
+        }
+      ]
+    },
+    "zones/europe-west1-b": {
+      "warning": {
+        "code": "NO_RESULTS_ON_PAGE",
+        "message": "There are no results for scope 'zones/europe-west1-b' on this page."
+      }
+    }
+  }
+}`
+
+	ExampleComputeFirewalls = `{
+  "kind": "compute#firewallList",
+  "id": "projects/example-project/global/firewalls",
+  "items": [
+    {
+      "kind": "compute#firewall",
+      "id": "7890123456789012345",
+      "creationTimestamp": "2020-06-01T17:25:00.000-07:00",
+      "name": "default-allow-ssh",
+      "description": "Allow SSH from anywhere",
+      "network": "https://www.googleapis.com/compute/v1/projects/example-project/global/networks/default",
+      "priority": 65534,
+      "sourceRanges": ["0.0.0.0/0"],
+      "allowed": [{"IPProtocol": "tcp", "ports": ["22"]}],
+      "direction": "INGRESS",
+      "logConfig": {"enable": false},
+      "disabled": false,
+      "selfLink": "https://www.googleapis.com/compute/v1/projects/example-project/global/firewalls/default-allow-ssh"
+    }
+  ]
+}`
+
+	ExampleSQLInstances = `{
+  "items": [
+    {
+      "kind": "sql#instance",
+      "state": "RUNNABLE",
+      "databaseVersion": "POSTGRES_13",
+      "settings": {
+        "authorizedGaeApplications": [],
+        "tier": "db-custom-1-3840",
+        "kind": "sql#settings",
+        "userLabels": {"env": "prod"},
+        "availabilityType": "ZONAL",
+        "backupConfiguration": {
+          "startTime": "07:00",
+          "kind": "sql#backupConfiguration",
+          "enabled": true,
+          "pointInTimeRecoveryEnabled": true
+        },
+        "ipConfiguration": {
+          "ipv4Enabled": true,
+          "authorizedNetworks": [{"value": "0.0.0.0/0", "name": "anywhere", "kind": "sql#aclEntry"}],
+          "requireSsl": false
+        },
+        "databaseFlags": [{"name": "log_connections", "value": "on"}],
+        "activationPolicy": "ALWAYS",
+        "dataDiskSizeGb": "10",
+        "storageAutoResize": true,
+        "deletionProtectionEnabled": false
+      },
+      "etag": "c1b4ba4e6b1c6a1c",
+      "ipAddresses": [{"type": "PRIMARY", "ipAddress": "203.0.113.20"}],
+      "instanceType": "CLOUD_SQL_INSTANCE",
+      "project": "example-project",
+      "backendType": "SECOND_GEN",
+      "selfLink": "https://sqladmin.googleapis.com/v1/projects/example-project/instances/example-db",
+      "connectionName": "example-project:us-central1:example-db",
+      "name": "example-db",
+      "region": "us-central1",
+      "gceZone": "us-central1-f",
+      "createTime": "2020-06-05T10:00:00.000Z"
+    }
+  ]
+}`
+)
+
+// NewExampleServer starts a fake server which returns the example responses for the example project.
+func NewExampleServer() *Server {
+	s := NewServer()
+	s.Handle(http.MethodGet, "/v1/projects/example-project", http.StatusOK, ExampleProject)
+	s.Handle(http.MethodPost, "/v1/projects/example-project:getIamPolicy", http.StatusOK, ExampleProjectIAMPolicy)
+	s.Handle(http.MethodGet, "/storage/v1/b", http.StatusOK, ExampleStorageBuckets)
+	s.Handle(http.MethodGet, "/storage/v1/b/example-bucket/iam", http.StatusOK, ExampleStorageBucketIAMPolicy)
+	s.Handle(http.MethodGet, "/compute/v1/projects/example-project/aggregated/instances", http.StatusOK, ExampleComputeInstances)
+	s.Handle(http.MethodGet, "/compute/v1/projects/example-project/global/firewalls", http.StatusOK, ExampleComputeFirewalls)
+	s.Handle(http.MethodGet, "/v1/projects/example-project/instances", http.StatusOK, ExampleSQLInstances)
+
+	// The APIs to get a single resource return the same JSON as the items of the lists
+	s.Handle(http.MethodGet, "/storage/v1/b/example-bucket", http.StatusOK,
+		jsoniter.Get([]byte(ExampleStorageBuckets), "items", 0).ToString())
+	s.Handle(http.MethodGet, "/compute/v1/projects/example-project/zones/us-central1-a/instances/example-instance", http.StatusOK,
+		jsoniter.Get([]byte(ExampleComputeInstances), "items", "zones/us-central1-a", "instances", 0).ToString())
+	s.Handle(http.MethodGet, "/compute/v1/projects/example-project/global/firewalls/default-allow-ssh", http.StatusOK,
+		jsoniter.Get([]byte(ExampleComputeFirewalls), "items", 0).ToString())
+	s.Handle(http.MethodGet, "/v1/projects/example-project/instances/example-db", http.StatusOK,
+		jsoniter.Get([]byte(ExampleSQLInstances), "items", 0).ToString())
+	return s
+}
@@ -0,0 +1,167 @@
+package gcptest
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/apitest"
+)
+
+const (
+	// ExampleAccessToken is issued by the fake token endpoint
+	ExampleAccessToken    = "ya29.example-access-token"
+	ExampleServiceAccount = "panther-audit@example-project.iam.gserviceaccount.com"
+	ExampleServiceKeyID   = "0123456789abcdef0123456789abcdef01234567"
+	ExampleTokenPath      = "/token"
+	// TokenURI is the token_uri of the example key, and the audience the fake token endpoint expects
+	TokenURI = "https://oauth2.googleapis.com/token"
+)
+
+var (
+	// Generating a key is slow, so all servers share one
+	privateKey     *rsa.PrivateKey
+	privateKeyOnce sync.Once
+)
+
+func examplePrivateKey() *rsa.PrivateKey {
+	privateKeyOnce.Do(func() {
+		var err error
+		if privateKey, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+			panic(err)
+		}
+	})
+	return privateKey
+}
+
+// Server is a fake of the Google OAuth2 token endpoint and the Google APIs, which only answers
+// requests authorized with a token it issued.
+type Server struct {
+	*httptest.Server
+	*apitest.Router
+
+	mu sync.Mutex
+	// The number of access tokens issued
+	TokensIssued int
+}
+
+// NewServer starts a fake server without any API responses, see NewExampleServer.
+func NewServer() *Server {
+	s := &Server{Router: apitest.NewRouter(ExampleAccessToken, writeError)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// ServiceAccountKey returns a JSON key file of the example service account.
+func (s *Server) ServiceAccountKey() []byte {
+	der, err := x509.MarshalPKCS8PrivateKey(examplePrivateKey())
+	if err != nil {
+		panic(err)
+	}
+	key, err := jsoniter.Marshal(map[string]string{
+		"type":           "service_account",
+		"project_id":     ExampleProjectID,
+		"private_key_id": ExampleServiceKeyID,
+		"private_key":    string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		"client_email":   ExampleServiceAccount,
+		"client_id":      "123456789012345678901",
+		"token_uri":      TokenURI,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost && r.URL.Path == ExampleTokenPath {
+		s.issueToken(w, r)
+		return
+	}
+	s.Router.ServeHTTP(w, r)
+}
+
+// issueToken checks the assertion was signed by the service account key, as Google does.
+func (s *Server) issueToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "urn:ietf:params:oauth:grant-type:jwt-bearer" {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error": "unsupported_grant_type"}`))
+		return
+	}
+
+	parts := strings.Split(r.PostForm.Get("assertion"), ".")
+	var claims struct {
+		Iss   string `json:"iss"`
+		Aud   string `json:"aud"`
+		Scope string `json:"scope"`
+	}
+	if len(parts) != 3 || !validSignature(parts) || decodeSegment(parts[1], &claims) != nil ||
+		claims.Iss != ExampleServiceAccount || claims.Aud != TokenURI || claims.Scope == "" {
+
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error": "invalid_grant", "error_description": "Invalid JWT Signature."}`))
+		return
+	}
+
+	s.mu.Lock()
+	s.TokensIssued++
+	s.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(`{"access_token": "` + ExampleAccessToken + `", "expires_in": 3599, "token_type": "Bearer"}`))
+}
+
+func validSignature(parts []string) bool {
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	return rsa.VerifyPKCS1v15(&examplePrivateKey().PublicKey, crypto.SHA256, digest[:], signature) == nil
+}
+
+func decodeSegment(segment string, out interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return jsoniter.Unmarshal(data, out)
+}
+
+func writeError(w http.ResponseWriter, code int, path string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	status, message := "NOT_FOUND", fmt.Sprintf("%s was not found", path)
+	if code == http.StatusUnauthorized {
+		status, message = "UNAUTHENTICATED", "Request had invalid authentication credentials."
+	}
+	_, _ = fmt.Fprintf(w, `{"error": {"code": %d, "message": %q, "status": %q}}`, code, message, status)
+}
@@ -0,0 +1,80 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/url"
+
+	"github.com/aws/aws-sdk-go/aws"
+
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// Version 3 policies are needed to see the conditions of role bindings
+var iamPolicyOptions = map[string]interface{}{
+	"options": map[string]int{"requestedPolicyVersion": 3},
+}
+
+// PollIAMProjectPolicy polls the IAM policy of the project being scanned
+func PollIAMProjectPolicy(pollerInput *gcpmodels.ResourcePollerInput, resourceID string) (interface{}, error) {
+	parsedResourceID := utils.ParseResourceID(resourceID)
+	if parsedResourceID == nil || parsedResourceID.AccountID != *pollerInput.ProjectID {
+		return nil, nil
+	}
+	snapshot, err := getIAMProjectPolicy(pollerInput)
+	if err != nil || snapshot == nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+func getIAMProjectPolicy(pollerInput *gcpmodels.ResourcePollerInput) (*gcpmodels.IAMProjectPolicy, error) {
+	snapshot := &gcpmodels.IAMProjectPolicy{}
+	requestURL := resourceManagerEndpoint + "/v1/projects/" + url.PathEscape(*pollerInput.ProjectID) + ":getIamPolicy"
+	err := postJSON(pollerInput.Client, requestURL, iamPolicyOptions, snapshot)
+	if IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot.ResourceID = aws.String(utils.GenerateResourceID(*pollerInput.ProjectID, "", gcpmodels.IAMProjectPolicySchema))
+	snapshot.ResourceType = aws.String(gcpmodels.IAMProjectPolicySchema)
+	snapshot.ProjectID = pollerInput.ProjectID
+	snapshot.Region = aws.String(gcpmodels.GlobalRegion)
+	return snapshot, nil
+}
+
+// PollIAMProjectPolicies gathers the IAM policy of the project being scanned
+func PollIAMProjectPolicies(pollerInput *gcpmodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	snapshot, err := getIAMProjectPolicy(pollerInput)
+	if err != nil || snapshot == nil || pollerInput.ShouldIgnoreResource(*snapshot.ResourceID) {
+		return nil, nil, err
+	}
+	return []apimodels.AddResourceEntry{{
+		Attributes:      snapshot,
+		ID:              *snapshot.ResourceID,
+		IntegrationID:   *pollerInput.IntegrationID,
+		IntegrationType: integrationType,
+		Type:            gcpmodels.IAMProjectPolicySchema,
+	}}, nil, nil
+}
@@ -0,0 +1,72 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+)
+
+func TestPollIAMProjectPolicies(t *testing.T) {
+	server, _ := setupExampleServer(t)
+	var requestBody string
+	server.HandleFunc(http.MethodPost, "/v1/projects/example-project:getIamPolicy", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requestBody = string(body)
+		w.Write([]byte(`{"version": 3, "etag": "BwW4Y1vEw2Q=", "bindings": [{"role": "roles/owner", "members": ["user:admin@example.com"]}]}`))
+	})
+
+	resources, marker, err := PollIAMProjectPolicies(testPollerInput(t))
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	require.Len(t, resources, 1)
+	assert.JSONEq(t, `{"options": {"requestedPolicyVersion": 3}}`, requestBody)
+
+	policy := resources[0].Attributes.(*gcpmodels.IAMProjectPolicy)
+	assert.Equal(t, "example-project::GCP.IAM.ProjectPolicy", resources[0].ID)
+	assert.Equal(t, "example-project", *policy.ProjectID)
+	assert.Equal(t, int64(3), *policy.Version)
+	assert.Equal(t, []*gcpmodels.IAMBinding{
+		{Role: aws.String("roles/owner"), Members: []*string{aws.String("user:admin@example.com")}},
+	}, policy.Bindings)
+}
+
+func TestPollIAMProjectPolicy(t *testing.T) {
+	setupExampleServer(t)
+
+	resource, err := PollIAMProjectPolicy(testPollerInput(t), "example-project::GCP.IAM.ProjectPolicy")
+	require.NoError(t, err)
+	policy := resource.(*gcpmodels.IAMProjectPolicy)
+	require.Len(t, policy.Bindings, 2)
+	assert.Equal(t, "request.time < timestamp('2021-01-01T00:00:00Z')", *policy.Bindings[1].Condition.Expression)
+	require.Len(t, policy.AuditConfigs, 1)
+	assert.Equal(t, "allServices", *policy.AuditConfigs[0].Service)
+	assert.Equal(t, "DATA_WRITE", *policy.AuditConfigs[0].AuditLogConfigs[1].LogType)
+
+	resource, err = PollIAMProjectPolicy(testPollerInput(t), "other-project::GCP.IAM.ProjectPolicy")
+	require.NoError(t, err)
+	assert.Nil(t, resource)
+}
@@ -0,0 +1,259 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/gcp/gcptest"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+var testIntegrationID = "0aab70c6-da66-4bb9-a83c-bbe8f5717fde"
+
+// setupExampleServer points the pollers at a fake server returning the example responses, and
+// records requeued scans instead of sending them to SQS.
+func setupExampleServer(t *testing.T) (*gcptest.Server, *[]pollermodels.ScanMsg) {
+	server := gcptest.NewExampleServer()
+	t.Cleanup(server.Close)
+
+	resourceManagerEndpoint = server.URL
+	storageEndpoint = server.URL
+	computeEndpoint = server.URL
+	sqlAdminEndpoint = server.URL
+	tokenEndpoint = server.URL + gcptest.ExampleTokenPath
+	clientCache = make(map[string]cachedClient)
+	getServiceAccountKey = func(integrationID string) ([]byte, error) {
+		return server.ServiceAccountKey(), nil
+	}
+	utils.TimeNowFunc = func() time.Time { return time.Time{} }
+
+	var requeued []pollermodels.ScanMsg
+	requeue = func(scanRequest pollermodels.ScanMsg, _ int64) error {
+		requeued = append(requeued, scanRequest)
+		return nil
+	}
+	return server, &requeued
+}
+
+// testPollerInput returns the input for scanning the example project.
+func testPollerInput(t *testing.T) *gcpmodels.ResourcePollerInput {
+	client, err := getClient(testIntegrationID)
+	require.NoError(t, err)
+	return &gcpmodels.ResourcePollerInput{
+		Client:        client,
+		IntegrationID: aws.String(testIntegrationID),
+		ProjectID:     aws.String(gcptest.ExampleProjectID),
+		Timestamp:     aws.Time(time.Time{}),
+	}
+}
+
+func TestPollMultiProjectScan(t *testing.T) {
+	server, requeued := setupExampleServer(t)
+
+	resources, err := Poll(&pollermodels.ScanEntry{
+		IntegrationID: aws.String(testIntegrationID),
+		ResourceType:  aws.String(gcpmodels.StorageBucketSchema),
+		GCPProjectIDs: []string{"example-project", "other-project"},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, resources)
+	assert.Empty(t, server.Requests)
+
+	require.Len(t, *requeued, 2)
+	for i, project := range []string{"example-project", "other-project"} {
+		require.Len(t, (*requeued)[i].Entries, 1)
+		entry := (*requeued)[i].Entries[0]
+		assert.Equal(t, project, *entry.GCPProjectID)
+		assert.Equal(t, gcpmodels.StorageBucketSchema, *entry.ResourceType)
+		assert.Equal(t, testIntegrationID, *entry.IntegrationID)
+		assert.Nil(t, entry.GCPProjectIDs)
+	}
+}
+
+func TestPollProjectScan(t *testing.T) {
+	server, requeued := setupExampleServer(t)
+
+	entry := &pollermodels.ScanEntry{
+		IntegrationID: aws.String(testIntegrationID),
+		ResourceType:  aws.String(gcpmodels.ComputeFirewallSchema),
+		GCPProjectID:  aws.String(gcptest.ExampleProjectID),
+	}
+	resources, err := Poll(entry)
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, "//compute.googleapis.com/projects/example-project/global/firewalls/default-allow-ssh", resources[0].ID)
+	assert.Equal(t, "gcp", resources[0].IntegrationType)
+	assert.Equal(t, gcpmodels.ComputeFirewallSchema, resources[0].Type)
+	assert.Empty(t, *requeued)
+	assert.Nil(t, entry.NextPageToken)
+	assert.Equal(t, 1, server.TokensIssued)
+}
+
+func TestPollProjectScanNextPage(t *testing.T) {
+	server, requeued := setupExampleServer(t)
+	defaultBatchSize = 1
+	defer func() { defaultBatchSize = 100 }()
+
+	server.HandleFunc(http.MethodGet, "/compute/v1/projects/example-project/global/firewalls",
+		func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "1", r.URL.Query().Get("maxResults"))
+			assert.Empty(t, r.URL.Query().Get("pageToken"))
+			_, _ = w.Write([]byte(`{"items": [{"name": "first"}], "nextPageToken": "page-2"}`))
+		})
+
+	entry := &pollermodels.ScanEntry{
+		IntegrationID: aws.String(testIntegrationID),
+		ResourceType:  aws.String(gcpmodels.ComputeFirewallSchema),
+		GCPProjectID:  aws.String(gcptest.ExampleProjectID),
+	}
+	resources, err := Poll(entry)
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	require.Len(t, *requeued, 1)
+	assert.Equal(t, "page-2", *(*requeued)[0].Entries[0].NextPageToken)
+}
+
+func TestPollProjectScanRateLimited(t *testing.T) {
+	server, requeued := setupExampleServer(t)
+	server.Handle(http.MethodGet, "/v1/projects/example-project/instances", http.StatusTooManyRequests,
+		`{"error": {"code": 429, "message": "Quota exceeded", "status": "RESOURCE_EXHAUSTED"}}`)
+
+	entry := &pollermodels.ScanEntry{
+		IntegrationID: aws.String(testIntegrationID),
+		ResourceType:  aws.String(gcpmodels.SQLInstanceSchema),
+		GCPProjectID:  aws.String(gcptest.ExampleProjectID),
+		NextPageToken: aws.String("page-3"),
+	}
+	resources, err := Poll(entry)
+	require.NoError(t, err)
+	assert.Empty(t, resources)
+	// The same page is scanned again later
+	require.Len(t, *requeued, 1)
+	assert.Equal(t, "page-3", *(*requeued)[0].Entries[0].NextPageToken)
+}
+
+func TestPollProjectScanError(t *testing.T) {
+	server, requeued := setupExampleServer(t)
+	server.Handle(http.MethodGet, "/v1/projects/example-project/instances", http.StatusForbidden,
+		`{"error": {"code": 403, "message": "Cloud SQL Admin API has not been used", "status": "PERMISSION_DENIED"}}`)
+
+	resources, err := Poll(&pollermodels.ScanEntry{
+		IntegrationID: aws.String(testIntegrationID),
+		ResourceType:  aws.String(gcpmodels.SQLInstanceSchema),
+		GCPProjectID:  aws.String(gcptest.ExampleProjectID),
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "PERMISSION_DENIED")
+	assert.Empty(t, resources)
+	assert.Empty(t, *requeued)
+}
+
+func TestPollSingleResource(t *testing.T) {
+	setupExampleServer(t)
+
+	resourceID := "//sqladmin.googleapis.com/projects/example-project/instances/example-db"
+	resources, err := Poll(&pollermodels.ScanEntry{
+		IntegrationID: aws.String(testIntegrationID),
+		ResourceID:    aws.String(resourceID),
+		ResourceType:  aws.String(gcpmodels.SQLInstanceSchema),
+		GCPProjectID:  aws.String(gcptest.ExampleProjectID),
+	})
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, resourceID, resources[0].ID)
+	assert.IsType(t, &gcpmodels.SQLInstance{}, resources[0].Attributes)
+}
+
+func TestPollSingleResourceDeleted(t *testing.T) {
+	setupExampleServer(t)
+
+	resources, err := Poll(&pollermodels.ScanEntry{
+		IntegrationID: aws.String(testIntegrationID),
+		ResourceID:    aws.String("//sqladmin.googleapis.com/projects/example-project/instances/deleted-db"),
+		ResourceType:  aws.String(gcpmodels.SQLInstanceSchema),
+		GCPProjectID:  aws.String(gcptest.ExampleProjectID),
+	})
+	require.NoError(t, err)
+	assert.Empty(t, resources)
+}
+
+func TestPollFiltered(t *testing.T) {
+	server, requeued := setupExampleServer(t)
+
+	for _, entry := range []*pollermodels.ScanEntry{
+		{
+			IntegrationID: aws.String(testIntegrationID),
+			ResourceType:  aws.String(gcpmodels.ProjectSchema),
+			GCPProjectID:  aws.String(gcptest.ExampleProjectID),
+			Enabled:       aws.Bool(false),
+		},
+		{
+			IntegrationID:          aws.String(testIntegrationID),
+			ResourceType:           aws.String(gcpmodels.ProjectSchema),
+			GCPProjectID:           aws.String(gcptest.ExampleProjectID),
+			ResourceTypeIgnoreList: []string{gcpmodels.ProjectSchema},
+		},
+		{
+			IntegrationID:           aws.String(testIntegrationID),
+			ResourceType:            aws.String(gcpmodels.ProjectSchema),
+			GCPProjectID:            aws.String(gcptest.ExampleProjectID),
+			ResourceRegexIgnoreList: []string{"//cloudresourcemanager.googleapis.com/projects/example-*"},
+		},
+	} {
+		resources, err := Poll(entry)
+		require.NoError(t, err)
+		assert.Empty(t, resources)
+	}
+	assert.Empty(t, *requeued)
+	// Only the regex filter needs the project to be scanned
+	assert.Equal(t, []string{"GET /v1/projects/example-project"}, server.Requests)
+}
+
+func TestPollServiceAccountKeyError(t *testing.T) {
+	setupExampleServer(t)
+	getServiceAccountKey = func(integrationID string) ([]byte, error) {
+		return []byte(`{"type": "authorized_user"}`), nil
+	}
+
+	resources, err := Poll(&pollermodels.ScanEntry{
+		IntegrationID: aws.String(testIntegrationID),
+		ResourceType:  aws.String(gcpmodels.ProjectSchema),
+		GCPProjectID:  aws.String(gcptest.ExampleProjectID),
+	})
+	require.Error(t, err)
+	assert.Empty(t, resources)
+}
+
+func TestIsResourceType(t *testing.T) {
+	assert.True(t, IsResourceType(gcpmodels.StorageBucketSchema))
+	assert.False(t, IsResourceType("AWS.S3.Bucket"))
+	for resourceType := range ServicePollers {
+		_, ok := IndividualResourcePollers[resourceType]
+		assert.True(t, ok, "no individual poller for %s", resourceType)
+	}
+}
@@ -0,0 +1,117 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+)
+
+const projectResourcePrefix = "//cloudresourcemanager.googleapis.com/projects/"
+
+// projectResourceID returns the full resource name of a project
+func projectResourceID(projectID string) string {
+	return projectResourcePrefix + projectID
+}
+
+// PollProject polls a single project by its full resource name
+func PollProject(pollerInput *gcpmodels.ResourcePollerInput, resourceID string) (interface{}, error) {
+	projectID := strings.TrimPrefix(resourceID, projectResourcePrefix)
+	if projectID != *pollerInput.ProjectID {
+		// Scans only have access to the projects of their integration
+		return nil, nil
+	}
+	project, err := getProject(pollerInput.Client, projectID)
+	if err != nil || project == nil {
+		return nil, err
+	}
+	return buildProjectSnapshot(project), nil
+}
+
+// getProject looks up a project, returning nil if it does not exist.
+func getProject(client *http.Client, projectID string) (*projectResponse, error) {
+	project := &projectResponse{}
+	err := getJSON(client, resourceManagerEndpoint+"/v1/projects/"+url.PathEscape(projectID), project)
+	if IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// CheckProject verifies a client can read an active project. It is used by the source-api to check
+// the service account of an integration.
+func CheckProject(client *http.Client, projectID string) error {
+	project, err := getProject(client, projectID)
+	if err != nil {
+		return err
+	}
+	if project == nil {
+		return errors.Errorf("project %s does not exist", projectID)
+	}
+	if state := aws.StringValue(project.LifecycleState); state != "ACTIVE" {
+		return errors.Errorf("project %s is %s", projectID, state)
+	}
+	return nil
+}
+
+// projectResponse holds the fields of the Resource Manager project which need converting
+type projectResponse struct {
+	gcpmodels.Project
+	CreateTime *time.Time
+}
+
+func buildProjectSnapshot(project *projectResponse) *gcpmodels.Project {
+	snapshot := project.Project
+	snapshot.ResourceID = aws.String(projectResourceID(aws.StringValue(project.ProjectID)))
+	snapshot.ResourceType = aws.String(gcpmodels.ProjectSchema)
+	snapshot.TimeCreated = project.CreateTime
+	snapshot.ID = snapshot.ProjectID
+	snapshot.Region = aws.String(gcpmodels.GlobalRegion)
+	return &snapshot
+}
+
+// PollProjects gathers information on the project being scanned
+func PollProjects(pollerInput *gcpmodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	project, err := getProject(pollerInput.Client, *pollerInput.ProjectID)
+	if err != nil || project == nil {
+		return nil, nil, err
+	}
+	snapshot := buildProjectSnapshot(project)
+	if pollerInput.ShouldIgnoreResource(*snapshot.ResourceID) {
+		return nil, nil, nil
+	}
+	return []apimodels.AddResourceEntry{{
+		Attributes:      snapshot,
+		ID:              *snapshot.ResourceID,
+		IntegrationID:   *pollerInput.IntegrationID,
+		IntegrationType: integrationType,
+		Type:            gcpmodels.ProjectSchema,
+	}}, nil, nil
+}
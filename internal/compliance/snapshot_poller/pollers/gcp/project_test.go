@@ -0,0 +1,101 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/gcp/gcptest"
+)
+
+func TestPollProjects(t *testing.T) {
+	setupExampleServer(t)
+
+	resources, marker, err := PollProjects(testPollerInput(t))
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	require.Len(t, resources, 1)
+
+	project := resources[0].Attributes.(*gcpmodels.Project)
+	assert.Equal(t, "//cloudresourcemanager.googleapis.com/projects/example-project", resources[0].ID)
+	assert.Equal(t, resources[0].ID, *project.ResourceID)
+	assert.Equal(t, gcpmodels.ProjectSchema, *project.ResourceType)
+	assert.Equal(t, "example-project", *project.ProjectID)
+	assert.Equal(t, "example-project", *project.ID)
+	assert.Equal(t, "Example Project", *project.Name)
+	assert.Equal(t, "global", *project.Region)
+	assert.Equal(t, "123456789012", *project.ProjectNumber)
+	assert.Equal(t, "ACTIVE", *project.LifecycleState)
+	assert.Equal(t, &gcpmodels.ProjectParent{Type: aws.String("organization"), ID: aws.String("111122223333")}, project.Parent)
+	assert.Equal(t, map[string]*string{"env": aws.String("prod")}, project.Labels)
+	assert.Equal(t, time.Date(2020, 6, 1, 17, 20, 0, 0, time.UTC), *project.TimeCreated)
+}
+
+func TestPollProjectsDoesNotExist(t *testing.T) {
+	server, _ := setupExampleServer(t)
+	server.Handle(http.MethodGet, "/v1/projects/example-project", http.StatusNotFound, `{}`)
+
+	resources, marker, err := PollProjects(testPollerInput(t))
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	assert.Empty(t, resources)
+}
+
+func TestPollProject(t *testing.T) {
+	setupExampleServer(t)
+
+	project, err := PollProject(testPollerInput(t), "//cloudresourcemanager.googleapis.com/projects/example-project")
+	require.NoError(t, err)
+	assert.IsType(t, &gcpmodels.Project{}, project)
+
+	// Projects outside of the integration are not scanned
+	project, err = PollProject(testPollerInput(t), "//cloudresourcemanager.googleapis.com/projects/other-project")
+	require.NoError(t, err)
+	assert.Nil(t, project)
+}
+
+func TestCheckProject(t *testing.T) {
+	server, _ := setupExampleServer(t)
+	client := testPollerInput(t).Client
+
+	assert.NoError(t, CheckProject(client, gcptest.ExampleProjectID))
+
+	err := CheckProject(client, "other-project")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not exist")
+
+	server.Handle(http.MethodGet, "/v1/projects/example-project", http.StatusOK,
+		`{"projectId": "example-project", "lifecycleState": "DELETE_REQUESTED"}`)
+	err = CheckProject(client, gcptest.ExampleProjectID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "DELETE_REQUESTED")
+
+	server.Handle(http.MethodGet, "/v1/projects/example-project", http.StatusForbidden,
+		`{"error": {"code": 403, "message": "The caller does not have permission", "status": "PERMISSION_DENIED"}}`)
+	err = CheckProject(client, gcptest.ExampleProjectID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "The caller does not have permission")
+}
@@ -0,0 +1,118 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+)
+
+const sqlInstanceResourcePrefix = "//sqladmin.googleapis.com/projects/"
+
+// sqlInstanceResponse holds the fields of the Cloud SQL instance which need converting
+type sqlInstanceResponse struct {
+	gcpmodels.SQLInstance
+	CreateTime *time.Time
+}
+
+// PollSQLInstance polls a single Cloud SQL instance by its full resource name
+func PollSQLInstance(pollerInput *gcpmodels.ResourcePollerInput, resourceID string) (interface{}, error) {
+	// Format: //sqladmin.googleapis.com/projects/{project}/instances/{name}
+	parts := strings.Split(strings.TrimPrefix(resourceID, sqlInstanceResourcePrefix), "/")
+	if len(parts) != 3 || parts[0] != *pollerInput.ProjectID || parts[1] != "instances" {
+		return nil, nil
+	}
+
+	instance := &sqlInstanceResponse{}
+	requestURL := fmt.Sprintf("%s/v1/projects/%s/instances/%s", sqlAdminEndpoint,
+		url.PathEscape(parts[0]), url.PathEscape(parts[2]))
+	err := getJSON(pollerInput.Client, requestURL, instance)
+	if IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := buildSQLInstanceSnapshot(pollerInput, instance)
+	if snapshot == nil {
+		return nil, nil
+	}
+	return snapshot, nil
+}
+
+func buildSQLInstanceSnapshot(pollerInput *gcpmodels.ResourcePollerInput, instance *sqlInstanceResponse) *gcpmodels.SQLInstance {
+	snapshot := instance.SQLInstance
+	if pollerInput.ShouldIgnoreRegion(aws.StringValue(snapshot.Region)) {
+		return nil
+	}
+
+	snapshot.ResourceID = aws.String(fmt.Sprintf("%s%s/instances/%s",
+		sqlInstanceResourcePrefix, *pollerInput.ProjectID, aws.StringValue(snapshot.Name)))
+	snapshot.ResourceType = aws.String(gcpmodels.SQLInstanceSchema)
+	snapshot.TimeCreated = instance.CreateTime
+	snapshot.ProjectID = pollerInput.ProjectID
+	// Cloud SQL keeps labels with the rest of the instance settings
+	if snapshot.Settings != nil {
+		snapshot.Labels = instance.Settings.UserLabels
+	}
+	return &snapshot
+}
+
+// PollSQLInstances gathers information on each Cloud SQL instance in a project
+func PollSQLInstances(pollerInput *gcpmodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	var resources []apimodels.AddResourceEntry
+	nextPageToken := pollerInput.NextPageToken
+	for {
+		var page struct {
+			Items         []*sqlInstanceResponse
+			NextPageToken *string
+		}
+		requestURL := sqlAdminEndpoint + "/v1/projects/" + url.PathEscape(*pollerInput.ProjectID) + "/instances"
+		if err := getJSON(pollerInput.Client, pageURL(requestURL, nextPageToken), &page); err != nil {
+			return nil, nil, err
+		}
+
+		for _, instance := range page.Items {
+			snapshot := buildSQLInstanceSnapshot(pollerInput, instance)
+			if snapshot == nil || pollerInput.ShouldIgnoreResource(*snapshot.ResourceID) {
+				continue
+			}
+			resources = append(resources, apimodels.AddResourceEntry{
+				Attributes:      snapshot,
+				ID:              *snapshot.ResourceID,
+				IntegrationID:   *pollerInput.IntegrationID,
+				IntegrationType: integrationType,
+				Type:            gcpmodels.SQLInstanceSchema,
+			})
+		}
+
+		nextPageToken = nextPage(page.NextPageToken)
+		if nextPageToken == nil || len(resources) >= defaultBatchSize {
+			return resources, nextPageToken, nil
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+)
+
+func TestPollSQLInstances(t *testing.T) {
+	setupExampleServer(t)
+
+	resources, marker, err := PollSQLInstances(testPollerInput(t))
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	require.Len(t, resources, 1)
+
+	instance := resources[0].Attributes.(*gcpmodels.SQLInstance)
+	assert.Equal(t, "//sqladmin.googleapis.com/projects/example-project/instances/example-db", resources[0].ID)
+	assert.Equal(t, "us-central1", *instance.Region)
+	assert.Equal(t, "POSTGRES_13", *instance.DatabaseVersion)
+	assert.Equal(t, "203.0.113.20", *instance.IPAddresses[0].IPAddress)
+	assert.Equal(t, time.Date(2020, 6, 5, 10, 0, 0, 0, time.UTC), *instance.TimeCreated)
+	assert.True(t, *instance.Settings.IPConfiguration.Ipv4Enabled)
+	assert.False(t, *instance.Settings.IPConfiguration.RequireSsl)
+	assert.Equal(t, "0.0.0.0/0", *instance.Settings.IPConfiguration.AuthorizedNetworks[0].Value)
+	assert.True(t, *instance.Settings.BackupConfiguration.PointInTimeRecoveryEnabled)
+	assert.Equal(t, "log_connections", *instance.Settings.DatabaseFlags[0].Name)
+	assert.Equal(t, map[string]*string{"env": aws.String("prod")}, instance.Labels)
+}
+
+func TestPollSQLInstancesIgnoredRegion(t *testing.T) {
+	setupExampleServer(t)
+	input := testPollerInput(t)
+	input.RegionIgnoreList = []string{"us-central1"}
+
+	resources, _, err := PollSQLInstances(input)
+	require.NoError(t, err)
+	assert.Empty(t, resources)
+}
@@ -0,0 +1,119 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+)
+
+const storageBucketResourcePrefix = "//storage.googleapis.com/projects/_/buckets/"
+
+// PollStorageBucket polls a single bucket by its full resource name
+func PollStorageBucket(pollerInput *gcpmodels.ResourcePollerInput, resourceID string) (interface{}, error) {
+	bucket := &gcpmodels.StorageBucket{}
+	name := strings.TrimPrefix(resourceID, storageBucketResourcePrefix)
+	err := getJSON(pollerInput.Client, storageEndpoint+"/storage/v1/b/"+url.PathEscape(name), bucket)
+	if IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := buildStorageBucketSnapshot(pollerInput, bucket)
+	if err != nil || snapshot == nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// buildStorageBucketSnapshot adds the IAM policy to a bucket returned by the Cloud Storage API
+func buildStorageBucketSnapshot(
+	pollerInput *gcpmodels.ResourcePollerInput, bucket *gcpmodels.StorageBucket) (*gcpmodels.StorageBucket, error) {
+
+	// Multi-region locations such as US are returned in upper case
+	region := strings.ToLower(aws.StringValue(bucket.Location))
+	if pollerInput.ShouldIgnoreRegion(region) {
+		return nil, nil
+	}
+
+	bucket.ResourceID = aws.String(storageBucketResourcePrefix + aws.StringValue(bucket.Name))
+	bucket.ResourceType = aws.String(gcpmodels.StorageBucketSchema)
+	bucket.ProjectID = pollerInput.ProjectID
+	bucket.Region = aws.String(region)
+
+	policy := &gcpmodels.IAMPolicy{}
+	requestURL := storageEndpoint + "/storage/v1/b/" + url.PathEscape(aws.StringValue(bucket.Name)) +
+		"/iam?optionsRequestedPolicyVersion=3"
+	if err := getJSON(pollerInput.Client, requestURL, policy); err != nil {
+		if IsRateLimited(err) {
+			return nil, err
+		}
+		// The rest of the bucket is still worth reporting
+		zap.L().Warn("failed to get bucket IAM policy", zap.String("bucket", *bucket.ResourceID), zap.Error(err))
+	} else {
+		bucket.IAMPolicy = policy
+	}
+	return bucket, nil
+}
+
+// PollStorageBuckets gathers information on each Cloud Storage bucket in a project
+func PollStorageBuckets(pollerInput *gcpmodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	var resources []apimodels.AddResourceEntry
+	nextPageToken := pollerInput.NextPageToken
+	for {
+		var page struct {
+			Items         []*gcpmodels.StorageBucket
+			NextPageToken *string
+		}
+		requestURL := storageEndpoint + "/storage/v1/b?project=" + url.QueryEscape(*pollerInput.ProjectID)
+		if err := getJSON(pollerInput.Client, pageURL(requestURL, nextPageToken), &page); err != nil {
+			return nil, nil, err
+		}
+
+		for _, bucket := range page.Items {
+			snapshot, err := buildStorageBucketSnapshot(pollerInput, bucket)
+			if err != nil {
+				return nil, nil, err
+			}
+			if snapshot == nil || pollerInput.ShouldIgnoreResource(*snapshot.ResourceID) {
+				continue
+			}
+			resources = append(resources, apimodels.AddResourceEntry{
+				Attributes:      snapshot,
+				ID:              *snapshot.ResourceID,
+				IntegrationID:   *pollerInput.IntegrationID,
+				IntegrationType: integrationType,
+				Type:            gcpmodels.StorageBucketSchema,
+			})
+		}
+
+		nextPageToken = nextPage(page.NextPageToken)
+		if nextPageToken == nil || len(resources) >= defaultBatchSize {
+			return resources, nextPageToken, nil
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+)
+
+func TestPollStorageBuckets(t *testing.T) {
+	server, _ := setupExampleServer(t)
+
+	resources, marker, err := PollStorageBuckets(testPollerInput(t))
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	require.Len(t, resources, 1)
+	assert.Contains(t, server.Requests, "GET /storage/v1/b/example-bucket/iam")
+
+	bucket := resources[0].Attributes.(*gcpmodels.StorageBucket)
+	assert.Equal(t, "//storage.googleapis.com/projects/_/buckets/example-bucket", resources[0].ID)
+	assert.Equal(t, gcpmodels.StorageBucketSchema, resources[0].Type)
+	assert.Equal(t, "example-bucket", *bucket.Name)
+	assert.Equal(t, "example-project", *bucket.ProjectID)
+	assert.Equal(t, "us", *bucket.Region)
+	assert.Equal(t, "US", *bucket.Location)
+	assert.Equal(t, time.Date(2020, 6, 2, 10, 0, 0, 0, time.UTC), *bucket.TimeCreated)
+	assert.True(t, *bucket.Versioning.Enabled)
+	assert.Equal(t, "example-logs", *bucket.Logging.LogBucket)
+	assert.Equal(t, "projects/example-project/locations/us/keyRings/ring/cryptoKeys/key", *bucket.Encryption.DefaultKMSKeyName)
+	assert.True(t, *bucket.IAMConfiguration.UniformBucketLevelAccess.Enabled)
+	assert.Equal(t, "enforced", *bucket.IAMConfiguration.PublicAccessPrevention)
+	assert.Equal(t, "86400", *bucket.RetentionPolicy.RetentionPeriod)
+	assert.Equal(t, map[string]*string{"team": aws.String("security")}, bucket.Labels)
+	require.NotNil(t, bucket.IAMPolicy)
+	require.Len(t, bucket.IAMPolicy.Bindings, 2)
+	assert.Equal(t, []*string{aws.String("allUsers")}, bucket.IAMPolicy.Bindings[1].Members)
+}
+
+func TestPollStorageBucketsIAMPolicyError(t *testing.T) {
+	server, _ := setupExampleServer(t)
+	server.Handle(http.MethodGet, "/storage/v1/b/example-bucket/iam", http.StatusForbidden,
+		`{"error": {"code": 403, "message": "does not have storage.buckets.getIamPolicy access", "status": "PERMISSION_DENIED"}}`)
+
+	resources, _, err := PollStorageBuckets(testPollerInput(t))
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Nil(t, resources[0].Attributes.(*gcpmodels.StorageBucket).IAMPolicy)
+}
+
+func TestPollStorageBucketsIgnoredRegion(t *testing.T) {
+	setupExampleServer(t)
+	input := testPollerInput(t)
+	input.RegionIgnoreList = []string{"us"}
+
+	resources, marker, err := PollStorageBuckets(input)
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	assert.Empty(t, resources)
+}
+
+func TestPollStorageBucketsError(t *testing.T) {
+	server, _ := setupExampleServer(t)
+	server.Handle(http.MethodGet, "/storage/v1/b", http.StatusInternalServerError, `{}`)
+
+	resources, marker, err := PollStorageBuckets(testPollerInput(t))
+	require.Error(t, err)
+	assert.Nil(t, marker)
+	assert.Nil(t, resources)
+}
+
+func TestPollStorageBucket(t *testing.T) {
+	setupExampleServer(t)
+
+	resource, err := PollStorageBucket(testPollerInput(t), "//storage.googleapis.com/projects/_/buckets/example-bucket")
+	require.NoError(t, err)
+	bucket := resource.(*gcpmodels.StorageBucket)
+	assert.Equal(t, "//storage.googleapis.com/projects/_/buckets/example-bucket", *bucket.ResourceID)
+	assert.NotNil(t, bucket.IAMPolicy)
+
+	resource, err = PollStorageBucket(testPollerInput(t), "//storage.googleapis.com/projects/_/buckets/deleted-bucket")
+	require.NoError(t, err)
+	assert.Nil(t, resource)
+}
@@ -0,0 +1,69 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	k8smodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/k8s"
+)
+
+const admissionRegistrationAPIPath = "/apis/admissionregistration.k8s.io/v1"
+
+var (
+	validatingWebhookConfigurationKind = &resourceKind{
+		schema:      k8smodels.ValidatingWebhookConfigurationSchema,
+		apiPath:     admissionRegistrationAPIPath,
+		plural:      "validatingwebhookconfigurations",
+		newResource: func() k8sResource { return &k8smodels.WebhookConfiguration{} },
+	}
+	mutatingWebhookConfigurationKind = &resourceKind{
+		schema:      k8smodels.MutatingWebhookConfigurationSchema,
+		apiPath:     admissionRegistrationAPIPath,
+		plural:      "mutatingwebhookconfigurations",
+		newResource: func() k8sResource { return &k8smodels.WebhookConfiguration{} },
+	}
+)
+
+// PollValidatingWebhookConfiguration polls a single validating webhook configuration by its resource ID
+func PollValidatingWebhookConfiguration(
+	pollerInput *k8smodels.ResourcePollerInput, resourceID string) (interface{}, error) {
+
+	return validatingWebhookConfigurationKind.get(pollerInput, resourceID)
+}
+
+// PollValidatingWebhookConfigurations gathers information on each validating webhook configuration in a cluster
+func PollValidatingWebhookConfigurations(
+	pollerInput *k8smodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+
+	return validatingWebhookConfigurationKind.list(pollerInput)
+}
+
+// PollMutatingWebhookConfiguration polls a single mutating webhook configuration by its resource ID
+func PollMutatingWebhookConfiguration(
+	pollerInput *k8smodels.ResourcePollerInput, resourceID string) (interface{}, error) {
+
+	return mutatingWebhookConfigurationKind.get(pollerInput, resourceID)
+}
+
+// PollMutatingWebhookConfigurations gathers information on each mutating webhook configuration in a cluster
+func PollMutatingWebhookConfigurations(
+	pollerInput *k8smodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+
+	return mutatingWebhookConfigurationKind.list(pollerInput)
+}
@@ -0,0 +1,84 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	k8smodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/k8s"
+)
+
+func TestPollValidatingWebhookConfigurations(t *testing.T) {
+	setupExampleServer(t)
+
+	resources, marker, err := PollValidatingWebhookConfigurations(testPollerInput(t))
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	require.Len(t, resources, 1)
+
+	configuration := resources[0].Attributes.(*k8smodels.WebhookConfiguration)
+	assert.Equal(t, testResourceID(
+		"/apis/admissionregistration.k8s.io/v1/validatingwebhookconfigurations/policy-controller"), resources[0].ID)
+	assert.Equal(t, k8smodels.ValidatingWebhookConfigurationSchema, resources[0].Type)
+	assert.Equal(t, k8smodels.GlobalRegion, *configuration.Region)
+	require.Len(t, configuration.Webhooks, 1)
+	webhook := configuration.Webhooks[0]
+	assert.Equal(t, "validate.policy.example.com", *webhook.Name)
+	assert.Equal(t, "Ignore", *webhook.FailurePolicy)
+	assert.Equal(t, "policy-webhook", *webhook.ClientConfig.Service.Name)
+	assert.Nil(t, webhook.ClientConfig.URL)
+	assert.Equal(t, aws.StringSlice([]string{"CREATE", "UPDATE"}), webhook.Rules[0].Operations)
+	assert.Equal(t, "NotIn", *webhook.NamespaceSelector.MatchExpressions[0].Operator)
+	assert.Equal(t, int64(10), *webhook.TimeoutSeconds)
+	assert.Nil(t, webhook.ReinvocationPolicy)
+}
+
+func TestPollMutatingWebhookConfigurations(t *testing.T) {
+	setupExampleServer(t)
+
+	resources, _, err := PollMutatingWebhookConfigurations(testPollerInput(t))
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+
+	configuration := resources[0].Attributes.(*k8smodels.WebhookConfiguration)
+	assert.Equal(t, k8smodels.MutatingWebhookConfigurationSchema, *configuration.ResourceType)
+	webhook := configuration.Webhooks[0]
+	assert.Equal(t, "https://injector.example.com/mutate", *webhook.ClientConfig.URL)
+	assert.Equal(t, "IfNeeded", *webhook.ReinvocationPolicy)
+}
+
+func TestPollValidatingWebhookConfiguration(t *testing.T) {
+	setupExampleServer(t)
+
+	resourceID := testResourceID("/apis/admissionregistration.k8s.io/v1/validatingwebhookconfigurations/policy-controller")
+	resource, err := PollValidatingWebhookConfiguration(testPollerInput(t), resourceID)
+	require.NoError(t, err)
+	configuration := resource.(*k8smodels.WebhookConfiguration)
+	assert.Equal(t, resourceID, *configuration.ResourceID)
+	assert.Len(t, configuration.Webhooks, 1)
+
+	resource, err = PollMutatingWebhookConfiguration(testPollerInput(t),
+		testResourceID("/apis/admissionregistration.k8s.io/v1/mutatingwebhookconfigurations/deleted"))
+	require.NoError(t, err)
+	assert.Nil(t, resource)
+}
@@ -0,0 +1,259 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// Credentials are what an integration stores to connect to its cluster: either a kubeconfig, or the
+// URL of the API server with a service account token.
+type Credentials struct {
+	Kubeconfig string `json:"kubeconfig,omitempty"`
+	Server     string `json:"server,omitempty"`
+	Token      string `json:"token,omitempty"`
+	// The PEM encoded CA certificate of the API server, when it is not signed by a public CA
+	CertificateAuthority string `json:"certificateAuthority,omitempty"`
+}
+
+// Cluster is the connection info of a cluster, resolved from the credentials of an integration.
+type Cluster struct {
+	Server               string
+	Token                string
+	CertificateAuthority []byte
+	ClientCertificate    *tls.Certificate
+}
+
+// ParseCredentials reads the credentials stored by the source-api.
+func ParseCredentials(data []byte) (*Cluster, error) {
+	credentials := &Credentials{}
+	if err := jsoniter.Unmarshal(data, credentials); err != nil {
+		return nil, errors.Wrap(err, "cluster credentials are not valid JSON")
+	}
+	return credentials.Cluster()
+}
+
+// Cluster validates the credentials and returns the connection info they describe.
+func (c *Credentials) Cluster() (*Cluster, error) {
+	var cluster *Cluster
+	if c.Kubeconfig != "" {
+		var err error
+		if cluster, err = parseKubeconfig([]byte(c.Kubeconfig)); err != nil {
+			return nil, err
+		}
+	} else {
+		if c.Token == "" {
+			return nil, errors.New("either a kubeconfig or a service account token is required")
+		}
+		cluster = &Cluster{Server: c.Server, Token: c.Token}
+		if c.CertificateAuthority != "" {
+			cluster.CertificateAuthority = []byte(c.CertificateAuthority)
+		}
+	}
+
+	serverURL, err := url.Parse(cluster.Server)
+	if err != nil || serverURL.Host == "" {
+		return nil, errors.Errorf("invalid API server URL %q", cluster.Server)
+	}
+	// Credentials are never sent in the clear
+	if serverURL.Scheme != "https" {
+		return nil, errors.Errorf("API server URL %q must use https", cluster.Server)
+	}
+	cluster.Server = strings.TrimSuffix(cluster.Server, "/")
+
+	if cluster.CertificateAuthority != nil && !x509.NewCertPool().AppendCertsFromPEM(cluster.CertificateAuthority) {
+		return nil, errors.New("certificate authority is not a PEM encoded certificate")
+	}
+	return cluster, nil
+}
+
+// kubeconfig holds the fields of a kubeconfig file which are needed to connect to a cluster.
+type kubeconfig struct {
+	CurrentContext string `yaml:"current-context"`
+	Clusters       []struct {
+		Name    string `yaml:"name"`
+		Cluster struct {
+			Server                   string `yaml:"server"`
+			CertificateAuthority     string `yaml:"certificate-authority"`
+			CertificateAuthorityData string `yaml:"certificate-authority-data"`
+			InsecureSkipTLSVerify    bool   `yaml:"insecure-skip-tls-verify"`
+		} `yaml:"cluster"`
+	} `yaml:"clusters"`
+	Contexts []struct {
+		Name    string `yaml:"name"`
+		Context struct {
+			Cluster string `yaml:"cluster"`
+			User    string `yaml:"user"`
+		} `yaml:"context"`
+	} `yaml:"contexts"`
+	Users []struct {
+		Name string `yaml:"name"`
+		User struct {
+			Token                 string                 `yaml:"token"`
+			TokenFile             string                 `yaml:"tokenFile"`
+			ClientCertificate     string                 `yaml:"client-certificate"`
+			ClientCertificateData string                 `yaml:"client-certificate-data"`
+			ClientKeyData         string                 `yaml:"client-key-data"`
+			Exec                  map[string]interface{} `yaml:"exec"`
+			AuthProvider          map[string]interface{} `yaml:"auth-provider"`
+		} `yaml:"user"`
+	} `yaml:"users"`
+}
+
+// parseKubeconfig resolves the current context of a kubeconfig.
+//
+// The pollers run in a lambda function, so only credentials embedded in the kubeconfig are
+// supported. Files and credential plugins cannot be used.
+func parseKubeconfig(data []byte) (*Cluster, error) {
+	config := &kubeconfig{}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, errors.Wrap(err, "kubeconfig is not valid YAML")
+	}
+
+	contextName := config.CurrentContext
+	if contextName == "" && len(config.Contexts) == 1 {
+		contextName = config.Contexts[0].Name
+	}
+	var clusterName, userName string
+	found := false
+	for _, context := range config.Contexts {
+		if context.Name == contextName {
+			clusterName, userName, found = context.Context.Cluster, context.Context.User, true
+			break
+		}
+	}
+	if !found {
+		return nil, errors.Errorf("kubeconfig has no context %q", contextName)
+	}
+
+	cluster := &Cluster{}
+	found = false
+	for _, entry := range config.Clusters {
+		if entry.Name != clusterName {
+			continue
+		}
+		found = true
+		if entry.Cluster.InsecureSkipTLSVerify {
+			return nil, errors.Errorf("cluster %q: insecure-skip-tls-verify is not supported", clusterName)
+		}
+		if entry.Cluster.CertificateAuthority != "" {
+			return nil, errors.Errorf("cluster %q: certificate-authority files are not supported, "+
+				"use certificate-authority-data", clusterName)
+		}
+		cluster.Server = entry.Cluster.Server
+		if entry.Cluster.CertificateAuthorityData != "" {
+			ca, err := base64.StdEncoding.DecodeString(entry.Cluster.CertificateAuthorityData)
+			if err != nil {
+				return nil, errors.Wrapf(err, "cluster %q: invalid certificate-authority-data", clusterName)
+			}
+			cluster.CertificateAuthority = ca
+		}
+		break
+	}
+	if !found {
+		return nil, errors.Errorf("kubeconfig has no cluster %q", clusterName)
+	}
+
+	for _, entry := range config.Users {
+		if entry.Name != userName {
+			continue
+		}
+		user := entry.User
+		switch {
+		case user.Exec != nil, user.AuthProvider != nil:
+			return nil, errors.Errorf("user %q: credential plugins are not supported, "+
+				"use a service account token", userName)
+		case user.TokenFile != "", user.ClientCertificate != "":
+			return nil, errors.Errorf("user %q: credential files are not supported, "+
+				"embed the credentials in the kubeconfig", userName)
+		case user.Token != "":
+			cluster.Token = user.Token
+		case user.ClientCertificateData != "" && user.ClientKeyData != "":
+			certificate, err := decodeKeyPair(user.ClientCertificateData, user.ClientKeyData)
+			if err != nil {
+				return nil, errors.Wrapf(err, "user %q", userName)
+			}
+			cluster.ClientCertificate = certificate
+		default:
+			return nil, errors.Errorf("user %q has no token or client certificate", userName)
+		}
+		return cluster, nil
+	}
+	return nil, errors.Errorf("kubeconfig has no user %q", userName)
+}
+
+func decodeKeyPair(certificateData, keyData string) (*tls.Certificate, error) {
+	certificatePEM, err := base64.StdEncoding.DecodeString(certificateData)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid client-certificate-data")
+	}
+	keyPEM, err := base64.StdEncoding.DecodeString(keyData)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid client-key-data")
+	}
+	certificate, err := tls.X509KeyPair(certificatePEM, keyPEM)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid client certificate")
+	}
+	return &certificate, nil
+}
+
+// NewClient returns an http client which authenticates requests to the API server of a cluster.
+func NewClient(cluster *Cluster) *http.Client {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if cluster.CertificateAuthority != nil {
+		tlsConfig.RootCAs = x509.NewCertPool()
+		tlsConfig.RootCAs.AppendCertsFromPEM(cluster.CertificateAuthority)
+	}
+	if cluster.ClientCertificate != nil {
+		tlsConfig.Certificates = []tls.Certificate{*cluster.ClientCertificate}
+	}
+
+	var transport http.RoundTripper = &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		TLSClientConfig:     tlsConfig,
+		TLSHandshakeTimeout: httpClient.Timeout,
+	}
+	if cluster.Token != "" {
+		transport = &tokenTransport{token: cluster.Token, base: transport}
+	}
+	return &http.Client{Timeout: httpClient.Timeout, Transport: transport}
+}
+
+// tokenTransport adds a bearer token to every request.
+type tokenTransport struct {
+	token string
+	base  http.RoundTripper
+}
+
+func (t *tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A RoundTripper must not modify the request it was given
+	authorized := req.Clone(req.Context())
+	authorized.Header.Set("Authorization", "Bearer "+t.token)
+	return t.base.RoundTrip(authorized)
+}
@@ -0,0 +1,169 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/k8s/k8stest"
+)
+
+func TestParseCredentialsToken(t *testing.T) {
+	server := k8stest.NewServer()
+	defer server.Close()
+
+	cluster, err := ParseCredentials(server.Credentials())
+	require.NoError(t, err)
+	assert.Equal(t, server.URL, cluster.Server)
+	assert.Equal(t, k8stest.ExampleToken, cluster.Token)
+	assert.Equal(t, server.CertificateAuthority(), string(cluster.CertificateAuthority))
+	assert.Nil(t, cluster.ClientCertificate)
+}
+
+func TestParseCredentialsKubeconfig(t *testing.T) {
+	server := k8stest.NewServer()
+	defer server.Close()
+
+	credentials, err := jsoniter.Marshal(&Credentials{Kubeconfig: server.Kubeconfig()})
+	require.NoError(t, err)
+	cluster, err := ParseCredentials(credentials)
+	require.NoError(t, err)
+	assert.Equal(t, server.URL, cluster.Server)
+	assert.Equal(t, k8stest.ExampleToken, cluster.Token)
+	assert.Equal(t, server.CertificateAuthority(), string(cluster.CertificateAuthority))
+}
+
+func TestParseKubeconfigClientCertificate(t *testing.T) {
+	certificate, key := testClientCertificate(t)
+	cluster, err := parseKubeconfig([]byte(`
+current-context: admin
+clusters:
+- name: example
+  cluster:
+    server: https://10.0.0.1:6443/
+contexts:
+- name: other
+  context: {cluster: missing, user: missing}
+- name: admin
+  context: {cluster: example, user: admin}
+users:
+- name: admin
+  user:
+    client-certificate-data: ` + base64.StdEncoding.EncodeToString(certificate) + `
+    client-key-data: ` + base64.StdEncoding.EncodeToString(key) + `
+`))
+	require.NoError(t, err)
+	assert.Equal(t, "https://10.0.0.1:6443/", cluster.Server)
+	assert.Empty(t, cluster.Token)
+	require.NotNil(t, cluster.ClientCertificate)
+	assert.Len(t, cluster.ClientCertificate.Certificate, 1)
+}
+
+func TestParseCredentialsInvalid(t *testing.T) {
+	server := k8stest.NewServer()
+	defer server.Close()
+	kubeconfig := server.Kubeconfig()
+
+	for name, credentials := range map[string]*Credentials{
+		"no token":           {Server: server.URL},
+		"no server":          {Token: k8stest.ExampleToken},
+		"http":               {Server: "http://10.0.0.1:6443", Token: k8stest.ExampleToken},
+		"invalid ca":         {Server: server.URL, Token: k8stest.ExampleToken, CertificateAuthority: "not a certificate"},
+		"invalid yaml":       {Kubeconfig: "clusters: {"},
+		"missing context":    {Kubeconfig: strings.Replace(kubeconfig, "current-context: example", "current-context: other", 1)},
+		"missing cluster":    {Kubeconfig: strings.Replace(kubeconfig, "cluster: example-cluster", "cluster: other", 1)},
+		"missing user":       {Kubeconfig: strings.Replace(kubeconfig, "user: panther-audit", "user: other", 1)},
+		"exec plugin":        {Kubeconfig: strings.Replace(kubeconfig, "    token:", "    exec: {command: aws}\n    token:", 1)},
+		"token file":         {Kubeconfig: strings.Replace(kubeconfig, "    token:", "    tokenFile: /var/run/token\n    token:", 1)},
+		"insecure":           {Kubeconfig: strings.Replace(kubeconfig, "    server:", "    insecure-skip-tls-verify: true\n    server:", 1)},
+		"no user credential": {Kubeconfig: strings.Replace(kubeconfig, "    token: "+k8stest.ExampleToken, "    username: admin", 1)},
+	} {
+		data, err := jsoniter.Marshal(credentials)
+		require.NoError(t, err)
+		cluster, err := ParseCredentials(data)
+		assert.Error(t, err, name)
+		assert.Nil(t, cluster, name)
+	}
+
+	cluster, err := ParseCredentials([]byte("not json"))
+	assert.Error(t, err)
+	assert.Nil(t, cluster)
+}
+
+func TestClientRejected(t *testing.T) {
+	server := k8stest.NewExampleServer()
+	defer server.Close()
+
+	// The API server rejects other tokens
+	cluster, err := ParseCredentials(server.Credentials())
+	require.NoError(t, err)
+	cluster.Token = "other-token"
+	err = CheckCluster(NewClient(cluster), cluster.Server)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "401")
+
+	// And the client only trusts the certificate authority of the cluster
+	cluster, err = ParseCredentials(server.Credentials())
+	require.NoError(t, err)
+	cluster.CertificateAuthority = nil
+	err = CheckCluster(NewClient(cluster), cluster.Server)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "certificate")
+}
+
+func TestCheckCluster(t *testing.T) {
+	server, _ := setupExampleServer(t)
+	input := testPollerInput(t)
+
+	require.NoError(t, CheckCluster(input.Client, input.Server))
+	assert.Equal(t, []string{"GET /api/v1/namespaces"}, server.Requests)
+}
+
+// testClientCertificate returns a self signed client certificate and its key, PEM encoded
+func testClientCertificate(t *testing.T) (certificate, key []byte) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "admin", Organization: []string{"system:masters"}},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(privateKey)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
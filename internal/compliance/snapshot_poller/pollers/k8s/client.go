@@ -0,0 +1,186 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+
+	sourcemodels "github.com/panther-labs/panther/api/lambda/source/models"
+)
+
+const (
+	// How long an authenticated client is reused before the credentials are read again, so rotated
+	// tokens are picked up
+	clientCacheDuration = time.Hour
+)
+
+var (
+	httpClient = &http.Client{Timeout: time.Minute}
+
+	secretsClient secretsmanageriface.SecretsManagerAPI = secretsmanager.New(session.Must(session.NewSession()))
+
+	clientCache   = make(map[string]cachedClient)
+	clientCacheMu sync.Mutex
+)
+
+type cachedClient struct {
+	client  *http.Client
+	server  string
+	expires time.Time
+}
+
+// getClient returns an http client authenticated with the credentials of an integration, and the URL
+// of the cluster's API server.
+func getClient(integrationID string) (*http.Client, string, error) {
+	clientCacheMu.Lock()
+	defer clientCacheMu.Unlock()
+
+	if cached, ok := clientCache[integrationID]; ok && time.Now().Before(cached.expires) {
+		return cached.client, cached.server, nil
+	}
+
+	data, err := getCredentials(integrationID)
+	if err != nil {
+		return nil, "", err
+	}
+	cluster, err := ParseCredentials(data)
+	if err != nil {
+		return nil, "", err
+	}
+	client := NewClient(cluster)
+	clientCache[integrationID] = cachedClient{
+		client:  client,
+		server:  cluster.Server,
+		expires: time.Now().Add(clientCacheDuration),
+	}
+	return client, cluster.Server, nil
+}
+
+// getCredentials reads the cluster credentials the source-api stored for an integration.
+//
+// Replaced by unit tests
+var getCredentials = func(integrationID string) ([]byte, error) {
+	output, err := secretsClient.GetSecretValue(&secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(sourcemodels.K8sCredentialsSecretName(integrationID)),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read the cluster credentials of integration %s", integrationID)
+	}
+	return []byte(aws.StringValue(output.SecretString)), nil
+}
+
+// APIError is the Status object returned by the Kubernetes API server when a request fails.
+type APIError struct {
+	StatusCode int
+	Reason     string
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("kubernetes api error %d %s: %s", e.StatusCode, e.Reason, e.Message)
+}
+
+// IsRateLimited is true when the API server rejected a request because of API priority and fairness
+// or a max in flight limit.
+func IsRateLimited(err error) bool {
+	apiErr, ok := errors.Cause(err).(*APIError)
+	return ok && apiErr.StatusCode == http.StatusTooManyRequests
+}
+
+// IsNotFound is true when the requested object does not exist.
+func IsNotFound(err error) bool {
+	apiErr, ok := errors.Cause(err).(*APIError)
+	return ok && apiErr.StatusCode == http.StatusNotFound
+}
+
+// IsExpired is true when a list continue token is too old, and the list has to be started again.
+func IsExpired(err error) bool {
+	apiErr, ok := errors.Cause(err).(*APIError)
+	return ok && apiErr.StatusCode == http.StatusGone
+}
+
+// getJSON decodes the response of a GET request into out.
+func getJSON(client *http.Client, requestURL string, out interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, requestURL, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to build request for %s", requestURL)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "GET %s failed", requestURL)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var status struct {
+			Reason  string `json:"reason"`
+			Message string `json:"message"`
+		}
+		// The body is best effort, the status code is enough to classify the error
+		_ = jsoniter.NewDecoder(resp.Body).Decode(&status)
+		return errors.Wrapf(&APIError{
+			StatusCode: resp.StatusCode,
+			Reason:     status.Reason,
+			Message:    status.Message,
+		}, "GET %s failed", requestURL)
+	}
+
+	if err = jsoniter.ConfigCompatibleWithStandardLibrary.NewDecoder(resp.Body).Decode(out); err != nil {
+		return errors.Wrapf(err, "failed to decode response of %s", requestURL)
+	}
+	return nil
+}
+
+// listPage is one page of a list request.
+type listPage struct {
+	Metadata struct {
+		Continue *string `json:"continue"`
+	} `json:"metadata"`
+	Items []jsoniter.RawMessage `json:"items"`
+}
+
+// listURL adds the page size and continue token parameters of the list APIs to a URL.
+func listURL(base string, limit int, continueToken *string) string {
+	params := url.Values{"limit": {fmt.Sprint(limit)}}
+	if continueToken != nil {
+		params.Set("continue", *continueToken)
+	}
+	return base + "?" + params.Encode()
+}
+
+// nextPage returns the continue token of the next page, or nil on the last page.
+func nextPage(token *string) *string {
+	if token == nil || *token == "" {
+		return nil
+	}
+	return token
+}
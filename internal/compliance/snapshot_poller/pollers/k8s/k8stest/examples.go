@@ -0,0 +1,362 @@
+package k8stest
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import "net/http"
+
+// Example responses of the Kubernetes API for one cluster, based on the API reference
+const (
+	ExampleNamespaces = `{
+  "kind": "NamespaceList",
+  "apiVersion": "v1",
+  "metadata": {"resourceVersion": "1001"},
+  "items": [
+    {
+      "metadata": {
+        "name": "default",
+        "uid": "8f3c2f5e-6a3d-4bd5-9c1e-0e0a4b8d5f01",
+        "resourceVersion": "192",
+        "creationTimestamp": "2020-06-01T17:20:00Z",
+        "labels": {"kubernetes.io/metadata.name": "default"}
+      },
+      "spec": {"finalizers": ["kubernetes"]},
+      "status": {"phase": "Active"}
+    },
+    {
+      "metadata": {
+        "name": "kube-system",
+        "uid": "2a6b8c1d-7e4f-4a3b-8d2c-1f0e9d8c7b02",
+        "resourceVersion": "14",
+        "creationTimestamp": "2020-06-01T17:19:58Z",
+        "labels": {
+          "kubernetes.io/metadata.name": "kube-system",
+          "pod-security.kubernetes.io/enforce": "privileged"
+        }
+      },
+      "spec": {"finalizers": ["kubernetes"]},
+      "status": {"phase": "Active"}
+    }
+  ]
+}`
+
+	ExampleNetworkPolicies = `{
+  "kind": "NetworkPolicyList",
+  "apiVersion": "networking.k8s.io/v1",
+  "metadata": {"resourceVersion": "1001"},
+  "items": [
+    {
+      "metadata": {
+        "name": "web-ingress",
+        "namespace": "default",
+        "uid": "5d2e1f0a-3b4c-4d5e-8f6a-7b8c9d0e1f03",
+        "creationTimestamp": "2020-06-02T10:00:00Z"
+      },
+      "spec": {
+        "podSelector": {"matchLabels": {"app": "web"}},
+        "policyTypes": ["Ingress", "Egress"],
+        "ingress": [
+          {
+            "from": [
+              {"ipBlock": {"cidr": "10.0.0.0/8", "except": ["10.1.0.0/16"]}},
+              {"namespaceSelector": {"matchLabels": {"team": "frontend"}}}
+            ],
+            "ports": [{"protocol": "TCP", "port": 8080}, {"protocol": "TCP", "port": "metrics"}]
+          }
+        ],
+        "egress": [{"to": [{"podSelector": {}}]}]
+      }
+    }
+  ]
+}`
+
+	ExampleRoles = `{
+  "kind": "RoleList",
+  "apiVersion": "rbac.authorization.k8s.io/v1",
+  "metadata": {"resourceVersion": "1001"},
+  "items": [
+    {
+      "metadata": {
+        "name": "pod-reader",
+        "namespace": "default",
+        "uid": "0c1d2e3f-4a5b-4c6d-8e7f-9a0b1c2d3e04",
+        "creationTimestamp": "2020-06-02T11:00:00Z"
+      },
+      "rules": [{"apiGroups": [""], "resources": ["pods", "pods/log"], "verbs": ["get", "watch", "list"]}]
+    }
+  ]
+}`
+
+	ExampleClusterRoles = `{
+  "kind": "ClusterRoleList",
+  "apiVersion": "rbac.authorization.k8s.io/v1",
+  "metadata": {"resourceVersion": "1001"},
+  "items": [
+    {
+      "metadata": {
+        "name": "cluster-admin",
+        "uid": "1e2f3a4b-5c6d-4e7f-8a9b-0c1d2e3f4a05",
+        "creationTimestamp": "2020-06-01T17:19:59Z",
+        "labels": {"kubernetes.io/bootstrapping": "rbac-defaults"},
+        "annotations": {"rbac.authorization.kubernetes.io/autoupdate": "true"}
+      },
+      "rules": [
+        {"apiGroups": ["*"], "resources": ["*"], "verbs": ["*"]},
+        {"nonResourceURLs": ["*"], "verbs": ["*"]}
+      ]
+    },
+    {
+      "metadata": {
+        "name": "monitoring",
+        "uid": "3a4b5c6d-7e8f-4a9b-8c0d-1e2f3a4b5c06",
+        "creationTimestamp": "2020-06-03T09:00:00Z"
+      },
+      "aggregationRule": {"clusterRoleSelectors": [{"matchLabels": {"rbac.example.com/aggregate-to-monitoring": "true"}}]},
+      "rules": []
+    }
+  ]
+}`
+
+	ExampleRoleBindings = `{
+  "kind": "RoleBindingList",
+  "apiVersion": "rbac.authorization.k8s.io/v1",
+  "metadata": {"resourceVersion": "1001"},
+  "items": [
+    {
+      "metadata": {
+        "name": "read-pods",
+        "namespace": "default",
+        "uid": "4b5c6d7e-8f9a-4b0c-8d1e-2f3a4b5c6d07",
+        "creationTimestamp": "2020-06-02T11:05:00Z"
+      },
+      "subjects": [
+        {"kind": "User", "apiGroup": "rbac.authorization.k8s.io", "name": "jane"},
+        {"kind": "ServiceAccount", "name": "web", "namespace": "default"}
+      ],
+      "roleRef": {"apiGroup": "rbac.authorization.k8s.io", "kind": "Role", "name": "pod-reader"}
+    }
+  ]
+}`
+
+	ExampleClusterRoleBindings = `{
+  "kind": "ClusterRoleBindingList",
+  "apiVersion": "rbac.authorization.k8s.io/v1",
+  "metadata": {"resourceVersion": "1001"},
+  "items": [
+    {
+      "metadata": {
+        "name": "cluster-admin",
+        "uid": "5c6d7e8f-9a0b-4c1d-8e2f-3a4b5c6d7e08",
+        "creationTimestamp": "2020-06-01T17:19:59Z",
+        "labels": {"kubernetes.io/bootstrapping": "rbac-defaults"}
+      },
+      "subjects": [{"kind": "Group", "apiGroup": "rbac.authorization.k8s.io", "name": "system:masters"}],
+      "roleRef": {"apiGroup": "rbac.authorization.k8s.io", "kind": "ClusterRole", "name": "cluster-admin"}
+    }
+  ]
+}`
+
+	ExamplePods = `{
+  "kind": "PodList",
+  "apiVersion": "v1",
+  "metadata": {"resourceVersion": "1001"},
+  "items": [
+    {
+      "metadata": {
+        "name": "web-5d8f6c7b9-x2k4p",
+        "namespace": "default",
+        "uid": "6d7e8f9a-0b1c-4d2e-8f3a-4b5c6d7e8f09",
+        "creationTimestamp": "2020-06-02T12:00:00Z",
+        "labels": {"app": "web", "pod-template-hash": "5d8f6c7b9"},
+        "ownerReferences": [{"apiVersion": "apps/v1", "kind": "ReplicaSet", "name": "web-5d8f6c7b9", "controller": true}]
+      },
+      "spec": {
+        "serviceAccountName": "web",
+        "nodeName": "node-1",
+        "securityContext": {"runAsNonRoot": true, "runAsUser": 1000, "fsGroup": 2000},
+        "containers": [
+          {
+            "name": "web",
+            "image": "nginx:1.19",
+            "imagePullPolicy": "IfNotPresent",
+            "env": [{"name": "DB_PASSWORD", "value": "hunter2"}],
+            "ports": [{"containerPort": 8080, "protocol": "TCP"}],
+            "securityContext": {
+              "allowPrivilegeEscalation": false,
+              "readOnlyRootFilesystem": true,
+              "capabilities": {"drop": ["ALL"]}
+            }
+          }
+        ],
+        "volumes": [{"name": "tls", "secret": {"secretName": "web-tls"}}]
+      },
+      "status": {"phase": "Running", "hostIP": "10.0.1.10", "podIP": "10.244.1.5", "qosClass": "BestEffort"}
+    },
+    {
+      "metadata": {
+        "name": "kube-proxy-7xq9z",
+        "namespace": "kube-system",
+        "uid": "7e8f9a0b-1c2d-4e3f-8a4b-5c6d7e8f9a10",
+        "creationTimestamp": "2020-06-01T17:21:00Z",
+        "labels": {"k8s-app": "kube-proxy"}
+      },
+      "spec": {
+        "serviceAccountName": "kube-proxy",
+        "nodeName": "node-1",
+        "hostNetwork": true,
+        "initContainers": [
+          {"name": "init-modules", "image": "busybox:1.32", "securityContext": {"privileged": true}}
+        ],
+        "containers": [
+          {
+            "name": "kube-proxy",
+            "image": "k8s.gcr.io/kube-proxy:v1.18.3",
+            "ports": [{"name": "metrics", "containerPort": 10249, "hostPort": 10249, "protocol": "TCP"}],
+            "securityContext": {"privileged": true}
+          }
+        ],
+        "volumes": [
+          {"name": "lib-modules", "hostPath": {"path": "/lib/modules", "type": ""}},
+          {"name": "xtables-lock", "hostPath": {"path": "/run/xtables.lock", "type": "FileOrCreate"}}
+        ]
+      },
+      "status": {"phase": "Running", "hostIP": "10.0.1.10", "podIP": "10.0.1.10", "qosClass": "BestEffort"}
+    }
+  ]
+}`
+
+	ExampleDeployments = `{
+  "kind": "DeploymentList",
+  "apiVersion": "apps/v1",
+  "metadata": {"resourceVersion": "1001"},
+  "items": [
+    {
+      "metadata": {
+        "name": "web",
+        "namespace": "default",
+        "uid": "8f9a0b1c-2d3e-4f4a-8b5c-6d7e8f9a0b11",
+        "creationTimestamp": "2020-06-02T11:59:00Z",
+        "labels": {"app": "web"}
+      },
+      "spec": {
+        "replicas": 3,
+        "selector": {"matchLabels": {"app": "web"}},
+        "template": {
+          "metadata": {"labels": {"app": "web"}},
+          "spec": {
+            "serviceAccountName": "web",
+            "hostPID": true,
+            "containers": [{"name": "web", "image": "nginx:1.19"}],
+            "volumes": [{"name": "docker", "hostPath": {"path": "/var/run/docker.sock", "type": "Socket"}}]
+          }
+        }
+      },
+      "status": {"replicas": 3, "readyReplicas": 3}
+    }
+  ]
+}`
+
+	ExampleValidatingWebhookConfigurations = `{
+  "kind": "ValidatingWebhookConfigurationList",
+  "apiVersion": "admissionregistration.k8s.io/v1",
+  "metadata": {"resourceVersion": "1001"},
+  "items": [
+    {
+      "metadata": {
+        "name": "policy-controller",
+        "uid": "9a0b1c2d-3e4f-4a5b-8c6d-7e8f9a0b1c12",
+        "creationTimestamp": "2020-06-04T08:00:00Z"
+      },
+      "webhooks": [
+        {
+          "name": "validate.policy.example.com",
+          "clientConfig": {
+            "service": {"namespace": "policy", "name": "policy-webhook", "path": "/validate", "port": 443},
+            "caBundle": "LS0tLS1CRUdJTiBDRVJUSUZJQ0FURS0tLS0tCg=="
+          },
+          "rules": [
+            {"operations": ["CREATE", "UPDATE"], "apiGroups": [""], "apiVersions": ["v1"], "resources": ["pods"], "scope": "*"}
+          ],
+          "failurePolicy": "Ignore",
+          "matchPolicy": "Equivalent",
+          "namespaceSelector": {"matchExpressions": [{"key": "policy", "operator": "NotIn", "values": ["disabled"]}]},
+          "objectSelector": {},
+          "sideEffects": "None",
+          "timeoutSeconds": 10,
+          "admissionReviewVersions": ["v1"]
+        }
+      ]
+    }
+  ]
+}`
+
+	ExampleMutatingWebhookConfigurations = `{
+  "kind": "MutatingWebhookConfigurationList",
+  "apiVersion": "admissionregistration.k8s.io/v1",
+  "metadata": {"resourceVersion": "1001"},
+  "items": [
+    {
+      "metadata": {
+        "name": "sidecar-injector",
+        "uid": "0b1c2d3e-4f5a-4b6c-8d7e-8f9a0b1c2d13",
+        "creationTimestamp": "2020-06-04T09:00:00Z"
+      },
+      "webhooks": [
+        {
+          "name": "inject.sidecar.example.com",
+          "clientConfig": {"url": "https://injector.example.com/mutate"},
+          "rules": [
+            {"operations": ["CREATE"], "apiGroups": [""], "apiVersions": ["v1"], "resources": ["pods"], "scope": "Namespaced"}
+          ],
+          "failurePolicy": "Fail",
+          "sideEffects": "None",
+          "timeoutSeconds": 30,
+          "admissionReviewVersions": ["v1", "v1beta1"],
+          "reinvocationPolicy": "IfNeeded"
+        }
+      ]
+    }
+  ]
+}`
+
+	emptyNetworkPolicies = `{"kind": "NetworkPolicyList", "apiVersion": "networking.k8s.io/v1", "metadata": {}, "items": []}`
+)
+
+// NewExampleServer starts a fake API server which responds with the example responses.
+func NewExampleServer() *Server {
+	s := NewServer()
+	s.HandleList("/api/v1/namespaces", ExampleNamespaces)
+	s.HandleList("/api/v1/pods", ExamplePods)
+	s.HandleList("/apis/apps/v1/deployments", ExampleDeployments)
+	s.HandleList("/apis/rbac.authorization.k8s.io/v1/roles", ExampleRoles)
+	s.HandleList("/apis/rbac.authorization.k8s.io/v1/clusterroles", ExampleClusterRoles)
+	s.HandleList("/apis/rbac.authorization.k8s.io/v1/rolebindings", ExampleRoleBindings)
+	s.HandleList("/apis/rbac.authorization.k8s.io/v1/clusterrolebindings", ExampleClusterRoleBindings)
+	s.HandleList("/apis/networking.k8s.io/v1/networkpolicies", ExampleNetworkPolicies)
+	s.HandleList("/apis/admissionregistration.k8s.io/v1/validatingwebhookconfigurations",
+		ExampleValidatingWebhookConfigurations)
+	s.HandleList("/apis/admissionregistration.k8s.io/v1/mutatingwebhookconfigurations",
+		ExampleMutatingWebhookConfigurations)
+
+	// The network policies of each namespace
+	s.Handle(http.MethodGet, "/apis/networking.k8s.io/v1/namespaces/default/networkpolicies",
+		http.StatusOK, ExampleNetworkPolicies)
+	s.Handle(http.MethodGet, "/apis/networking.k8s.io/v1/namespaces/kube-system/networkpolicies",
+		http.StatusOK, emptyNetworkPolicies)
+	return s
+}
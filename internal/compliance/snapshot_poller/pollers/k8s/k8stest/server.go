@@ -0,0 +1,137 @@
+package k8stest
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/apitest"
+)
+
+const (
+	// ExampleToken is the service account token the fake API server accepts
+	ExampleToken = "eyJhbGciOiJSUzI1NiJ9.example-service-account-token"
+)
+
+// Server is a fake Kubernetes API server, which only answers requests authorized with ExampleToken.
+type Server struct {
+	*httptest.Server
+	*apitest.Router
+}
+
+// NewServer starts a fake API server without any API responses, see NewExampleServer.
+func NewServer() *Server {
+	s := &Server{Router: apitest.NewRouter(ExampleToken, writeError)}
+	s.Server = httptest.NewTLSServer(s.Router)
+	return s
+}
+
+// HandleList responds to a list request with a body, and to a get request of each listed object
+// with the object, as the API server does.
+func (s *Server) HandleList(path string, body string) {
+	s.Handle(http.MethodGet, path, http.StatusOK, body)
+
+	var list struct {
+		Items []jsoniter.RawMessage `json:"items"`
+	}
+	if err := jsoniter.UnmarshalFromString(body, &list); err != nil {
+		panic(err)
+	}
+	apiPath, plural := path[:strings.LastIndex(path, "/")], path[strings.LastIndex(path, "/")+1:]
+	for _, item := range list.Items {
+		var object struct {
+			Metadata struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+			} `json:"metadata"`
+		}
+		if err := jsoniter.Unmarshal(item, &object); err != nil {
+			panic(err)
+		}
+		objectPath := apiPath
+		if object.Metadata.Namespace != "" {
+			objectPath += "/namespaces/" + object.Metadata.Namespace
+		}
+		objectPath += "/" + plural + "/" + object.Metadata.Name
+		s.Handle(http.MethodGet, objectPath, http.StatusOK, string(item))
+	}
+}
+
+// CertificateAuthority returns the PEM encoded certificate of the server, which is self signed.
+func (s *Server) CertificateAuthority() string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.Certificate().Raw}))
+}
+
+// Credentials returns the credentials of an integration connecting with a service account token.
+func (s *Server) Credentials() []byte {
+	credentials, err := jsoniter.Marshal(map[string]string{
+		"server":               s.URL,
+		"token":                ExampleToken,
+		"certificateAuthority": s.CertificateAuthority(),
+	})
+	if err != nil {
+		panic(err)
+	}
+	return credentials
+}
+
+// Kubeconfig returns a kubeconfig with the token and certificate of the server embedded.
+func (s *Server) Kubeconfig() string {
+	return fmt.Sprintf(`apiVersion: v1
+kind: Config
+current-context: example
+clusters:
+- name: example-cluster
+  cluster:
+    server: %s
+    certificate-authority-data: %s
+contexts:
+- name: example
+  context:
+    cluster: example-cluster
+    user: panther-audit
+users:
+- name: panther-audit
+  user:
+    token: %s
+`, s.URL, base64.StdEncoding.EncodeToString([]byte(s.CertificateAuthority())), ExampleToken)
+}
+
+func writeError(w http.ResponseWriter, code int, path string) {
+	if code == http.StatusUnauthorized {
+		WriteStatus(w, code, "Unauthorized", "Unauthorized")
+		return
+	}
+	WriteStatus(w, code, "NotFound", fmt.Sprintf("the server could not find the requested resource %s", path))
+}
+
+// WriteStatus writes the Status object the API server returns for failed requests.
+func WriteStatus(w http.ResponseWriter, code int, reason, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, _ = fmt.Fprintf(w, `{"kind": "Status", "apiVersion": "v1", "metadata": {}, "status": "Failure", `+
+		`"message": %q, "reason": %q, "code": %d}`, message, reason, code)
+}
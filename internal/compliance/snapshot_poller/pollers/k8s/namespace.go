@@ -0,0 +1,82 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	k8smodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/k8s"
+)
+
+var namespaceKind = &resourceKind{
+	schema:      k8smodels.NamespaceSchema,
+	apiPath:     "/api/v1",
+	plural:      "namespaces",
+	newResource: func() k8sResource { return &k8smodels.Namespace{} },
+	enrich:      countNetworkPolicies,
+}
+
+// PollNamespace polls a single namespace by its resource ID
+func PollNamespace(pollerInput *k8smodels.ResourcePollerInput, resourceID string) (interface{}, error) {
+	return namespaceKind.get(pollerInput, resourceID)
+}
+
+// PollNamespaces gathers information on each namespace in a cluster
+func PollNamespaces(pollerInput *k8smodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	return namespaceKind.list(pollerInput)
+}
+
+// CheckCluster verifies a client can list the namespaces of a cluster. It is used by the source-api to
+// check the credentials of an integration.
+func CheckCluster(client *http.Client, server string) error {
+	return getJSON(client, listURL(server+namespaceKind.apiPath+"/"+namespaceKind.plural, 1, nil), &listPage{})
+}
+
+// countNetworkPolicies adds the number of network policies in a namespace, so policies can find
+// namespaces where all traffic is allowed
+func countNetworkPolicies(pollerInput *k8smodels.ResourcePollerInput, resource k8sResource) error {
+	namespace := resource.(*k8smodels.Namespace)
+	base := pollerInput.Server + networkPolicyKind.apiPath + "/namespaces/" +
+		url.PathEscape(aws.StringValue(namespace.Name)) + "/" + networkPolicyKind.plural
+
+	var count int64
+	var continueToken *string
+	for {
+		page := &listPage{}
+		if err := getJSON(pollerInput.Client, listURL(base, defaultBatchSize, continueToken), page); err != nil {
+			if IsRateLimited(err) {
+				return err
+			}
+			// The rest of the namespace is still worth reporting
+			zap.L().Warn("failed to list namespace network policies",
+				zap.String("namespace", *namespace.ResourceID), zap.Error(err))
+			return nil
+		}
+		count += int64(len(page.Items))
+		if continueToken = nextPage(page.Metadata.Continue); continueToken == nil {
+			namespace.NetworkPolicyCount = aws.Int64(count)
+			return nil
+		}
+	}
+}
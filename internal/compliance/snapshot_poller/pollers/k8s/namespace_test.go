@@ -0,0 +1,99 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	k8smodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/k8s"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/k8s/k8stest"
+)
+
+func TestPollNamespaces(t *testing.T) {
+	server, _ := setupExampleServer(t)
+
+	resources, marker, err := PollNamespaces(testPollerInput(t))
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	require.Len(t, resources, 2)
+	assert.Contains(t, server.Requests, "GET /apis/networking.k8s.io/v1/namespaces/kube-system/networkpolicies")
+
+	namespace := resources[0].Attributes.(*k8smodels.Namespace)
+	assert.Equal(t, testResourceID("/api/v1/namespaces/default"), resources[0].ID)
+	assert.Equal(t, k8smodels.NamespaceSchema, resources[0].Type)
+	assert.Equal(t, "default", *namespace.Name)
+	assert.Nil(t, namespace.Namespace)
+	assert.Equal(t, k8smodels.GlobalRegion, *namespace.Region)
+	assert.Equal(t, "8f3c2f5e-6a3d-4bd5-9c1e-0e0a4b8d5f01", *namespace.UID)
+	assert.Equal(t, time.Date(2020, 6, 1, 17, 20, 0, 0, time.UTC), *namespace.TimeCreated)
+	assert.Equal(t, "Active", *namespace.Status.Phase)
+	assert.Equal(t, int64(1), *namespace.NetworkPolicyCount)
+
+	namespace = resources[1].Attributes.(*k8smodels.Namespace)
+	assert.Equal(t, "privileged", *namespace.Labels["pod-security.kubernetes.io/enforce"])
+	assert.Equal(t, int64(0), *namespace.NetworkPolicyCount)
+}
+
+func TestPollNamespacesNetworkPolicyError(t *testing.T) {
+	server, _ := setupExampleServer(t)
+	server.HandleFunc(http.MethodGet, "/apis/networking.k8s.io/v1/namespaces/default/networkpolicies",
+		func(w http.ResponseWriter, r *http.Request) {
+			k8stest.WriteStatus(w, http.StatusForbidden, "Forbidden", "networkpolicies is forbidden")
+		})
+
+	resources, _, err := PollNamespaces(testPollerInput(t))
+	require.NoError(t, err)
+	require.Len(t, resources, 2)
+	assert.Nil(t, resources[0].Attributes.(*k8smodels.Namespace).NetworkPolicyCount)
+	assert.NotNil(t, resources[1].Attributes.(*k8smodels.Namespace).NetworkPolicyCount)
+}
+
+func TestPollNamespacesNetworkPolicyRateLimited(t *testing.T) {
+	server, _ := setupExampleServer(t)
+	server.HandleFunc(http.MethodGet, "/apis/networking.k8s.io/v1/namespaces/default/networkpolicies",
+		func(w http.ResponseWriter, r *http.Request) {
+			k8stest.WriteStatus(w, http.StatusTooManyRequests, "TooManyRequests", "too many requests")
+		})
+
+	resources, marker, err := PollNamespaces(testPollerInput(t))
+	require.Error(t, err)
+	assert.True(t, IsRateLimited(err))
+	assert.Nil(t, marker)
+	assert.Nil(t, resources)
+}
+
+func TestPollNamespace(t *testing.T) {
+	setupExampleServer(t)
+
+	resource, err := PollNamespace(testPollerInput(t), testResourceID("/api/v1/namespaces/kube-system"))
+	require.NoError(t, err)
+	namespace := resource.(*k8smodels.Namespace)
+	assert.Equal(t, testResourceID("/api/v1/namespaces/kube-system"), *namespace.ResourceID)
+	assert.Equal(t, aws.String("kube-system"), namespace.Name)
+
+	resource, err = PollNamespace(testPollerInput(t), testResourceID("/api/v1/namespaces/deleted"))
+	require.NoError(t, err)
+	assert.Nil(t, resource)
+}
@@ -0,0 +1,42 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	k8smodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/k8s"
+)
+
+var networkPolicyKind = &resourceKind{
+	schema:      k8smodels.NetworkPolicySchema,
+	apiPath:     "/apis/networking.k8s.io/v1",
+	plural:      "networkpolicies",
+	namespaced:  true,
+	newResource: func() k8sResource { return &k8smodels.NetworkPolicy{} },
+}
+
+// PollNetworkPolicy polls a single network policy by its resource ID
+func PollNetworkPolicy(pollerInput *k8smodels.ResourcePollerInput, resourceID string) (interface{}, error) {
+	return networkPolicyKind.get(pollerInput, resourceID)
+}
+
+// PollNetworkPolicies gathers information on each network policy in a cluster
+func PollNetworkPolicies(pollerInput *k8smodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	return networkPolicyKind.list(pollerInput)
+}
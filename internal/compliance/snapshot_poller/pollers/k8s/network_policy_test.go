@@ -0,0 +1,68 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	k8smodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/k8s"
+)
+
+func TestPollNetworkPolicies(t *testing.T) {
+	setupExampleServer(t)
+
+	resources, marker, err := PollNetworkPolicies(testPollerInput(t))
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	require.Len(t, resources, 1)
+
+	policy := resources[0].Attributes.(*k8smodels.NetworkPolicy)
+	assert.Equal(t, testResourceID("/apis/networking.k8s.io/v1/namespaces/default/networkpolicies/web-ingress"), resources[0].ID)
+	assert.Equal(t, k8smodels.NetworkPolicySchema, resources[0].Type)
+	assert.Equal(t, "web", *policy.Spec.PodSelector.MatchLabels["app"])
+	assert.Equal(t, aws.StringSlice([]string{"Ingress", "Egress"}), policy.Spec.PolicyTypes)
+	require.Len(t, policy.Spec.Ingress, 1)
+	ingress := policy.Spec.Ingress[0]
+	assert.Equal(t, "10.0.0.0/8", *ingress.From[0].IPBlock.CIDR)
+	assert.Equal(t, []*string{aws.String("10.1.0.0/16")}, ingress.From[0].IPBlock.Except)
+	assert.Equal(t, "frontend", *ingress.From[1].NamespaceSelector.MatchLabels["team"])
+	// Ports are numbers or names
+	assert.Equal(t, float64(8080), ingress.Ports[0].Port)
+	assert.Equal(t, "metrics", ingress.Ports[1].Port)
+	require.Len(t, policy.Spec.Egress, 1)
+	assert.NotNil(t, policy.Spec.Egress[0].To[0].PodSelector)
+}
+
+func TestPollNetworkPolicy(t *testing.T) {
+	setupExampleServer(t)
+
+	resourceID := testResourceID("/apis/networking.k8s.io/v1/namespaces/default/networkpolicies/web-ingress")
+	resource, err := PollNetworkPolicy(testPollerInput(t), resourceID)
+	require.NoError(t, err)
+	assert.Equal(t, resourceID, *resource.(*k8smodels.NetworkPolicy).ResourceID)
+
+	resource, err = PollNetworkPolicy(testPollerInput(t),
+		testResourceID("/apis/networking.k8s.io/v1/namespaces/default/networkpolicies/deleted"))
+	require.NoError(t, err)
+	assert.Nil(t, resource)
+}
@@ -0,0 +1,265 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	resourcesapimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	k8smodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/k8s"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// resourcePoller is a simple struct to be used only for invoking the ResourcePollers in order.
+type resourcePoller struct {
+	description    string
+	resourcePoller k8smodels.ResourcePoller
+}
+
+const (
+	integrationType = "k8s"
+	// How long to wait before re-scanning a resource type the API server throttled
+	rateLimitDelay = time.Minute * 5
+)
+
+var (
+	// The default max number of resources to scan at once. We will keep paging until we scan this
+	// many resources, then do one additional page worth of resources
+	defaultBatchSize   = 100
+	pageRequeueDelayer = rand.New(rand.NewSource(time.Now().UnixNano())) // nolint:gosec
+
+	// Replaced by unit tests
+	requeue = utils.Requeue
+
+	// IndividualResourcePollers maps resource types to the functions polling a single resource by its ID.
+	IndividualResourcePollers = map[string]func(
+		input *k8smodels.ResourcePollerInput, resourceID string) (interface{}, error){
+		k8smodels.ClusterRoleBindingSchema:             PollClusterRoleBinding,
+		k8smodels.ClusterRoleSchema:                    PollClusterRole,
+		k8smodels.DeploymentSchema:                     PollDeployment,
+		k8smodels.MutatingWebhookConfigurationSchema:   PollMutatingWebhookConfiguration,
+		k8smodels.NamespaceSchema:                      PollNamespace,
+		k8smodels.NetworkPolicySchema:                  PollNetworkPolicy,
+		k8smodels.PodSchema:                            PollPod,
+		k8smodels.RoleBindingSchema:                    PollRoleBinding,
+		k8smodels.RoleSchema:                           PollRole,
+		k8smodels.ValidatingWebhookConfigurationSchema: PollValidatingWebhookConfiguration,
+	}
+
+	// ServicePollers maps a resource type to its ResourcePoller, which scans all resources of the
+	// type in one cluster.
+	ServicePollers = map[string]resourcePoller{
+		k8smodels.ClusterRoleBindingSchema:             {"ClusterRoleBinding", PollClusterRoleBindings},
+		k8smodels.ClusterRoleSchema:                    {"ClusterRole", PollClusterRoles},
+		k8smodels.DeploymentSchema:                     {"Deployment", PollDeployments},
+		k8smodels.MutatingWebhookConfigurationSchema:   {"MutatingWebhookConfiguration", PollMutatingWebhookConfigurations},
+		k8smodels.NamespaceSchema:                      {"Namespace", PollNamespaces},
+		k8smodels.NetworkPolicySchema:                  {"NetworkPolicy", PollNetworkPolicies},
+		k8smodels.PodSchema:                            {"Pod", PollPods},
+		k8smodels.RoleBindingSchema:                    {"RoleBinding", PollRoleBindings},
+		k8smodels.RoleSchema:                           {"Role", PollRoles},
+		k8smodels.ValidatingWebhookConfigurationSchema: {"ValidatingWebhookConfiguration", PollValidatingWebhookConfigurations},
+	}
+)
+
+// IsResourceType returns true if a resource type is scanned by the Kubernetes pollers.
+func IsResourceType(resourceType string) bool {
+	_, ok := ServicePollers[resourceType]
+	return ok
+}
+
+// Poll coordinates Kubernetes resource gathering for a k8s-scan integration.
+//
+// Each integration is a single cluster, and a scan of a resource type lists its objects in all
+// namespaces at once.
+func Poll(scanRequest *pollermodels.ScanEntry) (
+	generatedEvents []resourcesapimodels.AddResourceEntry, err error) {
+
+	if scanRequest.IntegrationID == nil {
+		return nil, errors.New("no integration ID provided")
+	}
+
+	// Check if integration is disabled
+	if scanRequest.Enabled != nil && !*scanRequest.Enabled {
+		zap.L().Info("source integration disabled",
+			zap.String("integration id", *scanRequest.IntegrationID))
+		return nil, nil
+	}
+
+	// If a resource ID is not provided, a resource type must be present
+	// This error cannot be retried so we don't return it
+	if scanRequest.ResourceType == nil {
+		zap.L().Error(
+			"Invalid scan request input - resourceType must be specified",
+			zap.Any("input", scanRequest),
+		)
+		return nil, nil
+	}
+
+	// Check if resource type is filtered
+	for _, resourceType := range scanRequest.ResourceTypeIgnoreList {
+		if resourceType == *scanRequest.ResourceType {
+			zap.L().Info("resource type filtered", zap.String("resource type", resourceType))
+			return nil, nil
+		}
+	}
+
+	client, server, err := getClient(*scanRequest.IntegrationID)
+	if err != nil {
+		return nil, err
+	}
+	pollerResourceInput := &k8smodels.ResourcePollerInput{
+		Client:        client,
+		Server:        server,
+		IntegrationID: scanRequest.IntegrationID,
+		// Note: The resources-api expects a time.Time formatted string.
+		Timestamp:               aws.Time(utils.TimeNowFunc()),
+		NextPageToken:           scanRequest.NextPageToken,
+		RegionIgnoreList:        scanRequest.RegionIgnoreList,
+		ResourceTypeIgnoreList:  scanRequest.ResourceTypeIgnoreList,
+		ResourceRegexIgnoreList: scanRequest.ResourceRegexIgnoreList,
+	}
+	if err = pollerResourceInput.CompileRegex(); err != nil {
+		zap.L().Error("unable to compile passed regex",
+			zap.Any("resource regex ignore list", scanRequest.ResourceRegexIgnoreList))
+		return nil, err
+	}
+
+	if scanRequest.ResourceID != nil {
+		zap.L().Debug("processing single resource scan")
+		return singleResourceScan(scanRequest, pollerResourceInput)
+	}
+
+	poller, ok := ServicePollers[*scanRequest.ResourceType]
+	if !ok {
+		return nil, errors.Errorf("invalid cluster resource type '%s' scan requested", *scanRequest.ResourceType)
+	}
+	zap.L().Info("processing cluster service scan",
+		zap.String("integration id", *scanRequest.IntegrationID),
+		zap.String("resourceType", *scanRequest.ResourceType))
+	return serviceScan(poller, pollerResourceInput, scanRequest)
+}
+
+func serviceScan(
+	poller resourcePoller,
+	pollerInput *k8smodels.ResourcePollerInput,
+	scanRequest *pollermodels.ScanEntry,
+) (generatedEvents []resourcesapimodels.AddResourceEntry, err error) {
+
+	var marker *string
+	generatedEvents, marker, err = poller.resourcePoller(pollerInput)
+	if err != nil {
+		if IsRateLimited(err) {
+			// Scan this page again once the API server has recovered, instead of failing the whole batch
+			zap.L().Warn("rate limited while polling, re-queueing scan",
+				zap.String("resourcePoller", poller.description), zap.Error(err))
+			return nil, requeue(pollermodels.ScanMsg{
+				Entries: []*pollermodels.ScanEntry{scanRequest},
+			}, int64(rateLimitDelay.Seconds())+int64(pageRequeueDelayer.Intn(60)+5))
+		}
+		if IsExpired(err) && scanRequest.NextPageToken != nil {
+			// The API server only keeps continue tokens for a few minutes, start the list over
+			zap.L().Warn("continue token expired, re-queueing full scan",
+				zap.String("resourcePoller", poller.description))
+			scanRequest.NextPageToken = nil
+			return nil, requeue(pollermodels.ScanMsg{
+				Entries: []*pollermodels.ScanEntry{scanRequest},
+			}, int64(pageRequeueDelayer.Intn(30)+1))
+		}
+		zap.L().Info(
+			"an error occurred while polling",
+			zap.String("resourcePoller", poller.description),
+			zap.String("errorMessage", err.Error()),
+		)
+		return nil, err
+	}
+
+	zap.L().Info(
+		"resources generated",
+		zap.Int("numResources", len(generatedEvents)),
+		zap.String("resourcePoller", poller.description),
+	)
+
+	// If we exited early because we hit the max batch size, re-queue a scan starting from where we
+	// left off. A nil token tells the caller the scan of this cluster is complete.
+	scanRequest.NextPageToken = marker
+	if marker != nil {
+		zap.L().Debug("hit max batch size")
+		err = requeue(pollermodels.ScanMsg{
+			Entries: []*pollermodels.ScanEntry{
+				scanRequest,
+			},
+		}, int64(pageRequeueDelayer.Intn(30)+1)) // Delay between 1 & 30 seconds to spread out page scans
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return generatedEvents, nil
+}
+
+func singleResourceScan(
+	scanRequest *pollermodels.ScanEntry,
+	pollerInput *k8smodels.ResourcePollerInput,
+) ([]resourcesapimodels.AddResourceEntry, error) {
+
+	pollFunction, ok := IndividualResourcePollers[*scanRequest.ResourceType]
+	if !ok {
+		zap.L().Error("unable to perform scan of specified resource type", zap.String("resourceType", *scanRequest.ResourceType))
+		// This error is not retryable
+		return nil, nil
+	}
+	// Check if ResourceID matches the integration's regex filter
+	if pollerInput.ShouldIgnoreResource(*scanRequest.ResourceID) {
+		return nil, nil
+	}
+
+	resource, err := pollFunction(pollerInput, *scanRequest.ResourceID)
+	if err != nil {
+		if IsRateLimited(err) {
+			return nil, requeue(pollermodels.ScanMsg{
+				Entries: []*pollermodels.ScanEntry{scanRequest},
+			}, int64(rateLimitDelay.Seconds())+int64(pageRequeueDelayer.Intn(60)+5))
+		}
+		return nil, errors.Wrapf(
+			err,
+			"could not scan k8s resource: %s",
+			aws.StringValue(scanRequest.ResourceID),
+		)
+	}
+
+	// This can happen for a number of reasons, most commonly that the resource no longer exists
+	if resource == nil {
+		return nil, nil
+	}
+
+	return []resourcesapimodels.AddResourceEntry{{
+		Attributes:      resource,
+		ID:              *scanRequest.ResourceID,
+		IntegrationID:   *scanRequest.IntegrationID,
+		IntegrationType: integrationType,
+		Type:            *scanRequest.ResourceType,
+	}}, nil
+}
@@ -0,0 +1,286 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	k8smodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/k8s"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/k8s/k8stest"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+var testIntegrationID = "0aab70c6-da66-4bb9-a83c-bbe8f5717fde"
+
+// setupExampleServer points the pollers at a fake API server returning the example responses, and
+// records requeued scans instead of sending them to SQS.
+func setupExampleServer(t *testing.T) (*k8stest.Server, *[]pollermodels.ScanMsg) {
+	server := k8stest.NewExampleServer()
+	t.Cleanup(server.Close)
+
+	clientCache = make(map[string]cachedClient)
+	getCredentials = func(integrationID string) ([]byte, error) {
+		return server.Credentials(), nil
+	}
+	utils.TimeNowFunc = func() time.Time { return time.Time{} }
+
+	var requeued []pollermodels.ScanMsg
+	requeue = func(scanRequest pollermodels.ScanMsg, _ int64) error {
+		requeued = append(requeued, scanRequest)
+		return nil
+	}
+	return server, &requeued
+}
+
+// testPollerInput returns the input for scanning the example cluster.
+func testPollerInput(t *testing.T) *k8smodels.ResourcePollerInput {
+	client, server, err := getClient(testIntegrationID)
+	require.NoError(t, err)
+	return &k8smodels.ResourcePollerInput{
+		Client:        client,
+		Server:        server,
+		IntegrationID: aws.String(testIntegrationID),
+		Timestamp:     aws.Time(time.Time{}),
+	}
+}
+
+// testResourceID returns the resource ID of an example object
+func testResourceID(path string) string {
+	return "k8s://" + testIntegrationID + path
+}
+
+func TestPollServiceScan(t *testing.T) {
+	server, requeued := setupExampleServer(t)
+
+	entry := &pollermodels.ScanEntry{
+		IntegrationID: aws.String(testIntegrationID),
+		ResourceType:  aws.String(k8smodels.ClusterRoleBindingSchema),
+	}
+	resources, err := Poll(entry)
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, testResourceID("/apis/rbac.authorization.k8s.io/v1/clusterrolebindings/cluster-admin"), resources[0].ID)
+	assert.Equal(t, "k8s", resources[0].IntegrationType)
+	assert.Equal(t, k8smodels.ClusterRoleBindingSchema, resources[0].Type)
+	assert.Equal(t, testIntegrationID, resources[0].IntegrationID)
+	assert.Empty(t, *requeued)
+	assert.Nil(t, entry.NextPageToken)
+	assert.Equal(t, []string{"GET /apis/rbac.authorization.k8s.io/v1/clusterrolebindings"}, server.Requests)
+}
+
+func TestPollServiceScanNextPage(t *testing.T) {
+	server, requeued := setupExampleServer(t)
+	defaultBatchSize = 1
+	defer func() { defaultBatchSize = 100 }()
+
+	server.HandleFunc(http.MethodGet, "/apis/rbac.authorization.k8s.io/v1/roles",
+		func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "1", r.URL.Query().Get("limit"))
+			assert.Empty(t, r.URL.Query().Get("continue"))
+			_, _ = w.Write([]byte(`{"metadata": {"continue": "page-2"}, ` +
+				`"items": [{"metadata": {"name": "first", "namespace": "default"}}]}`))
+		})
+
+	entry := &pollermodels.ScanEntry{
+		IntegrationID: aws.String(testIntegrationID),
+		ResourceType:  aws.String(k8smodels.RoleSchema),
+	}
+	resources, err := Poll(entry)
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	require.Len(t, *requeued, 1)
+	assert.Equal(t, "page-2", *(*requeued)[0].Entries[0].NextPageToken)
+}
+
+func TestPollServiceScanRateLimited(t *testing.T) {
+	server, requeued := setupExampleServer(t)
+	server.HandleFunc(http.MethodGet, "/api/v1/pods", func(w http.ResponseWriter, r *http.Request) {
+		k8stest.WriteStatus(w, http.StatusTooManyRequests, "TooManyRequests", "too many requests, please try again later")
+	})
+
+	entry := &pollermodels.ScanEntry{
+		IntegrationID: aws.String(testIntegrationID),
+		ResourceType:  aws.String(k8smodels.PodSchema),
+		NextPageToken: aws.String("page-3"),
+	}
+	resources, err := Poll(entry)
+	require.NoError(t, err)
+	assert.Empty(t, resources)
+	// The same page is scanned again later
+	require.Len(t, *requeued, 1)
+	assert.Equal(t, "page-3", *(*requeued)[0].Entries[0].NextPageToken)
+}
+
+func TestPollServiceScanContinueExpired(t *testing.T) {
+	server, requeued := setupExampleServer(t)
+	server.HandleFunc(http.MethodGet, "/api/v1/pods", func(w http.ResponseWriter, r *http.Request) {
+		k8stest.WriteStatus(w, http.StatusGone, "Expired", "The provided continue parameter is too old")
+	})
+
+	entry := &pollermodels.ScanEntry{
+		IntegrationID: aws.String(testIntegrationID),
+		ResourceType:  aws.String(k8smodels.PodSchema),
+		NextPageToken: aws.String("page-3"),
+	}
+	resources, err := Poll(entry)
+	require.NoError(t, err)
+	assert.Empty(t, resources)
+	// The list is started over
+	require.Len(t, *requeued, 1)
+	assert.Nil(t, (*requeued)[0].Entries[0].NextPageToken)
+}
+
+func TestPollServiceScanError(t *testing.T) {
+	server, requeued := setupExampleServer(t)
+	server.HandleFunc(http.MethodGet, "/api/v1/pods", func(w http.ResponseWriter, r *http.Request) {
+		k8stest.WriteStatus(w, http.StatusForbidden, "Forbidden",
+			`pods is forbidden: User "system:serviceaccount:panther:audit" cannot list resource "pods"`)
+	})
+
+	resources, err := Poll(&pollermodels.ScanEntry{
+		IntegrationID: aws.String(testIntegrationID),
+		ResourceType:  aws.String(k8smodels.PodSchema),
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Forbidden")
+	assert.Empty(t, resources)
+	assert.Empty(t, *requeued)
+}
+
+func TestPollSingleResource(t *testing.T) {
+	setupExampleServer(t)
+
+	resourceID := testResourceID("/apis/apps/v1/namespaces/default/deployments/web")
+	resources, err := Poll(&pollermodels.ScanEntry{
+		IntegrationID: aws.String(testIntegrationID),
+		ResourceID:    aws.String(resourceID),
+		ResourceType:  aws.String(k8smodels.DeploymentSchema),
+	})
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, resourceID, resources[0].ID)
+	assert.IsType(t, &k8smodels.Deployment{}, resources[0].Attributes)
+}
+
+func TestPollSingleResourceDeleted(t *testing.T) {
+	setupExampleServer(t)
+
+	resources, err := Poll(&pollermodels.ScanEntry{
+		IntegrationID: aws.String(testIntegrationID),
+		ResourceID:    aws.String(testResourceID("/apis/apps/v1/namespaces/default/deployments/deleted")),
+		ResourceType:  aws.String(k8smodels.DeploymentSchema),
+	})
+	require.NoError(t, err)
+	assert.Empty(t, resources)
+}
+
+func TestPollSingleResourceInvalidID(t *testing.T) {
+	server, _ := setupExampleServer(t)
+
+	for _, resourceID := range []string{
+		// Another integration
+		"k8s://11111111-2222-4333-8444-555555555555/apis/apps/v1/namespaces/default/deployments/web",
+		// Another kind
+		testResourceID("/api/v1/namespaces/default/pods/web"),
+		// No namespace
+		testResourceID("/apis/apps/v1/deployments/web"),
+	} {
+		resources, err := Poll(&pollermodels.ScanEntry{
+			IntegrationID: aws.String(testIntegrationID),
+			ResourceID:    aws.String(resourceID),
+			ResourceType:  aws.String(k8smodels.DeploymentSchema),
+		})
+		assert.Error(t, err, resourceID)
+		assert.Empty(t, resources)
+	}
+	assert.Empty(t, server.Requests)
+}
+
+func TestPollFiltered(t *testing.T) {
+	server, requeued := setupExampleServer(t)
+
+	for _, entry := range []*pollermodels.ScanEntry{
+		{
+			IntegrationID: aws.String(testIntegrationID),
+			ResourceType:  aws.String(k8smodels.ClusterRoleSchema),
+			Enabled:       aws.Bool(false),
+		},
+		{
+			IntegrationID:          aws.String(testIntegrationID),
+			ResourceType:           aws.String(k8smodels.ClusterRoleSchema),
+			ResourceTypeIgnoreList: []string{k8smodels.ClusterRoleSchema},
+		},
+		{
+			IntegrationID:           aws.String(testIntegrationID),
+			ResourceType:            aws.String(k8smodels.ClusterRoleSchema),
+			ResourceRegexIgnoreList: []string{"k8s://*/clusterroles/*"},
+		},
+	} {
+		resources, err := Poll(entry)
+		require.NoError(t, err)
+		assert.Empty(t, resources)
+	}
+	assert.Empty(t, *requeued)
+	// Only the regex filter needs the cluster roles to be listed
+	assert.Equal(t, []string{"GET /apis/rbac.authorization.k8s.io/v1/clusterroles"}, server.Requests)
+}
+
+func TestPollIgnoredNamespace(t *testing.T) {
+	setupExampleServer(t)
+
+	resources, err := Poll(&pollermodels.ScanEntry{
+		IntegrationID:    aws.String(testIntegrationID),
+		ResourceType:     aws.String(k8smodels.PodSchema),
+		RegionIgnoreList: []string{"kube-system"},
+	})
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, testResourceID("/api/v1/namespaces/default/pods/web-5d8f6c7b9-x2k4p"), resources[0].ID)
+}
+
+func TestPollCredentialsError(t *testing.T) {
+	setupExampleServer(t)
+	getCredentials = func(integrationID string) ([]byte, error) {
+		return []byte(`{"server": "https://example.com"}`), nil
+	}
+
+	resources, err := Poll(&pollermodels.ScanEntry{
+		IntegrationID: aws.String(testIntegrationID),
+		ResourceType:  aws.String(k8smodels.NamespaceSchema),
+	})
+	require.Error(t, err)
+	assert.Empty(t, resources)
+}
+
+func TestIsResourceType(t *testing.T) {
+	assert.True(t, IsResourceType(k8smodels.PodSchema))
+	assert.False(t, IsResourceType("AWS.S3.Bucket"))
+	assert.False(t, IsResourceType("GCP.Storage.Bucket"))
+	for resourceType := range ServicePollers {
+		_, ok := IndividualResourcePollers[resourceType]
+		assert.True(t, ok, "no individual poller for %s", resourceType)
+	}
+}
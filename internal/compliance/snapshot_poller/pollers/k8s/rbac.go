@@ -0,0 +1,95 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	k8smodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/k8s"
+)
+
+const rbacAPIPath = "/apis/rbac.authorization.k8s.io/v1"
+
+var (
+	roleKind = &resourceKind{
+		schema:      k8smodels.RoleSchema,
+		apiPath:     rbacAPIPath,
+		plural:      "roles",
+		namespaced:  true,
+		newResource: func() k8sResource { return &k8smodels.Role{} },
+	}
+	clusterRoleKind = &resourceKind{
+		schema:      k8smodels.ClusterRoleSchema,
+		apiPath:     rbacAPIPath,
+		plural:      "clusterroles",
+		newResource: func() k8sResource { return &k8smodels.Role{} },
+	}
+	roleBindingKind = &resourceKind{
+		schema:      k8smodels.RoleBindingSchema,
+		apiPath:     rbacAPIPath,
+		plural:      "rolebindings",
+		namespaced:  true,
+		newResource: func() k8sResource { return &k8smodels.RoleBinding{} },
+	}
+	clusterRoleBindingKind = &resourceKind{
+		schema:      k8smodels.ClusterRoleBindingSchema,
+		apiPath:     rbacAPIPath,
+		plural:      "clusterrolebindings",
+		newResource: func() k8sResource { return &k8smodels.RoleBinding{} },
+	}
+)
+
+// PollRole polls a single role by its resource ID
+func PollRole(pollerInput *k8smodels.ResourcePollerInput, resourceID string) (interface{}, error) {
+	return roleKind.get(pollerInput, resourceID)
+}
+
+// PollRoles gathers information on each role in a cluster
+func PollRoles(pollerInput *k8smodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	return roleKind.list(pollerInput)
+}
+
+// PollClusterRole polls a single cluster role by its resource ID
+func PollClusterRole(pollerInput *k8smodels.ResourcePollerInput, resourceID string) (interface{}, error) {
+	return clusterRoleKind.get(pollerInput, resourceID)
+}
+
+// PollClusterRoles gathers information on each cluster role in a cluster
+func PollClusterRoles(pollerInput *k8smodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	return clusterRoleKind.list(pollerInput)
+}
+
+// PollRoleBinding polls a single role binding by its resource ID
+func PollRoleBinding(pollerInput *k8smodels.ResourcePollerInput, resourceID string) (interface{}, error) {
+	return roleBindingKind.get(pollerInput, resourceID)
+}
+
+// PollRoleBindings gathers information on each role binding in a cluster
+func PollRoleBindings(pollerInput *k8smodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	return roleBindingKind.list(pollerInput)
+}
+
+// PollClusterRoleBinding polls a single cluster role binding by its resource ID
+func PollClusterRoleBinding(pollerInput *k8smodels.ResourcePollerInput, resourceID string) (interface{}, error) {
+	return clusterRoleBindingKind.get(pollerInput, resourceID)
+}
+
+// PollClusterRoleBindings gathers information on each cluster role binding in a cluster
+func PollClusterRoleBindings(pollerInput *k8smodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	return clusterRoleBindingKind.list(pollerInput)
+}
@@ -0,0 +1,109 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	k8smodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/k8s"
+)
+
+func TestPollRoles(t *testing.T) {
+	setupExampleServer(t)
+
+	resources, marker, err := PollRoles(testPollerInput(t))
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	require.Len(t, resources, 1)
+
+	role := resources[0].Attributes.(*k8smodels.Role)
+	assert.Equal(t, testResourceID("/apis/rbac.authorization.k8s.io/v1/namespaces/default/roles/pod-reader"), resources[0].ID)
+	assert.Equal(t, k8smodels.RoleSchema, *role.ResourceType)
+	assert.Equal(t, "default", *role.Region)
+	assert.Equal(t, "default", *role.Namespace)
+	require.Len(t, role.Rules, 1)
+	assert.Equal(t, []*string{aws.String("")}, role.Rules[0].APIGroups)
+	assert.Equal(t, aws.StringSlice([]string{"pods", "pods/log"}), role.Rules[0].Resources)
+	assert.Equal(t, aws.StringSlice([]string{"get", "watch", "list"}), role.Rules[0].Verbs)
+}
+
+func TestPollClusterRoles(t *testing.T) {
+	setupExampleServer(t)
+
+	resources, _, err := PollClusterRoles(testPollerInput(t))
+	require.NoError(t, err)
+	require.Len(t, resources, 2)
+
+	role := resources[0].Attributes.(*k8smodels.Role)
+	assert.Equal(t, testResourceID("/apis/rbac.authorization.k8s.io/v1/clusterroles/cluster-admin"), resources[0].ID)
+	assert.Equal(t, k8smodels.ClusterRoleSchema, resources[0].Type)
+	assert.Equal(t, k8smodels.GlobalRegion, *role.Region)
+	assert.Equal(t, "true", *role.Annotations["rbac.authorization.kubernetes.io/autoupdate"])
+	require.Len(t, role.Rules, 2)
+	assert.Equal(t, []*string{aws.String("*")}, role.Rules[1].NonResourceURLs)
+	assert.Nil(t, role.AggregationRule)
+
+	role = resources[1].Attributes.(*k8smodels.Role)
+	require.NotNil(t, role.AggregationRule)
+	assert.Equal(t, "true",
+		*role.AggregationRule.ClusterRoleSelectors[0].MatchLabels["rbac.example.com/aggregate-to-monitoring"])
+}
+
+func TestPollRoleBindings(t *testing.T) {
+	setupExampleServer(t)
+
+	resources, _, err := PollRoleBindings(testPollerInput(t))
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+
+	binding := resources[0].Attributes.(*k8smodels.RoleBinding)
+	assert.Equal(t, testResourceID("/apis/rbac.authorization.k8s.io/v1/namespaces/default/rolebindings/read-pods"), resources[0].ID)
+	require.Len(t, binding.Subjects, 2)
+	assert.Equal(t, "User", *binding.Subjects[0].Kind)
+	assert.Equal(t, "jane", *binding.Subjects[0].Name)
+	assert.Equal(t, "ServiceAccount", *binding.Subjects[1].Kind)
+	assert.Equal(t, "default", *binding.Subjects[1].Namespace)
+	assert.Equal(t, &k8smodels.RoleRef{
+		APIGroup: aws.String("rbac.authorization.k8s.io"),
+		Kind:     aws.String("Role"),
+		Name:     aws.String("pod-reader"),
+	}, binding.RoleRef)
+}
+
+func TestPollClusterRoleBinding(t *testing.T) {
+	setupExampleServer(t)
+
+	resourceID := testResourceID("/apis/rbac.authorization.k8s.io/v1/clusterrolebindings/cluster-admin")
+	resource, err := PollClusterRoleBinding(testPollerInput(t), resourceID)
+	require.NoError(t, err)
+	binding := resource.(*k8smodels.RoleBinding)
+	assert.Equal(t, resourceID, *binding.ResourceID)
+	assert.Equal(t, k8smodels.ClusterRoleBindingSchema, *binding.ResourceType)
+	assert.Equal(t, "system:masters", *binding.Subjects[0].Name)
+	assert.Equal(t, "ClusterRole", *binding.RoleRef.Kind)
+
+	// A role binding with the same name is not a cluster role binding
+	_, err = PollClusterRoleBinding(testPollerInput(t),
+		testResourceID("/apis/rbac.authorization.k8s.io/v1/namespaces/default/rolebindings/cluster-admin"))
+	assert.Error(t, err)
+}
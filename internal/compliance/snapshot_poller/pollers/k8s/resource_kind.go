@@ -0,0 +1,197 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	k8smodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/k8s"
+)
+
+const resourceIDScheme = "k8s://"
+
+// k8sResource is implemented by every model through its embedded generic fields.
+type k8sResource interface {
+	Generic() *k8smodels.GenericResource
+	K8s() *k8smodels.GenericK8sResource
+}
+
+// resourceKind describes how a kind of Kubernetes object is listed, read and converted to a resource.
+//
+// Every kind is scanned the same way, only the API path and the fields added by the poller differ.
+type resourceKind struct {
+	schema string
+	// The path of the API group and version, such as /api/v1 or /apis/apps/v1
+	apiPath    string
+	plural     string
+	namespaced bool
+
+	newResource func() k8sResource
+	// Optional, adds the fields which are not returned by the API
+	enrich func(pollerInput *k8smodels.ResourcePollerInput, resource k8sResource) error
+}
+
+// objectPath is the API path of one object.
+func (k *resourceKind) objectPath(namespace, name string) string {
+	path := k.apiPath
+	if k.namespaced {
+		path += "/namespaces/" + url.PathEscape(namespace)
+	}
+	return path + "/" + k.plural + "/" + url.PathEscape(name)
+}
+
+// resourceID identifies an object across all integrations, for example
+// k8s://<integration id>/apis/apps/v1/namespaces/default/deployments/web
+func (k *resourceKind) resourceID(integrationID, namespace, name string) string {
+	return resourceIDScheme + integrationID + k.objectPath(namespace, name)
+}
+
+// parseResourceID returns the namespace and name of an object of this kind, ok is false if the ID
+// belongs to another integration or kind.
+func (k *resourceKind) parseResourceID(integrationID, resourceID string) (namespace, name string, ok bool) {
+	path := strings.TrimPrefix(resourceID, resourceIDScheme+integrationID+k.apiPath+"/")
+	if path == resourceID {
+		return "", "", false
+	}
+
+	parts := strings.Split(path, "/")
+	if k.namespaced {
+		if len(parts) != 4 || parts[0] != "namespaces" {
+			return "", "", false
+		}
+		namespace, parts = parts[1], parts[2:]
+	}
+	if len(parts) != 2 || parts[0] != k.plural || parts[1] == "" {
+		return "", "", false
+	}
+	return namespace, parts[1], true
+}
+
+// build converts an object returned by the API to a resource, it returns nil for ignored objects.
+func (k *resourceKind) build(pollerInput *k8smodels.ResourcePollerInput, raw []byte) (k8sResource, error) {
+	var object struct {
+		Metadata k8smodels.ObjectMeta `json:"metadata"`
+	}
+	if err := jsoniter.ConfigCompatibleWithStandardLibrary.Unmarshal(raw, &object); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode %s metadata", k.plural)
+	}
+	resource := k.newResource()
+	if err := jsoniter.ConfigCompatibleWithStandardLibrary.Unmarshal(raw, resource); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode %s", k.plural)
+	}
+
+	metadata := object.Metadata
+	region := k8smodels.GlobalRegion
+	if k.namespaced {
+		region = aws.StringValue(metadata.Namespace)
+	}
+	// Namespaces take the place of regions
+	if pollerInput.ShouldIgnoreRegion(region) {
+		return nil, nil
+	}
+
+	generic := resource.Generic()
+	generic.ResourceID = aws.String(k.resourceID(
+		*pollerInput.IntegrationID, aws.StringValue(metadata.Namespace), aws.StringValue(metadata.Name)))
+	generic.ResourceType = aws.String(k.schema)
+	generic.TimeCreated = metadata.CreationTimestamp
+
+	k8s := resource.K8s()
+	k8s.Region = aws.String(region)
+	k8s.Name = metadata.Name
+	k8s.Namespace = metadata.Namespace
+	k8s.UID = metadata.UID
+	k8s.Labels = metadata.Labels
+	k8s.Annotations = metadata.Annotations
+
+	if k.enrich != nil {
+		if err := k.enrich(pollerInput, resource); err != nil {
+			return nil, err
+		}
+	}
+	return resource, nil
+}
+
+// get polls a single object by its resource ID, it returns nil if the object no longer exists.
+func (k *resourceKind) get(pollerInput *k8smodels.ResourcePollerInput, resourceID string) (interface{}, error) {
+	namespace, name, ok := k.parseResourceID(*pollerInput.IntegrationID, resourceID)
+	if !ok {
+		return nil, errors.Errorf("%s is not the ID of a %s resource", resourceID, k.schema)
+	}
+
+	var raw jsoniter.RawMessage
+	err := getJSON(pollerInput.Client, pollerInput.Server+k.objectPath(namespace, name), &raw)
+	if IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resource, err := k.build(pollerInput, raw)
+	if err != nil || resource == nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// list gathers the objects of this kind in all namespaces of a cluster
+func (k *resourceKind) list(pollerInput *k8smodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	var resources []apimodels.AddResourceEntry
+	continueToken := pollerInput.NextPageToken
+	for {
+		page := &listPage{}
+		requestURL := listURL(pollerInput.Server+k.apiPath+"/"+k.plural, defaultBatchSize, continueToken)
+		if err := getJSON(pollerInput.Client, requestURL, page); err != nil {
+			return nil, nil, err
+		}
+
+		for _, raw := range page.Items {
+			resource, err := k.build(pollerInput, raw)
+			if err != nil {
+				return nil, nil, err
+			}
+			if resource == nil {
+				continue
+			}
+			resourceID := *resource.Generic().ResourceID
+			if pollerInput.ShouldIgnoreResource(resourceID) {
+				continue
+			}
+			resources = append(resources, apimodels.AddResourceEntry{
+				Attributes:      resource,
+				ID:              resourceID,
+				IntegrationID:   *pollerInput.IntegrationID,
+				IntegrationType: integrationType,
+				Type:            k.schema,
+			})
+		}
+
+		continueToken = nextPage(page.Metadata.Continue)
+		if continueToken == nil || len(resources) >= defaultBatchSize {
+			return resources, continueToken, nil
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	k8smodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/k8s"
+)
+
+var (
+	podKind = &resourceKind{
+		schema:      k8smodels.PodSchema,
+		apiPath:     "/api/v1",
+		plural:      "pods",
+		namespaced:  true,
+		newResource: func() k8sResource { return &k8smodels.Pod{} },
+		enrich: func(_ *k8smodels.ResourcePollerInput, resource k8sResource) error {
+			pod := resource.(*k8smodels.Pod)
+			pod.Security = podSecurity(pod.Spec)
+			return nil
+		},
+	}
+	deploymentKind = &resourceKind{
+		schema:      k8smodels.DeploymentSchema,
+		apiPath:     "/apis/apps/v1",
+		plural:      "deployments",
+		namespaced:  true,
+		newResource: func() k8sResource { return &k8smodels.Deployment{} },
+		enrich: func(_ *k8smodels.ResourcePollerInput, resource k8sResource) error {
+			deployment := resource.(*k8smodels.Deployment)
+			if deployment.Spec != nil && deployment.Spec.Template != nil {
+				deployment.Security = podSecurity(deployment.Spec.Template.Spec)
+			}
+			return nil
+		},
+	}
+)
+
+// PollPod polls a single pod by its resource ID
+func PollPod(pollerInput *k8smodels.ResourcePollerInput, resourceID string) (interface{}, error) {
+	return podKind.get(pollerInput, resourceID)
+}
+
+// PollPods gathers information on each pod in a cluster
+func PollPods(pollerInput *k8smodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	return podKind.list(pollerInput)
+}
+
+// PollDeployment polls a single deployment by its resource ID
+func PollDeployment(pollerInput *k8smodels.ResourcePollerInput, resourceID string) (interface{}, error) {
+	return deploymentKind.get(pollerInput, resourceID)
+}
+
+// PollDeployments gathers information on each deployment in a cluster
+func PollDeployments(pollerInput *k8smodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	return deploymentKind.list(pollerInput)
+}
+
+// podSecurity summarizes the access to the host a pod specification grants
+func podSecurity(spec *k8smodels.PodSpec) *k8smodels.PodSecurity {
+	if spec == nil {
+		return nil
+	}
+	security := &k8smodels.PodSecurity{
+		HostNetwork: aws.BoolValue(spec.HostNetwork),
+		HostPID:     aws.BoolValue(spec.HostPID),
+		HostIPC:     aws.BoolValue(spec.HostIPC),
+	}
+
+	// Init containers run with the same privileges as the other containers
+	containers := append(append([]*k8smodels.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, container := range containers {
+		if container.SecurityContext != nil && aws.BoolValue(container.SecurityContext.Privileged) {
+			security.Privileged = true
+			security.PrivilegedContainers = append(security.PrivilegedContainers, container.Name)
+		}
+		for _, port := range container.Ports {
+			if aws.Int64Value(port.HostPort) != 0 {
+				security.HostPorts = append(security.HostPorts, port.HostPort)
+			}
+		}
+	}
+	for _, volume := range spec.Volumes {
+		if volume.HostPath != nil {
+			security.HostPaths = append(security.HostPaths, volume.HostPath.Path)
+		}
+	}
+	return security
+}
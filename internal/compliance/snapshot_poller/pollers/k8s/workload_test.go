@@ -0,0 +1,114 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	k8smodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/k8s"
+)
+
+func TestPollPods(t *testing.T) {
+	setupExampleServer(t)
+
+	resources, marker, err := PollPods(testPollerInput(t))
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	require.Len(t, resources, 2)
+
+	pod := resources[0].Attributes.(*k8smodels.Pod)
+	assert.Equal(t, testResourceID("/api/v1/namespaces/default/pods/web-5d8f6c7b9-x2k4p"), resources[0].ID)
+	assert.Equal(t, k8smodels.PodSchema, resources[0].Type)
+	assert.Equal(t, "default", *pod.Region)
+	assert.Equal(t, "web", *pod.Spec.ServiceAccountName)
+	assert.True(t, *pod.Spec.SecurityContext.RunAsNonRoot)
+	assert.Equal(t, int64(2000), *pod.Spec.SecurityContext.FSGroup)
+	container := pod.Spec.Containers[0]
+	assert.Equal(t, "nginx:1.19", *container.Image)
+	assert.False(t, *container.SecurityContext.AllowPrivilegeEscalation)
+	assert.Equal(t, []*string{aws.String("ALL")}, container.SecurityContext.Capabilities.Drop)
+	assert.Equal(t, "web-tls", *pod.Spec.Volumes[0].Secret.SecretName)
+	assert.Equal(t, "10.244.1.5", *pod.Status.PodIP)
+	assert.Equal(t, &k8smodels.PodSecurity{}, pod.Security)
+
+	pod = resources[1].Attributes.(*k8smodels.Pod)
+	assert.Equal(t, "kube-system", *pod.Region)
+	assert.Equal(t, &k8smodels.PodSecurity{
+		Privileged:           true,
+		PrivilegedContainers: aws.StringSlice([]string{"init-modules", "kube-proxy"}),
+		HostNetwork:          true,
+		HostPaths:            aws.StringSlice([]string{"/lib/modules", "/run/xtables.lock"}),
+		HostPorts:            aws.Int64Slice([]int64{10249}),
+	}, pod.Security)
+}
+
+func TestPollPodsEnvironmentNotStored(t *testing.T) {
+	setupExampleServer(t)
+
+	resources, _, err := PollPods(testPollerInput(t))
+	require.NoError(t, err)
+	data, err := jsoniter.Marshal(resources[0].Attributes)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "hunter2")
+}
+
+func TestPollDeployments(t *testing.T) {
+	setupExampleServer(t)
+
+	resources, _, err := PollDeployments(testPollerInput(t))
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+
+	deployment := resources[0].Attributes.(*k8smodels.Deployment)
+	assert.Equal(t, testResourceID("/apis/apps/v1/namespaces/default/deployments/web"), resources[0].ID)
+	assert.Equal(t, k8smodels.DeploymentSchema, resources[0].Type)
+	assert.Equal(t, int64(3), *deployment.Spec.Replicas)
+	assert.Equal(t, "web", *deployment.Spec.Selector.MatchLabels["app"])
+	assert.Equal(t, &k8smodels.PodSecurity{
+		HostPID:   true,
+		HostPaths: aws.StringSlice([]string{"/var/run/docker.sock"}),
+	}, deployment.Security)
+}
+
+func TestPollPod(t *testing.T) {
+	setupExampleServer(t)
+
+	resourceID := testResourceID("/api/v1/namespaces/kube-system/pods/kube-proxy-7xq9z")
+	resource, err := PollPod(testPollerInput(t), resourceID)
+	require.NoError(t, err)
+	pod := resource.(*k8smodels.Pod)
+	assert.Equal(t, resourceID, *pod.ResourceID)
+	assert.True(t, pod.Security.Privileged)
+
+	// Pods in ignored namespaces are not reported
+	input := testPollerInput(t)
+	input.RegionIgnoreList = []string{"kube-system"}
+	resource, err = PollPod(input, resourceID)
+	require.NoError(t, err)
+	assert.Nil(t, resource)
+}
+
+func TestPodSecurityNoSpec(t *testing.T) {
+	assert.Nil(t, podSecurity(nil))
+}
@@ -20,21 +20,32 @@ package pollers
 
 import (
 	"context"
+	"time"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambdacontext"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/organizations"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
 	api "github.com/panther-labs/panther/api/lambda/resources/models"
+	sourcemodels "github.com/panther-labs/panther/api/lambda/source/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
 	pollers "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws"
+	gcppollers "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/gcp"
+	k8spollers "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/k8s"
+	"github.com/panther-labs/panther/pkg/genericapi"
 	"github.com/panther-labs/panther/pkg/lambdalogger"
 	"github.com/panther-labs/panther/pkg/oplog"
 )
 
-const resourcesAPIBatchSize = 500
+const (
+	resourcesAPIBatchSize = 500
+	sourceAPIFunctionName = "panther-source-api"
+)
 
 // loadMessage marshals the incoming SQS message into a ScanMsg.
 func loadMessage(messageBody string) (*pollermodels.ScanMsg, error) {
@@ -59,6 +70,20 @@ func batchResources(resources []api.AddResourceEntry) (batches [][]api.AddResour
 	return
 }
 
+// poll scans the resources of an entry with the pollers of its cloud provider.
+func poll(entry *pollermodels.ScanEntry) (integrationType string, resources []api.AddResourceEntry, err error) {
+	if gcppollers.IsResourceType(aws.StringValue(entry.ResourceType)) {
+		resources, err = gcppollers.Poll(entry)
+		return "gcp", resources, err
+	}
+	if k8spollers.IsResourceType(aws.StringValue(entry.ResourceType)) {
+		resources, err = k8spollers.Poll(entry)
+		return "k8s", resources, err
+	}
+	resources, err = pollers.Poll(entry)
+	return "aws", resources, err
+}
+
 // Replaced by unit tests with an in-memory logger
 var loggerSetupFunc = func(ctx context.Context, initialFields map[string]interface{}) *lambdacontext.LambdaContext {
 	lc, _ := lambdalogger.ConfigureGlobal(ctx, initialFields)
@@ -88,21 +113,27 @@ func Handle(ctx context.Context, event events.SQSEvent) (err error) {
 		for _, entry := range scanRequest.Entries {
 			zap.L().Debug("starting poller",
 				zap.Any("sqsEntry", entry),
-				zap.Int("messageNumber", indx),
-				zap.String("integrationType", "aws"))
+				zap.Int("messageNumber", indx))
 
-			resources, pollErr := pollers.Poll(entry)
+			integrationType, resources, pollErr := poll(entry)
 			if pollErr != nil {
 				operation.LogError(errors.Wrap(pollErr, "poll failed"), zap.Any("sqsEntry", entry))
 				return pollErr
 			}
+			// Service scans clear the page token once the last page has been requeued or scanned
+			if entry.ResourceID == nil && entry.NextPageToken == nil {
+				recordResourceScan(entry)
+			}
+			if aws.StringValue(entry.ResourceType) == awsmodels.OrganizationsAccountSchema {
+				onboardOrganizationAccounts(entry, resources)
+			}
 
 			// Send data to the Resources API
 			if len(resources) > 0 {
 				zap.L().Debug("total resources generated",
 					zap.Int("messageNumber", indx),
 					zap.Int("numResources", len(resources)),
-					zap.String("integrationType", "aws"),
+					zap.String("integrationType", integrationType),
 				)
 
 				for _, batch := range batchResources(resources) {
@@ -120,3 +151,53 @@ func Handle(ctx context.Context, event events.SQSEvent) (err error) {
 
 	return nil
 }
+
+// recordResourceScan lets the source-api know a resource type was fully scanned in a region (or
+// split into per region scans), so the scheduler doesn't issue it again until it is due.
+func recordResourceScan(entry *pollermodels.ScanEntry) {
+	if entry.IntegrationID == nil || entry.ResourceType == nil {
+		return
+	}
+	input := &sourcemodels.LambdaInput{
+		UpdateIntegrationResourceScan: &sourcemodels.UpdateIntegrationResourceScanInput{
+			IntegrationID: *entry.IntegrationID,
+			ResourceType:  *entry.ResourceType,
+			Region:        aws.StringValue(entry.Region),
+			ScanTime:      time.Now().UTC(),
+		},
+	}
+	if err := genericapi.Invoke(lambdaClient, sourceAPIFunctionName, input, nil); err != nil {
+		// Not worth failing the message over, the slice is just scanned again on the next schedule
+		zap.L().Warn("failed to record resource scan", zap.Error(err), zap.Any("sqsEntry", entry))
+	}
+}
+
+// onboardOrganizationAccounts passes the active member accounts found while scanning an AWS Organization to the
+// source-api, which creates integrations for them if the organization integration has auto onboarding enabled.
+func onboardOrganizationAccounts(entry *pollermodels.ScanEntry, resources []api.AddResourceEntry) {
+	if entry.IntegrationID == nil {
+		return
+	}
+	var accountIDs []string
+	for _, resource := range resources {
+		account, ok := resource.Attributes.(*awsmodels.OrganizationsAccount)
+		if !ok || account.ManagementAccount || aws.StringValue(account.Status) != organizations.AccountStatusActive {
+			continue
+		}
+		accountIDs = append(accountIDs, aws.StringValue(account.ID))
+	}
+	if len(accountIDs) == 0 {
+		return
+	}
+
+	input := &sourcemodels.LambdaInput{
+		OnboardOrganizationAccounts: &sourcemodels.OnboardOrganizationAccountsInput{
+			IntegrationID: *entry.IntegrationID,
+			AccountIDs:    accountIDs,
+		},
+	}
+	if err := genericapi.Invoke(lambdaClient, sourceAPIFunctionName, input, nil); err != nil {
+		// Accounts which were not onboarded are tried again on the next organization scan
+		zap.L().Warn("failed to onboard organization accounts", zap.Error(err), zap.Any("sqsEntry", entry))
+	}
+}
@@ -28,22 +28,28 @@ import (
 	"github.com/aws/aws-lambda-go/lambdacontext"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/lambda"
 	"github.com/aws/aws-sdk-go/service/sts"
 	lru "github.com/hashicorp/golang-lru"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest/observer"
 
 	resourcesapi "github.com/panther-labs/panther/api/lambda/resources/models"
+	sourcemodels "github.com/panther-labs/panther/api/lambda/source/models"
 	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+	k8smodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/k8s"
 	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
 	pollers "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws"
 	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
 	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
 	"github.com/panther-labs/panther/pkg/gatewayapi"
+	"github.com/panther-labs/panther/pkg/testutils"
 )
 
 var testIntegrationID = "0aab70c6-da66-4bb9-a83c-bbe8f5717fde"
@@ -98,6 +104,14 @@ func setupTestLogger(ctx context.Context, _ map[string]interface{}) *lambdaconte
 	return lc
 }
 
+// Replace the source-api client with a mock which accepts the recorded resource scans.
+func mockSourceAPI() *testutils.LambdaMock {
+	mockLambda := &testutils.LambdaMock{}
+	mockLambda.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{}, nil)
+	lambdaClient = mockLambda
+	return mockLambda
+}
+
 func TestHandlerNonExistentIntegration(t *testing.T) {
 	loggerSetupFunc = setupTestLogger
 	logger := mockLogger(zapcore.InfoLevel)
@@ -146,51 +160,70 @@ func TestHandlerNonExistentIntegration(t *testing.T) {
 }
 
 func TestPollSourceNotEnabled(t *testing.T) {
-	loggerSetupFunc = setupTestLogger
-	logger := mockLogger(zapcore.InfoLevel)
-	mockResourceClient := &gatewayapi.MockClient{}
-	apiClient = mockResourceClient
-	pollers.AuditRoleName = "TestAuditRole"
-
-	testIntegrations := &pollermodels.ScanMsg{
-		Entries: []*pollermodels.ScanEntry{
-			{
-				AWSAccountID:  aws.String("123456789012"),
-				IntegrationID: &testIntegrationID,
-				Region:        aws.String("us-west-2"),
-				ResourceType:  aws.String(awsmodels.KmsKeySchema),
-				Enabled:       aws.Bool(false),
-			},
+	// Every integration type is dispatched to its pollers, which check the source is enabled
+	entries := map[string]*pollermodels.ScanEntry{
+		"aws": {
+			AWSAccountID:  aws.String("123456789012"),
+			IntegrationID: &testIntegrationID,
+			Region:        aws.String("us-west-2"),
+			ResourceType:  aws.String(awsmodels.KmsKeySchema),
+			Enabled:       aws.Bool(false),
 		},
-	}
-	testIntegrationStr, err := jsoniter.MarshalToString(testIntegrations)
-	require.NoError(t, err)
-
-	sampleEvent := events.SQSEvent{
-		Records: []events.SQSMessage{
-			{
-				AWSRegion:     "us-west-2",
-				MessageId:     "702a0aba-ab1f-11e8-b09c-f218981400a1",
-				ReceiptHandle: "AQEBCki01vLygW9L6Xq1hcSNR90swZdtgZHP1N5hEU1Dt22p66gQFxKEsVo7ObxpC+b/",
-				Body:          testIntegrationStr,
-				Md5OfBody:     "d3673b20e6c009a81c73961b798f838a",
-			},
+		"gcp": {
+			GCPProjectID:  aws.String("example-project"),
+			IntegrationID: &testIntegrationID,
+			ResourceType:  aws.String(gcpmodels.StorageBucketSchema),
+			Enabled:       aws.Bool(false),
+		},
+		"k8s": {
+			IntegrationID: &testIntegrationID,
+			ResourceType:  aws.String(k8smodels.PodSchema),
+			Enabled:       aws.Bool(false),
 		},
 	}
-	require.NoError(t, Handle(testContext(), sampleEvent))
 
-	mockResourceClient.AssertExpectations(t)
-	expected := []observer.LoggedEntry{
-		{
-			Entry: zapcore.Entry{Level: zapcore.InfoLevel, Message: "source integration disabled"},
-			Context: []zapcore.Field{
-				zap.String("integration id", "0aab70c6-da66-4bb9-a83c-bbe8f5717fde"),
-			},
-		},
+	for name, entry := range entries {
+		entry := entry
+		t.Run(name, func(t *testing.T) {
+			loggerSetupFunc = setupTestLogger
+			logger := mockLogger(zapcore.InfoLevel)
+			mockResourceClient := &gatewayapi.MockClient{}
+			apiClient = mockResourceClient
+			mockSourceClient := mockSourceAPI()
+			pollers.AuditRoleName = "TestAuditRole"
+
+			testIntegrations := &pollermodels.ScanMsg{Entries: []*pollermodels.ScanEntry{entry}}
+			testIntegrationStr, err := jsoniter.MarshalToString(testIntegrations)
+			require.NoError(t, err)
+
+			sampleEvent := events.SQSEvent{
+				Records: []events.SQSMessage{
+					{
+						AWSRegion:     "us-west-2",
+						MessageId:     "702a0aba-ab1f-11e8-b09c-f218981400a1",
+						ReceiptHandle: "AQEBCki01vLygW9L6Xq1hcSNR90swZdtgZHP1N5hEU1Dt22p66gQFxKEsVo7ObxpC+b/",
+						Body:          testIntegrationStr,
+						Md5OfBody:     "d3673b20e6c009a81c73961b798f838a",
+					},
+				},
+			}
+			require.NoError(t, Handle(testContext(), sampleEvent))
+
+			mockResourceClient.AssertExpectations(t)
+			mockSourceClient.AssertNumberOfCalls(t, "Invoke", 1)
+			expected := []observer.LoggedEntry{
+				{
+					Entry: zapcore.Entry{Level: zapcore.InfoLevel, Message: "source integration disabled"},
+					Context: []zapcore.Field{
+						zap.String("integration id", "0aab70c6-da66-4bb9-a83c-bbe8f5717fde"),
+					},
+				},
+			}
+			logs := logger.AllUntimed()
+			require.Len(t, logs, 2)
+			assert.Equal(t, expected, logs[:1])
+		})
 	}
-	logs := logger.AllUntimed()
-	require.Len(t, logs, 2)
-	assert.Equal(t, expected, logs[:1])
 }
 
 func TestPollRegionIgnored(t *testing.T) {
@@ -198,6 +231,7 @@ func TestPollRegionIgnored(t *testing.T) {
 	logger := mockLogger(zapcore.InfoLevel)
 	mockResourceClient := &gatewayapi.MockClient{}
 	apiClient = mockResourceClient
+	mockSourceClient := mockSourceAPI()
 	pollers.AuditRoleName = "TestAuditRole"
 
 	testIntegrations := &pollermodels.ScanMsg{
@@ -229,6 +263,7 @@ func TestPollRegionIgnored(t *testing.T) {
 	require.NoError(t, Handle(testContext(), sampleEvent))
 
 	mockResourceClient.AssertExpectations(t)
+	mockSourceClient.AssertNumberOfCalls(t, "Invoke", 1)
 	expected := []observer.LoggedEntry{
 		{
 			Entry: zapcore.Entry{Level: zapcore.InfoLevel, Message: "processing single region service scan"},
@@ -254,6 +289,7 @@ func TestPollResourceTypeIgnored(t *testing.T) {
 	logger := mockLogger(zapcore.InfoLevel)
 	mockResourceClient := &gatewayapi.MockClient{}
 	apiClient = mockResourceClient
+	mockSourceClient := mockSourceAPI()
 	pollers.AuditRoleName = "TestAuditRole"
 
 	testIntegrations := &pollermodels.ScanMsg{
@@ -285,6 +321,7 @@ func TestPollResourceTypeIgnored(t *testing.T) {
 	require.NoError(t, Handle(testContext(), sampleEvent))
 
 	mockResourceClient.AssertExpectations(t)
+	mockSourceClient.AssertNumberOfCalls(t, "Invoke", 1)
 	expected := []observer.LoggedEntry{
 		{
 			Entry: zapcore.Entry{Level: zapcore.InfoLevel, Message: "resource type filtered"},
@@ -304,6 +341,7 @@ func TestHandler(t *testing.T) {
 	logger := mockLogger(zapcore.InfoLevel)
 	mockResourceClient := &gatewayapi.MockClient{}
 	apiClient = mockResourceClient
+	mockSourceClient := mockSourceAPI()
 	pollers.AuditRoleName = "TestAuditRole"
 
 	testIntegrations := &pollermodels.ScanMsg{
@@ -421,6 +459,14 @@ func TestHandler(t *testing.T) {
 	require.NoError(t, Handle(testContext(), sampleEvent))
 
 	mockResourceClient.AssertExpectations(t)
+	mockSourceClient.AssertNumberOfCalls(t, "Invoke", 1)
+	var recorded sourcemodels.LambdaInput
+	require.NoError(t, jsoniter.Unmarshal(
+		mockSourceClient.Calls[0].Arguments.Get(0).(*lambda.InvokeInput).Payload, &recorded))
+	require.NotNil(t, recorded.UpdateIntegrationResourceScan)
+	assert.Equal(t, testIntegrationID, recorded.UpdateIntegrationResourceScan.IntegrationID)
+	assert.Equal(t, awsmodels.KmsKeySchema, recorded.UpdateIntegrationResourceScan.ResourceType)
+	assert.Equal(t, "us-west-2", recorded.UpdateIntegrationResourceScan.Region)
 	expected := []observer.LoggedEntry{
 		{
 			Entry: zapcore.Entry{Level: zapcore.InfoLevel, Message: "processing single region service scan"},
@@ -441,3 +487,40 @@ func TestHandler(t *testing.T) {
 	require.Len(t, logs, 3)
 	assert.Equal(t, expected, logs[:2]) // throw out last oplog msg
 }
+
+func TestOnboardOrganizationAccounts(t *testing.T) {
+	mockSourceClient := mockSourceAPI()
+
+	resources := []resourcesapi.AddResourceEntry{
+		{Attributes: &awsmodels.OrganizationsAccount{
+			GenericAWSResource: awsmodels.GenericAWSResource{ID: aws.String("111111111111")},
+			Status:             aws.String("ACTIVE"),
+			ManagementAccount:  true,
+		}},
+		{Attributes: &awsmodels.OrganizationsAccount{
+			GenericAWSResource: awsmodels.GenericAWSResource{ID: aws.String("222222222222")},
+			Status:             aws.String("ACTIVE"),
+		}},
+		{Attributes: &awsmodels.OrganizationsAccount{
+			GenericAWSResource: awsmodels.GenericAWSResource{ID: aws.String("333333333333")},
+			Status:             aws.String("SUSPENDED"),
+		}},
+	}
+	onboardOrganizationAccounts(&pollermodels.ScanEntry{IntegrationID: &testIntegrationID}, resources)
+
+	mockSourceClient.AssertNumberOfCalls(t, "Invoke", 1)
+	var onboarded sourcemodels.LambdaInput
+	require.NoError(t, jsoniter.Unmarshal(
+		mockSourceClient.Calls[0].Arguments.Get(0).(*lambda.InvokeInput).Payload, &onboarded))
+	require.NotNil(t, onboarded.OnboardOrganizationAccounts)
+	assert.Equal(t, testIntegrationID, onboarded.OnboardOrganizationAccounts.IntegrationID)
+	assert.Equal(t, []string{"222222222222"}, onboarded.OnboardOrganizationAccounts.AccountIDs)
+}
+
+func TestOnboardOrganizationAccountsNoMembers(t *testing.T) {
+	mockSourceClient := mockSourceAPI()
+
+	onboardOrganizationAccounts(&pollermodels.ScanEntry{IntegrationID: &testIntegrationID}, nil)
+
+	mockSourceClient.AssertNotCalled(t, "Invoke", mock.Anything)
+}
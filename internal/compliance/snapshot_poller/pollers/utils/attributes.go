@@ -0,0 +1,62 @@
+package utils
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+	"time"
+)
+
+// SNS and SQS return their resource attributes as a map of strings. These helpers convert
+// individual attributes, returning nil when the attribute is missing or malformed.
+
+// AttributeBool parses a boolean attribute
+func AttributeBool(attributes map[string]*string, name string) *bool {
+	value, ok := attributes[name]
+	if !ok || value == nil {
+		return nil
+	}
+	result, err := strconv.ParseBool(*value)
+	if err != nil {
+		return nil
+	}
+	return &result
+}
+
+// AttributeInt parses an integer attribute
+func AttributeInt(attributes map[string]*string, name string) *int64 {
+	value, ok := attributes[name]
+	if !ok || value == nil {
+		return nil
+	}
+	result, err := strconv.ParseInt(*value, 10, 64)
+	if err != nil {
+		return nil
+	}
+	return &result
+}
+
+// AttributeUnixTime parses an attribute holding an epoch timestamp in seconds
+func AttributeUnixTime(attributes map[string]*string, name string) *time.Time {
+	epoch := AttributeInt(attributes, name)
+	if epoch == nil {
+		return nil
+	}
+	return UnixTimeToDateTime(*epoch)
+}
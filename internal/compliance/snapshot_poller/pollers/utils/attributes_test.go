@@ -0,0 +1,43 @@
+package utils
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttributes(t *testing.T) {
+	attributes := map[string]*string{
+		"FifoQueue":         aws.String("true"),
+		"DelaySeconds":      aws.String("30"),
+		"CreatedTimestamp":  aws.String("1600000000"),
+		"VisibilityTimeout": aws.String("not a number"),
+	}
+
+	assert.Equal(t, aws.Bool(true), AttributeBool(attributes, "FifoQueue"))
+	assert.Equal(t, aws.Int64(30), AttributeInt(attributes, "DelaySeconds"))
+	assert.Equal(t, time.Unix(1600000000, 0), *AttributeUnixTime(attributes, "CreatedTimestamp"))
+	assert.Nil(t, AttributeInt(attributes, "VisibilityTimeout"))
+	assert.Nil(t, AttributeBool(attributes, "ContentBasedDeduplication"))
+	assert.Nil(t, AttributeUnixTime(attributes, "LastModifiedTimestamp"))
+}
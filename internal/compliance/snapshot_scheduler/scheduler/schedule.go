@@ -19,15 +19,19 @@ package scheduler
  */
 
 import (
+	"reflect"
+	"sort"
 	"time"
 
-	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/lambda"
 	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
 	"go.uber.org/zap"
 
 	"github.com/panther-labs/panther/api/lambda/source/models"
+	awspoller "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws"
+	gcppoller "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/gcp"
+	k8spoller "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/k8s"
 	"github.com/panther-labs/panther/pkg/genericapi"
 )
 
@@ -51,14 +55,32 @@ func PollAndIssueNewScans() error {
 
 	zap.L().Info("loaded enabled integrations", zap.Int("count", len(enabledIntegrations)))
 	var integrationsToScan []*models.SourceIntegrationMetadata
+	scanSlices := make(map[string][]models.ScanSlice)
+	now := time.Now()
 
 	for _, integration := range enabledIntegrations {
-		// Only add new scans if needed
-		if (scanIntervalElapsed(integration) && scanIsNotOngoing(integration)) || scanIsStuck(integration) {
-			integrationsToScan = append(integrationsToScan, &integration.SourceIntegrationMetadata)
-		} else {
+		// Integrations which haven't recorded any resource type scans yet are scanned in full
+		// based on the integration wide interval
+		if len(integration.ResourceScans) == 0 {
+			if (scanIntervalElapsed(integration) && scanIsNotOngoing(integration)) || scanIsStuck(integration) {
+				integrationsToScan = append(integrationsToScan, &integration.SourceIntegrationMetadata)
+			} else {
+				zap.L().Debug("skipping integration", zap.String("integrationID", integration.IntegrationID))
+			}
+			continue
+		}
+
+		// Otherwise only the resource types and regions which are due are scanned
+		var slices []models.ScanSlice
+		if scanIsNotOngoing(integration) || scanIsStuck(integration) {
+			slices = integration.DueScanSlices(scanResourceTypes(&integration.SourceIntegrationMetadata), now)
+		}
+		if len(slices) == 0 {
 			zap.L().Debug("skipping integration", zap.String("integrationID", integration.IntegrationID))
+			continue
 		}
+		integrationsToScan = append(integrationsToScan, &integration.SourceIntegrationMetadata)
+		scanSlices[integration.IntegrationID] = slices
 	}
 
 	return genericapi.Invoke(
@@ -66,23 +88,64 @@ func PollAndIssueNewScans() error {
 		sourceAPIFunctionName,
 		&models.LambdaInput{FullScan: &models.FullScanInput{
 			Integrations: integrationsToScan,
+			ScanSlices:   scanSlices,
 		}},
 		nil,
 	)
 }
 
-// GetEnabledIntegrations lists enabled integrations from the snapshot-api.
+// scanResourceTypes returns every resource type the snapshot pollers can scan for an integration.
+//
+// Organization resource types are only scanned for integrations with organization scanning enabled.
+func scanResourceTypes(integration *models.SourceIntegrationMetadata) []string {
+	switch integration.IntegrationType {
+	case models.IntegrationTypeGCPScan:
+		return sortedResourceTypes(gcppoller.ServicePollers, nil)
+	case models.IntegrationTypeK8sScan:
+		return sortedResourceTypes(k8spoller.ServicePollers, nil)
+	default:
+		return sortedResourceTypes(awspoller.ServicePollers, func(resourceType string) bool {
+			return !awspoller.IsOrganizationResourceType(resourceType) || integration.ScansOrganization()
+		})
+	}
+}
+
+// sortedResourceTypes returns the sorted keys of the ServicePollers map of a provider, keeping only the
+// resource types accepted by include (if any).
+//
+// The maps of each provider have a different (unexported) poller type, so their keys are read with reflection.
+func sortedResourceTypes(servicePollers interface{}, include func(resourceType string) bool) []string {
+	keys := reflect.ValueOf(servicePollers).MapKeys()
+	resourceTypes := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if resourceType := key.String(); include == nil || include(resourceType) {
+			resourceTypes = append(resourceTypes, resourceType)
+		}
+	}
+	sort.Strings(resourceTypes)
+	return resourceTypes
+}
+
+// GetEnabledIntegrations lists the cloud security integrations of every provider from the snapshot-api.
 func GetEnabledIntegrations() (integrations []*models.SourceIntegration, err error) {
+	var allIntegrations []*models.SourceIntegration
 	err = genericapi.Invoke(
 		lambdaClient,
 		sourceAPIFunctionName,
-		&models.LambdaInput{ListIntegrations: &models.ListIntegrationsInput{
-			IntegrationType: aws.String("aws-scan"),
-		}},
-		&integrations,
+		&models.LambdaInput{ListIntegrations: &models.ListIntegrationsInput{}},
+		&allIntegrations,
 	)
+	if err != nil {
+		return nil, err
+	}
 
-	return
+	for _, integration := range allIntegrations {
+		switch integration.IntegrationType {
+		case models.IntegrationTypeAWSScan, models.IntegrationTypeGCPScan, models.IntegrationTypeK8sScan:
+			integrations = append(integrations, integration)
+		}
+	}
+	return integrations, nil
 }
 
 // scanIsStuck checks if an integration's is stuck in the "scanning" state.
@@ -58,9 +58,7 @@ func (client *mockLambdaClient) Invoke(input *lambda.InvokeInput) (*lambda.Invok
 // getTestInvokeInput returns an example Lambda.Invoke input for the SnapshotAPI.
 func getTestInvokeInput() *lambda.InvokeInput {
 	input := &models.LambdaInput{
-		ListIntegrations: &models.ListIntegrationsInput{
-			IntegrationType: aws.String("aws-scan"),
-		},
+		ListIntegrations: &models.ListIntegrationsInput{},
 	}
 	payload, err := jsoniter.Marshal(input)
 	if err != nil {
@@ -185,6 +183,105 @@ func TestPollAndIssueNewScansNoneToRun(t *testing.T) {
 	assert.NoError(t, result)
 }
 
+func TestPollAndIssueNewScansDueSlices(t *testing.T) {
+	mockLambda := &mockLambdaClient{}
+	now := time.Now()
+
+	integration := &models.SourceIntegration{
+		SourceIntegrationMetadata: models.SourceIntegrationMetadata{
+			IntegrationID:    "45c378a7-2e36-4b12-8e16-2d3c49ff1371",
+			IntegrationType:  models.IntegrationTypeAWSScan,
+			ScanIntervalMins: 1440,
+			ResourceTypeScanConfigs: []models.ResourceTypeScanConfig{
+				{ResourceType: "AWS.IAM.Role", IntervalMins: 60, Priority: 10},
+			},
+		},
+		SourceIntegrationScanInformation: models.SourceIntegrationScanInformation{
+			ResourceScans: make(map[string]time.Time),
+		},
+	}
+	// Everything was scanned recently, except IAM roles which are scanned hourly
+	for _, resourceType := range scanResourceTypes(&integration.SourceIntegrationMetadata) {
+		integration.ResourceScans[models.ResourceScanKey(resourceType, "")] = now.Add(-2 * time.Hour)
+	}
+
+	mockLambda.
+		On("Invoke", getTestInvokeInput()).
+		Return(getTestInvokeOutput([]*models.SourceIntegration{integration}, 200), nil)
+	var fullScan models.LambdaInput
+	mockLambda.
+		On("Invoke", mock.Anything).
+		Return(getTestInvokeOutput(nil, 200), nil).
+		Run(func(args mock.Arguments) {
+			require.NoError(t, jsoniter.Unmarshal(args.Get(0).(*lambda.InvokeInput).Payload, &fullScan))
+		})
+	lambdaClient = mockLambda
+
+	result := PollAndIssueNewScans()
+
+	mockLambda.AssertExpectations(t)
+	require.NoError(t, result)
+	require.NotNil(t, fullScan.FullScan)
+	require.Len(t, fullScan.FullScan.Integrations, 1)
+	assert.Equal(t, map[string][]models.ScanSlice{
+		integration.IntegrationID: {{ResourceType: "AWS.IAM.Role", Priority: 10}},
+	}, fullScan.FullScan.ScanSlices)
+}
+
+func TestScanResourceTypesOrganization(t *testing.T) {
+	integration := &models.SourceIntegrationMetadata{IntegrationType: models.IntegrationTypeAWSScan}
+	assert.NotContains(t, scanResourceTypes(integration), "AWS.Organizations.Account")
+	assert.Contains(t, scanResourceTypes(integration), "AWS.IAM.Role")
+
+	integration.OrganizationScanEnabled = aws.Bool(true)
+	assert.Contains(t, scanResourceTypes(integration), "AWS.Organizations.Account")
+	assert.Contains(t, scanResourceTypes(integration), "AWS.IAM.Role")
+}
+
+func TestScanResourceTypesGCP(t *testing.T) {
+	integration := &models.SourceIntegrationMetadata{IntegrationType: models.IntegrationTypeGCPScan}
+	resourceTypes := scanResourceTypes(integration)
+	assert.Contains(t, resourceTypes, "GCP.Storage.Bucket")
+	assert.Contains(t, resourceTypes, "GCP.Project")
+	assert.NotContains(t, resourceTypes, "AWS.IAM.Role")
+}
+
+func TestScanResourceTypesK8s(t *testing.T) {
+	integration := &models.SourceIntegrationMetadata{IntegrationType: models.IntegrationTypeK8sScan}
+	resourceTypes := scanResourceTypes(integration)
+	assert.Contains(t, resourceTypes, "K8s.Pod")
+	assert.Contains(t, resourceTypes, "K8s.RBAC.ClusterRoleBinding")
+	assert.NotContains(t, resourceTypes, "GCP.Project")
+	assert.NotContains(t, resourceTypes, "AWS.IAM.Role")
+}
+
+func TestGetEnabledIntegrationsCloudSecurityOnly(t *testing.T) {
+	mockLambda := &mockLambdaClient{}
+	integrations := []*models.SourceIntegration{
+		{SourceIntegrationMetadata: models.SourceIntegrationMetadata{
+			IntegrationID: "45c378a7-2e36-4b12-8e16-2d3c49ff1371", IntegrationType: models.IntegrationTypeAWSScan}},
+		{SourceIntegrationMetadata: models.SourceIntegrationMetadata{
+			IntegrationID: "ebb4d69f-177b-4eff-a7a6-9251fdc72d21", IntegrationType: models.IntegrationTypeAWS3}},
+		{SourceIntegrationMetadata: models.SourceIntegrationMetadata{
+			IntegrationID: "c4e5d6f7-0a1b-4c2d-8e3f-4a5b6c7d8e9f", IntegrationType: models.IntegrationTypeGCPScan}},
+		{SourceIntegrationMetadata: models.SourceIntegrationMetadata{
+			IntegrationID: "d5f6a7b8-1c2d-4e3f-9a4b-5c6d7e8f9a0b", IntegrationType: models.IntegrationTypeK8sScan}},
+	}
+	mockLambda.
+		On("Invoke", getTestInvokeInput()).
+		Return(getTestInvokeOutput(integrations, 200), nil)
+	lambdaClient = mockLambda
+
+	result, err := GetEnabledIntegrations()
+
+	mockLambda.AssertExpectations(t)
+	require.NoError(t, err)
+	require.Len(t, result, 3)
+	assert.Equal(t, models.IntegrationTypeAWSScan, result[0].IntegrationType)
+	assert.Equal(t, models.IntegrationTypeGCPScan, result[1].IntegrationType)
+	assert.Equal(t, models.IntegrationTypeK8sScan, result[2].IntegrationType)
+}
+
 func TestPollAndIssueNewScansZeroIntegrations(t *testing.T) {
 	mockLambda := &mockLambdaClient{}
 	var emptyOutput []*models.SourceIntegration
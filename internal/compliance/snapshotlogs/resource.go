@@ -101,8 +101,10 @@ func extractIndicators(w pantherlog.ValueWriter, iter *jsoniter.Iterator, key st
 			pantherlog.ScanAWSAccountID(w, value)
 		case "Address", "AssignPublicIp", "PrivateIpAddress", "PrivateIPAddress", "PublicIpAddress", "PublicIPAddress":
 			pantherlog.ScanIPAddress(w, value)
-		case "Domain", "DomainName", "DNSName", "FQDN", "PrivateDnsName", "PublicDnsName":
+		case "CanonicalHostedZoneName", "Domain", "DomainName", "DNSName", "FQDN", "PrivateDnsName", "PublicDnsName":
 			pantherlog.ScanHostname(w, value)
+		case "ApiEndpoint":
+			pantherlog.ScanURL(w, value)
 		default:
 			switch {
 			case strings.HasSuffix(key, "ARN") || strings.HasSuffix(key, "arn") || arn.IsARN(value):
@@ -0,0 +1,304 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v2"
+
+	"github.com/panther-labs/panther/api/lambda/analysis"
+	"github.com/panther-labs/panther/api/lambda/analysis/models"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+)
+
+// Directories in the exported zipfile for each analysis type.
+//
+// BulkUpload only looks at file extensions and base names, so this layout is purely cosmetic.
+// It matches the layout of the panther-analysis repo.
+var exportDirs = map[models.DetectionType]string{
+	models.TypePolicy:    "policies",
+	models.TypeRule:      "rules",
+	models.TypeGlobal:    "global_helpers",
+	models.TypeDataModel: "data_models",
+}
+
+// BulkExport exports analysis items into a zipfile which can be re-imported with BulkUpload.
+func (API) BulkExport(input *models.BulkExportInput) *events.APIGatewayProxyResponse {
+	analysisTypes := input.AnalysisTypes
+	if len(analysisTypes) == 0 {
+		analysisTypes = []models.DetectionType{
+			models.TypePolicy, models.TypeRule, models.TypeGlobal, models.TypeDataModel}
+	}
+	filters := pythonListFilters(&pythonFilters{
+		Enabled: input.Enabled,
+		Tags:    input.Tags,
+	})
+	scanInput, err := buildScanInput(analysisTypes, nil, filters...)
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	ids := make(map[string]struct{}, len(input.IDs))
+	for _, id := range input.IDs {
+		ids[id] = struct{}{}
+	}
+
+	var items []tableItem
+	err = scanPages(scanInput, func(item tableItem) error {
+		if len(ids) > 0 {
+			if _, ok := ids[item.ID]; !ok {
+				return nil
+			}
+		}
+		items = append(items, item)
+		return nil
+	})
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	content, err := buildZipFile(items)
+	if err != nil {
+		zap.L().Error("failed to build zipfile", zap.Error(err))
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	result := models.BulkExportOutput{
+		Data: base64.StdEncoding.EncodeToString(content),
+	}
+	for _, item := range items {
+		switch item.Type {
+		case models.TypePolicy:
+			result.TotalPolicies++
+		case models.TypeRule:
+			result.TotalRules++
+		case models.TypeGlobal:
+			result.TotalGlobals++
+		case models.TypeDataModel:
+			result.TotalDataModels++
+		}
+	}
+	return gatewayapi.MarshalResponse(&result, http.StatusOK)
+}
+
+// buildZipFile writes analysis items into a zipfile in the layout read by extractZipFile.
+//
+// Each item is written as a YAML spec, along with a Python file for its body (if any).
+// Items are sorted by ID so exporting the same items always produces the same file names.
+func buildZipFile(items []tableItem) ([]byte, error) {
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].ID < items[j].ID
+	})
+
+	// extractZipFile matches bodies by their base file name, so these must be unique across directories
+	fileNames := make(map[string]struct{}, len(items))
+
+	var buffer bytes.Buffer
+	zipWriter := zip.NewWriter(&buffer)
+	for i := range items {
+		item := &items[i]
+		dir, ok := exportDirs[item.Type]
+		if !ok {
+			return nil, fmt.Errorf("%s has unknown analysis type %s", item.ID, item.Type)
+		}
+		name := exportFileName(item.ID, fileNames)
+
+		config, err := configFromTableItem(item)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export %s: %s", item.ID, err)
+		}
+		if item.Body != "" {
+			config.Filename = name + ".py"
+			if err := writeZipFile(zipWriter, path.Join(dir, config.Filename), []byte(item.Body)); err != nil {
+				return nil, err
+			}
+		}
+
+		spec, err := yaml.Marshal(&config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export %s: %s", item.ID, err)
+		}
+		if err := writeZipFile(zipWriter, path.Join(dir, name+".yml"), spec); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zipWriter.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+var unsafeFileNameChars = regexp.MustCompile(`[^A-Za-z0-9_\-]+`)
+
+// Generate a unique file name (without extension) for an analysis item ID.
+func exportFileName(id string, used map[string]struct{}) string {
+	base := strings.Trim(unsafeFileNameChars.ReplaceAllString(id, "_"), "_")
+	if base == "" {
+		base = "item"
+	}
+	name := base
+	for n := 2; ; n++ {
+		// Compare case-insensitive names so the archive can be extracted on any file system
+		if _, exists := used[strings.ToLower(name)]; !exists {
+			break
+		}
+		name = fmt.Sprintf("%s_%d", base, n)
+	}
+	used[strings.ToLower(name)] = struct{}{}
+	return name
+}
+
+func writeZipFile(w *zip.Writer, name string, content []byte) error {
+	f, err := w.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %s", name, err)
+	}
+	if _, err := f.Write(content); err != nil {
+		return fmt.Errorf("failed to write %s: %s", name, err)
+	}
+	return nil
+}
+
+// configFromTableItem is the reverse of tableItemFromConfig (except for the Filename).
+func configFromTableItem(item *tableItem) (analysis.Config, error) {
+	item.normalize()
+	config := analysis.Config{
+		AnalysisType:              strings.ToLower(string(item.Type)),
+		AutoRemediationID:         item.AutoRemediationID,
+		AutoRemediationParameters: item.AutoRemediationParameters,
+		Description:               item.Description,
+		DisplayName:               item.DisplayName,
+		Enabled:                   item.Enabled,
+		OutputIds:                 item.OutputIDs,
+		Reference:                 item.Reference,
+		Reports:                   item.Reports,
+		Runbook:                   item.Runbook,
+		Severity:                  string(item.Severity),
+		Suppressions:              item.Suppressions,
+		Tags:                      item.Tags,
+	}
+
+	switch item.Type {
+	case models.TypePolicy:
+		config.PolicyID = item.ID
+		config.ResourceTypes = item.ResourceTypes
+	case models.TypeRule:
+		config.RuleID = item.ID
+		config.LogTypes = item.ResourceTypes
+		config.DedupPeriodMinutes = item.DedupPeriodMinutes
+		config.Threshold = item.Threshold
+	case models.TypeGlobal:
+		config.GlobalID = item.ID
+		// The severity of globals and data models is always overwritten by BulkUpload
+		config.Severity = ""
+	case models.TypeDataModel:
+		config.DataModelID = item.ID
+		config.Extends = item.Extends
+		config.LogTypes = item.ResourceTypes
+		config.Severity = ""
+		for _, mapping := range item.Mappings {
+			config.Mappings = append(config.Mappings, analysis.Mapping{
+				Name:    mapping.Name,
+				Path:    mapping.Path,
+				Method:  mapping.Method,
+				LogType: mapping.LogType,
+			})
+		}
+	}
+
+	for _, test := range item.Tests {
+		resource, err := decodeTestResource(test.Resource)
+		if err != nil {
+			return config, fmt.Errorf("test %q has invalid JSON: %s", test.Name, err)
+		}
+		exported := analysis.Test{
+			ExpectedResult: test.ExpectedResult,
+			Name:           test.Name,
+		}
+		// BulkUpload treats any test with a Resource as a policy test
+		if item.Type == models.TypePolicy {
+			exported.Resource = exportedResource{resource}
+		} else {
+			exported.Log = exportedResource{resource}
+		}
+		config.Tests = append(config.Tests, exported)
+	}
+
+	return config, nil
+}
+
+// Decode a JSON test resource, keeping integers as integers so they are written to YAML unchanged.
+func decodeTestResource(resource string) (interface{}, error) {
+	decoder := json.NewDecoder(strings.NewReader(resource))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return convertJSONNumbers(value), nil
+}
+
+func convertJSONNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = convertJSONNumbers(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = convertJSONNumbers(item)
+		}
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+	}
+	return value
+}
+
+// exportedResource is never omitted from the YAML spec, even when empty.
+//
+// Otherwise an empty policy test resource would be re-imported as a rule test.
+type exportedResource struct {
+	value interface{}
+}
+
+func (r exportedResource) MarshalYAML() (interface{}, error) {
+	return r.value, nil
+}
+
+func (r exportedResource) IsZero() bool {
+	return false
+}
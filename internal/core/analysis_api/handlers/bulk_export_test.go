@@ -0,0 +1,141 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/analysis/models"
+	compliancemodels "github.com/panther-labs/panther/api/lambda/compliance/models"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/registry"
+)
+
+func TestBulkExportRoundTrip(t *testing.T) {
+	logTypesResolver = registry.NativeLogTypesResolver()
+	items := []tableItem{
+		{
+			AutoRemediationID:         "fix-it",
+			AutoRemediationParameters: map[string]string{"hello": "goodbye"},
+			Body:                      "def policy(resource):\n    return True\n",
+			Description:               "Matches every resource",
+			DisplayName:               "My Policy",
+			Enabled:                   true,
+			ID:                        "My.Policy",
+			ResourceTypes:             []string{"AWS.S3.Bucket"},
+			Severity:                  compliancemodels.SeverityHigh,
+			Suppressions:              []string{"panther.*"},
+			Tags:                      []string{"S3"},
+			Tests: []models.UnitTest{
+				{ExpectedResult: true, Name: "empty", Resource: `{}`},
+				{ExpectedResult: false, Name: "bucket", Resource: `{"Bucket":"x","Size":9007199254740993,"Ratio":0.5}`},
+			},
+			Type: models.TypePolicy,
+		},
+		{
+			Body:               "def rule(event):\n    return True\n",
+			DedupPeriodMinutes: 480,
+			Enabled:            true,
+			ID:                 "My/Rule",
+			OutputIDs:          []string{"slack"},
+			Reports:            map[string][]string{"MITRE ATT&CK": {"TA0001"}},
+			ResourceTypes:      []string{"AWS.CloudTrail"},
+			Severity:           compliancemodels.SeverityLow,
+			Tests: []models.UnitTest{
+				{ExpectedResult: true, Name: "event", Resource: `{"eventName":"ConsoleLogin","nested":[1,"a",null]}`},
+			},
+			Threshold: 42,
+			Type:      models.TypeRule,
+		},
+		{
+			Body:     "def helper():\n    pass\n",
+			ID:       "panther",
+			Severity: compliancemodels.SeverityInfo,
+			Type:     models.TypeGlobal,
+		},
+		{
+			ID: "My.DataModel",
+			Mappings: []models.DataModelMapping{
+				{Name: "source_ip", Path: "srcAddr"},
+				{Name: "source_ip", Path: "pktSrcAddr", LogType: "AWS.VPCFlow"},
+				{Name: "user", Method: "get_user"},
+			},
+			ResourceTypes: []string{"AWS.VPCFlow"},
+			Body:          "def get_user(event):\n    return None\n",
+			Severity:      compliancemodels.SeverityInfo,
+			Type:          models.TypeDataModel,
+		},
+	}
+
+	content, err := buildZipFile(append([]tableItem(nil), items...))
+	require.NoError(t, err)
+
+	zipReader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	require.NoError(t, err)
+	var fileNames []string
+	for _, f := range zipReader.File {
+		fileNames = append(fileNames, f.Name)
+	}
+	sort.Strings(fileNames)
+	expectedNames := []string{
+		"data_models/My_DataModel.py",
+		"data_models/My_DataModel.yml",
+		"global_helpers/panther.py",
+		"global_helpers/panther.yml",
+		"policies/My_Policy.py",
+		"policies/My_Policy.yml",
+		"rules/My_Rule.py",
+		"rules/My_Rule.yml",
+	}
+	assert.Equal(t, expectedNames, fileNames)
+
+	imported, err := extractZipFile(&models.BulkUploadInput{
+		Data: base64.StdEncoding.EncodeToString(content),
+	})
+	require.NoError(t, err)
+	require.Len(t, imported, len(items))
+	// Empty test resources keep the test type and integers keep their precision
+	assert.Equal(t, `{}`, imported["My.Policy"].Tests[0].Resource)
+	assert.JSONEq(t, `{"Bucket":"x","Ratio":0.5,"Size":9007199254740993}`, imported["My.Policy"].Tests[1].Resource)
+	assert.Contains(t, imported["My.Policy"].Tests[1].Resource, "9007199254740993")
+	for i := range items {
+		expected := &items[i]
+		actual, ok := imported[expected.ID]
+		require.True(t, ok, expected.ID)
+		assert.Equal(t, expected.Type, actual.Type)
+		assert.Equal(t, expected.Body, actual.Body)
+		assert.False(t, itemUpdated(expected, actual), expected.ID)
+		equal, err := policiesEqual(expected, actual)
+		require.NoError(t, err)
+		assert.True(t, equal, expected.ID)
+	}
+}
+
+func TestExportFileName(t *testing.T) {
+	used := make(map[string]struct{})
+	assert.Equal(t, "AWS_S3_Bucket", exportFileName("AWS.S3.Bucket", used))
+	assert.Equal(t, "aws_s3_bucket_2", exportFileName("aws:s3:bucket", used))
+	assert.Equal(t, "item", exportFileName("...", used))
+}
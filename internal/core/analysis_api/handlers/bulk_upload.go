@@ -57,6 +57,10 @@ func (API) BulkUpload(input *models.BulkUploadInput) *events.APIGatewayProxyResp
 		}
 	}
 
+	if input.DryRun {
+		return bulkUploadPreview(policies, input.Authoritative)
+	}
+
 	// Create/modify each policy in parallel
 	results := make(chan writeResult)
 	for _, policy := range policies {
@@ -97,40 +101,7 @@ func (API) BulkUpload(input *models.BulkUploadInput) *events.APIGatewayProxyResp
 			continue
 		}
 
-		switch result.item.Type {
-		case models.TypePolicy:
-			counts.TotalPolicies++
-			if result.changeType == newItem {
-				counts.NewPolicies++
-			} else if result.changeType == updatedItem {
-				counts.ModifiedPolicies++
-			}
-
-		case models.TypeRule:
-			counts.TotalRules++
-			if result.changeType == newItem {
-				counts.NewRules++
-			} else if result.changeType == updatedItem {
-				counts.ModifiedRules++
-			}
-
-		case models.TypeGlobal:
-			counts.TotalGlobals++
-			if result.changeType == newItem {
-				counts.NewGlobals++
-			} else if result.changeType == updatedItem {
-				counts.ModifiedGlobals++
-			}
-
-		case models.TypeDataModel:
-			counts.TotalDataModels++
-			if result.changeType == newItem {
-				counts.NewDataModels++
-			} else if result.changeType == updatedItem {
-				counts.ModifiedDataModels++
-			}
-
-		default:
+		if !countChange(&counts, result.item.Type, result.changeType) {
 			response = &events.APIGatewayProxyResponse{
 				Body:       "unknown detection type " + string(result.item.Type),
 				StatusCode: http.StatusBadRequest,
@@ -152,6 +123,47 @@ func (API) BulkUpload(input *models.BulkUploadInput) *events.APIGatewayProxyResp
 	return gatewayapi.MarshalResponse(&counts, http.StatusOK)
 }
 
+// Add an item change to the upload counts, returns false if the item type is unknown.
+func countChange(counts *models.BulkUploadOutput, itemType models.DetectionType, changeType int) bool {
+	switch itemType {
+	case models.TypePolicy:
+		counts.TotalPolicies++
+		if changeType == newItem {
+			counts.NewPolicies++
+		} else if changeType == updatedItem {
+			counts.ModifiedPolicies++
+		}
+
+	case models.TypeRule:
+		counts.TotalRules++
+		if changeType == newItem {
+			counts.NewRules++
+		} else if changeType == updatedItem {
+			counts.ModifiedRules++
+		}
+
+	case models.TypeGlobal:
+		counts.TotalGlobals++
+		if changeType == newItem {
+			counts.NewGlobals++
+		} else if changeType == updatedItem {
+			counts.ModifiedGlobals++
+		}
+
+	case models.TypeDataModel:
+		counts.TotalDataModels++
+		if changeType == newItem {
+			counts.NewDataModels++
+		} else if changeType == updatedItem {
+			counts.ModifiedDataModels++
+		}
+
+	default:
+		return false
+	}
+	return true
+}
+
 func extractZipFile(input *models.BulkUploadInput) (map[string]*tableItem, error) {
 	// Base64-decode
 	content, err := base64.StdEncoding.DecodeString(input.Data)
@@ -255,6 +267,22 @@ func extractZipFile(input *models.BulkUploadInput) (map[string]*tableItem, error
 		}
 	}
 
+	// Data models can extend each other, so they are validated once all of them are extracted
+	lookup := func(id string) (*tableItem, error) {
+		if item, ok := result[id]; ok {
+			return item, nil
+		}
+		return dynamoDataModelLookup(id)
+	}
+	for _, item := range result {
+		if item.Type != models.TypeDataModel {
+			continue
+		}
+		if err := validateDataModel(item, lookup); err != nil {
+			return nil, err
+		}
+	}
+
 	return result, nil
 }
 
@@ -316,6 +344,7 @@ func tableItemFromConfig(config analysis.Config) *tableItem {
 
 	case models.TypeDataModel:
 		item.ID = config.DataModelID
+		item.Extends = config.Extends
 		if len(config.ResourceTypes) == 0 {
 			item.ResourceTypes = config.LogTypes
 		}
@@ -351,9 +380,10 @@ func buildMapping(mapping analysis.Mapping) (models.DataModelMapping, error) {
 		return result, errPathOrMethodMissing
 	}
 	return models.DataModelMapping{
-		Name:   mapping.Name,
-		Path:   mapping.Path,
-		Method: mapping.Method,
+		Name:    mapping.Name,
+		Path:    mapping.Path,
+		Method:  mapping.Method,
+		LogType: mapping.LogType,
 	}, nil
 }
 
@@ -371,9 +401,6 @@ func readZipFile(zf *zip.File) ([]byte, error) {
 }
 
 func validateUploadedDataModel(item *tableItem) error {
-	if len(item.ResourceTypes) > 1 {
-		return errors.New("only one LogType may be specified per DataModel")
-	}
 	isEnabled, err := isSingleDataModelEnabled(item.ID, item.Enabled, item.ResourceTypes)
 	if err != nil {
 		return err
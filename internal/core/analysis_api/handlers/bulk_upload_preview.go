@@ -0,0 +1,199 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/aws/aws-lambda-go/events"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/lambda/analysis/models"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+)
+
+// Change types reported by a BulkUpload dry run
+const (
+	changeNew       = "NEW"
+	changeModified  = "MODIFIED"
+	changeUnchanged = "UNCHANGED"
+	changeRemoved   = "REMOVED"
+)
+
+// Preview the changes of a BulkUpload without writing anything to Dynamo or S3.
+//
+// Every uploaded item is compared with the existing item and the unit tests of policies and rules are run.
+func bulkUploadPreview(items map[string]*tableItem, authoritative bool) *events.APIGatewayProxyResponse {
+	ids := make([]string, 0, len(items))
+	for id := range items {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	result := models.BulkUploadOutput{
+		Changes: make([]models.BulkUploadChange, 0, len(ids)),
+	}
+	for _, id := range ids {
+		item := items[id]
+		oldItem, err := dynamoGet(id, true)
+		if err != nil {
+			return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+		}
+		if oldItem != nil && oldItem.Type != item.Type {
+			return &events.APIGatewayProxyResponse{
+				Body:       fmt.Sprintf("ID %s does not have expected type %s", item.ID, item.Type),
+				StatusCode: http.StatusConflict,
+			}
+		}
+
+		change, changeType, err := previewChange(oldItem, item)
+		if err != nil {
+			zap.L().Error("failed to compare item", zap.String("id", id), zap.Error(err))
+			return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+		}
+		if len(item.Tests) > 0 {
+			change.TestResults = runItemTests(item)
+		}
+		if !countChange(&result, item.Type, changeType) {
+			return &events.APIGatewayProxyResponse{
+				Body:       "unknown detection type " + string(item.Type),
+				StatusCode: http.StatusBadRequest,
+			}
+		}
+		result.Changes = append(result.Changes, *change)
+	}
+
+	if authoritative {
+		removed, err := removedItems(items)
+		if err != nil {
+			return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+		}
+		result.Changes = append(result.Changes, removed...)
+	}
+
+	return gatewayapi.MarshalResponse(&result, http.StatusOK)
+}
+
+// Compare an uploaded item with the existing item (if any).
+//
+// Returns the change along with the change type used for the upload counts.
+func previewChange(oldItem, item *tableItem) (*models.BulkUploadChange, int, error) {
+	change := models.BulkUploadChange{
+		ID:           item.ID,
+		AnalysisType: item.Type,
+	}
+	if oldItem == nil {
+		change.Change = changeNew
+		return &change, newItem, nil
+	}
+
+	// Compare copies so the uploaded item is not modified
+	before, after := *oldItem, *item
+	after.VersionID = "upload"
+	diff, err := diffItems(&before, &after)
+	if err != nil {
+		return nil, noChange, err
+	}
+	if diff.Body == "" && len(diff.Metadata) == 0 && len(diff.Tests) == 0 {
+		change.Change = changeUnchanged
+		return &change, noChange, nil
+	}
+	change.Change = changeModified
+	change.Body = diff.Body
+	change.Metadata = diff.Metadata
+	change.Tests = diff.Tests
+	return &change, updatedItem, nil
+}
+
+// Run the unit tests of an uploaded policy or rule
+func runItemTests(item *tableItem) *models.BulkUploadTestResults {
+	var results models.BulkUploadTestResults
+	switch item.Type {
+	case models.TypePolicy:
+		output, err := policyEngine.TestPolicy(&models.TestPolicyInput{
+			Body:          item.Body,
+			ResourceTypes: item.ResourceTypes,
+			Tests:         item.Tests,
+		})
+		if err != nil {
+			results.Error = err.Error()
+			return &results
+		}
+		for _, record := range output.Results {
+			addTestResult(&results, record.Name, record.Passed)
+		}
+	case models.TypeRule:
+		output, err := ruleEngine.TestRule(&models.TestRuleInput{
+			Body:     item.Body,
+			LogTypes: item.ResourceTypes,
+			Tests:    item.Tests,
+		})
+		if err != nil {
+			results.Error = err.Error()
+			return &results
+		}
+		for _, record := range output.Results {
+			addTestResult(&results, record.Name, record.Passed)
+		}
+	default:
+		return nil
+	}
+	return &results
+}
+
+func addTestResult(results *models.BulkUploadTestResults, name string, passed bool) {
+	if passed {
+		results.Passed++
+		return
+	}
+	results.Failed++
+	results.FailedTests = append(results.FailedTests, name)
+}
+
+// Find the existing items which are not part of the upload
+func removedItems(items map[string]*tableItem) ([]models.BulkUploadChange, error) {
+	scanInput, err := buildScanInput(
+		[]models.DetectionType{models.TypePolicy, models.TypeRule, models.TypeGlobal, models.TypeDataModel},
+		[]string{"id", "type"},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []models.BulkUploadChange
+	err = scanPages(scanInput, func(item tableItem) error {
+		if _, ok := items[item.ID]; !ok {
+			removed = append(removed, models.BulkUploadChange{
+				ID:           item.ID,
+				AnalysisType: item.Type,
+				Change:       changeRemoved,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(removed, func(i, j int) bool {
+		return removed[i].ID < removed[j].ID
+	})
+	return removed, nil
+}
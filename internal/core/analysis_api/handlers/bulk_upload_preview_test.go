@@ -0,0 +1,95 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/analysis/models"
+	compliancemodels "github.com/panther-labs/panther/api/lambda/compliance/models"
+)
+
+func TestPreviewChange(t *testing.T) {
+	existing := &tableItem{
+		Body:           "def policy(resource):\n    return True\n",
+		CreatedAt:      time.Now(),
+		CreatedBy:      "alice",
+		Enabled:        true,
+		ID:             "My.Policy",
+		LastModifiedBy: "alice",
+		LowerID:        "my.policy",
+		ResourceTypes:  []string{"AWS.S3.Bucket"},
+		Severity:       compliancemodels.SeverityLow,
+		Tags:           []string{"S3", "AWS"},
+		Tests: []models.UnitTest{
+			{Name: "test", ExpectedResult: true, Resource: `{"a":1,"b":2}`},
+		},
+		Type:      models.TypePolicy,
+		VersionID: "v1",
+	}
+	uploaded := &tableItem{
+		Body:          existing.Body,
+		Enabled:       true,
+		ID:            "My.Policy",
+		ResourceTypes: []string{"AWS.S3.Bucket"},
+		Severity:      compliancemodels.SeverityLow,
+		Tags:          []string{"AWS", "S3"},
+		Tests: []models.UnitTest{
+			{Name: "test", ExpectedResult: true, Resource: `{"b":2,"a":1}`},
+		},
+		Type: models.TypePolicy,
+	}
+
+	change, changeType, err := previewChange(nil, uploaded)
+	require.NoError(t, err)
+	assert.Equal(t, newItem, changeType)
+	assert.Equal(t, &models.BulkUploadChange{ID: "My.Policy", AnalysisType: models.TypePolicy, Change: "NEW"}, change)
+
+	// Only fields managed by the API and the order of sets and test JSON keys differ
+	change, changeType, err = previewChange(existing, uploaded)
+	require.NoError(t, err)
+	assert.Equal(t, noChange, changeType)
+	assert.Equal(t, "UNCHANGED", change.Change)
+	assert.Empty(t, change.Body)
+	assert.Empty(t, change.Metadata)
+	assert.Empty(t, change.Tests)
+
+	uploaded.Severity = compliancemodels.SeverityHigh
+	uploaded.Body = "def policy(resource):\n    return False\n"
+	change, changeType, err = previewChange(existing, uploaded)
+	require.NoError(t, err)
+	assert.Equal(t, updatedItem, changeType)
+	assert.Equal(t, "MODIFIED", change.Change)
+	assert.Contains(t, change.Body, "-    return True\n+    return False\n")
+	assert.Equal(t, []models.FieldDiff{{Field: "severity", From: `"LOW"`, To: `"HIGH"`}}, change.Metadata)
+	assert.Empty(t, change.Tests)
+	// The uploaded item is not modified
+	assert.Empty(t, uploaded.VersionID)
+}
+
+func TestAddTestResult(t *testing.T) {
+	var results models.BulkUploadTestResults
+	addTestResult(&results, "first", true)
+	addTestResult(&results, "second", false)
+	assert.Equal(t, models.BulkUploadTestResults{Passed: 1, Failed: 1, FailedTests: []string{"second"}}, results)
+}
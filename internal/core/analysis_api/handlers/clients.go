@@ -28,8 +28,13 @@ import (
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
 	"github.com/kelseyhightower/envconfig"
+	"gopkg.in/go-playground/validator.v9"
 
+	"github.com/panther-labs/panther/internal/compliance/snapshotlogs"
 	"github.com/panther-labs/panther/internal/core/analysis_api/analysis"
+	"github.com/panther-labs/panther/internal/core/logtypesapi"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/registry"
 	"github.com/panther-labs/panther/pkg/gatewayapi"
 )
 
@@ -46,6 +51,9 @@ var (
 
 	policyEngine analysis.PolicyEngine
 	ruleEngine   analysis.RuleEngine
+
+	// Resolves the schemas of native and custom log types to validate data model mappings
+	logTypesResolver logtypes.Resolver
 )
 
 type envConfig struct {
@@ -74,4 +82,16 @@ func Setup() {
 
 	policyEngine = analysis.NewPolicyEngine(lambdaClient, env.PolicyEngine)
 	ruleEngine = analysis.NewRuleEngine(lambdaClient, env.RulesEngine)
+
+	logTypesResolver = logtypes.ChainResolvers(
+		registry.NativeLogTypesResolver(),
+		snapshotlogs.Resolver(),
+		&logtypesapi.Resolver{
+			LogTypesAPI: &logtypesapi.LogTypesAPILambdaClient{
+				LambdaName: logtypesapi.LambdaName,
+				LambdaAPI:  lambdaClient,
+				Validate:   validator.New().Struct,
+			},
+		},
+	)
 }
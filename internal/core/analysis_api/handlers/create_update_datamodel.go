@@ -25,6 +25,7 @@ import (
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"go.uber.org/zap"
 
 	"github.com/panther-labs/panther/api/lambda/analysis/models"
 	"github.com/panther-labs/panther/pkg/gatewayapi"
@@ -53,6 +54,30 @@ func writeDataModel(input *models.UpdateDataModelInput, create bool) *events.API
 		}
 	}
 
+	item := &tableItem{
+		Body:          input.Body,
+		Description:   input.Description,
+		DisplayName:   input.DisplayName,
+		Enabled:       input.Enabled,
+		Extends:       input.Extends,
+		ID:            input.ID,
+		Mappings:      input.Mappings,
+		ResourceTypes: input.LogTypes,
+		Type:          models.TypeDataModel,
+	}
+
+	// check the inherited mappings and the mapping paths against the log type schemas
+	if err := validateDataModel(item, dynamoDataModelLookup); err != nil {
+		if errors.Is(err, errInvalidDataModel) {
+			return &events.APIGatewayProxyResponse{
+				Body:       err.Error(),
+				StatusCode: http.StatusBadRequest,
+			}
+		}
+		zap.L().Error("failed to validate data model", zap.String("id", input.ID), zap.Error(err))
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
 	// we only need to check for conflicting enabled DataModels if the new one is
 	// going to be enabled
 	isEnabled, err := isSingleDataModelEnabled(input.ID, input.Enabled, input.LogTypes)
@@ -69,17 +94,6 @@ func writeDataModel(input *models.UpdateDataModelInput, create bool) *events.API
 		}
 	}
 
-	item := &tableItem{
-		Body:          input.Body,
-		Description:   input.Description,
-		DisplayName:   input.DisplayName,
-		Enabled:       input.Enabled,
-		ID:            input.ID,
-		Mappings:      input.Mappings,
-		ResourceTypes: input.LogTypes,
-		Type:          models.TypeDataModel,
-	}
-
 	var statusCode int
 	if create {
 		if _, err := writeItem(item, input.UserID, aws.Bool(false)); err != nil {
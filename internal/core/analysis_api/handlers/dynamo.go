@@ -19,6 +19,7 @@ package handlers
  */
 
 import (
+	"errors"
 	"strings"
 	"time"
 
@@ -37,6 +38,8 @@ import (
 
 const (
 	maxDynamoBackoff = 30 * time.Second
+	// Maximum attempts to update the suppressions of a policy that is concurrently modified
+	maxSuppressionUpdates = 5
 )
 
 // The policy struct stored in Dynamo isn't quite the same as the policy struct returned in the API.
@@ -55,6 +58,7 @@ type tableItem struct {
 	Description               string            `json:"description,omitempty"`
 	DisplayName               string            `json:"displayName,omitempty"`
 	Enabled                   bool              `json:"enabled"`
+	Extends                   string            `json:"extends,omitempty"`
 	ID                        string            `json:"id"`
 	LastModified              time.Time         `json:"lastModified"`
 	LastModifiedBy            string            `json:"lastModifiedBy"`
@@ -77,6 +81,11 @@ type tableItem struct {
 	Tags         []string                  `json:"tags,omitempty" dynamodbav:"tags,stringset,omitempty"`
 	Tests        []models.UnitTest         `json:"tests,omitempty"`
 
+	// Audit records of the suppressions added with the Suppress action (including expired and revoked ones)
+	SuppressionDetails []suppressionRecord `json:"suppressionDetails,omitempty"`
+	// Incremented on every suppression change to detect concurrent updates
+	SuppressionsVersion int64 `json:"suppressionsVersion,omitempty"`
+
 	Type      models.DetectionType `json:"type"`
 	VersionID string               `json:"versionId,omitempty"`
 }
@@ -223,6 +232,7 @@ func (r *tableItem) DataModel() *models.DataModel {
 		Description:    r.Description,
 		DisplayName:    r.DisplayName,
 		Enabled:        r.Enabled,
+		Extends:        r.Extends,
 		ID:             r.ID,
 		LastModified:   r.LastModified,
 		LastModifiedBy: r.LastModifiedBy,
@@ -298,49 +308,85 @@ func (s stringSet) MarshalDynamoDBAttributeValue(av *dynamodb.AttributeValue) er
 	return nil
 }
 
-// Add suppressions to an existing policy, returning the updated list of policies.
-func addSuppressions(policyIDs []string, patterns []string) ([]*tableItem, error) {
-	update := expression.Add(expression.Name("suppressions"), expression.Value(stringSet(patterns)))
+// Read-modify-write the suppressions of a policy.
+//
+// The update is conditioned on the suppressions version that was read and is retried if the policy changed in between.
+// The modify function returns false if the policy does not need to change.
+// Returns the updated policy, or nil if the policy does not exist or did not change.
+func updateSuppressions(policyID string, modify func(*tableItem) bool) (*tableItem, error) {
+	for attempt := 1; ; attempt++ {
+		policy, err := dynamoGet(policyID, true)
+		if err != nil {
+			return nil, err
+		}
+		if policy == nil || policy.Type != models.TypePolicy {
+			zap.L().Warn("policy not found", zap.String("policyId", policyID))
+			return nil, nil
+		}
+		if !modify(policy) {
+			return nil, nil
+		}
+		item, err := dynamoUpdateSuppressions(policy)
+		if err == errSuppressionsChanged && attempt < maxSuppressionUpdates {
+			zap.L().Info("policy suppressions changed concurrently, retrying",
+				zap.String("policyId", policyID), zap.Int("attempt", attempt))
+			continue
+		}
+		return item, err
+	}
+}
+
+var errSuppressionsChanged = errors.New("policy suppressions changed concurrently")
+
+// Write the modified suppressions of a policy if its suppressions version did not change since it was read.
+func dynamoUpdateSuppressions(policy *tableItem) (*tableItem, error) {
+	update := expression.Set(expression.Name("suppressionDetails"), expression.Value(policy.SuppressionDetails))
+	if len(policy.Suppressions) > 0 {
+		update = update.Set(expression.Name("suppressions"), expression.Value(stringSet(policy.Suppressions)))
+	} else {
+		// string sets can not be empty
+		update = update.Remove(expression.Name("suppressions"))
+	}
+	update = update.Set(expression.Name("suppressionsVersion"), expression.Value(policy.SuppressionsVersion+1))
+
 	condition := expression.AttributeExists(expression.Name("id"))
+	if policy.SuppressionsVersion == 0 {
+		condition = condition.And(expression.AttributeNotExists(expression.Name("suppressionsVersion")))
+	} else {
+		condition = condition.And(expression.Name("suppressionsVersion").Equal(expression.Value(policy.SuppressionsVersion)))
+	}
 	expr, err := expression.NewBuilder().WithUpdate(update).WithCondition(condition).Build()
 	if err != nil {
 		zap.L().Error("failed to build update expression", zap.Error(err))
 		return nil, err
 	}
-	result := make([]*tableItem, 0, len(policyIDs))
-
-	// Dynamo does not support batch update - proceed sequentially
-	for _, policyID := range policyIDs {
-		zap.L().Info("updating policy suppressions", zap.String("policyId", policyID))
-		response, err := dynamoClient.UpdateItem(&dynamodb.UpdateItemInput{
-			ConditionExpression:       expr.Condition(),
-			ExpressionAttributeNames:  expr.Names(),
-			ExpressionAttributeValues: expr.Values(),
-			Key:                       tableKey(policyID),
-			ReturnValues:              aws.String("ALL_NEW"),
-			TableName:                 &env.Table,
-			UpdateExpression:          expr.Update(),
-		})
-
-		if err != nil {
-			aerr, ok := err.(awserr.Error)
-			if ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
-				zap.L().Warn("policy not found", zap.String("policyId", policyID))
-				continue
-			}
-			zap.L().Error("dynamoClient.UpdateItem failed", zap.Error(err))
-			return nil, err
-		}
 
-		var item tableItem
-		if err := dynamodbattribute.UnmarshalMap(response.Attributes, &item); err != nil {
-			zap.L().Error("failed to unmarshal updated policy", zap.Error(err))
-			return nil, err
+	zap.L().Info("updating policy suppressions", zap.String("policyId", policy.ID))
+	response, err := dynamoClient.UpdateItem(&dynamodb.UpdateItemInput{
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		Key:                       tableKey(policy.ID),
+		ReturnValues:              aws.String("ALL_NEW"),
+		TableName:                 &env.Table,
+		UpdateExpression:          expr.Update(),
+	})
+	if err != nil {
+		aerr, ok := err.(awserr.Error)
+		if ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			// The policy was either deleted or modified, the caller reads it again to find out
+			return nil, errSuppressionsChanged
 		}
-		result = append(result, &item)
+		zap.L().Error("dynamoClient.UpdateItem failed", zap.Error(err))
+		return nil, err
 	}
 
-	return result, nil
+	var item tableItem
+	if err := dynamodbattribute.UnmarshalMap(response.Attributes, &item); err != nil {
+		zap.L().Error("failed to unmarshal updated policy", zap.Error(err))
+		return nil, err
+	}
+	return &item, nil
 }
 
 // Write a single policy to Dynamo.
@@ -19,6 +19,7 @@ package handlers
  */
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
@@ -74,13 +75,64 @@ func (API) ListDataModels(input *models.ListDataModelsInput) *events.APIGatewayP
 		Models: make([]models.DataModel, 0, len(items)),
 		Paging: paging,
 	}
+	lookup := cachedDataModelLookup()
 	for _, item := range items {
-		result.Models = append(result.Models, *item.DataModel())
+		if !input.Resolved {
+			result.Models = append(result.Models, *item.DataModel())
+			continue
+		}
+
+		expanded, err := expandDataModel(&item, lookup)
+		if err != nil {
+			if errors.Is(err, errInvalidDataModel) {
+				// e.g. the base data model was deleted, the others can still be used
+				zap.L().Error("skipping data model", zap.String("id", item.ID), zap.Error(err))
+				continue
+			}
+			zap.L().Error("failed to resolve data model", zap.String("id", item.ID), zap.Error(err))
+			return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+		}
+		result.Models = append(result.Models, expanded...)
 	}
 
 	return gatewayapi.MarshalResponse(&result, http.StatusOK)
 }
 
+// Expand a data model into one entry per log type with the resolved mappings and body
+func expandDataModel(item *tableItem, lookup dataModelLookup) ([]models.DataModel, error) {
+	resolved, err := resolveDataModel(item, lookup)
+	if err != nil {
+		return nil, err
+	}
+
+	model := item.DataModel()
+	result := make([]models.DataModel, 0, len(model.LogTypes))
+	for _, logType := range model.LogTypes {
+		entry := *model
+		entry.Body = resolved.Body
+		entry.LogTypes = []string{logType}
+		entry.Mappings = resolved.Mappings[logType]
+		result = append(result, entry)
+	}
+	return result, nil
+}
+
+// Lookup data models in Dynamo, remembering the results so shared base data models are only read once
+func cachedDataModelLookup() dataModelLookup {
+	cache := make(map[string]*tableItem)
+	return func(id string) (*tableItem, error) {
+		if item, ok := cache[id]; ok {
+			return item, nil
+		}
+		item, err := dynamoDataModelLookup(id)
+		if err != nil {
+			return nil, err
+		}
+		cache[id] = item
+		return item, nil
+	}
+}
+
 func dataModelScanInput(input *models.ListDataModelsInput) (*dynamodb.ScanInput, error) {
 	var filters []expression.ConditionBuilder
 	if input.Enabled != nil {
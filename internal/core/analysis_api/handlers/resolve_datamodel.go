@@ -0,0 +1,248 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/panther-labs/panther/api/lambda/analysis/models"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logschema"
+)
+
+// Limits the length of a chain of data models extending each other
+const maxDataModelDepth = 10
+
+// User errors when validating a data model are wrapped with this to distinguish them from internal errors
+var errInvalidDataModel = errors.New("invalid data model")
+
+// A data model with the mappings and body of its base data models merged in
+type resolvedDataModel struct {
+	// The bodies of the base data models followed by the body of the data model itself,
+	// so that methods defined in a data model override the ones it inherits.
+	Body string
+
+	// The effective mappings for each log type of the data model
+	Mappings map[string][]models.DataModelMapping
+}
+
+// Looks up a data model by ID, returning nil if it does not exist
+type dataModelLookup func(id string) (*tableItem, error)
+
+// Lookup data models in the Dynamo table
+func dynamoDataModelLookup(id string) (*tableItem, error) {
+	return dynamoGet(id, false)
+}
+
+// Resolve the effective mappings of a data model by following the chain of base data models.
+//
+// The mappings of a data model override mappings with the same name inherited from its base.
+// Within each data model in the chain, mappings specific to a log type override the generic ones.
+func resolveDataModel(item *tableItem, lookup dataModelLookup) (*resolvedDataModel, error) {
+	chain := []*tableItem{item}
+	seen := map[string]bool{item.ID: true}
+	for baseID := item.Extends; baseID != ""; {
+		if seen[baseID] {
+			return nil, fmt.Errorf("%w %s: extends itself through %s", errInvalidDataModel, item.ID, baseID)
+		}
+		if len(chain) > maxDataModelDepth {
+			return nil, fmt.Errorf("%w %s: extends more than %d data models", errInvalidDataModel, item.ID, maxDataModelDepth)
+		}
+		seen[baseID] = true
+
+		base, err := lookup(baseID)
+		if err != nil {
+			return nil, err
+		}
+		if base == nil || base.Type != models.TypeDataModel {
+			return nil, fmt.Errorf("%w %s: base data model %s does not exist", errInvalidDataModel, item.ID, baseID)
+		}
+		chain = append(chain, base)
+		baseID = base.Extends
+	}
+
+	result := resolvedDataModel{
+		Mappings: make(map[string][]models.DataModelMapping, len(item.ResourceTypes)),
+	}
+
+	// Walk the chain from the root data model down to the item itself
+	var bodies []string
+	for i := len(chain) - 1; i >= 0; i-- {
+		if chain[i].Body != "" {
+			bodies = append(bodies, chain[i].Body)
+		}
+	}
+	result.Body = strings.Join(bodies, "\n\n")
+
+	for _, logType := range item.ResourceTypes {
+		var names []string
+		merged := make(map[string]models.DataModelMapping)
+		for i := len(chain) - 1; i >= 0; i-- {
+			// Apply the generic mappings first so the log type specific ones take precedence
+			for _, specific := range []bool{false, true} {
+				for _, mapping := range chain[i].Mappings {
+					if specific && mapping.LogType != logType || !specific && mapping.LogType != "" {
+						continue
+					}
+					if _, ok := merged[mapping.Name]; !ok {
+						names = append(names, mapping.Name)
+					}
+					mapping.LogType = ""
+					merged[mapping.Name] = mapping
+				}
+			}
+		}
+
+		if len(names) == 0 {
+			return nil, fmt.Errorf("%w %s: no mappings for log type %s", errInvalidDataModel, item.ID, logType)
+		}
+		mappings := make([]models.DataModelMapping, len(names))
+		for i, name := range names {
+			mappings[i] = merged[name]
+		}
+		result.Mappings[logType] = mappings
+	}
+
+	return &result, nil
+}
+
+// Validate the mappings of a data model, including the ones it inherits, against the schemas of its log types.
+//
+// Returns an error wrapping errInvalidDataModel if the data model is invalid.
+func validateDataModel(item *tableItem, lookup dataModelLookup) error {
+	logTypes := make(map[string]bool, len(item.ResourceTypes))
+	for _, logType := range item.ResourceTypes {
+		logTypes[logType] = true
+	}
+	for _, mapping := range item.Mappings {
+		if mapping.LogType != "" && !logTypes[mapping.LogType] {
+			return fmt.Errorf("%w %s: mapping %s applies to log type %s which is not one of its log types",
+				errInvalidDataModel, item.ID, mapping.Name, mapping.LogType)
+		}
+	}
+
+	resolved, err := resolveDataModel(item, lookup)
+	if err != nil {
+		return err
+	}
+
+	for _, logType := range item.ResourceTypes {
+		schema, err := logTypeSchema(logType)
+		if err != nil {
+			return err
+		}
+		if schema == nil {
+			return fmt.Errorf("%w %s: unknown log type %s", errInvalidDataModel, item.ID, logType)
+		}
+		for _, mapping := range resolved.Mappings[logType] {
+			if mapping.Path == "" {
+				continue
+			}
+			if err := checkSchemaPath(schema, mapping.Path); err != nil {
+				return fmt.Errorf("%w %s: mapping %s for log type %s: %s", errInvalidDataModel, item.ID, mapping.Name, logType, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Infer the value schema of the events of a native or custom log type, returns nil if the log type does not exist
+func logTypeSchema(logType string) (*logschema.ValueSchema, error) {
+	entry, err := logTypesResolver.Resolve(context.Background(), logType)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, nil
+	}
+	return logschema.InferTypeValueSchema(reflect.TypeOf(entry.Schema()))
+}
+
+// Check that a JSON path used in a data model mapping refers to a field of the event schema.
+//
+// Only the static prefix of the path is checked, the part after a wildcard, a recursive descent or
+// a filter expression (and anything nested in a JSON value) can only be resolved at runtime.
+func checkSchemaPath(schema *logschema.ValueSchema, path string) error {
+	rest := strings.TrimPrefix(path, "$")
+	value := schema
+	for rest != "" {
+		if value.Type == logschema.TypeJSON {
+			return nil
+		}
+
+		var name string
+		switch {
+		case strings.HasPrefix(rest, ".."):
+			return nil
+		case rest[0] == '.':
+			rest = rest[1:]
+			continue
+		case rest[0] == '[':
+			end := strings.IndexByte(rest, ']')
+			if end == -1 {
+				return fmt.Errorf("invalid path %q: unterminated bracket", path)
+			}
+			selector := strings.TrimSpace(rest[1:end])
+			rest = rest[end+1:]
+			if n := len(selector); n > 1 && (selector[0] == '\'' || selector[0] == '"') && selector[n-1] == selector[0] {
+				name = selector[1 : n-1]
+				break
+			}
+			if strings.ContainsAny(selector, "?(*:,") {
+				return nil
+			}
+			if value.Type != logschema.TypeArray {
+				return fmt.Errorf("invalid path %q: cannot index a value of type %s", path, value.Type)
+			}
+			value = value.Element
+			continue
+		default:
+			end := strings.IndexAny(rest, ".[")
+			if end == -1 {
+				end = len(rest)
+			}
+			name, rest = rest[:end], rest[end:]
+			if name == "*" {
+				return nil
+			}
+		}
+
+		if value.Type != logschema.TypeObject {
+			return fmt.Errorf("invalid path %q: cannot select field %s from a value of type %s", path, name, value.Type)
+		}
+		field := findSchemaField(value.Fields, name)
+		if field == nil {
+			return fmt.Errorf("invalid path %q: field %s does not exist", path, name)
+		}
+		value = &field.ValueSchema
+	}
+	return nil
+}
+
+func findSchemaField(fields []logschema.FieldSchema, name string) *logschema.FieldSchema {
+	for i := range fields {
+		if fields[i].Name == name {
+			return &fields[i]
+		}
+	}
+	return nil
+}
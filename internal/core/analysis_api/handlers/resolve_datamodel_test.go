@@ -0,0 +1,151 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/analysis/models"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/registry"
+)
+
+func testDataModelLookup(items ...*tableItem) dataModelLookup {
+	return func(id string) (*tableItem, error) {
+		for _, item := range items {
+			if item.ID == id {
+				return item, nil
+			}
+		}
+		return nil, nil
+	}
+}
+
+func TestResolveDataModel(t *testing.T) {
+	base := &tableItem{
+		Body: "def get_user(event): return 'base'\n",
+		ID:   "Base",
+		Mappings: []models.DataModelMapping{
+			{Name: "source_ip", Path: "sourceIPAddress"},
+			{Name: "user", Method: "get_user"},
+			{Name: "event_type", Path: "eventName"},
+		},
+		Type: models.TypeDataModel,
+	}
+	item := &tableItem{
+		Body:    "def get_user(event): return 'child'\n",
+		Extends: "Base",
+		ID:      "Child",
+		Mappings: []models.DataModelMapping{
+			{Name: "event_type", Path: "eventType"},
+			{Name: "source_ip", Path: "srcAddr", LogType: "AWS.VPCFlow"},
+		},
+		ResourceTypes: []string{"AWS.CloudTrail", "AWS.VPCFlow"},
+		Type:          models.TypeDataModel,
+	}
+
+	resolved, err := resolveDataModel(item, testDataModelLookup(base))
+	require.NoError(t, err)
+	assert.Equal(t, base.Body+"\n\n"+item.Body, resolved.Body)
+	assert.Equal(t, map[string][]models.DataModelMapping{
+		"AWS.CloudTrail": {
+			{Name: "source_ip", Path: "sourceIPAddress"},
+			{Name: "user", Method: "get_user"},
+			{Name: "event_type", Path: "eventType"},
+		},
+		"AWS.VPCFlow": {
+			{Name: "source_ip", Path: "srcAddr"},
+			{Name: "user", Method: "get_user"},
+			{Name: "event_type", Path: "eventType"},
+		},
+	}, resolved.Mappings)
+}
+
+func TestResolveDataModelErrors(t *testing.T) {
+	item := &tableItem{
+		Extends:       "Missing",
+		ID:            "Child",
+		ResourceTypes: []string{"AWS.CloudTrail"},
+		Type:          models.TypeDataModel,
+	}
+	_, err := resolveDataModel(item, testDataModelLookup())
+	require.True(t, errors.Is(err, errInvalidDataModel))
+	assert.Contains(t, err.Error(), "base data model Missing does not exist")
+
+	// cycle
+	other := &tableItem{ID: "Other", Extends: "Child", Type: models.TypeDataModel}
+	item.Extends = "Other"
+	_, err = resolveDataModel(item, testDataModelLookup(item, other))
+	require.True(t, errors.Is(err, errInvalidDataModel))
+	assert.Contains(t, err.Error(), "extends itself")
+
+	// no mappings
+	other.Extends = ""
+	_, err = resolveDataModel(item, testDataModelLookup(item, other))
+	require.True(t, errors.Is(err, errInvalidDataModel))
+	assert.Contains(t, err.Error(), "no mappings for log type AWS.CloudTrail")
+
+	// the base must be a data model
+	global := &tableItem{ID: "Other", Type: models.TypeGlobal}
+	_, err = resolveDataModel(item, testDataModelLookup(global))
+	require.True(t, errors.Is(err, errInvalidDataModel))
+}
+
+func TestValidateDataModel(t *testing.T) {
+	logTypesResolver = registry.NativeLogTypesResolver()
+	item := &tableItem{
+		ID: "DataModel",
+		Mappings: []models.DataModelMapping{
+			{Name: "source_ip", Path: "sourceIPAddress"},
+			{Name: "actor", Path: "$.userIdentity.arn"},
+			{Name: "resource", Path: "resources[0].arn"},
+			{Name: "bucket", Path: "$.requestParameters.bucketName"},
+			{Name: "any_ip", Path: "$.p_any_ip_addresses[*]"},
+			{Name: "user", Method: "get_user"},
+		},
+		ResourceTypes: []string{"AWS.CloudTrail"},
+		Type:          models.TypeDataModel,
+	}
+	require.NoError(t, validateDataModel(item, testDataModelLookup()))
+
+	item.Mappings[0].Path = "sourceIpAddress"
+	err := validateDataModel(item, testDataModelLookup())
+	require.True(t, errors.Is(err, errInvalidDataModel))
+	assert.Contains(t, err.Error(), "field sourceIpAddress does not exist")
+
+	item.Mappings[0].Path = "sourceIPAddress.ip"
+	err = validateDataModel(item, testDataModelLookup())
+	require.True(t, errors.Is(err, errInvalidDataModel))
+	assert.Contains(t, err.Error(), "cannot select field ip from a value of type string")
+
+	item.Mappings[0].Path = "sourceIPAddress"
+	item.Mappings = append(item.Mappings, models.DataModelMapping{Name: "x", Path: "x", LogType: "AWS.VPCFlow"})
+	err = validateDataModel(item, testDataModelLookup())
+	require.True(t, errors.Is(err, errInvalidDataModel))
+	assert.Contains(t, err.Error(), "not one of its log types")
+
+	item.Mappings = item.Mappings[:len(item.Mappings)-1]
+	item.ResourceTypes = []string{"Unknown.LogType"}
+	err = validateDataModel(item, testDataModelLookup())
+	require.True(t, errors.Is(err, errInvalidDataModel))
+	assert.Contains(t, err.Error(), "unknown log type Unknown.LogType")
+}
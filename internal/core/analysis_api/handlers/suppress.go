@@ -20,22 +20,112 @@ package handlers
 
 import (
 	"net/http"
+	"sort"
+	"time"
 
 	"github.com/aws/aws-lambda-go/events"
 	"go.uber.org/zap"
 
 	"github.com/panther-labs/panther/api/lambda/analysis/models"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
 )
 
+// The audit record of a suppression, stored with the policy in the Dynamo table.
+type suppressionRecord struct {
+	ResourcePattern string     `json:"resourcePattern"`
+	Justification   string     `json:"justification,omitempty"`
+	RequestedBy     string     `json:"requestedBy,omitempty"`
+	ApprovedBy      string     `json:"approvedBy,omitempty"`
+	TicketURL       string     `json:"ticketUrl,omitempty"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	CreatedBy       string     `json:"createdBy,omitempty"`
+	ExpiresAt       *time.Time `json:"expiresAt,omitempty"`
+	RevokedAt       *time.Time `json:"revokedAt,omitempty"`
+	RevokedBy       string     `json:"revokedBy,omitempty"`
+}
+
+func (r *suppressionRecord) status(now time.Time) models.SuppressionStatus {
+	switch {
+	case r.RevokedAt != nil:
+		return models.SuppressionRevoked
+	case r.ExpiresAt != nil && !r.ExpiresAt.After(now):
+		return models.SuppressionExpired
+	default:
+		return models.SuppressionActive
+	}
+}
+
+func (r *suppressionRecord) revoke(userID string, now time.Time) {
+	r.RevokedAt = &now
+	r.RevokedBy = userID
+}
+
+func (r *suppressionRecord) Suppression(policyID string, now time.Time) models.Suppression {
+	createdAt := r.CreatedAt
+	return models.Suppression{
+		PolicyID:        policyID,
+		ResourcePattern: r.ResourcePattern,
+		Status:          r.status(now),
+		Justification:   r.Justification,
+		RequestedBy:     r.RequestedBy,
+		ApprovedBy:      r.ApprovedBy,
+		TicketURL:       r.TicketURL,
+		CreatedAt:       &createdAt,
+		CreatedBy:       r.CreatedBy,
+		ExpiresAt:       r.ExpiresAt,
+		RevokedAt:       r.RevokedAt,
+		RevokedBy:       r.RevokedBy,
+	}
+}
+
 // Suppress adds suppressions for one or more policies in the same organization.
 func (API) Suppress(input *models.SuppressInput) *events.APIGatewayProxyResponse {
-	updates, err := addSuppressions(input.PolicyIDs, input.ResourcePatterns)
-	if err != nil {
-		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	now := time.Now().UTC()
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(now) {
+		return &events.APIGatewayProxyResponse{
+			Body:       "expiresAt must be in the future",
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	template := suppressionRecord{
+		Justification: input.Justification,
+		RequestedBy:   input.RequestedBy,
+		ApprovedBy:    input.ApprovedBy,
+		TicketURL:     input.TicketURL,
+		CreatedAt:     now,
+		CreatedBy:     input.UserID,
+		ExpiresAt:     input.ExpiresAt,
+	}
+	if template.RequestedBy == "" {
+		template.RequestedBy = input.UserID
 	}
 
-	// Update compliance status with new suppressions
-	for _, policy := range updates {
+	for _, policyID := range input.PolicyIDs {
+		policy, err := updateSuppressions(policyID, func(item *tableItem) bool {
+			for _, pattern := range input.ResourcePatterns {
+				// A new suppression of the same pattern replaces the previous one
+				for i := range item.SuppressionDetails {
+					record := &item.SuppressionDetails[i]
+					if record.ResourcePattern == pattern && record.status(now) == models.SuppressionActive {
+						record.revoke(input.UserID, now)
+					}
+				}
+				record := template
+				record.ResourcePattern = pattern
+				item.SuppressionDetails = append(item.SuppressionDetails, record)
+			}
+			item.Suppressions = setUnion(item.Suppressions, input.ResourcePatterns)
+			return true
+		})
+		if err != nil {
+			return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+		}
+		if policy == nil {
+			continue
+		}
+
+		// Update compliance status with new suppressions
 		if err := updateComplianceMetadata(policy); err != nil {
 			// Log an error, but don't mark the API call as a failure
 			zap.L().Error("failed to update compliance entries with new suppression", zap.Error(err))
@@ -44,3 +134,232 @@ func (API) Suppress(input *models.SuppressInput) *events.APIGatewayProxyResponse
 
 	return &events.APIGatewayProxyResponse{StatusCode: http.StatusOK}
 }
+
+// ListSuppressions lists the suppressions of all policies with their audit details.
+func (API) ListSuppressions(input *models.ListSuppressionsInput) *events.APIGatewayProxyResponse {
+	scanInput, err := buildScanInput([]models.DetectionType{models.TypePolicy},
+		[]string{"id", "suppressions", "suppressionDetails"})
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	policyIDs := make(map[string]bool, len(input.PolicyIDs))
+	for _, id := range input.PolicyIDs {
+		policyIDs[id] = true
+	}
+
+	now := time.Now().UTC()
+	result := models.ListSuppressionsOutput{Suppressions: []models.Suppression{}}
+	err = scanPages(scanInput, func(item tableItem) error {
+		if len(policyIDs) > 0 && !policyIDs[item.ID] {
+			return nil
+		}
+		for _, suppression := range policySuppressions(&item, now) {
+			if input.IncludeInactive || suppression.Status == models.SuppressionActive {
+				result.Suppressions = append(result.Suppressions, suppression)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		zap.L().Error("failed to scan suppressions", zap.Error(err))
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	sort.SliceStable(result.Suppressions, func(i, j int) bool {
+		left, right := result.Suppressions[i], result.Suppressions[j]
+		if left.PolicyID != right.PolicyID {
+			return left.PolicyID < right.PolicyID
+		}
+		if left.CreatedAt == nil || right.CreatedAt == nil {
+			return right.CreatedAt == nil && left.CreatedAt != nil
+		}
+		return left.CreatedAt.After(*right.CreatedAt)
+	})
+	return gatewayapi.MarshalResponse(&result, http.StatusOK)
+}
+
+// RevokeSuppressions lifts suppressions of a policy before they expire.
+//
+// The policy is re-evaluated, so failing resources which are no longer suppressed trigger alerts.
+func (API) RevokeSuppressions(input *models.RevokeSuppressionsInput) *events.APIGatewayProxyResponse {
+	now := time.Now().UTC()
+	found := false
+	var result models.RevokeSuppressionsOutput
+	policy, err := updateSuppressions(input.PolicyID, func(item *tableItem) bool {
+		found = true
+		result.Revoked = revokeSuppressions(item, input.ResourcePatterns, input.UserID, now)
+		return len(result.Revoked) > 0
+	})
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+	if !found {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusNotFound}
+	}
+
+	if policy != nil {
+		reevaluateSuppressions(policy)
+	}
+	if result.Revoked == nil {
+		result.Revoked = []models.Suppression{}
+	}
+	return gatewayapi.MarshalResponse(&result, http.StatusOK)
+}
+
+// ExpireSuppressions lifts all suppressions past their expiration time.
+//
+// The affected policies are re-evaluated, so failing resources which are no longer suppressed trigger alerts.
+func (API) ExpireSuppressions(_ *models.ExpireSuppressionsInput) *events.APIGatewayProxyResponse {
+	scanInput, err := buildScanInput([]models.DetectionType{models.TypePolicy},
+		[]string{"id", "suppressions", "suppressionDetails"})
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	// Find the patterns to remove first, then update each policy
+	now := time.Now().UTC()
+	expiredPatterns := make(map[string][]string)
+	err = scanPages(scanInput, func(item tableItem) error {
+		for _, suppression := range policySuppressions(&item, now) {
+			if suppression.Status == models.SuppressionExpired && setContains(item.Suppressions, suppression.ResourcePattern) &&
+				!hasActiveSuppression(&item, suppression.ResourcePattern, now) {
+
+				expiredPatterns[item.ID] = append(expiredPatterns[item.ID], suppression.ResourcePattern)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		zap.L().Error("failed to scan suppressions", zap.Error(err))
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	result := models.ExpireSuppressionsOutput{Expired: []models.Suppression{}}
+	for policyID, patterns := range expiredPatterns {
+		var expired []models.Suppression
+		policy, err := updateSuppressions(policyID, func(item *tableItem) bool {
+			expired = nil
+			for _, pattern := range patterns {
+				if hasActiveSuppression(item, pattern, now) {
+					continue // suppressed again in the meantime
+				}
+				for i := len(item.SuppressionDetails) - 1; i >= 0; i-- {
+					if record := item.SuppressionDetails[i]; record.ResourcePattern == pattern {
+						expired = append(expired, record.Suppression(policyID, now))
+						break
+					}
+				}
+				item.Suppressions = setDifference(item.Suppressions, []string{pattern})
+			}
+			return len(expired) > 0
+		})
+		if err != nil {
+			return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+		}
+		if policy == nil {
+			continue
+		}
+
+		zap.L().Info("suppressions expired", zap.String("policyId", policyID), zap.Int("count", len(expired)))
+		result.Expired = append(result.Expired, expired...)
+		reevaluateSuppressions(policy)
+	}
+
+	return gatewayapi.MarshalResponse(&result, http.StatusOK)
+}
+
+// Revoke the active suppressions of the given patterns, removing them from the policy.
+func revokeSuppressions(item *tableItem, patterns []string, userID string, now time.Time) []models.Suppression {
+	var revoked []models.Suppression
+	for _, pattern := range patterns {
+		if !setContains(item.Suppressions, pattern) {
+			continue
+		}
+		found := false
+		for i := range item.SuppressionDetails {
+			record := &item.SuppressionDetails[i]
+			if record.ResourcePattern == pattern && record.status(now) != models.SuppressionRevoked {
+				record.revoke(userID, now)
+				revoked = append(revoked, record.Suppression(item.ID, now))
+				found = true
+			}
+		}
+		if !found {
+			// A suppression without audit details, record who revoked it
+			record := suppressionRecord{ResourcePattern: pattern, CreatedAt: now}
+			record.revoke(userID, now)
+			item.SuppressionDetails = append(item.SuppressionDetails, record)
+			revoked = append(revoked, models.Suppression{
+				PolicyID: item.ID, ResourcePattern: pattern, Status: models.SuppressionRevoked,
+				RevokedAt: record.RevokedAt, RevokedBy: userID,
+			})
+		}
+		item.Suppressions = setDifference(item.Suppressions, []string{pattern})
+	}
+	return revoked
+}
+
+// Revoke the active suppressions whose pattern was removed by editing the policy directly.
+func revokeRemovedSuppressions(item *tableItem, userID string, now time.Time) {
+	for i := range item.SuppressionDetails {
+		record := &item.SuppressionDetails[i]
+		if record.status(now) == models.SuppressionActive && !setContains(item.Suppressions, record.ResourcePattern) {
+			record.revoke(userID, now)
+		}
+	}
+}
+
+// All suppressions of a policy: the audit records and the patterns which were added without one.
+func policySuppressions(item *tableItem, now time.Time) []models.Suppression {
+	result := make([]models.Suppression, 0, len(item.SuppressionDetails)+len(item.Suppressions))
+	for _, record := range item.SuppressionDetails {
+		result = append(result, record.Suppression(item.ID, now))
+	}
+	for _, pattern := range item.Suppressions {
+		if !hasSuppressionRecord(item, pattern, now) {
+			result = append(result, models.Suppression{
+				PolicyID:        item.ID,
+				ResourcePattern: pattern,
+				Status:          models.SuppressionActive,
+			})
+		}
+	}
+	return result
+}
+
+// Returns true if the pattern has an audit record which is active or expired but not yet lifted
+func hasSuppressionRecord(item *tableItem, pattern string, now time.Time) bool {
+	for i := range item.SuppressionDetails {
+		record := &item.SuppressionDetails[i]
+		if record.ResourcePattern == pattern && record.status(now) != models.SuppressionRevoked {
+			return true
+		}
+	}
+	return false
+}
+
+func hasActiveSuppression(item *tableItem, pattern string, now time.Time) bool {
+	for i := range item.SuppressionDetails {
+		record := &item.SuppressionDetails[i]
+		if record.ResourcePattern == pattern && record.status(now) == models.SuppressionActive {
+			return true
+		}
+	}
+	return false
+}
+
+// Queue an enabled policy for analysis after lifting suppressions.
+//
+// Updating the compliance metadata directly would mark the failing resources as unsuppressed
+// without alerting, the resource processor alerts on failures which were previously suppressed.
+func reevaluateSuppressions(policy *tableItem) {
+	if !policy.Enabled {
+		return
+	}
+	if err := queuePolicy(policy); err != nil {
+		// The status will still be updated on the next daily scan
+		zap.L().Error("failed to queue policy after lifting suppressions",
+			zap.String("policyId", policy.ID), zap.Error(err))
+	}
+}
@@ -0,0 +1,161 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/analysis/models"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+func TestSuppressionRecordStatus(t *testing.T) {
+	now := time.Now().UTC()
+	past, future := now.Add(-time.Minute), now.Add(time.Minute)
+
+	record := suppressionRecord{ResourcePattern: "prod.*", CreatedAt: past}
+	assert.Equal(t, models.SuppressionActive, record.status(now))
+	record.ExpiresAt = &future
+	assert.Equal(t, models.SuppressionActive, record.status(now))
+	record.ExpiresAt = &past
+	assert.Equal(t, models.SuppressionExpired, record.status(now))
+	record.revoke("user", now)
+	assert.Equal(t, models.SuppressionRevoked, record.status(now))
+	assert.Equal(t, "user", record.RevokedBy)
+}
+
+func TestPolicySuppressions(t *testing.T) {
+	now := time.Now().UTC()
+	past := now.Add(-time.Hour)
+	item := &tableItem{
+		ID:           "Policy",
+		Suppressions: []string{"expired.*", "legacy.*", "prod.*"},
+		SuppressionDetails: []suppressionRecord{
+			{ResourcePattern: "expired.*", CreatedAt: past, ExpiresAt: &past},
+			{ResourcePattern: "prod.*", CreatedAt: past, Justification: "known issue", TicketURL: "https://tickets/1"},
+		},
+	}
+
+	result := policySuppressions(item, now)
+	require.Len(t, result, 3)
+	assert.Equal(t, models.SuppressionExpired, result[0].Status)
+	assert.Equal(t, models.SuppressionActive, result[1].Status)
+	assert.Equal(t, "known issue", result[1].Justification)
+	// suppressions added by editing the policy have no audit record
+	assert.Equal(t, models.Suppression{
+		PolicyID:        "Policy",
+		ResourcePattern: "legacy.*",
+		Status:          models.SuppressionActive,
+	}, result[2])
+
+	assert.False(t, hasActiveSuppression(item, "expired.*", now))
+	assert.True(t, hasActiveSuppression(item, "prod.*", now))
+}
+
+func TestRevokeSuppressions(t *testing.T) {
+	now := time.Now().UTC()
+	item := &tableItem{
+		ID:           "Policy",
+		Suppressions: []string{"legacy.*", "prod.*", "dev.*"},
+		SuppressionDetails: []suppressionRecord{
+			{ResourcePattern: "prod.*", CreatedAt: now.Add(-time.Hour)},
+		},
+	}
+
+	revoked := revokeSuppressions(item, []string{"prod.*", "legacy.*", "unknown.*"}, "user", now)
+	require.Len(t, revoked, 2)
+	assert.Equal(t, "prod.*", revoked[0].ResourcePattern)
+	assert.Equal(t, models.SuppressionRevoked, revoked[0].Status)
+	assert.Equal(t, "legacy.*", revoked[1].ResourcePattern)
+	assert.Equal(t, "user", revoked[1].RevokedBy)
+
+	assert.Equal(t, []string{"dev.*"}, item.Suppressions)
+	// the legacy suppression now has a record of who revoked it
+	require.Len(t, item.SuppressionDetails, 2)
+	assert.Equal(t, models.SuppressionRevoked, item.SuppressionDetails[1].status(now))
+}
+
+func TestRevokeRemovedSuppressions(t *testing.T) {
+	now := time.Now().UTC()
+	item := &tableItem{
+		Suppressions: []string{"dev.*"},
+		SuppressionDetails: []suppressionRecord{
+			{ResourcePattern: "prod.*", CreatedAt: now},
+			{ResourcePattern: "dev.*", CreatedAt: now},
+		},
+	}
+	revokeRemovedSuppressions(item, "user", now)
+	assert.Equal(t, models.SuppressionRevoked, item.SuppressionDetails[0].status(now))
+	assert.Equal(t, models.SuppressionActive, item.SuppressionDetails[1].status(now))
+}
+
+func TestUpdateSuppressionsRetriesConcurrentChanges(t *testing.T) {
+	mockDynamo := &testutils.DynamoDBMock{}
+	dynamoClient = mockDynamo
+	defer func() { dynamoClient = nil }()
+
+	getItem := func(version int64, suppressions ...string) *dynamodb.GetItemOutput {
+		item, err := dynamodbattribute.MarshalMap(&tableItem{
+			ID:                  "policy.id",
+			Type:                models.TypePolicy,
+			Suppressions:        suppressions,
+			SuppressionsVersion: version,
+		})
+		require.NoError(t, err)
+		return &dynamodb.GetItemOutput{Item: item}
+	}
+	// The policy is suppressed concurrently between the first read and write
+	mockDynamo.On("GetItem", mock.Anything).Return(getItem(0), nil).Once()
+	mockDynamo.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{},
+		awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "changed", nil)).Once()
+	mockDynamo.On("GetItem", mock.Anything).Return(getItem(1, "staging.*"), nil).Once()
+	updated, err := dynamodbattribute.MarshalMap(&tableItem{
+		ID:                  "policy.id",
+		Type:                models.TypePolicy,
+		Suppressions:        []string{"prod.*", "staging.*"},
+		SuppressionsVersion: 2,
+	})
+	require.NoError(t, err)
+	// The retry is conditioned on the version read the second time
+	mockDynamo.On("UpdateItem", mock.MatchedBy(func(input *dynamodb.UpdateItemInput) bool {
+		versions := map[string]bool{}
+		for _, value := range input.ExpressionAttributeValues {
+			versions[aws.StringValue(value.N)] = true
+		}
+		return versions["1"] && versions["2"]
+	})).Return(&dynamodb.UpdateItemOutput{Attributes: updated}, nil).Once()
+
+	policy, err := updateSuppressions("policy.id", func(item *tableItem) bool {
+		item.Suppressions = append(item.Suppressions, "prod.*")
+		return true
+	})
+	require.NoError(t, err)
+	require.NotNil(t, policy)
+	assert.Equal(t, []string{"prod.*", "staging.*"}, policy.Suppressions)
+	mockDynamo.AssertExpectations(t)
+}
@@ -85,6 +85,29 @@ func setDifference(first, second []string) (result []string) {
 	return
 }
 
+// Compute the set union - items in either set, in the order they first appear
+func setUnion(first, second []string) (result []string) {
+	seen := make(map[string]bool, len(first)+len(second))
+	for _, x := range append(append([]string(nil), first...), second...) {
+		if !seen[x] {
+			seen[x] = true
+			result = append(result, x)
+		}
+	}
+
+	return
+}
+
+// Returns true if the set contains the item
+func setContains(set []string, item string) bool {
+	for _, x := range set {
+		if x == item {
+			return true
+		}
+	}
+	return false
+}
+
 // Returns true if the two string slices have the same unique elements in any order
 func setEquality(first, second []string) bool {
 	firstMap := make(map[string]struct{}, len(first))
@@ -199,6 +222,10 @@ func writeItem(item *tableItem, userID string, mustExist *bool) (int, error) {
 
 		item.CreatedAt = oldItem.CreatedAt
 		item.CreatedBy = oldItem.CreatedBy
+		// Suppression audit records are only managed by the suppression actions
+		item.SuppressionDetails = oldItem.SuppressionDetails
+		item.SuppressionsVersion = oldItem.SuppressionsVersion
+		revokeRemovedSuppressions(item, userID, time.Now().UTC())
 		if itemUpdated(oldItem, item) {
 			changeType = updatedItem
 		}
@@ -248,7 +275,8 @@ func itemUpdated(oldItem, newItem *tableItem) bool {
 		oldItem.Description == newItem.Description &&
 		setEquality(oldItem.OutputIDs, newItem.OutputIDs) &&
 		oldItem.DisplayName == newItem.DisplayName &&
-		oldItem.Enabled == newItem.Enabled && oldItem.Reference == newItem.Reference &&
+		oldItem.Enabled == newItem.Enabled && oldItem.Extends == newItem.Extends &&
+		oldItem.Reference == newItem.Reference &&
 		oldItem.Runbook == newItem.Runbook && oldItem.Severity == newItem.Severity &&
 		oldItem.DedupPeriodMinutes == newItem.DedupPeriodMinutes &&
 		oldItem.Threshold == newItem.Threshold &&
@@ -316,12 +344,14 @@ func itemUpdated(oldItem, newItem *tableItem) bool {
 }
 
 func mappingEquality(oldItem, newItem *tableItem) bool {
-	oldMappings := make(map[string]models.DataModelMapping)
+	// A mapping is identified by its name and the log type it is restricted to (if any)
+	type mappingKey struct{ name, logType string }
+	oldMappings := make(map[mappingKey]models.DataModelMapping)
 	for _, mapping := range oldItem.Mappings {
-		oldMappings[mapping.Name] = mapping
+		oldMappings[mappingKey{mapping.Name, mapping.LogType}] = mapping
 	}
 	for _, newMapping := range newItem.Mappings {
-		oldMapping, ok := oldMappings[newMapping.Name]
+		oldMapping, ok := oldMappings[mappingKey{newMapping.Name, newMapping.LogType}]
 		if !ok ||
 			oldMapping.Name != newMapping.Name ||
 			oldMapping.Path != newMapping.Path ||
@@ -0,0 +1,386 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pmezard/go-difflib/difflib"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/lambda/analysis/models"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+)
+
+const defaultVersionsPageSize = 25
+
+// Every write of an analysis item stores a full copy of the item in a new version of its S3 object.
+// The stored copy records who modified the item and when, so the version history can be
+// reconstructed from S3 alone.
+
+// ListDetectionVersions lists the stored versions of an analysis item, newest first.
+func (API) ListDetectionVersions(input *models.ListDetectionVersionsInput) *events.APIGatewayProxyResponse {
+	pageSize := input.PageSize
+	if pageSize == 0 {
+		pageSize = defaultVersionsPageSize
+	}
+
+	versionIDs, next, err := s3ListVersions(input.ID, input.VersionIDMarker, pageSize)
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+	if len(versionIDs) == 0 && input.VersionIDMarker == "" {
+		return &events.APIGatewayProxyResponse{
+			Body:       fmt.Sprintf("Cannot find %s", input.ID),
+			StatusCode: http.StatusNotFound,
+		}
+	}
+
+	result := models.ListDetectionVersionsOutput{
+		Versions:      make([]models.DetectionVersion, 0, len(versionIDs)),
+		NextVersionID: next,
+	}
+	for _, v := range versionIDs {
+		item, err := s3Get(input.ID, v.versionID)
+		if err != nil {
+			return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+		}
+		version := detectionVersion(item)
+		version.IsLatest = v.isLatest
+		result.Versions = append(result.Versions, version)
+	}
+	return gatewayapi.MarshalResponse(&result, http.StatusOK)
+}
+
+// DiffDetectionVersions compares the body, metadata and unit tests of two versions of an analysis item.
+func (API) DiffDetectionVersions(input *models.DiffDetectionVersionsInput) *events.APIGatewayProxyResponse {
+	from, err := s3Get(input.ID, input.FromVersionID)
+	if err != nil {
+		return versionErrorResponse(input.ID, input.FromVersionID, err)
+	}
+
+	var to *tableItem
+	if input.ToVersionID == "" {
+		to, err = dynamoGet(input.ID, false)
+		if err == nil && to == nil {
+			return &events.APIGatewayProxyResponse{
+				Body:       fmt.Sprintf("Cannot find %s", input.ID),
+				StatusCode: http.StatusNotFound,
+			}
+		}
+	} else {
+		to, err = s3Get(input.ID, input.ToVersionID)
+	}
+	if err != nil {
+		return versionErrorResponse(input.ID, input.ToVersionID, err)
+	}
+
+	result, err := diffItems(from, to)
+	if err != nil {
+		zap.L().Error("failed to diff versions", zap.String("id", input.ID), zap.Error(err))
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+	return gatewayapi.MarshalResponse(result, http.StatusOK)
+}
+
+// RevertDetection restores a previous version of an analysis item.
+//
+// The restored item is saved as a new version, so the history in between is preserved.
+func (API) RevertDetection(input *models.RevertDetectionInput) *events.APIGatewayProxyResponse {
+	item, err := s3Get(input.ID, input.VersionID)
+	if err != nil {
+		return versionErrorResponse(input.ID, input.VersionID, err)
+	}
+
+	if item.Type == models.TypeDataModel {
+		isEnabled, err := isSingleDataModelEnabled(item.ID, item.Enabled, item.ResourceTypes)
+		if err != nil {
+			return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+		}
+		if !isEnabled {
+			return &events.APIGatewayProxyResponse{
+				Body:       errMultipleDataModelsEnabled.Error(),
+				StatusCode: http.StatusBadRequest,
+			}
+		}
+	}
+
+	// The item may have been deleted since, in which case it is re-created
+	if _, err := writeItem(item, input.UserID, nil); err != nil {
+		if err == errWrongType {
+			return &events.APIGatewayProxyResponse{
+				Body:       fmt.Sprintf("%s has been replaced with a different analysis type", input.ID),
+				StatusCode: http.StatusConflict,
+			}
+		}
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	if item.Type == models.TypeGlobal {
+		if err := updateLayer(); err != nil {
+			return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+		}
+	}
+
+	return gatewayapi.MarshalResponse(item.Detection(""), http.StatusOK)
+}
+
+func versionErrorResponse(id, versionID string, err error) *events.APIGatewayProxyResponse {
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch awsErr.Code() {
+		case s3.ErrCodeNoSuchKey, "NoSuchVersion", "InvalidArgument":
+			return &events.APIGatewayProxyResponse{
+				Body:       fmt.Sprintf("Cannot find version %s of %s", versionID, id),
+				StatusCode: http.StatusNotFound,
+			}
+		}
+	}
+	return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+}
+
+func detectionVersion(item *tableItem) models.DetectionVersion {
+	return models.DetectionVersion{
+		AnalysisType:   item.Type,
+		ID:             item.ID,
+		LastModified:   item.LastModified,
+		LastModifiedBy: item.LastModifiedBy,
+		VersionID:      item.VersionID,
+	}
+}
+
+type objectVersion struct {
+	versionID string
+	isLatest  bool
+}
+
+// List the S3 versions of a single item, newest first.
+//
+// Returns the version ID to use as a marker for the next page (if any).
+func s3ListVersions(id, versionIDMarker string, pageSize int) ([]objectVersion, string, error) {
+	input := &s3.ListObjectVersionsInput{
+		Bucket: &env.Bucket,
+		// Other items may share the same prefix, they are sorted after this one
+		Prefix:  &id,
+		MaxKeys: aws.Int64(int64(pageSize + 1)),
+	}
+	if versionIDMarker != "" {
+		input.KeyMarker = &id
+		input.VersionIdMarker = &versionIDMarker
+	}
+
+	var versions []objectVersion
+	err := s3Client.ListObjectVersionsPages(input, func(page *s3.ListObjectVersionsOutput, lastPage bool) bool {
+		for _, v := range page.Versions {
+			if aws.StringValue(v.Key) != id || len(versions) > pageSize {
+				return false
+			}
+			versions = append(versions, objectVersion{
+				versionID: aws.StringValue(v.VersionId),
+				isLatest:  aws.BoolValue(v.IsLatest),
+			})
+		}
+		return len(versions) <= pageSize
+	})
+	if err != nil {
+		zap.L().Error("s3Client.ListObjectVersionsPages failed", zap.Error(err))
+		return nil, "", err
+	}
+
+	if len(versions) > pageSize {
+		versions = versions[:pageSize]
+		return versions, versions[pageSize-1].versionID, nil
+	}
+	return versions, "", nil
+}
+
+// Fields which are not compared in metadata diffs
+var diffIgnoredFields = map[string]struct{}{
+	"body":               {},
+	"createdAt":          {},
+	"createdBy":          {},
+	"lastModified":       {},
+	"lastModifiedBy":     {},
+	"lowerDisplayName":   {},
+	"lowerId":            {},
+	"lowerTags":          {},
+	"suppressionDetails": {},
+	"tests":              {},
+	"versionId":          {},
+}
+
+func diffItems(from, to *tableItem) (*models.DiffDetectionVersionsOutput, error) {
+	from.normalize()
+	to.normalize()
+	result := models.DiffDetectionVersionsOutput{
+		From:     detectionVersion(from),
+		To:       detectionVersion(to),
+		Metadata: []models.FieldDiff{},
+		Tests:    []models.TestDiff{},
+	}
+
+	var err error
+	result.Body, err = unifiedDiff(from.Body, to.Body, from.VersionID, to.VersionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Metadata, err = diffMetadata(from, to); err != nil {
+		return nil, err
+	}
+
+	fromTests := make(map[string]models.UnitTest, len(from.Tests))
+	for _, test := range from.Tests {
+		fromTests[test.Name] = test
+	}
+	toTests := make(map[string]models.UnitTest, len(to.Tests))
+	for _, test := range to.Tests {
+		toTests[test.Name] = test
+	}
+	for _, test := range from.Tests {
+		if _, ok := toTests[test.Name]; !ok {
+			diff, err := unifiedDiff(formatTest(test), "", from.VersionID, to.VersionID)
+			if err != nil {
+				return nil, err
+			}
+			result.Tests = append(result.Tests, models.TestDiff{Name: test.Name, Change: "REMOVED", Diff: diff})
+		}
+	}
+	for _, test := range to.Tests {
+		change := "ADDED"
+		var before string
+		if old, ok := fromTests[test.Name]; ok {
+			change = "MODIFIED"
+			before = formatTest(old)
+		}
+		diff, err := unifiedDiff(before, formatTest(test), from.VersionID, to.VersionID)
+		if err != nil {
+			return nil, err
+		}
+		if diff != "" {
+			result.Tests = append(result.Tests, models.TestDiff{Name: test.Name, Change: change, Diff: diff})
+		}
+	}
+	return &result, nil
+}
+
+func diffMetadata(from, to *tableItem) ([]models.FieldDiff, error) {
+	fromFields, err := itemFields(from)
+	if err != nil {
+		return nil, err
+	}
+	toFields, err := itemFields(to)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(fromFields)+len(toFields))
+	for name := range fromFields {
+		names = append(names, name)
+	}
+	for name := range toFields {
+		if _, ok := fromFields[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	result := []models.FieldDiff{}
+	for _, name := range names {
+		if _, ignored := diffIgnoredFields[name]; ignored {
+			continue
+		}
+		fromValue, toValue := fromFields[name], toFields[name]
+		if reflect.DeepEqual(fromValue, toValue) {
+			continue
+		}
+		diff := models.FieldDiff{Field: name}
+		if diff.From, err = jsoniter.MarshalToString(fromValue); err != nil {
+			return nil, err
+		}
+		if diff.To, err = jsoniter.MarshalToString(toValue); err != nil {
+			return nil, err
+		}
+		result = append(result, diff)
+	}
+	return result, nil
+}
+
+// Convert an item to a map of its JSON fields
+func itemFields(item *tableItem) (map[string]interface{}, error) {
+	data, err := jsoniter.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if err := jsoniter.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// Format a unit test as indented JSON with sorted keys so that diffs are readable
+func formatTest(test models.UnitTest) string {
+	resource := test.Resource
+	decoder := json.NewDecoder(strings.NewReader(resource))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err == nil {
+		if formatted, err := json.MarshalIndent(value, "", "  "); err == nil {
+			resource = string(formatted)
+		}
+	}
+	return fmt.Sprintf("expectedResult: %t\nresource: %s\n", test.ExpectedResult, resource)
+}
+
+func unifiedDiff(from, to, fromVersion, toVersion string) (string, error) {
+	if from == to {
+		return "", nil
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(from),
+		B:        splitLines(to),
+		FromFile: fromVersion,
+		ToFile:   toVersion,
+		Context:  3,
+	})
+}
+
+// Split text into lines, each ending with a newline
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if last := len(lines) - 1; lines[last] == "" {
+		lines = lines[:last]
+	} else {
+		lines[last] += "\n"
+	}
+	return lines
+}
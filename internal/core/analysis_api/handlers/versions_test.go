@@ -0,0 +1,129 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/analysis/models"
+	compliancemodels "github.com/panther-labs/panther/api/lambda/compliance/models"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+func TestS3ListVersions(t *testing.T) {
+	mockS3 := &testutils.S3Mock{}
+	s3Client = mockS3
+	env.Bucket = "versions"
+
+	page := &s3.ListObjectVersionsOutput{
+		Versions: []*s3.ObjectVersion{
+			{Key: aws.String("Rule"), VersionId: aws.String("v3"), IsLatest: aws.Bool(true)},
+			{Key: aws.String("Rule"), VersionId: aws.String("v2")},
+			{Key: aws.String("Rule"), VersionId: aws.String("v1")},
+			{Key: aws.String("Rule.Other"), VersionId: aws.String("o1"), IsLatest: aws.Bool(true)},
+		},
+	}
+	mockS3.On("ListObjectVersionsPages", mock.Anything, mock.Anything).Return(page, nil)
+
+	versions, next, err := s3ListVersions("Rule", "", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []objectVersion{{versionID: "v3", isLatest: true}, {versionID: "v2"}}, versions)
+	assert.Equal(t, "v2", next)
+
+	versions, next, err = s3ListVersions("Rule", "", 5)
+	require.NoError(t, err)
+	assert.Len(t, versions, 3)
+	assert.Empty(t, next)
+}
+
+func TestDiffItems(t *testing.T) {
+	from := &tableItem{
+		Body:           "def rule(event):\n    return True\n",
+		ID:             "My.Rule",
+		LastModifiedBy: "alice",
+		ResourceTypes:  []string{"AWS.CloudTrail"},
+		Severity:       compliancemodels.SeverityLow,
+		Tags:           []string{"b", "a"},
+		Tests: []models.UnitTest{
+			{Name: "removed", ExpectedResult: true, Resource: `{}`},
+			{Name: "modified", ExpectedResult: true, Resource: `{"a":1}`},
+			{Name: "unchanged", ExpectedResult: true, Resource: `{"a":1}`},
+		},
+		Type:      models.TypeRule,
+		VersionID: "from",
+	}
+	to := &tableItem{
+		Body:           "def rule(event):\n    return False\n",
+		ID:             "My.Rule",
+		LastModifiedBy: "bob",
+		ResourceTypes:  []string{"AWS.CloudTrail"},
+		Severity:       compliancemodels.SeverityHigh,
+		Tags:           []string{"a", "b"},
+		Tests: []models.UnitTest{
+			{Name: "unchanged", ExpectedResult: true, Resource: `{"a":1}`},
+			{Name: "modified", ExpectedResult: false, Resource: `{"a":2}`},
+			{Name: "added", ExpectedResult: false, Resource: `{}`},
+		},
+		Type:      models.TypeRule,
+		VersionID: "to",
+	}
+
+	result, err := diffItems(from, to)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", result.From.LastModifiedBy)
+	assert.Equal(t, "bob", result.To.LastModifiedBy)
+	expectedBody := `--- from
++++ to
+@@ -1,2 +1,2 @@
+ def rule(event):
+-    return True
++    return False
+`
+	assert.Equal(t, expectedBody, result.Body)
+	assert.Equal(t, []models.FieldDiff{{Field: "severity", From: `"LOW"`, To: `"HIGH"`}}, result.Metadata)
+
+	require.Len(t, result.Tests, 3)
+	assert.Equal(t, "removed", result.Tests[0].Name)
+	assert.Equal(t, "REMOVED", result.Tests[0].Change)
+	assert.Equal(t, "modified", result.Tests[1].Name)
+	assert.Equal(t, "MODIFIED", result.Tests[1].Change)
+	assert.Contains(t, result.Tests[1].Diff, "-expectedResult: true\n+expectedResult: false\n")
+	assert.Contains(t, result.Tests[1].Diff, "-  \"a\": 1\n+  \"a\": 2\n")
+	assert.Equal(t, "added", result.Tests[2].Name)
+	assert.Equal(t, "ADDED", result.Tests[2].Change)
+
+	// Identical versions have no differences
+	result, err = diffItems(from, from)
+	require.NoError(t, err)
+	assert.Empty(t, result.Body)
+	assert.Empty(t, result.Metadata)
+	assert.Empty(t, result.Tests)
+}
+
+func TestSplitLines(t *testing.T) {
+	assert.Nil(t, splitLines(""))
+	assert.Equal(t, []string{"a\n"}, splitLines("a"))
+	assert.Equal(t, []string{"a\n", "b\n"}, splitLines("a\nb\n"))
+}
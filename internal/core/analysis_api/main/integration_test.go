@@ -119,7 +119,7 @@ var (
 		Mappings: []models.DataModelMapping{
 			{
 				Name: "source_ip",
-				Path: "ipAddress",
+				Path: "ipaddr",
 			},
 		},
 	}
@@ -128,11 +128,11 @@ var (
 		Description: "Example LogType Schema",
 		Enabled:     true,
 		ID:          "SecondDataModelTypeAnalysis",
-		LogTypes:    []string{"Box.Events"},
+		LogTypes:    []string{"Box.Event"},
 		Mappings: []models.DataModelMapping{
 			{
 				Name: "source_ip",
-				Path: "ipAddress",
+				Path: "ip_address",
 			},
 		},
 	}
@@ -141,27 +141,27 @@ var (
 		Description: "Example LogType Schema",
 		Enabled:     false,
 		ID:          "ThirdDataModelTypeAnalysis",
-		LogTypes:    []string{"Box.Events"},
+		LogTypes:    []string{"Box.Event"},
 		Mappings: []models.DataModelMapping{
 			{
 				Name: "source_ip",
-				Path: "ipAddress",
+				Path: "ip_address",
 			},
 		},
 	}
 	dataModels           = [3]*models.DataModel{dataModel, dataModelTwo, dataModelDisabled}
 	dataModelFromBulkYML = &models.DataModel{
 		Enabled:  true,
-		ID:       "Some.Events.DataModel",
-		LogTypes: []string{"Some.Events"},
+		ID:       "AWS.VPCFlow.DataModel",
+		LogTypes: []string{"AWS.VPCFlow"},
 		Mappings: []models.DataModelMapping{
 			{
 				Name: "source_ip",
-				Path: "ipAddress",
+				Path: "srcAddr",
 			},
 			{
 				Name: "dest_ip",
-				Path: "destAddress",
+				Path: "dstAddr",
 			},
 		},
 	}
@@ -266,6 +266,10 @@ func TestIntegrationAPI(t *testing.T) {
 		return
 	}
 
+	t.Run("BulkExport", func(t *testing.T) {
+		t.Run("BulkExportRoundTrip", bulkExportRoundTrip)
+	})
+
 	t.Run("List", func(t *testing.T) {
 		t.Run("ListPolicies", listPolicies)
 		t.Run("ListFiltered", listFiltered)
@@ -297,6 +301,11 @@ func TestIntegrationAPI(t *testing.T) {
 		t.Run("SuppressSuccess", suppressSuccess)
 	})
 
+	t.Run("Versions", func(t *testing.T) {
+		t.Run("VersionsNotFound", versionsNotFound)
+		t.Run("VersionsRevertRule", versionsRevertRule)
+	})
+
 	t.Run("Delete", func(t *testing.T) {
 		t.Run("DeleteNotExists", deleteNotExists)
 		t.Run("DeletePolicies", deletePolicies)
@@ -306,6 +315,68 @@ func TestIntegrationAPI(t *testing.T) {
 	})
 }
 
+func versionsNotFound(t *testing.T) {
+	t.Parallel()
+	input := models.LambdaInput{
+		ListDetectionVersions: &models.ListDetectionVersionsInput{ID: "does-not-exist"},
+	}
+	statusCode, err := apiClient.Invoke(&input, nil)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, statusCode)
+}
+
+// The rule was modified once since it was created
+func versionsRevertRule(t *testing.T) {
+	t.Parallel()
+	input := models.LambdaInput{
+		ListDetectionVersions: &models.ListDetectionVersionsInput{ID: rule.ID},
+	}
+	var versions models.ListDetectionVersionsOutput
+	statusCode, err := apiClient.Invoke(&input, &versions)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	require.Len(t, versions.Versions, 2)
+	assert.True(t, versions.Versions[0].IsLatest)
+	assert.Equal(t, rule.VersionID, versions.Versions[1].VersionID)
+	assert.Equal(t, userID, versions.Versions[1].LastModifiedBy)
+
+	input = models.LambdaInput{
+		DiffDetectionVersions: &models.DiffDetectionVersionsInput{ID: rule.ID, FromVersionID: rule.VersionID},
+	}
+	var diff models.DiffDetectionVersionsOutput
+	statusCode, err = apiClient.Invoke(&input, &diff)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Empty(t, diff.Body)
+	assert.Equal(t, versions.Versions[0].VersionID, diff.To.VersionID)
+	var fields []string
+	for _, field := range diff.Metadata {
+		fields = append(fields, field.Field)
+	}
+	assert.Equal(t, []string{"dedupPeriodMinutes", "description", "threshold"}, fields)
+
+	input = models.LambdaInput{
+		RevertDetection: &models.RevertDetectionInput{ID: rule.ID, VersionID: rule.VersionID, UserID: userID},
+	}
+	var reverted models.Detection
+	statusCode, err = apiClient.Invoke(&input, &reverted)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, rule.Description, reverted.Description)
+	assert.NotEqual(t, rule.VersionID, reverted.VersionID)
+
+	// Reverting adds a new version
+	input = models.LambdaInput{
+		ListDetectionVersions: &models.ListDetectionVersionsInput{ID: rule.ID, PageSize: 1},
+	}
+	statusCode, err = apiClient.Invoke(&input, &versions)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	require.Len(t, versions.Versions, 1)
+	assert.Equal(t, reverted.VersionID, versions.Versions[0].VersionID)
+	assert.NotEmpty(t, versions.NextVersionID)
+}
+
 func testPolicyPass(t *testing.T) {
 	t.Parallel()
 	input := models.LambdaInput{
@@ -1428,12 +1499,15 @@ func modifyDataModel(t *testing.T) {
 	assert.Equal(t, *dataModel, result)
 
 	// verify can update logtypes to overlap if enabled is false
-	originalLogTypes := dataModel.LogTypes
+	// (the mappings must change too, they are validated against the log type schema)
+	originalLogTypes, originalMappings := dataModel.LogTypes, dataModel.Mappings
 	dataModel.Enabled = false
 	dataModel.LogTypes = dataModelTwo.LogTypes
+	dataModel.Mappings = dataModelTwo.Mappings
 
 	input.UpdateDataModel.Enabled = dataModel.Enabled
 	input.UpdateDataModel.LogTypes = dataModel.LogTypes
+	input.UpdateDataModel.Mappings = dataModel.Mappings
 	statusCode, err = apiClient.Invoke(&input, &result)
 	require.NoError(t, err)
 	assert.Equal(t, http.StatusOK, statusCode)
@@ -1445,8 +1519,10 @@ func modifyDataModel(t *testing.T) {
 	// change logtype back
 	dataModel.Enabled = true
 	dataModel.LogTypes = originalLogTypes
+	dataModel.Mappings = originalMappings
 	input.UpdateDataModel.Enabled = dataModel.Enabled
 	input.UpdateDataModel.LogTypes = dataModel.LogTypes
+	input.UpdateDataModel.Mappings = dataModel.Mappings
 	statusCode, err = apiClient.Invoke(&input, &result)
 	require.NoError(t, err)
 	assert.Equal(t, http.StatusOK, statusCode)
@@ -1685,6 +1761,54 @@ func bulkUploadSuccess(t *testing.T) {
 	assert.Equal(t, *dataModelFromBulkYML, getDataModel)
 }
 
+// Exported items are unchanged when uploaded again
+func bulkExportRoundTrip(t *testing.T) {
+	input := models.LambdaInput{
+		BulkExport: &models.BulkExportInput{
+			IDs: []string{policyFromBulk.ID, "Rule.Always.True", dataModelFromBulkYML.ID},
+		},
+	}
+	var exported models.BulkExportOutput
+	statusCode, err := apiClient.Invoke(&input, &exported)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, 1, exported.TotalPolicies)
+	assert.Equal(t, 1, exported.TotalRules)
+	assert.Equal(t, 1, exported.TotalDataModels)
+
+	// A dry run reports every item as unchanged
+	input = models.LambdaInput{
+		BulkUpload: &models.BulkUploadInput{Data: exported.Data, UserID: userID, DryRun: true},
+	}
+	var preview models.BulkUploadOutput
+	statusCode, err = apiClient.Invoke(&input, &preview)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	require.Len(t, preview.Changes, 3)
+	for _, change := range preview.Changes {
+		assert.Equal(t, "UNCHANGED", change.Change, change.ID)
+		if change.AnalysisType == models.TypePolicy {
+			require.NotNil(t, change.TestResults)
+			assert.Equal(t, 2, change.TestResults.Passed+change.TestResults.Failed)
+		}
+	}
+
+	input = models.LambdaInput{
+		BulkUpload: &models.BulkUploadInput{Data: exported.Data, UserID: userID},
+	}
+	var result models.BulkUploadOutput
+	statusCode, err = apiClient.Invoke(&input, &result)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+
+	expected := models.BulkUploadOutput{
+		TotalPolicies:   1,
+		TotalRules:      1,
+		TotalDataModels: 1,
+	}
+	assert.Equal(t, expected, result)
+}
+
 func listPolicies(t *testing.T) {
 	t.Parallel()
 	input := models.LambdaInput{
@@ -1959,7 +2083,7 @@ func listDataModels(t *testing.T) {
 			TotalPages: 1,
 		},
 		Models: []models.DataModel{
-			*dataModel, *dataModelTwo, *dataModelFromBulkYML, *dataModelDisabled,
+			*dataModelFromBulkYML, *dataModel, *dataModelTwo, *dataModelDisabled,
 		},
 	}
 	assert.Equal(t, expected, result)
@@ -1984,7 +2108,7 @@ func listEnabledDataModels(t *testing.T) {
 			TotalPages: 1,
 		},
 		Models: []models.DataModel{
-			*dataModel, *dataModelTwo, *dataModelFromBulkYML,
+			*dataModelFromBulkYML, *dataModel, *dataModelTwo,
 		},
 	}
 	assert.Equal(t, expected, result)
@@ -22,6 +22,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/pkg/errors"
 
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logschema"
@@ -43,6 +44,10 @@ type LogTypesAPI struct {
 	UpdateDataCatalog func(ctx context.Context, logType string, from, to []logschema.FieldSchema) error
 	// FIXME: Rename to LogTypesInUse
 	LogTypeInUse func(ctx context.Context) ([]string, error)
+	// S3API is used to read sample log lines from S3 objects
+	S3API s3iface.S3API
+	// SampleBuckets are the names of the only S3 buckets sample log lines are read from
+	SampleBuckets []string
 }
 
 // LogTypesDatabase handles the external actions required for LogTypesAPI to be implemented
@@ -61,6 +66,14 @@ type LogTypesDatabase interface {
 	BatchGetCustomLogs(ctx context.Context, ids ...string) ([]*CustomLogRecord, error)
 	// List deleted log types
 	ListDeletedLogTypes(ctx context.Context) ([]string, error)
+	// Get a single custom indicator record
+	GetCustomIndicator(ctx context.Context, name string) (*CustomIndicatorRecord, error)
+	// Create or replace a custom indicator record
+	PutCustomIndicator(ctx context.Context, record *CustomIndicatorRecord) error
+	// Delete a custom indicator record
+	DeleteCustomIndicator(ctx context.Context, name string) error
+	// List all custom indicator records
+	ListCustomIndicators(ctx context.Context) ([]*CustomIndicatorRecord, error)
 }
 
 const (
@@ -73,6 +86,7 @@ const (
 	ErrInvalidMetadata  = "InvalidMetadata"
 	ErrInvalidSyntax    = "InvalidSyntax"
 	ErrInvalidLogSchema = "InvalidLogSchema"
+	ErrInvalidIndicator = "InvalidIndicator"
 	ErrServerError      = "ServerError"
 )
 
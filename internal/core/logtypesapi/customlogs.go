@@ -75,6 +75,9 @@ type CustomLog struct {
 
 func (api *LogTypesAPI) PutCustomLog(ctx context.Context, input *PutCustomLogInput) (*PutCustomLogOutput, error) {
 	id := customlogs.LogType(input.LogType)
+	if err := api.registerIndicators(ctx); err != nil {
+		return nil, err
+	}
 	schema, err := buildSchema(id, &input.CustomLog)
 	if err != nil {
 		return nil, err
@@ -59,6 +59,9 @@ const (
 
 	recordKindStatus      = "status"
 	attrAvailableLogTypes = "AvailableLogTypes"
+
+	// We will use this kind of record to store custom indicators
+	recordKindIndicator = "indicator"
 )
 
 func (d *DynamoDBLogTypes) IndexLogTypes(ctx context.Context) ([]string, error) {
@@ -344,7 +347,7 @@ func buildUpdateTx(tableName string, record CustomLogRecord) transact.Transactio
 
 type recordKey struct {
 	RecordID   string `json:"RecordID" validate:"required"`
-	RecordKind string `json:"RecordKind" validate:"required,oneof=native custom"`
+	RecordKind string `json:"RecordKind" validate:"required,oneof=native custom indicator"`
 }
 
 func statusRecordKey() recordKey {
@@ -375,6 +378,104 @@ func customRecordID(id string, rev int64) string {
 	return strings.ToUpper(id)
 }
 
+func indicatorRecordKey(name string) recordKey {
+	return recordKey{
+		RecordID:   name,
+		RecordKind: recordKindIndicator,
+	}
+}
+
+type customIndicatorRecord struct {
+	recordKey
+	CustomIndicatorRecord
+}
+
+func (d *DynamoDBLogTypes) GetCustomIndicator(ctx context.Context, name string) (*CustomIndicatorRecord, error) {
+	input := dynamodb.GetItemInput{
+		TableName: aws.String(d.TableName),
+		Key:       mustMarshalMap(indicatorRecordKey(name)),
+	}
+	output, err := d.DB.GetItemWithContext(ctx, &input)
+	if err != nil {
+		return nil, err
+	}
+	record := customIndicatorRecord{}
+	if err := dynamodbattribute.UnmarshalMap(output.Item, &record); err != nil {
+		return nil, err
+	}
+	if record.Name == "" {
+		return nil, nil
+	}
+	return &record.CustomIndicatorRecord, nil
+}
+
+func (d *DynamoDBLogTypes) PutCustomIndicator(ctx context.Context, record *CustomIndicatorRecord) error {
+	item, err := dynamodbattribute.MarshalMap(&customIndicatorRecord{
+		recordKey:             indicatorRecordKey(record.Name),
+		CustomIndicatorRecord: *record,
+	})
+	if err != nil {
+		return err
+	}
+	input := dynamodb.PutItemInput{
+		TableName: aws.String(d.TableName),
+		Item:      item,
+	}
+	_, err = d.DB.PutItemWithContext(ctx, &input)
+	return err
+}
+
+func (d *DynamoDBLogTypes) DeleteCustomIndicator(ctx context.Context, name string) error {
+	input := dynamodb.DeleteItemInput{
+		TableName:           aws.String(d.TableName),
+		Key:                 mustMarshalMap(indicatorRecordKey(name)),
+		ConditionExpression: aws.String("attribute_exists(RecordID)"),
+	}
+	if _, err := d.DB.DeleteItemWithContext(ctx, &input); err != nil {
+		var awsErr interface {
+			Code() string
+		}
+		if errors.As(err, &awsErr) && awsErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return NewAPIError(ErrNotFound, fmt.Sprintf("indicator %q not found", name))
+		}
+		return err
+	}
+	return nil
+}
+
+func (d *DynamoDBLogTypes) ListCustomIndicators(ctx context.Context) ([]*CustomIndicatorRecord, error) {
+	cond := expression.Key(attrRecordKind).Equal(expression.Value(recordKindIndicator))
+	expr, err := expression.NewBuilder().WithKeyCondition(cond).Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build query expression")
+	}
+	input := dynamodb.QueryInput{
+		TableName:                 aws.String(d.TableName),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	}
+	var records []*CustomIndicatorRecord
+	var itemErr error
+	err = d.DB.QueryPagesWithContext(ctx, &input, func(page *dynamodb.QueryOutput, _ bool) bool {
+		for _, item := range page.Items {
+			record := customIndicatorRecord{}
+			if itemErr = dynamodbattribute.UnmarshalMap(item, &record); itemErr != nil {
+				return false
+			}
+			records = append(records, &record.CustomIndicatorRecord)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if itemErr != nil {
+		return nil, itemErr
+	}
+	return records, nil
+}
+
 type customLogRecord struct {
 	recordKey
 	Deleted bool `json:"IsDeleted,omitempty"  description:"Log record is deleted"`
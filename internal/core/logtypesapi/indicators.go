@@ -0,0 +1,243 @@
+package logtypesapi
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/customlogs"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logschema"
+	"github.com/panther-labs/panther/pkg/stringset"
+)
+
+// CustomIndicator is an indicator kind that can be used by the fields of all custom log schemas
+// nolint:lll
+type CustomIndicator struct {
+	Name        string `json:"name" validate:"required" description:"The indicator name to use in the indicators of string fields"`
+	Field       string `json:"field,omitempty" description:"The field where indicator values are collected (defaults to p_any_<name>s)"`
+	Description string `json:"description,omitempty" description:"Indicator description"`
+	Regex       string `json:"regex,omitempty" description:"A regular expression to extract values (the first non-empty capture group is used if any)"`
+	Builtin     string `json:"builtin,omitempty" description:"A builtin extractor to use for values (value, lowercase, uppercase, mac_address, uuid)"`
+}
+
+// Schema converts a custom indicator to a log schema indicator
+func (c *CustomIndicator) Schema() logschema.IndicatorSchema {
+	return logschema.IndicatorSchema{
+		Name:        c.Name,
+		Field:       c.Field,
+		Description: c.Description,
+		Regex:       c.Regex,
+		Builtin:     c.Builtin,
+	}
+}
+
+// CustomIndicatorRecord is a stored record for a custom indicator
+type CustomIndicatorRecord struct {
+	UpdatedAt time.Time `json:"updatedAt" description:"Last update timestamp of the record"`
+	CustomIndicator
+}
+
+var (
+	rxIndicatorName  = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+	rxIndicatorField = regexp.MustCompile(`^p_any_[a-z0-9_]+$`)
+)
+
+func validateIndicator(c *CustomIndicator) error {
+	if !rxIndicatorName.MatchString(c.Name) {
+		return NewAPIError(ErrInvalidIndicator, fmt.Sprintf("invalid indicator name %q", c.Name))
+	}
+	if !rxIndicatorField.MatchString(c.Schema().FieldNameJSON()) {
+		return NewAPIError(ErrInvalidIndicator, fmt.Sprintf("invalid indicator field %q", c.Field))
+	}
+	if (c.Regex == "") == (c.Builtin == "") {
+		return NewAPIError(ErrInvalidIndicator, "exactly one of regex or builtin must be defined")
+	}
+	if err := customlogs.ValidateIndicators(c.Schema()); err != nil {
+		return NewAPIError(ErrInvalidIndicator, err.Error())
+	}
+	return nil
+}
+
+// registerIndicators registers all stored custom indicators so that schemas using them can be built
+func (api *LogTypesAPI) registerIndicators(ctx context.Context) error {
+	records, err := api.Database.ListCustomIndicators(ctx)
+	if err != nil {
+		return err
+	}
+	return RegisterCustomIndicators(records...)
+}
+
+// RegisterCustomIndicators registers custom indicator records so that schemas using them can be built
+func RegisterCustomIndicators(records ...*CustomIndicatorRecord) error {
+	indicators := make([]logschema.IndicatorSchema, 0, len(records))
+	for _, record := range records {
+		indicators = append(indicators, record.Schema())
+	}
+	return customlogs.RegisterIndicators(indicators...)
+}
+
+// PutCustomIndicator creates or updates a custom indicator.
+// The field of an existing indicator cannot change since it is already a column in the tables using it.
+func (api *LogTypesAPI) PutCustomIndicator(ctx context.Context, input *PutCustomIndicatorInput) (*PutCustomIndicatorOutput, error) {
+	current, err := api.Database.GetCustomIndicator(ctx, input.Name)
+	if err != nil {
+		return nil, err
+	}
+	if current != nil && current.Schema().FieldNameJSON() != input.Schema().FieldNameJSON() {
+		return nil, NewAPIError(ErrInvalidUpdate, fmt.Sprintf("indicator %q field cannot change", input.Name))
+	}
+	if err := validateIndicator(&input.CustomIndicator); err != nil {
+		return nil, err
+	}
+	record := &CustomIndicatorRecord{
+		UpdatedAt:       time.Now().UTC(),
+		CustomIndicator: input.CustomIndicator,
+	}
+	if err := api.Database.PutCustomIndicator(ctx, record); err != nil {
+		return nil, err
+	}
+	// Only register the indicator once it is stored so that failed updates do not affect this process
+	if err := RegisterCustomIndicators(record); err != nil {
+		return nil, NewAPIError(ErrInvalidIndicator, err.Error())
+	}
+	return &PutCustomIndicatorOutput{Result: record}, nil
+}
+
+type PutCustomIndicatorInput struct {
+	CustomIndicator
+}
+
+//nolint:lll
+type PutCustomIndicatorOutput struct {
+	Result *CustomIndicatorRecord `json:"record,omitempty" description:"The modified record (field is omitted if an error occurred)"`
+	Error  *APIError              `json:"error,omitempty" description:"An error that occurred during the operation"`
+}
+
+// ListCustomIndicators lists all custom indicators
+func (api *LogTypesAPI) ListCustomIndicators(ctx context.Context) (*ListCustomIndicatorsOutput, error) {
+	records, err := api.Database.ListCustomIndicators(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if records == nil {
+		records = []*CustomIndicatorRecord{}
+	}
+	return &ListCustomIndicatorsOutput{CustomIndicators: records}, nil
+}
+
+//nolint:lll
+type ListCustomIndicatorsOutput struct {
+	CustomIndicators []*CustomIndicatorRecord `json:"customIndicators" description:"Custom indicator records stored"`
+	Error            *APIError                `json:"error,omitempty" description:"An error that occurred during the operation"`
+}
+
+// DelCustomIndicator deletes a custom indicator that is not used by any custom log schema
+func (api *LogTypesAPI) DelCustomIndicator(ctx context.Context, input *DelCustomIndicatorInput) (*DelCustomIndicatorOutput, error) {
+	records, err := api.listActiveCustomLogs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		schema := logschema.Schema{}
+		if err := yaml.Unmarshal([]byte(record.LogSpec), &schema); err != nil {
+			return nil, errors.Wrapf(err, "invalid schema for %q", record.LogType)
+		}
+		if usesIndicator(&schema, input.Name) {
+			return nil, NewAPIError(ErrInUse, fmt.Sprintf("indicator %q is used by %s", input.Name, record.LogType))
+		}
+	}
+	if err := api.Database.DeleteCustomIndicator(ctx, input.Name); err != nil {
+		return nil, err
+	}
+	return &DelCustomIndicatorOutput{}, nil
+}
+
+// listActiveCustomLogs lists the custom log records of log types that are not deleted
+func (api *LogTypesAPI) listActiveCustomLogs(ctx context.Context) ([]*CustomLogRecord, error) {
+	available, err := api.Database.IndexLogTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	deleted, err := api.Database.ListDeletedLogTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var custom []string
+	for _, logType := range available {
+		if strings.HasPrefix(logType, customlogs.LogTypePrefix) && !stringset.Contains(deleted, logType) {
+			custom = append(custom, logType)
+		}
+	}
+	if len(custom) == 0 {
+		return nil, nil
+	}
+	return api.Database.BatchGetCustomLogs(ctx, custom...)
+}
+
+type DelCustomIndicatorInput struct {
+	Name string `json:"name" validate:"required" description:"The indicator name"`
+}
+
+type DelCustomIndicatorOutput struct {
+	Error *APIError `json:"error,omitempty" description:"An error that occurred during the operation"`
+}
+
+// usesIndicator checks if a schema uses an indicator that it does not define itself
+func usesIndicator(schema *logschema.Schema, name string) bool {
+	for _, indicator := range schema.Indicators {
+		if indicator.Name == name {
+			return false
+		}
+	}
+	for _, def := range schema.Definitions {
+		if valueUsesIndicator(def, name) {
+			return true
+		}
+	}
+	for i := range schema.Fields {
+		if valueUsesIndicator(&schema.Fields[i].ValueSchema, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func valueUsesIndicator(v *logschema.ValueSchema, name string) bool {
+	if v == nil {
+		return false
+	}
+	for _, indicator := range v.Indicators {
+		if indicator == name {
+			return true
+		}
+	}
+	for i := range v.Fields {
+		if valueUsesIndicator(&v.Fields[i].ValueSchema, name) {
+			return true
+		}
+	}
+	return valueUsesIndicator(v.Element, name)
+}
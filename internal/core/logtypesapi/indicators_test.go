@@ -0,0 +1,145 @@
+package logtypesapi_test
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/core/logtypesapi"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logschema"
+)
+
+func TestAPI_CustomIndicators(t *testing.T) {
+	api := logtypesapi.LogTypesAPI{
+		Database: logtypesapi.NewInMemory(),
+		LogTypeInUse: func(ctx context.Context) ([]string, error) {
+			return nil, nil
+		},
+		UpdateDataCatalog: func(ctx context.Context, logType string, from, to []logschema.FieldSchema) error {
+			return nil
+		},
+	}
+	ctx := context.Background()
+	assert := require.New(t)
+
+	logSpec := `{"version": 0, "fields": [{"name": "device", "type": "string", "indicators": ["api_device_id"]}]}`
+	_, err := api.PutCustomLog(ctx, &logtypesapi.PutCustomLogInput{
+		LogType: "Custom.Devices",
+		CustomLog: logtypesapi.CustomLog{
+			LogSpec: logSpec,
+		},
+	})
+	assert.Error(err)
+	assert.Equal(logtypesapi.ErrInvalidLogSchema, logtypesapi.AsAPIError(err).Code)
+
+	_, err = api.PutCustomIndicator(ctx, &logtypesapi.PutCustomIndicatorInput{
+		CustomIndicator: logtypesapi.CustomIndicator{
+			Name: "Invalid-Name",
+		},
+	})
+	assert.Error(err)
+	assert.Equal(logtypesapi.ErrInvalidIndicator, logtypesapi.AsAPIError(err).Code)
+
+	indicator := logtypesapi.CustomIndicator{
+		Name:        "api_device_id",
+		Description: "Device ids",
+		Regex:       `dev-[0-9]+`,
+	}
+	reply, err := api.PutCustomIndicator(ctx, &logtypesapi.PutCustomIndicatorInput{
+		CustomIndicator: indicator,
+	})
+	assert.NoError(err)
+	assert.Equal(indicator, reply.Result.CustomIndicator)
+
+	// Changing the field of an indicator is not allowed
+	changed := indicator
+	changed.Field = "p_any_devices"
+	_, err = api.PutCustomIndicator(ctx, &logtypesapi.PutCustomIndicatorInput{
+		CustomIndicator: changed,
+	})
+	assert.Error(err)
+	assert.Equal(logtypesapi.ErrInvalidUpdate, logtypesapi.AsAPIError(err).Code)
+
+	_, err = api.PutCustomLog(ctx, &logtypesapi.PutCustomLogInput{
+		LogType: "Custom.Devices",
+		CustomLog: logtypesapi.CustomLog{
+			LogSpec: logSpec,
+		},
+	})
+	assert.NoError(err)
+
+	// Schemas cannot redefine an indicator defined with the API
+	_, err = api.PutCustomLog(ctx, &logtypesapi.PutCustomLogInput{
+		LogType: "Custom.OtherDevices",
+		CustomLog: logtypesapi.CustomLog{
+			LogSpec: `{"version": 0, "indicators": [{"name": "api_device_id", "builtin": "value"}], "fields": [{"name": "device", "type": "string", "indicators": ["api_device_id"]}]}`,
+		},
+	})
+	assert.Error(err)
+	assert.Equal(logtypesapi.ErrInvalidLogSchema, logtypesapi.AsAPIError(err).Code)
+
+	list, err := api.ListCustomIndicators(ctx)
+	assert.NoError(err)
+	assert.Len(list.CustomIndicators, 1)
+	assert.Equal(indicator, list.CustomIndicators[0].CustomIndicator)
+
+	_, err = api.DelCustomIndicator(ctx, &logtypesapi.DelCustomIndicatorInput{
+		Name: "api_device_id",
+	})
+	assert.Error(err)
+	assert.Equal(logtypesapi.ErrInUse, logtypesapi.AsAPIError(err).Code)
+
+	_, err = api.DelCustomLog(ctx, &logtypesapi.DelCustomLogInput{
+		LogType:  "Custom.Devices",
+		Revision: 1,
+	})
+	assert.NoError(err)
+	_, err = api.DelCustomIndicator(ctx, &logtypesapi.DelCustomIndicatorInput{
+		Name: "api_device_id",
+	})
+	assert.NoError(err)
+	_, err = api.DelCustomIndicator(ctx, &logtypesapi.DelCustomIndicatorInput{
+		Name: "api_device_id",
+	})
+	assert.Error(err)
+	assert.Equal(logtypesapi.ErrNotFound, logtypesapi.AsAPIError(err).Code)
+}
+
+// nolint:lll
+func (ListAvailableAPI) GetCustomIndicator(_ context.Context, _ string) (*logtypesapi.CustomIndicatorRecord, error) {
+	panic("implement me")
+}
+
+// nolint:lll
+func (ListAvailableAPI) PutCustomIndicator(_ context.Context, _ *logtypesapi.CustomIndicatorRecord) error {
+	panic("implement me")
+}
+
+// nolint:lll
+func (ListAvailableAPI) DeleteCustomIndicator(_ context.Context, _ string) error {
+	panic("implement me")
+}
+
+// nolint:lll
+func (ListAvailableAPI) ListCustomIndicators(_ context.Context) ([]*logtypesapi.CustomIndicatorRecord, error) {
+	panic("implement me")
+}
@@ -0,0 +1,166 @@
+package logtypesapi
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/customlogs"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logschema"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/pkg/stringset"
+)
+
+const (
+	// MaxSamples is the maximum number of sample lines in a request or read from an S3 object
+	MaxSamples = 10000
+	// maxSampleLineSize is the maximum size of a sample line read from an S3 object
+	maxSampleLineSize = 1024 * 1024
+)
+
+// InferCustomLog infers a custom log schema from sample JSON log lines.
+// The schema is returned in YAML and can be used as the `logSpec` of `PutCustomLog`.
+func (api *LogTypesAPI) InferCustomLog(ctx context.Context, input *InferCustomLogInput) (*InferCustomLogOutput, error) {
+	samples, err := api.readSamples(ctx, &input.Samples)
+	if err != nil {
+		return nil, err
+	}
+	inference := logschema.Inference{}
+	for i, sample := range samples {
+		if err := inference.AddJSON([]byte(sample)); err != nil {
+			return nil, NewAPIError(ErrInvalidSyntax, fmt.Sprintf("sample line %d: %s", i+1, err))
+		}
+	}
+	schema := inference.Schema()
+	if schema == nil {
+		return nil, NewAPIError(ErrInvalidSyntax, "no fields found in sample lines")
+	}
+	// Make sure the inferred schema is valid
+	desc := logtypes.Desc{
+		Name:         "Custom.Inferred",
+		Description:  "Inferred custom log schema",
+		ReferenceURL: "-",
+	}
+	if _, err := customlogs.Build(desc, schema); err != nil {
+		return nil, NewAPIError(ErrInvalidLogSchema, err.Error())
+	}
+	logSpec, err := yaml.Marshal(schema)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode schema to YAML")
+	}
+	return &InferCustomLogOutput{
+		LogSpec:    string(logSpec),
+		NumSamples: inference.NumSamples(),
+		Fields:     inference.FieldStats(),
+	}, nil
+}
+
+type InferCustomLogInput struct {
+	Samples
+}
+
+// Samples are sample log lines provided either directly or as an S3 object.
+// Objects are only read from the sample buckets so that the API cannot be used to read arbitrary objects.
+//nolint:lll
+type Samples struct {
+	Lines []string `json:"lines,omitempty" validate:"required_without=S3URL,max=10000" description:"Sample log lines"`
+	S3URL string   `json:"s3Url,omitempty" validate:"omitempty,startswith=s3://" description:"The URL of an S3 object with sample log lines (s3://bucket/key) in one of the sample buckets"`
+}
+
+//nolint:lll
+type InferCustomLogOutput struct {
+	LogSpec    string                 `json:"logSpec,omitempty" description:"The inferred log spec in YAML format"`
+	NumSamples int                    `json:"numSamples,omitempty" description:"The number of sample lines used"`
+	Fields     []logschema.FieldStats `json:"fields,omitempty" description:"Statistics about the fields found in the sample lines"`
+	Error      *APIError              `json:"error,omitempty" description:"An error that occurred during the operation"`
+}
+
+// readSamples reads the non-empty sample lines
+func (api *LogTypesAPI) readSamples(ctx context.Context, samples *Samples) ([]string, error) {
+	if samples.S3URL == "" {
+		if len(samples.Lines) > MaxSamples {
+			return nil, NewAPIError(ErrInvalidSyntax, fmt.Sprintf("too many sample lines (max %d)", MaxSamples))
+		}
+		var lines []string
+		for _, line := range samples.Lines {
+			if line = strings.TrimSpace(line); line != "" {
+				lines = append(lines, line)
+			}
+		}
+		return lines, nil
+	}
+	bucket, key, err := awsglue.ParseS3URL(samples.S3URL)
+	if err != nil {
+		return nil, NewAPIError(ErrInvalidSyntax, err.Error())
+	}
+	if !stringset.Contains(api.SampleBuckets, bucket) {
+		return nil, NewAPIError(ErrInvalidSyntax, fmt.Sprintf("bucket %q is not a sample bucket", bucket))
+	}
+	if api.S3API == nil {
+		return nil, errors.New("no S3 client")
+	}
+	reply, err := api.S3API.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer reply.Body.Close()
+	return readLines(reply.Body, MaxSamples)
+}
+
+// readLines reads up to maxLines non-empty lines, decompressing gzip input
+func readLines(r io.Reader, maxLines int) ([]string, error) {
+	buf := bufio.NewReader(r)
+	if header, err := buf.Peek(2); err == nil && header[0] == 0x1f && header[1] == 0x8b {
+		gz, err := gzip.NewReader(buf)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decompress sample lines")
+		}
+		defer gz.Close()
+		r = gz
+	} else {
+		r = buf
+	}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxSampleLineSize)
+	var lines []string
+	for len(lines) < maxLines && scanner.Scan() {
+		if line := bytes.TrimSpace(scanner.Bytes()); len(line) > 0 {
+			lines = append(lines, string(line))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read sample lines")
+	}
+	return lines, nil
+}
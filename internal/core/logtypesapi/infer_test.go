@@ -0,0 +1,140 @@
+package logtypesapi_test
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/core/logtypesapi"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+func TestAPI_InferCustomLog(t *testing.T) {
+	assert := require.New(t)
+	ctx := context.Background()
+	s3Mock := &testutils.S3Mock{}
+	api := logtypesapi.LogTypesAPI{
+		Database:      logtypesapi.NewInMemory(),
+		S3API:         s3Mock,
+		SampleBuckets: []string{"samples"},
+	}
+	lines := []string{
+		`{"ts": "2020-01-02T03:04:05Z", "ip": "1.1.1.1", "n": 1}`,
+		``,
+		`{"ts": "2020-01-02T03:04:06Z", "ip": "2.2.2.2"}`,
+	}
+	reply, err := api.InferCustomLog(ctx, &logtypesapi.InferCustomLogInput{
+		Samples: logtypesapi.Samples{
+			Lines: lines,
+		},
+	})
+	assert.NoError(err)
+	assert.Equal(2, reply.NumSamples)
+	expect := `
+version: 0
+fields:
+- name: ip
+  required: true
+  type: string
+  indicators: [ip]
+- name: "n"
+  type: bigint
+- name: ts
+  required: true
+  type: timestamp
+  timeFormat: rfc3339
+  isEventTime: true
+`
+	assert.YAMLEq(expect, reply.LogSpec)
+	assert.Len(reply.Fields, 3)
+	assert.Equal("n", reply.Fields[1].Path)
+	assert.Equal(0.5, reply.Fields[1].Presence)
+
+	// Read gzipped samples from a sample bucket
+	buf := bytes.Buffer{}
+	gz := gzip.NewWriter(&buf)
+	for _, line := range lines {
+		_, _ = gz.Write([]byte(line + "\n"))
+	}
+	assert.NoError(gz.Close())
+	s3Mock.On("GetObjectWithContext", mock.Anything, mock.Anything, mock.Anything).Return(&s3.GetObjectOutput{
+		Body: ioutil.NopCloser(&buf),
+	}, nil).Once()
+	reply, err = api.InferCustomLog(ctx, &logtypesapi.InferCustomLogInput{
+		Samples: logtypesapi.Samples{
+			S3URL: "s3://samples/samples.jsonl.gz",
+		},
+	})
+	assert.NoError(err)
+	assert.YAMLEq(expect, reply.LogSpec)
+	s3Mock.AssertExpectations(t)
+
+	// Objects in other buckets are not read
+	_, err = api.InferCustomLog(ctx, &logtypesapi.InferCustomLogInput{
+		Samples: logtypesapi.Samples{
+			S3URL: "s3://other/samples.jsonl",
+		},
+	})
+	assert.Error(err)
+	assert.Equal(logtypesapi.ErrInvalidSyntax, logtypesapi.AsAPIError(err).Code)
+	s3Mock.AssertNumberOfCalls(t, "GetObjectWithContext", 1)
+
+	// At most MaxSamples lines are read from an object
+	object := strings.Builder{}
+	for i := 0; i < logtypesapi.MaxSamples+10; i++ {
+		_, _ = fmt.Fprintf(&object, `{"n": %d}`+"\n", i)
+	}
+	s3Mock.On("GetObjectWithContext", mock.Anything, mock.Anything, mock.Anything).Return(&s3.GetObjectOutput{
+		Body: ioutil.NopCloser(strings.NewReader(object.String())),
+	}, nil).Once()
+	reply, err = api.InferCustomLog(ctx, &logtypesapi.InferCustomLogInput{
+		Samples: logtypesapi.Samples{
+			S3URL: "s3://samples/many.jsonl",
+		},
+	})
+	assert.NoError(err)
+	assert.Equal(logtypesapi.MaxSamples, reply.NumSamples)
+
+	// Too many sample lines are rejected
+	_, err = api.InferCustomLog(ctx, &logtypesapi.InferCustomLogInput{
+		Samples: logtypesapi.Samples{
+			Lines: make([]string, logtypesapi.MaxSamples+1),
+		},
+	})
+	assert.Error(err)
+	assert.Equal(logtypesapi.ErrInvalidSyntax, logtypesapi.AsAPIError(err).Code)
+
+	_, err = api.InferCustomLog(ctx, &logtypesapi.InferCustomLogInput{
+		Samples: logtypesapi.Samples{
+			Lines: []string{`{"foo":`},
+		},
+	})
+	assert.Error(err)
+	assert.Equal(logtypesapi.ErrInvalidSyntax, logtypesapi.AsAPIError(err).Code)
+}
@@ -21,6 +21,7 @@ package logtypesapi
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -30,9 +31,10 @@ import (
 // InMemDB is an in-memory implementation of the LogTypesDatabase.
 // It is useful for tests and for caching results of another implementation.
 type InMemDB struct {
-	mu      sync.RWMutex
-	deleted []string
-	records map[inMemKey]*CustomLogRecord
+	mu         sync.RWMutex
+	deleted    []string
+	records    map[inMemKey]*CustomLogRecord
+	indicators map[string]*CustomIndicatorRecord
 }
 
 type inMemKey struct {
@@ -44,7 +46,8 @@ var _ LogTypesDatabase = (*InMemDB)(nil)
 
 func NewInMemory() *InMemDB {
 	return &InMemDB{
-		records: map[inMemKey]*CustomLogRecord{},
+		records:    map[inMemKey]*CustomLogRecord{},
+		indicators: map[string]*CustomIndicatorRecord{},
 	}
 }
 
@@ -159,3 +162,39 @@ func (db *InMemDB) ListDeletedLogTypes(ctx context.Context) ([]string, error) {
 	out = append(out, db.deleted...)
 	return out, nil
 }
+
+func (db *InMemDB) GetCustomIndicator(_ context.Context, name string) (*CustomIndicatorRecord, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	return db.indicators[name], nil
+}
+
+func (db *InMemDB) PutCustomIndicator(_ context.Context, record *CustomIndicatorRecord) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.indicators[record.Name] = record
+	return nil
+}
+
+func (db *InMemDB) DeleteCustomIndicator(_ context.Context, name string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if _, ok := db.indicators[name]; !ok {
+		return NewAPIError(ErrNotFound, fmt.Sprintf(`indicator %q not found`, name))
+	}
+	delete(db.indicators, name)
+	return nil
+}
+
+func (db *InMemDB) ListCustomIndicators(_ context.Context) ([]*CustomIndicatorRecord, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	records := make([]*CustomIndicatorRecord, 0, len(db.indicators))
+	for _, record := range db.indicators {
+		records = append(records, record)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Name < records[j].Name
+	})
+	return records, nil
+}
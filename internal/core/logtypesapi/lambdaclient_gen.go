@@ -39,12 +39,17 @@ type LogTypesAPILambdaClient struct {
 }
 
 type LogTypesAPIPayload struct {
-	ListAvailableLogTypes *struct{}          `json:"ListAvailableLogTypes,omitempty"`
-	ListDeletedCustomLogs *struct{}          `json:"ListDeletedCustomLogs,omitempty"`
-	GetCustomLog          *GetCustomLogInput `json:"GetCustomLog,omitempty"`
-	PutCustomLog          *PutCustomLogInput `json:"PutCustomLog,omitempty"`
-	DelCustomLog          *DelCustomLogInput `json:"DelCustomLog,omitempty"`
-	ListCustomLogs        *struct{}          `json:"ListCustomLogs,omitempty"`
+	ListAvailableLogTypes *struct{}                `json:"ListAvailableLogTypes,omitempty"`
+	ListDeletedCustomLogs *struct{}                `json:"ListDeletedCustomLogs,omitempty"`
+	GetCustomLog          *GetCustomLogInput       `json:"GetCustomLog,omitempty"`
+	PutCustomLog          *PutCustomLogInput       `json:"PutCustomLog,omitempty"`
+	DelCustomLog          *DelCustomLogInput       `json:"DelCustomLog,omitempty"`
+	ListCustomLogs        *struct{}                `json:"ListCustomLogs,omitempty"`
+	PutCustomIndicator    *PutCustomIndicatorInput `json:"PutCustomIndicator,omitempty"`
+	ListCustomIndicators  *struct{}                `json:"ListCustomIndicators,omitempty"`
+	DelCustomIndicator    *DelCustomIndicatorInput `json:"DelCustomIndicator,omitempty"`
+	InferCustomLog        *InferCustomLogInput     `json:"InferCustomLog,omitempty"`
+	TestCustomLog         *TestCustomLogInput      `json:"TestCustomLog,omitempty"`
 }
 
 func (c *LogTypesAPILambdaClient) ListAvailableLogTypes(ctx context.Context) (*AvailableLogTypes, error) {
@@ -122,6 +127,73 @@ func (c *LogTypesAPILambdaClient) ListCustomLogs(ctx context.Context) (*ListCust
 	return &reply, nil
 }
 
+func (c *LogTypesAPILambdaClient) PutCustomIndicator(ctx context.Context, input *PutCustomIndicatorInput) (*PutCustomIndicatorOutput, error) {
+	if input == nil {
+		input = &PutCustomIndicatorInput{}
+	}
+	payload := LogTypesAPIPayload{
+		PutCustomIndicator: input,
+	}
+	reply := PutCustomIndicatorOutput{}
+	if err := c.invoke(ctx, &payload, &reply); err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
+func (c *LogTypesAPILambdaClient) ListCustomIndicators(ctx context.Context) (*ListCustomIndicatorsOutput, error) {
+	payload := LogTypesAPIPayload{
+		ListCustomIndicators: &struct{}{},
+	}
+	reply := ListCustomIndicatorsOutput{}
+	if err := c.invoke(ctx, &payload, &reply); err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
+func (c *LogTypesAPILambdaClient) DelCustomIndicator(ctx context.Context, input *DelCustomIndicatorInput) (*DelCustomIndicatorOutput, error) {
+	if input == nil {
+		input = &DelCustomIndicatorInput{}
+	}
+	payload := LogTypesAPIPayload{
+		DelCustomIndicator: input,
+	}
+	reply := DelCustomIndicatorOutput{}
+	if err := c.invoke(ctx, &payload, &reply); err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
+func (c *LogTypesAPILambdaClient) InferCustomLog(ctx context.Context, input *InferCustomLogInput) (*InferCustomLogOutput, error) {
+	if input == nil {
+		input = &InferCustomLogInput{}
+	}
+	payload := LogTypesAPIPayload{
+		InferCustomLog: input,
+	}
+	reply := InferCustomLogOutput{}
+	if err := c.invoke(ctx, &payload, &reply); err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
+func (c *LogTypesAPILambdaClient) TestCustomLog(ctx context.Context, input *TestCustomLogInput) (*TestCustomLogOutput, error) {
+	if input == nil {
+		input = &TestCustomLogInput{}
+	}
+	payload := LogTypesAPIPayload{
+		TestCustomLog: input,
+	}
+	reply := TestCustomLogOutput{}
+	if err := c.invoke(ctx, &payload, &reply); err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
 func (c *LogTypesAPILambdaClient) invoke(ctx context.Context, payload, reply interface{}) error {
 	if validate := c.Validate; validate != nil {
 		if err := validate(payload); err != nil {
@@ -22,9 +22,11 @@ import (
 	"context"
 
 	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	lambdaclient "github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/kelseyhightower/envconfig"
@@ -47,6 +49,8 @@ var config = struct {
 	Debug               bool
 	LogTypesTableName   string `required:"true" split_words:"true"`
 	DataCatalogQueueURL string `required:"true" split_words:"true"`
+	// The ARNs of the S3 buckets sample log lines can be read from
+	SampleBucketArns []string `split_words:"true"`
 }{}
 
 func main() {
@@ -64,6 +68,14 @@ func main() {
 	session := session.Must(session.NewSession())
 	lambdaClient := lambdaclient.New(session)
 	nativeLogTypes := logtypes.CollectNames(registry.NativeLogTypes())
+	var sampleBuckets []string
+	for _, bucketARN := range config.SampleBucketArns {
+		parsed, err := arn.Parse(bucketARN)
+		if err != nil {
+			logger.Fatal("invalid sample bucket ARN", zap.String("arn", bucketARN), zap.Error(err))
+		}
+		sampleBuckets = append(sampleBuckets, parsed.Resource)
+	}
 	// FIXME: uncomment the below line to add the resource history to the of available logTypes and allow rules to target
 	// nativeLogTypes = append(nativeLogTypes, logtypes.CollectNames(snapshotlogs.LogTypes())...)
 	api := &logtypesapi.LogTypesAPI{
@@ -75,6 +87,8 @@ func main() {
 			DB:        dynamodb.New(session),
 			TableName: config.LogTypesTableName,
 		},
+		S3API:         s3.New(session),
+		SampleBuckets: sampleBuckets,
 		UpdateDataCatalog: func(ctx context.Context, logType string, from, to []logschema.FieldSchema) error {
 			if from == nil || to == nil {
 				return nil
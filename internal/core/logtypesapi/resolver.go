@@ -66,6 +66,10 @@ func (r *Resolver) Resolve(ctx context.Context, name string) (logtypes.Entry, er
 	if err := yaml.Unmarshal([]byte(record.LogSpec), &schema); err != nil {
 		return nil, errors.Wrap(err, "invalid schema YAML")
 	}
+	// Register custom indicators defined using the API so that the schema can use them
+	if err := r.registerIndicators(ctx); err != nil {
+		return nil, err
+	}
 	desc := logtypes.Desc{
 		Name:         record.LogType,
 		Description:  record.Description,
@@ -77,3 +81,17 @@ func (r *Resolver) Resolve(ctx context.Context, name string) (logtypes.Entry, er
 	}
 	return entry, nil
 }
+
+func (r *Resolver) registerIndicators(ctx context.Context) error {
+	reply, err := r.LogTypesAPI.ListCustomIndicators(ctx)
+	if err != nil {
+		return err
+	}
+	if reply.Error != nil {
+		return NewAPIError(reply.Error.Code, reply.Error.Message)
+	}
+	if err := RegisterCustomIndicators(reply.CustomIndicators...); err != nil {
+		return errors.Wrap(err, "invalid custom indicators")
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+package logtypesapi
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/customlogs"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logschema"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+// TestCustomLog tests a custom log schema against sample log lines without storing it.
+// It reports the events parsed from each line along with the fields that were populated,
+// the indicator values that were extracted and any parse errors.
+func (api *LogTypesAPI) TestCustomLog(ctx context.Context, input *TestCustomLogInput) (*TestCustomLogOutput, error) {
+	if err := api.registerIndicators(ctx); err != nil {
+		return nil, err
+	}
+	logType := input.LogType
+	if logType == "" {
+		logType = "Custom.Test"
+	}
+	parser, err := buildTestParser(customlogs.LogType(logType), &input.CustomLog)
+	if err != nil {
+		return nil, err
+	}
+	samples, err := api.readSamples(ctx, &input.Samples)
+	if err != nil {
+		return nil, err
+	}
+	output := TestCustomLogOutput{
+		Results: make([]TestLogResult, 0, len(samples)),
+	}
+	for i, sample := range samples {
+		result := TestLogResult{
+			Line: i + 1,
+		}
+		events, err := parser.ParseLog(sample)
+		if err != nil {
+			result.Error = err.Error()
+			output.NumErrors++
+		}
+		for _, event := range events {
+			e, err := newTestLogEvent(event)
+			if err != nil {
+				return nil, err
+			}
+			result.Events = append(result.Events, *e)
+			output.NumEvents++
+		}
+		output.Results = append(output.Results, result)
+	}
+	return &output, nil
+}
+
+// nolint:lll
+type TestCustomLogInput struct {
+	LogType string `json:"logType,omitempty" validate:"omitempty,startswith=Custom." description:"The log type id to use for the parsed events (defaults to Custom.Test)"`
+	CustomLog
+	Samples
+}
+
+//nolint:lll
+type TestCustomLogOutput struct {
+	Results   []TestLogResult `json:"results,omitempty" description:"The results for each sample line"`
+	NumEvents int             `json:"numEvents,omitempty" description:"The total number of events parsed"`
+	NumErrors int             `json:"numErrors,omitempty" description:"The total number of lines that failed to parse"`
+	Error     *APIError       `json:"error,omitempty" description:"An error that occurred during the operation"`
+}
+
+// TestLogResult is the result of parsing a sample line
+//nolint:lll
+type TestLogResult struct {
+	Line   int            `json:"line" description:"The number of the sample line (starting at 1)"`
+	Events []TestLogEvent `json:"events,omitempty" description:"The events parsed from the line"`
+	Error  string         `json:"error,omitempty" description:"The error that occurred while parsing the line"`
+}
+
+// TestLogEvent is an event parsed from a sample line
+//nolint:lll
+type TestLogEvent struct {
+	Event      string              `json:"event" description:"The parsed event in JSON format including panther fields"`
+	Fields     []string            `json:"fields,omitempty" description:"The paths of the event fields that were populated"`
+	Indicators map[string][]string `json:"indicators,omitempty" description:"The indicator values extracted from the event by panther field"`
+}
+
+// buildTestParser builds a parser for a custom log schema.
+// Unlike `buildSchema` it reports all problems of a schema that fails validation.
+func buildTestParser(id string, c *CustomLog) (parsers.Interface, error) {
+	desc := logtypes.Desc{
+		Name:         id,
+		Description:  c.Description,
+		ReferenceURL: c.ReferenceURL,
+	}
+	desc.Fill()
+	if err := desc.Validate(); err != nil {
+		return nil, NewAPIError(ErrInvalidMetadata, err.Error())
+	}
+	schema := logschema.Schema{}
+	if err := yaml.Unmarshal([]byte(c.LogSpec), &schema); err != nil {
+		return nil, NewAPIError(ErrInvalidSyntax, err.Error())
+	}
+	if p := schema.Parser; p != nil && p.Native != nil {
+		return nil, NewAPIError(ErrInvalidLogSchema, "schemas using a native parser cannot be tested")
+	}
+	entry, err := customlogs.Build(desc, &schema)
+	if err != nil {
+		msg := err.Error()
+		if problems := logschema.ValidationErrors(err); len(problems) > 0 {
+			details := make([]string, len(problems))
+			for i, p := range problems {
+				details[i] = p.String()
+			}
+			msg = fmt.Sprintf("%s: %s", msg, strings.Join(details, "; "))
+		}
+		return nil, NewAPIError(ErrInvalidLogSchema, msg)
+	}
+	parser, err := entry.NewParser(nil)
+	if err != nil {
+		return nil, NewAPIError(ErrInvalidLogSchema, err.Error())
+	}
+	return parser, nil
+}
+
+func newTestLogEvent(result *pantherlog.Result) (*TestLogEvent, error) {
+	data, err := pantherlog.ConfigJSON().Marshal(result)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode parsed event")
+	}
+	var values map[string]interface{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, errors.Wrap(err, "failed to decode parsed event")
+	}
+	event := TestLogEvent{
+		Event: string(data),
+	}
+	for key, value := range values {
+		if !strings.HasPrefix(key, pantherlog.FieldPrefixJSON) {
+			event.Fields = appendFieldPaths(event.Fields, key, value)
+			continue
+		}
+		if !strings.HasPrefix(key, "p_any_") {
+			continue
+		}
+		if event.Indicators == nil {
+			event.Indicators = make(map[string][]string)
+		}
+		if items, ok := value.([]interface{}); ok {
+			for _, item := range items {
+				event.Indicators[key] = append(event.Indicators[key], fmt.Sprint(item))
+			}
+		}
+	}
+	sort.Strings(event.Fields)
+	return &event, nil
+}
+
+// appendFieldPaths appends the paths of all non-null values using `.` for nested objects and `[]` for array elements
+func appendFieldPaths(paths []string, path string, value interface{}) []string {
+	switch v := value.(type) {
+	case nil:
+		return paths
+	case map[string]interface{}:
+		for key, value := range v {
+			paths = appendFieldPaths(paths, path+"."+key, value)
+		}
+		return paths
+	case []interface{}:
+		n := len(paths)
+		for _, item := range v {
+			paths = appendFieldPaths(paths, path+"[]", item)
+		}
+		return append(paths[:n], uniquePaths(paths[n:])...)
+	default:
+		return append(paths, path)
+	}
+}
+
+func uniquePaths(paths []string) []string {
+	seen := make(map[string]bool, len(paths))
+	unique := paths[:0]
+	for _, p := range paths {
+		if !seen[p] {
+			seen[p] = true
+			unique = append(unique, p)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,100 @@
+package logtypesapi_test
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/core/logtypesapi"
+)
+
+func TestAPI_TestCustomLog(t *testing.T) {
+	assert := require.New(t)
+	ctx := context.Background()
+	api := logtypesapi.LogTypesAPI{
+		Database: logtypesapi.NewInMemory(),
+	}
+	logSpec := `
+version: 0
+fields:
+- name: ts
+  type: timestamp
+  timeFormat: rfc3339
+  isEventTime: true
+  required: true
+- name: src
+  type: object
+  fields:
+  - name: ip
+    type: string
+    indicators: [ip]
+- name: tags
+  type: array
+  element:
+    type: string
+`
+	reply, err := api.TestCustomLog(ctx, &logtypesapi.TestCustomLogInput{
+		CustomLog: logtypesapi.CustomLog{
+			LogSpec: logSpec,
+		},
+		Samples: logtypesapi.Samples{
+			Lines: []string{
+				`{"ts": "2020-01-02T03:04:05Z", "src": {"ip": "1.1.1.1"}, "tags": ["a", "b"]}`,
+				`{"src": {"ip": "2.2.2.2"}}`,
+				`{"ts": "2020-01-02T03:04:05Z"`,
+			},
+		},
+	})
+	assert.NoError(err)
+	assert.Equal(1, reply.NumEvents)
+	assert.Equal(2, reply.NumErrors)
+	assert.Len(reply.Results, 3)
+
+	result := reply.Results[0]
+	assert.Equal(1, result.Line)
+	assert.Empty(result.Error)
+	assert.Len(result.Events, 1)
+	event := result.Events[0]
+	assert.Equal([]string{"src.ip", "tags[]", "ts"}, event.Fields)
+	assert.Equal(map[string][]string{"p_any_ip_addresses": {"1.1.1.1"}}, event.Indicators)
+	assert.Contains(event.Event, `"p_log_type":"Custom.Test"`)
+
+	assert.Equal(2, reply.Results[1].Line)
+	assert.Empty(reply.Results[1].Events)
+	assert.NotEmpty(reply.Results[1].Error)
+	assert.NotEmpty(reply.Results[2].Error)
+
+	// Schema problems are reported in detail
+	_, err = api.TestCustomLog(ctx, &logtypesapi.TestCustomLogInput{
+		CustomLog: logtypesapi.CustomLog{
+			LogSpec: "version: 0\nfields:\n- name: foo\n  type: nope\n",
+		},
+		Samples: logtypesapi.Samples{
+			Lines: []string{`{}`},
+		},
+	})
+	assert.Error(err)
+	apiErr := logtypesapi.AsAPIError(err)
+	assert.NotNil(apiErr)
+	assert.Equal(logtypesapi.ErrInvalidLogSchema, apiErr.Code)
+	assert.Contains(apiErr.Message, "fields.0")
+}
@@ -21,18 +21,22 @@ package api
 import (
 	"errors"
 	"fmt"
+	"net/http"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/organizations"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/aws/aws-sdk-go/service/sts"
 	"go.uber.org/zap"
 
 	"github.com/panther-labs/panther/api/lambda/source/models"
+	gcppoller "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/gcp"
+	k8spoller "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/k8s"
 	"github.com/panther-labs/panther/pkg/genericapi"
 )
 
@@ -57,6 +61,10 @@ func (api *API) CheckIntegration(input *models.CheckIntegrationInput) (*models.S
 		return api.checkAwsS3Integration(input), nil
 	case models.IntegrationTypeSqs:
 		return api.checkSqsQueueHealth(input), nil
+	case models.IntegrationTypeGCPScan:
+		return api.checkGCPScanIntegration(input), nil
+	case models.IntegrationTypeK8sScan:
+		return api.checkK8sScanIntegration(input), nil
 	default:
 		return nil, checkIntegrationInternalError
 	}
@@ -68,9 +76,18 @@ func (api *API) checkAwsScanIntegration(input *models.CheckIntegrationInput) *mo
 		// Default to true, if these need to be checked and they are not healthy they will be overwritten
 		CWERoleStatus:         models.SourceIntegrationItemStatus{Healthy: true, Message: "Real time event setup is not enabled."},
 		RemediationRoleStatus: models.SourceIntegrationItemStatus{Healthy: true, Message: "Automatic remediation is not enabled."},
+		OrganizationStatus:    models.SourceIntegrationItemStatus{Healthy: true, Message: "Organization scanning is not enabled."},
+	}
+	var auditRoleCreds *credentials.Credentials
+	if input.AuditRole != nil {
+		auditRoleCreds, out.AuditRoleStatus = api.getAuditRoleCredentialsWithStatus(input.AWSAccountID, input.AuditRole)
+	} else {
+		auditRoleCreds, out.AuditRoleStatus = api.getCredentialsWithStatus(fmt.Sprintf(auditRoleFormat,
+			input.AWSAccountID, api.Config.Region))
+	}
+	if aws.BoolValue(input.EnableOrganization) && out.AuditRoleStatus.Healthy {
+		out.OrganizationStatus = api.checkOrganization(auditRoleCreds)
 	}
-	_, out.AuditRoleStatus = api.getCredentialsWithStatus(fmt.Sprintf(auditRoleFormat,
-		input.AWSAccountID, api.Config.Region))
 	if aws.BoolValue(input.EnableCWESetup) {
 		_, out.CWERoleStatus = api.getCredentialsWithStatus(fmt.Sprintf(cweRoleFormat,
 			input.AWSAccountID, api.Config.Region))
@@ -82,6 +99,91 @@ func (api *API) checkAwsScanIntegration(input *models.CheckIntegrationInput) *mo
 	return out
 }
 
+func (api *API) checkGCPScanIntegration(input *models.CheckIntegrationInput) *models.SourceIntegrationHealth {
+	out := &models.SourceIntegrationHealth{
+		IntegrationType: input.IntegrationType,
+	}
+	key, err := gcppoller.ParseServiceAccountKey([]byte(input.GCPServiceAccountKey))
+	if err != nil {
+		out.ServiceAccountStatus = models.SourceIntegrationItemStatus{
+			Healthy:      false,
+			Message:      "The service account key is invalid.",
+			ErrorMessage: err.Error(),
+		}
+		return out
+	}
+	out.ServiceAccountStatus = models.SourceIntegrationItemStatus{
+		Healthy: true,
+		Message: fmt.Sprintf("The key of service account %s is valid.", key.ClientEmail),
+	}
+	out.ProjectsStatus = checkProjects(gcppoller.NewClient(key), input.GCPProjectIDs)
+	return out
+}
+
+// checkProjects verifies the service account can read each project, which also verifies the key was
+// not revoked.
+func checkProjects(client *http.Client, projectIDs []string) models.SourceIntegrationItemStatus {
+	if len(projectIDs) == 0 {
+		return models.SourceIntegrationItemStatus{
+			Healthy: false,
+			Message: "At least one project must be specified.",
+		}
+	}
+	for _, projectID := range projectIDs {
+		if err := gcppoller.CheckProject(client, projectID); err != nil {
+			return models.SourceIntegrationItemStatus{
+				Healthy:      false,
+				Message:      fmt.Sprintf("An error occurred while trying to get project %s.", projectID),
+				ErrorMessage: err.Error(),
+			}
+		}
+	}
+	return models.SourceIntegrationItemStatus{
+		Healthy: true,
+		Message: "We were able to call resourcemanager.projects.get on the specified projects.",
+	}
+}
+
+func (api *API) checkK8sScanIntegration(input *models.CheckIntegrationInput) *models.SourceIntegrationHealth {
+	out := &models.SourceIntegrationHealth{
+		IntegrationType: input.IntegrationType,
+	}
+	credentials := &k8spoller.Credentials{
+		Kubeconfig:           input.K8sKubeconfig,
+		Server:               input.K8sAPIServerURL,
+		Token:                input.K8sServiceAccountToken,
+		CertificateAuthority: input.K8sCertificateAuthority,
+	}
+	cluster, err := credentials.Cluster()
+	if err != nil {
+		out.CredentialsStatus = models.SourceIntegrationItemStatus{
+			Healthy:      false,
+			Message:      "The cluster credentials are invalid.",
+			ErrorMessage: err.Error(),
+		}
+		return out
+	}
+	out.CredentialsStatus = models.SourceIntegrationItemStatus{
+		Healthy: true,
+		Message: fmt.Sprintf("The credentials for API server %s are valid.", cluster.Server),
+	}
+
+	// Listing namespaces verifies the API server is reachable, trusted and accepts the credentials
+	if err = k8spoller.CheckCluster(k8spoller.NewClient(cluster), cluster.Server); err != nil {
+		out.ClusterStatus = models.SourceIntegrationItemStatus{
+			Healthy:      false,
+			Message:      fmt.Sprintf("An error occurred while trying to list the namespaces of cluster %s.", cluster.Server),
+			ErrorMessage: err.Error(),
+		}
+		return out
+	}
+	out.ClusterStatus = models.SourceIntegrationItemStatus{
+		Healthy: true,
+		Message: "We were able to list the namespaces of the cluster.",
+	}
+	return out
+}
+
 func (api *API) checkAwsS3Integration(input *models.CheckIntegrationInput) *models.SourceIntegrationHealth {
 	out := &models.SourceIntegrationHealth{
 		IntegrationType: input.IntegrationType,
@@ -143,6 +245,27 @@ func (api *API) checkKey(roleCredentials *credentials.Credentials, key string) m
 	}
 }
 
+// checkOrganization verifies the audit role can enumerate the accounts of the AWS Organization, which
+// is only allowed from the management account or a delegated administrator.
+func (api *API) checkOrganization(roleCredentials *credentials.Credentials) models.SourceIntegrationItemStatus {
+	organizationsClient := organizations.New(api.AwsSession, &aws.Config{Credentials: roleCredentials})
+
+	_, err := organizationsClient.ListAccounts(&organizations.ListAccountsInput{MaxResults: aws.Int64(1)})
+	if err != nil {
+		return models.SourceIntegrationItemStatus{
+			Healthy: false,
+			Message: "An error occurred while trying to list the accounts of the AWS Organization. " +
+				"The account must be the management account or a delegated administrator of the organization.",
+			ErrorMessage: err.Error(),
+		}
+	}
+
+	return models.SourceIntegrationItemStatus{
+		Healthy: true,
+		Message: "We were able to call organizations:ListAccounts in the AWS Organization.",
+	}
+}
+
 func (api *API) checkBucket(roleCredentials *credentials.Credentials, bucket string) models.SourceIntegrationItemStatus {
 	s3Client := s3.New(api.AwsSession, &aws.Config{Credentials: roleCredentials})
 
@@ -161,6 +284,28 @@ func (api *API) checkBucket(roleCredentials *credentials.Credentials, bucket str
 	}
 }
 
+// getAuditRoleCredentialsWithStatus verifies the audit role of an integration can be assumed through its role
+// chain, with its external IDs and session tags.
+func (api *API) getAuditRoleCredentialsWithStatus(
+	accountID string, auditRole *models.AuditRoleConfig) (*credentials.Credentials, models.SourceIntegrationItemStatus) {
+
+	zap.L().Debug("checking audit role", zap.Any("auditRole", auditRole))
+	// Resources are reported in the account of the audit role
+	if roleARN, err := arn.Parse(auditRole.RoleARN); err != nil || roleARN.AccountID != accountID {
+		return nil, models.SourceIntegrationItemStatus{
+			Healthy: false,
+			Message: fmt.Sprintf("The audit role %s is not in account %s", auditRole.RoleARN, accountID),
+		}
+	}
+
+	roleCredentials := auditRole.Credentials(api.AwsSession)
+	if len(auditRole.RoleChain) == 0 {
+		return roleCredentials, api.checkCredentials(roleCredentials, auditRole.RoleARN)
+	}
+	return roleCredentials, api.checkCredentials(roleCredentials,
+		fmt.Sprintf("%s through %d intermediate role(s)", auditRole.RoleARN, len(auditRole.RoleChain)))
+}
+
 func (api *API) getCredentialsWithStatus(roleARN string) (*credentials.Credentials, models.SourceIntegrationItemStatus) {
 	zap.L().Debug("checking role", zap.String("roleArn", roleARN))
 	// Setup new credentials with the role
@@ -168,19 +313,22 @@ func (api *API) getCredentialsWithStatus(roleARN string) (*credentials.Credentia
 		api.AwsSession,
 		roleARN,
 	)
+	return roleCredentials, api.checkCredentials(roleCredentials, roleARN)
+}
 
-	// Use the role to make sure it's good
+// checkCredentials uses the credentials of a role to make sure it can be assumed
+func (api *API) checkCredentials(roleCredentials *credentials.Credentials, roleARN string) models.SourceIntegrationItemStatus {
 	stsClient := sts.New(api.AwsSession, aws.NewConfig().WithCredentials(roleCredentials))
 	_, err := stsClient.GetCallerIdentity(&sts.GetCallerIdentityInput{})
 	if err != nil {
-		return roleCredentials, models.SourceIntegrationItemStatus{
+		return models.SourceIntegrationItemStatus{
 			Healthy:      false,
 			Message:      fmt.Sprintf("We were unable to assume %s", roleARN),
 			ErrorMessage: err.Error(),
 		}
 	}
 
-	return roleCredentials, models.SourceIntegrationItemStatus{
+	return models.SourceIntegrationItemStatus{
 		Healthy: true,
 		Message: fmt.Sprintf("We were able to successfully assume %s", roleARN),
 	}
@@ -209,6 +357,10 @@ func (api *API) evaluateIntegration(integration *models.CheckIntegrationInput) (
 		if aws.BoolValue(integration.EnableCWESetup) && !status.CWERoleStatus.Healthy {
 			return status.CWERoleStatus.Message, false, nil
 		}
+
+		if aws.BoolValue(integration.EnableOrganization) && !status.OrganizationStatus.Healthy {
+			return status.OrganizationStatus.Message, false, nil
+		}
 		return "", true, nil
 	case models.IntegrationTypeAWS3:
 		if !status.ProcessingRoleStatus.Healthy {
@@ -228,6 +380,24 @@ func (api *API) evaluateIntegration(integration *models.CheckIntegrationInput) (
 			return status.SqsStatus.Message, false, nil
 		}
 		return status.SqsStatus.Message, true, nil
+	case models.IntegrationTypeGCPScan:
+		if !status.ServiceAccountStatus.Healthy {
+			return status.ServiceAccountStatus.Message, false, nil
+		}
+
+		if !status.ProjectsStatus.Healthy {
+			return status.ProjectsStatus.Message, false, nil
+		}
+		return "", true, nil
+	case models.IntegrationTypeK8sScan:
+		if !status.CredentialsStatus.Healthy {
+			return status.CredentialsStatus.Message, false, nil
+		}
+
+		if !status.ClusterStatus.Healthy {
+			return status.ClusterStatus.Message, false, nil
+		}
+		return "", true, nil
 
 	default:
 		return "", false, errors.New("invalid integration type")
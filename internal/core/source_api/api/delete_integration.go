@@ -76,6 +76,13 @@ func (api *API) DeleteIntegration(input *models.DeleteIntegrationInput) error {
 				return deleteIntegrationInternalError
 			}
 		}
+	case models.IntegrationTypeGCPScan, models.IntegrationTypeK8sScan:
+		if err := api.DeleteCredentialsSecret(integrationItem.IntegrationType, input.IntegrationID); err != nil {
+			zap.L().Error("failed to delete integration credentials",
+				zap.String("integrationId", input.IntegrationID),
+				zap.Error(err))
+			return deleteIntegrationInternalError
+		}
 	case models.IntegrationTypeSqs:
 		if err := api.RemoveSourceFromLambdaTrigger(input.IntegrationID); err != nil {
 			zap.L().Error("failed to remove sqs queue from source",
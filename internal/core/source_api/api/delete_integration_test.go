@@ -26,6 +26,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/stretchr/testify/assert"
@@ -124,6 +125,52 @@ func TestDeleteLogIntegrationKeepSqsQueuePermissions(t *testing.T) {
 	apiTest.AssertExpectations(t)
 }
 
+func TestDeleteScanIntegrationScanTypes(t *testing.T) {
+	t.Parallel()
+	// The credentials of the integration are deleted with it
+	for integrationType, secretID := range map[string]string{
+		models.IntegrationTypeGCPScan: "panther-source-gcp-" + testIntegrationID,
+		models.IntegrationTypeK8sScan: "panther-source-k8s-" + testIntegrationID,
+	} {
+		integrationType, secretID := integrationType, secretID
+		t.Run(integrationType, func(t *testing.T) {
+			t.Parallel()
+			apiTest := NewAPITest()
+
+			apiTest.mockDdb.On("DeleteItem", mock.Anything).Return(&dynamodb.DeleteItemOutput{}, nil)
+			apiTest.mockDdb.On("GetItem", mock.Anything).Return(generateGetItemOutput(integrationType), nil)
+			apiTest.mockSecrets.On("DeleteSecret", &secretsmanager.DeleteSecretInput{
+				SecretId:                   aws.String(secretID),
+				ForceDeleteWithoutRecovery: aws.Bool(true),
+			}).Return(&secretsmanager.DeleteSecretOutput{}, nil)
+
+			result := apiTest.DeleteIntegration(&models.DeleteIntegrationInput{
+				IntegrationID: testIntegrationID,
+			})
+
+			assert.NoError(t, result)
+			apiTest.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeleteGCPScanIntegrationSecretNotFound(t *testing.T) {
+	t.Parallel()
+	apiTest := NewAPITest()
+
+	apiTest.mockDdb.On("DeleteItem", mock.Anything).Return(&dynamodb.DeleteItemOutput{}, nil)
+	apiTest.mockDdb.On("GetItem", mock.Anything).Return(generateGetItemOutput(models.IntegrationTypeGCPScan), nil)
+	apiTest.mockSecrets.On("DeleteSecret", mock.Anything).Return(&secretsmanager.DeleteSecretOutput{},
+		awserr.New(secretsmanager.ErrCodeResourceNotFoundException, "secret not found", nil))
+
+	result := apiTest.DeleteIntegration(&models.DeleteIntegrationInput{
+		IntegrationID: testIntegrationID,
+	})
+
+	assert.NoError(t, result)
+	apiTest.AssertExpectations(t)
+}
+
 func TestDeleteIntegrationItemError(t *testing.T) {
 	t.Parallel()
 	apiTest := NewAPITest()
@@ -0,0 +1,119 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/pkg/genericapi"
+	"github.com/panther-labs/panther/pkg/stringset"
+)
+
+// Label of the integrations created for organization member accounts, followed by the account ID
+const organizationAccountLabelPrefix = "Organization "
+
+var (
+	onboardOrganizationInternalError = &genericapi.InternalError{Message: "Failed to onboard organization accounts"}
+)
+
+// OnboardOrganizationAccounts creates an aws-scan integration for each member account of an AWS
+// Organization which isn't onboarded yet, when the organization integration has auto onboarding enabled.
+//
+// The new integrations inherit the scan settings of the organization integration. Accounts which fail
+// the configuration check, most commonly because the audit role isn't deployed to them yet, are skipped
+// and retried the next time the organization accounts are scanned.
+func (api *API) OnboardOrganizationAccounts(
+	input *models.OnboardOrganizationAccountsInput) ([]*models.SourceIntegration, error) {
+
+	item, err := api.getItem(input.IntegrationID)
+	if err != nil {
+		return nil, err
+	}
+	organization := itemToIntegration(item)
+	if !organization.ScansOrganization() || !aws.BoolValue(organization.OrganizationAutoOnboard) {
+		zap.L().Debug("organization auto onboarding is not enabled", zap.String("integrationId", input.IntegrationID))
+		return nil, nil
+	}
+
+	existingIntegrations, err := api.ListIntegrations(&models.ListIntegrationsInput{
+		IntegrationType: aws.String(models.IntegrationTypeAWSScan),
+	})
+	if err != nil {
+		zap.L().Error("failed to fetch integrations", zap.Error(errors.WithStack(err)))
+		return nil, onboardOrganizationInternalError
+	}
+	onboardedAccounts := make(map[string]struct{}, len(existingIntegrations))
+	for _, existingIntegration := range existingIntegrations {
+		onboardedAccounts[existingIntegration.AWSAccountID] = struct{}{}
+	}
+
+	var newIntegrations []*models.SourceIntegration
+	for _, accountID := range stringset.Dedup(input.AccountIDs) {
+		if _, ok := onboardedAccounts[accountID]; ok {
+			continue
+		}
+		newIntegration, err := api.PutIntegration(&models.PutIntegrationInput{
+			PutIntegrationSettings: organizationAccountSettings(organization, accountID),
+		})
+		if err != nil {
+			var invalidInputErr *genericapi.InvalidInputError
+			if errors.As(err, &invalidInputErr) {
+				zap.L().Info("skipping organization account",
+					zap.String("integrationId", input.IntegrationID),
+					zap.String("accountId", accountID),
+					zap.String("reason", invalidInputErr.Message))
+				continue
+			}
+			return nil, err
+		}
+		zap.L().Info("onboarded organization account",
+			zap.String("integrationId", input.IntegrationID),
+			zap.String("accountId", accountID),
+			zap.String("newIntegrationId", newIntegration.IntegrationID))
+		newIntegrations = append(newIntegrations, newIntegration)
+	}
+	return newIntegrations, nil
+}
+
+// organizationAccountSettings builds the settings of a member account integration from the
+// organization integration.
+func organizationAccountSettings(organization *models.SourceIntegration, accountID string) models.PutIntegrationSettings {
+	settings := models.PutIntegrationSettings{
+		IntegrationLabel:        organizationAccountLabelPrefix + accountID,
+		IntegrationType:         models.IntegrationTypeAWSScan,
+		UserID:                  organization.CreatedBy,
+		AWSAccountID:            accountID,
+		CWEEnabled:              organization.CWEEnabled,
+		RemediationEnabled:      organization.RemediationEnabled,
+		ScanIntervalMins:        organization.ScanIntervalMins,
+		Enabled:                 organization.Enabled,
+		RegionIgnoreList:        organization.RegionIgnoreList,
+		ResourceTypeIgnoreList:  organization.ResourceTypeIgnoreList,
+		ResourceRegexIgnoreList: organization.ResourceRegexIgnoreList,
+		ResourceTypeScanConfigs: organization.ResourceTypeScanConfigs,
+	}
+	// Member accounts are reached through the same role chain, with an audit role of the same name
+	if organization.AuditRole != nil {
+		settings.AuditRole = organization.AuditRole.InAccount(accountID)
+	}
+	return settings
+}
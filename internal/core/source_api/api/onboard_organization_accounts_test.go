@@ -0,0 +1,164 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/internal/core/source_api/ddb"
+	"github.com/panther-labs/panther/internal/core/source_api/ddb/modelstest"
+)
+
+func generateOrganizationAttributes(autoOnboard bool) map[string]*dynamodb.AttributeValue {
+	attributes := generateDDBAttributes(models.IntegrationTypeAWSScan)
+	attributes["createdBy"] = &dynamodb.AttributeValue{S: aws.String(testUserID)}
+	attributes["scanIntervalMins"] = &dynamodb.AttributeValue{N: aws.String("1440")}
+	attributes["organizationScanEnabled"] = &dynamodb.AttributeValue{BOOL: aws.Bool(true)}
+	attributes["organizationAutoOnboard"] = &dynamodb.AttributeValue{BOOL: aws.Bool(autoOnboard)}
+	return attributes
+}
+
+func TestOnboardOrganizationAccounts(t *testing.T) {
+	t.Parallel()
+	apiTest := NewAPITest()
+	var checked []string
+	apiTest.EvaluateIntegrationFunc = func(input *models.CheckIntegrationInput) (string, bool, error) {
+		checked = append(checked, input.AWSAccountID)
+		// The audit role is not deployed to this account yet
+		if input.AWSAccountID == "444444444444" {
+			return "audit role missing", false, nil
+		}
+		return "", true, nil
+	}
+	mockDdb := &modelstest.MockDDBClient{
+		MockScanAttributes: []map[string]*dynamodb.AttributeValue{
+			generateOrganizationAttributes(true),
+			{
+				"integrationId":   {S: aws.String("0aab70c6-da66-4bb9-a83c-bbe8f5717fde")},
+				"integrationType": {S: aws.String(models.IntegrationTypeAWSScan)},
+				"awsAccountId":    {S: aws.String("333333333333")},
+			},
+		},
+	}
+	mockDdb.On("GetItem", mock.Anything).
+		Return(&dynamodb.GetItemOutput{Item: generateOrganizationAttributes(true)}, nil)
+	apiTest.DdbClient = &ddb.DDB{Client: mockDdb, TableName: "test"}
+	apiTest.mockSqs.On("SendMessageWithContext", mock.Anything, mock.Anything).Return(&sqs.SendMessageOutput{}, nil)
+	apiTest.mockSqs.On("SendMessageBatch", mock.Anything).Return(&sqs.SendMessageBatchOutput{}, nil)
+
+	out, err := apiTest.OnboardOrganizationAccounts(&models.OnboardOrganizationAccountsInput{
+		IntegrationID: testIntegrationID,
+		AccountIDs:    []string{"222222222222", "333333333333", "444444444444", "222222222222"},
+	})
+	require.NoError(t, err)
+	require.Len(t, out, 1)
+	assert.Equal(t, "222222222222", out[0].AWSAccountID)
+	assert.Equal(t, "Organization 222222222222", out[0].IntegrationLabel)
+	assert.Equal(t, testUserID, out[0].CreatedBy)
+	assert.Equal(t, 1440, out[0].ScanIntervalMins)
+	// Member accounts don't scan the organization themselves
+	assert.False(t, out[0].ScansOrganization())
+	// Accounts which are already onboarded are not checked again
+	assert.Equal(t, []string{"222222222222", "444444444444"}, checked)
+	apiTest.AssertExpectations(t)
+}
+
+// Member accounts are scanned through the role chain of the organization integration
+func TestOnboardOrganizationAccountsAuditRole(t *testing.T) {
+	t.Parallel()
+	apiTest := NewAPITest()
+	var checked []*models.AuditRoleConfig
+	apiTest.EvaluateIntegrationFunc = func(input *models.CheckIntegrationInput) (string, bool, error) {
+		checked = append(checked, input.AuditRole)
+		return "", true, nil
+	}
+	auditRole, err := dynamodbattribute.Marshal(&models.AuditRoleConfig{
+		RoleARN:    "arn:aws:iam::" + testAccountID + ":role/BusinessUnitAudit",
+		ExternalID: "bu-external-id",
+		RoleChain:  []models.ChainedRole{{RoleARN: "arn:aws:iam::111111111111:role/SecurityHub"}},
+	})
+	require.NoError(t, err)
+	organization := generateOrganizationAttributes(true)
+	organization["auditRole"] = auditRole
+	mockDdb := &modelstest.MockDDBClient{MockScanAttributes: []map[string]*dynamodb.AttributeValue{organization}}
+	mockDdb.On("GetItem", mock.Anything).Return(&dynamodb.GetItemOutput{Item: organization}, nil)
+	apiTest.DdbClient = &ddb.DDB{Client: mockDdb, TableName: "test"}
+	apiTest.mockSqs.On("SendMessageWithContext", mock.Anything, mock.Anything).Return(&sqs.SendMessageOutput{}, nil)
+	apiTest.mockSqs.On("SendMessageBatch", mock.Anything).Return(&sqs.SendMessageBatchOutput{}, nil)
+
+	out, err := apiTest.OnboardOrganizationAccounts(&models.OnboardOrganizationAccountsInput{
+		IntegrationID: testIntegrationID,
+		AccountIDs:    []string{"222222222222"},
+	})
+	require.NoError(t, err)
+	require.Len(t, out, 1)
+	expected := &models.AuditRoleConfig{
+		RoleARN:    "arn:aws:iam::222222222222:role/BusinessUnitAudit",
+		ExternalID: "bu-external-id",
+		RoleChain:  []models.ChainedRole{{RoleARN: "arn:aws:iam::111111111111:role/SecurityHub"}},
+	}
+	assert.Equal(t, []*models.AuditRoleConfig{expected}, checked)
+	assert.Equal(t, expected, out[0].AuditRole)
+	apiTest.AssertExpectations(t)
+}
+
+func TestOnboardOrganizationAccountsDisabled(t *testing.T) {
+	t.Parallel()
+	apiTest := NewAPITest()
+	apiTest.EvaluateIntegrationFunc = func(_ *models.CheckIntegrationInput) (string, bool, error) {
+		t.Fatal("no integration should be checked")
+		return "", false, nil
+	}
+	mockDdb := &modelstest.MockDDBClient{}
+	mockDdb.On("GetItem", mock.Anything).
+		Return(&dynamodb.GetItemOutput{Item: generateOrganizationAttributes(false)}, nil)
+	apiTest.DdbClient = &ddb.DDB{Client: mockDdb, TableName: "test"}
+
+	out, err := apiTest.OnboardOrganizationAccounts(&models.OnboardOrganizationAccountsInput{
+		IntegrationID: testIntegrationID,
+		AccountIDs:    []string{"222222222222"},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, out)
+	apiTest.AssertExpectations(t)
+}
+
+func TestOnboardOrganizationAccountsNotFound(t *testing.T) {
+	t.Parallel()
+	apiTest := NewAPITest()
+	mockDdb := &modelstest.MockDDBClient{}
+	mockDdb.On("GetItem", mock.Anything).Return(&dynamodb.GetItemOutput{}, nil)
+	apiTest.DdbClient = &ddb.DDB{Client: mockDdb, TableName: "test"}
+
+	out, err := apiTest.OnboardOrganizationAccounts(&models.OnboardOrganizationAccountsInput{
+		IntegrationID: testIntegrationID,
+		AccountIDs:    []string{"222222222222"},
+	})
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
@@ -21,6 +21,7 @@ package api
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -34,6 +35,8 @@ import (
 	"github.com/panther-labs/panther/api/lambda/source/models"
 	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
 	awspoller "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws"
+	gcppoller "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/gcp"
+	k8spoller "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/k8s"
 	"github.com/panther-labs/panther/internal/log_analysis/datacatalog_updater/datacatalog"
 	"github.com/panther-labs/panther/pkg/awsbatch/sqsbatch"
 	"github.com/panther-labs/panther/pkg/genericapi"
@@ -68,7 +71,7 @@ func (api *API) PutIntegration(input *models.PutIntegrationInput) (newIntegratio
 	}
 
 	// Try to setupExternalResources
-	if err := api.setupExternalResources(newIntegration); err != nil {
+	if err := api.setupExternalResources(newIntegration, input); err != nil {
 		zap.L().Error("failed to setup external integration", zap.Error(err))
 		return nil, putIntegrationInternalError
 	}
@@ -80,7 +83,8 @@ func (api *API) PutIntegration(input *models.PutIntegrationInput) (newIntegratio
 		return nil, putIntegrationInternalError
 	}
 
-	if input.IntegrationType == models.IntegrationTypeAWSScan {
+	switch input.IntegrationType {
+	case models.IntegrationTypeAWSScan, models.IntegrationTypeGCPScan, models.IntegrationTypeK8sScan:
 		err = api.FullScan(&models.FullScanInput{Integrations: []*models.SourceIntegrationMetadata{&newIntegration.SourceIntegrationMetadata}})
 		if err != nil {
 			zap.L().Error("failed to trigger scanning of resources", zap.Error(err))
@@ -91,8 +95,21 @@ func (api *API) PutIntegration(input *models.PutIntegrationInput) (newIntegratio
 	return newIntegration, nil
 }
 
-func (api *API) setupExternalResources(integration *models.SourceIntegration) error {
+func (api *API) setupExternalResources(integration *models.SourceIntegration, input *models.PutIntegrationInput) error {
 	switch integration.IntegrationType {
+	case models.IntegrationTypeGCPScan:
+		if err := api.CreateCredentialsSecret(integration.IntegrationType, integration.IntegrationID, input.GCPServiceAccountKey); err != nil {
+			return err
+		}
+	case models.IntegrationTypeK8sScan:
+		credentials, err := jsoniter.MarshalToString(k8sCredentials(
+			input.K8sKubeconfig, input.K8sAPIServerURL, input.K8sServiceAccountToken, input.K8sCertificateAuthority))
+		if err != nil {
+			return errors.Wrap(err, "failed to encode cluster credentials")
+		}
+		if err := api.CreateCredentialsSecret(integration.IntegrationType, integration.IntegrationID, credentials); err != nil {
+			return err
+		}
 	case models.IntegrationTypeAWS3:
 		if err := api.AllowExternalSnsTopicSubscription(integration.AWSAccountID); err != nil {
 			return errors.Wrap(err, "failed to add permissions to log processor queue")
@@ -125,15 +142,24 @@ func (api *API) validateIntegration(input *models.PutIntegrationInput) error {
 
 	// Validate the new integration
 	reason, passing, err := api.EvaluateIntegrationFunc(&models.CheckIntegrationInput{
-		AWSAccountID:      input.AWSAccountID,
-		IntegrationType:   input.IntegrationType,
-		IntegrationLabel:  input.IntegrationLabel,
-		EnableCWESetup:    input.CWEEnabled,
-		EnableRemediation: input.RemediationEnabled,
-		S3Bucket:          input.S3Bucket,
-		S3PrefixLogTypes:  input.S3PrefixLogTypes,
-		KmsKey:            input.KmsKey,
-		SqsConfig:         input.SqsConfig,
+		AWSAccountID:         input.AWSAccountID,
+		IntegrationType:      input.IntegrationType,
+		IntegrationLabel:     input.IntegrationLabel,
+		EnableCWESetup:       input.CWEEnabled,
+		EnableRemediation:    input.RemediationEnabled,
+		EnableOrganization:   input.OrganizationScanEnabled,
+		AuditRole:            input.AuditRole,
+		S3Bucket:             input.S3Bucket,
+		S3PrefixLogTypes:     input.S3PrefixLogTypes,
+		KmsKey:               input.KmsKey,
+		SqsConfig:            input.SqsConfig,
+		GCPServiceAccountKey: input.GCPServiceAccountKey,
+		GCPProjectIDs:        input.GCPProjectIDs,
+
+		K8sKubeconfig:           input.K8sKubeconfig,
+		K8sAPIServerURL:         input.K8sAPIServerURL,
+		K8sServiceAccountToken:  input.K8sServiceAccountToken,
+		K8sCertificateAuthority: input.K8sCertificateAuthority,
 	})
 	if err != nil {
 		return putIntegrationInternalError
@@ -200,6 +226,22 @@ func (api API) integrationAlreadyExists(input *models.PutIntegrationInput) error
 						Message: fmt.Sprintf("Integration with label %s already exists", input.IntegrationLabel),
 					}
 				}
+			case models.IntegrationTypeGCPScan:
+				// Each project can only be scanned by one cloudsec integration
+				if projectID := overlappingProject(existingIntegration.GCPProjectIDs, input.GCPProjectIDs); projectID != "" {
+					return &genericapi.InvalidInputError{
+						Message: fmt.Sprintf("Source project %s already onboarded", projectID),
+					}
+				}
+			case models.IntegrationTypeK8sScan:
+				// Each cluster can only be scanned by one cloudsec integration
+				server := k8sAPIServer(k8sCredentials(
+					input.K8sKubeconfig, input.K8sAPIServerURL, input.K8sServiceAccountToken, input.K8sCertificateAuthority))
+				if server != "" && existingIntegration.K8sAPIServerURL == server {
+					return &genericapi.InvalidInputError{
+						Message: fmt.Sprintf("Source cluster %s already onboarded", server),
+					}
+				}
 			}
 		}
 	}
@@ -207,42 +249,111 @@ func (api API) integrationAlreadyExists(input *models.PutIntegrationInput) error
 	return nil
 }
 
+// overlappingProject returns a GCP project which is in both lists, or "" if there is none.
+func overlappingProject(existing, projectIDs []string) string {
+	for _, projectID := range projectIDs {
+		for _, existingProjectID := range existing {
+			if projectID == existingProjectID {
+				return projectID
+			}
+		}
+	}
+	return ""
+}
+
+// k8sCredentials returns the credentials a k8s-scan integration connects to its cluster with, or nil
+// when a request does not include any.
+func k8sCredentials(kubeconfig, server, token, certificateAuthority string) *k8spoller.Credentials {
+	if kubeconfig == "" && token == "" {
+		return nil
+	}
+	return &k8spoller.Credentials{
+		Kubeconfig:           kubeconfig,
+		Server:               server,
+		Token:                token,
+		CertificateAuthority: certificateAuthority,
+	}
+}
+
+// k8sAPIServer returns the URL of the API server cluster credentials connect to, or "" if they are invalid.
+func k8sAPIServer(credentials *k8spoller.Credentials) string {
+	if credentials == nil {
+		return ""
+	}
+	cluster, err := credentials.Cluster()
+	if err != nil {
+		return ""
+	}
+	return cluster.Server
+}
+
 // FullScan schedules scans for each Resource type for each integration.
 //
-// Each Resource type is sent within its own SQS message.
+// Each Resource type is sent within its own SQS message. When the scheduler provides the slices which
+// are due, only those are scanned. Higher priority slices are sent first across all integrations.
 func (api *API) FullScan(input *models.FullScanInput) error {
-	var sqsEntries []*sqs.SendMessageBatchRequestEntry
-
-	// For each integration, add a ScanMsg to the queue per service
+	type pendingScan struct {
+		integration *models.SourceIntegrationMetadata
+		slice       models.ScanSlice
+	}
+	var scans []pendingScan
 	for _, integration := range input.Integrations {
-		for resourceType := range awspoller.ServicePollers {
-			scanMsg := &pollermodels.ScanMsg{
-				Entries: []*pollermodels.ScanEntry{
-					{
-						AWSAccountID:            &integration.AWSAccountID,
-						IntegrationID:           &integration.IntegrationID,
-						ResourceType:            aws.String(resourceType),
-						Enabled:                 integration.Enabled,
-						RegionIgnoreList:        integration.RegionIgnoreList,
-						ResourceTypeIgnoreList:  integration.ResourceTypeIgnoreList,
-						ResourceRegexIgnoreList: integration.ResourceRegexIgnoreList,
-					},
-				},
+		slices, ok := input.ScanSlices[integration.IntegrationID]
+		if !ok {
+			for _, resourceType := range scannedResourceTypes(integration) {
+				slices = append(slices, models.ScanSlice{
+					ResourceType: resourceType,
+					Priority:     integration.ScanConfig(resourceType).Priority,
+				})
 			}
+			models.SortScanSlices(slices)
+		}
+		for _, slice := range slices {
+			scans = append(scans, pendingScan{integration: integration, slice: slice})
+		}
+	}
+	sort.SliceStable(scans, func(i, j int) bool { return scans[i].slice.Priority > scans[j].slice.Priority })
 
-			messageBodyBytes, err := jsoniter.MarshalToString(scanMsg)
-			if err != nil {
-				return &genericapi.InternalError{Message: err.Error()}
-			}
+	// Add a ScanMsg to the queue per slice
+	sqsEntries := make([]*sqs.SendMessageBatchRequestEntry, 0, len(scans))
+	for _, scan := range scans {
+		integration := scan.integration
+		entry := &pollermodels.ScanEntry{
+			IntegrationID:           &integration.IntegrationID,
+			ResourceType:            aws.String(scan.slice.ResourceType),
+			Enabled:                 integration.Enabled,
+			RegionIgnoreList:        integration.RegionIgnoreList,
+			ResourceTypeIgnoreList:  integration.ResourceTypeIgnoreList,
+			ResourceRegexIgnoreList: integration.ResourceRegexIgnoreList,
+		}
+		switch integration.IntegrationType {
+		case models.IntegrationTypeGCPScan:
+			// The GCP poller splits this into one scan per project
+			entry.GCPProjectIDs = integration.GCPProjectIDs
+		case models.IntegrationTypeK8sScan:
+			// The integration is a single cluster, the poller reads its credentials by integration ID
+		default:
+			entry.AWSAccountID = &integration.AWSAccountID
+			entry.AuditRole = integration.AuditRole
+		}
+		// Generates an ID of: IntegrationID-AWSResourceType[-Region]
+		id := integration.IntegrationID + "-" + strings.Replace(scan.slice.ResourceType, ".", "", -1)
+		if scan.slice.Region != "" {
+			entry.Region = aws.String(scan.slice.Region)
+			id += "-" + strings.Replace(scan.slice.Region, "-", "", -1)
+		}
 
-			sqsEntries = append(sqsEntries, &sqs.SendMessageBatchRequestEntry{
-				// Generates an ID of: IntegrationID-AWSResourceType
-				Id: aws.String(
-					integration.IntegrationID + "-" + strings.Replace(resourceType, ".", "", -1),
-				),
-				MessageBody: aws.String(messageBodyBytes),
-			})
+		messageBodyBytes, err := jsoniter.MarshalToString(&pollermodels.ScanMsg{
+			Entries: []*pollermodels.ScanEntry{entry},
+		})
+		if err != nil {
+			return &genericapi.InternalError{Message: err.Error()}
 		}
+
+		sqsEntries = append(sqsEntries, &sqs.SendMessageBatchRequestEntry{
+			Id:          aws.String(id),
+			MessageBody: aws.String(messageBodyBytes),
+		})
 	}
 
 	zap.L().Info(
@@ -259,6 +370,29 @@ func (api *API) FullScan(input *models.FullScanInput) error {
 	return err
 }
 
+// scannedResourceTypes returns all resource types the snapshot pollers scan for an integration.
+func scannedResourceTypes(integration *models.SourceIntegrationMetadata) (resourceTypes []string) {
+	switch integration.IntegrationType {
+	case models.IntegrationTypeGCPScan:
+		for resourceType := range gcppoller.ServicePollers {
+			resourceTypes = append(resourceTypes, resourceType)
+		}
+		return resourceTypes
+	case models.IntegrationTypeK8sScan:
+		for resourceType := range k8spoller.ServicePollers {
+			resourceTypes = append(resourceTypes, resourceType)
+		}
+		return resourceTypes
+	}
+	for resourceType := range awspoller.ServicePollers {
+		if awspoller.IsOrganizationResourceType(resourceType) && !integration.ScansOrganization() {
+			continue
+		}
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+	return resourceTypes
+}
+
 func (api *API) generateNewIntegration(input *models.PutIntegrationInput) *models.SourceIntegration {
 	metadata := models.SourceIntegrationMetadata{
 		CreatedAtTime:    time.Now(),
@@ -281,6 +415,35 @@ func (api *API) generateNewIntegration(input *models.PutIntegrationInput) *model
 		metadata.RegionIgnoreList = input.RegionIgnoreList
 		metadata.ResourceTypeIgnoreList = input.ResourceTypeIgnoreList
 		metadata.ResourceRegexIgnoreList = input.ResourceRegexIgnoreList
+		metadata.ResourceTypeScanConfigs = input.ResourceTypeScanConfigs
+		metadata.OrganizationScanEnabled = input.OrganizationScanEnabled
+		metadata.OrganizationAutoOnboard = input.OrganizationAutoOnboard
+		metadata.AuditRole = input.AuditRole
+	case models.IntegrationTypeGCPScan:
+		metadata.GCPProjectIDs = input.GCPProjectIDs
+		metadata.LogProcessingRole = api.Config.InputDataRoleArn
+		metadata.ScanIntervalMins = input.ScanIntervalMins
+		metadata.S3Bucket = api.Config.InputDataBucketName
+		metadata.Enabled = input.Enabled
+		metadata.RegionIgnoreList = input.RegionIgnoreList
+		metadata.ResourceTypeIgnoreList = input.ResourceTypeIgnoreList
+		metadata.ResourceRegexIgnoreList = input.ResourceRegexIgnoreList
+		metadata.ResourceTypeScanConfigs = input.ResourceTypeScanConfigs
+		// The key was already validated by the configuration check
+		if key, err := gcppoller.ParseServiceAccountKey([]byte(input.GCPServiceAccountKey)); err == nil {
+			metadata.GCPServiceAccountEmail = key.ClientEmail
+		}
+	case models.IntegrationTypeK8sScan:
+		metadata.K8sAPIServerURL = k8sAPIServer(k8sCredentials(
+			input.K8sKubeconfig, input.K8sAPIServerURL, input.K8sServiceAccountToken, input.K8sCertificateAuthority))
+		metadata.LogProcessingRole = api.Config.InputDataRoleArn
+		metadata.ScanIntervalMins = input.ScanIntervalMins
+		metadata.S3Bucket = api.Config.InputDataBucketName
+		metadata.Enabled = input.Enabled
+		metadata.RegionIgnoreList = input.RegionIgnoreList
+		metadata.ResourceTypeIgnoreList = input.ResourceTypeIgnoreList
+		metadata.ResourceRegexIgnoreList = input.ResourceRegexIgnoreList
+		metadata.ResourceTypeScanConfigs = input.ResourceTypeScanConfigs
 	case models.IntegrationTypeAWS3:
 		metadata.AWSAccountID = input.AWSAccountID
 		metadata.S3Bucket = input.S3Bucket
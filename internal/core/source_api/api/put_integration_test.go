@@ -26,6 +26,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/stretchr/testify/assert"
@@ -35,6 +36,10 @@ import (
 	"github.com/panther-labs/panther/api/lambda/source/models"
 	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
 	awspoller "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws"
+	gcppoller "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/gcp"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/gcp/gcptest"
+	k8spoller "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/k8s"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/k8s/k8stest"
 	"github.com/panther-labs/panther/internal/core/source_api/ddb"
 	"github.com/panther-labs/panther/internal/core/source_api/ddb/modelstest"
 )
@@ -116,6 +121,126 @@ func TestAddToSnapshotQueue(t *testing.T) {
 	apiTest.AssertExpectations(t)
 }
 
+func TestFullScanDueSlices(t *testing.T) {
+	t.Parallel()
+	apiTest := NewAPITest()
+	apiTest.Config.SnapshotPollersQueueURL = "test-url"
+	testIntegration := models.SourceIntegrationMetadata{
+		AWSAccountID:    testAccountID,
+		IntegrationID:   testIntegrationID,
+		IntegrationType: models.IntegrationTypeAWSScan,
+	}
+	input := &models.FullScanInput{
+		Integrations: []*models.SourceIntegrationMetadata{&testIntegration},
+		ScanSlices: map[string][]models.ScanSlice{
+			testIntegrationID: {
+				{ResourceType: "AWS.S3.Bucket", Region: "us-west-2"},
+				{ResourceType: "AWS.IAM.Role", Priority: 10},
+			},
+		},
+	}
+
+	var sent []*sqs.SendMessageBatchRequestEntry
+	apiTest.mockSqs.On("SendMessageBatch", mock.Anything).Return(&sqs.SendMessageBatchOutput{}, nil).
+		Run(func(args mock.Arguments) {
+			sent = append(sent, args.Get(0).(*sqs.SendMessageBatchInput).Entries...)
+		})
+
+	require.NoError(t, apiTest.FullScan(input))
+	apiTest.AssertExpectations(t)
+
+	require.Len(t, sent, 2)
+	assert.Equal(t, testIntegrationID+"-AWSIAMRole", *sent[0].Id)
+	assert.Equal(t, testIntegrationID+"-AWSS3Bucket-uswest2", *sent[1].Id)
+
+	var msg pollermodels.ScanMsg
+	require.NoError(t, jsoniter.UnmarshalFromString(*sent[0].MessageBody, &msg))
+	assert.Equal(t, "AWS.IAM.Role", *msg.Entries[0].ResourceType)
+	assert.Nil(t, msg.Entries[0].Region)
+	require.NoError(t, jsoniter.UnmarshalFromString(*sent[1].MessageBody, &msg))
+	assert.Equal(t, "AWS.S3.Bucket", *msg.Entries[0].ResourceType)
+	assert.Equal(t, "us-west-2", *msg.Entries[0].Region)
+}
+
+// Organization resource types are only scanned for integrations with organization scanning enabled
+func TestFullScanOrganization(t *testing.T) {
+	t.Parallel()
+	apiTest := NewAPITest()
+	apiTest.Config.SnapshotPollersQueueURL = "test-url"
+	testIntegration := models.SourceIntegrationMetadata{
+		AWSAccountID:    testAccountID,
+		IntegrationID:   testIntegrationID,
+		IntegrationType: models.IntegrationTypeAWSScan,
+	}
+	organizationIntegration := models.SourceIntegrationMetadata{
+		AWSAccountID:            "111111111111",
+		IntegrationID:           "0aab70c6-da66-4bb9-a83c-bbe8f5717fde",
+		IntegrationType:         models.IntegrationTypeAWSScan,
+		OrganizationScanEnabled: aws.Bool(true),
+	}
+
+	scanned := make(map[string]map[string]bool)
+	apiTest.mockSqs.On("SendMessageBatch", mock.Anything).Return(&sqs.SendMessageBatchOutput{}, nil).
+		Run(func(args mock.Arguments) {
+			for _, entry := range args.Get(0).(*sqs.SendMessageBatchInput).Entries {
+				var msg pollermodels.ScanMsg
+				require.NoError(t, jsoniter.UnmarshalFromString(*entry.MessageBody, &msg))
+				integrationID := *msg.Entries[0].IntegrationID
+				if scanned[integrationID] == nil {
+					scanned[integrationID] = make(map[string]bool)
+				}
+				scanned[integrationID][*msg.Entries[0].ResourceType] = true
+			}
+		})
+
+	require.NoError(t, apiTest.FullScan(&models.FullScanInput{
+		Integrations: []*models.SourceIntegrationMetadata{&testIntegration, &organizationIntegration},
+	}))
+	apiTest.AssertExpectations(t)
+
+	assert.True(t, scanned[testIntegrationID]["AWS.IAM.Role"])
+	assert.False(t, scanned[testIntegrationID]["AWS.Organizations.Account"])
+	assert.True(t, scanned[organizationIntegration.IntegrationID]["AWS.IAM.Role"])
+	assert.True(t, scanned[organizationIntegration.IntegrationID]["AWS.Organizations.Account"])
+}
+
+// Scans of integrations with their own audit role carry its configuration to the poller
+func TestFullScanAuditRole(t *testing.T) {
+	t.Parallel()
+	apiTest := NewAPITest()
+	apiTest.Config.SnapshotPollersQueueURL = "test-url"
+	auditRole := &models.AuditRoleConfig{
+		RoleARN:     "arn:aws:iam::" + testAccountID + ":role/BusinessUnitAudit",
+		ExternalID:  "bu-external-id",
+		RoleChain:   []models.ChainedRole{{RoleARN: "arn:aws:iam::111111111111:role/SecurityHub"}},
+		SessionTags: map[string]string{"team": "security"},
+	}
+	testIntegration := models.SourceIntegrationMetadata{
+		AWSAccountID:    testAccountID,
+		IntegrationID:   testIntegrationID,
+		IntegrationType: models.IntegrationTypeAWSScan,
+		AuditRole:       auditRole,
+	}
+
+	var sent []*sqs.SendMessageBatchRequestEntry
+	apiTest.mockSqs.On("SendMessageBatch", mock.Anything).Return(&sqs.SendMessageBatchOutput{}, nil).
+		Run(func(args mock.Arguments) {
+			sent = append(sent, args.Get(0).(*sqs.SendMessageBatchInput).Entries...)
+		})
+
+	require.NoError(t, apiTest.FullScan(&models.FullScanInput{
+		Integrations: []*models.SourceIntegrationMetadata{&testIntegration},
+	}))
+	apiTest.AssertExpectations(t)
+
+	require.NotEmpty(t, sent)
+	for _, entry := range sent {
+		var msg pollermodels.ScanMsg
+		require.NoError(t, jsoniter.UnmarshalFromString(*entry.MessageBody, &msg))
+		assert.Equal(t, auditRole, msg.Entries[0].AuditRole)
+	}
+}
+
 func TestPutCloudSecIntegration(t *testing.T) {
 	t.Parallel()
 	apiTest := NewAPITest()
@@ -391,3 +516,226 @@ func TestPutSqsIntegration(t *testing.T) {
 	assert.JSONEq(t, expectedSqsQueuePolicy, *createQueueRequest.Attributes["Policy"])
 	apiTest.AssertExpectations(t)
 }
+
+func TestFullScanScanTypes(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		integration    models.SourceIntegrationMetadata
+		pollers        int
+		isResourceType func(string) bool
+		// Checks the scope of each scan entry
+		checkEntry func(t *testing.T, entry *pollermodels.ScanEntry)
+	}{
+		{
+			integration: models.SourceIntegrationMetadata{
+				IntegrationID:   testIntegrationID,
+				IntegrationType: models.IntegrationTypeGCPScan,
+				GCPProjectIDs:   []string{"example-project", "other-project"},
+			},
+			pollers:        len(gcppoller.ServicePollers),
+			isResourceType: gcppoller.IsResourceType,
+			checkEntry: func(t *testing.T, entry *pollermodels.ScanEntry) {
+				assert.Nil(t, entry.GCPProjectID)
+				assert.Equal(t, []string{"example-project", "other-project"}, entry.GCPProjectIDs)
+			},
+		},
+		{
+			integration: models.SourceIntegrationMetadata{
+				IntegrationID:   testIntegrationID,
+				IntegrationType: models.IntegrationTypeK8sScan,
+				K8sAPIServerURL: "https://10.0.0.1:6443",
+			},
+			pollers:        len(k8spoller.ServicePollers),
+			isResourceType: k8spoller.IsResourceType,
+			checkEntry: func(t *testing.T, entry *pollermodels.ScanEntry) {
+				assert.Equal(t, testIntegrationID, *entry.IntegrationID)
+				assert.Nil(t, entry.Region)
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.integration.IntegrationType, func(t *testing.T) {
+			t.Parallel()
+			apiTest := NewAPITest()
+			apiTest.Config.SnapshotPollersQueueURL = "test-url"
+
+			var entries []*pollermodels.ScanEntry
+			apiTest.mockSqs.On("SendMessageBatch", mock.Anything).Return(&sqs.SendMessageBatchOutput{}, nil).
+				Run(func(args mock.Arguments) {
+					for _, entry := range args.Get(0).(*sqs.SendMessageBatchInput).Entries {
+						var msg pollermodels.ScanMsg
+						require.NoError(t, jsoniter.UnmarshalFromString(*entry.MessageBody, &msg))
+						entries = append(entries, msg.Entries...)
+					}
+				})
+
+			require.NoError(t, apiTest.FullScan(&models.FullScanInput{
+				Integrations: []*models.SourceIntegrationMetadata{&tc.integration},
+			}))
+			apiTest.AssertExpectations(t)
+
+			require.Len(t, entries, tc.pollers)
+			for _, entry := range entries {
+				assert.True(t, tc.isResourceType(*entry.ResourceType))
+				assert.Nil(t, entry.AWSAccountID)
+				tc.checkEntry(t, entry)
+			}
+		})
+	}
+}
+
+func TestPutScanIntegrationScanTypes(t *testing.T) {
+	t.Parallel()
+	gcpServer := gcptest.NewServer()
+	defer gcpServer.Close()
+	key := string(gcpServer.ServiceAccountKey())
+	k8sServer := k8stest.NewServer()
+	defer k8sServer.Close()
+	kubeconfig := k8sServer.Kubeconfig()
+
+	for _, tc := range []struct {
+		settings     models.PutIntegrationSettings
+		secretPrefix string
+		// Checks the input of the health check, the integration and the stored secret
+		check func(t *testing.T, checked *models.CheckIntegrationInput, out *models.SourceIntegration, secret string)
+	}{
+		{
+			settings: models.PutIntegrationSettings{
+				IntegrationType:      models.IntegrationTypeGCPScan,
+				GCPServiceAccountKey: key,
+				GCPProjectIDs:        []string{gcptest.ExampleProjectID},
+			},
+			secretPrefix: "panther-source-gcp-",
+			check: func(t *testing.T, checked *models.CheckIntegrationInput, out *models.SourceIntegration, secret string) {
+				assert.Equal(t, key, checked.GCPServiceAccountKey)
+				assert.Equal(t, []string{gcptest.ExampleProjectID}, checked.GCPProjectIDs)
+				assert.Equal(t, []string{gcptest.ExampleProjectID}, out.GCPProjectIDs)
+				assert.Equal(t, gcptest.ExampleServiceAccount, out.GCPServiceAccountEmail)
+				// The key is only stored in the secret
+				assert.Equal(t, key, secret)
+			},
+		},
+		{
+			settings: models.PutIntegrationSettings{
+				IntegrationType: models.IntegrationTypeK8sScan,
+				K8sKubeconfig:   kubeconfig,
+			},
+			secretPrefix: "panther-source-k8s-",
+			check: func(t *testing.T, checked *models.CheckIntegrationInput, out *models.SourceIntegration, secret string) {
+				assert.Equal(t, kubeconfig, checked.K8sKubeconfig)
+				// The API server is read from the kubeconfig
+				assert.Equal(t, k8sServer.URL, out.K8sAPIServerURL)
+				// The credentials are only stored in the secret
+				cluster, err := k8spoller.ParseCredentials([]byte(secret))
+				require.NoError(t, err)
+				assert.Equal(t, k8stest.ExampleToken, cluster.Token)
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.settings.IntegrationType, func(t *testing.T) {
+			apiTest := NewAPITest()
+			apiTest.DdbClient = &ddb.DDB{Client: &modelstest.MockDDBClient{TestErr: false}, TableName: "test"}
+			apiTest.Config.InputDataBucketName = "input-data"
+			apiTest.Config.InputDataRoleArn = "role-arn"
+
+			var checked *models.CheckIntegrationInput
+			apiTest.EvaluateIntegrationFunc = func(input *models.CheckIntegrationInput) (string, bool, error) {
+				checked = input
+				return "", true, nil
+			}
+
+			// Message sent to create Cloud Security tables
+			apiTest.mockSqs.On("SendMessageWithContext", mock.Anything, mock.Anything).Return(&sqs.SendMessageOutput{}, nil)
+			// This message is to start full scan
+			apiTest.mockSqs.On("SendMessageBatch", mock.Anything).Return(&sqs.SendMessageBatchOutput{}, nil)
+			apiTest.mockSecrets.On("CreateSecret", mock.Anything).Return(&secretsmanager.CreateSecretOutput{}, nil)
+
+			settings := tc.settings
+			settings.IntegrationLabel = testIntegrationLabel
+			settings.ScanIntervalMins = 60
+			settings.UserID = testUserID
+			out, err := apiTest.PutIntegration(&models.PutIntegrationInput{PutIntegrationSettings: settings})
+			require.NoError(t, err)
+			require.NotEmpty(t, out)
+			apiTest.AssertExpectations(t)
+
+			assert.Empty(t, out.AWSAccountID)
+			bucket, prefixes := out.S3Info()
+			assert.Equal(t, "input-data", bucket)
+			assert.Equal(t, []string{"cloudsecurity"}, prefixes)
+			assert.Equal(t, "role-arn", out.RequiredLogProcessingRole())
+
+			createSecretRequest := apiTest.mockSecrets.Calls[0].Arguments.Get(0).(*secretsmanager.CreateSecretInput)
+			assert.Equal(t, tc.secretPrefix+out.IntegrationID, *createSecretRequest.Name)
+			tc.check(t, checked, out, *createSecretRequest.SecretString)
+		})
+	}
+}
+
+func TestPutK8sScanIntegrationClusterExists(t *testing.T) {
+	t.Parallel()
+	apiTest := NewAPITest()
+	apiTest.EvaluateIntegrationFunc = func(_ *models.CheckIntegrationInput) (string, bool, error) { return "", true, nil }
+
+	apiTest.DdbClient = &ddb.DDB{
+		Client: &modelstest.MockDDBClient{
+			MockScanAttributes: []map[string]*dynamodb.AttributeValue{
+				{
+					"integrationType": {S: aws.String(models.IntegrationTypeK8sScan)},
+					"k8sApiServerUrl": {S: aws.String("https://10.0.0.1:6443")},
+				},
+			},
+			TestErr: false,
+		},
+		TableName: "test",
+	}
+
+	out, err := apiTest.PutIntegration(&models.PutIntegrationInput{
+		PutIntegrationSettings: models.PutIntegrationSettings{
+			IntegrationLabel:       testIntegrationLabel,
+			IntegrationType:        models.IntegrationTypeK8sScan,
+			ScanIntervalMins:       60,
+			UserID:                 testUserID,
+			K8sAPIServerURL:        "https://10.0.0.1:6443/",
+			K8sServiceAccountToken: "token",
+		},
+	})
+	require.Error(t, err)
+	require.Empty(t, out)
+	assert.Equal(t, "Source cluster https://10.0.0.1:6443 already onboarded", err.Error())
+	apiTest.AssertExpectations(t)
+}
+
+func TestPutGCPScanIntegrationProjectExists(t *testing.T) {
+	t.Parallel()
+	apiTest := NewAPITest()
+	apiTest.EvaluateIntegrationFunc = func(_ *models.CheckIntegrationInput) (string, bool, error) { return "", true, nil }
+
+	apiTest.DdbClient = &ddb.DDB{
+		Client: &modelstest.MockDDBClient{
+			MockScanAttributes: []map[string]*dynamodb.AttributeValue{
+				{
+					"integrationType": {S: aws.String(models.IntegrationTypeGCPScan)},
+					"gcpProjectIds":   {L: []*dynamodb.AttributeValue{{S: aws.String("example-project")}}},
+				},
+			},
+			TestErr: false,
+		},
+		TableName: "test",
+	}
+
+	out, err := apiTest.PutIntegration(&models.PutIntegrationInput{
+		PutIntegrationSettings: models.PutIntegrationSettings{
+			IntegrationLabel: testIntegrationLabel,
+			IntegrationType:  models.IntegrationTypeGCPScan,
+			ScanIntervalMins: 60,
+			UserID:           testUserID,
+			GCPProjectIDs:    []string{"other-project", "example-project"},
+		},
+	})
+	require.Error(t, err)
+	require.Empty(t, out)
+	assert.Equal(t, "Source project example-project already onboarded", err.Error())
+	apiTest.AssertExpectations(t)
+}
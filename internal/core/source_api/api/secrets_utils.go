@@ -0,0 +1,97 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/pkg/awsutils"
+)
+
+// credentialsSecret returns the name and description of the secret holding the credentials of a
+// gcp-scan or k8s-scan integration, which the snapshot pollers read.
+func credentialsSecret(integrationType, integrationID string) (name, description string) {
+	if integrationType == models.IntegrationTypeK8sScan {
+		return models.K8sCredentialsSecretName(integrationID), "Kubernetes cluster credentials of Panther source " + integrationID
+	}
+	return models.GCPServiceAccountKeySecretName(integrationID), "GCP service account key of Panther source " + integrationID
+}
+
+// CreateCredentialsSecret stores the credentials of a gcp-scan or k8s-scan integration, where the
+// snapshot pollers read them from.
+func (api *API) CreateCredentialsSecret(integrationType, integrationID, credentials string) error {
+	name, description := credentialsSecret(integrationType, integrationID)
+	_, err := api.SecretsClient.CreateSecret(&secretsmanager.CreateSecretInput{
+		Name:         aws.String(name),
+		Description:  aws.String(description),
+		SecretString: aws.String(credentials),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to create credentials secret")
+	}
+	return nil
+}
+
+// GetCredentialsSecret reads the stored credentials of a gcp-scan or k8s-scan integration.
+func (api *API) GetCredentialsSecret(integrationType, integrationID string) (string, error) {
+	name, _ := credentialsSecret(integrationType, integrationID)
+	output, err := api.SecretsClient.GetSecretValue(&secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(name),
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read credentials secret")
+	}
+	return aws.StringValue(output.SecretString), nil
+}
+
+// UpdateCredentialsSecret replaces the stored credentials of a gcp-scan or k8s-scan integration.
+func (api *API) UpdateCredentialsSecret(integrationType, integrationID, credentials string) error {
+	name, _ := credentialsSecret(integrationType, integrationID)
+	_, err := api.SecretsClient.PutSecretValue(&secretsmanager.PutSecretValueInput{
+		SecretId:     aws.String(name),
+		SecretString: aws.String(credentials),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to update credentials secret")
+	}
+	return nil
+}
+
+// DeleteCredentialsSecret removes the credentials of a deleted gcp-scan or k8s-scan integration.
+func (api *API) DeleteCredentialsSecret(integrationType, integrationID string) error {
+	name, _ := credentialsSecret(integrationType, integrationID)
+	_, err := api.SecretsClient.DeleteSecret(&secretsmanager.DeleteSecretInput{
+		SecretId: aws.String(name),
+		// The credentials are revoked in GCP or the cluster by the user, there is nothing to recover
+		ForceDeleteWithoutRecovery: aws.Bool(true),
+	})
+	if awsutils.IsAnyError(err, secretsmanager.ErrCodeResourceNotFoundException) {
+		zap.L().Debug("the credentials secret of the integration doesn't exist",
+			zap.String("integrationId", integrationID))
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to delete credentials secret")
+	}
+	return nil
+}
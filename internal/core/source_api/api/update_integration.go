@@ -23,12 +23,17 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	jsoniter "github.com/json-iterator/go"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
 	"github.com/panther-labs/panther/api/lambda/source/models"
+	gcppoller "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/gcp"
+	k8spoller "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/k8s"
 	"github.com/panther-labs/panther/internal/core/source_api/ddb"
 	"github.com/panther-labs/panther/internal/log_analysis/datacatalog_updater/datacatalog"
+	"github.com/panther-labs/panther/pkg/awsutils"
 	"github.com/panther-labs/panther/pkg/genericapi"
 	"github.com/panther-labs/panther/pkg/stringset"
 )
@@ -51,6 +56,34 @@ func (api *API) UpdateIntegrationSettings(input *models.UpdateIntegrationSetting
 		return nil, err
 	}
 
+	// Credentials are not returned to the UI, so updates only include them when they're replaced
+	serviceAccountKey := input.GCPServiceAccountKey
+	newClusterCredentials := k8sCredentials(
+		input.K8sKubeconfig, input.K8sAPIServerURL, input.K8sServiceAccountToken, input.K8sCertificateAuthority)
+	clusterCredentials := &k8spoller.Credentials{}
+	switch existingItem.IntegrationType {
+	case models.IntegrationTypeGCPScan:
+		if serviceAccountKey == "" {
+			if serviceAccountKey, err = api.GetCredentialsSecret(existingItem.IntegrationType, existingItem.IntegrationID); err != nil {
+				zap.L().Error("failed to get service account key", zap.Error(err))
+				return nil, updateIntegrationInternalError
+			}
+		}
+	case models.IntegrationTypeK8sScan:
+		if newClusterCredentials != nil {
+			clusterCredentials = newClusterCredentials
+			break
+		}
+		stored, err := api.GetCredentialsSecret(existingItem.IntegrationType, existingItem.IntegrationID)
+		if err == nil {
+			err = jsoniter.UnmarshalFromString(stored, clusterCredentials)
+		}
+		if err != nil {
+			zap.L().Error("failed to get cluster credentials", zap.Error(err))
+			return nil, updateIntegrationInternalError
+		}
+	}
+
 	// Validate the updates
 	reason, passing, err := api.EvaluateIntegrationFunc(&models.CheckIntegrationInput{
 		// Same as the existing integration item
@@ -58,13 +91,22 @@ func (api *API) UpdateIntegrationSettings(input *models.UpdateIntegrationSetting
 		IntegrationType: existingItem.IntegrationType,
 
 		// From update existingItem request
-		IntegrationLabel:  input.IntegrationLabel,
-		EnableCWESetup:    input.CWEEnabled,
-		EnableRemediation: input.RemediationEnabled,
-		S3Bucket:          input.S3Bucket,
-		S3PrefixLogTypes:  input.S3PrefixLogTypes,
-		KmsKey:            input.KmsKey,
-		SqsConfig:         input.SqsConfig,
+		IntegrationLabel:     input.IntegrationLabel,
+		EnableCWESetup:       input.CWEEnabled,
+		EnableRemediation:    input.RemediationEnabled,
+		EnableOrganization:   input.OrganizationScanEnabled,
+		AuditRole:            input.AuditRole,
+		S3Bucket:             input.S3Bucket,
+		S3PrefixLogTypes:     input.S3PrefixLogTypes,
+		KmsKey:               input.KmsKey,
+		SqsConfig:            input.SqsConfig,
+		GCPServiceAccountKey: serviceAccountKey,
+		GCPProjectIDs:        input.GCPProjectIDs,
+
+		K8sKubeconfig:           clusterCredentials.Kubeconfig,
+		K8sAPIServerURL:         clusterCredentials.Server,
+		K8sServiceAccountToken:  clusterCredentials.Token,
+		K8sCertificateAuthority: clusterCredentials.CertificateAuthority,
 	})
 	if err != nil {
 		return nil, err
@@ -87,6 +129,24 @@ func (api *API) UpdateIntegrationSettings(input *models.UpdateIntegrationSetting
 
 	updateIntegrationDBItem(existingItem, input)
 
+	if existingItem.IntegrationType == models.IntegrationTypeGCPScan && input.GCPServiceAccountKey != "" {
+		if err := api.UpdateCredentialsSecret(existingItem.IntegrationType, existingItem.IntegrationID, input.GCPServiceAccountKey); err != nil {
+			zap.L().Error("failed to update service account key", zap.Error(err))
+			return nil, updateIntegrationInternalError
+		}
+	}
+
+	if existingItem.IntegrationType == models.IntegrationTypeK8sScan && newClusterCredentials != nil {
+		credentials, err := jsoniter.MarshalToString(newClusterCredentials)
+		if err == nil {
+			err = api.UpdateCredentialsSecret(existingItem.IntegrationType, existingItem.IntegrationID, credentials)
+		}
+		if err != nil {
+			zap.L().Error("failed to update cluster credentials", zap.Error(err))
+			return nil, updateIntegrationInternalError
+		}
+	}
+
 	if existingItem.IntegrationType == models.IntegrationTypeSqs {
 		err := api.UpdateSourceSqsQueue(
 			existingItem.IntegrationID, existingItem.SqsConfig.AllowedPrincipalArns, existingItem.SqsConfig.AllowedSourceArns)
@@ -155,6 +215,20 @@ func (api *API) validateUniqueConstraints(existingIntegrationItem *ddb.Integrati
 						Message: fmt.Sprintf("Integration with label %s already exists", input.IntegrationLabel),
 					}
 				}
+			case models.IntegrationTypeGCPScan:
+				if projectID := overlappingProject(existingIntegration.GCPProjectIDs, input.GCPProjectIDs); projectID != "" {
+					return &genericapi.InvalidInputError{
+						Message: fmt.Sprintf("Source project %s already onboarded", projectID),
+					}
+				}
+			case models.IntegrationTypeK8sScan:
+				server := k8sAPIServer(k8sCredentials(
+					input.K8sKubeconfig, input.K8sAPIServerURL, input.K8sServiceAccountToken, input.K8sCertificateAuthority))
+				if server != "" && existingIntegration.K8sAPIServerURL == server {
+					return &genericapi.InvalidInputError{
+						Message: fmt.Sprintf("Source cluster %s already onboarded", server),
+					}
+				}
 			}
 		}
 	}
@@ -172,6 +246,37 @@ func updateIntegrationDBItem(item *ddb.Integration, input *models.UpdateIntegrat
 		item.RegionIgnoreList = input.RegionIgnoreList
 		item.ResourceTypeIgnoreList = input.ResourceTypeIgnoreList
 		item.ResourceRegexIgnoreList = input.ResourceRegexIgnoreList
+		item.ResourceTypeScanConfigs = input.ResourceTypeScanConfigs
+		item.OrganizationScanEnabled = input.OrganizationScanEnabled
+		item.OrganizationAutoOnboard = input.OrganizationAutoOnboard
+		item.AuditRole = input.AuditRole
+	case models.IntegrationTypeGCPScan:
+		item.IntegrationLabel = input.IntegrationLabel
+		item.ScanIntervalMins = input.ScanIntervalMins
+		item.GCPProjectIDs = input.GCPProjectIDs
+		item.Enabled = input.Enabled
+		item.RegionIgnoreList = input.RegionIgnoreList
+		item.ResourceTypeIgnoreList = input.ResourceTypeIgnoreList
+		item.ResourceRegexIgnoreList = input.ResourceRegexIgnoreList
+		item.ResourceTypeScanConfigs = input.ResourceTypeScanConfigs
+		// The key was already validated by the configuration check
+		if key, err := gcppoller.ParseServiceAccountKey([]byte(input.GCPServiceAccountKey)); err == nil {
+			item.GCPServiceAccountEmail = key.ClientEmail
+		}
+	case models.IntegrationTypeK8sScan:
+		item.IntegrationLabel = input.IntegrationLabel
+		item.ScanIntervalMins = input.ScanIntervalMins
+		item.Enabled = input.Enabled
+		item.RegionIgnoreList = input.RegionIgnoreList
+		item.ResourceTypeIgnoreList = input.ResourceTypeIgnoreList
+		item.ResourceRegexIgnoreList = input.ResourceRegexIgnoreList
+		item.ResourceTypeScanConfigs = input.ResourceTypeScanConfigs
+		// New credentials were already validated by the configuration check
+		if server := k8sAPIServer(k8sCredentials(input.K8sKubeconfig, input.K8sAPIServerURL,
+			input.K8sServiceAccountToken, input.K8sCertificateAuthority)); server != "" {
+
+			item.K8sAPIServerURL = server
+		}
 	case models.IntegrationTypeAWS3:
 		if input.IntegrationLabel != "" {
 			item.IntegrationLabel = input.IntegrationLabel
@@ -225,6 +330,21 @@ func (api *API) UpdateIntegrationLastScanEnd(input *models.UpdateIntegrationLast
 	return nil
 }
 
+// UpdateIntegrationResourceScan records that a resource type of an integration was fully scanned in a region.
+func (api *API) UpdateIntegrationResourceScan(input *models.UpdateIntegrationResourceScanInput) error {
+	key := models.ResourceScanKey(input.ResourceType, input.Region)
+	err := api.DdbClient.UpdateResourceScan(input.IntegrationID, key, input.ScanTime)
+	if awsutils.IsAnyError(err, dynamodb.ErrCodeConditionalCheckFailedException) {
+		return &genericapi.DoesNotExistError{Message: "Integration does not exist"}
+	}
+	if err != nil {
+		zap.L().Error("failed to update integration resource scan", zap.Error(err),
+			zap.String("integrationId", input.IntegrationID), zap.String("resourceScan", key))
+		return &genericapi.InternalError{Message: "Failed updating the integration resource scan"}
+	}
+	return nil
+}
+
 func (api *API) getItem(integrationID string) (*ddb.Integration, error) {
 	item, err := api.DdbClient.GetItem(integrationID)
 	if err != nil {
@@ -24,7 +24,9 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -32,7 +34,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/gcp/gcptest"
 	"github.com/panther-labs/panther/internal/core/source_api/ddb"
+	"github.com/panther-labs/panther/pkg/genericapi"
 )
 
 func TestUpdateIntegrationSettingsAwsScanType(t *testing.T) {
@@ -79,6 +83,212 @@ func TestUpdateIntegrationSettingsAwsScanType(t *testing.T) {
 	apiTest.AssertExpectations(t)
 }
 
+func TestUpdateIntegrationSettingsAwsScanAuditRole(t *testing.T) {
+	t.Parallel()
+	apiTest := NewAPITest()
+	auditRole := &models.AuditRoleConfig{
+		RoleARN:     "arn:aws:iam::" + testAccountID + ":role/BusinessUnitAudit",
+		RoleChain:   []models.ChainedRole{{RoleARN: "arn:aws:iam::111111111111:role/SecurityHub", ExternalID: "hub-id"}},
+		SessionTags: map[string]string{"team": "security"},
+	}
+
+	// The new role is validated before it is saved
+	var checked *models.AuditRoleConfig
+	apiTest.EvaluateIntegrationFunc = func(input *models.CheckIntegrationInput) (string, bool, error) {
+		checked = input.AuditRole
+		return "", true, nil
+	}
+
+	getResponse := &dynamodb.GetItemOutput{Item: map[string]*dynamodb.AttributeValue{
+		"integrationId":   {S: aws.String(testIntegrationID)},
+		"integrationType": {S: aws.String(models.IntegrationTypeAWSScan)},
+		"awsAccountId":    {S: aws.String(testAccountID)},
+	}}
+	apiTest.mockDdb.On("GetItem", mock.Anything).Return(getResponse, nil).Once()
+	apiTest.mockDdb.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil).Once()
+	apiTest.mockDdb.On("Scan", mock.Anything).Return(&dynamodb.ScanOutput{}, nil).Once()
+
+	result, err := apiTest.UpdateIntegrationSettings(&models.UpdateIntegrationSettingsInput{
+		IntegrationID:    testIntegrationID,
+		IntegrationLabel: "new-label",
+		AuditRole:        auditRole,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, auditRole, checked)
+	assert.Equal(t, auditRole, result.AuditRole)
+	apiTest.AssertExpectations(t)
+}
+
+func TestUpdateIntegrationSettingsScanTypes(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		// The stored integration
+		item         map[string]*dynamodb.AttributeValue
+		secretID     string
+		storedSecret string
+		// The scope of the integration, which is updated
+		update   func(input *models.UpdateIntegrationSettingsInput)
+		expected models.SourceIntegrationMetadata
+		// The stored credentials are checked again
+		checkStored func(t *testing.T, checked *models.CheckIntegrationInput)
+	}{
+		{
+			item: map[string]*dynamodb.AttributeValue{
+				"integrationType":        {S: aws.String(models.IntegrationTypeGCPScan)},
+				"gcpServiceAccountEmail": {S: aws.String(gcptest.ExampleServiceAccount)},
+			},
+			secretID:     "panther-source-gcp-" + testIntegrationID,
+			storedSecret: "stored-key",
+			update: func(input *models.UpdateIntegrationSettingsInput) {
+				input.GCPProjectIDs = []string{"example-project", "other-project"}
+			},
+			expected: models.SourceIntegrationMetadata{
+				IntegrationType:        models.IntegrationTypeGCPScan,
+				GCPProjectIDs:          []string{"example-project", "other-project"},
+				GCPServiceAccountEmail: gcptest.ExampleServiceAccount,
+			},
+			checkStored: func(t *testing.T, checked *models.CheckIntegrationInput) {
+				// The stored key is checked against the new projects
+				assert.Equal(t, "stored-key", checked.GCPServiceAccountKey)
+				assert.Equal(t, []string{"example-project", "other-project"}, checked.GCPProjectIDs)
+			},
+		},
+		{
+			item: map[string]*dynamodb.AttributeValue{
+				"integrationType": {S: aws.String(models.IntegrationTypeK8sScan)},
+				"k8sApiServerUrl": {S: aws.String("https://10.0.0.1:6443")},
+			},
+			secretID:     "panther-source-k8s-" + testIntegrationID,
+			storedSecret: `{"server": "https://10.0.0.1:6443", "token": "stored-token"}`,
+			update: func(input *models.UpdateIntegrationSettingsInput) {
+				input.RegionIgnoreList = []string{"kube-system"}
+			},
+			expected: models.SourceIntegrationMetadata{
+				IntegrationType:  models.IntegrationTypeK8sScan,
+				RegionIgnoreList: []string{"kube-system"},
+				K8sAPIServerURL:  "https://10.0.0.1:6443",
+			},
+			checkStored: func(t *testing.T, checked *models.CheckIntegrationInput) {
+				assert.Equal(t, "stored-token", checked.K8sServiceAccountToken)
+				assert.Equal(t, "https://10.0.0.1:6443", checked.K8sAPIServerURL)
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.expected.IntegrationType, func(t *testing.T) {
+			t.Parallel()
+			apiTest := NewAPITest()
+
+			var checked *models.CheckIntegrationInput
+			apiTest.EvaluateIntegrationFunc = func(input *models.CheckIntegrationInput) (string, bool, error) {
+				checked = input
+				return "", true, nil
+			}
+
+			tc.item["integrationId"] = &dynamodb.AttributeValue{S: aws.String(testIntegrationID)}
+			apiTest.mockDdb.On("GetItem", mock.Anything).Return(&dynamodb.GetItemOutput{Item: tc.item}, nil).Once()
+			apiTest.mockDdb.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil).Once()
+			apiTest.mockDdb.On("Scan", mock.Anything).Return(&dynamodb.ScanOutput{}, nil).Once()
+			apiTest.mockSecrets.On("GetSecretValue", &secretsmanager.GetSecretValueInput{
+				SecretId: aws.String(tc.secretID),
+			}).Return(&secretsmanager.GetSecretValueOutput{SecretString: aws.String(tc.storedSecret)}, nil).Once()
+
+			input := &models.UpdateIntegrationSettingsInput{
+				IntegrationID:    testIntegrationID,
+				IntegrationLabel: "new-label",
+				ScanIntervalMins: 1440,
+				Enabled:          aws.Bool(true),
+			}
+			tc.update(input)
+			result, err := apiTest.UpdateIntegrationSettings(input)
+
+			expected := tc.expected
+			expected.IntegrationID = testIntegrationID
+			expected.IntegrationLabel = "new-label"
+			expected.ScanIntervalMins = 1440
+			expected.Enabled = aws.Bool(true)
+			assert.NoError(t, err)
+			assert.Equal(t, &models.SourceIntegration{SourceIntegrationMetadata: expected}, result)
+			tc.checkStored(t, checked)
+			apiTest.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateIntegrationSettingsScanTypesNewCredentials(t *testing.T) {
+	t.Parallel()
+	server := gcptest.NewServer()
+	defer server.Close()
+	key := string(server.ServiceAccountKey())
+
+	for _, tc := range []struct {
+		item map[string]*dynamodb.AttributeValue
+		// Sets the new credentials
+		update func(input *models.UpdateIntegrationSettingsInput)
+		secret *secretsmanager.PutSecretValueInput
+		check  func(t *testing.T, result *models.SourceIntegration)
+	}{
+		{
+			item: map[string]*dynamodb.AttributeValue{
+				"integrationType":        {S: aws.String(models.IntegrationTypeGCPScan)},
+				"gcpServiceAccountEmail": {S: aws.String("old@example-project.iam.gserviceaccount.com")},
+			},
+			update: func(input *models.UpdateIntegrationSettingsInput) {
+				input.GCPProjectIDs = []string{"example-project"}
+				input.GCPServiceAccountKey = key
+			},
+			secret: &secretsmanager.PutSecretValueInput{
+				SecretId:     aws.String("panther-source-gcp-" + testIntegrationID),
+				SecretString: aws.String(key),
+			},
+			check: func(t *testing.T, result *models.SourceIntegration) {
+				assert.Equal(t, gcptest.ExampleServiceAccount, result.GCPServiceAccountEmail)
+			},
+		},
+		{
+			item: map[string]*dynamodb.AttributeValue{
+				"integrationType": {S: aws.String(models.IntegrationTypeK8sScan)},
+				"k8sApiServerUrl": {S: aws.String("https://10.0.0.1:6443")},
+			},
+			update: func(input *models.UpdateIntegrationSettingsInput) {
+				input.K8sAPIServerURL = "https://cluster.example.com"
+				input.K8sServiceAccountToken = "new-token"
+			},
+			secret: &secretsmanager.PutSecretValueInput{
+				SecretId:     aws.String("panther-source-k8s-" + testIntegrationID),
+				SecretString: aws.String(`{"server":"https://cluster.example.com","token":"new-token"}`),
+			},
+			check: func(t *testing.T, result *models.SourceIntegration) {
+				assert.Equal(t, "https://cluster.example.com", result.K8sAPIServerURL)
+			},
+		},
+	} {
+		tc := tc
+		t.Run(*tc.item["integrationType"].S, func(t *testing.T) {
+			apiTest := NewAPITest()
+			apiTest.EvaluateIntegrationFunc = func(_ *models.CheckIntegrationInput) (string, bool, error) {
+				return "", true, nil
+			}
+
+			tc.item["integrationId"] = &dynamodb.AttributeValue{S: aws.String(testIntegrationID)}
+			apiTest.mockDdb.On("GetItem", mock.Anything).Return(&dynamodb.GetItemOutput{Item: tc.item}, nil).Once()
+			apiTest.mockDdb.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil).Once()
+			apiTest.mockDdb.On("Scan", mock.Anything).Return(&dynamodb.ScanOutput{}, nil).Once()
+			apiTest.mockSecrets.On("PutSecretValue", tc.secret).Return(&secretsmanager.PutSecretValueOutput{}, nil).Once()
+
+			input := &models.UpdateIntegrationSettingsInput{
+				IntegrationID:    testIntegrationID,
+				IntegrationLabel: "new-label",
+			}
+			tc.update(input)
+			result, err := apiTest.UpdateIntegrationSettings(input)
+			require.NoError(t, err)
+			tc.check(t, result)
+			apiTest.AssertExpectations(t)
+		})
+	}
+}
+
 func TestUpdateIntegrationSettingsAwsS3Type(t *testing.T) {
 	t.Parallel()
 	apiTest := NewAPITest()
@@ -215,6 +425,65 @@ func TestUpdateIntegrationLastScanEnd(t *testing.T) {
 	apiTest.AssertExpectations(t)
 }
 
+func TestUpdateIntegrationResourceScan(t *testing.T) {
+	t.Parallel()
+	apiTest := NewAPITest()
+
+	scanTime := time.Now().UTC()
+	apiTest.mockDdb.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, nil).Once()
+
+	err := apiTest.UpdateIntegrationResourceScan(&models.UpdateIntegrationResourceScanInput{
+		IntegrationID: testIntegrationID,
+		ResourceType:  "AWS.S3.Bucket",
+		Region:        "us-west-2",
+		ScanTime:      scanTime,
+	})
+
+	assert.NoError(t, err)
+	apiTest.AssertExpectations(t)
+	request := apiTest.mockDdb.Calls[0].Arguments.Get(0).(*dynamodb.UpdateItemInput)
+	assert.Equal(t, "SET #scans.#key = :time", *request.UpdateExpression)
+	assert.Equal(t, "AWS.S3.Bucket/us-west-2", *request.ExpressionAttributeNames["#key"])
+}
+
+func TestUpdateIntegrationResourceScanCreatesMap(t *testing.T) {
+	t.Parallel()
+	apiTest := NewAPITest()
+
+	conditionErr := awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "condition failed", nil)
+	apiTest.mockDdb.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, conditionErr).Once()
+	apiTest.mockDdb.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, nil).Once()
+
+	err := apiTest.UpdateIntegrationResourceScan(&models.UpdateIntegrationResourceScanInput{
+		IntegrationID: testIntegrationID,
+		ResourceType:  "AWS.IAM.Role",
+		ScanTime:      time.Now(),
+	})
+
+	assert.NoError(t, err)
+	apiTest.AssertExpectations(t)
+	request := apiTest.mockDdb.Calls[1].Arguments.Get(0).(*dynamodb.UpdateItemInput)
+	assert.Equal(t, "SET #scans = :scans", *request.UpdateExpression)
+	assert.Contains(t, request.ExpressionAttributeValues[":scans"].M, "AWS.IAM.Role/")
+}
+
+func TestUpdateIntegrationResourceScanDoesNotExist(t *testing.T) {
+	t.Parallel()
+	apiTest := NewAPITest()
+
+	conditionErr := awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "condition failed", nil)
+	apiTest.mockDdb.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, conditionErr).Times(4)
+
+	err := apiTest.UpdateIntegrationResourceScan(&models.UpdateIntegrationResourceScanInput{
+		IntegrationID: testIntegrationID,
+		ResourceType:  "AWS.IAM.Role",
+		ScanTime:      time.Now(),
+	})
+
+	assert.IsType(t, &genericapi.DoesNotExistError{}, err)
+	apiTest.AssertExpectations(t)
+}
+
 func TestSlicesContainSameElements(t *testing.T) {
 	t.Parallel()
 	type testCase struct {
@@ -55,6 +55,29 @@ func integrationToItem(input *models.SourceIntegration) *ddb.Integration {
 		item.ScanIntervalMins = input.ScanIntervalMins
 		item.ScanStatus = input.ScanStatus
 		item.StackName = input.StackName
+		item.ResourceTypeScanConfigs = input.ResourceTypeScanConfigs
+		item.ResourceScans = input.ResourceScans
+		item.OrganizationScanEnabled = input.OrganizationScanEnabled
+		item.OrganizationAutoOnboard = input.OrganizationAutoOnboard
+		item.AuditRole = input.AuditRole
+	case models.IntegrationTypeGCPScan, models.IntegrationTypeK8sScan:
+		item.GCPProjectIDs = input.GCPProjectIDs
+		item.GCPServiceAccountEmail = input.GCPServiceAccountEmail
+		item.K8sAPIServerURL = input.K8sAPIServerURL
+		item.EventStatus = input.EventStatus
+		item.LastScanErrorMessage = input.LastScanErrorMessage
+		item.LastScanEndTime = input.LastScanEndTime
+		item.LastScanStartTime = input.LastScanStartTime
+		item.LogProcessingRole = input.LogProcessingRole
+		item.S3Bucket = input.S3Bucket
+		item.ScanIntervalMins = input.ScanIntervalMins
+		item.ScanStatus = input.ScanStatus
+		item.Enabled = input.Enabled
+		item.RegionIgnoreList = input.RegionIgnoreList
+		item.ResourceTypeIgnoreList = input.ResourceTypeIgnoreList
+		item.ResourceRegexIgnoreList = input.ResourceRegexIgnoreList
+		item.ResourceTypeScanConfigs = input.ResourceTypeScanConfigs
+		item.ResourceScans = input.ResourceScans
 	case models.IntegrationTypeSqs:
 		item.SqsConfig = &ddb.SqsConfig{
 			QueueURL:             input.SqsConfig.QueueURL,
@@ -107,6 +130,29 @@ func itemToIntegration(item *ddb.Integration) *models.SourceIntegration {
 		integration.RegionIgnoreList = item.RegionIgnoreList
 		integration.ResourceTypeIgnoreList = item.ResourceTypeIgnoreList
 		integration.ResourceRegexIgnoreList = item.ResourceRegexIgnoreList
+		integration.ResourceTypeScanConfigs = item.ResourceTypeScanConfigs
+		integration.ResourceScans = item.ResourceScans
+		integration.OrganizationScanEnabled = item.OrganizationScanEnabled
+		integration.OrganizationAutoOnboard = item.OrganizationAutoOnboard
+		integration.AuditRole = item.AuditRole
+	case models.IntegrationTypeGCPScan, models.IntegrationTypeK8sScan:
+		integration.GCPProjectIDs = item.GCPProjectIDs
+		integration.GCPServiceAccountEmail = item.GCPServiceAccountEmail
+		integration.K8sAPIServerURL = item.K8sAPIServerURL
+		integration.ScanIntervalMins = item.ScanIntervalMins
+		integration.ScanStatus = item.ScanStatus
+		integration.S3Bucket = item.S3Bucket
+		integration.LogProcessingRole = item.LogProcessingRole
+		integration.EventStatus = item.EventStatus
+		integration.LastScanStartTime = item.LastScanStartTime
+		integration.LastScanEndTime = item.LastScanEndTime
+		integration.LastScanErrorMessage = item.LastScanErrorMessage
+		integration.Enabled = item.Enabled
+		integration.RegionIgnoreList = item.RegionIgnoreList
+		integration.ResourceTypeIgnoreList = item.ResourceTypeIgnoreList
+		integration.ResourceRegexIgnoreList = item.ResourceRegexIgnoreList
+		integration.ResourceTypeScanConfigs = item.ResourceTypeScanConfigs
+		integration.ResourceScans = item.ResourceScans
 	case models.IntegrationTypeSqs:
 		integration.SqsConfig = &models.SqsConfig{
 			S3Bucket:             item.SqsConfig.S3Bucket,
@@ -34,10 +34,11 @@ const (
 
 type APITest struct {
 	API
-	mockDdb    *testutils.DynamoDBMock
-	mockSqs    *testutils.SqsMock
-	mockS3     *testutils.S3Mock
-	mockLambda *testutils.LambdaMock
+	mockDdb     *testutils.DynamoDBMock
+	mockSqs     *testutils.SqsMock
+	mockS3      *testutils.S3Mock
+	mockLambda  *testutils.LambdaMock
+	mockSecrets *testutils.SecretsManagerMock
 }
 
 func NewAPITest() *APITest {
@@ -45,14 +46,17 @@ func NewAPITest() *APITest {
 	mockSqs := &testutils.SqsMock{}
 	mockS3 := &testutils.S3Mock{}
 	mockLambda := &testutils.LambdaMock{}
+	mockSecrets := &testutils.SecretsManagerMock{}
 	return &APITest{
-		mockDdb:    mockDdb,
-		mockSqs:    mockSqs,
-		mockS3:     mockS3,
-		mockLambda: mockLambda,
+		mockDdb:     mockDdb,
+		mockSqs:     mockSqs,
+		mockS3:      mockS3,
+		mockLambda:  mockLambda,
+		mockSecrets: mockSecrets,
 		API: API{
 			SqsClient:        mockSqs,
 			LambdaClient:     mockLambda,
+			SecretsClient:    mockSecrets,
 			TemplateS3Client: mockS3,
 			DdbClient:        &ddb.DDB{TableName: "test", Client: mockDdb},
 		},
@@ -64,4 +68,5 @@ func (a *APITest) AssertExpectations(t *testing.T) {
 	a.mockS3.AssertExpectations(t)
 	a.mockSqs.AssertExpectations(t)
 	a.mockLambda.AssertExpectations(t)
+	a.mockSecrets.AssertExpectations(t)
 }
@@ -40,6 +40,8 @@ type Integration struct {
 	LastScanEndTime      *time.Time `json:"lastScanEndTime,omitempty"`
 	LastScanErrorMessage string     `json:"lastScanErrorMessage,omitempty"`
 	ScanIntervalMins     int        `json:"scanIntervalMins,omitempty"`
+	// Keyed by models.ResourceScanKey, updated in place by UpdateResourceScan
+	ResourceScans map[string]time.Time `json:"resourceScans,omitempty"`
 	IntegrationStatus
 
 	// fields for configurable cloud security sources
@@ -48,6 +50,22 @@ type Integration struct {
 	ResourceTypeIgnoreList  []string `json:"resourceTypeIgnoreList"`
 	ResourceRegexIgnoreList []string `json:"resourceRegexIgnoreList"`
 
+	ResourceTypeScanConfigs []models.ResourceTypeScanConfig `json:"resourceTypeScanConfigs,omitempty"`
+
+	// fields for the management account of an AWS Organization
+	OrganizationScanEnabled *bool `json:"organizationScanEnabled,omitempty"`
+	OrganizationAutoOnboard *bool `json:"organizationAutoOnboard,omitempty"`
+
+	// role an aws-scan integration is scanned with instead of the default audit role
+	AuditRole *models.AuditRoleConfig `json:"auditRole,omitempty"`
+
+	// fields specific for a gcp-scan integration
+	GCPProjectIDs          []string `json:"gcpProjectIds,omitempty"`
+	GCPServiceAccountEmail string   `json:"gcpServiceAccountEmail,omitempty"`
+
+	// fields specific for a k8s-scan integration
+	K8sAPIServerURL string `json:"k8sApiServerUrl,omitempty"`
+
 	// fields specific for an s3 integration (plus AWSAccountID, StackName)
 	S3Bucket         string                  `json:"s3Bucket,omitempty"`
 	S3PrefixLogTypes models.S3PrefixLogtypes `json:"s3PrefixLogTypes,omitempty"`
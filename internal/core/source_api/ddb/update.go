@@ -19,9 +19,15 @@ package ddb
  */
 
 import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
 	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
 	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/pkg/awsutils"
 )
 
 func (ddb *DDB) UpdateStatus(integrationID string, status IntegrationStatus) error {
@@ -51,3 +57,58 @@ func (ddb *DDB) UpdateStatus(integrationID string, status IntegrationStatus) err
 	}
 	return nil
 }
+
+// UpdateResourceScan records the time a resource scan finished without rewriting the rest of the item,
+// since the pollers report many slices of the same integration concurrently.
+func (ddb *DDB) UpdateResourceScan(integrationID, key string, scanTime time.Time) error {
+	scanTimeValue, err := dynamodbattribute.Marshal(scanTime)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal scan time")
+	}
+	scansValue, err := dynamodbattribute.Marshal(map[string]time.Time{key: scanTime})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal resource scans")
+	}
+
+	// The expression builder splits names on dots, which resource types are full of, so the
+	// expressions are written out by hand.
+	updateRequest := &dynamodb.UpdateItemInput{
+		TableName: &ddb.TableName,
+		Key: map[string]*dynamodb.AttributeValue{
+			hashKey: {S: &integrationID},
+		},
+		UpdateExpression:    aws.String("SET #scans.#key = :time"),
+		ConditionExpression: aws.String("attribute_exists(#id) AND attribute_exists(#scans)"),
+		ExpressionAttributeNames: map[string]*string{
+			"#id":    aws.String(hashKey),
+			"#scans": aws.String("resourceScans"),
+			"#key":   &key,
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{":time": scanTimeValue},
+	}
+	// Items written before the first scan finished don't have the map yet, and DynamoDB can't set a
+	// nested attribute of a missing map.
+	createRequest := &dynamodb.UpdateItemInput{
+		TableName:           &ddb.TableName,
+		Key:                 updateRequest.Key,
+		UpdateExpression:    aws.String("SET #scans = :scans"),
+		ConditionExpression: aws.String("attribute_exists(#id) AND attribute_not_exists(#scans)"),
+		ExpressionAttributeNames: map[string]*string{
+			"#id":    aws.String(hashKey),
+			"#scans": aws.String("resourceScans"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{":scans": scansValue},
+	}
+
+	// Retry the nested update once in case another poller created the map first
+	for attempt := 0; attempt < 2; attempt++ {
+		for _, request := range []*dynamodb.UpdateItemInput{updateRequest, createRequest} {
+			_, err = ddb.Client.UpdateItem(request)
+			if !awsutils.IsAnyError(err, dynamodb.ErrCodeConditionalCheckFailedException) {
+				return errors.Wrap(err, "failed to update item")
+			}
+		}
+	}
+	// Both conditions kept failing, so the integration itself doesn't exist
+	return errors.Wrap(err, "failed to update item")
+}
@@ -62,6 +62,10 @@ type EnvConfig struct {
 	SqsQueueURL                 string `required:"true" split_words:"true"`
 	SqsBatchSize                int64  `required:"true" split_words:"true"`
 	SnsTopicARN                 string `required:"true" split_words:"true"`
+	// Comma separated list of threat intel lists used to enrich events (see threatintel.ParseSources)
+	ThreatIntelLists string `split_words:"true"`
+	// Comma separated list of MaxMind DB files used to add geolocation to IP addresses (see geoip.ParseURLs)
+	GeoIPDatabases string `envconfig:"GEOIP_DATABASES"`
 }
 
 func Setup() {
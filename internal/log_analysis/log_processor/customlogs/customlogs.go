@@ -21,7 +21,9 @@ package customlogs
 
 import (
 	"reflect"
+	"regexp"
 	"strings"
+	"sync"
 
 	"github.com/pkg/errors"
 
@@ -52,10 +54,17 @@ func Build(desc logtypes.Desc, schema *logschema.Schema) (logtypes.Entry, error)
 	if err := logschema.ValidateSchema(schema); err != nil {
 		return nil, err
 	}
+	logType := LogType(desc.Name)
+	if err := defineIndicators(logType, schema.Indicators...); err != nil {
+		return nil, err
+	}
 	valueSchema, err := logschema.Resolve(schema)
 	if err != nil {
 		return nil, err
 	}
+	if err := checkIndicators(valueSchema); err != nil {
+		return nil, err
+	}
 
 	typ, err := valueSchema.GoType()
 	if err != nil {
@@ -67,7 +76,6 @@ func Build(desc logtypes.Desc, schema *logschema.Schema) (logtypes.Entry, error)
 		return nil, err
 	}
 
-	logType := LogType(desc.Name)
 	preProcessor, err := buildPreprocessor(schema.Parser)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to build preprocessor")
@@ -92,6 +100,182 @@ func Build(desc logtypes.Desc, schema *logschema.Schema) (logtypes.Entry, error)
 	return entry, nil
 }
 
+// Builtin value extractors for custom indicators
+var indicatorBuiltins = map[string]pantherlog.ValueExtractor{
+	"value":       pantherlog.ExtractValue,
+	"lowercase":   pantherlog.ExtractLowercase,
+	"uppercase":   pantherlog.ExtractUppercase,
+	"mac_address": pantherlog.ExtractMACAddress,
+	"uuid":        pantherlog.ExtractUUID,
+}
+
+// indicatorDefinition tracks who defined a registered custom indicator.
+// Indicators defined with the log types API have no owner, schema indicators are owned by their log type.
+type indicatorDefinition struct {
+	Owner     string
+	Indicator logschema.IndicatorSchema
+}
+
+var (
+	indicatorsLock       sync.Mutex
+	indicatorDefinitions = map[string]*indicatorDefinition{}
+)
+
+// ValidateIndicators checks that custom indicator kinds can be registered without modifying any registered indicators.
+func ValidateIndicators(indicators ...logschema.IndicatorSchema) error {
+	_, err := compileIndicators(indicators)
+	return err
+}
+
+// RegisterIndicators registers custom indicator kinds so that schema fields can use them in `indicators`.
+// It should only be used for committed indicator definitions.
+// Registering an indicator that is already registered updates the way its values are extracted.
+// It fails if an indicator is already defined differently by a log schema.
+func RegisterIndicators(indicators ...logschema.IndicatorSchema) error {
+	compiled, err := compileIndicators(indicators)
+	if err != nil {
+		return err
+	}
+	indicatorsLock.Lock()
+	defer indicatorsLock.Unlock()
+	for _, c := range compiled {
+		if def, ok := indicatorDefinitions[c.Name]; ok && def.Owner != "" && !sameExtraction(&def.Indicator, &c.IndicatorSchema) {
+			return errors.Errorf("indicator %q is already defined by %s", c.Name, def.Owner)
+		}
+	}
+	for _, c := range compiled {
+		if err := c.register(""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// defineIndicators registers the indicators defined by a log schema.
+// Existing indicators are only updated if they were defined by the same log type.
+func defineIndicators(owner string, indicators ...logschema.IndicatorSchema) error {
+	compiled, err := compileIndicators(indicators)
+	if err != nil {
+		return err
+	}
+	indicatorsLock.Lock()
+	defer indicatorsLock.Unlock()
+	for _, c := range compiled {
+		if def, ok := indicatorDefinitions[c.Name]; ok && def.Owner != owner && !sameExtraction(&def.Indicator, &c.IndicatorSchema) {
+			if def.Owner == "" {
+				return errors.Errorf("indicator %q is already defined", c.Name)
+			}
+			return errors.Errorf("indicator %q is already defined by %s", c.Name, def.Owner)
+		}
+	}
+	for _, c := range compiled {
+		if def, ok := indicatorDefinitions[c.Name]; ok && def.Owner != owner {
+			continue
+		}
+		if err := c.register(owner); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type compiledIndicator struct {
+	logschema.IndicatorSchema
+	Field   pantherlog.FieldMeta
+	Extract pantherlog.ValueExtractor
+}
+
+func compileIndicators(indicators []logschema.IndicatorSchema) ([]*compiledIndicator, error) {
+	compiled := make([]*compiledIndicator, 0, len(indicators))
+	names := map[string]bool{}
+	for i := range indicators {
+		indicator := &indicators[i]
+		if names[indicator.Name] {
+			return nil, errors.Errorf("duplicate indicator %q", indicator.Name)
+		}
+		names[indicator.Name] = true
+		extract, err := buildValueExtractor(indicator)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid indicator %q", indicator.Name)
+		}
+		field, err := pantherlog.CustomIndicatorFieldMeta(indicator.FieldNameJSON(), indicator.Description)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid indicator %q", indicator.Name)
+		}
+		if err := pantherlog.CheckCustomIndicator(indicator.Name, field); err != nil {
+			return nil, errors.Wrapf(err, "invalid indicator %q", indicator.Name)
+		}
+		compiled = append(compiled, &compiledIndicator{
+			IndicatorSchema: *indicator,
+			Field:           field,
+			Extract:         extract,
+		})
+	}
+	return compiled, nil
+}
+
+// register registers or updates the indicator, indicatorsLock must be held
+func (c *compiledIndicator) register(owner string) (err error) {
+	if _, ok := indicatorDefinitions[c.Name]; ok {
+		_, err = pantherlog.UpdateCustomIndicator(c.Name, c.Field, c.Extract)
+	} else {
+		_, err = pantherlog.RegisterCustomIndicator(c.Name, c.Field, c.Extract)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to register indicator %q", c.Name)
+	}
+	indicatorDefinitions[c.Name] = &indicatorDefinition{
+		Owner:     owner,
+		Indicator: c.IndicatorSchema,
+	}
+	return nil
+}
+
+func sameExtraction(a, b *logschema.IndicatorSchema) bool {
+	return a.Regex == b.Regex && a.Builtin == b.Builtin && a.FieldNameJSON() == b.FieldNameJSON()
+}
+
+func buildValueExtractor(indicator *logschema.IndicatorSchema) (pantherlog.ValueExtractor, error) {
+	switch {
+	case indicator.Regex != "":
+		re, err := regexp.Compile(indicator.Regex)
+		if err != nil {
+			return nil, err
+		}
+		return pantherlog.RegexpExtractor(re), nil
+	case indicator.Builtin != "":
+		if extract, ok := indicatorBuiltins[indicator.Builtin]; ok {
+			return extract, nil
+		}
+		return nil, errors.Errorf("unknown builtin %q", indicator.Builtin)
+	default:
+		return nil, errors.New("no regex or builtin defined")
+	}
+}
+
+// checkIndicators ensures that all indicators used by string values have a registered scanner
+func checkIndicators(v *logschema.ValueSchema) error {
+	switch v.Type {
+	case logschema.TypeObject:
+		for i := range v.Fields {
+			if err := checkIndicators(&v.Fields[i].ValueSchema); err != nil {
+				return errors.WithMessagef(err, "field %q", v.Fields[i].Name)
+			}
+		}
+	case logschema.TypeArray:
+		if v.Element != nil {
+			return checkIndicators(v.Element)
+		}
+	case logschema.TypeString:
+		for _, name := range v.Indicators {
+			if scanner, _ := pantherlog.LookupScanner(name); scanner == nil {
+				return errors.Errorf("unknown indicator %q", name)
+			}
+		}
+	}
+	return nil
+}
+
 func buildPreprocessor(parser *logschema.Parser) (preprocessors.Interface, error) {
 	switch {
 	case parser == nil:
@@ -352,3 +352,80 @@ func TestNameCollisions(t *testing.T) {
 	assert.Error(err)
 	assert.Nil(entry)
 }
+
+func TestCustomIndicators(t *testing.T) {
+	assert := require.New(t)
+	logSchema := logschema.Schema{}
+	assert.NoError(yaml.Unmarshal([]byte(`
+version: 0
+indicators:
+- name: device_id
+  description: Device ids
+  regex: 'dev-[0-9]+'
+- name: mac_address
+  field: p_any_mac_addresses
+  builtin: mac_address
+fields:
+- name: device
+  type: string
+  indicators: [device_id]
+- name: mac
+  type: string
+  indicators: [mac_address]
+- name: ip
+  type: string
+  indicators: [ip]
+- name: time
+  type: timestamp
+  timeFormat: rfc3339
+  isEventTime: true
+`), &logSchema))
+	desc := logtypes.Desc{
+		Name:         "Devices",
+		Description:  "foo",
+		ReferenceURL: "-",
+	}
+	entry, err := customlogs.Build(desc, &logSchema)
+	assert.NoError(err)
+	expectJSON := `{
+  "device": "dev-42",
+  "mac": "00-1A-2B-3C-4D-5E",
+  "ip": "1.1.1.1",
+  "p_log_type": "Custom.Devices",
+  "p_any_ip_addresses": ["1.1.1.1"],
+  "p_any_device_ids": ["dev-42"],
+  "p_any_mac_addresses": ["00:1a:2b:3c:4d:5e"],
+  "time": "2020-01-01T00:00:00Z",
+  "p_event_time": "2020-01-01T00:00:00Z"
+}`
+	input := `{"device": "dev-42", "mac": "00-1A-2B-3C-4D-5E", "ip": "1.1.1.1", "time": "2020-01-01T00:00:00Z"}`
+	logtesting.TestRegisteredParser(t, entry, entry.String(), input, expectJSON)
+
+	logSchema.Fields[0].Indicators = []string{"unknown_id"}
+	_, err = customlogs.Build(desc, &logSchema)
+	assert.Error(err)
+
+	logSchema.Indicators = []logschema.IndicatorSchema{{Name: "ip", Builtin: "value"}}
+	_, err = customlogs.Build(desc, &logSchema)
+	assert.Error(err)
+
+	// Another log type cannot redefine the indicators of a schema
+	other := logtypes.Desc{
+		Name:         "OtherDevices",
+		Description:  "foo",
+		ReferenceURL: "-",
+	}
+	logSchema.Fields[0].Indicators = []string{"device_id"}
+	logSchema.Indicators = []logschema.IndicatorSchema{{Name: "device_id", Description: "Device ids", Builtin: "value"}}
+	_, err = customlogs.Build(other, &logSchema)
+	assert.Error(err)
+	assert.Error(customlogs.RegisterIndicators(logSchema.Indicators...))
+	// Validating does not modify the registered indicator
+	assert.NoError(customlogs.ValidateIndicators(logSchema.Indicators...))
+	logtesting.TestRegisteredParser(t, entry, entry.String(), input, expectJSON)
+	// Duplicate indicators are invalid
+	assert.Error(customlogs.ValidateIndicators(logSchema.Indicators[0], logSchema.Indicators[0]))
+	// The log type defining an indicator can update it
+	_, err = customlogs.Build(desc, &logSchema)
+	assert.NoError(err)
+}
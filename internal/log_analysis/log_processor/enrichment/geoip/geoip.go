@@ -0,0 +1,116 @@
+package geoip
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net"
+
+	"github.com/oschwald/maxminddb-golang"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+// DB looks up the geolocation and network owner of IP addresses in MaxMind DB (mmdb) databases.
+// Databases with different record types can be combined (ie `GeoLite2-City` and `GeoLite2-ASN`).
+// All methods of DB are safe to use from multiple goroutines.
+type DB struct {
+	readers []*maxminddb.Reader
+}
+
+var _ pantherlog.Enricher = (*DB)(nil)
+
+// record has all the fields of GeoIP2/GeoLite2 City, Country and ASN records used to enrich results
+type record struct {
+	Country struct {
+		ISOCode string            `maxminddb:"iso_code"`
+		Names   map[string]string `maxminddb:"names"`
+	} `maxminddb:"country"`
+	City struct {
+		Names map[string]string `maxminddb:"names"`
+	} `maxminddb:"city"`
+	ASN          uint32 `maxminddb:"autonomous_system_number"`
+	Organization string `maxminddb:"autonomous_system_organization"`
+}
+
+// language is the language used for country and city names
+const language = "en"
+
+// New creates a DB from mmdb database contents
+func New(databases ...[]byte) (*DB, error) {
+	db := &DB{}
+	for i, data := range databases {
+		r, err := maxminddb.FromBytes(data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid GeoIP database #%d", i)
+		}
+		db.readers = append(db.readers, r)
+	}
+	return db, nil
+}
+
+// Lookup finds the geolocation and network owner of an IP address.
+// It returns false if the IP address is not found in any database.
+func (db *DB) Lookup(addr string) (pantherlog.IPGeo, bool) {
+	geo := pantherlog.IPGeo{
+		IP: addr,
+	}
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return geo, false
+	}
+	found := false
+	for _, r := range db.readers {
+		var rec record
+		_, ok, err := r.LookupNetwork(ip, &rec)
+		if err != nil || !ok {
+			continue
+		}
+		found = true
+		if geo.CountryCode == "" {
+			geo.CountryCode = rec.Country.ISOCode
+		}
+		if geo.Country == "" {
+			geo.Country = rec.Country.Names[language]
+		}
+		if geo.City == "" {
+			geo.City = rec.City.Names[language]
+		}
+		if geo.ASN == 0 {
+			geo.ASN = rec.ASN
+		}
+		if geo.Organization == "" {
+			geo.Organization = rec.Organization
+		}
+	}
+	return geo, found
+}
+
+// EnrichResult implements pantherlog.Enricher interface.
+// It adds the geolocation of all IP address indicators of a result that are found in the databases.
+func (db *DB) EnrichResult(result *pantherlog.Result, values *pantherlog.ValueBuffer) {
+	if values == nil {
+		return
+	}
+	for _, addr := range values.Get(pantherlog.FieldIPAddress) {
+		if geo, ok := db.Lookup(addr); ok {
+			result.PantherIPGeo = append(result.PantherIPGeo, geo)
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package geoip_test
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/enrichment/geoip"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+func TestLookup(t *testing.T) {
+	assert := require.New(t)
+	db, err := geoip.New(testCityDB(t), testASNDB(t))
+	assert.NoError(err)
+
+	geo, ok := db.Lookup("81.2.69.160")
+	assert.True(ok)
+	assert.Equal(pantherlog.IPGeo{
+		IP:           "81.2.69.160",
+		CountryCode:  "GB",
+		Country:      "United Kingdom",
+		City:         "London",
+		ASN:          20712,
+		Organization: "Andrews & Arnold Ltd",
+	}, geo)
+
+	// Only in the ASN database
+	geo, ok = db.Lookup("81.2.1.1")
+	assert.True(ok)
+	assert.Equal(pantherlog.IPGeo{
+		IP:           "81.2.1.1",
+		ASN:          20712,
+		Organization: "Andrews & Arnold Ltd",
+	}, geo)
+
+	_, ok = db.Lookup("10.0.0.1")
+	assert.False(ok)
+	_, ok = db.Lookup("not an ip")
+	assert.False(ok)
+}
+
+func TestEnrichResult(t *testing.T) {
+	type event struct {
+		SourceIP pantherlog.String `json:"src" panther:"ip"`
+		DestIP   pantherlog.String `json:"dst" panther:"ip"`
+	}
+	db, err := geoip.New(testCityDB(t))
+	require.NoError(t, err)
+
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	builder := pantherlog.ResultBuilder{
+		NextRowID: pantherlog.StaticRowID("id"),
+		Now:       pantherlog.StaticNow(tm),
+	}
+	result, err := builder.BuildResult("Test", &event{
+		SourceIP: pantherlog.String{Value: "81.2.69.160", Exists: true},
+		DestIP:   pantherlog.String{Value: "10.0.0.1", Exists: true},
+	})
+	require.NoError(t, err)
+	result.Enricher = db
+	actual, err := pantherlog.ConfigJSON().Marshal(result)
+	require.NoError(t, err)
+	expect := `{
+		"src": "81.2.69.160",
+		"dst": "10.0.0.1",
+		"p_log_type": "Test",
+		"p_row_id": "id",
+		"p_event_time": "2020-01-01T00:00:00Z",
+		"p_parse_time": "2020-01-01T00:00:00Z",
+		"p_any_ip_addresses": ["10.0.0.1", "81.2.69.160"],
+		"p_ip_geo": [
+			{"ip": "81.2.69.160", "country_code": "GB", "country": "United Kingdom", "city": "London"}
+		]
+	}`
+	require.JSONEq(t, expect, string(actual))
+}
+
+func TestLoader(t *testing.T) {
+	assert := require.New(t)
+	dir, err := ioutil.TempDir("", "geoip")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "GeoLite2-ASN.mmdb")
+	assert.NoError(ioutil.WriteFile(path, testASNDB(t), 0600))
+
+	loader := geoip.Loader{
+		URLs: geoip.ParseURLs(path + ","),
+	}
+	db, err := loader.DB(context.Background())
+	assert.NoError(err)
+	_, ok := db.Lookup("81.2.69.160")
+	assert.True(ok)
+
+	// Unchanged files are not reloaded
+	same, err := loader.DB(context.Background())
+	assert.NoError(err)
+	assert.Same(db, same)
+
+	// Replace the database and make sure the new file is loaded
+	assert.NoError(ioutil.WriteFile(path, testCityDB(t), 0600))
+	future := time.Now().Add(time.Minute)
+	assert.NoError(os.Chtimes(path, future, future))
+	db, err = loader.DB(context.Background())
+	assert.NoError(err)
+	assert.NotSame(same, db)
+	geo, ok := db.Lookup("81.2.69.160")
+	assert.True(ok)
+	assert.Equal("London", geo.City)
+	assert.Zero(geo.ASN)
+
+	// A broken file keeps the previous database
+	assert.NoError(ioutil.WriteFile(path, []byte("foo"), 0600))
+	assert.NoError(os.Chtimes(path, future.Add(time.Minute), future.Add(time.Minute)))
+	prev, err := loader.DB(context.Background())
+	assert.Error(err)
+	assert.Same(db, prev)
+}
+
+func testCityDB(t *testing.T) []byte {
+	return buildTestDB(t, "GeoLite2-City", map[string]map[string]interface{}{
+		"81.2.69.0/24": {
+			"country": map[string]interface{}{
+				"iso_code": "GB",
+				"names":    map[string]interface{}{"en": "United Kingdom"},
+			},
+			"city": map[string]interface{}{
+				"names": map[string]interface{}{"en": "London"},
+			},
+		},
+	})
+}
+
+func testASNDB(t *testing.T) []byte {
+	return buildTestDB(t, "GeoLite2-ASN", map[string]map[string]interface{}{
+		"81.2.0.0/16": {
+			"autonomous_system_number":       uint32(20712),
+			"autonomous_system_organization": "Andrews & Arnold Ltd",
+		},
+	})
+}
+
+// buildTestDB writes a minimal IPv4 MaxMind DB with 24 bit records
+func buildTestDB(t *testing.T, dbType string, networks map[string]map[string]interface{}) []byte {
+	type node struct {
+		records [2]interface{}
+	}
+	type dataOffset int
+	root := &node{}
+	data := &bytes.Buffer{}
+	for cidr, rec := range networks {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		require.NoError(t, err)
+		ip := ipNet.IP.To4()
+		size, _ := ipNet.Mask.Size()
+		offset := dataOffset(data.Len())
+		encodeTestValue(t, data, rec)
+		n := root
+		for i := 0; i < size; i++ {
+			bit := (ip[i/8] >> (7 - uint(i%8))) & 1
+			if i == size-1 {
+				n.records[bit] = offset
+				break
+			}
+			child, ok := n.records[bit].(*node)
+			if !ok {
+				child = &node{}
+				n.records[bit] = child
+			}
+			n = child
+		}
+	}
+	// Number nodes breadth first
+	nodes := []*node{root}
+	ids := map[*node]int{root: 0}
+	for i := 0; i < len(nodes); i++ {
+		for _, rec := range nodes[i].records {
+			if child, ok := rec.(*node); ok {
+				ids[child] = len(nodes)
+				nodes = append(nodes, child)
+			}
+		}
+	}
+	out := &bytes.Buffer{}
+	nodeCount := len(nodes)
+	for _, n := range nodes {
+		for _, rec := range n.records {
+			value := nodeCount
+			switch rec := rec.(type) {
+			case *node:
+				value = ids[rec]
+			case dataOffset:
+				value = nodeCount + 16 + int(rec)
+			}
+			out.Write([]byte{byte(value >> 16), byte(value >> 8), byte(value)})
+		}
+	}
+	out.Write(make([]byte, 16))
+	out.Write(data.Bytes())
+	out.WriteString("\xAB\xCD\xEFMaxMind.com")
+	encodeTestValue(t, out, map[string]interface{}{
+		"binary_format_major_version": uint16(2),
+		"binary_format_minor_version": uint16(0),
+		"database_type":               dbType,
+		"ip_version":                  uint16(4),
+		"node_count":                  uint32(nodeCount),
+		"record_size":                 uint16(24),
+	})
+	return out.Bytes()
+}
+
+func encodeTestValue(t *testing.T, w *bytes.Buffer, value interface{}) {
+	const (
+		typeString = 2
+		typeUint16 = 5
+		typeUint32 = 6
+		typeMap    = 7
+	)
+	control := func(typ, size int) {
+		if size < 29 {
+			w.WriteByte(byte(typ<<5 | size))
+			return
+		}
+		require.Less(t, size, 29+256)
+		w.WriteByte(byte(typ<<5 | 29))
+		w.WriteByte(byte(size - 29))
+	}
+	switch v := value.(type) {
+	case string:
+		control(typeString, len(v))
+		w.WriteString(v)
+	case uint16:
+		control(typeUint16, 2)
+		_ = binary.Write(w, binary.BigEndian, v)
+	case uint32:
+		control(typeUint32, 4)
+		_ = binary.Write(w, binary.BigEndian, v)
+	case map[string]interface{}:
+		control(typeMap, len(v))
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			encodeTestValue(t, w, key)
+			encodeTestValue(t, w, v[key])
+		}
+	default:
+		t.Fatalf("unsupported value %v", value)
+	}
+}
@@ -0,0 +1,159 @@
+package geoip
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
+)
+
+// ParseURLs parses a comma separated list of database URLs.
+// Each URL is either an `s3://bucket/key` URL or a local file path.
+func ParseURLs(spec string) (urls []string) {
+	for _, u := range strings.Split(spec, ",") {
+		if u = strings.TrimSpace(u); u != "" {
+			urls = append(urls, u)
+		}
+	}
+	return urls
+}
+
+// Loader keeps a DB loaded from a set of database files and reloads it when any of the files changes.
+// This allows databases to be updated by uploading a new file to S3 without redeploying.
+type Loader struct {
+	S3API s3iface.S3API
+	// URLs are either `s3://bucket/key` URLs or local file paths
+	URLs []string
+	// CheckInterval is the minimum time between checks for changed files
+	CheckInterval time.Duration
+
+	mu        sync.Mutex
+	db        *DB
+	versions  []string
+	checkedAt time.Time
+}
+
+// DB returns the loaded database, reloading all files if any of them has changed.
+// If reloading fails the previously loaded database is returned along with the error.
+func (l *Loader) DB(ctx context.Context) (*DB, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.db != nil && time.Since(l.checkedAt) < l.CheckInterval {
+		return l.db, nil
+	}
+	versions := make([]string, len(l.URLs))
+	for i, u := range l.URLs {
+		v, err := l.version(ctx, u)
+		if err != nil {
+			return l.db, errors.Wrapf(err, "failed to check GeoIP database %q", u)
+		}
+		versions[i] = v
+	}
+	l.checkedAt = time.Now()
+	if l.db != nil && equalVersions(versions, l.versions) {
+		return l.db, nil
+	}
+	databases := make([][]byte, len(l.URLs))
+	for i, u := range l.URLs {
+		data, err := l.read(ctx, u)
+		if err != nil {
+			return l.db, errors.Wrapf(err, "failed to read GeoIP database %q", u)
+		}
+		databases[i] = data
+	}
+	db, err := New(databases...)
+	if err != nil {
+		return l.db, err
+	}
+	l.db, l.versions = db, versions
+	return db, nil
+}
+
+// version returns a string that changes whenever the file at `u` changes
+func (l *Loader) version(ctx context.Context, u string) (string, error) {
+	if !strings.HasPrefix(u, "s3://") {
+		info, err := os.Stat(u)
+		if err != nil {
+			return "", err
+		}
+		return info.ModTime().String() + "/" + strconv.FormatInt(info.Size(), 10), nil
+	}
+	bucket, key, err := l.s3Location(u)
+	if err != nil {
+		return "", err
+	}
+	reply, err := l.S3API.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return "", err
+	}
+	return aws.StringValue(reply.ETag), nil
+}
+
+func (l *Loader) read(ctx context.Context, u string) ([]byte, error) {
+	if !strings.HasPrefix(u, "s3://") {
+		return ioutil.ReadFile(u)
+	}
+	bucket, key, err := l.s3Location(u)
+	if err != nil {
+		return nil, err
+	}
+	reply, err := l.S3API.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer reply.Body.Close()
+	return ioutil.ReadAll(reply.Body)
+}
+
+func (l *Loader) s3Location(u string) (bucket, key string, err error) {
+	if l.S3API == nil {
+		return "", "", errors.New("no S3 client")
+	}
+	return awsglue.ParseS3URL(u)
+}
+
+func equalVersions(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,108 @@
+package threatintel
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+// defaultScanners are the pantherlog scanners used in order to detect the type of an indicator value
+// that has no explicit type.
+var defaultScanners = []string{"ip", "sha256", "sha1", "md5", "email", "domain"}
+
+// ReadCSV adds the indicators of a CSV list to the index.
+//
+// Each row has the indicator value in the first column and an optional indicator type in the second.
+// Indicator types are pantherlog scanner names (ie `ip`, `domain`, `md5`, `sha1`, `sha256`, `email`, `url`).
+// If the type is omitted it is detected from the value.
+// Lines starting with `#` are ignored and so is a header row with `indicator` or `value` in the first column.
+func (idx *Index) ReadCSV(list string, r io.Reader) error {
+	rd := csv.NewReader(r)
+	rd.Comment = '#'
+	rd.FieldsPerRecord = -1
+	rd.TrimLeadingSpace = true
+	rd.ReuseRecord = true
+	for numRows := 0; ; numRows++ {
+		row, err := rd.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to read CSV list %q", list)
+		}
+		value := strings.TrimSpace(row[0])
+		if numRows == 0 && isHeader(value) {
+			continue
+		}
+		var kind string
+		if len(row) > 1 {
+			kind = strings.ToLower(strings.TrimSpace(row[1]))
+		}
+		if err := idx.scan(list, value, kind); err != nil {
+			return errors.Wrapf(err, "invalid CSV list %q at row %d", list, numRows+1)
+		}
+	}
+}
+
+func isHeader(value string) bool {
+	switch strings.ToLower(value) {
+	case "indicator", "value":
+		return true
+	default:
+		return false
+	}
+}
+
+// scan adds the values produced by the pantherlog scanner named `kind` for an indicator.
+// If `kind` is empty the default scanners are tried in order until one of them produces values.
+func (idx *Index) scan(list, value, kind string) error {
+	if value == "" {
+		return nil
+	}
+	values := pantherlog.BlankValueBuffer()
+	defer values.Recycle()
+	if kind != "" {
+		scanner, _ := pantherlog.LookupScanner(kind)
+		if scanner == nil {
+			return errors.Errorf("unknown indicator type %q", kind)
+		}
+		scanner.ScanValues(values, value)
+	} else {
+		for _, name := range defaultScanners {
+			if scanner, _ := pantherlog.LookupScanner(name); scanner != nil {
+				scanner.ScanValues(values, value)
+			}
+			if !values.IsEmpty() {
+				break
+			}
+		}
+	}
+	for _, id := range values.Fields() {
+		for _, v := range values.Get(id) {
+			idx.Add(list, id, v)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package threatintel
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
+)
+
+// Source is the location of a threat intel list
+type Source struct {
+	// Name is the name of the list used in `p_enrichment` matches
+	Name string
+	// URL is either an `s3://bucket/key` URL or a local file path.
+	// Files ending in `.json` are read as STIX bundles, all other files are read as CSV.
+	// Files ending in `.gz` are decompressed.
+	URL string
+}
+
+// ParseSources parses a comma separated list of sources.
+// Each source is either a URL or a `name=URL` pair.
+// If no name is specified the base name of the URL without extensions is used.
+func ParseSources(spec string) (sources []Source, err error) {
+	for _, s := range strings.Split(spec, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		var src Source
+		if pos := strings.IndexByte(s, '='); pos != -1 {
+			src.Name, src.URL = strings.TrimSpace(s[:pos]), strings.TrimSpace(s[pos+1:])
+		} else {
+			src.URL = s
+			src.Name = baseName(s)
+		}
+		if src.Name == "" || src.URL == "" {
+			return nil, errors.Errorf("invalid threat intel source %q", s)
+		}
+		sources = append(sources, src)
+	}
+	return sources, nil
+}
+
+func baseName(u string) string {
+	name := path.Base(u)
+	if pos := strings.IndexByte(name, '.'); pos != -1 {
+		name = name[:pos]
+	}
+	return name
+}
+
+// Load reads all sources into a new index
+func Load(ctx context.Context, s3API s3iface.S3API, sources ...Source) (*Index, error) {
+	idx := New()
+	for _, src := range sources {
+		if err := idx.ReadSource(ctx, s3API, src); err != nil {
+			return nil, err
+		}
+	}
+	return idx, nil
+}
+
+// ReadSource adds the indicators of a source to the index.
+func (idx *Index) ReadSource(ctx context.Context, s3API s3iface.S3API, src Source) error {
+	r, err := openSource(ctx, s3API, src.URL)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open threat intel list %q", src.Name)
+	}
+	defer r.Close()
+
+	name := src.URL
+	var input io.Reader = r
+	if strings.HasSuffix(name, ".gz") {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return errors.Wrapf(err, "failed to decompress threat intel list %q", src.Name)
+		}
+		defer gz.Close()
+		input = gz
+		name = strings.TrimSuffix(name, ".gz")
+	}
+	if strings.HasSuffix(name, ".json") {
+		return idx.ReadSTIX(src.Name, input)
+	}
+	return idx.ReadCSV(src.Name, input)
+}
+
+func openSource(ctx context.Context, s3API s3iface.S3API, u string) (io.ReadCloser, error) {
+	if !strings.HasPrefix(u, "s3://") {
+		return os.Open(u)
+	}
+	if s3API == nil {
+		return nil, errors.New("no S3 client")
+	}
+	bucket, key, err := awsglue.ParseS3URL(u)
+	if err != nil {
+		return nil, err
+	}
+	reply, err := s3API.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reply.Body, nil
+}
+
+// Loader keeps an index loaded from a set of sources and reloads it when it becomes older than MaxAge.
+type Loader struct {
+	S3API   s3iface.S3API
+	Sources []Source
+	MaxAge  time.Duration
+
+	mu       sync.Mutex
+	index    *Index
+	loadedAt time.Time
+}
+
+// Index returns the loaded index, reloading all sources if it is stale.
+// If reloading fails the previously loaded index is returned along with the error.
+func (l *Loader) Index(ctx context.Context) (*Index, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.index != nil && time.Since(l.loadedAt) < l.MaxAge {
+		return l.index, nil
+	}
+	index, err := Load(ctx, l.S3API, l.Sources...)
+	if err != nil {
+		return l.index, err
+	}
+	l.index, l.loadedAt = index, time.Now()
+	return index, nil
+}
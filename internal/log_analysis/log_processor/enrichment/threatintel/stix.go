@@ -0,0 +1,149 @@
+package threatintel
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+)
+
+// stixObject has the fields of STIX 2.x objects needed to extract indicator values.
+// Both `indicator` objects with STIX patterns and cyber observable objects (ie `ipv4-addr`) are supported.
+type stixObject struct {
+	Type        string            `json:"type"`
+	Pattern     string            `json:"pattern"`
+	PatternType string            `json:"pattern_type"`
+	Revoked     bool              `json:"revoked"`
+	ValidUntil  string            `json:"valid_until"`
+	Value       string            `json:"value"`
+	Hashes      map[string]string `json:"hashes"`
+}
+
+// stixObjectScanners maps STIX cyber observable types to pantherlog scanners
+var stixObjectScanners = map[string]string{
+	"ipv4-addr":   "ip",
+	"ipv6-addr":   "ip",
+	"domain-name": "domain",
+	"email-addr":  "email",
+}
+
+// stixHashScanners maps STIX hash algorithm names to pantherlog scanners
+var stixHashScanners = map[string]string{
+	"MD5":     "md5",
+	"SHA-1":   "sha1",
+	"SHA1":    "sha1",
+	"SHA-256": "sha256",
+	"SHA256":  "sha256",
+}
+
+// Matches equality comparisons in STIX patterns (ie `[ipv4-addr:value = '198.51.100.1']`)
+var stixComparison = regexp.MustCompile(`([a-z0-9-]+):(value|hashes\.(?:'[^']+'|[A-Za-z0-9-]+))\s*=\s*'((?:[^'\\]|\\.)*)'`)
+
+// ReadSTIX adds the indicators of a STIX 2.x bundle or TAXII 2.x envelope to the index.
+// Only equality comparisons in STIX patterns are used. Revoked and expired indicators are skipped.
+func (idx *Index) ReadSTIX(list string, r io.Reader) error {
+	var bundle struct {
+		Objects []stixObject `json:"objects"`
+	}
+	if err := jsoniter.NewDecoder(r).Decode(&bundle); err != nil {
+		return errors.Wrapf(err, "failed to read STIX list %q", list)
+	}
+	now := time.Now()
+	for i := range bundle.Objects {
+		obj := &bundle.Objects[i]
+		if obj.Type == "indicator" {
+			if obj.Revoked || isExpired(obj.ValidUntil, now) {
+				continue
+			}
+			if obj.PatternType != "" && obj.PatternType != "stix" {
+				continue
+			}
+			if err := idx.scanPattern(list, obj.Pattern); err != nil {
+				return errors.Wrapf(err, "invalid STIX list %q", list)
+			}
+			continue
+		}
+		if err := idx.scanObject(list, obj.Type, obj.Value, obj.Hashes); err != nil {
+			return errors.Wrapf(err, "invalid STIX list %q", list)
+		}
+	}
+	return nil
+}
+
+func (idx *Index) scanPattern(list, pattern string) error {
+	for _, match := range stixComparison.FindAllStringSubmatch(pattern, -1) {
+		objectType, path, value := match[1], match[2], unescapeSTIX(match[3])
+		if path == "value" {
+			if err := idx.scanObject(list, objectType, value, nil); err != nil {
+				return err
+			}
+			continue
+		}
+		if objectType != "file" {
+			continue
+		}
+		algorithm := strings.Trim(strings.TrimPrefix(path, "hashes."), "'")
+		if err := idx.scanObject(list, objectType, "", map[string]string{algorithm: value}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (idx *Index) scanObject(list, objectType, value string, hashes map[string]string) error {
+	if kind, ok := stixObjectScanners[objectType]; ok {
+		return idx.scan(list, value, kind)
+	}
+	if objectType != "file" {
+		return nil
+	}
+	for algorithm, hash := range hashes {
+		if kind, ok := stixHashScanners[strings.ToUpper(algorithm)]; ok {
+			if err := idx.scan(list, hash, kind); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func isExpired(validUntil string, now time.Time) bool {
+	if validUntil == "" {
+		return false
+	}
+	tm, err := time.Parse(time.RFC3339Nano, validUntil)
+	if err != nil {
+		return false
+	}
+	return tm.Before(now)
+}
+
+var stixUnescape = strings.NewReplacer(`\'`, `'`, `\\`, `\`)
+
+func unescapeSTIX(s string) string {
+	if strings.IndexByte(s, '\\') == -1 {
+		return s
+	}
+	return stixUnescape.Replace(s)
+}
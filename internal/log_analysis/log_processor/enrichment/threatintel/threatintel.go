@@ -0,0 +1,130 @@
+package threatintel
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+// Index is an in-memory set of threat intel indicators.
+// It maps indicator values to the names of the lists that contain them so that each indicator value of an event
+// is checked against all lists with a single map lookup.
+// An Index is not safe for concurrent writes. Once loaded it can be used by multiple goroutines to enrich results.
+type Index struct {
+	lists  []string
+	values map[pantherlog.FieldID]map[string][]string
+}
+
+var _ pantherlog.Enricher = (*Index)(nil)
+
+// New creates an empty index
+func New() *Index {
+	return &Index{
+		values: make(map[pantherlog.FieldID]map[string][]string),
+	}
+}
+
+// Lists returns the names of the lists loaded in the index
+func (idx *Index) Lists() []string {
+	return append([]string(nil), idx.lists...)
+}
+
+// Len returns the number of distinct indicator values in the index
+func (idx *Index) Len() (n int) {
+	for _, values := range idx.values {
+		n += len(values)
+	}
+	return n
+}
+
+// Add adds an indicator value of a list to the index.
+// The value is normalized the same way as the values collected by pantherlog scanners.
+func (idx *Index) Add(list string, id pantherlog.FieldID, value string) {
+	value = normalizeValue(id, value)
+	if value == "" || list == "" {
+		return
+	}
+	idx.lists = insertSorted(idx.lists, list)
+	values, ok := idx.values[id]
+	if !ok {
+		values = make(map[string][]string)
+		idx.values[id] = values
+	}
+	values[value] = insertSorted(values[value], list)
+}
+
+// insertSorted inserts a name to a sorted slice of distinct names
+func insertSorted(names []string, name string) []string {
+	pos := sort.SearchStrings(names, name)
+	if pos < len(names) && names[pos] == name {
+		return names
+	}
+	names = append(names, "")
+	copy(names[pos+1:], names[pos:])
+	names[pos] = name
+	return names
+}
+
+// Lookup returns the names of the lists that contain an indicator value
+func (idx *Index) Lookup(id pantherlog.FieldID, value string) []string {
+	if values, ok := idx.values[id]; ok {
+		return values[normalizeValue(id, value)]
+	}
+	return nil
+}
+
+// EnrichResult implements pantherlog.Enricher interface.
+// It appends a match to the result for every list that contains one of the collected indicator values.
+func (idx *Index) EnrichResult(result *pantherlog.Result, values *pantherlog.ValueBuffer) {
+	if values == nil {
+		return
+	}
+	// Fields() and Get() are sorted so matches have a stable order
+	for _, id := range values.Fields() {
+		index, ok := idx.values[id]
+		if !ok {
+			continue
+		}
+		for _, value := range values.Get(id) {
+			for _, list := range index[normalizeValue(id, value)] {
+				result.PantherEnrichment = append(result.PantherEnrichment, pantherlog.EnrichmentMatch{
+					List:      list,
+					Field:     pantherlog.FieldNameJSON(id),
+					Indicator: value,
+				})
+			}
+		}
+	}
+}
+
+func normalizeValue(id pantherlog.FieldID, value string) string {
+	value = strings.TrimSpace(value)
+	switch id {
+	case pantherlog.FieldDomainName:
+		// Fully qualified domain names can end with a dot
+		return strings.ToLower(strings.TrimSuffix(value, "."))
+	case pantherlog.FieldEmail, pantherlog.FieldMD5Hash, pantherlog.FieldSHA1Hash, pantherlog.FieldSHA256Hash:
+		return strings.ToLower(value)
+	default:
+		return value
+	}
+}
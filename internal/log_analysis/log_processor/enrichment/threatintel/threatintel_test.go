@@ -0,0 +1,194 @@
+package threatintel_test
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/enrichment/threatintel"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+func TestReadCSV(t *testing.T) {
+	const input = `indicator,type
+# Comments are ignored
+198.51.100.1
+Evil.Example.COM
+D41D8CD98F00B204E9800998ECF8427E
+bad@example.com
+https://phish.example.org/login,url
+`
+	assert := require.New(t)
+	idx := threatintel.New()
+	assert.NoError(idx.ReadCSV("intel", strings.NewReader(input)))
+	assert.Equal([]string{"intel"}, idx.Lists())
+	assert.Equal(5, idx.Len())
+	assert.Equal([]string{"intel"}, idx.Lookup(pantherlog.FieldIPAddress, "198.51.100.1"))
+	assert.Equal([]string{"intel"}, idx.Lookup(pantherlog.FieldDomainName, "evil.example.com."))
+	assert.Equal([]string{"intel"}, idx.Lookup(pantherlog.FieldMD5Hash, "d41d8cd98f00b204e9800998ecf8427e"))
+	assert.Equal([]string{"intel"}, idx.Lookup(pantherlog.FieldEmail, "bad@example.com"))
+	assert.Equal([]string{"intel"}, idx.Lookup(pantherlog.FieldDomainName, "phish.example.org"))
+	assert.Nil(idx.Lookup(pantherlog.FieldIPAddress, "198.51.100.2"))
+
+	err := idx.ReadCSV("intel", strings.NewReader("198.51.100.1,foo\n"))
+	assert.Error(err)
+	assert.Contains(err.Error(), `unknown indicator type "foo"`)
+}
+
+func TestReadSTIX(t *testing.T) {
+	const input = `{
+	"type": "bundle",
+	"id": "bundle--5d0092c5-5f74-4287-9642-33f4c354e56d",
+	"objects": [
+		{
+			"type": "indicator",
+			"pattern_type": "stix",
+			"pattern": "[ipv4-addr:value = '198.51.100.1'] OR [domain-name:value = 'evil.example.com']"
+		},
+		{
+			"type": "indicator",
+			"pattern": "[file:hashes.'SHA-256' = 'AEC070645FE53EE3B3763059376134F058CC337247C978ADD178B6CCDFB0019F']"
+		},
+		{
+			"type": "indicator",
+			"revoked": true,
+			"pattern": "[ipv4-addr:value = '198.51.100.2']"
+		},
+		{
+			"type": "indicator",
+			"valid_until": "2016-01-01T00:00:00Z",
+			"pattern": "[ipv4-addr:value = '198.51.100.3']"
+		},
+		{
+			"type": "indicator",
+			"pattern_type": "snort",
+			"pattern": "alert tcp any any -> 198.51.100.4 any"
+		},
+		{
+			"type": "email-addr",
+			"value": "bad@example.com"
+		},
+		{
+			"type": "file",
+			"hashes": {
+				"MD5": "d41d8cd98f00b204e9800998ecf8427e"
+			}
+		}
+	]
+}`
+	assert := require.New(t)
+	idx := threatintel.New()
+	assert.NoError(idx.ReadSTIX("stix", strings.NewReader(input)))
+	assert.Equal(5, idx.Len())
+	assert.NotNil(idx.Lookup(pantherlog.FieldIPAddress, "198.51.100.1"))
+	assert.NotNil(idx.Lookup(pantherlog.FieldDomainName, "evil.example.com"))
+	assert.NotNil(idx.Lookup(pantherlog.FieldSHA256Hash, "aec070645fe53ee3b3763059376134f058cc337247c978add178b6ccdfb0019f"))
+	assert.NotNil(idx.Lookup(pantherlog.FieldEmail, "bad@example.com"))
+	assert.NotNil(idx.Lookup(pantherlog.FieldMD5Hash, "d41d8cd98f00b204e9800998ecf8427e"))
+	assert.Nil(idx.Lookup(pantherlog.FieldIPAddress, "198.51.100.2"))
+	assert.Nil(idx.Lookup(pantherlog.FieldIPAddress, "198.51.100.3"))
+	assert.Nil(idx.Lookup(pantherlog.FieldIPAddress, "198.51.100.4"))
+}
+
+func TestEnrichResult(t *testing.T) {
+	type event struct {
+		SourceIP pantherlog.String `json:"src" panther:"ip"`
+		Host     pantherlog.String `json:"host" panther:"hostname"`
+	}
+	idx := threatintel.New()
+	idx.Add("tor_exits", pantherlog.FieldIPAddress, "198.51.100.1")
+	idx.Add("abuse", pantherlog.FieldIPAddress, "198.51.100.1")
+	idx.Add("abuse", pantherlog.FieldDomainName, "evil.example.com")
+
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	builder := pantherlog.ResultBuilder{
+		NextRowID: pantherlog.StaticRowID("id"),
+		Now:       pantherlog.StaticNow(tm),
+	}
+	result, err := builder.BuildResult("Test", &event{
+		SourceIP: pantherlog.String{Value: "198.51.100.1", Exists: true},
+		Host:     pantherlog.String{Value: "evil.example.com", Exists: true},
+	})
+	require.NoError(t, err)
+	result.Enricher = idx
+	actual, err := pantherlog.ConfigJSON().Marshal(result)
+	require.NoError(t, err)
+	expect := `{
+		"src": "198.51.100.1",
+		"host": "evil.example.com",
+		"p_log_type": "Test",
+		"p_row_id": "id",
+		"p_event_time": "2020-01-01T00:00:00Z",
+		"p_parse_time": "2020-01-01T00:00:00Z",
+		"p_any_ip_addresses": ["198.51.100.1"],
+		"p_any_domain_names": ["evil.example.com"],
+		"p_enrichment": [
+			{"list": "abuse", "field": "p_any_ip_addresses", "indicator": "198.51.100.1"},
+			{"list": "tor_exits", "field": "p_any_ip_addresses", "indicator": "198.51.100.1"},
+			{"list": "abuse", "field": "p_any_domain_names", "indicator": "evil.example.com"}
+		]
+	}`
+	require.JSONEq(t, expect, string(actual))
+}
+
+func TestParseSources(t *testing.T) {
+	assert := require.New(t)
+	sources, err := threatintel.ParseSources("s3://bucket/intel/tor_exits.csv.gz, abuse = /var/intel/bundle.json,")
+	assert.NoError(err)
+	assert.Equal([]threatintel.Source{
+		{Name: "tor_exits", URL: "s3://bucket/intel/tor_exits.csv.gz"},
+		{Name: "abuse", URL: "/var/intel/bundle.json"},
+	}, sources)
+	_, err = threatintel.ParseSources("=s3://bucket/key")
+	assert.Error(err)
+}
+
+func TestLoadS3(t *testing.T) {
+	assert := require.New(t)
+	s3Mock := &testutils.S3Mock{}
+	s3Mock.On("GetObjectWithContext", mock.Anything, &s3.GetObjectInput{
+		Bucket: aws.String("bucket"),
+		Key:    aws.String("intel/list.csv"),
+	}, mock.Anything).Return(&s3.GetObjectOutput{
+		Body: ioutil.NopCloser(strings.NewReader("198.51.100.1\n")),
+	}, nil).Once()
+	loader := threatintel.Loader{
+		S3API:   s3Mock,
+		Sources: []threatintel.Source{{Name: "list", URL: "s3://bucket/intel/list.csv"}},
+		MaxAge:  time.Hour,
+	}
+	idx, err := loader.Index(context.Background())
+	assert.NoError(err)
+	assert.Equal([]string{"list"}, idx.Lookup(pantherlog.FieldIPAddress, "198.51.100.1"))
+	// Second call uses the cached index
+	cached, err := loader.Index(context.Background())
+	assert.NoError(err)
+	assert.Same(idx, cached)
+	s3Mock.AssertExpectations(t)
+}
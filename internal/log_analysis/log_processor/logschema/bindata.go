@@ -95,7 +95,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _schemaJson = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x5a\x6d\x6f\xdb\xb6\x13\x7f\x9f\x4f\x41\xb0\xf9\xbf\x69\xed\x3a\xfd\x67\xdd\xd0\xbc\x19\xda\xac\x45\x0b\xf4\x21\x68\xd6\x16\x6b\xec\x04\x8c\x74\xb2\x99\x51\xa4\x46\x52\x79\x68\xe1\xef\x3e\xe8\xc9\x12\x29\x52\x96\xe2\xb8\xc3\xba\xbe\x48\x62\x93\x77\xc7\xbb\xe3\xef\x8e\xc7\x63\xbe\xee\x20\x84\x77\x55\xb0\x80\x98\xe0\x03\x84\x17\x5a\x27\x07\x93\xc9\x85\x12\x7c\x5c\x8c\x3e\x14\x72\x3e\x09\x25\x89\xf4\x78\xef\x97\x49\x31\x76\x0f\x8f\x32\x3e\x4d\x35\x83\x8c\xeb\x88\x70\xbd\x00\x89\x98\x98\xa3\x52\x56\x4e\xb0\x4b\xc3\x4a\xa8\x3a\x98\x4c\x64\xca\x93\x82\xf2\x21\x15\xa5\x28\x35\x61\x62\xae\x12\x08\x26\x97\x7b\x25\x93\x84\x28\xe3\xba\x37\x09\x21\xa2\x9c\x6a\x2a\xb8\x2a\xa9\x8f\x13\x08\x0a\xaa\xc6\x1c\x3e\x40\x99\x19\x08\xe1\x06\x51\x35\x96\xa9\x79\x93\xe4\x5a\x8a\xf3\x0b\x08\x74\xce\x9e\x8f\x27\x52\x24\x20\x35\x05\xd5\xa0\x46\x08\x5f\x82\x54\x54\x70\x63\x10\x21\x1c\x08\xae\x34\x3e\x40\x7b\xab\xc1\xe5\xa8\x66\x5a\xb9\xd0\xe0\xa9\x96\x56\x5a\x52\x3e\xc7\xa3\xe6\x5c\x4c\xf9\x6b\xe0\x73\xbd\xc0\x07\x68\xdf\x98\x49\x88\xd6\x20\x33\x05\xf0\xe9\xc9\xd3\xf1\xe7\x59\xf6\x8b\x8c\xbf\xec\x8d\x9f\xcc\x1e\xec\x62\xe7\xfa\x21\xa8\x40\xd2\x44\x3b\x14\xb7\x94\x70\xb2\x4b\x88\x40\x02\x0f\xe0\xc3\xfb\xd7\x43\x8c\x88\x84\x8c\x49\xe6\x15\x9c\x4a\xea\x16\x9d\x10\xa9\x40\xfa\x84\x5a\x9b\x52\x78\x86\x5c\x1f\x35\xf7\xe6\x91\xed\xb7\x8e\x59\xcf\xa6\x16\x3b\xa8\x2e\x5b\x83\x08\x61\xc1\xe1\x5d\x86\xb8\x13\x6b\x02\xb5\x48\x73\x72\x37\x3e\x0b\x2b\x0f\x8f\x3f\x7e\xa2\x7a\xf1\x12\x48\x08\x12\xb7\xb8\x97\xa3\x3b\x5b\x42\xa4\xda\xbb\x8a\x35\x32\xdb\xe9\xd0\x01\x47\x44\xe9\x98\xe8\x60\xe1\x72\x4d\x97\x22\x2f\x88\xd2\x6f\x72\xc6\x4e\xf9\x12\xe6\x70\x3d\x54\xf6\xfb\x8c\xa9\x87\x70\x4e\x34\xbd\x84\xa1\xd2\xdf\x16\x5c\x3b\x3e\xa7\x2d\x9d\x38\x8e\x28\xb0\xd0\x46\x95\x67\x9d\x02\xd5\x2f\x0a\x0e\x4f\xbc\xb6\xf3\x57\x39\xd5\x15\x1a\x65\x6a\x38\xea\x40\xb9\x2b\x65\xf4\x76\xd0\x25\x61\x29\xe4\x09\xd4\xef\x1d\x43\x21\x12\x86\x39\x2b\x61\x86\x4e\x11\x61\x0a\x76\x6c\xf6\x15\x2b\x96\xf0\x57\x4a\x25\x64\xc7\xc3\xc9\x2a\xe1\x8e\x56\x4e\x2e\x20\x5b\x92\x63\xc3\x9b\x8e\xc4\x4e\xa4\x24\x37\x75\x5e\x8f\x29\x7f\xa5\x21\x36\x72\x03\xa6\xe5\x48\x23\xcf\xbb\x3d\x90\x6b\xd0\xf4\xc0\xd2\xd0\xa5\x9e\x6e\x28\x42\x18\xb3\x32\x48\xff\x0d\xed\xd8\x49\x4e\x62\x27\xb6\x7d\x09\xbd\xb5\x3b\xa6\xa3\xbd\x72\xce\x85\x60\x40\x78\xb7\xa0\x92\xb8\x27\x8e\x32\xea\xe3\xa0\x05\x23\x4b\xa6\xff\xd0\x5a\x6f\xa7\x17\x91\x06\xb4\x72\x17\x8e\x4a\x51\x33\x57\x24\xf6\x88\x66\x47\x50\x54\xcb\x9b\x40\xad\x09\x1b\xe0\x68\x1f\x2f\x3d\x96\x2c\x4c\xb6\xd6\x1c\xa4\x74\x01\xb6\x4d\x24\xe4\x61\xb5\x89\x00\x15\x10\x46\xe4\x26\x12\x34\x8d\x6d\xc7\x0f\xe2\x97\x10\xf5\xd9\xb7\x15\x5a\x1d\xc9\xc5\xaa\x7a\x30\xf0\x34\x36\x76\xb3\x5d\x17\xb5\x03\xdd\x4a\x51\x08\xe1\xac\xc2\x6e\x7e\xa7\xdc\xa0\x8f\x98\x20\xc6\x80\x8a\x09\x63\x16\xd1\x39\x9d\xdb\x23\x65\x24\x37\x86\x32\x17\x2a\x4d\xe2\x04\x9b\xe5\x1e\x76\x7a\xa2\x81\x9a\x0d\x2a\x68\x47\xae\x58\x95\xcf\x95\x90\x6d\x9d\xb1\xdd\x47\x4d\xae\x99\xef\x9c\xa9\x01\xbf\x2d\xdb\x0b\x18\x38\x4d\x07\x06\x31\x70\xdd\xcf\xf6\x8e\x8c\xb4\xc6\xf0\x6a\x19\xd3\xf2\x46\xa4\xde\xb1\xe9\x5d\x97\x87\x2a\x94\x0a\xf0\xaf\x40\x5f\x03\xbb\x09\xfb\x2a\x64\x6a\x90\xcf\x06\xd9\x6e\x19\x5c\xe7\xd7\x6d\xed\x75\xd7\x75\x8b\xf2\x90\x06\x44\x0b\xe9\x2d\xfe\xec\x84\xe1\x2c\x61\x3a\x10\xb2\x5a\xa1\x79\x6c\x2e\x37\xf0\x58\x2d\xf0\x56\x49\x92\x1a\xf9\x27\x14\x31\xa1\x46\x9a\x5a\x08\xa5\x8b\xc3\xba\x1e\x4b\x25\x6b\x7e\xe5\xa0\xcf\x48\x18\xca\xe6\x58\x1c\x3e\x36\xb2\xe4\x82\x3c\xb2\xbe\xff\xff\xf1\xcf\x46\x22\xbe\x52\x67\x44\xf2\xd6\x50\x10\x88\x94\xeb\x33\x1a\xda\x33\x94\x2b\x4d\x78\x00\x8e\x29\x4d\x8c\xac\xaf\x25\x29\xc8\xdc\x67\x4c\x75\x94\x6d\x0b\x6f\x75\xa2\x77\x43\x4e\x3d\xbf\x04\xae\x7f\xa7\xad\x9a\xd2\x5f\x07\x2e\xad\x73\xe4\x45\x75\xdb\x37\xd8\xdd\xf7\xe7\x75\xd5\x9c\x7d\x15\xae\xbb\x49\xcf\x52\xca\xf4\x98\x72\xb4\xb2\x08\x95\x6d\x86\x16\x8f\x59\x40\xe2\x43\x11\xc7\xa2\xcd\xa7\xda\x8c\xab\xd4\x23\xa3\x60\x7f\x7f\xff\x49\x96\x57\x52\x4e\xaf\xab\xbf\x67\xb1\x5a\x7d\x4c\xeb\x8f\x5c\xe1\xce\xdb\xf4\xed\x8d\x3e\x4c\x95\x16\xf1\x70\x93\x9f\xa2\xa0\xe6\x2c\x99\x10\xe5\x48\x69\x19\xe5\x43\x5c\x68\x92\x13\xb7\x24\x35\xda\x4c\xff\x3b\x21\x4f\xcf\x9f\x05\x87\x61\xf4\xf2\xd5\x45\xfc\x26\x39\xfe\x70\xf5\xe9\xfa\xe6\x8f\x2f\x9f\x67\xfe\xaa\x7b\x58\xfa\x1d\x19\x08\x32\x43\xa3\xaa\xd2\xb6\x15\x19\x8d\x6a\xc7\xc2\x34\x91\x73\x68\xe1\xb9\x6f\xd3\xee\x51\x6f\x07\x14\xcb\x98\xd7\x90\xca\x78\x57\xe3\xa8\x87\x23\x8c\x05\x16\x44\x95\x9c\xb3\xb5\x9e\xaa\x69\x3d\xee\xd2\x32\x05\xa7\xb7\x42\x60\x34\xa6\xda\xdf\xcb\xeb\x3c\xe3\x47\x99\xfd\xd3\xdc\x0b\xa8\x56\xb3\x14\x1c\x91\x94\xe5\x5b\x35\x72\x6f\x54\x20\x58\x1a\xfb\x3b\x25\x8e\xc3\xd2\xd5\x04\x40\x1d\xa7\x68\x67\xa0\x7a\xb6\xdd\xd7\x29\x52\x7f\xd2\xe4\x48\x42\x44\xed\xbe\xd7\x6d\xa0\xd5\x2c\x11\xe3\x44\xdf\x7c\xcc\x4a\xbf\x6f\xe8\x89\xb5\xd6\x6a\x49\xe3\xe3\x84\x04\xb7\x3b\x56\xe0\x3a\x21\x3c\x6c\xf5\x76\x50\xc7\x9d\x10\xae\xf5\x51\x1e\x34\xcf\x9b\xbc\xed\x60\xf4\x87\x59\xdd\x3c\x1d\x1a\x69\x15\x10\xd7\xc7\xd9\x3f\x18\x2d\x6b\x43\xdc\xea\xce\xfd\x08\xb4\x1f\x81\x76\xc7\x81\x56\x3f\x0e\x0c\x8d\xb0\xe2\x2d\x62\x7d\x7c\xb9\xde\x2c\xee\x72\x77\x3c\xdd\xe1\xea\xb5\xe4\xa8\x2c\x9e\xfe\x5b\x18\xdd\x28\x5a\xff\x4d\xf8\x7d\x6b\x3f\x2c\x39\xda\xca\xeb\x31\xea\x68\xe0\xfb\x5f\x63\x3b\xb4\x69\x3c\x87\x6d\x2b\x9c\xca\xcb\xc0\x6f\x99\xff\xb6\xfb\x2a\xb5\x37\x7e\x72\x36\xbb\xef\x7c\x93\x5a\x77\x5b\xf2\xe1\x6d\xf3\x07\xaa\xd1\xb7\x4b\x2c\x43\xd3\xfe\x77\x95\x40\xbe\x93\x24\xe1\xe1\xea\x11\x9c\x1e\x38\xb6\xaf\xb2\x96\xc7\xac\x57\x48\xfb\x18\x1a\xfe\x18\xb9\x0e\x34\x3f\xb9\x1c\xdc\x5b\x52\xe3\x5f\x83\x72\x05\xd1\x15\xd5\x0b\x94\x30\x12\xc0\x42\xb0\xac\x34\x35\xc8\x77\x03\x11\x97\xdd\x6f\xfc\x26\x55\x1a\x05\x82\x6b\x42\x39\x22\x1a\x31\x20\x4a\x23\xc1\xc1\xcf\xde\xec\x64\x4c\xa7\x5f\xa7\x53\x75\xff\xe4\x74\x39\x7b\x90\x7d\x98\x4e\x97\xeb\x5f\x8f\x06\x9b\x22\x52\x8d\x38\x5c\x31\xca\x41\xf9\x4d\x79\xc7\xd9\x0d\x22\x8c\x89\xab\x8a\x38\x33\x48\x2f\x00\x01\x0f\xbd\x26\x9c\x9e\x9c\x4e\xa7\x3c\xd3\x9e\xff\xba\xeb\x7d\xba\xda\xc9\x7e\x96\x3b\x7f\x07\x00\x00\xff\xff\x64\x4b\x14\x3b\xc1\x25\x00\x00")
+var _schemaJson = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x5a\x5b\x73\x13\xb7\x17\x7f\xcf\xa7\xd0\x88\xfc\x5f\xc0\xc1\xe1\x9f\xd2\x0e\x79\xe9\x40\x0a\x03\x33\x5c\x32\xa4\xc0\x94\xd8\x78\x94\xdd\xb3\xb6\xa8\x56\xda\x4a\xda\x24\x86\xf1\x77\xef\xec\x7d\xb5\x2b\xed\x25\x8e\xe9\x94\xf2\x00\xb1\x25\x9d\xa3\x73\x8e\x7e\xe7\x26\xf9\xeb\x1e\x42\x78\x5f\x79\x2b\x08\x09\x3e\x46\x78\xa5\x75\x74\x3c\x9d\x7e\x56\x82\x1f\x64\xa3\xf7\x85\x5c\x4e\x7d\x49\x02\x7d\x70\xf8\xcb\x34\x1b\xbb\x83\x27\x09\x9d\xa6\x9a\x41\x42\x75\x4a\xb8\x5e\x81\x44\x4c\x2c\x51\xce\x2b\x5d\xb0\x4f\xfd\x82\xa9\x3a\x9e\x4e\x65\xcc\xa3\x6c\xe5\x7d\x2a\x72\x56\x6a\xca\xc4\x52\x45\xe0\x4d\x2f\x0f\x73\x22\x09\x41\x42\x75\x67\xea\x43\x40\x39\xd5\x54\x70\x95\xaf\x3e\x8b\xc0\xcb\x56\xd5\xe6\xf0\x31\x4a\xd4\x40\x08\xd7\x16\x15\x63\x89\x98\xeb\x28\x95\x52\x5c\x7c\x06\x4f\xa7\xe4\xe9\x78\x24\x45\x04\x52\x53\x50\xb5\xd5\x08\xe1\x4b\x90\x8a\x0a\x6e\x0c\x22\x84\x3d\xc1\x95\xc6\xc7\xe8\xb0\x1c\xdc\x4c\x2a\xa2\xd2\x84\x06\x4d\xb1\xb5\xd2\x92\xf2\x25\x9e\xd4\xe7\x42\xca\x5f\x02\x5f\xea\x15\x3e\x46\x47\xc6\x4c\x44\xb4\x06\x99\x08\x80\x3f\x9d\x3f\x3e\xf8\x38\x4f\xfe\x23\x07\x5f\x0e\x0f\x1e\xcd\xef\xed\x63\xeb\xfe\x3e\x28\x4f\xd2\x48\x5b\x04\x6f\x08\x61\x25\x97\x10\x80\x04\xee\xc1\xbb\xb7\x2f\xc7\x28\x11\x08\x19\x92\xc4\x2a\x38\x96\xd4\xce\x3a\x22\x52\x81\x74\x31\x6d\x1c\x4a\x66\x19\x72\x7d\x5a\x3f\x9b\x07\x4d\xbb\x75\xcc\x3a\x0e\x35\x3b\x41\x75\xd9\x1a\x44\x08\x0b\x0e\x6f\x12\xc4\x9d\x37\x26\x50\x6b\x69\xba\xdc\x8e\xcf\x4c\xcb\x93\xb3\xf7\x1f\xa8\x5e\x3d\x07\xe2\x83\xc4\x2d\xea\xcd\xe4\xd6\xb6\x10\xb1\x76\xee\xd2\x18\x99\xef\x75\xc8\x80\x03\xa2\x74\x48\xb4\xb7\xb2\x99\xa6\x4b\x90\x67\x44\xe9\x57\x29\x61\x27\x7f\x09\x4b\xb8\x1e\xcb\xfb\x6d\x42\x34\x80\x39\x27\x9a\x5e\xc2\x58\xee\xaf\x33\xaa\x3d\x97\xd1\x36\x56\x1c\x07\x14\x98\xdf\x44\x95\x63\x9f\x0c\xd5\xcf\x32\x0a\x87\xbf\xb6\xe3\x57\x3e\xd5\xe5\x1a\x79\x68\x38\xed\x40\xb9\x2d\x64\x0c\x36\xd0\x25\x61\x31\xa4\x01\xd4\x6d\x1d\x43\x20\xe2\xfb\x29\x29\x61\x86\x4c\x01\x61\x0a\xac\x8a\x53\xee\x53\x8f\x68\x21\x9d\x7a\x13\x29\xc9\xda\x54\x9b\x6a\x08\x2d\xaa\xda\x75\x28\x77\x68\xea\x51\x3b\xd7\xbd\x86\x60\x58\xc2\x5f\x31\x95\x90\xe4\xab\xf3\x32\x03\x4c\xca\x53\xcf\x7c\x28\x5f\x8e\xcd\x1d\xb6\x48\x35\x9c\x84\x4d\xf4\x3a\x94\xf2\x62\xa5\x45\xf8\xa2\xd8\xd8\x0e\xaa\x54\xd8\x31\xe1\xbb\x9e\x69\xa2\x05\xe1\xeb\xc5\x79\x06\x9a\xc5\xee\x12\x4d\x3b\x1c\x0c\x4e\x93\x0f\xac\x2c\x2f\x62\xca\x34\xed\x93\xc6\x60\x0a\x3c\x0e\xb3\x93\x4e\xf0\x9e\x9c\x33\x13\x57\x20\x3d\xa2\xd2\x2f\x71\x14\x55\x5f\x42\xe2\x2d\x88\xef\x4b\x50\x2a\x9d\x8b\xa9\x8f\xe7\x43\x91\x94\x1e\xf0\xbc\x9c\x6b\xa7\x1a\x43\x64\x83\x34\xb3\xd4\xdc\xa6\xb2\x9b\xa8\xb0\x45\x5b\xc0\x4a\x88\x5e\x97\x2d\x60\xde\xc4\x9c\x05\xe8\x0d\xe3\x9a\xa5\x0b\x39\xf8\x32\x2f\xf1\x74\x37\xc7\x53\xc1\xdc\x08\x91\x16\xce\x66\x14\x48\x60\xf0\x22\x0f\x02\x65\xc2\xb7\x84\x05\x87\xf7\xa4\x8e\x51\x0f\x07\x1b\x43\x96\x6a\xba\x26\x08\x61\xac\xeb\xac\x3a\xa3\x74\x47\x78\xb6\xb8\xbc\xcd\xa2\x3d\x39\xb5\x3c\x72\x27\x9f\x0b\x21\x18\x10\xde\xcd\x28\x5f\x3c\x30\x39\x24\xab\xcf\xbc\x56\x6e\x68\xf0\x74\x07\x88\x7e\x3d\x9d\x69\xa6\xed\x54\x93\x9c\x55\xbf\x83\x0c\xce\x74\xa5\xab\x18\xe0\xa8\x16\xd6\xc0\xd1\xe3\xc8\x8e\x1e\x26\x55\xb9\xb1\xe7\x28\xa1\x33\xb0\x6d\xc3\x21\x75\xab\x6d\x18\x28\x8f\x30\x22\xb7\xe1\xa0\x69\xd8\x34\xfc\x28\x7a\x09\xc1\x90\x73\x2b\xd1\x3a\x20\x6c\x15\xf9\xa0\x8a\x23\xad\xac\xd1\x76\xf4\x56\xa1\x82\x93\xb6\x19\x1b\xb5\x8e\xb1\x3e\x60\x82\x18\x03\x2a\x24\x8c\x35\x16\x5d\xd0\x65\x73\x24\xf7\xe4\xda\x50\x62\x42\xa5\x49\x18\x61\xb3\x87\xc3\x56\x4b\xd4\x50\xb3\x45\xad\x62\x89\x15\x65\x4f\x5c\x30\x71\x57\x25\xdb\x15\xce\xdd\x49\x36\x95\xcc\x55\xab\x55\x80\xdf\x95\xee\x19\x0c\xac\xaa\x03\x83\x10\xb8\x1e\xa6\x7b\x47\x44\xea\x51\xbc\xd8\xc6\xd4\xbc\xe6\xa9\xb7\xac\xfa\xa0\xd2\x2a\x45\x71\x09\xfa\x0a\xd8\x75\xd8\x17\x2e\x53\x81\x7c\x70\x65\x55\x0b\xfe\xa5\xc2\x55\x7c\xdd\xd5\x59\x77\x95\xb6\xdf\xb0\xb3\xb9\x51\x57\x63\xb1\x18\xb5\xd5\x76\x84\xaf\xc7\xe7\xb6\xac\x13\xef\xee\x4f\xb6\x6a\x73\xac\x91\xad\xb9\xeb\x8d\x42\x3d\x35\xa2\xa8\x2f\x42\x42\x8d\x60\xbb\x12\x4a\x67\x25\x47\x35\x16\x4b\x56\xff\xca\x41\xa7\x3d\x42\x7d\x2c\xf4\x1f\x1a\xb1\x7e\x45\x1e\x34\xbe\xff\xff\xe1\xcf\x46\x3a\xb9\x52\x0b\x22\x79\x6b\xc8\xf3\x44\xcc\xf5\x82\xfa\xcd\x19\xca\x95\x26\xdc\x03\xcb\x94\x26\x46\xee\xd2\x92\x64\xcb\xec\x99\xb2\x48\xc8\xbb\xf2\x9a\x2a\x5d\xd9\x1d\x47\x3d\xbd\x04\xae\x7f\xa7\xed\x66\xd8\x59\xcd\x6e\x1a\xd9\xf0\x59\x71\x11\x69\x90\xdb\xaf\xf6\xfa\x6a\xd2\xe6\x2d\x5d\x75\xd1\xfd\x24\x69\xaf\x0e\x28\x47\xa5\x46\x28\xbf\x01\x6d\xd1\x98\x65\x30\x3e\x11\x61\x28\xda\x74\xaa\x4d\x58\x06\x50\x19\x78\x47\x47\x47\x8f\xd2\xa6\x93\xd3\xeb\xe2\xef\x22\x54\xe5\xc7\xb8\xfa\xc8\x15\xee\xbc\xe8\xbb\xb9\xd2\x27\xa9\x4f\x8e\x57\xf9\x31\xf2\x2a\xca\x9c\x08\x51\x8e\x94\x96\x41\x3a\xc4\x85\x26\xe9\xe2\x16\xa7\x5a\x1b\xf9\xbf\x73\xf2\xf8\xe2\x89\x77\xe2\x07\xcf\x5f\x7c\x0e\x5f\x45\x67\xef\xae\x3e\x5c\xaf\xff\xf8\xf2\x71\xee\xee\x1d\xc6\x25\x91\x89\x81\x20\xd3\x35\x8a\x5a\x73\x57\x9e\x51\xab\xd9\x1a\x98\x26\x72\x09\x2d\x3c\xdf\xe0\xa2\xa4\xc7\x00\xd9\x36\x66\x33\x55\x28\x6f\xbb\xd3\x1e\x60\x08\x63\x83\x15\x51\x39\xe5\xbc\xd7\x52\xd5\x5a\x87\xb9\xb4\x8c\xed\x97\x8a\x3e\x30\x1a\x52\xed\x7e\x66\xe8\xac\x54\x26\x89\xfe\xb3\xd4\x0a\xa8\x12\x33\x67\x1c\x90\x98\xa5\x47\x35\xb1\x1f\x94\x27\x58\x1c\xba\x2f\x71\x2d\x29\xdf\x76\x95\x81\x3a\x6a\x81\x4e\x47\x75\x1c\xbb\xeb\x12\x5b\xfd\x49\xa3\x53\x09\x01\xbd\xe5\x3b\x38\x08\x23\xbd\x7e\x9f\x14\xb0\xdf\xd0\x12\xbd\xda\x6a\x49\xc3\xb3\x88\x78\x37\x4b\x2b\x70\x1d\x11\xee\xb7\x6e\xa8\x50\x47\x67\x0b\xd7\xfa\x34\x75\x9a\xa7\x75\xda\xb6\x33\xba\xdd\xac\x7a\xd7\x19\xeb\x69\x05\x10\xfb\xfd\xec\x1f\xf4\x96\x5e\x17\x77\x3f\x1c\xfc\x70\xb4\x1f\x8e\x76\x1b\x8e\x56\xbd\x5b\x8e\xf5\xb0\xec\x99\xb4\xdf\xbf\x6c\xcf\xa9\xb7\x79\x3a\x8e\x3b\xee\xe2\x21\xf7\x34\x2f\x9e\xfe\x5b\x18\xdd\xca\x5b\xff\x4d\xf8\x7d\xdd\x7c\xf3\xee\x7c\x71\x1a\xf3\xf2\xe8\x7c\xbf\xeb\x90\xa6\xf6\x52\xbf\x2b\x77\xca\x9b\x81\xdf\x12\xfb\xed\xf6\xc1\xbc\x78\xa8\xea\x7f\xb6\x68\x75\x2c\x2e\xbc\xdd\xea\xdb\xf9\xae\x03\xcb\xd8\xb0\xff\x5d\x05\x90\xef\x24\x48\x38\xa8\x06\x38\xa7\x03\x8e\xed\x56\xb6\x61\xb1\xc6\x5b\x6a\x33\x0d\x8d\x7f\x52\xed\x03\xcd\x4f\x36\x03\x0f\xe6\x54\xfb\xd5\x62\x2a\x20\xba\xa2\x7a\x85\x22\x46\x3c\x58\x09\x96\x94\xa6\xc6\xf2\x7d\x4f\x84\xf9\x1d\x3e\x7e\x15\x2b\x8d\x3c\xc1\x35\xa1\x1c\x11\x8d\x18\x10\xa5\x91\xe0\xe0\x26\xaf\xdf\x64\xcc\x66\x5f\x67\x33\x75\xf7\xfc\xd3\x66\x7e\x2f\xf9\x30\x9b\x6d\xfa\xef\x49\x47\xab\x22\x62\x8d\x38\x5c\x31\xca\x41\xb9\x55\x79\xc3\xd9\x1a\x11\xc6\xc4\x55\xb1\x38\x51\x48\xaf\x00\x01\xf7\xdd\x3f\x12\x39\xff\x34\x9b\xf1\x44\x7a\xfe\xeb\xbe\xf3\x72\x76\x2f\xf9\xb7\xd9\xfb\x3b\x00\x00\xff\xff\xe3\x42\xb5\x51\x5c\x2a\x00\x00")
 
 func schemaJsonBytes() ([]byte, error) {
 	return bindataRead(
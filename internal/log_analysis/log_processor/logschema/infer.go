@@ -21,6 +21,7 @@ package logschema
 import (
 	"encoding/json"
 	"net"
+	"net/mail"
 	"net/url"
 	"reflect"
 	"sort"
@@ -29,7 +30,9 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/itchyny/timefmt-go"
 	"github.com/pkg/errors"
+	"golang.org/x/net/publicsuffix"
 
 	"github.com/panther-labs/panther/pkg/x/structfields"
 )
@@ -116,6 +119,12 @@ func inferString(s string) *ValueSchema {
 			TimeFormat: "rfc3339",
 		}
 	}
+	if timeFormat := inferTimeFormat(s); timeFormat != "" {
+		return &ValueSchema{
+			Type:       TypeTimestamp,
+			TimeFormat: timeFormat,
+		}
+	}
 	return &ValueSchema{
 		Type:       TypeString,
 		Indicators: inferIndicators(s),
@@ -132,9 +141,55 @@ func inferIndicators(s string) []string {
 	if _, err := arn.Parse(s); err == nil {
 		return []string{"aws_arn"}
 	}
+	if addr, err := mail.ParseAddress(s); err == nil && addr.Address == s {
+		return []string{"email"}
+	}
+	if isDomainName(s) {
+		return []string{"domain"}
+	}
 	return nil
 }
 
+// isDomainName checks if s looks like a domain name with a known public suffix (ie `example.com` but not `index.html`)
+func isDomainName(s string) bool {
+	s = strings.TrimSuffix(s, ".")
+	if !strings.Contains(s, ".") || len(s) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(s, ".") {
+		if label == "" || len(label) > 63 || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return false
+		}
+		for _, c := range label {
+			if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '-') {
+				return false
+			}
+		}
+	}
+	suffix, icann := publicsuffix.PublicSuffix(strings.ToLower(s))
+	return icann && suffix != strings.ToLower(s)
+}
+
+// Common timestamp formats in strftime notation that are checked when inferring timestamps.
+// RFC3339 timestamps are checked before these.
+var inferTimeFormats = []string{
+	"%Y-%m-%dT%H:%M:%S",
+	"%Y-%m-%d %H:%M:%S",
+	"%Y-%m-%d %H:%M:%S %z",
+	"%d/%b/%Y:%H:%M:%S %z",
+	"%a, %d %b %Y %H:%M:%S %Z",
+	"%m/%d/%Y %H:%M:%S",
+}
+
+func inferTimeFormat(s string) string {
+	for _, format := range inferTimeFormats {
+		if _, err := timefmt.Parse(s, format); err == nil {
+			return format
+		}
+	}
+	return ""
+}
+
 // NonEmpty scrubs the ValueSchema from any empty object/array schemas.
 func (v *ValueSchema) NonEmpty() *ValueSchema {
 	if v == nil {
@@ -0,0 +1,244 @@
+package logschema
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"sort"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+)
+
+// Inference infers a schema by merging the schemas of sample JSON objects.
+// It also collects statistics about the fields found in the samples.
+type Inference struct {
+	// MaxExamples is the maximum number of distinct example values to keep for each field (defaults to 3).
+	MaxExamples int
+
+	numSamples int
+	root       *ValueSchema
+	fields     map[string]*FieldStats
+}
+
+// FieldStats holds statistics about a field found in sample objects.
+// Nested fields use '.' to separate names and '[]' to denote array elements (ie `foo.bar[].baz`).
+// nolint:lll
+type FieldStats struct {
+	Path     string   `json:"path" description:"The path of the field"`
+	Count    int      `json:"count" description:"The number of samples with a non-null value for the field"`
+	Presence float64  `json:"presence" description:"The ratio of samples with a non-null value for the field"`
+	Examples []string `json:"examples,omitempty" description:"Distinct example values of the field"`
+}
+
+// Common names of event time fields, the event time is more likely to be one of these.
+var eventTimeFieldNames = []string{
+	"time",
+	"timestamp",
+	"@timestamp",
+	"ts",
+	"eventtime",
+	"event_time",
+	"date",
+	"datetime",
+	"created_at",
+	"createdat",
+}
+
+// inferJSON uses json.Number for numbers to distinguish between integers and floats
+var inferJSON = jsoniter.Config{
+	UseNumber: true,
+}.Froze()
+
+// AddJSON adds a sample JSON object
+func (inf *Inference) AddJSON(data []byte) error {
+	var obj map[string]interface{}
+	if err := inferJSON.Unmarshal(data, &obj); err != nil {
+		return errors.Wrap(err, "invalid JSON object")
+	}
+	if obj == nil {
+		return errors.New("invalid JSON object")
+	}
+	inf.AddObject(obj)
+	return nil
+}
+
+// AddObject adds a sample object.
+// Numbers in the object should be `json.Number` to distinguish between integers and floats.
+func (inf *Inference) AddObject(obj map[string]interface{}) {
+	inf.numSamples++
+	inf.root = Merge(inf.root, InferJSONValueSchema(obj))
+	inf.collect("", obj, map[string]bool{})
+}
+
+// NumSamples returns the number of samples added
+func (inf *Inference) NumSamples() int {
+	return inf.numSamples
+}
+
+// Schema returns the inferred schema with fields sorted by name.
+// Empty objects and arrays are removed and the most likely timestamp field is marked as the event time.
+// It returns nil if no fields were found.
+func (inf *Inference) Schema() *Schema {
+	root := inf.root.NonEmpty()
+	if root == nil || len(root.Fields) == 0 {
+		return nil
+	}
+	sortFields(root)
+	markEventTime(root.Fields)
+	return &Schema{
+		Version: 0,
+		Fields:  root.Fields,
+	}
+}
+
+// FieldStats returns the statistics of all fields sorted by path
+func (inf *Inference) FieldStats() []FieldStats {
+	stats := make([]FieldStats, 0, len(inf.fields))
+	for _, s := range inf.fields {
+		s := *s
+		if inf.numSamples > 0 {
+			s.Presence = float64(s.Count) / float64(inf.numSamples)
+		}
+		stats = append(stats, s)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Path < stats[j].Path
+	})
+	return stats
+}
+
+func sortFields(v *ValueSchema) {
+	if v == nil {
+		return
+	}
+	sort.SliceStable(v.Fields, func(i, j int) bool {
+		return v.Fields[i].Name < v.Fields[j].Name
+	})
+	for i := range v.Fields {
+		sortFields(&v.Fields[i].ValueSchema)
+	}
+	sortFields(v.Element)
+}
+
+func (inf *Inference) collect(path string, x interface{}, seen map[string]bool) {
+	switch v := x.(type) {
+	case map[string]interface{}:
+		for key, val := range v {
+			if val == nil {
+				continue
+			}
+			fieldPath := key
+			if path != "" {
+				fieldPath = path + "." + key
+			}
+			stats := inf.fieldStats(fieldPath)
+			if !seen[fieldPath] {
+				seen[fieldPath] = true
+				stats.Count++
+			}
+			inf.collect(fieldPath, val, seen)
+		}
+	case []interface{}:
+		for _, el := range v {
+			if obj, ok := el.(map[string]interface{}); ok {
+				inf.collect(path+"[]", obj, seen)
+				continue
+			}
+			inf.collect(path, el, seen)
+		}
+	case nil:
+	default:
+		inf.addExample(path, v)
+	}
+}
+
+func (inf *Inference) fieldStats(path string) *FieldStats {
+	if inf.fields == nil {
+		inf.fields = map[string]*FieldStats{}
+	}
+	stats, ok := inf.fields[path]
+	if !ok {
+		stats = &FieldStats{
+			Path: path,
+		}
+		inf.fields[path] = stats
+	}
+	return stats
+}
+
+func (inf *Inference) addExample(path string, x interface{}) {
+	const maxExampleLength = 256
+	maxExamples := inf.MaxExamples
+	if maxExamples <= 0 {
+		maxExamples = 3
+	}
+	stats := inf.fieldStats(path)
+	if len(stats.Examples) >= maxExamples {
+		return
+	}
+	example, ok := x.(string)
+	if !ok {
+		data, err := inferJSON.Marshal(x)
+		if err != nil {
+			return
+		}
+		example = string(data)
+	}
+	if len(example) > maxExampleLength {
+		example = example[:maxExampleLength]
+	}
+	for _, duplicate := range stats.Examples {
+		if duplicate == example {
+			return
+		}
+	}
+	stats.Examples = append(stats.Examples, example)
+}
+
+// markEventTime marks the most likely top-level timestamp field as the event time.
+// Required fields and fields with common event time names are preferred.
+func markEventTime(fields []FieldSchema) {
+	best, bestScore := -1, -1
+	for i := range fields {
+		field := &fields[i]
+		if field.Type != TypeTimestamp {
+			continue
+		}
+		if field.IsEventTime {
+			return
+		}
+		score := 0
+		if field.Required {
+			score += 2
+		}
+		for _, name := range eventTimeFieldNames {
+			if strings.EqualFold(field.Name, name) {
+				score++
+				break
+			}
+		}
+		if score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	if best != -1 {
+		fields[best].IsEventTime = true
+	}
+}
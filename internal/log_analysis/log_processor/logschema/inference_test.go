@@ -0,0 +1,107 @@
+package logschema_test
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logschema"
+)
+
+func TestInference(t *testing.T) {
+	assert := require.New(t)
+	inf := logschema.Inference{
+		MaxExamples: 2,
+	}
+	for _, line := range []string{
+		`{"time": "2020-01-02 03:04:05", "created": "2020-01-02T03:04:05Z", "src": "1.1.1.1", "host": "example.com", "user": {"email": "alice@example.com"}, "tags": ["a", "b"]}`,
+		`{"time": "2020-01-02 03:04:06", "src": "2.2.2.2", "host": "www.example.org", "user": {"email": "bob@example.com"}, "tags": ["c"], "count": 42}`,
+		`{"time": "2020-01-02 03:04:07", "src": "3.3.3.3", "host": "example.net", "user": null, "resources": [{"arn": "arn:aws:s3:::bucket"}]}`,
+	} {
+		assert.NoError(inf.AddJSON([]byte(line)))
+	}
+	assert.Error(inf.AddJSON([]byte(`[]`)))
+	assert.Equal(3, inf.NumSamples())
+
+	schema := inf.Schema()
+	assert.NotNil(schema)
+	data, err := yaml.Marshal(schema)
+	assert.NoError(err)
+	assert.YAMLEq(`
+version: 0
+fields:
+- name: count
+  type: bigint
+- name: created
+  type: timestamp
+  timeFormat: rfc3339
+- name: host
+  required: true
+  type: string
+  indicators: [domain]
+- name: resources
+  type: array
+  element:
+    type: object
+    fields:
+    - name: arn
+      required: true
+      type: string
+      indicators: [aws_arn]
+- name: src
+  required: true
+  type: string
+  indicators: [ip]
+- name: tags
+  type: array
+  element:
+    type: string
+- name: time
+  required: true
+  type: timestamp
+  timeFormat: '%Y-%m-%d %H:%M:%S'
+  isEventTime: true
+- name: user
+  type: object
+  fields:
+  - name: email
+    required: true
+    type: string
+    indicators: [email]
+`, string(data))
+
+	stats := map[string]logschema.FieldStats{}
+	for _, s := range inf.FieldStats() {
+		stats[s.Path] = s
+	}
+	assert.Equal(logschema.FieldStats{
+		Path:     "src",
+		Count:    3,
+		Presence: 1,
+		Examples: []string{"1.1.1.1", "2.2.2.2"},
+	}, stats["src"])
+	assert.Equal(2, stats["user.email"].Count)
+	assert.Equal([]string{"a", "b"}, stats["tags"].Examples)
+	assert.Equal([]string{"42"}, stats["count"].Examples)
+	assert.Equal(1, stats["resources[].arn"].Count)
+	assert.InDelta(1.0/3.0, stats["resources"].Presence, 0.001)
+}
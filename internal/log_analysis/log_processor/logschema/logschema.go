@@ -65,9 +65,30 @@ type Schema struct {
 	ReferenceURL string                  `json:"referenceURL,omitempty" yaml:"referenceURL,omitempty"`
 	Version      int                     `json:"version" yaml:"version"`
 	Definitions  map[string]*ValueSchema `json:"definitions,omitempty" yaml:"definitions,omitempty"`
+	Indicators   []IndicatorSchema       `json:"indicators,omitempty" yaml:"indicators,omitempty"`
 	Fields       []FieldSchema           `json:"fields" yaml:"fields"`
 }
 
+// IndicatorSchema defines a custom indicator kind.
+// String fields with the indicator name in their `indicators` collect values in a `p_any_*` field.
+// Values are extracted either with a regular expression or with a builtin extractor.
+type IndicatorSchema struct {
+	Name        string `json:"name" yaml:"name"`
+	Field       string `json:"field,omitempty" yaml:"field,omitempty"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Regex       string `json:"regex,omitempty" yaml:"regex,omitempty"`
+	Builtin     string `json:"builtin,omitempty" yaml:"builtin,omitempty"`
+}
+
+// FieldNameJSON returns the name of the field where indicator values are collected.
+// It defaults to `p_any_<name>s`.
+func (i IndicatorSchema) FieldNameJSON() string {
+	if i.Field != "" {
+		return i.Field
+	}
+	return "p_any_" + i.Name + "s"
+}
+
 func (s *Schema) Clone() *Schema {
 	if s == nil {
 		return nil
@@ -30,7 +30,10 @@ import (
 	"github.com/panther-labs/panther/internal/compliance/snapshotlogs"
 	"github.com/panther-labs/panther/internal/core/logtypesapi"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/enrichment/geoip"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/enrichment/threatintel"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/processor"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/registry"
 	"github.com/panther-labs/panther/pkg/lambdalogger"
@@ -40,10 +43,37 @@ const (
 	// How often we check if we need to scale (controls responsiveness).
 	defaultScalingDecisionInterval = 30 * time.Second
 	logTypeMaxAge                  = time.Minute
+	threatIntelMaxAge              = time.Hour
+	geoIPCheckInterval             = 15 * time.Minute
+)
+
+var (
+	// threatIntel loads the threat intel lists used to enrich events, it is nil if no lists are configured
+	threatIntel *threatintel.Loader
+	// geoIP loads the GeoIP databases used to enrich events, it is nil if no databases are configured
+	geoIP *geoip.Loader
 )
 
 func main() {
 	common.Setup()
+	sources, err := threatintel.ParseSources(common.Config.ThreatIntelLists)
+	if err != nil {
+		panic(err)
+	}
+	if len(sources) > 0 {
+		threatIntel = &threatintel.Loader{
+			S3API:   common.S3Client,
+			Sources: sources,
+			MaxAge:  threatIntelMaxAge,
+		}
+	}
+	if urls := geoip.ParseURLs(common.Config.GeoIPDatabases); len(urls) > 0 {
+		geoIP = &geoip.Loader{
+			S3API:         common.S3Client,
+			URLs:          urls,
+			CheckInterval: geoIPCheckInterval,
+		}
+	}
 	lambda.Start(handle)
 }
 
@@ -82,6 +112,31 @@ func process(ctx context.Context, scalingDecisionInterval time.Duration) (err er
 
 	parsersResolver := logtypes.ParserResolver(logTypesResolver)
 
-	sqsMessageCount, err = processor.PollEvents(ctx, common.SqsClient, parsersResolver)
+	sqsMessageCount, err = processor.PollEvents(ctx, common.SqsClient, parsersResolver, loadEnricher(ctx))
 	return err
 }
+
+// loadEnricher loads the lookup lists and databases used to enrich events.
+// Failing to load enrichment data does not stop log processing, events are processed without it.
+func loadEnricher(ctx context.Context) pantherlog.Enricher {
+	var enrichers []pantherlog.Enricher
+	if threatIntel != nil {
+		index, err := threatIntel.Index(ctx)
+		if err != nil {
+			zap.L().Warn("failed to load threat intel lists", zap.Error(err))
+		}
+		if index != nil {
+			enrichers = append(enrichers, index)
+		}
+	}
+	if geoIP != nil {
+		db, err := geoIP.DB(ctx)
+		if err != nil {
+			zap.L().Warn("failed to load GeoIP databases", zap.Error(err))
+		}
+		if db != nil {
+			enrichers = append(enrichers, db)
+		}
+	}
+	return pantherlog.MultiEnricher(enrichers...)
+}
@@ -0,0 +1,241 @@
+package pantherlog
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net"
+	"regexp"
+	"strings"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+)
+
+// Custom field ids are allocated in the order of registration and are only valid for the lifetime of the process.
+var (
+	nextCustomFieldID FieldID = 1 << 20
+	customFields              = map[FieldID]bool{}
+)
+
+func isCustomField(id FieldID) bool {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	return customFields[id]
+}
+
+// ValueExtractor extracts indicator values from a string.
+// It should return no values if `input` is not valid.
+type ValueExtractor func(input string) []string
+
+// RegisterCustomIndicator defines an indicator field and a scanner for it at runtime.
+// Argument `name` is the name of the scanner to use in `panther` struct tags (ie "device_id").
+// Argument `field` describes the indicator field (ie `p_any_device_ids`).
+// Argument `extract` extracts the indicator values from string fields tagged with the scanner name.
+// It fails if a scanner with the same name is already registered, use UpdateCustomIndicator to change it.
+// It is safe to use concurrently.
+func RegisterCustomIndicator(name string, field FieldMeta, extract ValueExtractor) (FieldID, error) {
+	if extract == nil {
+		return FieldNone, errors.New("nil value extractor")
+	}
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	if err := checkCustomIndicator(name, field); err != nil {
+		return FieldNone, err
+	}
+	if _, duplicate := registeredScanners[name]; duplicate {
+		return FieldNone, errors.Errorf("duplicate scanner %q", name)
+	}
+
+	id := nextCustomFieldID
+	for _, taken := registeredFields[id]; taken; _, taken = registeredFields[id] {
+		id++
+	}
+	if err := registerIndicator(id, field); err != nil {
+		return FieldNone, err
+	}
+	nextCustomFieldID = id + 1
+	customFields[id] = true
+	scanner := &customScanner{
+		id: id,
+	}
+	scanner.extract.Store(extract)
+	registeredScanners[name] = &scannerEntry{
+		Scanner: scanner,
+		Fields:  []FieldID{id},
+	}
+	return id, nil
+}
+
+// UpdateCustomIndicator changes the extractor and the field description of a registered custom indicator.
+// The field itself cannot change since it may already be used by event schemas.
+// It is safe to use concurrently.
+func UpdateCustomIndicator(name string, field FieldMeta, extract ValueExtractor) (FieldID, error) {
+	if extract == nil {
+		return FieldNone, errors.New("nil value extractor")
+	}
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	if err := checkCustomIndicator(name, field); err != nil {
+		return FieldNone, err
+	}
+	entry, ok := registeredScanners[name]
+	if !ok {
+		return FieldNone, errors.Errorf("custom indicator %q is not registered", name)
+	}
+	scanner := entry.Scanner.(*customScanner)
+	registeredFields[scanner.id] = field.StructField()
+	scanner.extract.Store(extract)
+	return scanner.id, nil
+}
+
+// CheckCustomIndicator checks if a custom indicator can be registered or updated without modifying the registry.
+// It fails if `name` is used by a native scanner or by a custom indicator with a different field,
+// or if the field is already used by another indicator.
+func CheckCustomIndicator(name string, field FieldMeta) error {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	return checkCustomIndicator(name, field)
+}
+
+func checkCustomIndicator(name string, field FieldMeta) error {
+	if name == "" {
+		return errors.New("anonymous scanner")
+	}
+	if entry, ok := registeredScanners[name]; ok {
+		scanner, isCustom := entry.Scanner.(*customScanner)
+		if !isCustom {
+			return errors.Errorf("scanner %q is not a custom indicator", name)
+		}
+		if registeredFieldNamesJSON[scanner.id] != field.NameJSON || registeredFields[scanner.id].Name != field.Name {
+			return errors.Errorf("custom indicator %q is already registered with a different field", name)
+		}
+		return nil
+	}
+	if !strings.HasPrefix(field.Name, FieldPrefix) {
+		return errors.Errorf(`invalid field name %q`, field.Name)
+	}
+	if !strings.HasPrefix(field.NameJSON, FieldPrefixJSON) {
+		return errors.Errorf(`invalid field name JSON %q`, field.NameJSON)
+	}
+	if _, duplicate := fieldsByName[field.Name]; duplicate {
+		return errors.Errorf(`duplicate field name %q`, field.Name)
+	}
+	if _, duplicate := fieldsByName[field.NameJSON]; duplicate {
+		return errors.Errorf(`duplicate JSON field name %q`, field.NameJSON)
+	}
+	return nil
+}
+
+// customScanner writes extracted values to a custom indicator field.
+// The extractor is swapped atomically so that updates apply to encoders that are already built.
+type customScanner struct {
+	id      FieldID
+	extract atomic.Value
+}
+
+var _ ValueScanner = (*customScanner)(nil)
+
+// ScanValues implements ValueScanner interface
+func (s *customScanner) ScanValues(w ValueWriter, input string) {
+	extract := s.extract.Load().(ValueExtractor)
+	for _, value := range extract(input) {
+		if value != "" {
+			w.WriteValues(s.id, value)
+		}
+	}
+}
+
+// CustomIndicatorFieldMeta returns the field metadata for a custom indicator field JSON name (ie `p_any_device_ids`).
+// The go field name is derived from the JSON name (ie `PantherAnyDeviceIds`).
+func CustomIndicatorFieldMeta(nameJSON, description string) (FieldMeta, error) {
+	if !strings.HasPrefix(nameJSON, FieldPrefixJSON) {
+		return FieldMeta{}, errors.Errorf(`invalid field name JSON %q`, nameJSON)
+	}
+	name := strings.Builder{}
+	name.WriteString(FieldPrefix)
+	for _, part := range strings.Split(strings.TrimPrefix(nameJSON, FieldPrefixJSON), "_") {
+		if part == "" {
+			continue
+		}
+		name.WriteString(strings.ToUpper(part[:1]))
+		name.WriteString(part[1:])
+	}
+	return FieldMeta{
+		Name:        name.String(),
+		NameJSON:    nameJSON,
+		Description: description,
+	}, nil
+}
+
+// ExtractValue extracts the trimmed input as a value
+func ExtractValue(input string) []string {
+	if input = strings.TrimSpace(input); input != "" {
+		return []string{input}
+	}
+	return nil
+}
+
+// ExtractLowercase extracts the trimmed input in lower case as a value
+func ExtractLowercase(input string) []string {
+	return ExtractValue(strings.ToLower(input))
+}
+
+// ExtractUppercase extracts the trimmed input in upper case as a value
+func ExtractUppercase(input string) []string {
+	return ExtractValue(strings.ToUpper(input))
+}
+
+// ExtractMACAddress extracts a MAC address normalized to lower case colon separated hex digits
+func ExtractMACAddress(input string) []string {
+	addr, err := net.ParseMAC(strings.TrimSpace(input))
+	if err != nil {
+		return nil
+	}
+	return []string{addr.String()}
+}
+
+var rxUUID = regexp.MustCompile(`^[[:xdigit:]]{8}-[[:xdigit:]]{4}-[[:xdigit:]]{4}-[[:xdigit:]]{4}-[[:xdigit:]]{12}$`)
+
+// ExtractUUID extracts a UUID normalized to lower case
+func ExtractUUID(input string) []string {
+	input = strings.TrimSpace(input)
+	input = strings.TrimPrefix(strings.TrimSuffix(input, "}"), "{")
+	if rxUUID.MatchString(input) {
+		return []string{strings.ToLower(input)}
+	}
+	return nil
+}
+
+// RegexpExtractor extracts all matches of a regular expression.
+// If the expression has capture groups, the first non-empty group of each match is extracted instead.
+func RegexpExtractor(re *regexp.Regexp) ValueExtractor {
+	return func(input string) (values []string) {
+		for _, match := range re.FindAllStringSubmatch(input, -1) {
+			value := match[0]
+			for _, group := range match[1:] {
+				if group != "" {
+					value = group
+					break
+				}
+			}
+			values = append(values, value)
+		}
+		return values
+	}
+}
@@ -0,0 +1,108 @@
+package pantherlog_test
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+func TestRegisterCustomIndicator(t *testing.T) {
+	field, err := pantherlog.CustomIndicatorFieldMeta("p_any_test_device_ids", "Test device ids")
+	require.NoError(t, err)
+	require.Equal(t, "PantherAnyTestDeviceIds", field.Name)
+	rx := regexp.MustCompile(`dev-([0-9]+)`)
+	id, err := pantherlog.RegisterCustomIndicator("test_device_id", field, pantherlog.RegexpExtractor(rx))
+	require.NoError(t, err)
+	require.Equal(t, "p_any_test_device_ids", pantherlog.FieldNameJSON(id))
+
+	// Registering again fails
+	_, err = pantherlog.RegisterCustomIndicator("test_device_id", field, pantherlog.ExtractValue)
+	require.Error(t, err)
+	require.NoError(t, pantherlog.CheckCustomIndicator("test_device_id", field))
+	// Updating keeps the field id
+	again, err := pantherlog.UpdateCustomIndicator("test_device_id", field, pantherlog.ExtractValue)
+	require.NoError(t, err)
+	require.Equal(t, id, again)
+	// Updating a different field for the same name fails
+	other, err := pantherlog.CustomIndicatorFieldMeta("p_any_test_other_ids", "Other ids")
+	require.NoError(t, err)
+	require.Error(t, pantherlog.CheckCustomIndicator("test_device_id", other))
+	_, err = pantherlog.UpdateCustomIndicator("test_device_id", other, pantherlog.ExtractValue)
+	require.Error(t, err)
+	// Updating an unregistered indicator fails
+	_, err = pantherlog.UpdateCustomIndicator("test_other_id", other, pantherlog.ExtractValue)
+	require.Error(t, err)
+	// Native scanners cannot be overridden
+	_, err = pantherlog.RegisterCustomIndicator("ip", other, pantherlog.ExtractValue)
+	require.Error(t, err)
+	// Native fields cannot be reused
+	ipField, err := pantherlog.CustomIndicatorFieldMeta("p_any_ip_addresses", "IP addresses")
+	require.NoError(t, err)
+	require.Error(t, pantherlog.CheckCustomIndicator("test_ip", ipField))
+	_, err = pantherlog.RegisterCustomIndicator("test_ip", ipField, pantherlog.ExtractValue)
+	require.Error(t, err)
+
+	_, err = pantherlog.UpdateCustomIndicator("test_device_id", field, pantherlog.RegexpExtractor(rx))
+	require.NoError(t, err)
+
+	type event struct {
+		Device string `json:"device" panther:"test_device_id"`
+		IP     string `json:"ip" panther:"ip"`
+	}
+	typ, err := pantherlog.BuildEventTypeSchema(reflect.TypeOf(event{}))
+	require.NoError(t, err)
+	_, ok := typ.FieldByName("PantherAnyTestDeviceIds")
+	require.True(t, ok)
+
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	result, err := newBuilder("id", now).BuildResult("Test.Event", &event{
+		Device: "dev-42,dev-7",
+		IP:     "1.1.1.1",
+	})
+	require.NoError(t, err)
+	actual, err := buildAPI().Marshal(result)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"device": "dev-42,dev-7",
+		"ip": "1.1.1.1",
+		"p_row_id": "id",
+		"p_log_type": "Test.Event",
+		"p_event_time": "2020-01-01T00:00:00Z",
+		"p_parse_time": "2020-01-01T00:00:00Z",
+		"p_any_ip_addresses": ["1.1.1.1"],
+		"p_any_test_device_ids": ["42", "7"]
+	}`, string(actual))
+}
+
+func TestValueExtractors(t *testing.T) {
+	require.Equal(t, []string{"00:1a:2b:3c:4d:5e"}, pantherlog.ExtractMACAddress("00-1A-2B-3C-4D-5E"))
+	require.Nil(t, pantherlog.ExtractMACAddress("foo"))
+	require.Equal(t, []string{"0f8fad5b-d9cb-469f-a165-70867728950e"}, pantherlog.ExtractUUID("{0F8FAD5B-D9CB-469F-A165-70867728950E}"))
+	require.Nil(t, pantherlog.ExtractUUID("0f8fad5b"))
+	require.Equal(t, []string{"foo"}, pantherlog.ExtractLowercase(" FOO "))
+	require.Equal(t, []string{"FOO"}, pantherlog.ExtractUppercase("foo"))
+	require.Nil(t, pantherlog.ExtractValue(" "))
+}
@@ -0,0 +1,79 @@
+package pantherlog
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Enricher adds data to a result based on the indicator values collected from its event.
+// Enrichers run while a result is being encoded, after all indicator values have been collected and before
+// the Panther fields are written.
+type Enricher interface {
+	EnrichResult(result *Result, values *ValueBuffer)
+}
+
+// EnricherFunc is a function implementing Enricher interface
+type EnricherFunc func(result *Result, values *ValueBuffer)
+
+var _ Enricher = (EnricherFunc)(nil)
+
+// EnrichResult implements Enricher interface
+func (f EnricherFunc) EnrichResult(result *Result, values *ValueBuffer) {
+	f(result, values)
+}
+
+// MultiEnricher enriches a result with multiple enrichers
+func MultiEnricher(enrichers ...Enricher) Enricher {
+	var m multiEnricher
+	for _, e := range enrichers {
+		if e != nil {
+			m = append(m, e)
+		}
+	}
+	switch len(m) {
+	case 0:
+		return nil
+	case 1:
+		return m[0]
+	default:
+		return m
+	}
+}
+
+type multiEnricher []Enricher
+
+func (m multiEnricher) EnrichResult(result *Result, values *ValueBuffer) {
+	for _, e := range m {
+		e.EnrichResult(result, values)
+	}
+}
+
+// EnrichmentMatch is an indicator value of a result that was found in a lookup list.
+type EnrichmentMatch struct {
+	List      string `json:"list" validate:"required" description:"The name of the lookup list that contains the indicator"`
+	Field     string `json:"field" validate:"required" description:"The name of the indicator field that contains the indicator"`
+	Indicator string `json:"indicator" validate:"required" description:"The indicator value that matched"`
+}
+
+// IPGeo is the geolocation and network owner of an IP address indicator of a result.
+type IPGeo struct {
+	IP           string `json:"ip" validate:"required" description:"The IP address"`
+	CountryCode  string `json:"country_code,omitempty" description:"The ISO 3166-1 alpha-2 code of the country of the IP address"`
+	Country      string `json:"country,omitempty" description:"The name of the country of the IP address"`
+	City         string `json:"city,omitempty" description:"The name of the city of the IP address"`
+	ASN          uint32 `json:"asn,omitempty" description:"The autonomous system number of the network of the IP address"`
+	Organization string `json:"organization,omitempty" description:"The organization that owns the autonomous system of the IP address"`
+}
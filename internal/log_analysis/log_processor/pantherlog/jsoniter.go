@@ -94,6 +94,10 @@ func (e *resultEncoder) Encode(ptr unsafe.Pointer, stream *jsoniter.Stream) {
 	stream.WriteVal(result.Event)
 	stream.Attachment = att
 
+	if result.Enricher != nil {
+		result.Enricher.EnrichResult(result, result.values)
+	}
+
 	// Extend the JSON object in the stream buffer with the required Panther fields
 	e.writePantherFields(result, stream)
 
@@ -141,12 +145,24 @@ func (*resultEncoder) writePantherFields(r *Result, stream *jsoniter.Stream) {
 		stream.WriteVal(r.PantherSourceLabel)
 	}
 
+	if len(r.PantherEnrichment) > 0 {
+		stream.WriteMore()
+		stream.WriteObjectField(FieldEnrichmentJSON)
+		stream.WriteVal(r.PantherEnrichment)
+	}
+
+	if len(r.PantherIPGeo) > 0 {
+		stream.WriteMore()
+		stream.WriteObjectField(FieldIPGeoJSON)
+		stream.WriteVal(r.PantherIPGeo)
+	}
+
 	for id, values := range r.values.index {
 		if len(values) == 0 || id.IsCore() {
 			continue
 		}
-		fieldName, ok := registeredFieldNamesJSON[id]
-		if !ok {
+		fieldName := FieldNameJSON(id)
+		if fieldName == "" {
 			continue
 		}
 		sort.Strings(values)
@@ -23,6 +23,7 @@ import (
 	"reflect"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/fatih/structtag"
@@ -44,6 +45,8 @@ const (
 	CoreFieldRowID
 	CoreFieldSourceID
 	CoreFieldSourceLabel
+	CoreFieldEnrichment
+	CoreFieldIPGeo
 )
 
 func coreField(id FieldID) reflect.StructField {
@@ -58,7 +61,8 @@ func (id FieldID) IsCore() bool {
 // Indicator fields (>0)
 // These fields collect string values from the log event.
 // Each log type can choose the indicator fields it requires.
-// Modules can register new indicator fields at init() using RegisterIndicator
+// Modules can register new indicator fields at init() using RegisterIndicator.
+// Custom indicator fields are registered at runtime using RegisterCustomIndicator.
 const (
 	FieldIPAddress FieldID = 1 + iota
 	FieldDomainName
@@ -88,6 +92,10 @@ type CoreFields struct {
 	PantherRowID       string    `json:"p_row_id" validate:"required" description:"Panther added field with unique id (within table)"`
 	PantherSourceID    string    `json:"p_source_id,omitempty" description:"Panther added field with the source id"`
 	PantherSourceLabel string    `json:"p_source_label,omitempty" description:"Panther added field with the source label"`
+	// nolint:lll
+	PantherEnrichment []EnrichmentMatch `json:"p_enrichment,omitempty" description:"Panther added field with the lookup list matches of indicator values in the row"`
+	// nolint:lll
+	PantherIPGeo []IPGeo `json:"p_ip_geo,omitempty" description:"Panther added field with the geolocation and network owner of ip addresses associated with the row"`
 }
 
 const (
@@ -100,9 +108,13 @@ const (
 	FieldParseTimeJSON   = FieldPrefixJSON + "parse_time"
 	FieldSourceIDJSON    = FieldPrefixJSON + "source_id"
 	FieldSourceLabelJSON = FieldPrefixJSON + "source_label"
+	FieldEnrichmentJSON  = FieldPrefixJSON + "enrichment"
+	FieldIPGeoJSON       = FieldPrefixJSON + "ip_geo"
 )
 
 var (
+	// registryLock guards the registries of fields and scanners since custom indicators are registered at runtime
+	registryLock   sync.RWMutex
 	typCoreFields  = reflect.TypeOf(CoreFields{})
 	typStringSlice = reflect.TypeOf([]string(nil))
 	// Registered fields holds the distinct index of field ids to struct fields
@@ -114,6 +126,8 @@ var (
 		CoreFieldLogType:     coreField(CoreFieldLogType),
 		CoreFieldSourceID:    coreField(CoreFieldSourceID),
 		CoreFieldSourceLabel: coreField(CoreFieldSourceLabel),
+		CoreFieldEnrichment:  coreField(CoreFieldEnrichment),
+		CoreFieldIPGeo:       coreField(CoreFieldIPGeo),
 	}
 	// registeredFieldNamesJSON stores the JSON field names of registered field ids.
 	registeredFieldNamesJSON = map[FieldID]string{}
@@ -122,24 +136,32 @@ var (
 		// Reserve field name for embedded event
 		"PantherEvent": FieldNone,
 		// Reserve all field names for core fields
-		FieldEventTimeJSON: FieldNone,
-		"PantherEventTime": FieldNone,
-		FieldParseTimeJSON: FieldNone,
-		"PantherParseTime": FieldNone,
-		FieldLogTypeJSON:   FieldNone,
-		"PantherLogType":   FieldNone,
-		FieldRowIDJSON:     FieldNone,
-		"PantherRowID":     FieldNone,
+		FieldEventTimeJSON:  FieldNone,
+		"PantherEventTime":  FieldNone,
+		FieldParseTimeJSON:  FieldNone,
+		"PantherParseTime":  FieldNone,
+		FieldLogTypeJSON:    FieldNone,
+		"PantherLogType":    FieldNone,
+		FieldRowIDJSON:      FieldNone,
+		"PantherRowID":      FieldNone,
+		FieldEnrichmentJSON: FieldNone,
+		"PantherEnrichment": FieldNone,
+		FieldIPGeoJSON:      FieldNone,
+		"PantherIPGeo":      FieldNone,
 	}
 )
 
 // FieldNameJSON returns the JSON field name of a field id.
 func FieldNameJSON(kind FieldID) string {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
 	return registeredFieldNamesJSON[kind]
 }
 
 // RegisteredFieldNamesJSON returns the JSON field names for registered indicator fields
 func RegisteredFieldNamesJSON() (names []string) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
 	for id, name := range registeredFieldNamesJSON {
 		if id.IsCore() {
 			continue
@@ -233,7 +255,7 @@ func init() {
 
 // MustRegisterIndicator allows modules to define their own indicator fields.
 // It panics if a registration error occurs.
-// WARNING: This function is not concurrent safe and it *must* be used during `init()`
+// It should be used during `init()`, use RegisterCustomIndicator to define indicator fields at runtime.
 func MustRegisterIndicator(id FieldID, field FieldMeta) {
 	if err := RegisterIndicator(id, field); err != nil {
 		panic(err)
@@ -241,12 +263,18 @@ func MustRegisterIndicator(id FieldID, field FieldMeta) {
 }
 
 // RegisterIndicator allows modules to define their own indicator fields.
-// WARNING: This function is not concurrent safe and it *must* be used during `init()`
+// It should be used during `init()`, use RegisterCustomIndicator to define indicator fields at runtime.
 // These fields are always added as `[]string` and values can be collected can by scanners using `RegisterScanner`.
 func RegisterIndicator(id FieldID, field FieldMeta) error {
 	if id <= FieldNone {
 		return errors.New(`invalid field id`)
 	}
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	return registerIndicator(id, field)
+}
+
+func registerIndicator(id FieldID, field FieldMeta) error {
 	if !strings.HasPrefix(field.Name, FieldPrefix) {
 		return errors.Errorf(`invalid field name %q`, field.Name)
 	}
@@ -259,8 +287,8 @@ func RegisterIndicator(id FieldID, field FieldMeta) error {
 	if _, duplicateFieldName := fieldsByName[field.Name]; duplicateFieldName {
 		return errors.Errorf(`duplicate field name %q`, field.Name)
 	}
-	if _, duplicateFieldNameJSON := fieldsByName[field.Name]; duplicateFieldNameJSON {
-		return errors.Errorf(`duplicate JSON field name %q`, field.Name)
+	if _, duplicateFieldNameJSON := fieldsByName[field.NameJSON]; duplicateFieldNameJSON {
+		return errors.Errorf(`duplicate JSON field name %q`, field.NameJSON)
 	}
 	registeredFields[id] = field.StructField()
 	registeredFieldNamesJSON[id] = field.NameJSON
@@ -270,6 +298,22 @@ func RegisterIndicator(id FieldID, field FieldMeta) error {
 	return nil
 }
 
+// lookupField finds the struct field of a registered field id
+func lookupField(id FieldID) (reflect.StructField, bool) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	field, ok := registeredFields[id]
+	return field, ok
+}
+
+// lookupFieldID finds the id of a registered field by either its go or JSON name
+func lookupFieldID(name string) (FieldID, bool) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	id, ok := fieldsByName[name]
+	return id, ok
+}
+
 // DefaultIndicators returns the default panther indicator fields.
 // It creates a new copy so that outside packages cannot affect the defaults.
 func DefaultIndicators() FieldSet {
@@ -351,7 +395,7 @@ func BuildEventTypeSchema(eventType reflect.Type, indicators ...FieldID) (reflec
 			return nil, errors.New(`invalid field id`)
 		}
 
-		field, ok := registeredFields[id]
+		field, ok := lookupField(id)
 		if !ok {
 			continue
 		}
@@ -532,7 +576,7 @@ func FieldSetFromTag(tag string) FieldSet {
 
 	// Lookup field json name to see if it is one of the registered fields
 	if jsonTag, err := tags.Get(`json`); err == nil {
-		if id, ok := fieldsByName[jsonTag.Name]; ok {
+		if id, ok := lookupFieldID(jsonTag.Name); ok {
 			return FieldSet{id}
 		}
 	}
@@ -578,7 +622,7 @@ func FieldSetFromType(typ reflect.Type) (fields FieldSet) {
 }
 
 func appendFieldSet(fields FieldSet, field *reflect.StructField) FieldSet {
-	if id, ok := fieldsByName[field.Name]; ok {
+	if id, ok := lookupFieldID(field.Name); ok {
 		return fields.Add(id)
 	}
 
@@ -595,7 +639,7 @@ func FieldSetFromJSON(input []byte) (fields FieldSet) {
 		return nil
 	}
 	for key := range obj {
-		if id, ok := fieldsByName[key]; ok {
+		if id, ok := lookupFieldID(key); ok {
 			fields = fields.Add(id)
 		}
 	}
@@ -608,8 +652,13 @@ func (fields FieldSet) Len() int {
 }
 
 // Less implements sort.Interface
+// Custom indicator fields are sorted by name since their ids depend on the order they were registered.
 func (fields FieldSet) Less(i, j int) bool {
-	return fields[i] < fields[j]
+	a, b := fields[i], fields[j]
+	if isCustomField(a) && isCustomField(b) {
+		return FieldNameJSON(a) < FieldNameJSON(b)
+	}
+	return a < b
 }
 
 // Swap implements sort.Interface
@@ -40,7 +40,16 @@ func TestMetaEventStruct(t *testing.T) {
 	// nolint:lll
 	expectMappings := map[string]string{
 		"addr":               "addr",
+		"asn":                "asn",
+		"city":               "city",
+		"country":            "country",
+		"country_code":       "country_code",
+		"field":              "field",
 		"foo":                "foo",
+		"indicator":          "indicator",
+		"ip":                 "ip",
+		"list":               "list",
+		"organization":       "organization",
 		"p_any_domain_names": "p_any_domain_names",
 		"p_any_ip_addresses": "p_any_ip_addresses",
 		"p_event_time":       "p_event_time",
@@ -49,6 +58,8 @@ func TestMetaEventStruct(t *testing.T) {
 		"p_row_id":           "p_row_id",
 		"p_source_id":        "p_source_id",
 		"p_source_label":     "p_source_label",
+		"p_enrichment":       "p_enrichment",
+		"p_ip_geo":           "p_ip_geo",
 		"ts":                 "ts",
 	}
 	require.Equal(t, expectMappings, mappings)
@@ -63,6 +74,8 @@ func TestMetaEventStruct(t *testing.T) {
 		{"p_row_id", "string", "Panther added field with unique id (within table)", true},
 		{"p_source_id", "string", "Panther added field with the source id", false},
 		{"p_source_label", "string", "Panther added field with the source label", false},
+		{"p_enrichment", "array<struct<list:string,field:string,indicator:string>>", "Panther added field with the lookup list matches of indicator values in the row", false},
+		{"p_ip_geo", "array<struct<ip:string,country_code:string,country:string,city:string,asn:bigint,organization:string>>", "Panther added field with the geolocation and network owner of ip addresses associated with the row", false},
 		{"p_any_ip_addresses", "array<string>", "Panther added field with collection of ip addresses associated with the row", false},
 		{"p_any_domain_names", "array<string>", "Panther added field with collection of domain names associated with the row", false},
 	}, columns)
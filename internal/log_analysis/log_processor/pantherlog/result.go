@@ -35,6 +35,9 @@ type Result struct {
 	// to avoid duplicate panther fields in resulting JSON.
 	// FIXME: Remove this field once all parsers are ported to the new method.
 	EventIncludesPantherFields bool
+	// Enricher adds enrichment fields to the result once all indicator values have been collected.
+	// It is not used for results with EventIncludesPantherFields set.
+	Enricher Enricher
 	// Collected indicator values for this result.
 	// This field is normally nil throughout the lifetime of results.
 	// It is populated temporarily by the custom jsoniter encoder for *Result to collect all indicator field values.
@@ -306,3 +306,49 @@ func TestSourceFields(t *testing.T) {
 	require.NoError(t, err)
 	require.JSONEq(t, expect, string(actual))
 }
+
+func TestResultEnricher(t *testing.T) {
+	rowID := "id"
+	now := time.Now().UTC()
+	b := newBuilder(rowID, now)
+	event := testEvent{
+		Name:    "event",
+		IP:      "1.1.1.1",
+		Host:    null.FromString("2.1.1.1"),
+		TraceID: null.FromString("foo"),
+	}
+
+	api := buildAPI()
+	result, err := b.BuildResult("TestEvent", &event)
+	require.NoError(t, err)
+	result.Enricher = pantherlog.EnricherFunc(func(r *pantherlog.Result, values *pantherlog.ValueBuffer) {
+		for _, ip := range values.Get(pantherlog.FieldIPAddress) {
+			if ip == "2.1.1.1" {
+				r.PantherEnrichment = append(r.PantherEnrichment, pantherlog.EnrichmentMatch{
+					List:      "bad_ips",
+					Field:     pantherlog.FieldNameJSON(pantherlog.FieldIPAddress),
+					Indicator: ip,
+				})
+			}
+		}
+	})
+	expect := fmt.Sprintf(`{
+		"p_row_id": "id",
+		"p_log_type": "TestEvent",
+		"p_event_time": "%s",
+		"p_parse_time": "%s",
+		"@name": "event",
+		"ip": "1.1.1.1",
+		"hostname": "2.1.1.1",
+		"trace_id": "foo",
+		"p_any_trace_ids": ["foo"],
+		"p_any_ip_addresses": ["1.1.1.1","2.1.1.1"],
+		"p_enrichment": [{"list":"bad_ips","field":"p_any_ip_addresses","indicator":"2.1.1.1"}]
+	}`,
+		now.Format(time.RFC3339Nano),
+		now.Format(time.RFC3339Nano),
+	)
+	actual, err := api.Marshal(result)
+	require.NoError(t, err)
+	require.JSONEq(t, expect, string(actual))
+}
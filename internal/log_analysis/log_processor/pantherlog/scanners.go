@@ -80,6 +80,8 @@ func RegisterScanner(name string, scanner ValueScanner, fields ...FieldID) error
 	if scanner == nil {
 		return errors.New("nil scanner")
 	}
+	registryLock.Lock()
+	defer registryLock.Unlock()
 	if err := checkFields(fields); err != nil {
 		return err
 	}
@@ -110,6 +112,8 @@ func checkFields(fields []FieldID) error {
 
 // LookupScanner finds a registered scanner and field ids by name.
 func LookupScanner(name string) (scanner ValueScanner, fields []FieldID) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
 	if entry, ok := registeredScanners[name]; ok {
 		scanner = entry.Scanner
 		fields = append(fields, entry.Fields...)
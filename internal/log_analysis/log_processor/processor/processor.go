@@ -151,11 +151,15 @@ type Processor struct {
 	input      *common.DataStream
 	classifier classification.ClassifierAPI
 	operation  *oplog.Operation
+	// enricher is optional and is assigned to all results to add enrichment fields
+	enricher pantherlog.Enricher
 }
 
 type Factory func(r *common.DataStream) (*Processor, error)
 
-func NewFactory(resolver pantherlog.ParserResolver) Factory {
+// NewFactory creates a processor factory.
+// The enricher is optional and adds enrichment fields to all results.
+func NewFactory(resolver pantherlog.ParserResolver, enricher pantherlog.Enricher) Factory {
 	return func(input *common.DataStream) (*Processor, error) {
 		switch src := input.Source; src.IntegrationType {
 		case models.IntegrationTypeSqs:
@@ -166,6 +170,7 @@ func NewFactory(resolver pantherlog.ParserResolver) Factory {
 					Resolver:   resolver,
 					LoadSource: sources.LoadSource,
 				},
+				enricher: enricher,
 			}, nil
 		case models.IntegrationTypeAWS3:
 			var availableLogTypes []string
@@ -181,8 +186,9 @@ func NewFactory(resolver pantherlog.ParserResolver) Factory {
 				operation:  common.OpLogManager.Start(operationName),
 				input:      input,
 				classifier: c,
+				enricher:   enricher,
 			}, nil
-		case models.IntegrationTypeAWSScan:
+		case models.IntegrationTypeAWSScan, models.IntegrationTypeGCPScan, models.IntegrationTypeK8sScan:
 			c, err := sources.BuildClassifier(src.RequiredLogTypes(), src, resolver)
 			if err != nil {
 				return nil, err
@@ -191,6 +197,7 @@ func NewFactory(resolver pantherlog.ParserResolver) Factory {
 				operation:  common.OpLogManager.Start(operationName),
 				input:      input,
 				classifier: c,
+				enricher:   enricher,
 			}, nil
 
 		default:
@@ -247,6 +254,9 @@ func (p *Processor) processLogLine(ctx context.Context, line string, outputChan
 		return
 	}
 	for _, event := range result.Events {
+		if p.enricher != nil {
+			event.Enricher = p.enricher
+		}
 		select {
 		case outputChan <- event:
 		case <-ctx.Done():
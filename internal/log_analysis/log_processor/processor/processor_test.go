@@ -93,7 +93,7 @@ func TestProcess(t *testing.T) {
 	destination := (&testDestination{}).standardMock()
 
 	dataStream := makeDataStream()
-	f := NewFactory(testResolver)
+	f := NewFactory(testResolver, nil)
 	p, err := f(dataStream)
 	require.NoError(t, err)
 	mockClassifier := &testClassifier{}
@@ -136,7 +136,7 @@ func TestProcessDataStreamError(t *testing.T) {
 
 	destination := (&testDestination{}).standardMock()
 	dataStream := makeBadDataStream() // failure to read data, never hits classifier
-	f := NewFactory(testResolver)
+	f := NewFactory(testResolver, nil)
 	p, err := f(dataStream)
 	require.NoError(t, err)
 	mockClassifier := &testClassifier{}
@@ -205,7 +205,7 @@ func TestProcessDestinationError(t *testing.T) {
 	})
 
 	dataStream := makeDataStream()
-	f := NewFactory(testResolver)
+	f := NewFactory(testResolver, nil)
 	p, err := f(dataStream)
 	require.NoError(t, err)
 	mockClassifier := &testClassifier{}
@@ -250,7 +250,7 @@ func TestProcessClassifyFailure(t *testing.T) {
 
 	destination := (&testDestination{}).standardMock()
 	dataStream := makeDataStream()
-	f := NewFactory(testResolver)
+	f := NewFactory(testResolver, nil)
 	p, err := f(dataStream)
 	require.NoError(t, err)
 	mockClassifier := &testClassifier{}
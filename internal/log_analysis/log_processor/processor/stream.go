@@ -55,9 +55,10 @@ func PollEvents(
 	ctx context.Context,
 	sqsClient sqsiface.SQSAPI,
 	resolver pantherlog.ParserResolver,
+	enricher pantherlog.Enricher,
 ) (sqsMessageCount int, err error) {
 
-	newProcessor := NewFactory(resolver)
+	newProcessor := NewFactory(resolver, enricher)
 	process := func(streams <-chan *common.DataStream, dest destinations.Destination) error {
 		return Process(ctx, streams, dest, newProcessor)
 	}
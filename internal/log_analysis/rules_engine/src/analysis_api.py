@@ -50,9 +50,13 @@ class AnalysisAPIClient:
         return result
 
     def get_enabled_data_models(self) -> List[Dict[str, Any]]:
-        """Gets information for all enabled data models."""
+        """Gets information for all enabled data models.
+
+        Data models are resolved by the API: there is one entry per log type with the inherited mappings
+        and the bodies of any base data models included.
+        """
         # There should only be one page, but loop over them just in case
-        list_input: Dict[str, Any] = {'listDataModels': {'enabled': True, 'page': 1, 'pageSize': 250}}
+        list_input: Dict[str, Any] = {'listDataModels': {'enabled': True, 'resolved': True, 'page': 1, 'pageSize': 250}}
 
         result = []
         while True:
@@ -39,6 +39,8 @@ import (
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager/s3manageriface"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
 	"github.com/aws/aws-sdk-go/service/sns"
 	"github.com/aws/aws-sdk-go/service/sns/snsiface"
 	"github.com/aws/aws-sdk-go/service/sqs"
@@ -100,6 +102,14 @@ func (m *S3Mock) ListObjectsV2PagesWithContext(ctx aws.Context, input *s3.ListOb
 	return args.Error(1)
 }
 
+func (m *S3Mock) ListObjectVersionsPages(input *s3.ListObjectVersionsInput,
+	f func(page *s3.ListObjectVersionsOutput, morePages bool) bool) error {
+
+	args := m.Called(input, f)
+	f(args.Get(0).(*s3.ListObjectVersionsOutput), false)
+	return args.Error(1)
+}
+
 func (m *S3Mock) SelectObjectContent(input *s3.SelectObjectContentInput) (*s3.SelectObjectContentOutput, error) {
 	args := m.Called(input)
 	return args.Get(0).(*s3.SelectObjectContentOutput), args.Error(1)
@@ -197,11 +207,28 @@ func (m *DynamoDBMock) Query(input *dynamodb.QueryInput) (*dynamodb.QueryOutput,
 	return args.Get(0).(*dynamodb.QueryOutput), args.Error(1)
 }
 
+func (m *DynamoDBMock) QueryPages(input *dynamodb.QueryInput, fn func(*dynamodb.QueryOutput, bool) bool) error {
+	args := m.Called(input, fn)
+	return args.Error(0)
+}
+
 func (m *DynamoDBMock) Scan(input *dynamodb.ScanInput) (*dynamodb.ScanOutput, error) {
 	args := m.Called(input)
 	return args.Get(0).(*dynamodb.ScanOutput), args.Error(1)
 }
 
+func (m *DynamoDBMock) BatchWriteItem(input *dynamodb.BatchWriteItemInput) (*dynamodb.BatchWriteItemOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*dynamodb.BatchWriteItemOutput), args.Error(1)
+}
+
+func (m *DynamoDBMock) BatchGetItemPages(
+	input *dynamodb.BatchGetItemInput, fn func(*dynamodb.BatchGetItemOutput, bool) bool) error {
+
+	args := m.Called(input, fn)
+	return args.Error(0)
+}
+
 type SqsMock struct {
 	sqsiface.SQSAPI
 	mock.Mock
@@ -445,3 +472,28 @@ func (m *FirehoseMock) PutRecordBatchWithContext(
 	args := m.Called(ctx, input, options)
 	return args.Get(0).(*firehose.PutRecordBatchOutput), args.Error(1)
 }
+
+type SecretsManagerMock struct {
+	secretsmanageriface.SecretsManagerAPI
+	mock.Mock
+}
+
+func (m *SecretsManagerMock) CreateSecret(input *secretsmanager.CreateSecretInput) (*secretsmanager.CreateSecretOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*secretsmanager.CreateSecretOutput), args.Error(1)
+}
+
+func (m *SecretsManagerMock) GetSecretValue(input *secretsmanager.GetSecretValueInput) (*secretsmanager.GetSecretValueOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*secretsmanager.GetSecretValueOutput), args.Error(1)
+}
+
+func (m *SecretsManagerMock) PutSecretValue(input *secretsmanager.PutSecretValueInput) (*secretsmanager.PutSecretValueOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*secretsmanager.PutSecretValueOutput), args.Error(1)
+}
+
+func (m *SecretsManagerMock) DeleteSecret(input *secretsmanager.DeleteSecretInput) (*secretsmanager.DeleteSecretOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*secretsmanager.DeleteSecretOutput), args.Error(1)
+}
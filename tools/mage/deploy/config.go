@@ -35,17 +35,20 @@ type PantherConfig struct {
 
 type Infra struct {
 	BaseLayerVersionArns               string   `yaml:"BaseLayerVersionArns"`
+	GeoIPDatabases                     []string `yaml:"GeoIPDatabases"`
 	LoadBalancerSecurityGroupCidr      string   `yaml:"LoadBalancerSecurityGroupCidr"`
 	LogProcessorLambdaMemorySize       int      `yaml:"LogProcessorLambdaMemorySize"`
 	LogProcessorLambdaSQSReadBatchSize string   `yaml:"LogProcessorLambdaSQSReadBatchSize"`
 	PipLayer                           []string `yaml:"PipLayer"`
 	KvTableBillingMode                 string   `yaml:"KvTableBillingMode"`
 	PythonLayerVersionArn              string   `yaml:"PythonLayerVersionArn"`
+	SampleBucketArns                   []string `yaml:"SampleBucketArns"`
 	SecurityGroupID                    string   `yaml:"SecurityGroupID"`
 	SubnetOneID                        string   `yaml:"SubnetOneID"`
 	SubnetTwoID                        string   `yaml:"SubnetTwoID"`
 	SubnetOneIPRange                   string   `yaml:"SubnetOneIPRange"`
 	SubnetTwoIPRange                   string   `yaml:"SubnetTwoIPRange"`
+	ThreatIntelLists                   []string `yaml:"ThreatIntelLists"`
 	VpcID                              string   `yaml:"VpcID"`
 }
 
@@ -67,6 +70,7 @@ type Setup struct {
 	DataReplicationBucket string           `yaml:"DataReplicationBucket"`
 	InitialAnalysisSets   []string         `yaml:"InitialAnalysisSets"`
 	LogSubscriptions      LogSubscriptions `yaml:"LogSubscriptions"`
+	AuditRoleArns         []string         `yaml:"AuditRoleArns"`
 }
 
 type Company struct {
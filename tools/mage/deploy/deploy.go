@@ -27,14 +27,18 @@ import (
 	"strings"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/aws/aws-sdk-go/service/lambda"
 	"github.com/magefile/mage/sh"
 
 	"github.com/panther-labs/panther/api/lambda/users/models"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/enrichment/geoip"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/enrichment/threatintel"
 	"github.com/panther-labs/panther/pkg/awscfn"
 	"github.com/panther-labs/panther/pkg/genericapi"
 	"github.com/panther-labs/panther/pkg/prompt"
 	"github.com/panther-labs/panther/pkg/shutil"
+	"github.com/panther-labs/panther/pkg/stringset"
 	"github.com/panther-labs/panther/tools/cfnstacks"
 	"github.com/panther-labs/panther/tools/mage/build"
 	"github.com/panther-labs/panther/tools/mage/clients"
@@ -492,6 +496,7 @@ func deployAppsyncStack(outputs map[string]string) error {
 func deployCloudSecurityStack(settings *PantherConfig, outputs map[string]string) error {
 	_, err := deployTemplate(cfnstacks.CloudsecTemplate, outputs["SourceBucket"], cfnstacks.Cloudsec, map[string]string{
 		"AlarmTopicArn":              outputs["AlarmTopicArn"],
+		"AuditRoleArns":              strings.Join(settings.Setup.AuditRoleArns, ","),
 		"CloudWatchLogRetentionDays": strconv.Itoa(settings.Monitoring.CloudWatchLogRetentionDays),
 		"CustomResourceVersion":      customResourceVersion(),
 		"Debug":                      strconv.FormatBool(settings.Monitoring.Debug),
@@ -507,10 +512,17 @@ func deployCloudSecurityStack(settings *PantherConfig, outputs map[string]string
 }
 
 func deployCoreStack(settings *PantherConfig, outputs map[string]string) error {
-	_, err := deployTemplate(cfnstacks.CoreTemplate, outputs["SourceBucket"], cfnstacks.Core, map[string]string{
+	enrichmentBuckets, err := enrichmentBucketArns(settings)
+	if err != nil {
+		return err
+	}
+	// Sample logs for custom log schemas can be kept next to the enrichment data
+	sampleBuckets := stringset.Concat(settings.Infra.SampleBucketArns, enrichmentBuckets)
+	_, err = deployTemplate(cfnstacks.CoreTemplate, outputs["SourceBucket"], cfnstacks.Core, map[string]string{
 		"AlarmTopicArn":              outputs["AlarmTopicArn"],
 		"AnalysisVersionsBucket":     outputs["AnalysisVersionsBucket"],
 		"AppDomainURL":               outputs["LoadBalancerUrl"],
+		"AuditRoleArns":              strings.Join(settings.Setup.AuditRoleArns, ","),
 		"CloudWatchLogRetentionDays": strconv.Itoa(settings.Monitoring.CloudWatchLogRetentionDays),
 		"CompanyDisplayName":         settings.Setup.Company.DisplayName,
 		"CompanyEmail":               settings.Setup.Company.Email,
@@ -523,6 +535,7 @@ func deployCoreStack(settings *PantherConfig, outputs map[string]string) error {
 		"OutputsKeyId":               outputs["OutputsEncryptionKeyId"],
 		"PantherVersion":             util.Semver(),
 		"KvTableBillingMode":         settings.Infra.KvTableBillingMode,
+		"SampleBucketArns":           strings.Join(sampleBuckets, ","),
 		"SqsKeyId":                   outputs["QueueEncryptionKeyId"],
 		"TracingMode":                settings.Monitoring.TracingMode,
 		"UserPoolId":                 outputs["UserPoolId"],
@@ -536,13 +549,19 @@ func deployDashboardStack(bucket string) error {
 }
 
 func deployLogAnalysisStack(settings *PantherConfig, outputs map[string]string) error {
-	_, err := deployTemplate(cfnstacks.LogAnalysisTemplate, outputs["SourceBucket"], cfnstacks.LogAnalysis, map[string]string{
+	enrichmentBuckets, err := enrichmentBucketArns(settings)
+	if err != nil {
+		return err
+	}
+	_, err = deployTemplate(cfnstacks.LogAnalysisTemplate, outputs["SourceBucket"], cfnstacks.LogAnalysis, map[string]string{
 		"AlarmTopicArn":                      outputs["AlarmTopicArn"],
 		"AthenaResultsBucket":                outputs["AthenaResultsBucket"],
 		"AthenaWorkGroup":                    outputs["AthenaWorkGroup"],
 		"CloudWatchLogRetentionDays":         strconv.Itoa(settings.Monitoring.CloudWatchLogRetentionDays),
 		"CustomResourceVersion":              customResourceVersion(),
 		"Debug":                              strconv.FormatBool(settings.Monitoring.Debug),
+		"EnrichmentBucketArns":               strings.Join(enrichmentBuckets, ","),
+		"GeoIPDatabases":                     strings.Join(settings.Infra.GeoIPDatabases, ","),
 		"InputDataBucket":                    outputs["InputDataBucket"],
 		"InputDataTopicArn":                  outputs["InputDataTopicArn"],
 		"LayerVersionArns":                   settings.Infra.BaseLayerVersionArns,
@@ -552,11 +571,37 @@ func deployLogAnalysisStack(settings *PantherConfig, outputs map[string]string)
 		"ProcessedDataTopicArn":              outputs["ProcessedDataTopicArn"],
 		"PythonLayerVersionArn":              outputs["PythonLayerVersionArn"],
 		"SqsKeyId":                           outputs["QueueEncryptionKeyId"],
+		"ThreatIntelLists":                   strings.Join(settings.Infra.ThreatIntelLists, ","),
 		"TracingMode":                        settings.Monitoring.TracingMode,
 	})
 	return err
 }
 
+// enrichmentBucketArns returns the ARNs of the S3 buckets holding the threat intel lists and GeoIP databases,
+// which are the only buckets the log processor is allowed to read enrichment data from.
+func enrichmentBucketArns(settings *PantherConfig) ([]string, error) {
+	sources, err := threatintel.ParseSources(strings.Join(settings.Infra.ThreatIntelLists, ","))
+	if err != nil {
+		return nil, err
+	}
+	urls := geoip.ParseURLs(strings.Join(settings.Infra.GeoIPDatabases, ","))
+	for _, source := range sources {
+		urls = append(urls, source.URL)
+	}
+
+	partition, _ := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), clients.Region())
+	var arns []string
+	for _, u := range urls {
+		// Files in a Lambda layer don't need any permissions
+		if !strings.HasPrefix(u, "s3://") {
+			continue
+		}
+		bucket := strings.SplitN(strings.TrimPrefix(u, "s3://"), "/", 2)[0]
+		arns = stringset.Append(arns, fmt.Sprintf("arn:%s:s3:::%s", partition.ID(), bucket))
+	}
+	return arns, nil
+}
+
 func deployOnboardStack(settings *PantherConfig, outputs map[string]string) error {
 	var err error
 	if settings.Setup.OnboardSelf {
@@ -70,7 +70,10 @@ export const DEFAULT_ALERT_CONTEXT_FUNCTION =
 
 export const RESOURCE_TYPES = [
   'AWS.ACM.Certificate',
+  'AWS.ApiGateway.RestApi',
+  'AWS.ApiGatewayV2.Api',
   'AWS.CloudFormation.Stack',
+  'AWS.CloudFront.Distribution',
   'AWS.CloudTrail',
   'AWS.CloudTrail.Meta',
   'AWS.CloudWatch.LogGroup',
@@ -83,9 +86,16 @@ export const RESOURCE_TYPES = [
   'AWS.EC2.SecurityGroup',
   'AWS.EC2.Volume',
   'AWS.EC2.VPC',
+  'AWS.ECR.Repository',
   'AWS.ECS.Cluster',
+  'AWS.ECS.Service',
+  'AWS.ECS.TaskDefinition',
+  'AWS.EFS.FileSystem',
   'AWS.EKS.Cluster',
+  'AWS.EKS.NodeGroup',
+  'AWS.ELB.LoadBalancer',
   'AWS.ELBV2.ApplicationLoadBalancer',
+  'AWS.ElastiCache.ReplicationGroup',
   'AWS.GuardDuty.Detector',
   'AWS.IAM.Group',
   'AWS.IAM.Policy',
@@ -94,12 +104,42 @@ export const RESOURCE_TYPES = [
   'AWS.IAM.User',
   'AWS.KMS.Key',
   'AWS.Lambda.Function',
+  'AWS.OpenSearch.Domain',
+  'AWS.Organizations.Account',
+  'AWS.Organizations.DelegatedAdministrator',
+  'AWS.Organizations.OrganizationalUnit',
+  'AWS.Organizations.ServiceControlPolicy',
+  'AWS.Organizations.TagPolicy',
   'AWS.PasswordPolicy',
+  'AWS.RDS.Cluster',
+  'AWS.RDS.ClusterSnapshot',
   'AWS.RDS.Instance',
+  'AWS.RDS.Snapshot',
   'AWS.Redshift.Cluster',
+  'AWS.Route53.HostedZone',
   'AWS.S3.Bucket',
+  'AWS.SecretsManager.Secret',
+  'AWS.SNS.Topic',
+  'AWS.SQS.Queue',
+  'AWS.SSM.Parameter',
   'AWS.WAF.Regional.WebACL',
   'AWS.WAF.WebACL',
+  'GCP.Compute.Firewall',
+  'GCP.Compute.Instance',
+  'GCP.IAM.ProjectPolicy',
+  'GCP.Project',
+  'GCP.SQL.Instance',
+  'GCP.Storage.Bucket',
+  'K8s.Admission.MutatingWebhookConfiguration',
+  'K8s.Admission.ValidatingWebhookConfiguration',
+  'K8s.Deployment',
+  'K8s.Namespace',
+  'K8s.NetworkPolicy',
+  'K8s.Pod',
+  'K8s.RBAC.ClusterRole',
+  'K8s.RBAC.ClusterRoleBinding',
+  'K8s.RBAC.Role',
+  'K8s.RBAC.RoleBinding',
 ] as const;
 
 const VERSION_PARTS = pantherConfig.PANTHER_VERSION.split('.'); // ["1", "7", "1]